@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/ens"
+	"github.com/Mohsinsiddi/w3cli/internal/resolve"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+)
+
+// newAddressResolver returns the resolver behind every EVM address argument:
+// raw addresses, EVM wallet names, contracts registered on network, contact
+// labels and ENS names.
+func newAddressResolver(network string) *resolve.Resolver {
+	mgr := newWalletManager()
+	contracts := newContractRegistry()
+	_ = contracts.Load()
+	book := newContactBook()
+	_ = book.Load()
+	return &resolve.Resolver{
+		Wallets: func(name string) (string, bool) {
+			w, err := mgr.Get(name)
+			if err != nil || w.Chain() != wallet.ChainEVM {
+				return "", false
+			}
+			return w.Address, true
+		},
+		Contracts: func(name string) (string, bool) {
+			e, err := contracts.Get(name, network)
+			if err != nil {
+				return "", false
+			}
+			return e.Address, true
+		},
+		Contacts: func(name string) (string, bool) {
+			c, err := book.Get(name)
+			if err != nil {
+				return "", false
+			}
+			return c.AddressOn(network), true
+		},
+		ENS: ensResolver(chain.NewRegistry()),
+	}
+}
+
+// resolveAddress resolves an address argument on network and, when it was a
+// name, prints what it resolved to so the user can check it.
+func resolveAddress(input, network string) (string, error) {
+	res, err := newAddressResolver(network).Resolve(input)
+	if err != nil {
+		return "", err
+	}
+	printResolved(res)
+	return res.Address, nil
+}
+
+// printResolved prints "↳ name (kind) → 0x…" for named results.
+func printResolved(res *resolve.Result) {
+	if res.Named() {
+		fmt.Println(ui.Meta("↳ " + res.String()))
+	}
+}
+
+// ensResolver resolves names on Ethereum mainnet, connecting lazily so
+// inputs without names never touch the network.
+func ensResolver(reg *chain.Registry) func(name string) (string, error) {
+	var client *chain.EVMClient
+	return func(name string) (string, error) {
+		if client == nil {
+			eth, err := reg.GetByName("ethereum")
+			if err != nil {
+				return "", err
+			}
+			url, err := pickBestRPC(eth, "mainnet")
+			if err != nil {
+				return "", err
+			}
+			client = chain.NewEVMClient(url)
+		}
+		return ens.Resolve(name, client)
+	}
+}
@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/airdrop"
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	airdropCSV       string
+	airdropToken     string
+	airdropNetwork   string
+	airdropWallet    string
+	airdropMode      string
+	airdropBatchSize int
+	airdropDisperse  string
+	airdropReport    string
+	airdropDryRun    bool
+	airdropYes       bool
+)
+
+var airdropCmd = &cobra.Command{
+	Use:   "airdrop",
+	Short: "Send native coins or ERC-20 tokens to a CSV list of recipients",
+	Long: `Distribute native coins or an ERC-20 token to every row of a CSV file.
+
+The CSV has two columns — address (or ENS name) and amount in token units.
+A header row and lines starting with # are ignored:
+
+  address,amount
+  0x1111111111111111111111111111111111111111,100
+  vitalik.eth,2.5
+
+Every row is validated before anything is sent: addresses must be valid and
+correctly checksummed, ENS names must resolve, amounts must be positive and
+fit the token's decimals, and no recipient may appear twice. The total is
+checked against the wallet balance (and, in disperse mode, the allowance).
+
+Modes:
+  sequential  one transfer per recipient (default)
+  disperse    batches of --batch-size recipients through a Disperse contract
+
+Every transaction is written to <csv>.ledger.json before it is broadcast.
+Re-running the same command resumes: confirmed rows are never paid again and
+in-flight transactions are re-checked on chain first. A CSV report is written
+at the end.
+
+Examples:
+  w3cli airdrop --csv recipients.csv --network base --dry-run
+  w3cli airdrop --csv recipients.csv --token 0xA0b8...eB48 --network base
+  w3cli airdrop --csv recipients.csv --mode disperse --batch-size 200 --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if airdropCSV == "" {
+			return fmt.Errorf("--csv is required")
+		}
+		if airdropMode != airdrop.ModeSequential && airdropMode != airdrop.ModeDisperse {
+			return fmt.Errorf("--mode must be %s or %s", airdrop.ModeSequential, airdrop.ModeDisperse)
+		}
+		if airdropToken != "" {
+			if _, err := parseAddress(airdropToken); err != nil {
+				return fmt.Errorf("invalid --token: %w", err)
+			}
+		}
+
+		chainName := airdropNetwork
+		if chainName == "" {
+			chainName = cfg.DefaultNetwork
+		}
+		walletName := airdropWallet
+		if walletName == "" {
+			walletName = cfg.DefaultWallet
+		}
+
+		w, _, err := loadSigningWallet(walletName)
+		if err != nil {
+			return err
+		}
+
+		reg := chain.NewRegistry()
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+		}
+		if c.Type != chain.ChainTypeEVM {
+			return fmt.Errorf("airdrop supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+		}
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return err
+		}
+		client := chain.NewEVMClient(rpcURL)
+
+		// ── Validate recipients ─────────────────────────────────────────────
+		rows, lines, err := airdrop.ReadCSV(airdropCSV)
+		if err != nil {
+			return err
+		}
+
+		decimals, symbol := 18, c.NativeCurrency
+		if airdropToken != "" {
+			if decimals, err = readTokenDecimals(client, airdropToken); err != nil {
+				return err
+			}
+			symbol = "tokens"
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Validating %d recipients...", len(rows)))
+		spin.Start()
+		recipients, problems := airdrop.Validate(rows, lines, decimals, ensResolver(reg))
+		spin.Stop()
+		if len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Println(ui.Err(p.Error()))
+			}
+			return fmt.Errorf("%d invalid row(s) in %s — nothing was sent", len(problems), airdropCSV)
+		}
+
+		ledger, err := airdrop.LoadLedger(airdrop.LedgerPath(airdropCSV))
+		if err != nil {
+			return err
+		}
+		if err := ledger.Bind(chainName, airdropToken, w.Address, airdropMode); err != nil {
+			return err
+		}
+		if err := ledger.Check(recipients); err != nil {
+			return err
+		}
+
+		chainID, err := client.ChainID()
+		if err != nil {
+			return err
+		}
+
+		disperse := airdropDisperse
+		if disperse == "" {
+			disperse = airdrop.DefaultDisperse
+		}
+		a := &airdrop.Airdropper{
+			Recipients:     recipients,
+			Ledger:         ledger,
+			Client:         client,
+			Signer:         wallet.NewSigner(w, wallet.DefaultKeystore()),
+			ChainID:        chainID,
+			Token:          airdropToken,
+			Mode:           airdropMode,
+			Disperse:       disperse,
+			BatchSize:      airdropBatchSize,
+			Nonces:         newNonceManager().For(client, chainID, w.Address),
+			ConfirmTimeout: config.TxConfirmTimeout,
+		}
+
+		// ── Preflight ───────────────────────────────────────────────────────
+		pending := a.Pending()
+		remaining := airdrop.Total(pending)
+
+		spin = ui.NewSpinner("Checking balance...")
+		spin.Start()
+		preflight, err := airdropPreflight(client, w.Address, disperse, remaining, decimals)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+
+		pairs := [][2]string{
+			{"CSV", airdropCSV},
+			{"Recipients", fmt.Sprintf("%d (%d still to pay)", len(recipients), len(pending))},
+			{"Asset", symbol},
+			{"Total", formatTokenAmount(airdrop.Total(recipients), decimals)},
+			{"Remaining", formatTokenAmount(remaining, decimals)},
+			{"Balance", formatTokenAmount(preflight.balance, decimals)},
+			{"Mode", airdropMode},
+			{"From", ui.Addr(w.Address)},
+			{"Ledger", ledger.Path()},
+		}
+		if airdropToken != "" {
+			pairs = append(pairs, [2]string{"Token", ui.Addr(airdropToken)})
+		}
+		if airdropMode == airdrop.ModeDisperse {
+			pairs = append(pairs, [2]string{"Disperse", ui.Addr(disperse)})
+			if preflight.allowance != nil {
+				pairs = append(pairs, [2]string{"Allowance", formatTokenAmount(preflight.allowance, decimals)})
+			}
+		}
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Airdrop · %s (%s)", c.DisplayName, cfg.NetworkMode), pairs))
+
+		if counts := ledger.Counts(); counts[airdrop.StatusConfirmed]+counts[airdrop.StatusSent] > 0 {
+			fmt.Println(ui.Info(fmt.Sprintf("Resuming — %d confirmed, %d in flight.", counts[airdrop.StatusConfirmed], counts[airdrop.StatusSent])))
+		}
+		if n := ledger.Counts()[airdrop.StatusUnknown]; n > 0 {
+			fmt.Println(ui.Warn(fmt.Sprintf("%d row(s) are in an unknown state and will not be retried — check them in the ledger.", n)))
+		}
+		for _, p := range preflight.problems {
+			fmt.Println(ui.Err(p))
+		}
+
+		if airdropDryRun {
+			if len(preflight.problems) > 0 {
+				return fmt.Errorf("preflight failed")
+			}
+			fmt.Println(ui.Success("All rows valid. Run without --dry-run to send."))
+			return nil
+		}
+		if len(preflight.problems) > 0 {
+			return fmt.Errorf("preflight failed — nothing was sent")
+		}
+		if len(pending) == 0 && ledger.Counts()[airdrop.StatusSent] == 0 {
+			fmt.Println(ui.Success("Every recipient has already been paid."))
+			return writeAirdropReport(ledger)
+		}
+
+		if !airdropYes && !ui.ConfirmDanger(fmt.Sprintf("Send %s %s to %d recipient(s) on %s?",
+			formatTokenAmount(remaining, decimals), symbol, len(pending), c.DisplayName)) {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		// ── Send ────────────────────────────────────────────────────────────
+		a.OnProgress = func(p airdrop.Progress) {
+			who := ui.TruncateAddr(p.Rows[0].Address)
+			if len(p.Rows) > 1 {
+				who = fmt.Sprintf("%d recipients", len(p.Rows))
+			}
+			switch p.Status {
+			case airdrop.StatusConfirmed:
+				fmt.Println(ui.Success(fmt.Sprintf("%s · %s", who, ui.TruncateAddr(p.Hash))))
+			case airdrop.StatusFailed, airdrop.StatusUnknown:
+				fmt.Println(ui.Err(fmt.Sprintf("%s · %s", who, p.Detail)))
+			}
+		}
+		runErr := a.Run()
+
+		fmt.Println()
+		counts := ledger.Counts()
+		t := ui.NewTable([]ui.Column{{Title: "STATUS", Width: 12}, {Title: "ROWS", Width: 8}})
+		for _, s := range []string{airdrop.StatusConfirmed, airdrop.StatusSent, airdrop.StatusFailed, airdrop.StatusUnknown, airdrop.StatusPending} {
+			if counts[s] > 0 {
+				t.AddRow(ui.Row{s, fmt.Sprintf("%d", counts[s])})
+			}
+		}
+		fmt.Println(ui.StyleTitle.Render("  Summary"))
+		fmt.Println(t.Render())
+
+		if err := writeAirdropReport(ledger); err != nil {
+			return err
+		}
+		if runErr != nil {
+			fmt.Println(ui.Hint("Re-run the same command to resume — confirmed rows will not be paid again."))
+			return runErr
+		}
+		if counts[airdrop.StatusFailed] > 0 {
+			return fmt.Errorf("%d row(s) failed — re-run to retry them", counts[airdrop.StatusFailed])
+		}
+		return nil
+	},
+}
+
+type airdropCheck struct {
+	balance   *big.Int
+	allowance *big.Int // disperse token mode only
+	problems  []string
+}
+
+// airdropPreflight checks that the wallet can cover total, and in disperse
+// mode that the contract exists and holds enough allowance.
+func airdropPreflight(client *chain.EVMClient, from, disperse string, total *big.Int, decimals int) (*airdropCheck, error) {
+	check := &airdropCheck{}
+	if airdropToken == "" {
+		bal, err := client.GetBalance(from)
+		if err != nil {
+			return nil, err
+		}
+		check.balance = bal.Wei
+		if bal.Wei.Cmp(total) <= 0 && total.Sign() > 0 {
+			check.problems = append(check.problems, fmt.Sprintf("balance %s does not cover %s plus gas",
+				formatTokenAmount(bal.Wei, decimals), formatTokenAmount(total, decimals)))
+		}
+	} else {
+		bal, err := client.GetTokenBalance(airdropToken, from, decimals)
+		if err != nil {
+			return nil, err
+		}
+		check.balance = bal.Raw
+		if bal.Raw.Cmp(total) < 0 {
+			check.problems = append(check.problems, fmt.Sprintf("token balance %s is less than %s",
+				formatTokenAmount(bal.Raw, decimals), formatTokenAmount(total, decimals)))
+		}
+	}
+
+	if airdropMode != airdrop.ModeDisperse {
+		return check, nil
+	}
+	code, err := client.GetCode(disperse)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" || code == "0x" {
+		check.problems = append(check.problems, fmt.Sprintf("no contract at %s on this chain — pass --disperse or use --mode sequential", disperse))
+		return check, nil
+	}
+	if airdropToken != "" {
+		allowance, err := client.GetAllowance(airdropToken, from, disperse)
+		if err != nil {
+			return nil, err
+		}
+		check.allowance = allowance
+		if allowance.Cmp(total) < 0 {
+			amount := formatTokenAmount(total, decimals)
+			if strings.Contains(amount, ".") {
+				amount = strings.TrimRight(strings.TrimRight(amount, "0"), ".")
+			}
+			check.problems = append(check.problems, fmt.Sprintf("allowance is below %s — run: w3cli approve --token %s --spender %s --amount %s",
+				amount, airdropToken, disperse, amount))
+		}
+	}
+	return check, nil
+}
+
+// readTokenDecimals calls decimals() on token. Guessing would scale every
+// amount in the CSV wrongly, so any failure aborts the airdrop.
+func readTokenDecimals(client *chain.EVMClient, token string) (int, error) {
+	raw, err := client.CallContract(token, "0x313ce567")
+	if err != nil {
+		return 0, fmt.Errorf("reading decimals() of %s: %w", token, err)
+	}
+	d, ok := new(big.Int).SetString(strings.TrimPrefix(raw, "0x"), 16)
+	if len(raw) < 66 || !ok || !d.IsInt64() || d.Int64() > 77 {
+		return 0, fmt.Errorf("%s returned no valid decimals() — is it an ERC-20?", token)
+	}
+	return int(d.Int64()), nil
+}
+
+func writeAirdropReport(ledger *airdrop.Ledger) error {
+	path := airdropReport
+	if path == "" {
+		path = airdropCSV + ".report.csv"
+	}
+	if err := ledger.WriteReport(path); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	fmt.Println(ui.Meta("Report written to " + path))
+	return nil
+}
+
+func init() {
+	airdropCmd.Flags().StringVar(&airdropCSV, "csv", "", "recipients CSV (address,amount) (required)")
+	airdropCmd.Flags().StringVar(&airdropToken, "token", "", "ERC-20 token address (default: native coin)")
+	airdropCmd.Flags().StringVar(&airdropNetwork, "network", "", "chain (default: config)")
+	airdropCmd.Flags().StringVar(&airdropWallet, "wallet", "", "wallet name (default: config)")
+	airdropCmd.Flags().StringVar(&airdropMode, "mode", airdrop.ModeSequential, "sequential|disperse")
+	airdropCmd.Flags().IntVar(&airdropBatchSize, "batch-size", airdrop.DefaultBatchSize, "recipients per disperse transaction")
+	airdropCmd.Flags().StringVar(&airdropDisperse, "disperse", "", "disperse contract address (default: Disperse.app)")
+	airdropCmd.Flags().StringVar(&airdropReport, "report", "", "report CSV path (default: <csv>.report.csv)")
+	airdropCmd.Flags().BoolVar(&airdropDryRun, "dry-run", false, "validate and check balances without sending")
+	airdropCmd.Flags().BoolVarP(&airdropYes, "yes", "y", false, "skip the confirmation prompt")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,7 +14,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var allBalBothFlag bool
+var (
+	allBalBothFlag   bool
+	allBalTokensFlag bool
+)
 
 var allBalCmd = &cobra.Command{
 	Use:   "allbal [address]",
@@ -26,6 +30,10 @@ no waiting for the slowest chain before seeing any results.
 Once every chain has responded the table auto-sorts by USD value (highest first)
 and displays a total at the bottom.
 
+With --tokens the USD column also includes well-known ERC-20s (USDC, USDT,
+WETH, ...), tokens registered with w3cli contract add and the tokens of
+subscribed token lists, on mainnet. Balances are read in batched calls.
+
 Network mode is taken from your config (mainnet by default).
 Override with global flags or --both for a side-by-side view.
 
@@ -37,7 +45,8 @@ Keyboard controls:
 Examples:
   w3cli allbal 0xf39Fd6...            # mainnet, all chains
   w3cli allbal --testnet              # testnet, uses default wallet
-  w3cli allbal --both 0xf39Fd6...    # mainnet + testnet columns side by side`,
+  w3cli allbal --both 0xf39Fd6...    # mainnet + testnet columns side by side
+  w3cli allbal --tokens 0xf39Fd6...  # include token holdings in the USD column`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var address string
@@ -103,17 +112,21 @@ func runAllBal(address, mode string) error {
 	for i, c := range chains {
 		chainNames[i] = c.Name
 	}
-	priceFetcher := price.NewFetcher(cfg.PriceCurrency)
+	priceFetcher := newPriceFetcher()
 	priceSpin := ui.NewSpinner("Fetching USD prices...")
 	priceSpin.Start()
-	priceMap, _ := priceFetcher.GetPrices(chainNames) // ignore error — rows show "—" if unavailable
+	prices := &allBalPrices{}
+	prices.native, _ = priceFetcher.GetPrices(chainNames) // ignore error — rows show "—" if unavailable
+	if allBalTokensFlag && mode != "testnet" {
+		prices.loadTokens(priceFetcher, chainNames)
+	}
 	priceSpin.Stop()
 
 	// FetchFn wraps fetchChainBal as a tea.Cmd for retry support.
 	fetchFn := func(chainName, netMode string) tea.Cmd {
 		c := chainsByName[chainName]
 		return func() tea.Msg {
-			return ui.AllBalResultMsg(fetchChainBal(c, address, netMode, priceMap))
+			return ui.AllBalResultMsg(fetchChainBal(c, address, netMode, prices))
 		}
 	}
 
@@ -132,10 +145,10 @@ func runAllBal(address, mode string) error {
 	for _, c := range chains {
 		c := c
 		if mode == "both" {
-			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, "mainnet", priceMap))) }()
-			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, "testnet", priceMap))) }()
+			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, "mainnet", prices))) }()
+			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, "testnet", prices))) }()
 		} else {
-			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, mode, priceMap))) }()
+			go func() { prog.Send(ui.AllBalResultMsg(fetchChainBal(c, address, mode, prices))) }()
 		}
 	}
 
@@ -143,9 +156,64 @@ func runAllBal(address, mode string) error {
 	return err
 }
 
+// allBalPrices is what fetchChainBal needs to value a chain: native prices
+// and, with --tokens, the tracked tokens per chain. Token prices are looked
+// up per chain for the tokens actually held, so long token lists don't turn
+// into huge price requests.
+type allBalPrices struct {
+	native  map[string]float64
+	tokens  map[string][]trackedToken
+	fetcher *price.Fetcher
+	mu      sync.Mutex // guards fetcher, shared by the per-chain goroutines
+}
+
+// loadTokens collects the tracked tokens of every chain up front so the
+// per-chain goroutines only read balances.
+func (p *allBalPrices) loadTokens(f *price.Fetcher, chainNames []string) {
+	contracts := newContractRegistry()
+	_ = contracts.Load()
+	lists := newTokenListStore()
+	p.fetcher = f
+	p.tokens = map[string][]trackedToken{}
+	for _, name := range chainNames {
+		if tokens := trackedTokens(name, contracts, lists); len(tokens) > 0 {
+			p.tokens[name] = tokens
+		}
+	}
+}
+
+// tokenValue returns the value of address's tracked token holdings on c.
+func (p *allBalPrices) tokenValue(client *chain.EVMClient, c chain.Chain, address string) float64 {
+	tokens := p.tokens[c.Name]
+	if len(tokens) == 0 {
+		return 0
+	}
+	held, _ := fetchTokenHoldings(client, address, tokens)
+	if len(held) == 0 {
+		return 0
+	}
+	addrs := make([]string, len(held))
+	for i, h := range held {
+		addrs[i] = h.Token.Address
+	}
+	p.mu.Lock()
+	quotes, err := p.fetcher.GetTokenPrices(c.Name, addrs)
+	p.mu.Unlock()
+	if err != nil {
+		return 0
+	}
+	var total float64
+	for _, h := range held {
+		if q, ok := quotes[price.TokenKey(h.Token.Address)]; ok {
+			total += parseFloat(h.Amount) * q.Price
+		}
+	}
+	return total
+}
+
 // fetchChainBal fetches the balance for one chain/netMode and returns the result.
 // It is a pure function — safe to call from both goroutines and tea.Cmd.
-func fetchChainBal(c chain.Chain, address, netMode string, priceMap map[string]float64) ui.AllBalResult {
+func fetchChainBal(c chain.Chain, address, netMode string, prices *allBalPrices) ui.AllBalResult {
 	start := time.Now()
 
 	rpcURL, err := pickBestRPC(&c, netMode)
@@ -200,8 +268,13 @@ func fetchChainBal(c chain.Chain, address, netMode string, priceMap map[string]f
 	usdStr := "—"
 	if netMode == "mainnet" {
 		// Testnet tokens have no real USD value — only show price for mainnet.
-		if usdPrice, ok := priceMap[c.Name]; ok && usdPrice > 0 {
-			usdStr = fmt.Sprintf("$%.2f", parseFloat(bal.ETH)*usdPrice)
+		total := 0.0
+		if usdPrice, ok := prices.native[c.Name]; ok && usdPrice > 0 {
+			total = parseFloat(bal.ETH) * usdPrice
+		}
+		total += prices.tokenValue(client, c, address)
+		if total > 0 {
+			usdStr = fmt.Sprintf("$%.2f", total)
 		}
 	}
 
@@ -216,4 +289,5 @@ func fetchChainBal(c chain.Chain, address, netMode string, priceMap map[string]f
 
 func init() {
 	allBalCmd.Flags().BoolVar(&allBalBothFlag, "both", false, "show mainnet and testnet balances side by side")
+	allBalCmd.Flags().BoolVar(&allBalTokensFlag, "tokens", false, "include well-known, registered and token-list ERC-20 holdings in the USD column")
 }
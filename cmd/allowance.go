@@ -35,7 +35,8 @@ var allowanceCmd = &cobra.Command{
 
 Examples:
   w3cli allowance --token 0xUSDC --owner 0xOwner --spender 0xDEX
-  w3cli allowance --token 0xUSDC --owner myWallet --spender 0xRouter --network ethereum`,
+  w3cli allowance --token 0xUSDC --owner myWallet --spender 0xRouter --network ethereum
+  w3cli allowance --token usdc --spender uniswap-router   # registered contract names`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if allowanceToken == "" {
 			return fmt.Errorf("--token is required — provide the ERC-20 contract address")
@@ -56,13 +57,20 @@ Examples:
 				return fmt.Errorf("--owner is required or set a default wallet")
 			}
 			owner = addr
-		} else if !strings.HasPrefix(owner, "0x") {
-			mgr := newWalletManager()
-			w, err := mgr.Get(owner)
+		} else {
+			addr, err := resolveAddress(owner, chainName)
 			if err != nil {
-				return fmt.Errorf("wallet %q not found", owner)
+				return fmt.Errorf("--owner: %w", err)
 			}
-			owner = w.Address
+			owner = addr
+		}
+		token, err := resolveAddress(allowanceToken, chainName)
+		if err != nil {
+			return fmt.Errorf("--token: %w", err)
+		}
+		spender, err := resolveAddress(allowanceSpender, chainName)
+		if err != nil {
+			return fmt.Errorf("--spender: %w", err)
 		}
 
 		reg := chain.NewRegistry()
@@ -81,7 +89,7 @@ Examples:
 		spin := ui.NewSpinner("Querying allowance...")
 		spin.Start()
 
-		allowance, err := client.GetAllowance(allowanceToken, owner, allowanceSpender)
+		allowance, err := client.GetAllowance(token, owner, spender)
 		if err != nil {
 			spin.Stop()
 			return fmt.Errorf("querying allowance: %w", err)
@@ -89,7 +97,7 @@ Examples:
 
 		// Fetch decimals for formatting.
 		decimals := 18
-		if raw, err := client.CallContract(allowanceToken, "0x313ce567"); err == nil && len(raw) >= 66 {
+		if raw, err := client.CallContract(token, "0x313ce567"); err == nil && len(raw) >= 66 {
 			if d, ok := new(big.Int).SetString(strings.TrimPrefix(raw, "0x"), 16); ok {
 				decimals = int(d.Int64())
 			}
@@ -99,9 +107,9 @@ Examples:
 		formatted := formatTokenAmount(allowance, decimals)
 
 		fmt.Println(ui.KeyValueBlock("ERC-20 Allowance", [][2]string{
-			{"Token", ui.Addr(allowanceToken)},
+			{"Token", ui.Addr(token)},
 			{"Owner", ui.Addr(owner)},
-			{"Spender", ui.Addr(allowanceSpender)},
+			{"Spender", ui.Addr(spender)},
 			{"Allowance", ui.Val(formatted)},
 			{"Raw", allowance.String()},
 			{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
@@ -117,7 +125,8 @@ var approveCmd = &cobra.Command{
 
 Examples:
   w3cli approve --token 0xUSDC --spender 0xDEX --amount 1000
-  w3cli approve --token 0xUSDC --spender 0xRouter --amount 1000 --wallet myWallet`,
+  w3cli approve --token 0xUSDC --spender 0xRouter --amount 1000 --wallet myWallet
+  w3cli approve --token usdc --spender uniswap-router --amount 1000`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if approveToken == "" {
 			return fmt.Errorf("--token is required")
@@ -138,6 +147,15 @@ Examples:
 			walletName = cfg.DefaultWallet
 		}
 
+		token, err := resolveAddress(approveToken, chainName)
+		if err != nil {
+			return fmt.Errorf("--token: %w", err)
+		}
+		spender, err := resolveAddress(approveSpender, chainName)
+		if err != nil {
+			return fmt.Errorf("--spender: %w", err)
+		}
+
 		w, _, err := loadSigningWallet(walletName)
 		if err != nil {
 			return err
@@ -163,7 +181,7 @@ Examples:
 
 		// Fetch decimals.
 		decimals := 18
-		if raw, err := client.CallContract(approveToken, "0x313ce567"); err == nil && len(raw) >= 66 {
+		if raw, err := client.CallContract(token, "0x313ce567"); err == nil && len(raw) >= 66 {
 			if d, ok := new(big.Int).SetString(strings.TrimPrefix(raw, "0x"), 16); ok {
 				decimals = int(d.Int64())
 			}
@@ -180,7 +198,7 @@ Examples:
 
 		// Build approve(address,uint256) calldata.
 		// Selector: 0x095ea7b3
-		spenderBytes, _ := hex.DecodeString(strings.TrimPrefix(approveSpender, "0x"))
+		spenderBytes, _ := hex.DecodeString(strings.TrimPrefix(spender, "0x"))
 		addrWord := make([]byte, 32)
 		copy(addrWord[12:], spenderBytes)
 		amtWord := make([]byte, 32)
@@ -205,13 +223,7 @@ Examples:
 			return err
 		}
 
-		nonce, err := client.GetPendingNonce(w.Address)
-		if err != nil {
-			spin.Stop()
-			return err
-		}
-
-		gasLimit, err := client.EstimateGas(w.Address, approveToken, calldataHex, nil)
+		gasLimit, err := client.EstimateGas(w.Address, token, calldataHex, nil)
 		if err != nil {
 			gasLimit = config.GasLimitERC20Transfer
 		}
@@ -223,8 +235,8 @@ Examples:
 			fmt.Sprintf("Approve Preview · %s (%s)", c.DisplayName, cfg.NetworkMode),
 			[][2]string{
 				{"From", ui.Addr(w.Address)},
-				{"Token", ui.Addr(approveToken)},
-				{"Spender", ui.Addr(approveSpender)},
+				{"Token", ui.Addr(token)},
+				{"Spender", ui.Addr(spender)},
 				{"Amount", fmt.Sprintf("%s (decimals: %d)", approveAmount, decimals)},
 				{"Gas Limit", fmt.Sprintf("%d", gasLimit)},
 				{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
@@ -235,13 +247,19 @@ Examples:
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		spin = ui.NewSpinner("Broadcasting approve...")
 		spin.Start()
 
-		tokenAddr := common.HexToAddress(approveToken)
+		tokenAddr := common.HexToAddress(token)
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
@@ -263,6 +281,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		spin = ui.NewSpinner("Waiting for confirmation...")
 		spin.Start()
@@ -296,13 +315,13 @@ func formatTokenAmount(raw *big.Int, decimals int) string {
 }
 
 func init() {
-	allowanceCmd.Flags().StringVar(&allowanceToken, "token", "", "ERC-20 token address (required)")
-	allowanceCmd.Flags().StringVar(&allowanceOwner, "owner", "", "owner address or wallet name")
-	allowanceCmd.Flags().StringVar(&allowanceSpender, "spender", "", "spender address (required)")
+	allowanceCmd.Flags().StringVar(&allowanceToken, "token", "", "ERC-20 token address or contract name (required)")
+	allowanceCmd.Flags().StringVar(&allowanceOwner, "owner", "", "owner address, wallet, contract, contact or ENS name")
+	allowanceCmd.Flags().StringVar(&allowanceSpender, "spender", "", "spender address, wallet, contract, contact or ENS name (required)")
 	allowanceCmd.Flags().StringVar(&allowanceNetwork, "network", "", "chain (default: config)")
 
-	approveCmd.Flags().StringVar(&approveToken, "token", "", "ERC-20 token address (required)")
-	approveCmd.Flags().StringVar(&approveSpender, "spender", "", "spender address (required)")
+	approveCmd.Flags().StringVar(&approveToken, "token", "", "ERC-20 token address or contract name (required)")
+	approveCmd.Flags().StringVar(&approveSpender, "spender", "", "spender address, wallet, contract, contact or ENS name (required)")
 	approveCmd.Flags().StringVar(&approveAmount, "amount", "", "amount to approve (required)")
 	approveCmd.Flags().StringVar(&approveWallet, "wallet", "", "wallet name (default: config)")
 	approveCmd.Flags().StringVar(&approveNetwork, "network", "", "chain (default: config)")
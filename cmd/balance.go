@@ -3,13 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
 	"github.com/Mohsinsiddi/w3cli/internal/config"
 	"github.com/Mohsinsiddi/w3cli/internal/price"
 	"github.com/Mohsinsiddi/w3cli/internal/rpc"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +21,7 @@ var (
 	balanceWallet  string
 	balanceNetwork string
 	balanceToken   string
+	balanceTokens  bool
 	balanceLive    bool
 )
 
@@ -32,7 +37,11 @@ Examples:
   w3cli balance 0xABC...                        # default chain + mode
   w3cli balance --network base --testnet         # Base Sepolia
   w3cli balance --network ethereum --mainnet     # Ethereum mainnet
-  w3cli balance --token 0xUSDC... --live         # ERC-20 live dashboard`,
+  w3cli balance --token 0xUSDC... --live         # ERC-20 live dashboard
+  w3cli balance --token USDC --network base      # token by symbol
+  w3cli balance --tokens                         # native + every tracked/listed token
+  w3cli balance mySolWallet --network solana     # SOL + SPL token accounts
+  w3cli balance mySuiWallet                      # SUI + every coin type`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Allow positional arg as shorthand for --wallet.
@@ -69,23 +78,50 @@ func fetchAndPrintBalance(address, chainName, networkMode string) error {
 		return err
 	}
 
-	var priceFetcher = price.NewFetcher(cfg.PriceCurrency)
+	var priceFetcher = newPriceFetcher()
+	valueLabel := "Value (" + strings.ToUpper(cfg.PriceCurrency) + ")"
 
-	if balanceToken != "" {
+	if balanceToken != "" && c.Type == chain.ChainTypeEVM {
 		client := chain.NewEVMClient(rpcURL)
-		bal, err := client.GetTokenBalance(balanceToken, address, 18)
 		spin.Stop()
+		token, err := resolveTokenArg(balanceToken, c, networkMode, client)
 		if err != nil {
 			return err
 		}
-		fmt.Println(ui.KeyValueBlock(
-			fmt.Sprintf("Token Balance on %s", c.DisplayName),
-			[][2]string{
-				{"Address", ui.Addr(address)},
-				{"Token", ui.Addr(balanceToken)},
-				{"Balance", bal.Formatted},
-			},
-		))
+		balanceToken = token
+		spin = ui.NewSpinner("Fetching token balance...")
+		spin.Start()
+		decimals, err := readTokenDecimals(client, balanceToken)
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		bal, err := client.GetTokenBalance(balanceToken, address, decimals)
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		// Testnet tokens have no real value — only price on mainnet.
+		value, source := "—", ""
+		if networkMode == "mainnet" {
+			if quotes, err := priceFetcher.GetTokenPrices(chainName, []string{balanceToken}); err == nil {
+				if q, ok := quotes[price.TokenKey(balanceToken)]; ok {
+					value = fiat(parseFloat(bal.Formatted) * q.Price)
+					source = q.Source
+				}
+			}
+		}
+		spin.Stop()
+		pairs := [][2]string{
+			{"Address", ui.Addr(address)},
+			{"Token", ui.Addr(balanceToken)},
+			{"Balance", bal.Formatted},
+			{valueLabel, value},
+		}
+		if source != "" {
+			pairs = append(pairs, [2]string{"Price Source", source})
+		}
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Token Balance on %s", c.DisplayName), pairs))
 		return nil
 	}
 
@@ -97,21 +133,21 @@ func fetchAndPrintBalance(address, chainName, networkMode string) error {
 		if err != nil {
 			return err
 		}
-		usdPrice, _ := priceFetcher.GetPrice(chainName)
-		usdValue := fmt.Sprintf("—")
-		if usdPrice > 0 {
-			ethFloat := parseFloat(bal.ETH)
-			usdValue = fmt.Sprintf("$%.2f", ethFloat*usdPrice)
-		}
+		usdValue := nativeValue(priceFetcher, chainName, networkMode, bal.ETH)
 		fmt.Println(ui.KeyValueBlock(
 			fmt.Sprintf("Balance on %s", c.DisplayName),
 			[][2]string{
 				{"Address", ui.Addr(address)},
 				{"Network", c.DisplayName + " (" + networkMode + ")"},
 				{"Balance", bal.ETH + " " + c.NativeCurrency},
-				{"USD Value", usdValue},
+				{valueLabel, usdValue},
 			},
 		))
+		if balanceTokens {
+			if err := printEVMTokens(client, c, networkMode, address, priceFetcher); err != nil {
+				fmt.Println(ui.Warn("Token balances unavailable: " + err.Error()))
+			}
+		}
 
 	case chain.ChainTypeSolana:
 		client := chain.NewSolanaClient(rpcURL)
@@ -126,10 +162,12 @@ func fetchAndPrintBalance(address, chainName, networkMode string) error {
 				{"Address", ui.Addr(address)},
 				{"Network", "Solana (" + networkMode + ")"},
 				{"Balance", bal.ETH + " SOL"},
-				{"USD Value", "—"},
+				{valueLabel, nativeValue(priceFetcher, chainName, networkMode, bal.ETH)},
 			},
 		))
-		fmt.Println(ui.Hint("USD pricing for Solana coming soon."))
+		if err := printSolanaTokens(client, address, balanceToken); err != nil {
+			fmt.Println(ui.Warn("Token accounts unavailable: " + err.Error()))
+		}
 
 	case chain.ChainTypeSUI:
 		client := chain.NewSUIClient(rpcURL)
@@ -144,15 +182,84 @@ func fetchAndPrintBalance(address, chainName, networkMode string) error {
 				{"Address", ui.Addr(address)},
 				{"Network", "SUI (" + networkMode + ")"},
 				{"Balance", bal.ETH + " SUI"},
-				{"USD Value", "—"},
+				{valueLabel, nativeValue(priceFetcher, chainName, networkMode, bal.ETH)},
 			},
 		))
-		fmt.Println(ui.Hint("USD pricing for SUI coming soon."))
+		if err := printSUIBalances(client, address, balanceToken); err != nil {
+			fmt.Println(ui.Warn("Coin balances unavailable: " + err.Error()))
+		}
 	}
 
 	return nil
 }
 
+// printEVMTokens scans every tracked token on c — well-known, registered and
+// listed — with batched balanceOf calls and prints the non-zero holdings.
+func printEVMTokens(client *chain.EVMClient, c *chain.Chain, networkMode, address string, f *price.Fetcher) error {
+	var tokens []trackedToken
+	if networkMode == "mainnet" {
+		contracts := newContractRegistry()
+		_ = contracts.Load()
+		tokens = trackedTokens(c.Name, contracts, newTokenListStore())
+	} else {
+		tokens = listedTokens(newTokenListStore(), tokenChainID(c, networkMode, client))
+	}
+	if len(tokens) == 0 {
+		fmt.Println(ui.Hint("No known tokens on this chain — add a list: w3cli tokens list add <url>"))
+		return nil
+	}
+
+	spin := ui.NewSpinner(fmt.Sprintf("Scanning %d tokens...", len(tokens)))
+	spin.Start()
+	held, err := fetchTokenHoldings(client, address, tokens)
+	var quotes map[string]price.Quote
+	if err == nil && len(held) > 0 && networkMode == "mainnet" {
+		addrs := make([]string, len(held))
+		for i, h := range held {
+			addrs[i] = h.Token.Address
+		}
+		quotes, _ = f.GetTokenPrices(c.Name, addrs)
+	}
+	spin.Stop()
+	if err != nil {
+		return err
+	}
+	if len(held) == 0 {
+		fmt.Println(ui.Meta(fmt.Sprintf("No token balances (%d tokens checked)", len(tokens))))
+		return nil
+	}
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "Token", Width: 10},
+		{Title: "Address", Width: 44},
+		{Title: "Balance", Width: 24},
+		{Title: "Value (" + strings.ToUpper(cfg.PriceCurrency) + ")", Width: 14},
+	})
+	for _, h := range held {
+		value := "—"
+		if q, ok := quotes[price.TokenKey(h.Token.Address)]; ok {
+			value = fiat(parseFloat(h.Amount) * q.Price)
+		}
+		t.AddRow(ui.Row{ui.Val(h.Token.Symbol), ui.Addr(h.Token.Address), h.Amount, value})
+	}
+	fmt.Println(t.Render())
+	fmt.Println(ui.Meta(fmt.Sprintf("%d of %d tokens held", len(held), len(tokens))))
+	return nil
+}
+
+// nativeValue values a native balance on mainnet, or "—" when there is no
+// price (or on testnet, where coins are worthless).
+func nativeValue(f *price.Fetcher, chainName, networkMode, amount string) string {
+	if networkMode != "mainnet" {
+		return "—"
+	}
+	p, err := f.GetPrice(chainName)
+	if err != nil || p <= 0 {
+		return "—"
+	}
+	return fiat(parseFloat(amount) * p)
+}
+
 func runLiveDashboard(address, chainName, networkMode string) error {
 	reg := chain.NewRegistry()
 	c, _ := reg.GetByName(chainName)
@@ -167,7 +274,7 @@ func runLiveDashboard(address, chainName, networkMode string) error {
 		if err != nil {
 			return nil, err
 		}
-		p := price.NewFetcher(cfg.PriceCurrency)
+		p := newPriceFetcher()
 		usdPrice, _ := p.GetPrice(chainName)
 		usdStr := fmt.Sprintf("$%.2f", parseFloat(bal.ETH)*usdPrice)
 
@@ -219,18 +326,25 @@ func resolveWalletAndChain(walletFlag, networkFlag string) (string, string, erro
 		if w == nil {
 			return "", "", fmt.Errorf("no wallet specified — use --wallet <address> or set a default:\n  w3cli wallet add myWallet 0x...\n  w3cli wallet use myWallet")
 		}
-		return w.Address, chainName, nil
+		return w.Address, walletNetwork(w.Chain(), networkFlag, chainName), nil
 	}
 
-	if len(walletFlag) >= 40 && (walletFlag[:2] == "0x" || walletFlag[:2] == "0X") {
-		return walletFlag, chainName, nil
+	if sui.IsAddress(walletFlag) {
+		return walletFlag, walletNetwork(wallet.ChainSUI, networkFlag, chainName), nil
+	}
+	if solana.IsAddress(walletFlag) {
+		return walletFlag, walletNetwork(wallet.ChainSolana, networkFlag, chainName), nil
+	}
+	if w, err := mgr.Get(walletFlag); err == nil {
+		return w.Address, walletNetwork(w.Chain(), networkFlag, chainName), nil
 	}
 
-	w, err := mgr.Get(walletFlag)
+	// Anything else is an EVM address, contract, contact or ENS name.
+	address, err := resolveAddress(walletFlag, chainName)
 	if err != nil {
-		return "", "", fmt.Errorf("wallet %q not found — run `w3cli wallet list` to see available wallets, or pass an address directly", walletFlag)
+		return "", "", fmt.Errorf("%w — run `w3cli wallet list` to see available wallets", err)
 	}
-	return w.Address, chainName, nil
+	return address, chainName, nil
 }
 
 func parseFloat(s string) float64 {
@@ -242,6 +356,7 @@ func parseFloat(s string) float64 {
 func init() {
 	balanceCmd.Flags().StringVar(&balanceWallet, "wallet", "", "wallet name or address")
 	balanceCmd.Flags().StringVar(&balanceNetwork, "network", "", "chain to query (default: config)")
-	balanceCmd.Flags().StringVar(&balanceToken, "token", "", "ERC-20 token contract address")
+	balanceCmd.Flags().StringVar(&balanceToken, "token", "", "ERC-20 token address, contract name or symbol (SPL mint on Solana, coin type on SUI)")
+	balanceCmd.Flags().BoolVar(&balanceTokens, "tokens", false, "also scan every well-known, registered and token-list ERC-20")
 	balanceCmd.Flags().BoolVar(&balanceLive, "live", false, "live refresh mode")
 }
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Mohsinsiddi/w3cli/internal/batch"
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchNetwork string
+	batchWallet  string
+	batchDryRun  bool
+	batchYes     bool
+	batchRestart bool
+	batchForce   bool
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Run multi-step transaction plans",
+}
+
+var batchRunCmd = &cobra.Command{
+	Use:   "run <plan.yaml>",
+	Short: "Execute a plan of sends, transfers, calls and deploys",
+	Long: `Execute an ordered plan of transactions from a YAML (or JSON) file.
+
+Step actions:
+  send       native transfer          to, value
+  transfer   ERC-20 transfer          token, to, amount (token units)
+  call       contract write           contract (registered name or 0x), function, args, value
+  deploy     deploy from artifact     artifact, args, name (registry name)
+
+Values can reference earlier results and variables:
+  ${vars.NAME}  ${steps.ID.address}  ${steps.ID.hash}  ${env.NAME}  ${wallet}
+
+Every run starts with a dry-run of all pending steps via eth_call. Deploy
+addresses are predicted from the wallet nonce, so later steps can use them.
+Nonces are assigned sequentially from the pending nonce. Progress is saved to
+<plan>.state.json after every step — re-running the same plan resumes from
+the first unconfirmed step.
+
+Example plan:
+
+  network: base
+  wallet: deployer
+  vars:
+    treasury: "0xabc..."
+  steps:
+    - id: token
+      action: deploy
+      name: MyToken
+      artifact: ./out/Token.sol/Token.json
+      args: ["My Token", "MTK", "18", "1000000000000000000000000"]
+    - id: seed
+      action: transfer
+      token: ${steps.token.address}
+      to: ${vars.treasury}
+      amount: "1000"
+    - id: fund
+      action: send
+      to: ${vars.treasury}
+      value: "0.01"
+
+Examples:
+  w3cli batch run plan.yaml --dry-run
+  w3cli batch run plan.yaml --testnet
+  w3cli batch run plan.yaml --yes            # no confirmation prompt
+  w3cli batch run plan.yaml --restart        # ignore saved progress`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		planPath := args[0]
+		plan, err := batch.LoadPlan(planPath)
+		if err != nil {
+			return err
+		}
+
+		chainName := batchNetwork
+		if chainName == "" {
+			chainName = plan.Network
+		}
+		if chainName == "" {
+			chainName = cfg.DefaultNetwork
+		}
+		walletName := batchWallet
+		if walletName == "" {
+			walletName = plan.Wallet
+		}
+		if walletName == "" {
+			walletName = cfg.DefaultWallet
+		}
+
+		w, _, err := loadSigningWallet(walletName)
+		if err != nil {
+			return err
+		}
+
+		reg := chain.NewRegistry()
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+		}
+		if c.Type != chain.ChainTypeEVM {
+			return fmt.Errorf("batch supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+		}
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return err
+		}
+		client := chain.NewEVMClient(rpcURL)
+
+		chainID, err := client.ChainID()
+		if err != nil {
+			return err
+		}
+
+		state, err := batch.LoadState(batch.StatePath(planPath))
+		if err != nil {
+			return err
+		}
+		if batchRestart {
+			state.Reset()
+		}
+		if err := state.CheckCompatible(plan.Hash, chainName, w.Address); err != nil {
+			return err
+		}
+
+		contracts := newContractRegistry()
+		if err := contracts.Load(); err != nil {
+			return err
+		}
+
+		runner := &batch.Runner{
+			Plan:           plan,
+			Network:        chainName,
+			ChainID:        chainID,
+			Client:         client,
+			Signer:         wallet.NewSigner(w, wallet.DefaultKeystore()),
+			Contracts:      contracts,
+			State:          state,
+			BaseDir:        filepath.Dir(planPath),
+			Nonces:         newNonceManager().For(client, chainID, w.Address),
+			ConfirmTimeout: config.TxConfirmTimeout,
+			DeployTimeout:  config.TxDeployTimeout,
+		}
+
+		fmt.Println(ui.KeyValueBlock(
+			fmt.Sprintf("Batch · %s (%s)", c.DisplayName, cfg.NetworkMode),
+			[][2]string{
+				{"Plan", planPath},
+				{"Steps", fmt.Sprintf("%d", len(plan.Steps))},
+				{"Wallet", ui.Addr(w.Address)},
+				{"State", state.Path()},
+			}))
+		if state.Started() {
+			fmt.Println(ui.Info("Resuming — confirmed steps will be skipped."))
+		}
+		fmt.Println()
+
+		// ── Dry-run ─────────────────────────────────────────────────────────
+		spin := ui.NewSpinner("Simulating plan...")
+		spin.Start()
+		sim, err := runner.Simulate()
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		fmt.Println(ui.StyleTitle.Render("  Dry-run"))
+		fmt.Println(renderBatchResults(sim))
+
+		failed := 0
+		for _, r := range sim {
+			if r.Status == batch.StatusFailed {
+				failed++
+			}
+		}
+		if batchDryRun {
+			if failed > 0 {
+				return fmt.Errorf("%d step(s) would revert", failed)
+			}
+			fmt.Println(ui.Success("Dry-run passed. Run without --dry-run to execute."))
+			return nil
+		}
+		if failed > 0 && !batchForce {
+			return fmt.Errorf("%d step(s) would revert — fix the plan or pass --force to run anyway", failed)
+		}
+
+		if !batchYes && !ui.Confirm(fmt.Sprintf("Execute %d step(s) on %s?", len(plan.Steps), c.DisplayName)) {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		// ── Execute ─────────────────────────────────────────────────────────
+		runner.OnStep = func(r batch.StepResult) {
+			switch r.Status {
+			case batch.StatusConfirmed:
+				if r.Detail == "already confirmed" {
+					fmt.Println(ui.Meta(fmt.Sprintf("  %s · already confirmed", r.ID)))
+				} else {
+					fmt.Println(ui.Success(fmt.Sprintf("%s · %s %s", r.ID, r.Action, r.Hash)))
+				}
+			case batch.StatusFailed:
+				fmt.Println(ui.Err(fmt.Sprintf("%s · %s", r.ID, r.Detail)))
+			}
+		}
+		results, runErr := runner.Execute()
+
+		fmt.Println()
+		fmt.Println(ui.StyleTitle.Render("  Summary"))
+		fmt.Println(renderBatchResults(results))
+
+		if runErr != nil {
+			fmt.Println(ui.Hint("Fix the problem and re-run the same command to resume from the failed step."))
+			return runErr
+		}
+
+		var gasTotal uint64
+		for _, r := range results {
+			gasTotal += r.GasUsed
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("All %d steps confirmed (gas used: %d)", len(results), gasTotal)))
+		return nil
+	},
+}
+
+var batchStatusCmd = &cobra.Command{
+	Use:   "status <plan.yaml>",
+	Short: "Show saved progress for a plan",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := batch.LoadPlan(args[0])
+		if err != nil {
+			return err
+		}
+		state, err := batch.LoadState(batch.StatePath(args[0]))
+		if err != nil {
+			return err
+		}
+		if !state.Started() {
+			fmt.Println(ui.Info("No progress recorded for this plan."))
+			return nil
+		}
+		if state.PlanHash != plan.Hash {
+			fmt.Println(ui.Warn("Plan file changed since this state was recorded."))
+		}
+
+		var results []batch.StepResult
+		for _, s := range plan.Steps {
+			r := batch.StepResult{ID: s.ID, Action: s.Action, Status: batch.StatusPending}
+			if st, ok := state.Steps[s.ID]; ok {
+				r.Status = st.Status
+				r.Hash = st.Hash
+				r.Nonce = st.Nonce
+				r.Outputs = st.Outputs
+				r.Detail = st.Error
+			}
+			results = append(results, r)
+		}
+		fmt.Println(ui.KeyValueBlock("Batch Status", [][2]string{
+			{"Network", state.Network},
+			{"Wallet", ui.Addr(state.From)},
+			{"State", state.Path()},
+		}))
+		fmt.Println(renderBatchResults(results))
+		return nil
+	},
+}
+
+// renderBatchResults renders step results as a table.
+func renderBatchResults(results []batch.StepResult) string {
+	t := ui.NewTable([]ui.Column{
+		{Title: "STEP", Width: 14},
+		{Title: "ACTION", Width: 9},
+		{Title: "STATUS", Width: 10},
+		{Title: "TARGET", Width: 16},
+		{Title: "DETAIL", Width: 40},
+	})
+	for _, r := range results {
+		target := r.Target
+		if target != "" {
+			target = ui.TruncateAddr(target)
+		} else if r.Action == batch.ActionDeploy {
+			target = "(create)"
+		}
+
+		status := r.Status
+		switch r.Status {
+		case "ok", batch.StatusConfirmed:
+			status = ui.StyleSuccess.Render(r.Status)
+		case batch.StatusFailed:
+			status = ui.StyleError.Render(r.Status)
+		case batch.StatusSkipped, batch.StatusSent:
+			status = ui.StyleWarning.Render(r.Status)
+		}
+
+		detail := r.Detail
+		if detail == "" && r.Hash != "" && r.Hash != "(pending)" {
+			detail = ui.TruncateAddr(r.Hash)
+		}
+		if detail == "" && r.Outputs["address"] != "" {
+			detail = "→ " + r.Outputs["address"]
+		}
+		if len(detail) > 40 {
+			detail = detail[:39] + "…"
+		}
+		t.AddRow(ui.Row{r.ID, r.Action, status, target, detail})
+	}
+	return t.Render()
+}
+
+func init() {
+	batchRunCmd.Flags().StringVar(&batchNetwork, "network", "", "chain (default: plan, then config)")
+	batchRunCmd.Flags().StringVar(&batchWallet, "wallet", "", "signing wallet (default: plan, then config)")
+	batchRunCmd.Flags().BoolVar(&batchDryRun, "dry-run", false, "simulate every step and exit")
+	batchRunCmd.Flags().BoolVarP(&batchYes, "yes", "y", false, "skip the confirmation prompt")
+	batchRunCmd.Flags().BoolVar(&batchRestart, "restart", false, "discard saved progress and start from step 1")
+	batchRunCmd.Flags().BoolVar(&batchForce, "force", false, "execute even if the dry-run reports reverts")
+	batchCmd.AddCommand(batchRunCmd, batchStatusCmd)
+}
@@ -13,7 +13,7 @@ import (
 var callNetwork string
 
 var callCmd = &cobra.Command{
-	Use:   "call <address> <function> [args...]",
+	Use:   "call <address-or-name> <function> [args...]",
 	Short: "Call a read-only contract function",
 	Long: `Call a read-only (view/pure) function on a smart contract.
 
@@ -27,7 +27,8 @@ Examples:
   w3cli call 0xUSDC decimals
   w3cli call 0xUSDC balanceOf 0xYourAddress
   w3cli call 0xUSDC allowance 0xOwner 0xSpender
-  w3cli call 0xUSDC totalSupply --network ethereum`,
+  w3cli call 0xUSDC totalSupply --network ethereum
+  w3cli call usdc totalSupply                    # registered contract name`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contractAddr := args[0]
@@ -38,6 +39,10 @@ Examples:
 		if chainName == "" {
 			chainName = cfg.DefaultNetwork
 		}
+		contractAddr, err := resolveAddress(contractAddr, chainName)
+		if err != nil {
+			return err
+		}
 
 		reg := chain.NewRegistry()
 		c, err := reg.GetByName(chainName)
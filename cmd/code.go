@@ -1,27 +1,41 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var codeNetwork string
+var (
+	codeNetwork string
+	codeDisasm  bool
+)
 
 var codeCmd = &cobra.Command{
-	Use:   "code <address>",
+	Use:   "code <address-or-name>",
 	Short: "Check if an address is a contract (has bytecode) or an EOA",
 	Long: `Query the bytecode at an address to determine if it's a smart contract
 or an externally-owned account (EOA).
 
-Shows the bytecode size and a preview of the first bytes.
+Shows the bytecode size and a preview of the first bytes, plus the compiler
+version and metadata hash (IPFS or Swarm) that solc and vyper append to the
+code. Proxies (EIP-1967, transparent, UUPS, beacon, EIP-1167 clones, Safe)
+are detected and their implementation, admin and beacon addresses shown.
+
+--disasm prints the full opcode listing, naming the function selectors it
+recognises. Use 'code diff' to compare deployed code with another address or
+a build artifact.
 
 Examples:
   w3cli code 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48   # USDC (contract)
-  w3cli code 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045   # vitalik (EOA)`,
+  w3cli code 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045   # vitalik (EOA)
+  w3cli code vitalik.eth                                   # ENS names work too
+  w3cli code 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 --disasm | less`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		address := args[0]
@@ -30,6 +44,10 @@ Examples:
 		if chainName == "" {
 			chainName = cfg.DefaultNetwork
 		}
+		address, err := resolveAddress(address, chainName)
+		if err != nil {
+			return err
+		}
 
 		reg := chain.NewRegistry()
 		c, err := reg.GetByName(chainName)
@@ -71,15 +89,86 @@ Examples:
 				preview = preview[:64] + "..."
 			}
 			pairs = append(pairs, [2]string{"Preview", "0x" + preview})
+
+			raw, err := contract.DecodeCode(code)
+			if err != nil {
+				return err
+			}
+			pairs = append(pairs, metadataPairs(raw)...)
+
+			proxy, err := contract.DetectProxy(client, address)
+			if err != nil {
+				fmt.Println(ui.Warn("Proxy check failed: " + err.Error()))
+			}
+			pairs = append(pairs, proxyPairs(proxy)...)
 		} else {
 			pairs = append(pairs, [2]string{"Type", ui.Val("EOA (no code)")})
 		}
 
 		fmt.Println(ui.KeyValueBlock("Address Type", pairs))
+
+		if codeDisasm && isContract {
+			raw, _ := contract.DecodeCode(code)
+			fmt.Println()
+			for _, line := range disasmLines(raw, newSignatureDB(newContractRegistry())) {
+				fmt.Println("  " + line)
+			}
+		}
 		return nil
 	},
 }
 
 func init() {
-	codeCmd.Flags().StringVar(&codeNetwork, "network", "", "chain (default: config)")
+	codeCmd.PersistentFlags().StringVar(&codeNetwork, "network", "", "chain (default: config)")
+	codeCmd.Flags().BoolVar(&codeDisasm, "disasm", false, "print the opcode disassembly")
+	codeCmd.AddCommand(codeDiffCmd)
+}
+
+// metadataPairs describes the compiler metadata appended to code, if any.
+func metadataPairs(code []byte) [][2]string {
+	runtime, meta := contract.SplitMetadata(code)
+	if meta == nil {
+		return nil
+	}
+	md, err := contract.DecodeMetadata(meta)
+	if err != nil {
+		return [][2]string{{"Metadata", ui.Meta(fmt.Sprintf("%d bytes (undecodable)", len(meta)))}}
+	}
+	var pairs [][2]string
+	if md.Compiler != "" {
+		compiler := md.Compiler
+		if md.Experimental {
+			compiler += " (experimental)"
+		}
+		pairs = append(pairs, [2]string{"Compiler", ui.Val(compiler)})
+	}
+	switch {
+	case md.IPFS != "":
+		pairs = append(pairs, [2]string{"Metadata", "ipfs://" + md.IPFS})
+	case md.Swarm != "":
+		pairs = append(pairs, [2]string{"Metadata", "bzz://" + md.Swarm})
+	}
+	pairs = append(pairs, [2]string{"Runtime Size", fmt.Sprintf("%d bytes + %d metadata", len(runtime), len(meta))})
+	return pairs
+}
+
+// disasmLines renders code as "0x0000  PUSH1 0x80" lines, naming PUSH4
+// selectors found in db and summarising the trailing metadata.
+func disasmLines(code []byte, db *contract.SignatureDB) []string {
+	runtime, meta := contract.SplitMetadata(code)
+	ins := contract.Disassemble(runtime)
+	lines := make([]string, 0, len(ins)+1)
+	for _, in := range ins {
+		line := fmt.Sprintf("0x%04x  %s", in.PC, in)
+		if len(in.Arg) == 4 {
+			if e, ok := db.Lookup("0x" + hex.EncodeToString(in.Arg)); ok {
+				line += "  " + ui.Meta("// "+contract.RecoveredFunction{Entry: &e}.Signature())
+			}
+		}
+		lines = append(lines, line)
+	}
+	if meta != nil {
+		lines = append(lines, ui.Meta(fmt.Sprintf("0x%04x  -- metadata, %d bytes: 0x%s", len(runtime), len(meta), hex.EncodeToString(meta))))
+	}
+	return lines
 }
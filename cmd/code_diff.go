@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var codeDiffNetworks string
+
+var codeDiffCmd = &cobra.Command{
+	Use:   "diff <address> [address|artifact.json]",
+	Short: "Compare deployed bytecode with another contract or a build artifact",
+	Long: `Compare the runtime bytecode deployed at an address with another address
+or with the deployedBytecode of a local Hardhat, Foundry or solc artifact.
+
+The compiler metadata appended to the code (which changes with comments,
+paths and compiler settings) is ignored, and so are immutables: values
+baked in by the constructor. Artifacts record where immutables live; between
+two addresses every differing PUSH32 constant is treated as one and listed.
+
+With --networks the same address is compared across chains, the first chain
+being the reference — or every chain against the artifact when one is given.
+Exits non-zero when the code differs.
+
+Examples:
+  w3cli code diff 0xProxyImplA 0xProxyImplB --network base
+  w3cli code diff 0xVault out/Vault.sol/Vault.json --network sepolia
+  w3cli code diff 0xVault --networks ethereum,base,optimism,arbitrum
+  w3cli code diff 0xVault out/Vault.sol/Vault.json --networks base,optimism`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 2 && !isArtifactPath(args[1]) && codeDiffNetworks != "" {
+			return fmt.Errorf("--networks compares one address across chains — pass a single address (and optionally an artifact)")
+		}
+		if len(args) == 1 && codeDiffNetworks == "" {
+			return fmt.Errorf("nothing to compare with — pass a second address or artifact, or --networks a,b,...")
+		}
+		if isArtifactPath(args[0]) {
+			return fmt.Errorf("the first argument must be a deployed address — put the artifact second")
+		}
+
+		var art *contract.DeployedArtifact
+		if len(args) == 2 && isArtifactPath(args[1]) {
+			var err error
+			if art, err = contract.LoadDeployedArtifact(args[1]); err != nil {
+				return err
+			}
+		}
+
+		reg := chainpkg.NewRegistry()
+		if codeDiffNetworks != "" {
+			chains, err := deployChains(reg, codeDiffNetworks, false)
+			if err != nil {
+				return err
+			}
+			return codeDiffAcrossChains(chains, args[0], art, args[len(args)-1])
+		}
+
+		chainName := codeNetwork
+		if chainName == "" {
+			chainName = cfg.DefaultNetwork
+		}
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q", chainName)
+		}
+
+		addrA, codeA, err := fetchRuntimeCode(c, args[0])
+		if err != nil {
+			return err
+		}
+		var d *contract.CodeDiff
+		labelB := args[1]
+		if art != nil {
+			d = art.Compare(codeA)
+		} else {
+			addrB, codeB, err := fetchRuntimeCode(c, args[1])
+			if err != nil {
+				return err
+			}
+			labelB = addrB
+			d = contract.CompareCode(codeA, codeB)
+		}
+
+		pairs := [][2]string{
+			{"A", ui.Addr(addrA)},
+			{"B", labelB},
+			{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
+			{"Size", fmt.Sprintf("%d / %d bytes (metadata excluded)", d.SizeA, d.SizeB)},
+			{"Result", diffVerdict(d)},
+		}
+		fmt.Println(ui.KeyValueBlock("Bytecode Diff", pairs))
+		printCodeChanges("Immutables (ignored)", d.Immutables, len(d.Immutables))
+		printCodeChanges("Differences", d.Changes, d.ChangeCount)
+		if !d.Match() {
+			return fmt.Errorf("bytecode differs")
+		}
+		return nil
+	},
+}
+
+func init() {
+	codeDiffCmd.Flags().StringVar(&codeDiffNetworks, "networks", "", "compare the address across chains (comma-separated)")
+}
+
+// codeDiffAcrossChains compares the code at input on every chain with the
+// artifact, or with the first chain's code when art is nil.
+func codeDiffAcrossChains(chains []chainpkg.Chain, input string, art *contract.DeployedArtifact, artPath string) error {
+	refLabel := artPath
+	var ref []byte
+	t := ui.NewTable([]ui.Column{
+		{Title: "Network", Width: 14},
+		{Title: "Address", Width: 16},
+		{Title: "Size", Width: 8},
+		{Title: "Result", Width: 40},
+	})
+
+	differing := 0
+	for i := range chains {
+		c := &chains[i]
+		addr, code, err := fetchRuntimeCode(c, input)
+		if err != nil {
+			differing++
+			t.AddRow(ui.Row{c.DisplayName, ui.TruncateAddr(addr), "-", ui.Err(err.Error())})
+			continue
+		}
+
+		runtime, _ := contract.SplitMetadata(code)
+		size := fmt.Sprint(len(runtime))
+		var d *contract.CodeDiff
+		switch {
+		case art != nil:
+			d = art.Compare(code)
+		case ref == nil:
+			ref = code
+			refLabel = c.DisplayName
+			t.AddRow(ui.Row{c.DisplayName, ui.TruncateAddr(addr), size, ui.Meta("reference")})
+			continue
+		default:
+			d = contract.CompareCode(ref, code)
+		}
+		if !d.Match() {
+			differing++
+		}
+		t.AddRow(ui.Row{c.DisplayName, ui.TruncateAddr(addr), size, diffVerdict(d)})
+	}
+
+	fmt.Printf("\n  Comparing against %s\n", ui.Val(refLabel))
+	fmt.Println(t.Render())
+	if differing > 0 {
+		return fmt.Errorf("bytecode differs or is missing on %d of %d chain(s)", differing, len(chains))
+	}
+	fmt.Println(ui.Success(fmt.Sprintf("Same contract on all %d chains", len(chains))))
+	return nil
+}
+
+// fetchRuntimeCode resolves input on c and returns its deployed code.
+func fetchRuntimeCode(c *chainpkg.Chain, input string) (string, []byte, error) {
+	address, err := resolveAddress(input, c.Name)
+	if err != nil {
+		return input, nil, err
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return address, nil, err
+	}
+	hexCode, err := chainpkg.NewEVMClient(rpcURL).GetCode(address)
+	if err != nil {
+		return address, nil, fmt.Errorf("querying code on %s: %w", c.DisplayName, err)
+	}
+	code, err := contract.DecodeCode(hexCode)
+	if err != nil {
+		return address, nil, err
+	}
+	if len(code) == 0 {
+		return address, nil, fmt.Errorf("no code at %s on %s", address, c.DisplayName)
+	}
+	return address, code, nil
+}
+
+// isArtifactPath reports whether a diff operand names a local JSON file
+// rather than an address or name.
+func isArtifactPath(arg string) bool {
+	if strings.HasSuffix(strings.ToLower(arg), ".json") {
+		return true
+	}
+	if strings.HasPrefix(arg, "0x") {
+		return false
+	}
+	info, err := os.Stat(arg)
+	return err == nil && !info.IsDir()
+}
+
+// diffVerdict summarises a comparison in a few words.
+func diffVerdict(d *contract.CodeDiff) string {
+	switch {
+	case d.Identical:
+		return ui.Success("identical")
+	case !d.Match():
+		return ui.Err(fmt.Sprintf("different — %d instruction(s)", d.ChangeCount))
+	}
+	var notes []string
+	if d.MetadataDiffers {
+		notes = append(notes, "metadata")
+	}
+	if n := len(d.Immutables); n > 0 {
+		notes = append(notes, fmt.Sprintf("%d immutable(s)", n))
+	}
+	if len(notes) == 0 {
+		return ui.Success("match")
+	}
+	return ui.Success("match") + ui.Meta(" ("+strings.Join(notes, ", ")+" differ)")
+}
+
+// printCodeChanges lists changes, noting those beyond the ones kept.
+func printCodeChanges(title string, changes []contract.CodeChange, total int) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Printf("\n  %s\n", title)
+	t := ui.NewTable([]ui.Column{
+		{Title: "PC", Width: 8},
+		{Title: "A", Width: 36},
+		{Title: "B", Width: 36},
+	})
+	for _, c := range changes {
+		a, b := c.A, c.B
+		if a == "" {
+			a = ui.Meta("(end)")
+		}
+		if b == "" {
+			b = ui.Meta("(end)")
+		}
+		t.AddRow(ui.Row{fmt.Sprintf("0x%04x", c.PC), a, b})
+	}
+	fmt.Println(t.Render())
+	if total > len(changes) {
+		fmt.Println(ui.Hint(fmt.Sprintf("… and %d more", total-len(changes))))
+	}
+}
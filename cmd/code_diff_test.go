@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsArtifactPath(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "Vault")
+	require.NoError(t, os.WriteFile(file, []byte("{}"), 0o644))
+
+	assert.True(t, isArtifactPath("out/Vault.sol/Vault.json"))
+	assert.True(t, isArtifactPath("Vault.JSON"))
+	assert.True(t, isArtifactPath(file))
+	assert.False(t, isArtifactPath("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"))
+	assert.False(t, isArtifactPath("vitalik.eth"))
+	assert.False(t, isArtifactPath(t.TempDir()))
+}
+
+func TestDiffVerdict(t *testing.T) {
+	assert.Contains(t, diffVerdict(&contract.CodeDiff{Identical: true}), "identical")
+	assert.Contains(t, diffVerdict(&contract.CodeDiff{}), "match")
+
+	v := diffVerdict(&contract.CodeDiff{MetadataDiffers: true, Immutables: make([]contract.CodeChange, 2)})
+	assert.Contains(t, v, "match")
+	assert.Contains(t, v, "metadata, 2 immutable(s) differ")
+
+	assert.Contains(t, diffVerdict(&contract.CodeDiff{ChangeCount: 3}), "different — 3 instruction(s)")
+}
+
+func TestDisasmLines(t *testing.T) {
+	// PUSH4 transfer(address,uint256) EQ, then {"test": 0xaa} metadata.
+	code, _ := hex.DecodeString("63a9059cbb14" + "a1647465737441aa0008")
+	lines := disasmLines(code, contract.NewSignatureDB())
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "0x0000  PUSH4 0xa9059cbb"), lines[0])
+	assert.Contains(t, lines[0], "transfer(address,uint256)")
+	assert.Equal(t, "0x0005  EQ", lines[1])
+	assert.Contains(t, lines[2], "metadata, 10 bytes")
+}
+
+func TestMetadataPairs(t *testing.T) {
+	pairs := metadataPairs(chainpkg.W3TokenInitCode())
+	got := map[string]string{}
+	for _, p := range pairs {
+		got[p[0]] = p[1]
+	}
+	assert.Contains(t, got["Compiler"], "solc 0.8.26")
+	assert.Contains(t, got["Metadata"], "ipfs://Qm")
+	assert.Contains(t, got["Runtime Size"], "+ 53 metadata")
+
+	assert.Nil(t, metadataPairs([]byte{0x60, 0x80}))
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/spf13/cobra"
@@ -61,6 +62,8 @@ var configListCmd = &cobra.Command{
 			{"Network Mode", mode},
 			{"Default Wallet", wallet},
 			{"RPC Algorithm", algo},
+			{"Price Currency", strings.ToUpper(cfg.PriceCurrency)},
+			{"Price Sources", strings.Join(priceSourceNames(), " → ")},
 			{"Explorer API Key", apiKeyStatus},
 			{"Config Directory", cfg.Dir()},
 		}))
@@ -237,6 +240,70 @@ Examples:
 	},
 }
 
+var configSetCurrencyCmd = &cobra.Command{
+	Use:   "set-currency <code>",
+	Short: "Set the fiat currency for prices (usd, eur, gbp, ...)",
+	Long: `Set the currency balance, allbal and portfolio value holdings in. Any
+currency CoinGecko quotes works; USD-only sources are converted.
+
+Examples:
+  w3cli config set-currency eur
+  w3cli config set-currency usd`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code := strings.ToUpper(strings.TrimSpace(args[0]))
+		if len(code) < 3 || len(code) > 4 {
+			return fmt.Errorf("invalid currency %q — use a code like usd, eur or gbp", args[0])
+		}
+		cfg.PriceCurrency = code
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Price currency set to %s", code)))
+		return nil
+	},
+}
+
+var configSetPriceSourcesCmd = &cobra.Command{
+	Use:   "set-price-sources <source,...>",
+	Short: "Choose and order the token price sources",
+	Long: `Set which sources price ERC-20 tokens, in the order they are tried. Each
+source is only asked for tokens the earlier ones couldn't price.
+
+Sources:
+  coingecko  CoinGecko token prices (any currency)
+  defillama  DefiLlama coins API (USD)
+  chainlink  On-chain Chainlink USD feeds for major tokens
+  uniswap    On-chain Uniswap V3 30-minute TWAP against USDC
+
+Examples:
+  w3cli config set-price-sources defillama,coingecko
+  w3cli config set-price-sources chainlink,uniswap   # on-chain only, no price APIs`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var names []string
+		for _, n := range strings.Split(args[0], ",") {
+			n = strings.ToLower(strings.TrimSpace(n))
+			if n == "" {
+				continue
+			}
+			if !isPriceSource(n) {
+				return fmt.Errorf("unknown price source %q — choose from: %s", n, strings.Join(defaultPriceSources, ", "))
+			}
+			names = append(names, n)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no price sources given")
+		}
+		cfg.PriceSources = names
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success("Price sources: " + strings.Join(names, " → ")))
+		return nil
+	},
+}
+
 func init() {
 	configSetExplorerKeyCmd.Flags().StringVar(&explorerKeyChain, "chain", "", "set key for a specific chain only")
 	configCmd.AddCommand(
@@ -247,5 +314,7 @@ func init() {
 		configSetRPCCmd,
 		configSetExplorerKeyCmd,
 		configSetKeyCmd,
+		configSetCurrencyCmd,
+		configSetPriceSourcesCmd,
 	)
 }
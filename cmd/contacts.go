@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contacts"
+	"github.com/Mohsinsiddi/w3cli/internal/resolve"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contactsNetwork string
+	contactsTags    []string
+	contactsNote    string
+	contactsTag     string
+)
+
+var contactsCmd = &cobra.Command{
+	Use:   "contacts",
+	Short: "Manage the address book",
+	Long: `Label the addresses you deal with — exchanges, multisigs, friends — so
+they can be used by name wherever an address is accepted and show up by
+name in txs, watch, tx and events output.
+
+A contact has one default EVM address and optional per-network overrides
+(including Solana and SUI addresses). send warns when a recipient is not
+in the book or looks like a contact without being it (address poisoning).`,
+}
+
+// ── contacts add ─────────────────────────────────────────────────────────────
+
+var contactsAddCmd = &cobra.Command{
+	Use:   "add <label> <address>",
+	Short: "Add a contact or another address for one",
+	Long: `Add a contact. Without --network the address is the contact's default on
+every EVM chain; with --network it only applies there. Adding to an existing
+label merges tags and sets the extra address.
+
+Examples:
+  w3cli contacts add binance 0x28C6c06298d514Db089934071355E5743bf21d60 --tag cex
+  w3cli contacts add alice 0x1111...1111 --note "hardware wallet"
+  w3cli contacts add alice 0x2222...2222 --network base
+  w3cli contacts add alice 7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label := args[0]
+		if err := contacts.ValidateLabel(label); err != nil {
+			return err
+		}
+		address, network, err := contactAddress(args[1], contactsNetwork)
+		if err != nil {
+			return err
+		}
+
+		book, err := loadContactBook()
+		if err != nil {
+			return err
+		}
+
+		entry := &contacts.Contact{Label: label, Tags: contactsTags, Notes: contactsNote}
+		if network == "" {
+			entry.Address = address
+		} else {
+			entry.Chains = map[string]string{network: address}
+		}
+
+		existing, err := book.Get(label)
+		if err == nil {
+			if network == "" && existing.Address != "" && !strings.EqualFold(existing.Address, address) {
+				return fmt.Errorf("contact %q already has address %s — use --network to add a per-chain address, or remove it first", label, existing.Address)
+			}
+			existing.Merge(entry)
+		} else {
+			entry.AddedAt = time.Now().UTC().Format(time.RFC3339)
+			book.Put(entry)
+		}
+
+		for _, m := range book.Similar(address, network) {
+			fmt.Println(ui.Warn(fmt.Sprintf("%s looks like contact %q (%s) — make sure this isn't a poisoned address", address, m.Label, m.Address)))
+		}
+
+		if err := book.Save(); err != nil {
+			return err
+		}
+		where := "all EVM chains"
+		if network != "" {
+			where = network
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Saved contact %q → %s (%s)", label, address, where)))
+		return nil
+	},
+}
+
+// ── contacts list ────────────────────────────────────────────────────────────
+
+var contactsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List contacts",
+	Long: `List contacts, optionally only those with a tag or an address on a network.
+
+Examples:
+  w3cli contacts list
+  w3cli contacts list --tag cex
+  w3cli contacts list --network base`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		book, err := loadContactBook()
+		if err != nil {
+			return err
+		}
+
+		var shown []*contacts.Contact
+		for _, c := range book.All() {
+			if contactsTag != "" && !c.HasTag(contactsTag) {
+				continue
+			}
+			if contactsNetwork != "" && c.AddressOn(contactsNetwork) == "" {
+				continue
+			}
+			shown = append(shown, c)
+		}
+		if len(shown) == 0 {
+			fmt.Println(ui.Info("No contacts found."))
+			fmt.Println(ui.Hint("Add one: w3cli contacts add <label> <address>"))
+			return nil
+		}
+
+		t := ui.NewTable([]ui.Column{
+			{Title: "Label", Width: 16},
+			{Title: "Address", Width: 44},
+			{Title: "Chains", Width: 18},
+			{Title: "Tags", Width: 14},
+			{Title: "Notes", Width: 24},
+		})
+		for _, c := range shown {
+			addr := c.Address
+			if contactsNetwork != "" {
+				addr = c.AddressOn(contactsNetwork)
+			} else if addr == "" && len(c.Chains) > 0 {
+				addr = c.Chains[c.Networks()[0]]
+			}
+			t.AddRow(ui.Row{
+				ui.Val(c.Label),
+				ui.Addr(addr),
+				strings.Join(c.Networks(), ", "),
+				strings.Join(c.Tags, ", "),
+				c.Notes,
+			})
+		}
+		fmt.Println(t.Render())
+		fmt.Println(ui.Meta(fmt.Sprintf("%d contact(s)", len(shown))))
+		return nil
+	},
+}
+
+// ── contacts remove ──────────────────────────────────────────────────────────
+
+var contactsRemoveCmd = &cobra.Command{
+	Use:   "remove <label>",
+	Short: "Remove a contact, or one of its per-network addresses",
+	Long: `Remove a contact. With --network only that network's address is removed.
+
+Examples:
+  w3cli contacts remove alice
+  w3cli contacts remove alice --network base`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label := args[0]
+		book, err := loadContactBook()
+		if err != nil {
+			return err
+		}
+
+		if contactsNetwork != "" {
+			c, err := book.Get(label)
+			if err != nil {
+				return err
+			}
+			if _, ok := c.Chains[contactsNetwork]; !ok {
+				return fmt.Errorf("contact %q has no %s address", label, contactsNetwork)
+			}
+			delete(c.Chains, contactsNetwork)
+			if c.Address == "" && len(c.Chains) == 0 {
+				_ = book.Remove(label)
+			}
+		} else if err := book.Remove(label); err != nil {
+			return err
+		}
+
+		if err := book.Save(); err != nil {
+			return err
+		}
+		if contactsNetwork != "" {
+			fmt.Println(ui.Success(fmt.Sprintf("Removed %s address from contact %q", contactsNetwork, label)))
+		} else {
+			fmt.Println(ui.Success(fmt.Sprintf("Removed contact %q", label)))
+		}
+		return nil
+	},
+}
+
+// ── contacts import ──────────────────────────────────────────────────────────
+
+var contactsImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import contacts from CSV or JSON",
+	Long: `Import contacts from a CSV file with a header row, or a JSON array in the
+same format as contacts.json. Existing labels are merged.
+
+CSV columns (label and address required):
+  label,address,network,tags,notes
+  binance,0x28C6...1d60,,cex;hot,deposit address
+  alice,0x1111...1111,base,friend,
+
+Examples:
+  w3cli contacts import contacts.csv
+  w3cli contacts import backup.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		entries, err := contacts.ParseImport(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		book, err := loadContactBook()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		added, updated := 0, 0
+		for _, e := range entries {
+			if err := normalizeContact(e); err != nil {
+				return err
+			}
+			if existing, err := book.Get(e.Label); err == nil {
+				existing.Merge(e)
+				updated++
+				continue
+			}
+			if e.AddedAt == "" {
+				e.AddedAt = now
+			}
+			book.Put(e)
+			added++
+		}
+
+		if err := book.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Imported %d contact(s): %d new, %d updated", len(entries), added, updated)))
+		return nil
+	},
+}
+
+func init() {
+	contactsAddCmd.Flags().StringVar(&contactsNetwork, "network", "", "only use this address on one network")
+	contactsAddCmd.Flags().StringSliceVar(&contactsTags, "tag", nil, "tag (repeatable)")
+	contactsAddCmd.Flags().StringVar(&contactsNote, "note", "", "free-form note")
+
+	contactsListCmd.Flags().StringVar(&contactsTag, "tag", "", "only contacts with this tag")
+	contactsListCmd.Flags().StringVar(&contactsNetwork, "network", "", "only contacts with an address on this network")
+
+	contactsRemoveCmd.Flags().StringVar(&contactsNetwork, "network", "", "only remove this network's address")
+
+	contactsCmd.AddCommand(
+		contactsAddCmd,
+		contactsListCmd,
+		contactsRemoveCmd,
+		contactsImportCmd,
+	)
+}
+
+func newContactBook() *contacts.Book {
+	return contacts.NewBook(filepath.Join(cfg.Dir(), "contacts.json"))
+}
+
+func loadContactBook() (*contacts.Book, error) {
+	book := newContactBook()
+	if err := book.Load(); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// contactAddress validates address for network and returns it in canonical
+// form. Solana and SUI addresses given without --network are filed under
+// their chain, since they can't be a contact's default EVM address.
+func contactAddress(address, network string) (string, string, error) {
+	chainType := chain.ChainTypeEVM
+	if network != "" {
+		c, err := chain.NewRegistry().GetByName(network)
+		if err != nil {
+			return "", "", fmt.Errorf("unknown network %q — run `w3cli network list`", network)
+		}
+		chainType = c.Type
+	} else {
+		switch {
+		case sui.IsAddress(address):
+			chainType, network = chain.ChainTypeSUI, "sui"
+		case solana.IsAddress(address):
+			chainType, network = chain.ChainTypeSolana, "solana"
+		}
+	}
+
+	switch chainType {
+	case chain.ChainTypeSolana:
+		if !solana.IsAddress(address) {
+			return "", "", fmt.Errorf("invalid Solana address %q", address)
+		}
+		return address, network, nil
+	case chain.ChainTypeSUI:
+		if !sui.IsAddress(address) {
+			return "", "", fmt.Errorf("invalid SUI address %q", address)
+		}
+		return strings.ToLower(address), network, nil
+	default:
+		addr, err := resolve.ParseAddress(address)
+		return addr, network, err
+	}
+}
+
+// normalizeContact validates and canonicalizes every address of an imported
+// contact.
+func normalizeContact(c *contacts.Contact) error {
+	if c.Address != "" {
+		addr, network, err := contactAddress(c.Address, "")
+		if err != nil {
+			return fmt.Errorf("contact %q: %w", c.Label, err)
+		}
+		if network != "" {
+			c.Address = ""
+			if c.Chains == nil {
+				c.Chains = map[string]string{}
+			}
+			c.Chains[network] = addr
+		} else {
+			c.Address = addr
+		}
+	}
+	for network, a := range c.Chains {
+		addr, _, err := contactAddress(a, network)
+		if err != nil {
+			return fmt.Errorf("contact %q on %s: %w", c.Label, network, err)
+		}
+		c.Chains[network] = addr
+	}
+	return nil
+}
+
+// contactAddressMatching returns the address of contact label that valid
+// accepts — how Solana and SUI commands find a contact's address on their
+// chain without knowing which network key it was filed under.
+func contactAddressMatching(label string, valid func(string) bool) (string, bool) {
+	book := newContactBook()
+	if err := book.Load(); err != nil {
+		return "", false
+	}
+	c, err := book.Get(label)
+	if err != nil {
+		return "", false
+	}
+	for _, n := range c.Networks() {
+		if valid(c.Chains[n]) {
+			return c.Chains[n], true
+		}
+	}
+	return "", false
+}
+
+// addressLabels names addresses for display on one network: address-book
+// labels first, then wallet names.
+type addressLabels struct {
+	network string
+	book    *contacts.Book
+	wallets map[string]string // address key → wallet name
+}
+
+// newAddressLabels loads the address book and wallets. Errors just mean
+// fewer labels.
+func newAddressLabels(network string) *addressLabels {
+	l := &addressLabels{network: network, book: newContactBook(), wallets: map[string]string{}}
+	_ = l.book.Load()
+	for _, w := range newWalletManager().List() {
+		l.wallets[addressKey(w.Address)] = w.Name
+	}
+	return l
+}
+
+// Name returns the label for addr, or "" when it isn't known.
+func (l *addressLabels) Name(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	if label, ok := l.book.LabelFor(addr, l.network); ok {
+		return label
+	}
+	return l.wallets[addressKey(addr)]
+}
+
+// Short returns the label for addr, or the truncated address.
+func (l *addressLabels) Short(addr string) string {
+	if name := l.Name(addr); name != "" {
+		return name
+	}
+	return ui.TruncateAddr(addr)
+}
+
+// Addr renders the full address followed by its label when there is one.
+func (l *addressLabels) Addr(addr string) string {
+	if name := l.Name(addr); name != "" {
+		return ui.Addr(addr) + " " + ui.Val("("+name+")")
+	}
+	return ui.Addr(addr)
+}
+
+// addressKey lowercases hex addresses so differently-cased copies match;
+// base58 is case-sensitive and kept as is.
+func addressKey(addr string) string {
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		return strings.ToLower(addr)
+	}
+	return addr
+}
+
+// warnRecipient prints address-poisoning warnings before a send: the
+// recipient looks like a contact or wallet without being it, or it is not
+// known at all. It never blocks; the send preview and confirmation follow.
+func warnRecipient(to, network string) {
+	labels := newAddressLabels(network)
+	if labels.Name(to) != "" {
+		return
+	}
+
+	for _, m := range labels.book.Similar(to, network) {
+		fmt.Println(ui.Warn(fmt.Sprintf("Recipient %s looks like contact %q (%s) but is a DIFFERENT address — possible address poisoning", to, m.Label, m.Address)))
+	}
+	for key, name := range labels.wallets {
+		if contacts.LookAlike(key, to) {
+			fmt.Println(ui.Warn(fmt.Sprintf("Recipient %s looks like your wallet %q but is a DIFFERENT address — possible address poisoning", to, name)))
+		}
+	}
+
+	reg := newContractRegistry()
+	if err := reg.Load(); err == nil {
+		for _, e := range reg.All() {
+			if e.Network == network && strings.EqualFold(e.Address, to) {
+				return
+			}
+		}
+	}
+	fmt.Println(ui.Warn(fmt.Sprintf("Recipient %s is not in your contacts or wallets", to)))
+	fmt.Println(ui.Hint(fmt.Sprintf("Double-check it against the source, then save it: w3cli contacts add <label> %s", to)))
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contacts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactAddress(t *testing.T) {
+	addr, network, err := contactAddress("0x28c6c06298d514db089934071355e5743bf21d60", "")
+	require.NoError(t, err)
+	assert.Equal(t, "0x28C6c06298d514Db089934071355E5743bf21d60", addr)
+	assert.Empty(t, network)
+
+	addr, network, err = contactAddress("7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", "")
+	require.NoError(t, err)
+	assert.Equal(t, "7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", addr)
+	assert.Equal(t, "solana", network)
+
+	sui := "0x" + "AB" + "00000000000000000000000000000000000000000000000000000000000000"
+	addr, network, err = contactAddress(sui, "")
+	require.NoError(t, err)
+	assert.Equal(t, "0xab00000000000000000000000000000000000000000000000000000000000000", addr)
+	assert.Equal(t, "sui", network)
+
+	_, _, err = contactAddress("0x28C6c06298d514Db089934071355E5743bf21d61", "")
+	assert.ErrorContains(t, err, "checksum")
+
+	_, _, err = contactAddress("0x28c6c06298d514db089934071355e5743bf21d60", "solana")
+	assert.ErrorContains(t, err, "invalid Solana address")
+
+	_, _, err = contactAddress("0x28c6c06298d514db089934071355e5743bf21d60", "nope")
+	assert.ErrorContains(t, err, "unknown network")
+}
+
+func TestNormalizeContact(t *testing.T) {
+	c := &contacts.Contact{
+		Label:   "alice",
+		Address: "7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV",
+		Chains:  map[string]string{"base": "0x28c6c06298d514db089934071355e5743bf21d60"},
+	}
+	require.NoError(t, normalizeContact(c))
+	assert.Empty(t, c.Address)
+	assert.Equal(t, "7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", c.Chains["solana"])
+	assert.Equal(t, "0x28C6c06298d514Db089934071355E5743bf21d60", c.Chains["base"])
+
+	assert.Error(t, normalizeContact(&contacts.Contact{Label: "bad", Address: "0x123"}))
+}
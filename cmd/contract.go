@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +31,7 @@ var (
 	contractABIFile      string
 	contractBuiltin      string // --builtin <id>
 	contractFetchABI     bool
+	contractFromBytecode bool // --from-bytecode
 	contractNetwork      string
 	contractStudioWallet string
 
@@ -37,6 +40,7 @@ var (
 	contractDeployValue  string // --value (ETH to send for payable constructors)
 	contractDeployGas    uint64 // --gas (gas limit override)
 	contractDeployWallet string // --wallet (signing wallet)
+	contractDeployVerify bool   // --verify (verify source on the explorer after deploy)
 )
 
 var contractCmd = &cobra.Command{
@@ -54,12 +58,23 @@ var contractAddCmd = &cobra.Command{
 ABI source (pick one):
   --abi <file>        Raw ABI JSON array or Hardhat/Foundry artifact
   --builtin <id>      Use a bundled ABI (see: w3cli contract builtins)
-  --fetch             Auto-fetch ABI from explorer (requires API key)
+  --fetch             Auto-fetch the verified ABI from the chain's explorer,
+                      falling back to Sourcify (full or partial match)
+  --from-bytecode     Recover a best-effort ABI from unverified bytecode:
+                      dispatcher selectors named from the built-in signature
+                      list, built-in ABIs and registered contracts, with
+                      view/write inferred from the code
+
+Proxies (EIP-1967, transparent, UUPS, beacon, EIP-1167 clones, Safe) are
+detected automatically. With --fetch the implementation's ABI is fetched and
+merged with the proxy's, so studio and call work on the proxy address.
 
 Examples:
   w3cli contract add myUSDC 0xA0b8...  --builtin erc20 --network ethereum
   w3cli contract add myNFT  0x1234...  --abi ./out/MyNFT.sol/MyNFT.json
-  w3cli contract add myToken 0xABCD... --builtin w3token --network base`,
+  w3cli contract add myToken 0xABCD... --builtin w3token --network base
+  w3cli contract add aave   0x8787...  --fetch --network ethereum   # proxy → implementation ABI
+  w3cli contract add mystery 0x5678... --from-bytecode --network base`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, address := args[0], args[1]
@@ -73,6 +88,23 @@ Examples:
 			return err
 		}
 
+		// Proxy detection needs the chain; a registry entry is still useful
+		// without it, so failures only warn.
+		var proxy *contract.ProxyInfo
+		var c *chainpkg.Chain
+		if ch, err := newChainRegistry().GetByName(network); err == nil && ch.Type == chainpkg.ChainTypeEVM {
+			c = ch
+			if rpcURL, err := pickBestRPC(c, cfg.NetworkMode); err == nil {
+				spin := ui.NewSpinner("Checking for a proxy...")
+				spin.Start()
+				proxy, err = contract.DetectProxy(chainpkg.NewEVMClient(rpcURL), address)
+				spin.Stop()
+				if err != nil {
+					fmt.Println(ui.Warn("Proxy check failed: " + err.Error()))
+				}
+			}
+		}
+
 		var abi []contract.ABIEntry
 		var kind, builtinID, abiSource string
 		var err error
@@ -96,10 +128,35 @@ Examples:
 			builtinID = contractBuiltin
 
 		case contractFetchABI:
-			return fmt.Errorf("--fetch requires an explorer API key\n  Set one with: w3cli config set-explorer-key <key>\n  Or provide a local ABI file: --abi <file.json>")
+			if c == nil {
+				return fmt.Errorf("unknown EVM chain %q — run `w3cli network list`", network)
+			}
+			spin := ui.NewSpinner(fmt.Sprintf("Fetching ABI from %s explorer...", c.DisplayName))
+			spin.Start()
+			abi, err = fetchContractABI(c, network, address, proxy)
+			spin.Stop()
+			if err != nil {
+				return fmt.Errorf("%w\n  Set an explorer key with: w3cli config set-explorer-key <key>\n  Or provide a local ABI file: --abi <file.json>\n  Or recover one from the bytecode: --from-bytecode", err)
+			}
+			kind = "fetched"
+
+		case contractFromBytecode:
+			if c == nil {
+				return fmt.Errorf("unknown EVM chain %q — run `w3cli network list`", network)
+			}
+			spin := ui.NewSpinner("Analyzing bytecode...")
+			spin.Start()
+			var reports []bytecodeReport
+			abi, reports, err = recoverContractABI(c, address, proxy, reg)
+			spin.Stop()
+			printBytecodeReports(reports)
+			if err != nil {
+				return err
+			}
+			kind = "bytecode"
 		}
 
-		reg.Add(&contract.Entry{
+		entry := &contract.Entry{
 			Name:      name,
 			Network:   network,
 			Address:   address,
@@ -107,13 +164,35 @@ Examples:
 			Kind:      kind,
 			BuiltinID: builtinID,
 			ABISource: abiSource,
-		})
+		}
+		if proxy != nil {
+			entry.ProxyKind = proxy.Kind
+			if proxy.Implementation != "" {
+				entry.RecordImplementation(contract.ImplementationRecord{
+					Address: proxy.Implementation,
+					At:      time.Now().UTC().Format(time.RFC3339),
+				})
+			}
+		}
+		reg.Add(entry)
 
 		if err := reg.Save(); err != nil {
 			return err
 		}
 
 		fmt.Println(ui.Success(fmt.Sprintf("Contract %q registered on %s at %s", name, network, ui.Addr(address))))
+		if proxy != nil {
+			fmt.Println(ui.KeyValueBlock("Proxy Detected", proxyPairs(proxy)))
+			if !contractFetchABI && !contractFromBytecode {
+				fmt.Println(ui.Hint("Use --fetch to register the implementation's ABI, or --abi with the implementation artifact."))
+			}
+		}
+		if kind == "fetched" {
+			fmt.Println(ui.Hint(fmt.Sprintf("Fetched ABI: %d functions", countFunctions(abi))))
+		}
+		if kind == "bytecode" {
+			fmt.Println(ui.Hint(fmt.Sprintf("Recovered ABI: %d functions — names and mutability are best-effort", countFunctions(abi))))
+		}
 		if builtinID != "" {
 			fmt.Println(ui.Hint(fmt.Sprintf("Using built-in ABI: %s (%d functions)", builtinID, countFunctions(abi))))
 		}
@@ -235,7 +314,7 @@ var contractListCmd = &cobra.Command{
 			{Title: "Name", Width: 16},
 			{Title: "Network", Width: 14},
 			{Title: "Address", Width: 44},
-			{Title: "Kind", Width: 10},
+			{Title: "Kind", Width: 14},
 			{Title: "Functions", Width: 10},
 		})
 
@@ -244,6 +323,9 @@ var contractListCmd = &cobra.Command{
 			if kind == "" {
 				kind = "custom"
 			}
+			if e.ProxyKind != "" {
+				kind += " (proxy)"
+			}
 			t.AddRow(ui.Row{
 				ui.Val(e.Name),
 				ui.ChainName(e.Network),
@@ -351,17 +433,112 @@ var contractCallCmd = &cobra.Command{
 var contractSyncCmd = &cobra.Command{
 	Use:   "sync [contract]",
 	Short: "Re-fetch ABI for a contract (or all)",
+	Long: `Re-check registered contracts for proxies and re-fetch explorer ABIs.
+
+Picks up proxy upgrades: the current implementation is detected again and,
+for contracts added with --fetch, its ABI is re-fetched and merged with the
+proxy's. Contracts added with --from-bytecode are analyzed again. Contracts with a local or builtin ABI only get their proxy info
+refreshed.
+
+Examples:
+  w3cli contract sync aave --network ethereum
+  w3cli contract sync --all`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
 		if !all && len(args) == 0 {
 			return fmt.Errorf("provide a contract name or --all")
 		}
-		fmt.Println(ui.Info("ABI sync via explorer requires an API key."))
-		fmt.Println(ui.Hint("Use `w3cli sync run` for manifest-based sync."))
+		network := contractNetwork
+		if network == "" {
+			network = cfg.DefaultNetwork
+		}
+
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		var entries []*contract.Entry
+		if all {
+			entries = reg.All()
+		} else {
+			e, err := reg.Get(args[0], network)
+			if err != nil {
+				return err
+			}
+			entries = []*contract.Entry{e}
+		}
+
+		chainReg := newChainRegistry()
+		synced := 0
+		for _, e := range entries {
+			if err := syncContractEntry(chainReg, e); err != nil {
+				fmt.Println(ui.Warn(fmt.Sprintf("%s (%s): %v", e.Name, e.Network, err)))
+				continue
+			}
+			synced++
+		}
+		if err := reg.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Synced %d of %d contract(s)", synced, len(entries))))
 		return nil
 	},
 }
 
+// syncContractEntry refreshes an entry's proxy info and, for fetched ABIs,
+// re-fetches the ABI from the explorer.
+func syncContractEntry(chainReg *chainpkg.Registry, e *contract.Entry) error {
+	c, err := chainReg.GetByName(e.Network)
+	if err != nil || c.Type != chainpkg.ChainTypeEVM {
+		return fmt.Errorf("unknown EVM chain %q", e.Network)
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return err
+	}
+	proxy, err := contract.DetectProxy(chainpkg.NewEVMClient(rpcURL), e.Address)
+	if err != nil {
+		return err
+	}
+
+	prevImpl := e.Implementation
+	e.ProxyKind, e.Implementation = "", ""
+	if proxy != nil {
+		e.ProxyKind = proxy.Kind
+		if proxy.Implementation != "" {
+			e.RecordImplementation(contract.ImplementationRecord{
+				Address: proxy.Implementation,
+				At:      time.Now().UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	switch e.Kind {
+	case "fetched":
+		abi, err := fetchContractABI(c, e.Network, e.Address, proxy)
+		if err != nil {
+			return err
+		}
+		e.ABI = abi
+	case "bytecode":
+		abi, _, err := recoverContractABI(c, e.Address, proxy, nil)
+		if err != nil {
+			return err
+		}
+		e.ABI = abi
+	}
+
+	msg := fmt.Sprintf("%s (%s): %d functions", e.Name, e.Network, countFunctions(e.ABI))
+	if proxy != nil {
+		msg += " · " + proxy.Kind
+		if prevImpl != "" && !strings.EqualFold(prevImpl, proxy.Implementation) {
+			msg += fmt.Sprintf(" · upgraded %s → %s", prevImpl, proxy.Implementation)
+		}
+	}
+	fmt.Println(ui.Success(msg))
+	return nil
+}
+
 // ── contract studio ───────────────────────────────────────────────────────────
 
 var contractStudioCmd = &cobra.Command{
@@ -372,6 +549,18 @@ var contractStudioCmd = &cobra.Command{
 Navigate functions with ↑↓ (or j/k), press Enter to select.
 For read functions: results are displayed immediately.
 For write functions: inputs are collected, a preview is shown, and you confirm before broadcasting.
+Press c instead of Enter to only build the calldata and copy it to the clipboard,
+for a Safe, Tenderly or another signer. Arrays and tuples are entered as JSON;
+returned arrays and tuples are shown as a tree.
+
+  /   fuzzy-search functions by name (or selector prefix); Esc clears
+  f   pin a function to the Favourites section at the top
+  h   call history — args, results and tx hashes — Enter re-runs a call
+  w   watch a view function: it is re-called on every new block
+
+A side panel streams the contract's recent events, decoded with its ABI, and
+the latest values of watched functions. History and favourites are saved per
+contract in studio.json in the config directory.
 
 Specify a contract name to open it directly, or omit to pick from the registered list.
 
@@ -472,47 +661,102 @@ Examples:
 			}
 		}
 
+		// ── Saved history & favourites ────────────────────────────────────
+		store := newStudioStore()
+		if err := store.Load(); err != nil {
+			return err
+		}
+		state := store.For(contractName, network)
+		for i := range studioEntries {
+			studioEntries[i].Favourite = state.IsFavourite(studioEntries[i].Selector)
+		}
+
 		// ── Main studio loop ──────────────────────────────────────────────
+		model := ui.StudioModel{
+			ContractName: contractName,
+			Address:      entry.Address,
+			Network:      network,
+			Mode:         cfg.NetworkMode,
+			Kind:         kind,
+			FuncCount:    funcCount,
+			EventCount:   eventCount,
+			Entries:      studioEntries,
+			Poll:         studioPoller(client, entry),
+		}
 		for {
-			model := ui.StudioModel{
-				ContractName: contractName,
-				Address:      entry.Address,
-				Network:      network,
-				Mode:         cfg.NetworkMode,
-				Kind:         kind,
-				FuncCount:    funcCount,
-				EventCount:   eventCount,
-				Entries:      studioEntries,
-			}
-
-			selected, err := ui.RunStudio(model)
+			model.History = studioHistoryItems(state.History)
+			final, err := ui.RunStudio(model)
 			if err != nil {
 				return err
 			}
-			if selected == nil {
+			model = final // keeps favourites, watches and events for the next run
+			state.SetFavourites(studioFavourites(model.Entries))
+			if err := store.Save(); err != nil {
+				fmt.Println(ui.Warn("saving studio state: " + err.Error()))
+			}
+
+			selected, action := model.Selected, model.Action
+			var inputs []string
+			if action == ui.StudioRerun {
+				// ── Re-run a saved call ───────────────────────────────────
+				call := state.History[model.HistoryIndex]
+				if selected = studioEntryBySelector(model.Entries, call.Selector); selected == nil {
+					fmt.Println(ui.Err(fmt.Sprintf("%s is no longer in the ABI", call.Sig)))
+					continue
+				}
+				inputs = call.Args
+				fmt.Println()
+				fmt.Printf("%s\n",
+					ui.StyleTitle.Render(fmt.Sprintf("  %s  ›  %s  (re-run)", contractName, selected.Sig)))
+			} else if selected == nil {
 				// User pressed q — exit
 				fmt.Println(ui.Meta("Exiting contract studio."))
 				return nil
-			}
-
-			// ── Collect inputs ────────────────────────────────────────────
-			fmt.Println()
-			fmt.Printf("%s\n\n",
-				ui.StyleTitle.Render(fmt.Sprintf("  %s  ›  %s", contractName, selected.Sig)))
-			if selected.Description != "" {
-				fmt.Println(ui.Meta("  " + selected.Description))
+			} else {
+				// ── Collect inputs ────────────────────────────────────────
 				fmt.Println()
+				fmt.Printf("%s\n\n",
+					ui.StyleTitle.Render(fmt.Sprintf("  %s  ›  %s", contractName, selected.Sig)))
+				if selected.Description != "" {
+					fmt.Println(ui.Meta("  " + selected.Description))
+					fmt.Println()
+				}
+
+				inputs, err = collectStudioInputs(selected.Inputs)
+				if err != nil {
+					return err
+				}
 			}
 
-			inputs, err := collectStudioInputs(selected.Inputs)
-			if err != nil {
-				return err
+			if action == ui.StudioAddWatch {
+				// ── Watch with arguments — straight back to the studio ────
+				model.Watches = append(model.Watches, ui.StudioWatch{
+					Selector: selected.Selector,
+					Label:    studioWatchLabel(selected, inputs),
+					Args:     inputs,
+				})
+				continue
 			}
 
 			// ── Execute ───────────────────────────────────────────────────
-			if !selected.IsWrite {
+			call := contract.StudioCall{
+				Selector: selected.Selector,
+				Sig:      selected.Sig,
+				Args:     inputs,
+				At:       time.Now().UTC().Format(time.RFC3339),
+			}
+			record := false
+			if action == ui.StudioCalldata {
+				// ── Calldata only ─────────────────────────────────────────
+				studioBuildCalldata(entry, selected, inputs, network)
+			} else if !selected.IsWrite {
 				// ── Read call ─────────────────────────────────────────────
-				studioExecuteRead(entry, selected, inputs, rpcURL, contractName, network, cfg.NetworkMode)
+				results, err := studioExecuteRead(entry, selected, inputs, client, network, cfg.NetworkMode)
+				call.Results = results
+				if err != nil {
+					call.Error = err.Error()
+				}
+				record = true
 			} else {
 				// ── Write tx ──────────────────────────────────────────────
 				if signerWallet == nil {
@@ -520,7 +764,20 @@ Examples:
 				} else if signerWallet.Type != wallet.TypeSigning {
 					fmt.Println(ui.Err(fmt.Sprintf("wallet %q is watch-only — use a signing wallet", walletName)))
 				} else {
-					studioExecuteWrite(entry, selected, inputs, client, c, signerWallet, contractName, cfg.NetworkMode)
+					hash, value, err := studioExecuteWrite(entry, selected, inputs, client, c, signerWallet, contractName, cfg.NetworkMode)
+					if hash != "" {
+						call.TxHash, call.Value = hash, value
+						if err != nil {
+							call.Error = err.Error()
+						}
+						record = true
+					}
+				}
+			}
+			if record {
+				state.Record(call)
+				if err := store.Save(); err != nil {
+					fmt.Println(ui.Warn("saving studio history: " + err.Error()))
 				}
 			}
 
@@ -637,6 +894,12 @@ func validateABIInput(typ, val string) string {
 		return "value cannot be empty"
 	}
 	switch {
+	case strings.HasSuffix(typ, "]") || strings.HasPrefix(typ, "("):
+		v := strings.TrimSpace(val)
+		if !strings.HasPrefix(v, "[") && !strings.HasPrefix(v, "{") || !json.Valid([]byte(v)) {
+			return fmt.Sprintf("%s must be JSON, e.g. [\"0x…\", 1]", typ)
+		}
+
 	case typ == "address":
 		v := strings.TrimSpace(val)
 		if !strings.HasPrefix(v, "0x") && !strings.HasPrefix(v, "0X") {
@@ -689,42 +952,72 @@ func validateABIInput(typ, val string) string {
 
 // ── studio: read execution ────────────────────────────────────────────────────
 
+// studioExecuteRead calls a view function and prints its results, arrays
+// and tuples as a tree. It returns each result on one line, for the history.
 func studioExecuteRead(
 	entry *contract.Entry,
 	fn *ui.StudioEntry,
 	inputs []string,
-	rpcURL string,
-	contractName, network, mode string,
-) {
+	client *chainpkg.EVMClient,
+	network, mode string,
+) ([]string, error) {
+	abiEntry, ok := studioABIEntry(entry.ABI, fn.Selector)
+	if !ok {
+		err := fmt.Errorf("%s not found in the ABI", fn.Sig)
+		fmt.Println(ui.Err(err.Error()))
+		return nil, err
+	}
+
 	spin := ui.NewSpinner(fmt.Sprintf("Calling %s()...", fn.Name))
 	spin.Start()
-
-	caller := contract.NewCallerFromEntries(rpcURL, entry.ABI)
-	results, err := caller.Call(entry.Address, fn.Name, inputs...)
+	values, err := studioCallValues(client, entry.Address, abiEntry, inputs)
 	spin.Stop()
 
 	if err != nil {
 		fmt.Println(ui.Err(err.Error()))
-		return
+		return nil, err
 	}
 
-	pairs := make([][2]string, 0, len(results)+2)
+	pairs := make([][2]string, 0, len(values)+2)
 	pairs = append(pairs, [2]string{"Contract", ui.Addr(entry.Address)})
 	pairs = append(pairs, [2]string{"Function", fn.Sig})
-	for i, r := range results {
+	var trees []contract.Value
+	for i, v := range values {
 		label := fmt.Sprintf("Result [%d]", i)
-		if i < len(fn.OutputTypes) {
+		if v.Name != "" {
+			label = v.Name
+		} else if i < len(fn.OutputTypes) {
 			label = fn.OutputTypes[i]
 		}
-		pairs = append(pairs, [2]string{label, ui.Val(r)})
+		if v.IsComposite() {
+			pairs = append(pairs, [2]string{label, ui.Meta(v.Type + " ↓")})
+			trees = append(trees, v)
+			continue
+		}
+		pairs = append(pairs, [2]string{label, ui.Val(v.Text)})
 	}
 
 	fmt.Println()
 	fmt.Println(ui.KeyValueBlock(fmt.Sprintf("%s() · %s (%s)", fn.Name, network, mode), pairs))
+	for _, v := range trees {
+		label := v.Name
+		if label == "" {
+			label = "result"
+		}
+		fmt.Println()
+		fmt.Println(ui.StyleHeader.Render(fmt.Sprintf("  ── %s (%s) ", label, v.Type)))
+		for _, line := range studioValueLines(v, "    ") {
+			fmt.Println(line)
+		}
+	}
+	return studioValueStrings(values), nil
 }
 
 // ── studio: write tx execution ────────────────────────────────────────────────
 
+// studioExecuteWrite previews, signs and sends a transaction. It returns the
+// hash ("" when nothing was sent), the ETH value, and the error when the
+// sent transaction failed.
 func studioExecuteWrite(
 	entry *contract.Entry,
 	fn *ui.StudioEntry,
@@ -733,17 +1026,11 @@ func studioExecuteWrite(
 	c *chainpkg.Chain,
 	w *wallet.Wallet,
 	contractName, mode string,
-) {
+) (txHash, value string, err error) {
 	warnIfNoSession()
 
 	// Find the matching ABIEntry for EncodeCalldata
-	var abiEntry contract.ABIEntry
-	for _, e := range entry.ABI {
-		if e.Type == "function" && e.Name == fn.Name {
-			abiEntry = e
-			break
-		}
-	}
+	abiEntry, _ := studioABIEntry(entry.ABI, fn.Selector)
 
 	calldataHex, calldataRaw, err := contract.EncodeCalldata(abiEntry, inputs)
 	if err != nil {
@@ -791,12 +1078,6 @@ func studioExecuteWrite(
 		return
 	}
 
-	nonce, err := client.GetPendingNonce(w.Address)
-	if err != nil {
-		fmt.Println(ui.Err("getting nonce: " + err.Error()))
-		return
-	}
-
 	// ── Preview ───────────────────────────────────────────────────────────
 	pairs := [][2]string{
 		{"Contract", ui.Addr(entry.Address)},
@@ -830,6 +1111,13 @@ func studioExecuteWrite(
 		return
 	}
 
+	lease, err := reserveNonce(client, chainID, w.Address)
+	if err != nil {
+		fmt.Println(ui.Err("getting nonce: " + err.Error()))
+		return
+	}
+	defer lease.Release()
+
 	// ── Sign + broadcast ──────────────────────────────────────────────────
 	contractAddr, err := parseAddress(entry.Address)
 	if err != nil {
@@ -839,7 +1127,7 @@ func studioExecuteWrite(
 
 	tx := types.NewTx(&types.DynamicFeeTx{
 		ChainID:   big.NewInt(chainID),
-		Nonce:     nonce,
+		Nonce:     lease.Nonce,
 		GasTipCap: gasPrice,
 		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 		Gas:       gasLimit,
@@ -865,6 +1153,8 @@ func studioExecuteWrite(
 		fmt.Println(ui.Err("broadcast: " + err.Error()))
 		return
 	}
+	lease.Used()
+	txHash, value = hash, valueDisplay
 
 	// ── Wait for receipt ──────────────────────────────────────────────────
 	spin = ui.NewSpinner(fmt.Sprintf("Waiting for %s() to be mined...", fn.Name))
@@ -888,6 +1178,7 @@ func studioExecuteWrite(
 		{"Explorer", explorer + "/tx/" + hash},
 	}))
 	ui.OpenURL(explorer + "/tx/" + hash)
+	return txHash, value, nil
 }
 
 // ── contract deploy ────────────────────────────────────────────────────────────
@@ -902,10 +1193,23 @@ After deployment the contract is auto-registered so "contract studio" works imme
 
 Constructor args can be supplied with --args (comma-separated) or interactively.
 
+With --create2 --salt the contract is deployed through a CREATE2 factory (see
+"w3cli create2"), so the same artifact, args and salt give the same address on
+every chain. Constructors then see the factory as msg.sender.
+
+With --networks (or --all-testnets) the contract is deployed to several chains
+concurrently, each with its own nonce and fees, behind a live progress table.
+Every address is registered under the same name and written to a sync
+manifest (--manifest, default deployments.json) the team can "w3cli sync" from.
+
 Examples:
   w3cli contract deploy MyNFT ./artifacts/MyNFT.json --network base --wallet deployer
   w3cli contract deploy Token ./out/Token.sol/Token.json --args "MyToken,MTK,18,1000000"
-  w3cli contract deploy Vault ./artifacts/Vault.json --network sepolia`,
+  w3cli contract deploy Vault ./artifacts/Vault.json --network sepolia
+  w3cli contract deploy Vault ./out/Vault.sol/Vault.json --network base --verify
+  w3cli contract deploy Vault ./out/Vault.sol/Vault.json --create2 --salt vault-v1
+  w3cli contract deploy Vault ./out/Vault.sol/Vault.json --networks base,optimism,arbitrum --create2 --salt vault-v1
+  w3cli contract deploy Vault ./out/Vault.sol/Vault.json --all-testnets`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contractName := args[0]
@@ -917,6 +1221,15 @@ Examples:
 			return err
 		}
 
+		// Load the compiler input now so --verify fails before spending gas.
+		var verifySrc *contract.VerifySource
+		if contractDeployVerify {
+			verifySrc, err = contract.LoadVerifySource(artifactPath)
+			if err != nil {
+				return fmt.Errorf("--verify: %w", err)
+			}
+		}
+
 		// ── 2. Find constructor in ABI ─────────────────────────────────────
 		var constructor *contract.ABIEntry
 		for i := range artifact.ABI {
@@ -973,7 +1286,9 @@ Examples:
 		copy(deployData, artifact.Bytecode)
 		copy(deployData[len(artifact.Bytecode):], encodedArgs)
 
-		deployHex := "0x" + hex.EncodeToString(deployData)
+		if contractDeployNetworks != "" || contractDeployAllTestnets {
+			return runMultiDeploy(contractName, artifactPath, artifact, deployData, encodedArgs, verifySrc)
+		}
 
 		// ── 6. Resolve chain + wallet ──────────────────────────────────────
 		chainName := contractNetwork
@@ -1024,23 +1339,41 @@ Examples:
 			return err
 		}
 
-		gasLimit := contractDeployGas
-		if gasLimit == 0 {
-			gasLimit, err = client.EstimateGas(w.Address, "", deployHex, valueBig)
-			if err != nil {
-				gasLimit = config.GasLimitContractDeploy
-			}
-		}
-
 		chainID, err := client.ChainID()
 		if err != nil {
 			spin.Stop()
 			return err
 		}
-		nonce, err := client.GetPendingNonce(w.Address)
-		if err != nil {
-			spin.Stop()
-			return err
+
+		// CREATE2 goes through a factory: the tx calls it with salt + init code.
+		var plan *create2Plan
+		var txTo *common.Address
+		txData := deployData
+		if contractDeployCreate2 {
+			plan, err = planCreate2(client, c, chainID, w.Address, deployData, nil)
+			if err != nil {
+				spin.Stop()
+				return err
+			}
+			if plan.Exists {
+				spin.Stop()
+				fmt.Println(ui.Info(fmt.Sprintf("Already deployed at %s on %s — nothing to do.", plan.Address.Hex(), c.DisplayName)))
+				fmt.Println(ui.Hint(fmt.Sprintf("Register it: w3cli contract add %s %s --abi %s --network %s", contractName, plan.Address.Hex(), artifactPath, chainName)))
+				return nil
+			}
+			txTo, txData = &plan.Factory.Address, plan.Data
+		}
+
+		gasLimit := contractDeployGas
+		if gasLimit == 0 {
+			to := ""
+			if txTo != nil {
+				to = txTo.Hex()
+			}
+			gasLimit, err = client.EstimateGas(w.Address, to, "0x"+hex.EncodeToString(txData), valueBig)
+			if err != nil {
+				gasLimit = config.GasLimitContractDeploy
+			}
 		}
 		spin.Stop()
 
@@ -1065,6 +1398,9 @@ Examples:
 		if contractDeployValue != "" {
 			pairs = append(pairs, [2]string{"Value", contractDeployValue + " ETH"})
 		}
+		if plan != nil {
+			pairs = append(pairs, plan.pairs()...)
+		}
 
 		pairs = append(pairs,
 			[2]string{"Gas Limit", fmt.Sprintf("%d", gasLimit)},
@@ -1081,19 +1417,25 @@ Examples:
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		// ── 10. Sign + broadcast ───────────────────────────────────────────
 		spin = ui.NewSpinner("Deploying contract...")
 		spin.Start()
 
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
-			To:        nil, // contract creation
+			To:        txTo, // nil = contract creation
 			Value:     valueBig,
-			Data:      deployData,
+			Data:      txData,
 		})
 
 		ks := wallet.DefaultKeystore()
@@ -1109,6 +1451,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		// ── 11. Wait for receipt ───────────────────────────────────────────
 		spin = ui.NewSpinner("Waiting for deployment confirmation...")
@@ -1118,34 +1461,48 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("deploy tx %s: %w", hash, err)
 		}
+		contractAddr := receipt.ContractAddress
+		if plan != nil {
+			contractAddr = plan.Address.Hex()
+			if code, err := client.GetCode(contractAddr); err != nil || !hasCode(code) {
+				return fmt.Errorf("factory call %s succeeded but no code at %s — the constructor probably reverted", hash, contractAddr)
+			}
+		}
 
 		// ── 12. Show result ────────────────────────────────────────────────
 		explorer := c.Explorer(cfg.NetworkMode)
 		fmt.Println()
 		fmt.Println(ui.KeyValueBlock("Contract Deployed ✓", [][2]string{
-			{"Contract", ui.Addr(receipt.ContractAddress)},
+			{"Contract", ui.Addr(contractAddr)},
 			{"Tx Hash", ui.Addr(hash)},
 			{"Block", fmt.Sprintf("%d", receipt.BlockNumber)},
 			{"Gas Used", fmt.Sprintf("%d", receipt.GasUsed)},
 			{"Name", contractName},
 			{"Deployer", ui.Addr(w.Address)},
-			{"Explorer", explorer + "/address/" + receipt.ContractAddress},
+			{"Explorer", explorer + "/address/" + contractAddr},
 		}))
 
 		// ── 13. Auto-register in contract registry ─────────────────────────
 		contractReg := newContractRegistry()
 		if loadErr := contractReg.Load(); loadErr == nil {
-			contractReg.Add(&contract.Entry{
+			entry := &contract.Entry{
 				Name:       contractName,
 				Network:    chainName,
-				Address:    receipt.ContractAddress,
+				Address:    contractAddr,
 				ABI:        artifact.ABI,
 				Kind:       "deployed",
 				ABISource:  artifactPath,
 				Deployer:   w.Address,
 				TxHash:     hash,
 				DeployedAt: time.Now().UTC().Format(time.RFC3339),
-			})
+
+				ConstructorArgs: hex.EncodeToString(encodedArgs),
+			}
+			if plan != nil {
+				entry.Salt = "0x" + hex.EncodeToString(plan.Salt[:])
+				entry.Factory = plan.Factory.Address.Hex()
+			}
+			contractReg.Add(entry)
 			if saveErr := contractReg.Save(); saveErr == nil {
 				fmt.Println(ui.Success(fmt.Sprintf(
 					"Registered in contract studio as %q — use: w3cli contract studio %s",
@@ -1153,9 +1510,17 @@ Examples:
 			}
 		}
 
+		// ── 14. Verify source ──────────────────────────────────────────────
+		if verifySrc != nil {
+			if err := verifyOnExplorer(c, chainName, contractAddr, verifySrc, hex.EncodeToString(encodedArgs)); err != nil {
+				fmt.Println(ui.Warn("Verification failed: " + err.Error()))
+				fmt.Println(ui.Hint(fmt.Sprintf("Retry: w3cli contract verify %s --network %s", contractName, chainName)))
+			}
+		}
+
 		fmt.Println(ui.Hint(fmt.Sprintf(
 			"Interact: w3cli contract studio %s --network %s", contractName, chainName)))
-		ui.OpenURL(explorer + "/address/" + receipt.ContractAddress)
+		ui.OpenURL(explorer + "/address/" + contractAddr)
 		return nil
 	},
 }
@@ -1166,7 +1531,8 @@ func init() {
 	// add
 	contractAddCmd.Flags().StringVar(&contractABIFile, "abi", "", "path to ABI JSON file or Hardhat/Foundry artifact")
 	contractAddCmd.Flags().StringVar(&contractBuiltin, "builtin", "", "use a bundled ABI (see: w3cli contract builtins)")
-	contractAddCmd.Flags().BoolVar(&contractFetchABI, "fetch", false, "auto-fetch ABI from explorer")
+	contractAddCmd.Flags().BoolVar(&contractFetchABI, "fetch", false, "auto-fetch ABI from explorer (Sourcify fallback)")
+	contractAddCmd.Flags().BoolVar(&contractFromBytecode, "from-bytecode", false, "recover a best-effort ABI from unverified bytecode")
 	contractAddCmd.Flags().StringVar(&contractNetwork, "network", "", "chain (default: config)")
 
 	// import
@@ -1182,6 +1548,7 @@ func init() {
 
 	// sync
 	contractSyncCmd.Flags().Bool("all", false, "sync all contracts")
+	contractSyncCmd.Flags().StringVar(&contractNetwork, "network", "", "chain (default: config)")
 
 	// remove
 	contractRemoveCmd.Flags().StringVar(&contractNetwork, "network", "", "chain (default: config)")
@@ -1192,10 +1559,16 @@ func init() {
 	contractDeployCmd.Flags().Uint64Var(&contractDeployGas, "gas", 0, "gas limit override (0 = auto-estimate)")
 	contractDeployCmd.Flags().StringVar(&contractDeployWallet, "wallet", "", "signing wallet (default: config)")
 	contractDeployCmd.Flags().StringVar(&contractNetwork, "network", "", "chain (default: config)")
+	contractDeployCmd.Flags().BoolVar(&contractDeployVerify, "verify", false, "verify the source on the block explorer after deploy")
+	contractDeployCmd.Flags().BoolVar(&verifyEtherscan, "etherscan", false, "with --verify: use Etherscan V2 instead of BlockScout")
+	contractDeployCmd.Flags().BoolVar(&contractDeployCreate2, "create2", false, "deploy through a CREATE2 factory (same address on every chain)")
+	contractDeployCmd.Flags().StringVar(&create2Salt, "salt", "", "with --create2: salt (0x-hex, decimal or text)")
+	contractDeployCmd.Flags().StringVar(&create2Factory, "factory", "", "with --create2: deterministic (default) or createx")
 
 	contractCmd.AddCommand(
 		contractAddCmd,
 		contractImportCmd,
+		contractImportDeploymentsCmd,
 		contractBuiltinsCmd,
 		contractListCmd,
 		contractRemoveCmd,
@@ -1203,6 +1576,7 @@ func init() {
 		contractSyncCmd,
 		contractStudioCmd,
 		contractDeployCmd,
+		contractVerifyCmd,
 	)
 }
 
@@ -1266,7 +1640,7 @@ func abiToStudioEntries(abi []contract.ABIEntry, decimals int) []ui.StudioEntry
 			isAmt := isTokenFunc && p.Type == "uint256" &&
 				(p.Name == "value" || p.Name == "amount" || p.Name == "wad")
 
-			example := abiTypeExample(p.Type)
+			example := abiTypeExample(p.CanonicalType())
 			if isAmt && decimals >= 0 {
 				one := new(big.Float).SetPrec(256).SetFloat64(1.0)
 				example = fmt.Sprintf("1  or  0.5  (human units, scaled ×10^%d = %s raw per unit)",
@@ -1276,7 +1650,7 @@ func abiToStudioEntries(abi []contract.ABIEntry, decimals int) []ui.StudioEntry
 
 			params[i] = ui.StudioParam{
 				Name:          p.Name,
-				Type:          p.Type,
+				Type:          p.CanonicalType(),
 				Example:       example,
 				IsTokenAmount: isAmt && decimals >= 0,
 				Decimals:      decimals,
@@ -1289,11 +1663,7 @@ func abiToStudioEntries(abi []contract.ABIEntry, decimals int) []ui.StudioEntry
 		}
 
 		// Build canonical signature
-		inputTypes := make([]string, len(e.Inputs))
-		for i, p := range e.Inputs {
-			inputTypes[i] = p.Type
-		}
-		sig := e.Name + "(" + strings.Join(inputTypes, ",") + ")"
+		sig := e.Signature()
 
 		entries = append(entries, ui.StudioEntry{
 			Name:        e.Name,
@@ -1313,6 +1683,10 @@ func abiToStudioEntries(abi []contract.ABIEntry, decimals int) []ui.StudioEntry
 // abiTypeExample returns a human-friendly example value for an ABI type.
 func abiTypeExample(typ string) string {
 	switch {
+	case strings.HasSuffix(typ, "]"):
+		return `["…", "…"]  (JSON array)`
+	case strings.HasPrefix(typ, "("):
+		return `["…", 1]  (JSON array in member order)`
 	case typ == "address":
 		return "0xAbCd1234...EF56  (42 hex chars, 0x prefix)"
 	case typ == "uint256":
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+)
+
+// bytecodeReport is the analysis of one contract's runtime code.
+type bytecodeReport struct {
+	Label    string // "Contract" or "Implementation"
+	Address  string
+	Analysis *contract.BytecodeAnalysis
+}
+
+// recoverContractABI recovers a best-effort ABI from the code at address.
+// For a proxy the implementation is analyzed too and its functions merged
+// with the proxy's own, as with fetched ABIs.
+func recoverContractABI(c *chainpkg.Chain, address string, proxy *contract.ProxyInfo, reg *contract.Registry) ([]contract.ABIEntry, []bytecodeReport, error) {
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	client := chainpkg.NewEVMClient(rpcURL)
+	db := newSignatureDB(reg)
+
+	targets := []bytecodeReport{{Label: "Contract", Address: address}}
+	if proxy != nil && proxy.Implementation != "" {
+		targets = append(targets, bytecodeReport{Label: "Implementation", Address: proxy.Implementation})
+	}
+	var abi []contract.ABIEntry
+	for i := len(targets) - 1; i >= 0; i-- {
+		t := &targets[i]
+		hexCode, err := client.GetCode(t.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("querying code at %s: %w", t.Address, err)
+		}
+		code, err := contract.DecodeCode(hexCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(code) == 0 {
+			return nil, nil, fmt.Errorf("%s has no code — nothing to analyze", t.Address)
+		}
+		t.Analysis = contract.AnalyzeBytecode(code, db)
+		abi = contract.MergeABI(abi, t.Analysis.ABI())
+	}
+	if len(abi) == 0 {
+		return nil, targets, fmt.Errorf("no known function selectors in the bytecode — provide an ABI with --abi <file.json>")
+	}
+	return abi, targets, nil
+}
+
+// newSignatureDB returns the signature database extended with the ABIs of
+// registered contracts, so previously added contracts name selectors too.
+func newSignatureDB(reg *contract.Registry) *contract.SignatureDB {
+	db := contract.NewSignatureDB()
+	if reg != nil {
+		for _, e := range reg.All() {
+			if e.Kind != "bytecode" {
+				db.Add(e.ABI)
+			}
+		}
+	}
+	return db
+}
+
+// printBytecodeReports renders recovered functions, one table per contract.
+func printBytecodeReports(reports []bytecodeReport) {
+	for _, r := range reports {
+		if r.Analysis == nil {
+			continue
+		}
+		fmt.Printf("\n  %s %s · %d bytes · %d selectors\n", r.Label, ui.Addr(r.Address), r.Analysis.Size, len(r.Analysis.Functions))
+		if len(r.Analysis.Functions) == 0 {
+			continue
+		}
+		t := ui.NewTable([]ui.Column{
+			{Title: "Selector", Width: 12},
+			{Title: "Function", Width: 44},
+			{Title: "Mutability", Width: 12},
+		})
+		for _, f := range r.Analysis.Functions {
+			sig := f.Signature()
+			if sig == "" {
+				sig = ui.Meta("unknown")
+			}
+			mut := f.Mutability
+			if mut == "" {
+				mut = "?"
+			}
+			t.AddRow(ui.Row{f.Selector, sig, mut})
+		}
+		fmt.Println(t.Render())
+		if unknown := r.Analysis.Unknown(); len(unknown) > 0 {
+			fmt.Println(ui.Hint(fmt.Sprintf("%d unknown selector(s) left out: %s", len(unknown), strings.Join(unknown, ", "))))
+		}
+	}
+}
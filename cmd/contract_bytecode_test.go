@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSignatureDBIncludesRegistry(t *testing.T) {
+	harvest := contract.ABIEntry{Type: "function", Name: "harvest", Inputs: []contract.ABIParam{{Name: "pool", Type: "uint256"}}}
+	guess := contract.ABIEntry{Type: "function", Name: "sweep", Inputs: []contract.ABIParam{{Type: "address"}}}
+
+	reg := contract.NewRegistry(filepath.Join(t.TempDir(), "contracts.json"))
+	reg.Add(&contract.Entry{Name: "vault", Network: "base", Kind: "imported", ABI: []contract.ABIEntry{harvest}})
+	reg.Add(&contract.Entry{Name: "mystery", Network: "base", Kind: "bytecode", ABI: []contract.ABIEntry{guess}})
+
+	db := newSignatureDB(reg)
+	_, ok := db.Lookup(harvest.Selector())
+	assert.True(t, ok, "registered ABIs name selectors")
+	_, ok = db.Lookup(guess.Selector())
+	assert.False(t, ok, "recovered ABIs are not fed back in")
+
+	_, ok = newSignatureDB(nil).Lookup("0xa9059cbb")
+	assert.True(t, ok)
+}
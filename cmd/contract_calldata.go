@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+)
+
+// ── studio: calldata only ─────────────────────────────────────────────────────
+
+// studioABIEntry returns the ABI function behind a studio entry, matched by
+// selector so overloads resolve to the right one.
+func studioABIEntry(abi []contract.ABIEntry, selector string) (contract.ABIEntry, bool) {
+	for _, e := range abi {
+		if e.Type == "function" && e.Selector() == selector {
+			return e, true
+		}
+	}
+	return contract.ABIEntry{}, false
+}
+
+// studioBuildCalldata encodes a call without sending it and copies the
+// calldata to the clipboard, to paste into a Safe, Tenderly or another signer.
+func studioBuildCalldata(entry *contract.Entry, fn *ui.StudioEntry, inputs []string, network string) {
+	abiEntry, ok := studioABIEntry(entry.ABI, fn.Selector)
+	if !ok {
+		fmt.Println(ui.Err(fmt.Sprintf("%s not found in the ABI", fn.Sig)))
+		return
+	}
+	_, calldata, err := contract.EncodeCalldata(abiEntry, inputs)
+	if err != nil {
+		fmt.Println(ui.Err("encoding calldata: " + err.Error()))
+		return
+	}
+	calldataHex := "0x" + hex.EncodeToString(calldata)
+
+	pairs := [][2]string{
+		{"To", ui.Addr(entry.Address)},
+		{"Network", network},
+		{"Function", fn.Sig},
+		{"Selector", fn.Selector},
+	}
+	for i, p := range fn.Inputs {
+		lbl := p.Name
+		if lbl == "" {
+			lbl = fmt.Sprintf("arg%d", i)
+		}
+		if i < len(inputs) {
+			pairs = append(pairs, [2]string{lbl, inputs[i]})
+		}
+	}
+	pairs = append(pairs,
+		[2]string{"Calldata", ui.Val(calldataHex)},
+		[2]string{"Bytes", fmt.Sprintf("%d", len(calldata))},
+	)
+	fmt.Println()
+	fmt.Println(ui.KeyValueBlock(fmt.Sprintf("%s() · calldata", fn.Name), pairs))
+
+	if err := ui.CopyToClipboard(calldataHex); err == nil {
+		fmt.Println(ui.Success("Calldata copied to clipboard"))
+	} else {
+		fmt.Println(ui.Hint("No clipboard available — copy the calldata above."))
+	}
+	if fn.IsPayable {
+		fmt.Println(ui.Hint("This function is payable — set the ETH value in the signer."))
+	}
+}
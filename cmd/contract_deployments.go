@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var contractImportDryRun bool
+
+// ── contract import-deployments ───────────────────────────────────────────────
+
+var contractImportDeploymentsCmd = &cobra.Command{
+	Use:   "import-deployments <dir>",
+	Short: "Register every contract from hardhat-deploy, Foundry or Ignition records",
+	Long: `Scan a project for deployment records and register every contract with its
+ABI and deploy transaction hash.
+
+Detected formats:
+  • hardhat-deploy:   deployments/<network>/<Name>.json (+ .chainId)
+  • Foundry:          broadcast/<Script>.s.sol/<chainId>/run-latest.json (ABIs from out/)
+  • Hardhat Ignition: ignition/deployments/chain-<id>/deployed_addresses.json
+
+Chain IDs are mapped to w3cli networks (mainnet and testnet IDs). Records for
+unknown chains (e.g. a local node) are skipped unless --network says where
+they belong. The same contract deployed twice on one chain is registered as
+Name, Name_2, ...
+
+Examples:
+  w3cli contract import-deployments .
+  w3cli contract import-deployments ./contracts --dry-run
+  w3cli contract import-deployments ./broadcast --network base`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, warnings, err := contract.ScanDeployments(args[0])
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings {
+			fmt.Println(ui.Warn(w))
+		}
+		if len(deps) == 0 {
+			fmt.Println(ui.Info("No deployment records found."))
+			fmt.Println(ui.Hint("Looked for deployments/, broadcast/ and ignition/deployments/ under " + args[0]))
+			return nil
+		}
+
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		chains := newChainRegistry()
+
+		t := ui.NewTable([]ui.Column{
+			{Title: "Contract", Width: 20},
+			{Title: "Network", Width: 18},
+			{Title: "Address", Width: 44},
+			{Title: "ABI", Width: 5},
+			{Title: "Format", Width: 14},
+			{Title: "Status", Width: 10},
+		})
+		imported, skipped := 0, 0
+		for _, d := range deps {
+			network, mode, ok := deploymentNetwork(chains, d, contractNetwork)
+			if !ok {
+				skipped++
+				fmt.Println(ui.Warn(fmt.Sprintf("Skipping %s at %s: unknown chain %s — pass --network", d.Name, d.Address, deploymentChainLabel(d))))
+				continue
+			}
+
+			status := "new"
+			if existing, err := reg.Get(d.Name, network); err == nil {
+				status = "updated"
+				if strings.EqualFold(existing.Address, d.Address) && existing.TxHash == d.TxHash {
+					status = "unchanged"
+				}
+			}
+			abi := "—"
+			if len(d.ABI) > 0 {
+				abi = fmt.Sprintf("%d", countFunctions(d.ABI))
+			}
+			netLabel := network
+			if mode == "testnet" {
+				netLabel += " (testnet)"
+			}
+			t.AddRow(ui.Row{ui.Val(d.Name), netLabel, ui.Addr(d.Address), abi, d.Format, status})
+			imported++
+
+			if contractImportDryRun {
+				continue
+			}
+			source := d.Source
+			if d.Artifact != "" {
+				source = d.Artifact
+			}
+			reg.Add(&contract.Entry{
+				Name:       d.Name,
+				Network:    network,
+				Address:    d.Address,
+				ABI:        d.ABI,
+				Kind:       "deployed",
+				ABISource:  source,
+				Deployer:   d.Deployer,
+				TxHash:     d.TxHash,
+				DeployedAt: d.DeployedAt,
+			})
+		}
+		if imported > 0 {
+			fmt.Println(t.Render())
+		}
+
+		if contractImportDryRun {
+			fmt.Println(ui.Hint(fmt.Sprintf("Dry run — %d contract(s) would be registered, %d skipped.", imported, skipped)))
+			return nil
+		}
+		if err := reg.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Registered %d contract(s), skipped %d", imported, skipped)))
+		if imported > 0 {
+			fmt.Println(ui.Hint("Run `w3cli contract list` to see them."))
+		}
+		return nil
+	},
+}
+
+// hardhatNetworkAliases maps common hardhat network names (used as
+// hardhat-deploy folder names) to w3cli chains, for records without .chainId.
+var hardhatNetworkAliases = map[string][2]string{
+	"mainnet":              {"ethereum", "mainnet"},
+	"sepolia":              {"ethereum", "testnet"},
+	"bsc":                  {"bnb", "mainnet"},
+	"bsctestnet":           {"bnb", "testnet"},
+	"arbitrumone":          {"arbitrum", "mainnet"},
+	"arbitrumsepolia":      {"arbitrum", "testnet"},
+	"basesepolia":          {"base", "testnet"},
+	"optimisticethereum":   {"optimism", "mainnet"},
+	"optimismsepolia":      {"optimism", "testnet"},
+	"matic":                {"polygon", "mainnet"},
+	"polygonamoy":          {"polygon", "testnet"},
+	"avax":                 {"avalanche", "mainnet"},
+	"avalanchefujitestnet": {"avalanche", "testnet"},
+	"xdai":                 {"gnosis", "mainnet"},
+}
+
+// deploymentNetwork maps a deployment to a w3cli network and mode: by chain
+// ID, else by hardhat-deploy folder name, else the --network fallback.
+func deploymentNetwork(reg *chainpkg.Registry, d contract.Deployment, fallback string) (string, string, bool) {
+	if d.ChainID != 0 {
+		if c, err := reg.GetByChainID(d.ChainID); err == nil {
+			return c.Name, c.ModeOf(d.ChainID), true
+		}
+	} else if d.Network != "" {
+		if c, err := reg.GetByName(d.Network); err == nil {
+			return c.Name, "mainnet", true
+		}
+		if a, ok := hardhatNetworkAliases[strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(d.Network))]; ok {
+			return a[0], a[1], true
+		}
+	}
+	if fallback != "" {
+		return fallback, "", true
+	}
+	return "", "", false
+}
+
+func deploymentChainLabel(d contract.Deployment) string {
+	if d.ChainID != 0 {
+		return fmt.Sprintf("%d", d.ChainID)
+	}
+	return fmt.Sprintf("%q", d.Network)
+}
+
+func init() {
+	contractImportDeploymentsCmd.Flags().StringVar(&contractNetwork, "network", "", "network for records whose chain is unknown")
+	contractImportDeploymentsCmd.Flags().BoolVar(&contractImportDryRun, "dry-run", false, "show what would be registered without saving")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentNetwork(t *testing.T) {
+	reg := chain.NewRegistry()
+	tests := []struct {
+		name     string
+		dep      contract.Deployment
+		fallback string
+		network  string
+		mode     string
+		ok       bool
+	}{
+		{"mainnet chain id", contract.Deployment{ChainID: 8453}, "", "base", "mainnet", true},
+		{"testnet chain id", contract.Deployment{ChainID: 11155111}, "", "ethereum", "testnet", true},
+		{"folder is a w3cli name", contract.Deployment{Network: "polygon"}, "", "polygon", "mainnet", true},
+		{"hardhat alias", contract.Deployment{Network: "arbitrumSepolia"}, "", "arbitrum", "testnet", true},
+		{"unknown chain id", contract.Deployment{ChainID: 31337}, "", "", "", false},
+		{"unknown chain id with fallback", contract.Deployment{ChainID: 31337}, "base", "base", "", true},
+		{"unknown folder", contract.Deployment{Network: "localhost"}, "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, mode, ok := deploymentNetwork(reg, tt.dep, tt.fallback)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.network, network)
+			assert.Equal(t, tt.mode, mode)
+		})
+	}
+}
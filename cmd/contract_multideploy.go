@@ -0,0 +1,436 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/create2"
+	csync "github.com/Mohsinsiddi/w3cli/internal/sync"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	contractDeployNetworks    string // --networks (comma-separated chains)
+	contractDeployAllTestnets bool   // --all-testnets
+	contractDeployManifest    string // --manifest (sync manifest to update)
+)
+
+// multiDeployJob is one chain's deployment in a multi-chain deploy.
+type multiDeployJob struct {
+	Chain  chainpkg.Chain
+	Result ui.MultiDeployResult
+	Plan   *create2Plan // set for --create2
+}
+
+// deployChains resolves --networks / --all-testnets to the EVM chains to
+// deploy to. --all-testnets picks every EVM chain with a testnet RPC.
+func deployChains(reg *chainpkg.Registry, networks string, allTestnets bool) ([]chainpkg.Chain, error) {
+	if allTestnets {
+		if networks != "" {
+			return nil, fmt.Errorf("use either --networks or --all-testnets, not both")
+		}
+		var out []chainpkg.Chain
+		for _, c := range reg.All() {
+			if c.Type == chainpkg.ChainTypeEVM && len(c.RPCs("testnet")) > 0 {
+				out = append(out, c)
+			}
+		}
+		return out, nil
+	}
+
+	var out []chainpkg.Chain
+	seen := map[string]bool{}
+	for _, name := range strings.Split(networks, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		c, err := reg.GetByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown chain %q — run `w3cli network list`", name)
+		}
+		if c.Type != chainpkg.ChainTypeEVM {
+			return nil, fmt.Errorf("%s is not an EVM chain", c.DisplayName)
+		}
+		seen[name] = true
+		out = append(out, *c)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("--networks needs at least one chain, e.g. --networks base,optimism")
+	}
+	return out, nil
+}
+
+// manifestAddresses maps network → address for the chains that ended up
+// with the contract (deployed now or already there via CREATE2).
+func manifestAddresses(jobs []*multiDeployJob) map[string]string {
+	out := map[string]string{}
+	for _, j := range jobs {
+		if j.Result.Err == nil && j.Result.Address != "" {
+			out[j.Chain.Name] = j.Result.Address
+		}
+	}
+	return out
+}
+
+// runMultiDeploy deploys initCode to several chains concurrently, with a
+// live progress table, then registers every address under name and writes
+// the sync manifest.
+func runMultiDeploy(name, artifactPath string, artifact *contract.ArtifactFull, initCode, encodedArgs []byte, verifySrc *contract.VerifySource) error {
+	if contractNetwork != "" {
+		return fmt.Errorf("--network cannot be combined with --networks or --all-testnets")
+	}
+	if contractDeployAllTestnets {
+		if mainnet {
+			return fmt.Errorf("--all-testnets cannot be combined with --mainnet")
+		}
+		cfg.NetworkMode = "testnet"
+	}
+	mode := cfg.NetworkMode
+
+	chains, err := deployChains(chainpkg.NewRegistry(), contractDeployNetworks, contractDeployAllTestnets)
+	if err != nil {
+		return err
+	}
+
+	walletName := contractDeployWallet
+	if walletName == "" {
+		walletName = cfg.DefaultWallet
+	}
+	w, _, err := loadSigningWallet(walletName)
+	if err != nil {
+		return err
+	}
+	warnIfNoSession()
+
+	value := big.NewInt(0)
+	if contractDeployValue != "" {
+		if value, err = ethToWei(contractDeployValue); err != nil {
+			return fmt.Errorf("invalid --value %q: %w", contractDeployValue, err)
+		}
+	}
+
+	// ── Preview ────────────────────────────────────────────────────────
+	names := make([]string, len(chains))
+	for i, c := range chains {
+		names[i] = c.DisplayName
+	}
+	pairs := [][2]string{
+		{"Deployer", ui.Addr(w.Address)},
+		{"Contract", name},
+		{"Artifact", artifactPath},
+		{"Chains", fmt.Sprintf("%d · %s", len(chains), strings.Join(names, ", "))},
+	}
+	if contractDeployValue != "" {
+		pairs = append(pairs, [2]string{"Value", contractDeployValue + " ETH (per chain)"})
+	}
+	if contractDeployCreate2 {
+		factory, err := create2.FactoryByName(create2Factory)
+		if err != nil {
+			return err
+		}
+		salt, err := create2.ParseSalt(create2Salt)
+		if err != nil {
+			return fmt.Errorf("--create2: %w", err)
+		}
+		sender := common.HexToAddress(w.Address)
+		if factory.ChainBound(salt, sender) {
+			pairs = append(pairs, [2]string{"CREATE2 Address", ui.Warn("chain-bound salt — differs per chain")})
+		} else {
+			addr, err := factory.Predict(salt, initCode, sender, 0)
+			if err != nil {
+				return err
+			}
+			pairs = append(pairs, [2]string{"CREATE2 Address", ui.Addr(addr.Hex()) + ui.Meta(" (every chain)")})
+		}
+		pairs = append(pairs, [2]string{"Factory", fmt.Sprintf("%s (%s)", factory.Name, factory.Address.Hex())})
+	} else {
+		pairs = append(pairs, [2]string{"Addresses", ui.Meta("nonce-based — use --create2 for the same address everywhere")})
+	}
+	gas := "estimated per chain"
+	if contractDeployGas != 0 {
+		gas = fmt.Sprintf("%d", contractDeployGas)
+	}
+	pairs = append(pairs,
+		[2]string{"Gas Limit", gas},
+		[2]string{"Manifest", contractDeployManifest},
+	)
+	fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Multi-Chain Deploy Preview · %s", mode), pairs))
+
+	if !ui.Confirm(fmt.Sprintf("Deploy to %d chains?", len(chains))) {
+		fmt.Println(ui.Meta("Cancelled."))
+		return nil
+	}
+
+	// ── Deploy with live progress ──────────────────────────────────────
+	jobs := make([]*multiDeployJob, len(chains))
+	rows := make([]ui.MultiDeployRow, len(chains))
+	rowIndex := make(map[string]int, len(chains))
+	for i, c := range chains {
+		jobs[i] = &multiDeployJob{Chain: c}
+		rows[i] = ui.MultiDeployRow{ChainName: c.Name, DisplayName: c.DisplayName}
+		rowIndex[c.Name] = i
+	}
+	m := ui.MultiDeployModel{Name: name, Mode: mode, Rows: rows, RowIndex: rowIndex, Total: len(chains)}
+	prog := tea.NewProgram(m, tea.WithInput(os.Stdin), tea.WithOutput(os.Stdout))
+
+	// Quitting the TUI stops chains that have not broadcast yet; sent
+	// transactions are still awaited so they get registered.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		j := j
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stage := func(s string) { prog.Send(ui.MultiDeployStageMsg{ChainName: j.Chain.Name, Stage: s}) }
+			j.Result, j.Plan = deployToChain(ctx, &j.Chain, mode, w, initCode, value, stage)
+			prog.Send(ui.MultiDeployResultMsg(j.Result))
+		}()
+	}
+
+	final, err := prog.Run()
+	cancel()
+	if err != nil {
+		return err
+	}
+	if md, ok := final.(ui.MultiDeployModel); ok && md.Done < md.Total {
+		spin := ui.NewSpinner("Waiting for transactions already sent...")
+		spin.Start()
+		wg.Wait()
+		spin.Stop()
+	}
+	wg.Wait() // results are written just before they are sent
+
+	// ── Summary ────────────────────────────────────────────────────────
+	t := ui.NewTable([]ui.Column{
+		{Title: "Chain", Width: 16},
+		{Title: "Address", Width: 42},
+		{Title: "Result", Width: 36},
+	})
+	for _, j := range jobs {
+		r := j.Result
+		switch {
+		case r.Err != nil:
+			t.AddRow(ui.Row{j.Chain.DisplayName, "—", ui.Err(trimDeployErr(r.Err.Error()))})
+		case r.Skipped:
+			t.AddRow(ui.Row{j.Chain.DisplayName, r.Address, ui.Info("already deployed")})
+		default:
+			t.AddRow(ui.Row{j.Chain.DisplayName, r.Address, ui.Success(fmt.Sprintf("gas %d · %s", r.GasUsed, r.Elapsed.Truncate(time.Second)))})
+		}
+	}
+	fmt.Println(ui.StyleTitle.Render(fmt.Sprintf("  %s · %s", name, mode)))
+	fmt.Println(t.Render())
+
+	addresses := manifestAddresses(jobs)
+	if len(addresses) == 0 {
+		return fmt.Errorf("no chain was deployed")
+	}
+
+	// ── Register every address under the same name ─────────────────────
+	contractReg := newContractRegistry()
+	if err := contractReg.Load(); err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, j := range jobs {
+		if j.Result.Err != nil || j.Result.Address == "" {
+			continue
+		}
+		entry := &contract.Entry{
+			Name:       name,
+			Network:    j.Chain.Name,
+			Address:    j.Result.Address,
+			ABI:        artifact.ABI,
+			Kind:       "deployed",
+			ABISource:  artifactPath,
+			Deployer:   w.Address,
+			TxHash:     j.Result.TxHash,
+			DeployedAt: now,
+
+			ConstructorArgs: hex.EncodeToString(encodedArgs),
+		}
+		if j.Plan != nil {
+			entry.Salt = "0x" + hex.EncodeToString(j.Plan.Salt[:])
+			entry.Factory = j.Plan.Factory.Address.Hex()
+		}
+		contractReg.Add(entry)
+	}
+	if err := contractReg.Save(); err != nil {
+		return err
+	}
+	fmt.Println(ui.Success(fmt.Sprintf("Registered %q on %d chains", name, len(addresses))))
+
+	// ── Team manifest ──────────────────────────────────────────────────
+	abiJSON, err := json.MarshalIndent(artifact.ABI, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifest, err := csync.UpdateManifest(contractDeployManifest, name, abiJSON, addresses)
+	if err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	fmt.Println(ui.Success(fmt.Sprintf("Manifest %s updated (version %d)", contractDeployManifest, manifest.Version)))
+	if _, err := os.Stat(contractDeployManifest + ".sig"); err == nil {
+		fmt.Println(ui.Warn(fmt.Sprintf("%s.sig is now stale — re-sign: w3cli sync sign %s", contractDeployManifest, contractDeployManifest)))
+	}
+
+	// ── Verify source ──────────────────────────────────────────────────
+	if verifySrc != nil {
+		for _, j := range jobs {
+			if j.Result.Err != nil || j.Result.Skipped {
+				continue
+			}
+			if err := verifyOnExplorer(&j.Chain, j.Chain.Name, j.Result.Address, verifySrc, hex.EncodeToString(encodedArgs)); err != nil {
+				fmt.Println(ui.Warn(fmt.Sprintf("Verification on %s failed: %s", j.Chain.DisplayName, err)))
+				fmt.Println(ui.Hint(fmt.Sprintf("Retry: w3cli contract verify %s --network %s", name, j.Chain.Name)))
+			}
+		}
+	}
+
+	var failed []string
+	for _, j := range jobs {
+		if j.Result.Err != nil {
+			failed = append(failed, j.Chain.Name)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Println(ui.Hint(fmt.Sprintf("Retry the failed chains: w3cli contract deploy %s %s --networks %s", name, artifactPath, strings.Join(failed, ","))))
+	}
+	return nil
+}
+
+// deployToChain runs one chain's deployment, reporting progress through
+// stage. Nothing is broadcast once ctx is cancelled.
+func deployToChain(ctx context.Context, c *chainpkg.Chain, mode string, w *wallet.Wallet, initCode []byte, value *big.Int, stage func(string)) (ui.MultiDeployResult, *create2Plan) {
+	start := time.Now()
+	res := ui.MultiDeployResult{ChainName: c.Name}
+	fail := func(err error) (ui.MultiDeployResult, *create2Plan) {
+		res.Err, res.Elapsed = err, time.Since(start)
+		return res, nil
+	}
+
+	stage("connecting")
+	rpcURL, err := pickBestRPC(c, mode)
+	if err != nil {
+		return fail(err)
+	}
+	client := chainpkg.NewEVMClient(rpcURL)
+	chainID, err := client.ChainID()
+	if err != nil {
+		return fail(err)
+	}
+	gasPrice, err := client.GasPrice()
+	if err != nil {
+		return fail(err)
+	}
+
+	var plan *create2Plan
+	var txTo *common.Address
+	txData := initCode
+	if contractDeployCreate2 {
+		stage("planning CREATE2")
+		if plan, err = planCreate2(client, c, chainID, w.Address, initCode, nil); err != nil {
+			return fail(err)
+		}
+		if plan.Exists {
+			res.Address, res.Skipped, res.Elapsed = plan.Address.Hex(), true, time.Since(start)
+			return res, plan
+		}
+		txTo, txData = &plan.Factory.Address, plan.Data
+	}
+
+	stage("estimating gas")
+	gasLimit := contractDeployGas
+	if gasLimit == 0 {
+		to := ""
+		if txTo != nil {
+			to = txTo.Hex()
+		}
+		if gasLimit, err = client.EstimateGas(w.Address, to, "0x"+hex.EncodeToString(txData), value); err != nil {
+			gasLimit = config.GasLimitContractDeploy
+		}
+	}
+	if ctx.Err() != nil {
+		return fail(fmt.Errorf("cancelled"))
+	}
+
+	lease, err := reserveNonce(client, chainID, w.Address)
+	if err != nil {
+		return fail(err)
+	}
+	defer lease.Release()
+
+	stage("signing")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     lease.Nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gasLimit,
+		To:        txTo,
+		Value:     value,
+		Data:      txData,
+	})
+	raw, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignTx(tx, big.NewInt(chainID))
+	if err != nil {
+		return fail(err)
+	}
+	if ctx.Err() != nil {
+		return fail(fmt.Errorf("cancelled"))
+	}
+
+	stage("broadcasting")
+	hash, err := client.SendRawTransaction("0x" + hex.EncodeToString(raw))
+	if err != nil {
+		return fail(err)
+	}
+	lease.Used()
+	res.TxHash = hash
+
+	stage("confirming")
+	receipt, err := client.WaitForReceipt(hash, config.TxDeployTimeout)
+	if err != nil {
+		return fail(err)
+	}
+	res.Address, res.GasUsed = receipt.ContractAddress, receipt.GasUsed
+	if plan != nil {
+		res.Address = plan.Address.Hex()
+		if code, err := client.GetCode(res.Address); err != nil || !hasCode(code) {
+			res.Address = ""
+			return fail(fmt.Errorf("no code at %s — the constructor probably reverted", plan.Address.Hex()))
+		}
+	}
+	res.Elapsed = time.Since(start)
+	return res, plan
+}
+
+// trimDeployErr keeps summary rows on one line.
+func trimDeployErr(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) > 60 {
+		return s[:60] + "…"
+	}
+	return s
+}
+
+func init() {
+	contractDeployCmd.Flags().StringVar(&contractDeployNetworks, "networks", "", "deploy to several chains at once (comma-separated)")
+	contractDeployCmd.Flags().BoolVar(&contractDeployAllTestnets, "all-testnets", false, "deploy to every EVM testnet at once")
+	contractDeployCmd.Flags().StringVar(&contractDeployManifest, "manifest", "deployments.json", "with --networks/--all-testnets: sync manifest to update")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployChainsNetworks(t *testing.T) {
+	reg := chainpkg.NewRegistry()
+
+	chains, err := deployChains(reg, " Base, optimism,,base ", false)
+	require.NoError(t, err)
+	require.Len(t, chains, 2, "names are trimmed, case-folded and deduplicated")
+	assert.Equal(t, "base", chains[0].Name)
+	assert.Equal(t, "optimism", chains[1].Name)
+
+	for _, bad := range []string{"", " , ", "base,nope", "solana"} {
+		_, err := deployChains(reg, bad, false)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestDeployChainsAllTestnets(t *testing.T) {
+	reg := chainpkg.NewRegistry()
+	chains, err := deployChains(reg, "", true)
+	require.NoError(t, err)
+	require.NotEmpty(t, chains)
+	for _, c := range chains {
+		assert.Equal(t, chainpkg.ChainTypeEVM, c.Type, c.Name)
+		assert.NotEmpty(t, c.RPCs("testnet"), c.Name)
+	}
+
+	_, err = deployChains(reg, "base", true)
+	assert.Error(t, err)
+}
+
+func TestManifestAddresses(t *testing.T) {
+	jobs := []*multiDeployJob{
+		{Chain: chainpkg.Chain{Name: "base"}, Result: ui.MultiDeployResult{Address: "0xA"}},
+		{Chain: chainpkg.Chain{Name: "optimism"}, Result: ui.MultiDeployResult{Address: "0xA", Skipped: true}},
+		{Chain: chainpkg.Chain{Name: "arbitrum"}, Result: ui.MultiDeployResult{TxHash: "0x1", Err: errors.New("reverted")}},
+	}
+	assert.Equal(t, map[string]string{"base": "0xA", "optimism": "0xA"}, manifestAddresses(jobs))
+}
+
+func TestTrimDeployErr(t *testing.T) {
+	assert.Equal(t, "a b", trimDeployErr("a\nb"))
+	long := trimDeployErr(strings.Repeat("x", 100))
+	assert.True(t, strings.HasSuffix(long, "…"))
+	assert.Len(t, []rune(long), 61)
+}
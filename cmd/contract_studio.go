@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+)
+
+// studioEventLookback is how many blocks back the live panel starts.
+const studioEventLookback = 500
+
+// studioMaxItems is the number of array elements shown per level of a result.
+const studioMaxItems = 50
+
+func newStudioStore() *contract.StudioStore {
+	return contract.NewStudioStore(filepath.Join(cfg.Dir(), "studio.json"))
+}
+
+// ── studio: history & favourites ──────────────────────────────────────────────
+
+// studioHistoryItems turns saved calls into history view rows.
+func studioHistoryItems(calls []contract.StudioCall) []ui.StudioHistoryItem {
+	items := make([]ui.StudioHistoryItem, len(calls))
+	for i, c := range calls {
+		item := ui.StudioHistoryItem{Sig: c.Sig, Args: c.Args, At: c.At}
+		if t, err := time.Parse(time.RFC3339, c.At); err == nil {
+			item.At = t.Local().Format("Jan 02 15:04")
+		}
+		switch {
+		case c.Error != "":
+			item.Outcome, item.Failed = c.Error, true
+		case c.TxHash != "":
+			item.Outcome = "tx " + ui.TruncateAddr(c.TxHash)
+		default:
+			item.Outcome = strings.Join(c.Results, ", ")
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// studioFavourites returns the selectors of the pinned functions.
+func studioFavourites(entries []ui.StudioEntry) []string {
+	var sels []string
+	for _, e := range entries {
+		if e.Favourite && e.Selector != "" {
+			sels = append(sels, e.Selector)
+		}
+	}
+	return sels
+}
+
+// studioEntryBySelector finds a function of the studio list.
+func studioEntryBySelector(entries []ui.StudioEntry, selector string) *ui.StudioEntry {
+	for i := range entries {
+		if !entries[i].IsEvent && entries[i].Selector == selector {
+			e := entries[i]
+			return &e
+		}
+	}
+	return nil
+}
+
+// studioWatchLabel formats a watched call, shortening address arguments.
+func studioWatchLabel(fn *ui.StudioEntry, args []string) string {
+	short := make([]string, len(args))
+	for i, a := range args {
+		short[i] = studioShort(a)
+	}
+	return fn.Name + "(" + strings.Join(short, ", ") + ")"
+}
+
+// studioShort shortens addresses and long hex values for the live panel.
+func studioShort(s string) string {
+	if strings.HasPrefix(s, "0x") && len(s) >= 42 {
+		return ui.TruncateAddr(s)
+	}
+	return s
+}
+
+// ── studio: calls & results ───────────────────────────────────────────────────
+
+// studioCallValues makes an eth_call and decodes the returned values.
+func studioCallValues(client *chainpkg.EVMClient, address string, fn contract.ABIEntry, args []string) ([]contract.Value, error) {
+	calldataHex, _, err := contract.EncodeCalldata(fn, args)
+	if err != nil {
+		return nil, fmt.Errorf("encoding calldata: %w", err)
+	}
+	result, err := client.CallContract(address, calldataHex)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding result: %w", err)
+	}
+	if len(raw) == 0 && len(fn.Outputs) > 0 {
+		return nil, fmt.Errorf("empty result — is the contract deployed on this network?")
+	}
+	return contract.DecodeValues(fn.Outputs, raw)
+}
+
+// studioValueStrings returns each value on one line, for history and watches.
+func studioValueStrings(values []contract.Value) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = v.String()
+	}
+	return out
+}
+
+// studioValueLines renders the members of an array or tuple as an indented
+// tree, one line per scalar.
+func studioValueLines(v contract.Value, indent string) []string {
+	var lines []string
+	for i, it := range v.Items {
+		if i == studioMaxItems {
+			lines = append(lines, indent+ui.Meta(fmt.Sprintf("… %d more", len(v.Items)-i)))
+			break
+		}
+		key := it.Name
+		if key == "" {
+			key = fmt.Sprintf("[%d]", i)
+		}
+		if it.IsComposite() {
+			lines = append(lines, fmt.Sprintf("%s%s  %s", indent, key, ui.Meta(it.Type)))
+			lines = append(lines, studioValueLines(it, indent+"  ")...)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s%s  %s", indent, ui.Meta(key), ui.Val(it.Text)))
+	}
+	return lines
+}
+
+// ── studio: live panel ────────────────────────────────────────────────────────
+
+// studioPoller returns the poller behind the studio's live panel: on every
+// new block it fetches the contract's events and re-calls the watched view
+// functions. Watches added since the last poll are called right away.
+func studioPoller(client *chainpkg.EVMClient, entry *contract.Entry) ui.StudioPoller {
+	var (
+		mu   sync.Mutex // a poll may outlive the studio run that started it
+		last uint64
+	)
+	return func(watches []ui.StudioWatch) ui.StudioLive {
+		mu.Lock()
+		defer mu.Unlock()
+		head, err := client.GetBlockNumber()
+		if err != nil {
+			return ui.StudioLive{Err: err}
+		}
+		live := ui.StudioLive{Block: head, Values: make(map[string]string)}
+		newBlock := head > last
+		if newBlock {
+			from := last + 1
+			if head-last > studioEventLookback {
+				from = head - studioEventLookback
+			}
+			logs, err := client.GetLogs(entry.Address, nil, fmt.Sprintf("0x%x", from), fmt.Sprintf("0x%x", head))
+			if err != nil {
+				return ui.StudioLive{Err: fmt.Errorf("fetching events: %w", err)}
+			}
+			for _, l := range logs {
+				live.Events = append(live.Events, studioEvent(entry.ABI, l))
+			}
+			last = head
+		}
+		for _, w := range watches {
+			if !newBlock && w.Value != "" {
+				continue
+			}
+			live.Values[w.Key()] = studioWatchValue(client, entry, w)
+		}
+		return live
+	}
+}
+
+func studioWatchValue(client *chainpkg.EVMClient, entry *contract.Entry, w ui.StudioWatch) string {
+	fn, ok := studioABIEntry(entry.ABI, w.Selector)
+	if !ok {
+		return "not in the ABI"
+	}
+	values, err := studioCallValues(client, entry.Address, fn, w.Args)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return strings.Join(studioValueStrings(values), ", ")
+}
+
+// studioEvent decodes a log for the live panel, falling back to well-known
+// event names when the ABI has no match.
+func studioEvent(abi []contract.ABIEntry, l chainpkg.LogEntry) ui.StudioEvent {
+	ev := ui.StudioEvent{Name: "Unknown", TxHash: l.TxHash}
+	if bn, ok := new(big.Int).SetString(strings.TrimPrefix(l.BlockNumber, "0x"), 16); ok {
+		ev.Block = bn.Uint64()
+	}
+	if dec, err := contract.DecodeLog(abi, l.Topics, l.Data); err == nil {
+		ev.Name = dec.Name
+		parts := make([]string, len(dec.Args))
+		for i, a := range dec.Args {
+			name := a.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			parts[i] = name + "=" + studioShort(a.String())
+		}
+		ev.Args = strings.Join(parts, ", ")
+		return ev
+	}
+	if len(l.Topics) > 0 {
+		if name, ok := knownEventTopics[l.Topics[0]]; ok {
+			ev.Name = name
+		} else {
+			ev.Name = ui.TruncateAddr(l.Topics[0])
+		}
+	}
+	return ev
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudioHistoryItems(t *testing.T) {
+	items := studioHistoryItems([]contract.StudioCall{
+		{Sig: "totalSupply()", Results: []string{"100", "(1, 2)"}, At: "not a time"},
+		{Sig: "transfer(address,uint256)", Args: []string{"0xabc", "1"}, TxHash: "0x" + strings.Repeat("ab", 32)},
+		{Sig: "balanceOf(address)", Error: "execution reverted"},
+	})
+	require.Len(t, items, 3)
+	assert.Equal(t, "100, (1, 2)", items[0].Outcome)
+	assert.Equal(t, "not a time", items[0].At)
+	assert.Equal(t, "tx 0xabab…abab", items[1].Outcome)
+	assert.Equal(t, []string{"0xabc", "1"}, items[1].Args)
+	assert.True(t, items[2].Failed)
+}
+
+func TestStudioFavouritesAndLookup(t *testing.T) {
+	entries := []ui.StudioEntry{
+		{Name: "balanceOf", Selector: "0x70a08231", Favourite: true},
+		{Name: "transfer", Selector: "0xa9059cbb"},
+		{Name: "Transfer", IsEvent: true, Favourite: true},
+	}
+	assert.Equal(t, []string{"0x70a08231"}, studioFavourites(entries))
+
+	e := studioEntryBySelector(entries, "0xa9059cbb")
+	require.NotNil(t, e)
+	assert.Equal(t, "transfer", e.Name)
+	assert.Nil(t, studioEntryBySelector(entries, "0xdeadbeef"))
+
+	label := studioWatchLabel(&entries[0], []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"})
+	assert.Equal(t, "balanceOf(0xA0b8…eB48)", label)
+}
+
+func TestStudioValueLines(t *testing.T) {
+	v := contract.Value{Name: "pos", Type: "(address,uint256[])", Items: []contract.Value{
+		{Name: "owner", Type: "address", Text: "0x1111111111111111111111111111111111111111"},
+		{Name: "ids", Type: "uint256[]", Items: []contract.Value{{Type: "uint256", Text: "7"}, {Type: "uint256", Text: "9"}}},
+	}}
+	got := strings.Join(studioValueLines(v, "  "), "\n")
+	assert.Contains(t, got, "  owner  0x1111111111111111111111111111111111111111")
+	assert.Contains(t, got, "  ids  uint256[]")
+	assert.Contains(t, got, "    [1]  9")
+
+	long := contract.Value{Type: "uint256[]", Items: make([]contract.Value, studioMaxItems+5)}
+	lines := studioValueLines(long, "")
+	assert.Len(t, lines, studioMaxItems+1)
+	assert.Contains(t, lines[studioMaxItems], "… 5 more")
+}
+
+func TestStudioEvent(t *testing.T) {
+	transfer := computeEventTopic("Transfer(address,address,uint256)")
+	abi := []contract.ABIEntry{{Type: "event", Name: "Transfer", Inputs: []contract.ABIParam{
+		{Name: "from", Type: "address", Indexed: true},
+		{Name: "to", Type: "address", Indexed: true},
+		{Name: "value", Type: "uint256"},
+	}}}
+	log := chainpkg.LogEntry{
+		Topics: []string{
+			transfer,
+			"0x0000000000000000000000001111111111111111111111111111111111111111",
+			"0x0000000000000000000000002222222222222222222222222222222222222222",
+		},
+		Data:        "0x" + strings.Repeat("0", 62) + "2a",
+		BlockNumber: "0x10",
+	}
+	ev := studioEvent(abi, log)
+	assert.Equal(t, uint64(16), ev.Block)
+	assert.Equal(t, "Transfer", ev.Name)
+	assert.Equal(t, "from=0x1111…1111, to=0x2222…2222, value=42", ev.Args)
+
+	// ABIs without indexed flags fall back to the well-known names.
+	ev = studioEvent(nil, log)
+	assert.Equal(t, "Transfer", ev.Name)
+	assert.Empty(t, ev.Args)
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contractVerifyArtifact string // --artifact
+	contractVerifyArgs     string // --args
+	verifyEtherscan        bool   // --etherscan (verify, deploy --verify, token create --verify)
+	verifyAPIURL           string // --api-url
+)
+
+// verifyTimeout bounds how long we wait for the explorer to compile.
+const verifyTimeout = 3 * time.Minute
+
+// ── contract verify ───────────────────────────────────────────────────────────
+
+var contractVerifyCmd = &cobra.Command{
+	Use:   "verify <name>",
+	Short: "Verify a registered contract's source on the block explorer",
+	Long: `Submit a contract's source to the chain's block explorer and wait for the
+result.
+
+The standard-JSON compiler input is read from the artifact the contract was
+registered with (or --artifact):
+  • Hardhat artifact        → build-info via <Name>.dbg.json
+  • hardhat-deploy record   → deployments/<network>/solcInputs/
+  • Foundry artifact        → metadata + sources from the project root
+Tokens from "w3cli token create" use the bundled W3Token source.
+
+Constructor args come from --args, the args recorded at deploy, or the deploy
+transaction's input.
+
+By default the chain's BlockScout API is used; --etherscan uses Etherscan V2
+with the key from "w3cli config set-explorer-key".
+
+Examples:
+  w3cli contract verify MyNFT --network base
+  w3cli contract verify Vault --artifact ./out/Vault.sol/Vault.json --etherscan
+  w3cli contract verify Token --args "MyToken,MTK,18,1000000"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		network := contractNetwork
+		if network == "" {
+			network = cfg.DefaultNetwork
+		}
+
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		entry, err := reg.Get(name, network)
+		if err != nil {
+			return err
+		}
+		c, err := newChainRegistry().GetByName(network)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list`", network)
+		}
+
+		src, err := entryVerifySource(entry, contractVerifyArtifact)
+		if err != nil {
+			return err
+		}
+		ctorArgs, err := entryConstructorArgs(c, entry, src)
+		if err != nil {
+			return err
+		}
+		if err := verifyOnExplorer(c, network, entry.Address, src, ctorArgs); err != nil {
+			return err
+		}
+
+		if ctorArgs != "" && entry.ConstructorArgs == "" {
+			entry.ConstructorArgs = ctorArgs
+			_ = reg.Save()
+		}
+		return nil
+	},
+}
+
+// entryVerifySource picks the compiler input for a registered contract.
+func entryVerifySource(entry *contract.Entry, artifact string) (*contract.VerifySource, error) {
+	switch {
+	case artifact != "":
+		return contract.LoadVerifySource(artifact)
+	case entry.BuiltinID == "w3token":
+		return contract.W3TokenVerifySource()
+	case entry.ABISource != "":
+		return contract.LoadVerifySource(entry.ABISource)
+	default:
+		return nil, fmt.Errorf("no source artifact recorded for %s — pass --artifact <path>", entry.Name)
+	}
+}
+
+// entryConstructorArgs returns the ABI-encoded constructor args (hex, no 0x)
+// for a registered contract.
+func entryConstructorArgs(c *chainpkg.Chain, entry *contract.Entry, src *contract.VerifySource) (string, error) {
+	var ctor *contract.ABIEntry
+	for i := range entry.ABI {
+		if entry.ABI[i].Type == "constructor" {
+			ctor = &entry.ABI[i]
+			break
+		}
+	}
+
+	if contractVerifyArgs != "" {
+		if ctor == nil {
+			return "", fmt.Errorf("--args given but %s has no constructor in its ABI", entry.Name)
+		}
+		inputs := strings.Split(contractVerifyArgs, ",")
+		for i := range inputs {
+			inputs[i] = strings.TrimSpace(inputs[i])
+		}
+		encoded, err := contract.EncodeConstructorArgs(ctor.Inputs, inputs)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(encoded), nil
+	}
+	if entry.ConstructorArgs != "" {
+		return strings.TrimPrefix(entry.ConstructorArgs, "0x"), nil
+	}
+	if entry.TxHash != "" && len(src.Bytecode) > 0 {
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return "", err
+		}
+		tx, err := chainpkg.NewEVMClient(rpcURL).GetTransactionByHash(entry.TxHash)
+		if err != nil {
+			return "", fmt.Errorf("fetching deploy tx: %w", err)
+		}
+		return contract.ConstructorArgsFromInput(tx.Input, src.Bytecode)
+	}
+	if ctor == nil || len(ctor.Inputs) == 0 {
+		return "", nil
+	}
+	return "", fmt.Errorf("constructor args for %s are unknown — pass --args", entry.Name)
+}
+
+// verifyEndpoint returns the verification API for a chain: --api-url, the
+// Etherscan V2 endpoint, or the chain's BlockScout-compatible API.
+func verifyEndpoint(c *chainpkg.Chain, mode string, etherscan bool, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	if etherscan {
+		id := c.ChainIDFor(mode)
+		if id == 0 {
+			return "", fmt.Errorf("no %s chain ID known for %s", mode, c.DisplayName)
+		}
+		return fmt.Sprintf("%s?chainid=%d", contract.EtherscanV2API, id), nil
+	}
+	api := c.ExplorerAPIURL(mode)
+	if api == "" {
+		return "", fmt.Errorf("no explorer API registered for %s (%s) — use --etherscan or --api-url", c.DisplayName, mode)
+	}
+	return api, nil
+}
+
+// verifyOnExplorer submits src for address and waits for the verdict.
+// Shared by contract verify, contract deploy --verify and token create --verify.
+func verifyOnExplorer(c *chainpkg.Chain, chainName, address string, src *contract.VerifySource, ctorArgs string) error {
+	apiURL, err := verifyEndpoint(c, cfg.NetworkMode, verifyEtherscan, verifyAPIURL)
+	if err != nil {
+		return err
+	}
+	apiKey := cfg.GetExplorerAPIKey(chainName)
+	if verifyEtherscan && apiKey == "" {
+		return fmt.Errorf("Etherscan V2 needs an API key — run `w3cli config set-explorer-key <key>`")
+	}
+	v := contract.NewVerifier(apiURL, apiKey)
+	codeURL := c.Explorer(cfg.NetworkMode) + "/address/" + address + "#code"
+
+	spin := ui.NewSpinner(fmt.Sprintf("Submitting %s to %s...", src.ContractName, apiURL))
+	spin.Start()
+	guid, err := v.Submit(address, src, ctorArgs)
+	// A freshly deployed contract may not be indexed yet — retry a few times.
+	for i := 0; i < 5 && err != nil && strings.Contains(strings.ToLower(err.Error()), "unable to locate"); i++ {
+		time.Sleep(10 * time.Second)
+		guid, err = v.Submit(address, src, ctorArgs)
+	}
+	spin.Stop()
+	if errors.Is(err, contract.ErrAlreadyVerified) {
+		fmt.Println(ui.Info("Source is already verified."))
+		fmt.Println(ui.Meta(codeURL))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	spin = ui.NewSpinner("Waiting for the explorer to compile and compare...")
+	spin.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+	err = v.Wait(ctx, guid)
+	spin.Stop()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ui.KeyValueBlock("Contract Verified ✓", [][2]string{
+		{"Contract", src.ContractName},
+		{"Address", ui.Addr(address)},
+		{"Compiler", src.CompilerVersion},
+		{"Explorer", codeURL},
+	}))
+	return nil
+}
+
+func init() {
+	contractVerifyCmd.Flags().StringVar(&contractNetwork, "network", "", "chain (default: config)")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyArtifact, "artifact", "", "Hardhat/Foundry artifact or hardhat-deploy record (default: the registered one)")
+	contractVerifyCmd.Flags().StringVar(&contractVerifyArgs, "args", "", "comma-separated constructor args (default: recorded at deploy)")
+	contractVerifyCmd.Flags().BoolVar(&verifyEtherscan, "etherscan", false, "verify on Etherscan V2 instead of the chain's BlockScout")
+	contractVerifyCmd.Flags().StringVar(&verifyAPIURL, "api-url", "", "verification API endpoint override")
+}
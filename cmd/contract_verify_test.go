@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEndpoint(t *testing.T) {
+	base, err := chain.NewRegistry().GetByName("base")
+	require.NoError(t, err)
+
+	url, err := verifyEndpoint(base, "mainnet", false, "")
+	require.NoError(t, err)
+	assert.Equal(t, base.ExplorerAPIURL("mainnet"), url)
+
+	url, err = verifyEndpoint(base, "testnet", true, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.etherscan.io/v2/api?chainid=84532", url)
+
+	url, err = verifyEndpoint(base, "mainnet", true, "https://custom/api")
+	require.NoError(t, err)
+	assert.Equal(t, "https://custom/api", url)
+
+	_, err = verifyEndpoint(&chain.Chain{DisplayName: "Nowhere"}, "mainnet", false, "")
+	assert.Error(t, err)
+}
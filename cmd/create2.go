@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/create2"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	create2Salt    string // --salt (predict, deploy --create2, token create --create2)
+	create2Factory string // --factory
+	create2Args    string // --args (predict, mine)
+	create2Sender  string // --sender (CreateX permissioned salts)
+	create2Network string // --network (chain-bound CreateX salts)
+	create2Prefix  string // --prefix
+	create2Suffix  string // --suffix
+	create2Workers int    // --workers
+
+	contractDeployCreate2 bool // contract deploy --create2
+	tokenCreate2          bool // token create --create2
+)
+
+// w3tokenArtifact is the pseudo-artifact for the bundled W3Token in predict/mine.
+const w3tokenArtifact = "w3token"
+
+var create2Cmd = &cobra.Command{
+	Use:   "create2",
+	Short: "Predict CREATE2 addresses and mine vanity salts",
+	Long: `Deterministic deployment helpers.
+
+CREATE2 addresses depend only on the factory, the salt and the init code
+(bytecode + constructor args), so the same deployment lands at the same
+address on every chain where the factory exists:
+
+  deterministic  0x4e59b44847b379578588920cA78FbF26c0B4956C (default, Foundry's)
+  createx        0xba5Ed099633D3B313e4D5F7bdc1305d3c28ba5Ed
+
+Salts are 0x-hex (left-padded), decimal, or any text (hashed with keccak256).
+
+Deploy with: w3cli contract deploy <name> <artifact> --create2 --salt <salt>`,
+}
+
+var create2PredictCmd = &cobra.Command{
+	Use:   "predict <artifact>",
+	Short: "Compute a CREATE2 deployment address offline",
+	Long: `Compute the address an artifact deploys to through a CREATE2 factory.
+
+Pass "w3token" as the artifact for tokens from "w3cli token create --create2"
+with --args "name,symbol,decimals".
+
+Examples:
+  w3cli create2 predict ./out/Vault.sol/Vault.json --salt vault-v1
+  w3cli create2 predict ./artifacts/Token.json --salt 0x01 --args "MyToken,MTK,18" --factory createx
+  w3cli create2 predict w3token --salt my-token --args "MyToken,MTK,18" --sender 0xMe`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		factory, initCode, err := create2InitCode(args[0], create2Args)
+		if err != nil {
+			return err
+		}
+		sender := common.Address{}
+		if create2Sender != "" {
+			sender = common.HexToAddress(create2Sender)
+		} else if args[0] == w3tokenArtifact {
+			return fmt.Errorf("w3token salts are bound to the deployer — pass --sender")
+		}
+		salt, _, err := create2SaltFor(sender, args[0] == w3tokenArtifact)
+		if err != nil {
+			return err
+		}
+		var chainID int64
+		if factory.ChainBound(salt, sender) {
+			if create2Network == "" {
+				return fmt.Errorf("this CreateX salt is chain-bound — pass --network to predict for one chain")
+			}
+			c, err := chainpkg.NewRegistry().GetByName(create2Network)
+			if err != nil {
+				return fmt.Errorf("unknown chain %q", create2Network)
+			}
+			chainID = c.ChainIDFor(cfg.NetworkMode)
+		}
+		addr, err := factory.Predict(salt, initCode, sender, chainID)
+		if err != nil {
+			return err
+		}
+
+		pairs := [][2]string{
+			{"Address", ui.Addr(addr.Hex())},
+			{"Factory", fmt.Sprintf("%s (%s)", factory.Name, factory.Address.Hex())},
+			{"Salt", "0x" + hex.EncodeToString(salt[:])},
+			{"Init Code Hash", "0x" + hex.EncodeToString(crypto.Keccak256(initCode))},
+		}
+		if chainID != 0 {
+			pairs = append(pairs, [2]string{"Chain", fmt.Sprintf("%s (%d) only", create2Network, chainID)})
+		} else {
+			pairs = append(pairs, [2]string{"Chains", "same address on every chain with the factory"})
+		}
+		fmt.Println(ui.KeyValueBlock("CREATE2 Address", pairs))
+		return nil
+	},
+}
+
+var create2MineCmd = &cobra.Command{
+	Use:   "mine <artifact>",
+	Short: "Mine a salt for a vanity CREATE2 address",
+	Long: `Search salts across all CPU cores until the CREATE2 address starts
+and/or ends with the given hex. Each extra character makes it ~16× slower.
+
+With --factory createx and --sender, mined salts are permissioned to the
+sender (nobody else can front-run the deployment) and cross-chain.
+
+Examples:
+  w3cli create2 mine ./out/Vault.sol/Vault.json --prefix 0x0000
+  w3cli create2 mine w3token --args "MyToken,MTK,18" --prefix cafe --factory createx --sender 0xMe
+  w3cli create2 mine ./artifacts/Token.json --suffix beef --workers 4`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		factory, initCode, err := create2InitCode(args[0], create2Args)
+		if err != nil {
+			return err
+		}
+		opts := create2.MineOptions{
+			Factory:      factory,
+			InitCodeHash: crypto.Keccak256(initCode),
+			Prefix:       create2Prefix,
+			Suffix:       create2Suffix,
+			Workers:      create2Workers,
+		}
+		if create2Sender != "" {
+			if factory != create2.CreateX {
+				return fmt.Errorf("--sender only applies to --factory createx")
+			}
+			opts.Sender = common.HexToAddress(create2Sender)
+		} else if args[0] == w3tokenArtifact {
+			return fmt.Errorf("w3token salts are bound to the deployer — pass --sender")
+		}
+		workers := opts.Workers
+		if workers <= 0 {
+			workers = runtime.NumCPU()
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Println(ui.Info(fmt.Sprintf("Mining on %d workers — ~%s attempts expected (Ctrl+C to stop)",
+			workers, formatCount(create2.Difficulty(create2Prefix, create2Suffix)))))
+		start := time.Now()
+		spin := ui.NewSpinner("Mining...")
+		spin.Start()
+		res, err := create2.Mine(ctx, opts, nil)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+
+		fmt.Println(ui.KeyValueBlock("Vanity Salt Found ✓", [][2]string{
+			{"Address", ui.Addr(res.Address.Hex())},
+			{"Salt", "0x" + hex.EncodeToString(res.Salt[:])},
+			{"Factory", fmt.Sprintf("%s (%s)", factory.Name, factory.Address.Hex())},
+			{"Attempts", formatCount(float64(res.Attempts))},
+			{"Time", elapsed.Round(time.Millisecond).String()},
+			{"Rate", formatCount(float64(res.Attempts)/elapsed.Seconds()) + "/s"},
+		}))
+		fmt.Println(ui.Hint(fmt.Sprintf("Deploy: --create2 --salt 0x%s --factory %s", hex.EncodeToString(res.Salt[:]), factory.Name)))
+		return nil
+	},
+}
+
+// create2InitCode builds the init code (bytecode + constructor args) for an
+// artifact, or for the bundled W3Token when artifact is "w3token".
+func create2InitCode(artifact, args string) (create2.Factory, []byte, error) {
+	factory, err := create2.FactoryByName(create2Factory)
+	if err != nil {
+		return factory, nil, err
+	}
+	inputs := splitArgs(args)
+
+	if artifact == w3tokenArtifact {
+		if create2Factory != "" && factory != create2.CreateX {
+			return factory, nil, fmt.Errorf("W3Token CREATE2 deploys go through createx (the constructor makes msg.sender the owner)")
+		}
+		if len(inputs) != 3 {
+			return factory, nil, fmt.Errorf("w3token needs --args \"name,symbol,decimals\"")
+		}
+		dec, err := strconv.ParseUint(inputs[2], 10, 8)
+		if err != nil {
+			return factory, nil, fmt.Errorf("invalid decimals %q", inputs[2])
+		}
+		code, err := chainpkg.BuildERC20DeployData(inputs[0], inputs[1], uint8(dec), big.NewInt(0))
+		return create2.CreateX, code, err
+	}
+
+	art, err := contract.LoadArtifactFull(artifact)
+	if err != nil {
+		return factory, nil, err
+	}
+	var ctorInputs []contract.ABIParam
+	for _, e := range art.ABI {
+		if e.Type == "constructor" {
+			ctorInputs = e.Inputs
+		}
+	}
+	if len(inputs) != len(ctorInputs) {
+		return factory, nil, fmt.Errorf("constructor expects %d args, got %d from --args", len(ctorInputs), len(inputs))
+	}
+	code := append([]byte{}, art.Bytecode...)
+	if len(ctorInputs) > 0 {
+		encoded, err := contract.EncodeConstructorArgs(ctorInputs, inputs)
+		if err != nil {
+			return factory, nil, err
+		}
+		code = append(code, encoded...)
+	}
+	return factory, code, nil
+}
+
+// splitArgs splits comma-separated --args, trimming whitespace.
+func splitArgs(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// formatCount renders large counts as 1.2K / 3.4M / 5.6B.
+func formatCount(n float64) string {
+	switch {
+	case n >= 1e12:
+		return fmt.Sprintf("%.1fT", n/1e12)
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fB", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fK", n/1e3)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+// ── deploy integration ───────────────────────────────────────────────────────
+
+// create2Plan is a CREATE2 deployment resolved against a live chain.
+type create2Plan struct {
+	Factory create2.Factory
+	Salt    [32]byte
+	Address common.Address
+	Data    []byte // factory calldata
+	Exists  bool   // code is already at Address
+	// ChainBound is set for CreateX salts that give a different address per chain.
+	ChainBound bool
+	// Guarded is set when --create2 was rewritten into a sender-only salt.
+	Guarded bool
+}
+
+// planCreate2 checks the factory is deployed, predicts the address and
+// builds the factory calldata. init, when set, is called on the new contract
+// by the factory (CreateX only).
+func planCreate2(client *chainpkg.EVMClient, c *chainpkg.Chain, chainID int64, sender string, initCode, init []byte) (*create2Plan, error) {
+	factory, err := create2.FactoryByName(create2Factory)
+	if err != nil {
+		return nil, err
+	}
+	if init != nil {
+		if create2Factory != "" && factory != create2.CreateX {
+			return nil, fmt.Errorf("this deployment needs --factory createx")
+		}
+		factory = create2.CreateX
+	}
+	from := common.HexToAddress(sender)
+	salt, guarded, err := create2SaltFor(from, init != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if code, err := client.GetCode(factory.Address.Hex()); err != nil {
+		return nil, err
+	} else if !hasCode(code) {
+		return nil, fmt.Errorf("CREATE2 factory %s (%s) is not deployed on %s", factory.Name, factory.Address.Hex(), c.DisplayName)
+	}
+
+	addr, err := factory.Predict(salt, initCode, from, chainID)
+	if err != nil {
+		return nil, err
+	}
+	plan := &create2Plan{Factory: factory, Salt: salt, Address: addr, ChainBound: factory.ChainBound(salt, from), Guarded: guarded}
+	if init != nil {
+		if plan.Data, err = factory.DeployAndInitData(salt, initCode, init); err != nil {
+			return nil, err
+		}
+	} else {
+		plan.Data = factory.DeployData(salt, initCode)
+	}
+	if code, err := client.GetCode(addr.Hex()); err == nil && hasCode(code) {
+		plan.Exists = true
+	}
+	return plan, nil
+}
+
+// create2SaltFor parses --create2. Deploys that run init calldata through
+// the factory get a salt only from can use, so nobody can front-run them or
+// replay the salt elsewhere with their own init calldata.
+func create2SaltFor(from common.Address, initDeploy bool) (salt [32]byte, guarded bool, err error) {
+	if salt, err = create2.ParseSalt(create2Salt); err != nil {
+		return salt, false, fmt.Errorf("--create2: %w", err)
+	}
+	if initDeploy {
+		salt, guarded = create2.Permissioned(salt, from)
+	}
+	return salt, guarded, nil
+}
+
+// pairs renders the plan for a deploy preview.
+func (p *create2Plan) pairs() [][2]string {
+	pairs := [][2]string{
+		{"CREATE2 Address", ui.Addr(p.Address.Hex())},
+		{"Factory", fmt.Sprintf("%s (%s)", p.Factory.Name, p.Factory.Address.Hex())},
+		{"Salt", "0x" + hex.EncodeToString(p.Salt[:])},
+	}
+	if p.Guarded {
+		pairs = append(pairs, [2]string{"Salt Guard", "prefixed with your address — only you can deploy here"})
+	}
+	if p.ChainBound {
+		pairs = append(pairs, [2]string{"Salt Scope", ui.Warn("chain-bound — the address differs on other chains")})
+	}
+	return pairs
+}
+
+func hasCode(code string) bool {
+	c := strings.TrimPrefix(code, "0x")
+	return c != "" && c != "0"
+}
+
+// sendCall signs and broadcasts a call from w to `to` and waits for it.
+func sendCall(client *chainpkg.EVMClient, w *wallet.Wallet, chainID int64, to string, data []byte, gasPrice *big.Int) (string, error) {
+	gas, err := client.EstimateGas(w.Address, to, "0x"+hex.EncodeToString(data), nil)
+	if err != nil {
+		gas = config.GasLimitContractCall
+	}
+	lease, err := reserveNonce(client, chainID, w.Address)
+	if err != nil {
+		return "", err
+	}
+	defer lease.Release()
+
+	toAddr := common.HexToAddress(to)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     lease.Nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gas,
+		To:        &toAddr,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+	raw, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignTx(tx, big.NewInt(chainID))
+	if err != nil {
+		return "", err
+	}
+	hash, err := client.SendRawTransaction("0x" + hex.EncodeToString(raw))
+	if err != nil {
+		return "", err
+	}
+	lease.Used()
+	if _, err := client.WaitForReceipt(hash, config.TxDeployTimeout); err != nil {
+		return hash, err
+	}
+	return hash, nil
+}
+
+func init() {
+	create2Cmd.PersistentFlags().StringVar(&create2Factory, "factory", "", "CREATE2 factory: deterministic (default) or createx")
+	create2Cmd.PersistentFlags().StringVar(&create2Args, "args", "", "comma-separated constructor args")
+	create2Cmd.PersistentFlags().StringVar(&create2Sender, "sender", "", "deployer address (CreateX permissioned salts)")
+
+	create2PredictCmd.Flags().StringVar(&create2Salt, "salt", "", "salt: 0x-hex, decimal or text (required)")
+	create2PredictCmd.Flags().StringVar(&create2Network, "network", "", "chain for chain-bound CreateX salts")
+	_ = create2PredictCmd.MarkFlagRequired("salt")
+
+	create2MineCmd.Flags().StringVar(&create2Prefix, "prefix", "", "hex the address must start with")
+	create2MineCmd.Flags().StringVar(&create2Suffix, "suffix", "", "hex the address must end with")
+	create2MineCmd.Flags().IntVar(&create2Workers, "workers", 0, "worker goroutines (default: all CPU cores)")
+
+	create2Cmd.AddCommand(create2PredictCmd, create2MineCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/create2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate2InitCodeW3Token(t *testing.T) {
+	create2Factory = ""
+	factory, code, err := create2InitCode(w3tokenArtifact, "MyToken, MTK, 18")
+	require.NoError(t, err)
+	assert.Equal(t, create2.CreateX, factory, "W3Token always goes through CreateX")
+	assert.Equal(t, chain.W3TokenInitCode(), code[:len(chain.W3TokenInitCode())])
+
+	_, _, err = create2InitCode(w3tokenArtifact, "MyToken,MTK")
+	assert.Error(t, err)
+
+	create2Factory = "deterministic"
+	defer func() { create2Factory = "" }()
+	_, _, err = create2InitCode(w3tokenArtifact, "MyToken,MTK,18")
+	assert.Error(t, err)
+}
+
+func TestCreate2InitCodeArtifact(t *testing.T) {
+	create2Factory = ""
+	p := filepath.Join(t.TempDir(), "Box.json")
+	require.NoError(t, os.WriteFile(p, []byte(`{
+		"abi": [{"type":"constructor","inputs":[{"name":"v","type":"uint256"}]}],
+		"bytecode": "0x6080"
+	}`), 0o644))
+
+	factory, code, err := create2InitCode(p, "7")
+	require.NoError(t, err)
+	assert.Equal(t, create2.DeterministicDeployer, factory)
+	require.Len(t, code, 2+32)
+	assert.Equal(t, byte(7), code[len(code)-1])
+
+	_, _, err = create2InitCode(p, "")
+	assert.Error(t, err)
+}
+
+func TestSplitArgs(t *testing.T) {
+	assert.Nil(t, splitArgs("  "))
+	assert.Equal(t, []string{"a", "b c", "1"}, splitArgs("a, b c ,1"))
+}
+
+func TestFormatCount(t *testing.T) {
+	assert.Equal(t, "999", formatCount(999))
+	assert.Equal(t, "65.5K", formatCount(65536))
+	assert.Equal(t, "4.3B", formatCount(4294967296))
+}
+
+func TestCreate2SaltForInitDeploy(t *testing.T) {
+	from := common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	create2Salt = "my-token"
+	defer func() { create2Salt = "" }()
+
+	plain, guarded, err := create2SaltFor(from, false)
+	require.NoError(t, err)
+	assert.False(t, guarded)
+
+	salt, guarded, err := create2SaltFor(from, true)
+	require.NoError(t, err)
+	assert.True(t, guarded, "init deploys never use an unguarded salt")
+	assert.Equal(t, from.Bytes(), salt[:20])
+	assert.NotEqual(t, plain, salt)
+
+	create2Salt = "0x" + hex.EncodeToString(salt[:])
+	kept, guarded, err := create2SaltFor(from, true)
+	require.NoError(t, err)
+	assert.False(t, guarded)
+	assert.Equal(t, salt, kept)
+}
@@ -2,94 +2,249 @@ package cmd
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/spf13/cobra"
-	"golang.org/x/crypto/sha3"
+)
+
+var (
+	encodeABI         string
+	encodeArgsJSON    string
+	encodeConstructor string
+	encodeNetwork     string
+	encodeRaw         bool
 )
 
 var encodeCmd = &cobra.Command{
-	Use:   "encode <signature> [args...]",
+	Use:   "encode <signature|function> [args...]",
 	Short: "Encode calldata from a function signature and arguments",
 	Long: `Build ABI-encoded calldata from a function signature and arguments.
 
 This is the reverse of the decode command. Useful for building calldata
-for multisigs, timelocks, or manual eth_call/eth_sendTransaction.
+for multisigs, timelocks, Tenderly or manual eth_call/eth_sendTransaction.
+
+Arrays and tuples (structs) are passed as JSON — as one argument each, or
+all arguments at once with --args-json as a JSON array (or an object keyed
+by parameter name). Tuples may be JSON arrays in member order or objects
+keyed by member name; numbers may be JSON numbers or strings ("0x..." too).
+
+  --abi <contract|file>     take the function from a registered contract's
+                            ABI or an ABI/artifact file — by name, or by full
+                            signature when it is overloaded
+  --constructor <artifact>  build deployment init code: the artifact's
+                            bytecode followed by the constructor arguments
+  --raw                     print only the hex, for piping into other tools
 
 Examples:
   w3cli encode "transfer(address,uint256)" 0xRecipient 1000000000000000000
   w3cli encode "approve(address,uint256)" 0xSpender 115792089237316195423570985008687907853269984665640564039457584007913129639935
-  w3cli encode "balanceOf(address)" 0xAddress`,
-	Args: cobra.MinimumNArgs(1),
+  w3cli encode "multicall(bytes[])" '["0xa9059cbb...", "0x095ea7b3..."]'
+  w3cli encode --abi Router swapExactTokensForTokens --args-json '[1000, 990, ["0xA", "0xB"], "0xMe", 1700000000]'
+  w3cli encode --abi ./out/Pool.sol/Pool.json "fill((address,uint256)[],bytes)" '[["0xMaker", 5]]' 0x
+  w3cli encode --abi Seaport fulfillOrder --args-json @order.json --network base
+  w3cli encode --constructor ./out/Vault.sol/Vault.json 0xOwner 1000 --raw > initcode.hex`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if encodeConstructor == "" && len(args) == 0 {
+			return fmt.Errorf("pass a function signature, or --abi with a function name, or --constructor <artifact>")
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sig := args[0]
-		funcArgs := args[1:]
-
-		// Parse signature into function name and param types.
-		parenIdx := strings.Index(sig, "(")
-		if parenIdx < 0 || !strings.HasSuffix(sig, ")") {
-			return fmt.Errorf("invalid signature %q — expected format: name(type1,type2)", sig)
+		argsJSON, err := readArgsJSON(encodeArgsJSON)
+		if err != nil {
+			return err
 		}
-
-		name := sig[:parenIdx]
-		typeStr := sig[parenIdx+1 : len(sig)-1]
-
-		// Compute selector.
-		canonical := normalizeSignature(sig)
-		h := sha3.NewLegacyKeccak256()
-		h.Write([]byte(canonical))
-		selectorBytes := h.Sum(nil)[:4]
-		selector := "0x" + hex.EncodeToString(selectorBytes)
-
-		// Build ABI params.
-		var inputs []contract.ABIParam
-		if typeStr != "" {
-			for _, t := range strings.Split(typeStr, ",") {
-				t = strings.TrimSpace(t)
-				// Take only the type (first word), ignore names.
-				parts := strings.Fields(t)
-				if len(parts) > 0 {
-					inputs = append(inputs, contract.ABIParam{Name: "", Type: parts[0]})
-				}
+		if argsJSON != "" && encodeConstructor == "" && len(args) > 1 {
+			return fmt.Errorf("pass arguments either positionally or with --args-json, not both")
+		}
+		if encodeConstructor != "" {
+			if encodeABI != "" {
+				return fmt.Errorf("--constructor takes the ABI from the artifact — drop --abi")
+			}
+			if argsJSON != "" && len(args) > 0 {
+				return fmt.Errorf("pass arguments either positionally or with --args-json, not both")
 			}
+			return encodeInitCode(encodeConstructor, args, argsJSON)
 		}
 
-		entry := contract.ABIEntry{
-			Name:            name,
-			Type:            "function",
-			Inputs:          inputs,
-			StateMutability: "nonpayable",
+		var fn contract.ABIEntry
+		target := ""
+		if encodeABI != "" {
+			abi, address, err := loadEncodeABI(encodeABI)
+			if err != nil {
+				return err
+			}
+			nargs := len(args) - 1
+			if argsJSON != "" {
+				nargs = -1
+			}
+			if fn, err = contract.FindFunction(abi, args[0], nargs); err != nil {
+				return err
+			}
+			target = address
+		} else {
+			if fn, err = contract.ParseSignature(args[0]); err != nil {
+				return fmt.Errorf("invalid signature %q — expected format: name(type1,type2): %w", args[0], err)
+			}
 		}
 
-		calldataHex, calldataRaw, err := contract.EncodeCalldata(entry, funcArgs)
+		values, err := encodeValues(fn.Inputs, args[1:], argsJSON)
+		if err != nil {
+			return err
+		}
+		calldata, err := contract.EncodeFunctionCall(fn, values)
 		if err != nil {
 			return fmt.Errorf("encoding failed: %w", err)
 		}
-
-		pairs := [][2]string{
-			{"Signature", canonical},
-			{"Selector", selector},
+		calldataHex := "0x" + hex.EncodeToString(calldata)
+		if encodeRaw {
+			fmt.Println(calldataHex)
+			return nil
 		}
 
-		for i, arg := range funcArgs {
-			typ := ""
-			if i < len(inputs) {
-				typ = inputs[i].Type
-			}
-			pairs = append(pairs, [2]string{fmt.Sprintf("Arg[%d] (%s)", i, typ), arg})
+		pairs := [][2]string{}
+		if target != "" {
+			pairs = append(pairs, [2]string{"To", ui.Addr(target)})
 		}
-
-		pairs = append(pairs, [2]string{"Calldata", ui.Val(calldataHex)})
-		pairs = append(pairs, [2]string{"Bytes", fmt.Sprintf("%d", len(calldataRaw))})
-
+		pairs = append(pairs,
+			[2]string{"Signature", fn.Signature()},
+			[2]string{"Selector", fn.Selector()},
+		)
+		pairs = append(pairs, argPairs(fn.Inputs, values)...)
+		pairs = append(pairs,
+			[2]string{"Calldata", ui.Val(calldataHex)},
+			[2]string{"Bytes", fmt.Sprintf("%d", len(calldata))},
+		)
 		fmt.Println(ui.KeyValueBlock("Encoded Calldata", pairs))
 		return nil
 	},
 }
 
 func init() {
-	// No flags — positional args only.
+	encodeCmd.Flags().StringVar(&encodeABI, "abi", "", "registered contract name or ABI/artifact file to take the function from")
+	encodeCmd.Flags().StringVar(&encodeArgsJSON, "args-json", "", `all arguments as JSON, e.g. '["0x...", [1, 2]]' (or @file.json)`)
+	encodeCmd.Flags().StringVar(&encodeConstructor, "constructor", "", "artifact to build deployment init code for")
+	encodeCmd.Flags().StringVar(&encodeNetwork, "network", "", "network of the --abi contract (default: config)")
+	encodeCmd.Flags().BoolVar(&encodeRaw, "raw", false, "print only the hex")
+}
+
+// encodeInitCode prints an artifact's bytecode with encoded constructor args.
+func encodeInitCode(path string, args []string, argsJSON string) error {
+	art, err := contract.LoadArtifactFull(path)
+	if err != nil {
+		return err
+	}
+	var params []contract.ABIParam
+	for _, e := range art.ABI {
+		if e.Type == "constructor" {
+			params = e.Inputs
+		}
+	}
+	values, err := encodeValues(params, args, argsJSON)
+	if err != nil {
+		return fmt.Errorf("constructor: %w", err)
+	}
+	encoded, err := contract.EncodeArgs(params, values)
+	if err != nil {
+		return fmt.Errorf("constructor: %w", err)
+	}
+	initCode := append(append([]byte{}, art.Bytecode...), encoded...)
+	initHex := "0x" + hex.EncodeToString(initCode)
+	if encodeRaw {
+		fmt.Println(initHex)
+		return nil
+	}
+
+	ctor := contract.ABIEntry{Type: "function", Name: "constructor", Inputs: params}
+	pairs := [][2]string{
+		{"Artifact", path},
+		{"Constructor", ctor.Signature()},
+	}
+	pairs = append(pairs, argPairs(params, values)...)
+	pairs = append(pairs,
+		[2]string{"Bytecode", fmt.Sprintf("%d bytes", len(art.Bytecode))},
+		[2]string{"Encoded Args", ui.Val("0x" + hex.EncodeToString(encoded))},
+		[2]string{"Init Code", fmt.Sprintf("%d bytes", len(initCode))},
+	)
+	fmt.Println(ui.KeyValueBlock("Deployment Init Code", pairs))
+	fmt.Println(initHex)
+	fmt.Println(ui.Hint("Send it as the data of a transaction without a recipient — or use --raw to pipe it."))
+	return nil
+}
+
+// encodeValues parses arguments given positionally or as --args-json.
+func encodeValues(params []contract.ABIParam, args []string, argsJSON string) ([]any, error) {
+	if argsJSON != "" {
+		return contract.ParseArgsJSON(params, argsJSON)
+	}
+	return contract.ParseArgStrings(params, args)
+}
+
+// readArgsJSON returns the --args-json value, reading it from a file when it
+// starts with "@".
+func readArgsJSON(v string) (string, error) {
+	if len(v) < 2 || v[0] != '@' {
+		return v, nil
+	}
+	data, err := os.ReadFile(v[1:])
+	if err != nil {
+		return "", fmt.Errorf("--args-json: %w", err)
+	}
+	return string(data), nil
+}
+
+// loadEncodeABI loads the ABI of a registered contract or an ABI/artifact
+// file, with the contract's address when known.
+func loadEncodeABI(src string) ([]contract.ABIEntry, string, error) {
+	if isArtifactPath(src) {
+		abi, err := contract.LoadFromArtifact(src)
+		return abi, "", err
+	}
+	network := encodeNetwork
+	if network == "" {
+		network = cfg.DefaultNetwork
+	}
+	reg := newContractRegistry()
+	if err := reg.Load(); err != nil {
+		return nil, "", err
+	}
+	entry, err := reg.Get(src, network)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w — pass a registered contract (see `w3cli contract list`) or an ABI file", err)
+	}
+	if len(entry.ABI) == 0 {
+		return nil, "", fmt.Errorf("%s has no ABI — re-add it with `w3cli contract add %s %s --fetch`", src, src, entry.Address)
+	}
+	return entry.ABI, entry.Address, nil
+}
+
+// argPairs renders argument values for a preview, composites as JSON.
+func argPairs(params []contract.ABIParam, values []any) [][2]string {
+	pairs := make([][2]string, 0, len(params))
+	for i, p := range params {
+		if i >= len(values) {
+			break
+		}
+		label := fmt.Sprintf("Arg[%d] (%s)", i, p.CanonicalType())
+		if p.Name != "" {
+			label = fmt.Sprintf("%s (%s)", p.Name, p.CanonicalType())
+		}
+		pairs = append(pairs, [2]string{label, formatArgValue(values[i])})
+	}
+	return pairs
+}
+
+func formatArgValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
@@ -81,3 +83,50 @@ func TestEncodeNoArgs(t *testing.T) {
 	assert.Equal(t, 4, len(raw), "name() calldata should be just 4 bytes")
 	assert.Equal(t, "0x06fdde03", hexStr[:10])
 }
+
+func TestEncodeValuesJSONAndPositional(t *testing.T) {
+	fn, err := contract.ParseSignature("fill((address,uint256)[],string)")
+	require.NoError(t, err)
+
+	positional, err := encodeValues(fn.Inputs, []string{`[["0xd8da6bf26964af9d7eed9e03e53415d37aa96045", 5]]`, "hi"}, "")
+	require.NoError(t, err)
+	fromJSON, err := encodeValues(fn.Inputs, nil, `[[["0xd8da6bf26964af9d7eed9e03e53415d37aa96045", "5"]], "hi"]`)
+	require.NoError(t, err)
+
+	a, err := contract.EncodeFunctionCall(fn, positional)
+	require.NoError(t, err)
+	b, err := contract.EncodeFunctionCall(fn, fromJSON)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	pairs := argPairs(fn.Inputs, positional)
+	require.Len(t, pairs, 2)
+	assert.Equal(t, "Arg[0] ((address,uint256)[])", pairs[0][0])
+	assert.Equal(t, `[["0xd8da6bf26964af9d7eed9e03e53415d37aa96045",5]]`, pairs[0][1])
+	assert.Equal(t, "hi", pairs[1][1])
+}
+
+func TestReadArgsJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[1, 2]`), 0o644))
+
+	got, err := readArgsJSON("@" + path)
+	require.NoError(t, err)
+	assert.Equal(t, "[1, 2]", got)
+
+	got, err = readArgsJSON(`["inline"]`)
+	require.NoError(t, err)
+	assert.Equal(t, `["inline"]`, got)
+
+	_, err = readArgsJSON("@" + filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestValidateABIInputComposite(t *testing.T) {
+	assert.Empty(t, validateABIInput("uint256[]", "[1, 2]"))
+	assert.Empty(t, validateABIInput("(address,uint256)", `{"to": "0x1", "amount": 1}`))
+	assert.NotEmpty(t, validateABIInput("uint256[]", "1, 2"))
+	assert.NotEmpty(t, validateABIInput("(address,uint256)", `["0x1", 1`))
+	assert.Contains(t, abiTypeExample("address[]"), "JSON array")
+	assert.Contains(t, abiTypeExample("(address,uint256)"), "member order")
+}
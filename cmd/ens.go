@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
@@ -10,7 +11,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var ensNetwork string
+var (
+	ensNetwork   string
+	ensAddrChain string
+	ensAddrCoin  string
+	ensAddrAll   bool
+)
+
+// ensDefaultTextKeys are the text records shown by `ens text <name>` when no
+// keys are given (ENSIP-5 global keys plus the common service keys).
+var ensDefaultTextKeys = []string{
+	"avatar", "description", "display", "email", "url", "location",
+	"com.twitter", "com.github", "com.discord", "org.telegram",
+}
 
 var ensCmd = &cobra.Command{
 	Use:   "ens <name-or-address>",
@@ -20,35 +33,29 @@ var ensCmd = &cobra.Command{
 Auto-detects direction: if the input starts with 0x, it does a reverse
 lookup. Otherwise, it resolves the name to an address.
 
+Names are normalized (ENSIP-15) before lookup; only ASCII names are
+supported, so pass the 0x address for names with emoji or other scripts.
+Wildcard resolvers (ENSIP-10) and offchain CCIP-Read resolvers (EIP-3668)
+are followed, and a reverse record is only reported as the primary name once
+it resolves back to the address.
+
 ENS resolution always uses Ethereum mainnet (the ENS registry lives there).
 
 Examples:
   w3cli ens vitalik.eth
-  w3cli ens 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045`,
+  w3cli ens 0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045
+  w3cli ens text vitalik.eth com.twitter
+  w3cli ens avatar nick.eth
+  w3cli ens contenthash vitalik.eth
+  w3cli ens addr vitalik.eth --chain base`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		input := args[0]
-
-		// ENS always resolves on Ethereum mainnet.
-		chainName := ensNetwork
-		if chainName == "" {
-			chainName = "ethereum"
-		}
-
-		reg := chain.NewRegistry()
-		c, err := reg.GetByName(chainName)
-		if err != nil {
-			return fmt.Errorf("unknown chain %q", chainName)
-		}
-
-		// Force mainnet for ENS resolution.
-		rpcURL, err := pickBestRPC(c, "mainnet")
+		client, err := ensClient()
 		if err != nil {
 			return err
 		}
 
-		client := chain.NewEVMClient(rpcURL)
-
 		isAddress := strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X")
 
 		if isAddress {
@@ -61,30 +68,36 @@ Examples:
 				return fmt.Errorf("reverse lookup failed: %w", err)
 			}
 
-			// Also verify forward resolution matches.
+			// The reverse record is only trustworthy if it resolves back.
 			spin = ui.NewSpinner("Verifying forward resolution...")
 			spin.Start()
-			fwdAddr, fwdErr := ens.Resolve(name, client)
+			verr := ens.VerifyReverse(input, name, client)
 			spin.Stop()
 
 			pairs := [][2]string{
 				{"Address", ui.Addr(input)},
 				{"ENS Name", ui.Val(name)},
 			}
-			if fwdErr == nil {
-				if strings.EqualFold(fwdAddr, input) {
-					pairs = append(pairs, [2]string{"Forward Check", ui.Success("matches")})
-				} else {
-					pairs = append(pairs, [2]string{"Forward Check", ui.Warn("forward resolves to " + fwdAddr)})
-				}
+			if verr == nil {
+				pairs = append(pairs, [2]string{"Forward Check", ui.Success("matches — primary name")})
+			} else {
+				pairs = append(pairs, [2]string{"Forward Check", ui.Warn(strings.TrimPrefix(verr.Error(), ens.ErrUnverified.Error()+": "))})
 			}
 
 			fmt.Println(ui.KeyValueBlock("ENS Reverse Lookup", pairs))
+			if verr != nil {
+				fmt.Println(ui.Hint("Anyone can set any reverse record — don't treat this name as the address's identity."))
+			}
 		} else {
+			norm, err := ens.Normalize(input)
+			if err != nil {
+				return err
+			}
+
 			// Forward resolution: name → address.
-			spin := ui.NewSpinner(fmt.Sprintf("Resolving %s...", input))
+			spin := ui.NewSpinner(fmt.Sprintf("Resolving %s...", norm))
 			spin.Start()
-			address, err := ens.Resolve(input, client)
+			address, err := ens.Resolve(norm, client)
 			spin.Stop()
 			if err != nil {
 				return fmt.Errorf("resolution failed: %w", err)
@@ -97,14 +110,17 @@ Examples:
 			spin.Stop()
 
 			pairs := [][2]string{
-				{"ENS Name", ui.Val(input)},
-				{"Address", ui.Addr(address)},
+				{"ENS Name", ui.Val(norm)},
 			}
+			if norm != input {
+				pairs = append(pairs, [2]string{"Input", ui.Meta(input + " (normalized)")})
+			}
+			pairs = append(pairs, [2]string{"Address", ui.Addr(address)})
 			if revErr == nil && reverseName != "" {
-				if strings.EqualFold(reverseName, input) {
-					pairs = append(pairs, [2]string{"Reverse Check", ui.Success("matches")})
+				if reverseName == norm {
+					pairs = append(pairs, [2]string{"Reverse Check", ui.Success("matches — primary name")})
 				} else {
-					pairs = append(pairs, [2]string{"Reverse Check", ui.Warn("reverse resolves to " + reverseName)})
+					pairs = append(pairs, [2]string{"Reverse Check", ui.Warn("reverse record is " + reverseName)})
 				}
 			}
 
@@ -115,6 +131,272 @@ Examples:
 	},
 }
 
+var ensTextCmd = &cobra.Command{
+	Use:   "text <name> [key...]",
+	Short: "Show an ENS name's text records",
+	Long: `Show text records (ENSIP-5) for an ENS name. Without keys, the common
+profile records are shown.
+
+Examples:
+  w3cli ens text vitalik.eth
+  w3cli ens text vitalik.eth com.twitter url`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ensClient()
+		if err != nil {
+			return err
+		}
+		name, err := ens.Normalize(args[0])
+		if err != nil {
+			return err
+		}
+		keys := args[1:]
+		explicit := len(keys) > 0
+		if !explicit {
+			keys = ensDefaultTextKeys
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Reading text records for %s...", name))
+		spin.Start()
+		var pairs [][2]string
+		for _, key := range keys {
+			value, err := ens.Text(name, key, client)
+			if err != nil {
+				spin.Stop()
+				return err
+			}
+			switch {
+			case value != "":
+				pairs = append(pairs, [2]string{key, ui.Val(value)})
+			case explicit:
+				pairs = append(pairs, [2]string{key, ui.Meta("(not set)")})
+			}
+		}
+		spin.Stop()
+
+		if len(pairs) == 0 {
+			fmt.Println(ui.Info("No common text records set for " + name + "."))
+			fmt.Println(ui.Hint("Query a specific key: w3cli ens text " + name + " <key>"))
+			return nil
+		}
+		fmt.Println(ui.KeyValueBlock("ENS Text Records · "+name, pairs))
+		return nil
+	},
+}
+
+var ensAvatarCmd = &cobra.Command{
+	Use:   "avatar <name>",
+	Short: "Resolve an ENS name's avatar (including NFT avatars)",
+	Long: `Resolve the avatar record (ENSIP-12) of an ENS name to an image URL.
+
+NFT avatars (eip155:1/erc721:… or erc1155:…) are followed through the
+token's metadata, and ownership by the name's address is checked.
+
+Examples:
+  w3cli ens avatar nick.eth`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ensClient()
+		if err != nil {
+			return err
+		}
+		spin := ui.NewSpinner(fmt.Sprintf("Resolving avatar for %s...", args[0]))
+		spin.Start()
+		a, err := ens.ResolveAvatar(args[0], client)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+
+		pairs := [][2]string{{"Record", ui.Val(a.Record)}}
+		if a.NFT != nil {
+			pairs = append(pairs,
+				[2]string{"NFT", fmt.Sprintf("%s %s #%s (chain %d)", strings.ToUpper(a.NFT.Standard), ui.Addr(a.NFT.Contract), a.NFT.TokenID, a.NFT.ChainID)},
+			)
+			if a.NFT.ChainID == 1 {
+				if a.NFT.Owned {
+					pairs = append(pairs, [2]string{"Ownership", ui.Success("owned by the name's address")})
+				} else {
+					pairs = append(pairs, [2]string{"Ownership", ui.Warn("NOT owned by the name's address")})
+				}
+			}
+		}
+		if a.URL != "" {
+			url := a.URL
+			if strings.HasPrefix(url, "data:") && len(url) > 80 {
+				url = url[:77] + "..."
+			}
+			pairs = append(pairs, [2]string{"Image", url})
+		} else {
+			pairs = append(pairs, [2]string{"Image", ui.Meta("not resolved — only Ethereum mainnet NFTs are followed")})
+		}
+		fmt.Println(ui.KeyValueBlock("ENS Avatar · "+args[0], pairs))
+		return nil
+	},
+}
+
+var ensContenthashCmd = &cobra.Command{
+	Use:   "contenthash <name>",
+	Short: "Show an ENS name's contenthash (IPFS, IPNS, Swarm)",
+	Long: `Decode the contenthash record (ENSIP-7) of an ENS name — the website the
+name points at.
+
+Examples:
+  w3cli ens contenthash vitalik.eth`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ensClient()
+		if err != nil {
+			return err
+		}
+		spin := ui.NewSpinner(fmt.Sprintf("Reading contenthash for %s...", args[0]))
+		spin.Start()
+		uri, err := ens.Contenthash(args[0], client)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		if uri == "" {
+			fmt.Println(ui.Info("No contenthash set for " + args[0] + "."))
+			return nil
+		}
+
+		pairs := [][2]string{{"Content", ui.Val(uri)}}
+		if gw := ens.GatewayURL(uri); gw != uri {
+			pairs = append(pairs, [2]string{"Gateway", gw})
+		}
+		if name, err := ens.Normalize(args[0]); err == nil && strings.HasSuffix(name, ".eth") {
+			pairs = append(pairs, [2]string{"eth.limo", "https://" + name + ".limo"})
+		}
+		fmt.Println(ui.KeyValueBlock("ENS Contenthash · "+args[0], pairs))
+		return nil
+	},
+}
+
+var ensAddrCmd = &cobra.Command{
+	Use:   "addr <name>",
+	Short: "Resolve an ENS name's address on another chain",
+	Long: `Resolve the multichain address record (ENSIP-9/11) of an ENS name.
+
+--chain takes any chain from the registry (EVM chains use their ENSIP-11
+coin type and fall back to the name's default EVM address); --coin-type
+takes a raw SLIP-44 coin type. --all lists every registered chain.
+
+Examples:
+  w3cli ens addr vitalik.eth --chain base
+  w3cli ens addr vitalik.eth --chain solana
+  w3cli ens addr vitalik.eth --coin-type 0
+  w3cli ens addr vitalik.eth --all`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ensClient()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+
+		type target struct {
+			label string
+			coin  uint64
+		}
+		var targets []target
+		switch {
+		case ensAddrAll:
+			for _, c := range chain.NewRegistry().All() {
+				if coin, ok := ensCoinTypeForChain(&c); ok {
+					targets = append(targets, target{c.Name, coin})
+				}
+			}
+		case ensAddrCoin != "":
+			coin, err := strconv.ParseUint(ensAddrCoin, 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --coin-type %q", ensAddrCoin)
+			}
+			targets = append(targets, target{"coin " + ensAddrCoin, coin})
+		default:
+			chainName := ensAddrChain
+			if chainName == "" {
+				chainName = "ethereum"
+			}
+			c, err := chain.NewRegistry().GetByName(chainName)
+			if err != nil {
+				return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+			}
+			coin, ok := ensCoinTypeForChain(c)
+			if !ok {
+				return fmt.Errorf("ENS has no coin type for %s", c.DisplayName)
+			}
+			targets = append(targets, target{c.Name, coin})
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Resolving %s...", name))
+		spin.Start()
+		t := ui.NewTable([]ui.Column{
+			{Title: "CHAIN", Width: 16},
+			{Title: "COIN TYPE", Width: 12},
+			{Title: "ADDRESS", Width: 46},
+		})
+		found := 0
+		var lastErr error
+		for _, tg := range targets {
+			addr, err := ens.ResolveCoin(name, tg.coin, client)
+			if err != nil {
+				lastErr = err
+				if len(targets) > 1 && strings.Contains(err.Error(), "no address record") {
+					continue
+				}
+				spin.Stop()
+				return err
+			}
+			t.AddRow(ui.Row{tg.label, fmt.Sprintf("%d", tg.coin), addr})
+			found++
+		}
+		spin.Stop()
+
+		if found == 0 {
+			return lastErr
+		}
+		fmt.Println(t.Render())
+		return nil
+	},
+}
+
+// ensCoinTypeForChain maps a registry chain to its ENS coin type.
+func ensCoinTypeForChain(c *chain.Chain) (uint64, bool) {
+	switch c.Type {
+	case chain.ChainTypeSolana:
+		return ens.CoinTypeSolana, true
+	case chain.ChainTypeSUI:
+		return ens.CoinTypeSUI, true
+	}
+	if c.ChainID == 0 {
+		return 0, false
+	}
+	return ens.EVMCoinType(c.ChainID), true
+}
+
+// ensClient returns a mainnet client for the chain ENS is read from.
+func ensClient() (*chain.EVMClient, error) {
+	chainName := ensNetwork
+	if chainName == "" {
+		chainName = "ethereum"
+	}
+	c, err := chain.NewRegistry().GetByName(chainName)
+	if err != nil {
+		return nil, fmt.Errorf("unknown chain %q", chainName)
+	}
+	// Force mainnet for ENS resolution.
+	rpcURL, err := pickBestRPC(c, "mainnet")
+	if err != nil {
+		return nil, err
+	}
+	return chain.NewEVMClient(rpcURL), nil
+}
+
 func init() {
-	ensCmd.Flags().StringVar(&ensNetwork, "network", "", "chain for RPC (default: ethereum)")
+	ensCmd.PersistentFlags().StringVar(&ensNetwork, "network", "", "chain for RPC (default: ethereum)")
+	ensAddrCmd.Flags().StringVar(&ensAddrChain, "chain", "", "chain whose address to resolve (default: ethereum)")
+	ensAddrCmd.Flags().StringVar(&ensAddrCoin, "coin-type", "", "raw SLIP-44 / ENSIP-11 coin type")
+	ensAddrCmd.Flags().BoolVar(&ensAddrAll, "all", false, "resolve every registered chain")
+	ensCmd.AddCommand(ensTextCmd, ensAvatarCmd, ensContenthashCmd, ensAddrCmd)
 }
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/ens"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestENSCoinTypeForChain(t *testing.T) {
+	reg := chain.NewRegistry()
+	for name, want := range map[string]uint64{
+		"ethereum": ens.CoinTypeETH,
+		"base":     0x80000000 | 8453,
+		"solana":   ens.CoinTypeSolana,
+		"sui":      ens.CoinTypeSUI,
+	} {
+		c, err := reg.GetByName(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, ok := ensCoinTypeForChain(c)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, got, name)
+	}
+}
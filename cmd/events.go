@@ -56,7 +56,8 @@ Common events (Transfer, Approval, etc.) are auto-decoded.
 Examples:
   w3cli events 0xUSDC --network ethereum
   w3cli events 0xUSDC --topic 0xddf252... --from 0x100 --to latest
-  w3cli events 0xToken --count 20 --testnet`,
+  w3cli events 0xToken --count 20 --testnet
+  w3cli events myToken                          # registered contract name`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		contractAddr := args[0]
@@ -65,6 +66,10 @@ Examples:
 		if chainName == "" {
 			chainName = cfg.DefaultNetwork
 		}
+		contractAddr, err := resolveAddress(contractAddr, chainName)
+		if err != nil {
+			return err
+		}
 
 		reg := chain.NewRegistry()
 		c, err := reg.GetByName(chainName)
@@ -113,7 +118,7 @@ Examples:
 		}
 
 		if len(logs) == 0 {
-			fmt.Println(ui.Info(fmt.Sprintf("No events found for %s in the specified range", ui.TruncateAddr(contractAddr))))
+			fmt.Println(ui.Info(fmt.Sprintf("No events found for %s in the specified range", newAddressLabels(chainName).Short(contractAddr))))
 			return nil
 		}
 
@@ -124,10 +129,11 @@ Examples:
 			displayCount = eventsCount
 		}
 
+		labels := newAddressLabels(chainName)
 		fmt.Println(ui.KeyValueBlock(
 			fmt.Sprintf("Events · %s (%s)", c.DisplayName, cfg.NetworkMode),
 			[][2]string{
-				{"Contract", ui.Addr(contractAddr)},
+				{"Contract", labels.Addr(contractAddr)},
 				{"Found", fmt.Sprintf("%d events (showing %d)", len(logs)+(displayCount-len(logs)), displayCount)},
 				{"Block Range", fmt.Sprintf("%s → %s", fromBlock, toBlock)},
 			}))
@@ -170,7 +176,7 @@ Examples:
 						}
 					}
 					if !allZero {
-						topicVal = ui.Addr("0x" + addr)
+						topicVal = labels.Addr("0x" + addr)
 					}
 				}
 				pairs = append(pairs, [2]string{fmt.Sprintf("Topic[%d]", j), topicVal})
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/monitor"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	monitorRules    string
+	monitorNetwork  string
+	monitorInterval time.Duration
+	monitorOnce     bool
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Run alert rules against wallets and contracts",
+	Long: `Run a monitoring daemon driven by a rules file (YAML or JSON).
+
+Each rule watches one address or contract for a single kind of condition:
+
+  balance   balance_below / balance_above (native units)
+  event     event: Paused(address)      — any event signature or topic hash
+  tx        direction: in|out|any, method: transfer(address,uint256),
+            value_above: "10"           — matched against every new block
+
+Alerts go to one or more sinks: stdout, file (JSON lines), webhook
+(HTTP POST with a JSON body) or desktop (native notification). Repeated
+alerts for the same tx/log/balance state are deduplicated, and an optional
+rate limit caps alert volume during incidents.
+
+Example rules file:
+
+  interval: 15s
+  dedup: 10m
+  rate_limit: { max: 20, per: 1m }
+  sinks:
+    - type: stdout
+    - type: webhook
+      url: https://hooks.slack.com/services/...
+      headers: { Authorization: "Bearer $ALERT_TOKEN" }
+  rules:
+    - name: hot-wallet-low
+      network: base
+      address: 0xabc...
+      balance_below: "0.5"
+    - name: token-paused
+      network: ethereum
+      address: 0xToken...
+      event: Paused(address)
+      severity: critical
+    - name: deployer-activity
+      network: base
+      address: 0xDeployer...
+      direction: out
+
+Examples:
+  w3cli monitor --rules rules.yaml
+  w3cli monitor --rules rules.yaml --testnet --interval 30s
+  w3cli monitor --rules rules.yaml --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if monitorRules == "" {
+			return fmt.Errorf("--rules is required")
+		}
+		rf, err := monitor.LoadRules(monitorRules)
+		if err != nil {
+			return err
+		}
+
+		defNetwork := monitorNetwork
+		if defNetwork == "" {
+			defNetwork = cfg.DefaultNetwork
+		}
+
+		interval := monitorInterval
+		if interval <= 0 {
+			interval = rf.IntervalOr(15 * time.Second)
+		}
+
+		var notifiers []monitor.Notifier
+		var sinkNames []string
+		for _, spec := range rf.Sinks {
+			n, err := monitor.NewNotifier(spec)
+			if err != nil {
+				return err
+			}
+			notifiers = append(notifiers, n)
+			sinkNames = append(sinkNames, n.Name())
+		}
+		rateMax := rf.RateLimit.Max
+		ratePer, _ := time.ParseDuration(rf.RateLimit.Per)
+		dispatcher := monitor.NewDispatcher(notifiers, rf.DedupWindow(), rateMax, ratePer)
+		dispatcher.OnError(func(n monitor.Notifier, err error) {
+			fmt.Fprintln(os.Stderr, ui.Warn(fmt.Sprintf("sink %s: %v", n.Name(), err)))
+		})
+
+		reg := chain.NewRegistry()
+		var watchers []*monitor.Watcher
+		for _, name := range rf.Networks(defNetwork) {
+			c, err := reg.GetByName(name)
+			if err != nil {
+				return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", name)
+			}
+			if c.Type != chain.ChainTypeEVM {
+				return fmt.Errorf("monitor supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+			}
+			rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+			if err != nil {
+				return err
+			}
+			w := monitor.NewWatcher(name, c.NativeCurrency, chain.NewEVMClient(rpcURL), rf.Rules)
+			w.EventNames = knownEventTopics
+			watchers = append(watchers, w)
+		}
+
+		rateStr := "off"
+		if rateMax > 0 {
+			rateStr = fmt.Sprintf("%d per %s", rateMax, rf.RateLimit.Per)
+		}
+		fmt.Println(ui.KeyValueBlock(
+			fmt.Sprintf("Monitor · %s", cfg.NetworkMode),
+			[][2]string{
+				{"Rules", fmt.Sprintf("%d", len(rf.Rules))},
+				{"Networks", strings.Join(rf.Networks(defNetwork), ", ")},
+				{"Sinks", strings.Join(sinkNames, ", ")},
+				{"Interval", interval.String()},
+				{"Dedup", rf.DedupWindow().String()},
+				{"Rate limit", rateStr},
+			}))
+		fmt.Println()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if monitorOnce {
+			pollWatchers(ctx, watchers, dispatcher)
+			return nil
+		}
+
+		fmt.Println(ui.Info("Monitoring… press Ctrl+C to stop."))
+		pollWatchers(ctx, watchers, dispatcher)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if n := dispatcher.Suppressed(); n > 0 {
+					fmt.Println(ui.Meta(fmt.Sprintf("%d alert(s) dropped by rate limit", n)))
+				}
+				return nil
+			case <-ticker.C:
+				pollWatchers(ctx, watchers, dispatcher)
+			}
+		}
+	},
+}
+
+// pollWatchers runs one evaluation round on every network. RPC errors are
+// reported but never stop the daemon — the next tick retries from the same block.
+func pollWatchers(ctx context.Context, watchers []*monitor.Watcher, d *monitor.Dispatcher) {
+	for _, w := range watchers {
+		alerts, err := w.Poll()
+		for _, a := range alerts {
+			d.Dispatch(ctx, a)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.Warn(fmt.Sprintf("%s: %v", w.Network, err)))
+		}
+	}
+}
+
+func init() {
+	monitorCmd.Flags().StringVar(&monitorRules, "rules", "", "rules file (YAML or JSON)")
+	monitorCmd.Flags().StringVar(&monitorNetwork, "network", "", "default chain for rules without a network")
+	monitorCmd.Flags().DurationVar(&monitorInterval, "interval", 0, "poll interval (overrides the rules file)")
+	monitorCmd.Flags().BoolVar(&monitorOnce, "once", false, "evaluate balance rules once and exit (for cron)")
+}
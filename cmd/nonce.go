@@ -1,16 +1,26 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/nonce"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
 var (
 	nonceWallet  string
 	nonceNetwork string
+	nonceYes     bool
 )
 
 var nonceCmd = &cobra.Command{
@@ -21,9 +31,17 @@ var nonceCmd = &cobra.Command{
 If confirmed and pending nonces differ, it means transactions are pending
 or stuck in the mempool.
 
+Every w3cli command that sends a transaction reserves its nonce from a local
+store (nonces.json in the config dir, guarded by a lock file), so scripts and
+parallel shells sending from the same wallet never collide. The subcommands
+inspect and repair that store.
+
 Examples:
   w3cli nonce
-  w3cli nonce --wallet myWallet --network ethereum`,
+  w3cli nonce --wallet myWallet --network ethereum
+  w3cli nonce gaps
+  w3cli nonce fill
+  w3cli nonce reset`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		address, chainName, err := resolveWalletAndChain(nonceWallet, nonceNetwork)
 		if err != nil {
@@ -77,7 +95,265 @@ Examples:
 	},
 }
 
+var nonceGapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "List nonces handed out locally that the node has not seen",
+	Long: `Compare the local nonce store with the chain.
+
+A gap is a nonce w3cli reserved that is not counted by the node's pending
+nonce: the transaction may still be propagating, may be queued behind a lower
+gap, or was never broadcast. The lowest gap blocks every later transaction.
+
+Examples:
+  w3cli nonce gaps
+  w3cli nonce gaps --wallet deployer --network base`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address, c, client, chainID, err := nonceTarget()
+		if err != nil {
+			return err
+		}
+		st, err := newNonceManager().Status(client, chainID, address)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Nonce Gaps · %s (%s)", c.DisplayName, cfg.NetworkMode), [][2]string{
+			{"Wallet", ui.Addr(address)},
+			{"Confirmed", fmt.Sprintf("%d", st.Confirmed)},
+			{"Pending", fmt.Sprintf("%d", st.Pending)},
+			{"Local Next", fmt.Sprintf("%d", st.Next)},
+		}))
+		if len(st.Gaps) == 0 {
+			fmt.Println(ui.Success("No gaps — local store and node agree."))
+			return nil
+		}
+
+		t := ui.NewTable([]ui.Column{{Title: "NONCE", Width: 10}, {Title: "ISSUED", Width: 22}, {Title: "NOTE", Width: 30}})
+		for i, g := range st.Gaps {
+			issued, note := "-", "released, never sent"
+			if !g.IssuedAt.IsZero() {
+				issued = g.IssuedAt.Local().Format("2006-01-02 15:04:05")
+				note = fmt.Sprintf("%s ago", time.Since(g.IssuedAt).Round(time.Second))
+			}
+			if g.Held {
+				note += ", awaiting offline signature"
+			}
+			if i == 0 {
+				note += " (blocking)"
+			}
+			t.AddRow(ui.Row{fmt.Sprintf("%d", g.Nonce), issued, note})
+		}
+		fmt.Println(t.Render())
+		fmt.Println(ui.Hint("w3cli nonce fill   — plug holes with 0-value self-transfers"))
+		fmt.Println(ui.Hint("w3cli nonce reset  — forget local state and follow the node"))
+		return nil
+	},
+}
+
+var nonceResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Forget locally reserved nonces for a wallet on a chain",
+	Long: `Drop the local nonce state for a wallet so the next send starts from the
+node's pending nonce. Use this after transactions were dropped from the mempool.
+
+Examples:
+  w3cli nonce reset
+  w3cli nonce reset --wallet deployer --network base`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address, c, _, chainID, err := nonceTarget()
+		if err != nil {
+			return err
+		}
+		if err := newNonceManager().Reset(chainID, address); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Local nonce state cleared for %s on %s", ui.TruncateAddr(address), c.DisplayName)))
+		return nil
+	},
+}
+
+var nonceFillCmd = &cobra.Command{
+	Use:   "fill",
+	Short: "Fill nonce gaps with 0-value self-transfers",
+	Long: `Send a 0-value transfer to yourself at each nonce the node is missing, so
+transactions queued above the gap can be mined.
+
+Only the node's current pending nonce is ever filled, one at a time, so a
+transaction that is already queued is never replaced.
+
+Examples:
+  w3cli nonce fill
+  w3cli nonce fill --wallet deployer --network base --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walletName := nonceWallet
+		if walletName == "" {
+			walletName = cfg.DefaultWallet
+		}
+		w, _, err := loadSigningWallet(walletName)
+		if err != nil {
+			return err
+		}
+		nonceWallet = w.Address
+		address, c, client, chainID, err := nonceTarget()
+		if err != nil {
+			return err
+		}
+
+		mgr := newNonceManager()
+		st, err := mgr.Status(client, chainID, address)
+		if err != nil {
+			return err
+		}
+		if len(st.Gaps) == 0 {
+			fmt.Println(ui.Success("No gaps to fill."))
+			return nil
+		}
+
+		gasPrice, err := client.GasPrice()
+		if err != nil {
+			return err
+		}
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Fill Nonce Gaps · %s (%s)", c.DisplayName, cfg.NetworkMode), [][2]string{
+			{"Wallet", ui.Addr(address)},
+			{"Gaps", fmt.Sprintf("%d (nonces %d–%d)", len(st.Gaps), st.Gaps[0].Nonce, st.Gaps[len(st.Gaps)-1].Nonce)},
+			{"Per Tx", fmt.Sprintf("0 %s · %d gas · %d Gwei", c.NativeCurrency, config.GasLimitETHTransfer, toGwei(gasPrice))},
+		}))
+		if !nonceYes && !ui.Confirm(fmt.Sprintf("Send up to %d self-transfer(s)?", len(st.Gaps))) {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		signer := wallet.NewSigner(w, wallet.DefaultKeystore())
+		filled := 0
+		for range st.Gaps {
+			// Re-read the pending nonce each time: filling one hole lets the
+			// node promote queued txs above it.
+			pending, err := client.GetPendingNonce(address)
+			if err != nil {
+				return err
+			}
+			if pending >= st.Next {
+				break
+			}
+			hash, err := sendSelfTransfer(client, signer, address, pending, gasPrice, chainID)
+			if err != nil {
+				if isNonceOccupied(err) {
+					continue // a tx for this nonce arrived meanwhile
+				}
+				return fmt.Errorf("filling nonce %d: %w", pending, err)
+			}
+			filled++
+			fmt.Println(ui.Success(fmt.Sprintf("nonce %d · %s", pending, hash)))
+		}
+
+		if filled == 0 {
+			fmt.Println(ui.Info("The node caught up on its own — nothing to fill."))
+		} else {
+			fmt.Println(ui.Success(fmt.Sprintf("Filled %d gap(s)", filled)))
+		}
+		return nil
+	},
+}
+
+// sendSelfTransfer broadcasts a 0-value transfer from address to itself.
+func sendSelfTransfer(client *chain.EVMClient, signer *wallet.Signer, address string, n uint64, gasPrice *big.Int, chainID int64) (string, error) {
+	to := common.HexToAddress(address)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     n,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       config.GasLimitETHTransfer,
+		To:        &to,
+		Value:     big.NewInt(0),
+	})
+	raw, err := signer.SignTx(tx, big.NewInt(chainID))
+	if err != nil {
+		return "", err
+	}
+	return client.SendRawTransaction("0x" + hex.EncodeToString(raw))
+}
+
+// nonceTarget resolves the --wallet/--network flags to an EVM client.
+func nonceTarget() (string, *chain.Chain, *chain.EVMClient, int64, error) {
+	address, chainName, err := resolveWalletAndChain(nonceWallet, nonceNetwork)
+	if err != nil {
+		return "", nil, nil, 0, err
+	}
+	c, err := chain.NewRegistry().GetByName(chainName)
+	if err != nil {
+		return "", nil, nil, 0, fmt.Errorf("unknown chain %q — run `w3cli network list`", chainName)
+	}
+	if c.Type != chain.ChainTypeEVM {
+		return "", nil, nil, 0, fmt.Errorf("nonces apply to EVM chains only (%s is %s)", c.DisplayName, c.Type)
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return "", nil, nil, 0, err
+	}
+	client := chain.NewEVMClient(rpcURL)
+	chainID, err := client.ChainID()
+	if err != nil {
+		return "", nil, nil, 0, err
+	}
+	return address, c, client, chainID, nil
+}
+
+func newNonceManager() *nonce.Manager {
+	return nonce.NewManager(cfg.Dir())
+}
+
+// nonceLease is a nonce reserved for one transaction. Call Used once the
+// transaction is broadcast; Release (usually deferred) returns it otherwise.
+type nonceLease struct {
+	Nonce uint64
+	src   *nonce.Source
+	used  bool
+}
+
+// reserveNonce takes the next nonce for address from the shared local store.
+// Call it after the user has confirmed, just before signing: a deferred
+// Release does not run when the process is interrupted, and until the lease
+// expires the nonce blocks every later send.
+func reserveNonce(client *chain.EVMClient, chainID int64, address string) (*nonceLease, error) {
+	src := newNonceManager().For(client, chainID, address)
+	n, err := src.Next()
+	if err != nil {
+		return nil, err
+	}
+	return &nonceLease{Nonce: n, src: src}, nil
+}
+
+// Used marks the nonce as spent.
+func (l *nonceLease) Used() { l.used = true }
+
+// Hold marks the nonce as spent by a transaction signed elsewhere, so it
+// stays reserved past the lease until the node sees it.
+func (l *nonceLease) Hold() error {
+	if err := l.src.Hold(l.Nonce); err != nil {
+		return err
+	}
+	l.used = true
+	return nil
+}
+
+// Release returns the nonce to the store unless it was used.
+func (l *nonceLease) Release() {
+	if !l.used {
+		l.src.Release(l.Nonce) //nolint:errcheck
+	}
+}
+
+// isNonceOccupied reports errors meaning the node already has a tx at the nonce.
+func isNonceOccupied(err error) bool {
+	return chain.IsAlreadyKnown(err) || chain.IsNonceTooLow(err) ||
+		strings.Contains(strings.ToLower(err.Error()), "replacement transaction underpriced")
+}
+
 func init() {
-	nonceCmd.Flags().StringVar(&nonceWallet, "wallet", "", "wallet name or address")
-	nonceCmd.Flags().StringVar(&nonceNetwork, "network", "", "chain to query (default: config)")
+	nonceCmd.PersistentFlags().StringVar(&nonceWallet, "wallet", "", "wallet name or address")
+	nonceCmd.PersistentFlags().StringVar(&nonceNetwork, "network", "", "chain to query (default: config)")
+	nonceFillCmd.Flags().BoolVarP(&nonceYes, "yes", "y", false, "skip the confirmation prompt")
+	nonceCmd.AddCommand(nonceGapsCmd, nonceResetCmd, nonceFillCmd)
 }
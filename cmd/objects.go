@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	objectsWallet  string
+	objectsNetwork string
+	objectsLimit   int
+)
+
+var objectsCmd = &cobra.Command{
+	Use:   "objects [wallet-name-or-address]",
+	Short: "List objects owned by a SUI address",
+	Long: `List the objects (coins, NFTs, capabilities…) owned by a SUI address.
+
+Examples:
+  w3cli objects mySuiWallet
+  w3cli objects 0x5f… --testnet --limit 100`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 && objectsWallet == "" {
+			objectsWallet = args[0]
+		}
+		address, chainName, err := resolveWalletAndChain(objectsWallet, objectsNetwork)
+		if err != nil {
+			return err
+		}
+		c, err := chain.NewRegistry().GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+		}
+		if c.Type != chain.ChainTypeSUI {
+			return fmt.Errorf("objects are only available on SUI — pass --network sui")
+		}
+
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return err
+		}
+		spin := ui.NewSpinner(fmt.Sprintf("Fetching objects on %s (%s)...", c.DisplayName, cfg.NetworkMode))
+		spin.Start()
+		objs, err := chain.NewSUIClient(rpcURL).GetOwnedObjects(address, objectsLimit)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		if len(objs) == 0 {
+			fmt.Println(ui.Info("No objects owned by " + address + "."))
+			return nil
+		}
+
+		t := ui.NewTable([]ui.Column{
+			{Title: "OBJECT ID", Width: 14},
+			{Title: "VERSION", Width: 10},
+			{Title: "TYPE", Width: 60},
+		})
+		for _, o := range objs {
+			typ := o.Type
+			if len(typ) > 60 {
+				typ = typ[:28] + "…" + typ[len(typ)-31:]
+			}
+			t.AddRow(ui.Row{ui.TruncateAddr(o.ObjectID), o.Version, typ})
+		}
+		fmt.Println(t.Render())
+		fmt.Println(ui.Meta(fmt.Sprintf("%d object(s)", len(objs))))
+		if len(objs) == objectsLimit {
+			fmt.Println(ui.Hint("More may exist — raise --limit to see them."))
+		}
+		return nil
+	},
+}
+
+func init() {
+	objectsCmd.Flags().StringVar(&objectsWallet, "wallet", "", "wallet name or address")
+	objectsCmd.Flags().StringVar(&objectsNetwork, "network", "", "chain to query (default: sui for SUI wallets)")
+	objectsCmd.Flags().IntVar(&objectsLimit, "limit", 50, "maximum number of objects to list")
+}
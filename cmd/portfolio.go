@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/price"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portfolioChains string
+	portfolioTokens []string
+	portfolioMin    float64
+)
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio [wallet...]",
+	Short: "Value native and token holdings across chains",
+	Long: `Total native coins and token holdings per wallet across chains, valued in
+the configured price currency (w3cli config set-currency).
+
+EVM wallets are checked on the major mainnets for well-known tokens (USDC,
+USDT, WETH, ...), every ERC-20 registered with w3cli contract add and the
+tokens of subscribed token lists (w3cli tokens list add).
+Solana wallets include SPL token accounts and SUI wallets every coin type.
+Balances are always read from mainnet.
+
+Token prices come from the configured sources (w3cli config
+set-price-sources) and are cached for 5 minutes.
+
+Examples:
+  w3cli portfolio                              # every wallet
+  w3cli portfolio alice bob
+  w3cli portfolio 0xabc... --chains all        # every EVM mainnet
+  w3cli portfolio --chains base --token 0xTOKEN...
+  w3cli portfolio --min 1                      # hide dust below 1 unit of currency`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targets, err := portfolioTargets(args)
+		if err != nil {
+			return err
+		}
+		evmChains, err := portfolioEVMChains(portfolioChains)
+		if err != nil {
+			return err
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Reading balances for %d wallet(s)...", len(targets)))
+		spin.Start()
+		holdings, warnings := collectHoldings(targets, evmChains)
+		spin.Stop()
+
+		spin = ui.NewSpinner("Fetching prices...")
+		spin.Start()
+		priceHoldings(newPriceFetcher(), holdings)
+		spin.Stop()
+
+		for _, w := range warnings {
+			fmt.Println(ui.Warn(w))
+		}
+		printPortfolio(targets, holdings)
+		return nil
+	},
+}
+
+// portfolioTarget is a wallet (or raw address) to value.
+type portfolioTarget struct {
+	Label     string
+	Address   string
+	ChainType string
+}
+
+// holding is one asset balance of one wallet on one chain.
+type holding struct {
+	Wallet string
+	Chain  string
+	Symbol string
+	Token  string // empty for the native coin
+	Amount float64
+	Price  float64
+	Priced bool
+	Source string
+}
+
+func (h *holding) Value() float64 { return h.Amount * h.Price }
+
+// portfolioTargets resolves args to wallets; no args means every wallet.
+func portfolioTargets(args []string) ([]portfolioTarget, error) {
+	mgr := newWalletManager()
+	var out []portfolioTarget
+	if len(args) == 0 {
+		for _, w := range mgr.List() {
+			out = append(out, portfolioTarget{Label: w.Name, Address: w.Address, ChainType: w.Chain()})
+		}
+		if len(out) == 0 {
+			return nil, fmt.Errorf("no wallets — pass an address or add one: w3cli wallet add <name> <address>")
+		}
+		return out, nil
+	}
+
+	for _, a := range args {
+		address, chainName, err := resolveWalletAndChain(a, "")
+		if err != nil {
+			return nil, err
+		}
+		chainType := wallet.ChainEVM
+		if c, err := chain.NewRegistry().GetByName(chainName); err == nil && c.Type != chain.ChainTypeEVM {
+			chainType = string(c.Type)
+		}
+		out = append(out, portfolioTarget{Label: a, Address: address, ChainType: chainType})
+	}
+	return out, nil
+}
+
+// portfolioEVMChains returns the EVM chains to scan: the comma-separated
+// flag, "all", or by default the chains with a well-known token list.
+func portfolioEVMChains(flag string) ([]*chain.Chain, error) {
+	reg := chain.NewRegistry()
+	var out []*chain.Chain
+	switch strings.TrimSpace(flag) {
+	case "":
+		for _, c := range reg.All() {
+			if _, ok := wellKnownTokens[c.Name]; ok && c.Type == chain.ChainTypeEVM {
+				c := c
+				out = append(out, &c)
+			}
+		}
+	case "all":
+		for _, c := range reg.All() {
+			if c.Type == chain.ChainTypeEVM {
+				c := c
+				out = append(out, &c)
+			}
+		}
+	default:
+		for _, name := range strings.Split(flag, ",") {
+			c, err := reg.GetByName(strings.TrimSpace(name))
+			if err != nil {
+				return nil, fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", name)
+			}
+			if c.Type != chain.ChainTypeEVM {
+				continue // Solana/SUI wallets are always scanned on their own chain.
+			}
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// collectHoldings reads every target's balances concurrently, one goroutine
+// per wallet and chain. Failures become warnings so one bad RPC doesn't hide
+// the rest of the portfolio.
+func collectHoldings(targets []portfolioTarget, evmChains []*chain.Chain) ([]*holding, []string) {
+	reg := chain.NewRegistry()
+	contracts := newContractRegistry()
+	_ = contracts.Load()
+	lists := newTokenListStore()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		holdings []*holding
+		warnings []string
+	)
+	add := func(hs []*holding, err error, where string) {
+		mu.Lock()
+		defer mu.Unlock()
+		holdings = append(holdings, hs...)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", where, err))
+		}
+	}
+
+	for _, t := range targets {
+		t := t
+		switch t.ChainType {
+		case wallet.ChainSolana, wallet.ChainSUI:
+			c, err := reg.GetByName(t.ChainType)
+			if err != nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var hs []*holding
+				var err error
+				if t.ChainType == wallet.ChainSolana {
+					hs, err = solanaHoldings(c, t)
+				} else {
+					hs, err = suiHoldings(c, t)
+				}
+				add(hs, err, t.Label+" on "+c.DisplayName)
+			}()
+		default:
+			for _, c := range evmChains {
+				c := c
+				tokens := trackedTokens(c.Name, contracts, lists)
+				for _, extra := range portfolioTokens {
+					tokens = append(tokens, trackedToken{Address: extra, Symbol: ui.TruncateAddr(extra), Decimals: -1})
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					hs, err := evmHoldings(c, t, tokens)
+					add(hs, err, t.Label+" on "+c.DisplayName)
+				}()
+			}
+		}
+	}
+	wg.Wait()
+	return holdings, warnings
+}
+
+func evmHoldings(c *chain.Chain, t portfolioTarget, tokens []trackedToken) ([]*holding, error) {
+	url, err := pickBestRPC(c, "mainnet")
+	if err != nil {
+		return nil, err
+	}
+	client := chain.NewEVMClient(url)
+	bal, err := client.GetBalance(t.Address)
+	if err != nil {
+		return nil, err
+	}
+	var out []*holding
+	if bal.Wei.Sign() > 0 {
+		out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: c.NativeCurrency, Amount: parseFloat(bal.ETH)})
+	}
+	held, err := fetchTokenHoldings(client, t.Address, tokens)
+	for _, th := range held {
+		out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: th.Token.Symbol, Token: th.Token.Address, Amount: parseFloat(th.Amount)})
+	}
+	return out, err
+}
+
+func solanaHoldings(c *chain.Chain, t portfolioTarget) ([]*holding, error) {
+	url, err := pickBestRPC(c, "mainnet")
+	if err != nil {
+		return nil, err
+	}
+	client := chain.NewSolanaClient(url)
+	bal, err := client.GetBalance(t.Address)
+	if err != nil {
+		return nil, err
+	}
+	var out []*holding
+	if bal.Wei.Sign() > 0 {
+		out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: c.NativeCurrency, Amount: parseFloat(bal.ETH)})
+	}
+	accounts, err := client.GetTokenAccountsByOwner(t.Address, "")
+	if err != nil {
+		return out, fmt.Errorf("token accounts: %w", err)
+	}
+	for _, a := range accounts {
+		if a.Amount.Sign() == 0 {
+			continue
+		}
+		out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: ui.TruncateAddr(a.Mint), Token: a.Mint, Amount: parseFloat(a.Formatted)})
+	}
+	return out, nil
+}
+
+func suiHoldings(c *chain.Chain, t portfolioTarget) ([]*holding, error) {
+	url, err := pickBestRPC(c, "mainnet")
+	if err != nil {
+		return nil, err
+	}
+	client := chain.NewSUIClient(url)
+	balances, err := client.GetAllBalances(t.Address)
+	if err != nil {
+		return nil, err
+	}
+	var out []*holding
+	for _, b := range balances {
+		if b.Total.Sign() == 0 {
+			continue
+		}
+		if b.CoinType == chain.SUICoinType {
+			out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: c.NativeCurrency, Amount: parseFloat(formatTokenAmount(b.Total, 9))})
+			continue
+		}
+		symbol, decimals := b.CoinType, 0
+		if md, err := client.GetCoinMetadata(b.CoinType); err == nil && md != nil {
+			symbol, decimals = md.Symbol, md.Decimals
+		}
+		out = append(out, &holding{Wallet: t.Label, Chain: c.Name, Symbol: symbol, Token: b.CoinType, Amount: parseFloat(formatTokenAmount(b.Total, decimals))})
+	}
+	return out, nil
+}
+
+// priceHoldings fills in prices: native coins in one batch, tokens in one
+// request per chain.
+func priceHoldings(f *price.Fetcher, holdings []*holding) {
+	chains := map[string]bool{}
+	tokensByChain := map[string][]string{}
+	for _, h := range holdings {
+		if h.Token == "" {
+			chains[h.Chain] = true
+		} else {
+			tokensByChain[h.Chain] = append(tokensByChain[h.Chain], h.Token)
+		}
+	}
+
+	names := make([]string, 0, len(chains))
+	for n := range chains {
+		names = append(names, n)
+	}
+	native, _ := f.GetPrices(names)
+
+	tokenPrices := map[string]map[string]price.Quote{}
+	for chainName, tokens := range tokensByChain {
+		if quotes, err := f.GetTokenPrices(chainName, tokens); err == nil {
+			tokenPrices[chainName] = quotes
+		}
+	}
+
+	for _, h := range holdings {
+		if h.Token == "" {
+			if p, ok := native[h.Chain]; ok && p > 0 {
+				h.Price, h.Priced, h.Source = p, true, "coingecko"
+			}
+			continue
+		}
+		if q, ok := tokenPrices[h.Chain][price.TokenKey(h.Token)]; ok {
+			h.Price, h.Priced, h.Source = q.Price, true, q.Source
+		}
+	}
+}
+
+func printPortfolio(targets []portfolioTarget, holdings []*holding) {
+	byWallet := map[string][]*holding{}
+	for _, h := range holdings {
+		byWallet[h.Wallet] = append(byWallet[h.Wallet], h)
+	}
+
+	cur := strings.ToUpper(cfg.PriceCurrency)
+	var grand float64
+	unpriced := 0
+	for _, t := range targets {
+		hs := byWallet[t.Label]
+		sort.Slice(hs, func(i, j int) bool { return hs[i].Value() > hs[j].Value() })
+
+		fmt.Println(ui.StyleTitle.Render(fmt.Sprintf("%s  %s", t.Label, ui.TruncateAddr(t.Address))))
+		table := ui.NewTable([]ui.Column{
+			{Title: "Chain", Width: 12},
+			{Title: "Asset", Width: 14},
+			{Title: "Balance", Width: 20},
+			{Title: "Price", Width: 14},
+			{Title: "Value (" + cur + ")", Width: 16},
+		})
+		var total float64
+		shown := 0
+		for _, h := range hs {
+			if h.Priced && portfolioMin > 0 && h.Value() < portfolioMin {
+				continue
+			}
+			priceStr, valueStr := "—", "—"
+			if h.Priced {
+				priceStr = fiat(h.Price)
+				valueStr = fiat(h.Value())
+				total += h.Value()
+			} else {
+				unpriced++
+			}
+			table.AddRow(ui.Row{ui.ChainName(h.Chain), h.Symbol, trimAmount(h.Amount), priceStr, valueStr})
+			shown++
+		}
+		if shown == 0 {
+			fmt.Println(ui.Meta("  No holdings found."))
+		} else {
+			fmt.Println(table.Render())
+		}
+		fmt.Println(ui.Meta("  Total: ") + ui.Val(fiat(total)))
+		fmt.Println()
+		grand += total
+	}
+
+	if len(targets) > 1 {
+		fmt.Println(ui.Success(fmt.Sprintf("Portfolio total: %s across %d wallet(s)", fiat(grand), len(targets))))
+	}
+	if unpriced > 0 {
+		fmt.Println(ui.Hint(fmt.Sprintf("%d holding(s) had no price from %s.", unpriced, strings.Join(priceSourceNames(), ", "))))
+	}
+}
+
+// trimAmount renders an amount with up to 6 decimals and no trailing zeros.
+func trimAmount(v float64) string {
+	s := new(big.Float).SetFloat64(v).Text('f', 6)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+func init() {
+	portfolioCmd.Flags().StringVar(&portfolioChains, "chains", "", `EVM chains to scan, comma-separated, or "all" (default: chains with known tokens)`)
+	portfolioCmd.Flags().StringSliceVar(&portfolioTokens, "token", nil, "extra ERC-20 token address to include (repeatable)")
+	portfolioCmd.Flags().Float64Var(&portfolioMin, "min", 0, "hide priced holdings worth less than this")
+}
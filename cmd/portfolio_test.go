@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackedTokensAddsRegisteredERC20s(t *testing.T) {
+	reg := contract.NewRegistry(filepath.Join(t.TempDir(), "contracts.json"))
+	erc20 := []contract.ABIEntry{
+		{Name: "balanceOf", Type: "function"},
+		{Name: "decimals", Type: "function"},
+		{Name: "transfer", Type: "function"},
+	}
+	reg.Add(&contract.Entry{Name: "myToken", Network: "base", Address: "0x1111111111111111111111111111111111111111", ABI: erc20})
+	reg.Add(&contract.Entry{Name: "nft", Network: "base", Address: "0x2222222222222222222222222222222222222222",
+		ABI: []contract.ABIEntry{{Name: "balanceOf", Type: "function"}, {Name: "ownerOf", Type: "function"}}})
+	reg.Add(&contract.Entry{Name: "usdcAgain", Network: "base", Address: "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913", ABI: erc20})
+	reg.Add(&contract.Entry{Name: "elsewhere", Network: "polygon", Address: "0x3333333333333333333333333333333333333333", ABI: erc20})
+
+	tokens := trackedTokens("base", reg, nil)
+	assert.Len(t, tokens, len(wellKnownTokens["base"])+1)
+	last := tokens[len(tokens)-1]
+	assert.Equal(t, "myToken", last.Symbol)
+	assert.Equal(t, -1, last.Decimals, "registered tokens read decimals on-chain")
+
+	assert.Empty(t, trackedTokens("fantom", nil, nil))
+}
+
+func TestIsPriceSource(t *testing.T) {
+	for _, s := range defaultPriceSources {
+		assert.True(t, isPriceSource(s))
+	}
+	assert.False(t, isPriceSource("binance"))
+}
+
+func TestTrimAmount(t *testing.T) {
+	assert.Equal(t, "1.5", trimAmount(1.5))
+	assert.Equal(t, "2", trimAmount(2))
+	assert.Equal(t, "0.000001", trimAmount(0.000001))
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/price"
+	"github.com/Mohsinsiddi/w3cli/internal/tokenlist"
+)
+
+// defaultPriceSources is the token price source order when the config
+// doesn't set one: the two APIs first, then the on-chain fallbacks.
+var defaultPriceSources = []string{"coingecko", "defillama", "chainlink", "uniswap"}
+
+func isPriceSource(name string) bool {
+	for _, s := range defaultPriceSources {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// priceSourceNames returns the configured source order.
+func priceSourceNames() []string {
+	if len(cfg.PriceSources) > 0 {
+		return cfg.PriceSources
+	}
+	return defaultPriceSources
+}
+
+// newPriceFetcher returns a fetcher in the configured currency with the
+// configured sources and the on-disk price cache.
+func newPriceFetcher() *price.Fetcher {
+	f := price.NewFetcher(cfg.PriceCurrency)
+	f.SetCache(price.NewCache(filepath.Join(cfg.Dir(), "prices.json"), price.DefaultCacheTTL))
+
+	reg := chain.NewRegistry()
+	clients := map[string]price.Caller{}
+	dial := func(chainName string) (price.Caller, error) {
+		if c, ok := clients[chainName]; ok {
+			return c, nil
+		}
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return nil, err
+		}
+		url, err := pickBestRPC(c, "mainnet")
+		if err != nil {
+			return nil, err
+		}
+		clients[chainName] = chain.NewEVMClient(url)
+		return clients[chainName], nil
+	}
+
+	var sources []price.Source
+	for _, name := range priceSourceNames() {
+		switch name {
+		case "coingecko":
+			sources = append(sources, price.NewCoinGecko(nil))
+		case "defillama":
+			sources = append(sources, price.NewDefiLlama(nil))
+		case "chainlink":
+			sources = append(sources, price.NewChainlink(dial))
+		case "uniswap":
+			sources = append(sources, price.NewUniswapTWAP(dial))
+		}
+	}
+	f.SetSources(sources...)
+	return f
+}
+
+// fiat formats a value in the configured currency.
+func fiat(v float64) string {
+	return price.Format(v, cfg.PriceCurrency)
+}
+
+// trackedToken is an ERC-20 whose balance portfolio-style views check.
+type trackedToken struct {
+	Address  string
+	Symbol   string
+	Decimals int
+}
+
+// wellKnownTokens are the major tokens checked on each mainnet by default.
+var wellKnownTokens = map[string][]trackedToken{
+	"ethereum": {
+		{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "USDC", 6},
+		{"0xdAC17F958D2ee523a2206206994597C13D831ec7", "USDT", 6},
+		{"0x6B175474E89094C44Da98b954EedeAC495271d0F", "DAI", 18},
+		{"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "WETH", 18},
+		{"0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", "WBTC", 8},
+		{"0x514910771AF9Ca656af840dff83E8264EcF986CA", "LINK", 18},
+	},
+	"base": {
+		{"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", "USDC", 6},
+		{"0x4200000000000000000000000000000000000006", "WETH", 18},
+	},
+	"arbitrum": {
+		{"0xaf88d065e77c8cC2239327C5EDb3A432268e5831", "USDC", 6},
+		{"0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9", "USDT", 6},
+		{"0x82aF49447D8a07e3bd95BD0d56f35241523fBab1", "WETH", 18},
+		{"0x912CE59144191C1204E64559FE8253a0e49E6548", "ARB", 18},
+	},
+	"optimism": {
+		{"0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85", "USDC", 6},
+		{"0x4200000000000000000000000000000000000006", "WETH", 18},
+		{"0x4200000000000000000000000000000000000042", "OP", 18},
+	},
+	"polygon": {
+		{"0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", "USDC", 6},
+		{"0xc2132D05D31c914a87C6611C10748AEb04B58e8F", "USDT", 6},
+		{"0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619", "WETH", 18},
+	},
+	"bnb": {
+		{"0x55d398326f99059fF775485246999027B3197955", "USDT", 18},
+		{"0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d", "USDC", 18},
+	},
+}
+
+// trackedTokens returns the tokens to check on mainnet chainName: the
+// well-known list, ERC-20 contracts registered on that network, and the
+// tokens of the subscribed token lists. Decimals of registered tokens are
+// unknown (-1) until read on-chain.
+func trackedTokens(chainName string, reg *contract.Registry, lists *tokenlist.Store) []trackedToken {
+	out := append([]trackedToken(nil), wellKnownTokens[chainName]...)
+	seen := map[string]bool{}
+	for _, t := range out {
+		seen[strings.ToLower(t.Address)] = true
+	}
+	if reg != nil {
+		for _, e := range reg.All() {
+			if e.Network != chainName || seen[strings.ToLower(e.Address)] || !isERC20ABI(e.ABI) {
+				continue
+			}
+			seen[strings.ToLower(e.Address)] = true
+			out = append(out, trackedToken{Address: e.Address, Symbol: e.Name, Decimals: -1})
+		}
+	}
+	if lists != nil {
+		if c, err := chain.NewRegistry().GetByName(chainName); err == nil {
+			for _, t := range listedTokens(lists, c.ChainID) {
+				if seen[strings.ToLower(t.Address)] {
+					continue
+				}
+				seen[strings.ToLower(t.Address)] = true
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// isERC20ABI reports whether an ABI has the fungible-token read functions.
+func isERC20ABI(abi []contract.ABIEntry) bool {
+	has := map[string]bool{}
+	for _, e := range abi {
+		if e.Type == "function" {
+			has[e.Name] = true
+		}
+	}
+	return has["balanceOf"] && has["decimals"] && has["transfer"]
+}
+
+// tokenHolding is a non-zero ERC-20 balance.
+type tokenHolding struct {
+	Token  trackedToken
+	Amount string // formatted
+}
+
+// fetchTokenHoldings returns the non-zero balances of tokens held by address.
+// Balances are read in batched balanceOf calls; decimals are only read for
+// held tokens that don't know them.
+func fetchTokenHoldings(client *chain.EVMClient, address string, tokens []trackedToken) ([]tokenHolding, error) {
+	addrs := make([]string, len(tokens))
+	for i, t := range tokens {
+		addrs[i] = t.Address
+	}
+	raws, err := client.GetTokenBalances(addrs, address)
+	if err != nil {
+		return nil, fmt.Errorf("reading token balances: %w", err)
+	}
+	var out []tokenHolding
+	for _, t := range tokens {
+		raw, ok := raws[strings.ToLower(t.Address)]
+		if !ok || raw.Sign() == 0 {
+			continue
+		}
+		if t.Decimals < 0 {
+			d, err := readTokenDecimals(client, t.Address)
+			if err != nil {
+				continue // an amount scaled by a guess is worse than none
+			}
+			t.Decimals = d
+		}
+		out = append(out, tokenHolding{Token: t, Amount: formatTokenAmount(raw, t.Decimals)})
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+)
+
+// proxyPairs renders a detected proxy as key/value rows for KeyValueBlock.
+func proxyPairs(p *contract.ProxyInfo) [][2]string {
+	if p == nil {
+		return nil
+	}
+	pairs := [][2]string{{"Proxy", ui.Val(p.Kind)}}
+	impl := p.Implementation
+	if impl == "" {
+		impl = "unknown"
+	} else {
+		impl = ui.Addr(impl)
+	}
+	pairs = append(pairs, [2]string{"Implementation", impl})
+	if p.Admin != "" {
+		pairs = append(pairs, [2]string{"Admin", ui.Addr(p.Admin)})
+	}
+	if p.Beacon != "" {
+		pairs = append(pairs, [2]string{"Beacon", ui.Addr(p.Beacon)})
+	}
+	return pairs
+}
+
+// fetchContractABI fetches a verified ABI from the chain's explorer, falling
+// back to Sourcify. For a proxy the implementation's ABI is fetched too and
+// merged with the proxy's own, so read/write functions of the
+// implementation are callable by name.
+func fetchContractABI(c *chain.Chain, chainName, address string, proxy *contract.ProxyInfo) ([]contract.ABIEntry, error) {
+	fetch := verifiedABISource(c, chainName)
+
+	own, ownErr := fetch(address)
+	if proxy == nil || proxy.Implementation == "" {
+		return own, ownErr
+	}
+	impl, err := fetch(proxy.Implementation)
+	if err != nil {
+		if ownErr != nil {
+			return nil, fmt.Errorf("implementation %s: %w", proxy.Implementation, err)
+		}
+		fmt.Println(ui.Warn(fmt.Sprintf("Implementation ABI unavailable (%v) — using the proxy's own ABI.", err)))
+		return own, nil
+	}
+	return contract.MergeABI(impl, own), nil
+}
+
+// verifiedABISource returns a fetcher that asks the explorer first and then
+// Sourcify, which has full and partial matches for contracts the explorer
+// does not know.
+func verifiedABISource(c *chain.Chain, chainName string) func(address string) ([]contract.ABIEntry, error) {
+	api := c.ExplorerAPIURL(cfg.NetworkMode)
+	chainID := c.ChainIDFor(cfg.NetworkMode)
+	f := contract.NewFetcher(cfg.GetExplorerAPIKey(chainName))
+
+	return func(address string) ([]contract.ABIEntry, error) {
+		explorerErr := fmt.Errorf("no explorer API registered for %s (%s)", c.DisplayName, cfg.NetworkMode)
+		if api != "" {
+			abi, err := f.FetchFromExplorer(strings.TrimSuffix(api, "/api"), address) // FetchFromExplorer appends /api
+			if err == nil {
+				return abi, nil
+			}
+			explorerErr = err
+		}
+		if chainID == 0 {
+			return nil, explorerErr
+		}
+		abi, match, err := f.FetchFromSourcify(contract.SourcifyServer, chainID, address)
+		if err != nil {
+			return nil, fmt.Errorf("%v; Sourcify: %v", explorerErr, err)
+		}
+		fmt.Println(ui.Meta(fmt.Sprintf("ABI for %s from Sourcify (%s match)", ui.TruncateAddr(address), match)))
+		return abi, nil
+	}
+}
@@ -0,0 +1,756 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	chainpkg "github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	proxyNetwork string
+	proxyWallet  string
+	proxyYes     bool
+
+	proxyDeployKind  string
+	proxyDeployInit  string
+	proxyDeployAdmin string
+	proxyDeployName  string
+	proxyDeployImpl  string
+	proxyArgs        []string
+
+	proxyUpgradeCall     string
+	proxyUpgradeFrom     string
+	proxyUpgradeSkipSafe bool
+
+	proxyValidateKind string
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Deploy and upgrade ERC-1967 proxies with storage-layout checks",
+	Long: `Deploy upgradeable contracts behind ERC-1967 proxies and upgrade them safely:
+  deploy    deploy an implementation and a UUPS or transparent proxy for it
+  upgrade   deploy a new implementation and point the proxy at it
+  validate  check two artifacts' storage layouts are upgrade-compatible
+  history   list the implementations a proxy has pointed to
+
+Upgrades compare the storage layout of the current implementation's artifact
+with the new one first: variables may be appended (or take space from a
+__gap) but never moved, removed or retyped. Artifacts need a storage layout —
+Foundry: extra_output = ["storageLayout"]; Hardhat: add "storageLayout" to
+outputSelection.
+
+Examples:
+  w3cli proxy deploy ./out/Vault.sol/Vault.json --kind uups --init "initialize(address)" --arg 0xOwner
+  w3cli proxy upgrade Vault ./out/VaultV2.sol/VaultV2.json --network base
+  w3cli proxy validate ./out/Vault.sol/Vault.json ./out/VaultV2.sol/VaultV2.json
+  w3cli proxy history Vault --network base`,
+}
+
+var proxyDeployCmd = &cobra.Command{
+	Use:   "deploy <impl-artifact>",
+	Short: "Deploy an implementation behind a new UUPS or transparent proxy",
+	Long: `Deploy the implementation from an artifact, then an ERC-1967 proxy that points
+at it and calls the initializer given with --init and --arg in the same
+transaction, so nobody can initialize it first.
+
+  --kind uups         upgrades go through the implementation (UUPSUpgradeable);
+                      the implementation must keep upgradeToAndCall
+  --kind transparent  upgrades go through the proxy's admin (--admin, default
+                      the deploying wallet), which can only upgrade and change
+                      the admin — it cannot use the contract through the proxy
+
+The proxy is registered under the implementation's name (or --name) with its
+implementation history, so "contract studio" works on it and "proxy upgrade"
+knows which artifact it was built from.
+
+Examples:
+  w3cli proxy deploy ./out/Vault.sol/Vault.json --init "initialize(address)" --arg 0xOwner
+  w3cli proxy deploy ./artifacts/Box.json --kind transparent --admin 0xSafe --network base
+  w3cli proxy deploy ./out/Vault.sol/Vault.json --impl 0xAlreadyDeployedImpl --name VaultB`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		artifactPath := args[0]
+		kind, err := parseProxyDeployKind(proxyDeployKind)
+		if err != nil {
+			return err
+		}
+		artifact, err := contract.LoadArtifactFull(artifactPath)
+		if err != nil {
+			return err
+		}
+		if err := checkImplementationArtifact(artifact, kind); err != nil {
+			return err
+		}
+		initData, err := buildTxData("", proxyDeployInit, proxyArgs)
+		if err != nil {
+			return fmt.Errorf("--init: %w", err)
+		}
+		name := proxyDeployName
+		if name == "" {
+			name = artifactContractName(artifactPath)
+		}
+
+		chainName, c, client, err := proxyClient()
+		if err != nil {
+			return err
+		}
+		w, _, err := loadSigningWallet(proxyWalletName())
+		if err != nil {
+			return err
+		}
+
+		admin := common.HexToAddress(w.Address)
+		if proxyDeployAdmin != "" {
+			if kind != contract.ProxyDeployTransparent {
+				return fmt.Errorf("--admin is for --kind transparent; a UUPS proxy's upgrade rights live in the implementation")
+			}
+			resolved, err := resolveAddress(proxyDeployAdmin, chainName)
+			if err != nil {
+				return err
+			}
+			if admin, err = parseAddress(resolved); err != nil {
+				return err
+			}
+		}
+		var impl common.Address
+		if proxyDeployImpl != "" {
+			resolved, err := resolveAddress(proxyDeployImpl, chainName)
+			if err != nil {
+				return err
+			}
+			if impl, err = parseAddress(resolved); err != nil {
+				return err
+			}
+			if code, err := client.GetCode(impl.Hex()); err != nil {
+				return err
+			} else if !hasCode(code) {
+				return fmt.Errorf("no contract at --impl %s on %s", impl.Hex(), c.DisplayName)
+			}
+		}
+
+		labels := newAddressLabels(chainName)
+		pairs := [][2]string{
+			{"Name", name},
+			{"Kind", proxyKindLabel(kind)},
+			{"Artifact", artifactPath},
+		}
+		if proxyDeployImpl != "" {
+			pairs = append(pairs, [2]string{"Implementation", labels.Addr(impl.Hex())})
+		}
+		if len(initData) > 0 {
+			pairs = append(pairs, [2]string{"Initializer", proxyCallLabel(proxyDeployInit, proxyArgs)})
+		} else {
+			pairs = append(pairs, [2]string{"Initializer", ui.Warn("none — make sure the contract needs no initialization")})
+		}
+		if kind == contract.ProxyDeployTransparent {
+			pairs = append(pairs, [2]string{"Admin", labels.Addr(admin.Hex())})
+		}
+		pairs = append(pairs,
+			[2]string{"Deployer", ui.Addr(w.Address)},
+			[2]string{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
+		)
+		fmt.Println(ui.KeyValueBlock("Proxy Deploy Preview", pairs))
+		if kind == contract.ProxyDeployTransparent && strings.EqualFold(admin.Hex(), w.Address) {
+			fmt.Println(ui.Hint("The admin cannot call the contract through the proxy — use --admin with a Safe or a separate key for day-to-day use."))
+		}
+		if !proxyYes && !ui.Confirm("Deploy?") {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		chainID, err := client.ChainID()
+		if err != nil {
+			return err
+		}
+		gasPrice, err := client.GasPrice()
+		if err != nil {
+			return err
+		}
+
+		if proxyDeployImpl == "" {
+			spin := ui.NewSpinner("Deploying implementation...")
+			spin.Start()
+			_, addr, err := sendCreate(client, w, chainID, artifact.Bytecode, gasPrice)
+			spin.Stop()
+			if err != nil {
+				return fmt.Errorf("deploying implementation: %w", err)
+			}
+			impl = common.HexToAddress(addr)
+			fmt.Println(ui.Success("Implementation deployed at " + impl.Hex()))
+		}
+
+		initCode := contract.ERC1967ProxyInitCode(impl, initData)
+		if kind == contract.ProxyDeployTransparent {
+			initCode = contract.TransparentProxyInitCode(impl, admin, initData)
+		}
+		spin := ui.NewSpinner("Deploying proxy...")
+		spin.Start()
+		hash, proxyAddr, err := sendCreate(client, w, chainID, initCode, gasPrice)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("deploying proxy (implementation %s): %w", impl.Hex(), err)
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		entry := &contract.Entry{
+			Name:       name,
+			Network:    chainName,
+			Address:    proxyAddr,
+			ABI:        artifact.ABI,
+			Kind:       "deployed",
+			ABISource:  artifactPath,
+			Deployer:   w.Address,
+			TxHash:     hash,
+			DeployedAt: now,
+			ProxyKind:  contract.ProxyUUPS,
+		}
+		if kind == contract.ProxyDeployTransparent {
+			entry.ProxyKind = contract.ProxyTransparent
+		}
+		entry.RecordImplementation(contract.ImplementationRecord{Address: impl.Hex(), Artifact: artifactPath, TxHash: hash, At: now})
+
+		result := [][2]string{
+			{"Proxy", ui.Addr(proxyAddr)},
+			{"Implementation", ui.Addr(impl.Hex())},
+			{"Kind", entry.ProxyKind},
+			{"Tx Hash", ui.Addr(hash)},
+			{"Explorer", c.Explorer(cfg.NetworkMode) + "/address/" + proxyAddr},
+		}
+		if kind == contract.ProxyDeployTransparent {
+			result = append(result, [2]string{"Admin", labels.Addr(admin.Hex())})
+		}
+		fmt.Println()
+		fmt.Println(ui.KeyValueBlock("Proxy Deployed ✓", result))
+
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		reg.Add(entry)
+		if err := reg.Save(); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Registered as %q — use: w3cli contract studio %s", name, name)))
+		return nil
+	},
+}
+
+var proxyUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <name> <new-artifact>",
+	Short: "Deploy a new implementation and upgrade a proxy to it",
+	Long: `Upgrade a registered proxy (see "contract add" or "proxy deploy"):
+
+  1. check the new artifact's storage layout against the current
+     implementation's (the artifact it was deployed from, or --from)
+  2. check the wallet may upgrade, with a dry run
+  3. deploy the new implementation
+  4. upgradeToAndCall — on the proxy for UUPS and transparent proxies the
+     wallet administers, or through an OpenZeppelin ProxyAdmin it owns —
+     calling --call with --arg on the new implementation if given
+
+The registry entry then uses the new ABI and records the implementation.
+
+Examples:
+  w3cli proxy upgrade Vault ./out/VaultV2.sol/VaultV2.json --network base
+  w3cli proxy upgrade Vault ./out/VaultV2.sol/VaultV2.json --call "initializeV2(uint256)" --arg 42
+  w3cli proxy upgrade Box ./artifacts/BoxV2.json --from ./artifacts/Box.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, newPath := args[0], args[1]
+		chainName, c, client, err := proxyClient()
+		if err != nil {
+			return err
+		}
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		entry, err := reg.Get(name, chainName)
+		if err != nil {
+			return fmt.Errorf("%w — register the proxy with `w3cli contract add %s <address> --network %s`", err, name, chainName)
+		}
+		w, _, err := loadSigningWallet(proxyWalletName())
+		if err != nil {
+			return err
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Reading proxy on %s...", c.DisplayName))
+		spin.Start()
+		proxy, err := contract.DetectProxy(client, entry.Address)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		if proxy == nil || proxy.Implementation == "" {
+			return fmt.Errorf("%s (%s) is not an ERC-1967 proxy", name, entry.Address)
+		}
+		switch proxy.Kind {
+		case contract.ProxyUUPS, contract.ProxyEIP1967, contract.ProxyTransparent:
+		default:
+			return fmt.Errorf("%s proxies are not supported — upgrade %s proxies through their own tooling", proxy.Kind, proxy.Kind)
+		}
+		if entry.Implementation != "" && !strings.EqualFold(entry.Implementation, proxy.Implementation) {
+			fmt.Println(ui.Warn(fmt.Sprintf("Registry has implementation %s but the proxy points at %s — it was upgraded outside w3cli.", entry.Implementation, proxy.Implementation)))
+		}
+
+		newArt, err := contract.LoadArtifactFull(newPath)
+		if err != nil {
+			return err
+		}
+		uups := proxy.Kind != contract.ProxyTransparent
+		kind := contract.ProxyDeployTransparent
+		if uups {
+			kind = contract.ProxyDeployUUPS
+		}
+		if err := checkImplementationArtifact(newArt, kind); err != nil {
+			if !proxyUpgradeSkipSafe {
+				return err
+			}
+			fmt.Println(ui.Warn(err.Error()))
+		}
+
+		// 1. Storage layout.
+		oldPath := proxyUpgradeFrom
+		if oldPath == "" && strings.EqualFold(entry.Implementation, proxy.Implementation) {
+			oldPath = entry.ImplementationArtifact()
+		}
+		layoutNote := ui.Warn("not checked")
+		switch {
+		case proxyUpgradeSkipSafe:
+			fmt.Println(ui.Warn("Skipping the storage layout and UUPS checks."))
+		case oldPath == "":
+			return fmt.Errorf("the artifact of the current implementation %s is unknown — pass --from <artifact> it was built from (or --unsafe-skip-checks)", proxy.Implementation)
+		default:
+			issues, err := checkUpgradeLayouts(oldPath, newPath)
+			if err != nil {
+				return err
+			}
+			if printLayoutIssues(issues) > 0 {
+				return fmt.Errorf("storage layout of %s is incompatible with %s — the upgrade would corrupt state", newPath, oldPath)
+			}
+			layoutNote = ui.Success("compatible with " + filepath.Base(oldPath))
+		}
+
+		callData, err := buildTxData("", proxyUpgradeCall, proxyArgs)
+		if err != nil {
+			return fmt.Errorf("--call: %w", err)
+		}
+
+		// 2. Route and dry run, upgrading to the current implementation.
+		route, err := planUpgrade(client, entry, proxy, w.Address, callData)
+		if err != nil {
+			return err
+		}
+		current := common.HexToAddress(proxy.Implementation)
+		if _, err := client.EstimateGas(w.Address, route.To, "0x"+hex.EncodeToString(route.Data(current, nil)), nil); err != nil {
+			return fmt.Errorf("dry run failed — %s cannot upgrade this proxy: %w", w.Address, err)
+		}
+
+		labels := newAddressLabels(chainName)
+		pairs := [][2]string{
+			{"Proxy", labels.Addr(entry.Address)},
+			{"Kind", proxy.Kind},
+			{"Current", labels.Addr(proxy.Implementation)},
+			{"New", newPath},
+			{"Storage Layout", layoutNote},
+			{"Upgrade Via", route.Label},
+		}
+		if len(callData) > 0 {
+			pairs = append(pairs, [2]string{"Call", proxyCallLabel(proxyUpgradeCall, proxyArgs)})
+		}
+		pairs = append(pairs,
+			[2]string{"Wallet", ui.Addr(w.Address)},
+			[2]string{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
+		)
+		fmt.Println(ui.KeyValueBlock("Proxy Upgrade Preview", pairs))
+		if !proxyYes && !ui.Confirm("Upgrade?") {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		chainID, err := client.ChainID()
+		if err != nil {
+			return err
+		}
+		gasPrice, err := client.GasPrice()
+		if err != nil {
+			return err
+		}
+
+		// 3. New implementation.
+		spin = ui.NewSpinner("Deploying new implementation...")
+		spin.Start()
+		_, implAddr, err := sendCreate(client, w, chainID, newArt.Bytecode, gasPrice)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("deploying implementation: %w", err)
+		}
+		impl := common.HexToAddress(implAddr)
+		fmt.Println(ui.Success("Implementation deployed at " + impl.Hex()))
+
+		// 4. Upgrade.
+		spin = ui.NewSpinner("Upgrading proxy...")
+		spin.Start()
+		hash, err := sendCall(client, w, chainID, route.To, route.Data(impl, callData), gasPrice)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("upgrade tx %s: %w — the new implementation stays deployed at %s", hash, err, impl.Hex())
+		}
+		if word, err := client.GetStorageAt(entry.Address, contract.SlotEIP1967Implementation); err == nil &&
+			!strings.HasSuffix(strings.ToLower(word), strings.ToLower(impl.Hex()[2:])) {
+			fmt.Println(ui.Warn("The upgrade tx succeeded but the implementation slot does not hold the new address."))
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		if len(entry.ImplementationHistory) == 0 {
+			entry.RecordImplementation(contract.ImplementationRecord{Address: proxy.Implementation, Artifact: oldPath, At: now})
+		}
+		entry.ProxyKind = proxy.Kind
+		entry.ABI = newArt.ABI
+		entry.ABISource = newPath
+		entry.RecordImplementation(contract.ImplementationRecord{Address: impl.Hex(), Artifact: newPath, TxHash: hash, At: now})
+		if err := reg.Save(); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println(ui.KeyValueBlock("Proxy Upgraded ✓", [][2]string{
+			{"Proxy", ui.Addr(entry.Address)},
+			{"Implementation", ui.Addr(impl.Hex())},
+			{"Previous", ui.Addr(proxy.Implementation)},
+			{"Tx Hash", ui.Addr(hash)},
+			{"Explorer", c.Explorer(cfg.NetworkMode) + "/tx/" + hash},
+		}))
+		return nil
+	},
+}
+
+var proxyValidateCmd = &cobra.Command{
+	Use:   "validate <old-artifact> <new-artifact>",
+	Short: "Check that a new implementation's storage layout is upgrade-safe",
+	Long: `Compare the storage layouts of two implementation artifacts without touching
+the network — run it in CI before an upgrade. Exits non-zero when a variable
+was moved, removed or retyped, or a __gap was overrun. With --kind uups the
+new implementation must also keep upgradeToAndCall and proxiableUUID.
+
+Examples:
+  w3cli proxy validate ./out/Vault.sol/Vault.json ./out/VaultV2.sol/VaultV2.json
+  w3cli proxy validate old/Box.json artifacts/Box.json --kind uups`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if proxyValidateKind != "" {
+			kind, err := parseProxyDeployKind(proxyValidateKind)
+			if err != nil {
+				return err
+			}
+			artifact, err := contract.LoadArtifactFull(args[1])
+			if err != nil {
+				return err
+			}
+			if err := checkImplementationArtifact(artifact, kind); err != nil {
+				return err
+			}
+		}
+		issues, err := checkUpgradeLayouts(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if printLayoutIssues(issues) > 0 {
+			return fmt.Errorf("storage layout is not upgrade-safe")
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("%s can replace %s", filepath.Base(args[1]), filepath.Base(args[0]))))
+		return nil
+	},
+}
+
+var proxyHistoryCmd = &cobra.Command{
+	Use:   "history <name>",
+	Short: "List the implementations a registered proxy has pointed to",
+	Long: `Show the implementation history recorded for a proxy by "proxy deploy",
+"proxy upgrade" and "contract sync", newest last.
+
+Examples:
+  w3cli proxy history Vault --network base`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainName := proxyChainName()
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return err
+		}
+		entry, err := reg.Get(args[0], chainName)
+		if err != nil {
+			return err
+		}
+		if len(entry.ImplementationHistory) == 0 {
+			fmt.Println(ui.Meta(fmt.Sprintf("No implementation history for %s on %s — run `w3cli contract sync %s` to record the current one.", args[0], chainName, args[0])))
+			return nil
+		}
+		t := ui.NewTable([]ui.Column{
+			{Title: "#", Width: 3},
+			{Title: "Implementation", Width: 44},
+			{Title: "Artifact", Width: 30},
+			{Title: "Since", Width: 20},
+		})
+		for i, r := range entry.ImplementationHistory {
+			artifact := r.Artifact
+			if artifact == "" {
+				artifact = ui.Meta("unknown")
+			}
+			t.AddRow(ui.Row{fmt.Sprint(i + 1), r.Address, artifact, r.At})
+		}
+		fmt.Printf("\n  %s · %s (%s)\n", args[0], ui.Addr(entry.Address), entry.ProxyKind)
+		fmt.Println(t.Render())
+		return nil
+	},
+}
+
+func init() {
+	proxyCmd.PersistentFlags().StringVar(&proxyNetwork, "network", "", "chain (default: config)")
+	proxyCmd.PersistentFlags().StringVar(&proxyWallet, "wallet", "", "signing wallet (default: config)")
+
+	proxyDeployCmd.Flags().StringVar(&proxyDeployKind, "kind", contract.ProxyDeployUUPS, "proxy pattern: uups or transparent")
+	proxyDeployCmd.Flags().StringVar(&proxyDeployInit, "init", "", `initializer signature, e.g. "initialize(address,uint256)"`)
+	proxyDeployCmd.Flags().StringArrayVar(&proxyArgs, "arg", nil, "initializer argument (repeat per parameter)")
+	proxyDeployCmd.Flags().StringVar(&proxyDeployAdmin, "admin", "", "transparent proxy admin (default: the wallet)")
+	proxyDeployCmd.Flags().StringVar(&proxyDeployName, "name", "", "registry name (default: the artifact's name)")
+	proxyDeployCmd.Flags().StringVar(&proxyDeployImpl, "impl", "", "use an already deployed implementation")
+	proxyDeployCmd.Flags().BoolVarP(&proxyYes, "yes", "y", false, "skip the confirmation prompt")
+
+	proxyUpgradeCmd.Flags().StringVar(&proxyUpgradeCall, "call", "", `function to call on the new implementation, e.g. "initializeV2(uint256)"`)
+	proxyUpgradeCmd.Flags().StringArrayVar(&proxyArgs, "arg", nil, "--call argument (repeat per parameter)")
+	proxyUpgradeCmd.Flags().StringVar(&proxyUpgradeFrom, "from", "", "artifact of the current implementation (default: the one recorded)")
+	proxyUpgradeCmd.Flags().BoolVar(&proxyUpgradeSkipSafe, "unsafe-skip-checks", false, "skip the storage layout and UUPS checks")
+	proxyUpgradeCmd.Flags().BoolVarP(&proxyYes, "yes", "y", false, "skip the confirmation prompt")
+
+	proxyValidateCmd.Flags().StringVar(&proxyValidateKind, "kind", "", "also check the new implementation suits this proxy kind")
+
+	proxyCmd.AddCommand(proxyDeployCmd, proxyUpgradeCmd, proxyValidateCmd, proxyHistoryCmd)
+}
+
+func proxyChainName() string {
+	if proxyNetwork != "" {
+		return proxyNetwork
+	}
+	return cfg.DefaultNetwork
+}
+
+func proxyWalletName() string {
+	if proxyWallet != "" {
+		return proxyWallet
+	}
+	return cfg.DefaultWallet
+}
+
+// proxyClient connects to the EVM chain selected with --network.
+func proxyClient() (string, *chainpkg.Chain, *chainpkg.EVMClient, error) {
+	name := proxyChainName()
+	c, err := chainpkg.NewRegistry().GetByName(name)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("unknown chain %q — run `w3cli network list`", name)
+	}
+	if c.Type != chainpkg.ChainTypeEVM {
+		return "", nil, nil, fmt.Errorf("proxies are EVM-only (%s is %s)", c.DisplayName, c.Type)
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, c, chainpkg.NewEVMClient(rpcURL), nil
+}
+
+// parseProxyDeployKind validates a --kind value.
+func parseProxyDeployKind(s string) (string, error) {
+	switch k := strings.ToLower(strings.TrimSpace(s)); k {
+	case contract.ProxyDeployUUPS, contract.ProxyDeployTransparent:
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown --kind %q — use uups or transparent", s)
+}
+
+func proxyKindLabel(kind string) string {
+	if kind == contract.ProxyDeployTransparent {
+		return contract.ProxyTransparent
+	}
+	return contract.ProxyUUPS
+}
+
+// checkImplementationArtifact rejects artifacts that cannot sit behind a
+// proxy of the given kind.
+func checkImplementationArtifact(a *contract.ArtifactFull, kind string) error {
+	for _, e := range a.ABI {
+		if e.Type == "constructor" && len(e.Inputs) > 0 {
+			return fmt.Errorf("the implementation constructor takes arguments — deploy it with `w3cli contract deploy` and pass --impl <address>")
+		}
+	}
+	if kind == contract.ProxyDeployUUPS {
+		return contract.CheckUUPSImplementation(a.ABI)
+	}
+	return nil
+}
+
+// artifactContractName derives a contract name from an artifact path:
+// "out/Vault.sol/Vault.json" → "Vault".
+func artifactContractName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// proxyCallLabel renders a signature with its arguments filled in.
+func proxyCallLabel(sig string, args []string) string {
+	name, _, _ := strings.Cut(sig, "(")
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// checkUpgradeLayouts loads both storage layouts and compares them.
+func checkUpgradeLayouts(oldPath, newPath string) ([]contract.LayoutIssue, error) {
+	old, err := contract.LoadStorageLayout(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("current implementation: %w", err)
+	}
+	next, err := contract.LoadStorageLayout(newPath)
+	if err != nil {
+		return nil, fmt.Errorf("new implementation: %w", err)
+	}
+	return contract.CheckStorageUpgrade(old, next), nil
+}
+
+// printLayoutIssues lists the issues and returns how many are unsafe.
+func printLayoutIssues(issues []contract.LayoutIssue) int {
+	unsafe := 0
+	for _, i := range issues {
+		if i.Unsafe {
+			unsafe++
+			fmt.Println(ui.Err(i.String()))
+		} else {
+			fmt.Println(ui.Warn(i.String()))
+		}
+	}
+	return unsafe
+}
+
+// upgradeRoute is where the upgrade call goes and how it is encoded.
+type upgradeRoute struct {
+	To    string
+	Label string
+	Data  func(impl common.Address, call []byte) []byte
+}
+
+// planUpgrade picks how wallet upgrades the proxy: through the proxy itself
+// (UUPS, or a transparent proxy it administers) or through the ProxyAdmin
+// contract it owns.
+func planUpgrade(client *chainpkg.EVMClient, entry *contract.Entry, proxy *contract.ProxyInfo, walletAddr string, call []byte) (*upgradeRoute, error) {
+	proxyAddr := common.HexToAddress(entry.Address)
+	if proxy.Kind != contract.ProxyTransparent {
+		// OpenZeppelin 4.x UUPS still has upgradeTo, and its
+		// upgradeToAndCall always calls the new implementation.
+		legacy := hasFunction(entry.ABI, "upgradeTo(address)")
+		return &upgradeRoute{
+			To:    entry.Address,
+			Label: "proxy · upgradeToAndCall (UUPS)",
+			Data: func(impl common.Address, call []byte) []byte {
+				return contract.UpgradeCalldata(impl, call, legacy)
+			},
+		}, nil
+	}
+
+	if strings.EqualFold(proxy.Admin, walletAddr) {
+		return &upgradeRoute{
+			To:    entry.Address,
+			Label: "proxy · upgradeToAndCall (admin)",
+			Data: func(impl common.Address, call []byte) []byte {
+				return contract.UpgradeCalldata(impl, call, false)
+			},
+		}, nil
+	}
+	code, err := client.GetCode(proxy.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCode(code) {
+		return nil, fmt.Errorf("the proxy admin is %s, not %s — upgrade with that wallet", proxy.Admin, walletAddr)
+	}
+	out, err := client.CallContract(proxy.Admin, "0x8da5cb5b") // owner()
+	if err != nil {
+		return nil, fmt.Errorf("proxy admin %s is a contract without owner() — upgrade through it manually", proxy.Admin)
+	}
+	if owner := common.HexToAddress(out); !strings.EqualFold(owner.Hex(), walletAddr) {
+		return nil, fmt.Errorf("ProxyAdmin %s is owned by %s, not %s — if that is a Safe, propose the upgrade with `w3cli safe propose`", proxy.Admin, owner.Hex(), walletAddr)
+	}
+	// UPGRADE_INTERFACE_VERSION() exists from OpenZeppelin 5.
+	_, v5Err := client.CallContract(proxy.Admin, "0xad3cb1cc")
+	v4 := v5Err != nil
+	return &upgradeRoute{
+		To:    proxy.Admin,
+		Label: "ProxyAdmin " + proxy.Admin,
+		Data: func(impl common.Address, call []byte) []byte {
+			return contract.ProxyAdminUpgradeCalldata(proxyAddr, impl, call, v4)
+		},
+	}, nil
+}
+
+// hasFunction reports whether abi has a function with the signature.
+func hasFunction(abi []contract.ABIEntry, sig string) bool {
+	want, err := contract.ParseSignature(sig)
+	if err != nil {
+		return false
+	}
+	for _, e := range abi {
+		if e.Type == "function" && e.Selector() == want.Selector() {
+			return true
+		}
+	}
+	return false
+}
+
+// sendCreate signs and broadcasts a contract creation from w, waits for it
+// and returns the tx hash and the new contract's address.
+func sendCreate(client *chainpkg.EVMClient, w *wallet.Wallet, chainID int64, initCode []byte, gasPrice *big.Int) (string, string, error) {
+	gas, err := client.EstimateGas(w.Address, "", "0x"+hex.EncodeToString(initCode), nil)
+	if err != nil {
+		return "", "", fmt.Errorf("estimating gas: %w", err)
+	}
+	lease, err := reserveNonce(client, chainID, w.Address)
+	if err != nil {
+		return "", "", err
+	}
+	defer lease.Release()
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chainID),
+		Nonce:     lease.Nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gas * 12 / 10,
+		Value:     big.NewInt(0),
+		Data:      initCode,
+	})
+	raw, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignTx(tx, big.NewInt(chainID))
+	if err != nil {
+		return "", "", err
+	}
+	hash, err := client.SendRawTransaction("0x" + hex.EncodeToString(raw))
+	if err != nil {
+		return "", "", err
+	}
+	lease.Used()
+	receipt, err := client.WaitForReceipt(hash, config.TxDeployTimeout)
+	if err != nil {
+		return hash, "", err
+	}
+	return hash, receipt.ContractAddress, nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProxyDeployKind(t *testing.T) {
+	k, err := parseProxyDeployKind(" UUPS ")
+	require.NoError(t, err)
+	assert.Equal(t, contract.ProxyDeployUUPS, k)
+	k, err = parseProxyDeployKind("transparent")
+	require.NoError(t, err)
+	assert.Equal(t, contract.ProxyDeployTransparent, k)
+	_, err = parseProxyDeployKind("beacon")
+	assert.Error(t, err)
+}
+
+func TestArtifactContractName(t *testing.T) {
+	assert.Equal(t, "Vault", artifactContractName("out/Vault.sol/Vault.json"))
+	assert.Equal(t, "Box", artifactContractName("artifacts/contracts/Box.sol/Box.json"))
+}
+
+func TestProxyCallLabel(t *testing.T) {
+	assert.Equal(t, "initialize(0xOwner, 42)", proxyCallLabel("initialize(address,uint256)", []string{"0xOwner", "42"}))
+	assert.Equal(t, "initializeV2()", proxyCallLabel("initializeV2()", nil))
+}
+
+func TestCheckImplementationArtifact(t *testing.T) {
+	upgradeable := []contract.ABIEntry{
+		{Type: "function", Name: "upgradeToAndCall", Inputs: []contract.ABIParam{{Type: "address"}, {Type: "bytes"}}},
+		{Type: "function", Name: "proxiableUUID"},
+	}
+	art := &contract.ArtifactFull{ABI: upgradeable}
+	assert.NoError(t, checkImplementationArtifact(art, contract.ProxyDeployUUPS))
+	assert.True(t, hasFunction(art.ABI, "upgradeToAndCall(address,bytes)"))
+	assert.False(t, hasFunction(art.ABI, "upgradeTo(address)"))
+
+	plain := &contract.ArtifactFull{ABI: []contract.ABIEntry{{Type: "function", Name: "store"}}}
+	assert.Error(t, checkImplementationArtifact(plain, contract.ProxyDeployUUPS))
+	assert.NoError(t, checkImplementationArtifact(plain, contract.ProxyDeployTransparent))
+
+	withCtor := &contract.ArtifactFull{ABI: append([]contract.ABIEntry{
+		{Type: "constructor", Inputs: []contract.ABIParam{{Type: "address"}}},
+	}, upgradeable...)}
+	err := checkImplementationArtifact(withCtor, contract.ProxyDeployUUPS)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--impl")
+}
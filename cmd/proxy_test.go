@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyPairs(t *testing.T) {
+	assert.Nil(t, proxyPairs(nil))
+
+	pairs := proxyPairs(&contract.ProxyInfo{Kind: contract.ProxyUUPS, Implementation: "0x2222222222222222222222222222222222222222"})
+	require.Len(t, pairs, 2)
+	assert.Equal(t, "Proxy", pairs[0][0])
+	assert.Equal(t, "Implementation", pairs[1][0])
+
+	pairs = proxyPairs(&contract.ProxyInfo{
+		Kind:   contract.ProxyBeacon,
+		Admin:  "0x3333333333333333333333333333333333333333",
+		Beacon: "0x4444444444444444444444444444444444444444",
+	})
+	require.Len(t, pairs, 4)
+	assert.Contains(t, pairs[1][1], "unknown")
+	assert.Equal(t, "Admin", pairs[2][0])
+	assert.Equal(t, "Beacon", pairs[3][0])
+}
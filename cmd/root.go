@@ -95,14 +95,17 @@ func init() {
 		defaultCmd,
 		networkCmd,
 		walletCmd,
+		contactsCmd,
 		balanceCmd,
 		allBalCmd,
+		portfolioCmd,
 		allGasCmd,
 		blockCmd,
 		txsCmd,
 		txCmd,
 		sendCmd,
 		tokenCmd,
+		tokensCmd,
 		rpcCmd,
 		contractCmd,
 		configCmd,
@@ -127,5 +130,11 @@ func init() {
 		storageCmd,
 		codeCmd,
 		eventsCmd,
+		monitorCmd,
+		batchCmd,
+		airdropCmd, objectsCmd,
+		create2Cmd,
+		safeCmd,
+		proxyCmd,
 	)
 }
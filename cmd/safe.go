@@ -0,0 +1,783 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/safe"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	safeNetwork string
+	safeWallet  string
+
+	safeProposeTo          string
+	safeProposeValue       string
+	safeProposeData        string
+	safeProposeSig         string
+	safeProposeArgs        []string
+	safeProposeContract    string
+	safeProposeFn          string
+	safeProposeBatch       string
+	safeProposeMultiSend   string
+	safeProposeNonce       int64
+	safeProposeSafeVersion string
+	safeProposeNote        string
+	safeProposeOut         string
+	safeProposeNoSign      bool
+
+	safeSignSignature string
+	safeSignOut       string
+	safeSignYes       bool
+
+	safeExecYes bool
+)
+
+var safeCmd = &cobra.Command{
+	Use:   "safe",
+	Short: "Inspect Safe multisigs and collect signatures offline",
+	Long: `Work with Safe (formerly Gnosis Safe) multisig wallets.
+
+A proposal is a JSON file holding the SafeTx, its EIP-712 hash and the owner
+signatures collected so far. Pass it between owners — over chat, email or a
+USB stick — and execute it once enough owners have signed:
+  info     owners, threshold, nonce, modules and guard of a Safe
+  propose  build a SafeTx (send, contract call or batch) and sign it
+  sign     add an owner's signature to a proposal file
+  exec     submit execTransaction once the threshold is met
+
+Only info, exec and propose without --nonce touch the network.
+
+Examples:
+  w3cli safe info 0xSafe --network base
+  w3cli safe propose 0xSafe --to 0xRecipient --value 1.5 --network base
+  w3cli safe sign safe-base-12.json --wallet alice
+  w3cli safe exec safe-base-12.json --wallet relayer`,
+}
+
+var safeInfoCmd = &cobra.Command{
+	Use:   "info <safe>",
+	Short: "Show a Safe's owners, threshold, nonce and modules",
+	Long: `Read a Safe's configuration with contract calls: owners, threshold, the next
+nonce, version, enabled modules, the transaction guard and fallback handler.
+
+Examples:
+  w3cli safe info 0xSafe --network base
+  w3cli safe info treasury --network ethereum`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainName := safeChainName("")
+		address, err := resolveAddress(args[0], chainName)
+		if err != nil {
+			return err
+		}
+		c, client, err := safeClient(chainName)
+		if err != nil {
+			return err
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Reading Safe on %s...", c.DisplayName))
+		spin.Start()
+		info, err := safe.ReadInfo(client, address)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+
+		labels := newAddressLabels(chainName)
+		version := info.Version
+		if version == "" {
+			version = "unknown"
+		}
+		pairs := [][2]string{
+			{"Address", ui.Addr(info.Address)},
+			{"Version", version},
+			{"Threshold", fmt.Sprintf("%d of %d", info.Threshold, len(info.Owners))},
+			{"Nonce", fmt.Sprintf("%d", info.Nonce)},
+		}
+		for i, o := range info.Owners {
+			pairs = append(pairs, [2]string{fmt.Sprintf("Owner %d", i+1), labels.Addr(o)})
+		}
+		if len(info.Modules) == 0 {
+			pairs = append(pairs, [2]string{"Modules", ui.Meta("none")})
+		}
+		for i, m := range info.Modules {
+			pairs = append(pairs, [2]string{fmt.Sprintf("Module %d", i+1), labels.Addr(m)})
+		}
+		pairs = append(pairs,
+			[2]string{"Guard", safeOptionalAddr(labels, info.Guard)},
+			[2]string{"Fallback", safeOptionalAddr(labels, info.FallbackHandler)},
+		)
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Safe · %s (%s)", c.DisplayName, cfg.NetworkMode), pairs))
+		return nil
+	},
+}
+
+var safeProposeCmd = &cobra.Command{
+	Use:   "propose <safe>",
+	Short: "Build a Safe transaction and sign it",
+	Long: `Build a SafeTx, compute its EIP-712 hash, sign it with a local owner wallet
+and write a proposal file for the other owners.
+
+The transaction is one of:
+  --to [--value]                 a native transfer
+  --to --data | --sig --arg      a call with raw or encoded calldata
+  --contract --fn --arg          a call to a contract in the registry
+  --batch calls.json             several calls run through MultiSendCallOnly
+
+A batch file is a JSON array of calls, each either
+  {"to": "0x…", "value": "0.1", "data": "0x…"}
+  {"to": "0x…", "sig": "approve(address,uint256)", "args": ["0x…", "100"]}
+  {"contract": "vault", "function": "deposit", "args": ["100"], "value": "0"}
+
+The Safe's nonce and version are read from the chain. Pass --nonce (and
+--safe-version for Safes older than 1.3.0) to build the proposal offline.
+
+Examples:
+  w3cli safe propose 0xSafe --to 0xRecipient --value 1.5 --network base
+  w3cli safe propose 0xSafe --contract usdc --fn transfer --arg 0xBob --arg 1000000
+  w3cli safe propose 0xSafe --batch payroll.json --nonce 12 --wallet alice
+  w3cli safe propose 0xSafe --to 0xToken --sig "approve(address,uint256)" \
+      --arg 0xSpender --arg 0 --no-sign -o revoke.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainName := safeChainName("")
+		reg := chain.NewRegistry()
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+		}
+		if c.Type != chain.ChainTypeEVM {
+			return fmt.Errorf("Safe supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+		}
+		chainID := c.ChainIDFor(cfg.NetworkMode)
+		if chainID == 0 {
+			return fmt.Errorf("%s has no %s chain ID", c.DisplayName, cfg.NetworkMode)
+		}
+
+		address, err := resolveAddress(args[0], chainName)
+		if err != nil {
+			return err
+		}
+		safeAddr, err := parseAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid safe: %w", err)
+		}
+
+		calls, err := safeProposeCalls(chainName)
+		if err != nil {
+			return err
+		}
+
+		// Online unless the nonce is given: then the file can be built on
+		// the offline machine that holds the key.
+		var info *safe.Info
+		nonce := uint64(safeProposeNonce)
+		version := safeProposeSafeVersion
+		if safeProposeNonce < 0 {
+			_, client, err := safeClient(chainName)
+			if err != nil {
+				return err
+			}
+			spin := ui.NewSpinner(fmt.Sprintf("Reading Safe on %s...", c.DisplayName))
+			spin.Start()
+			info, err = safe.ReadInfo(client, safeAddr.Hex())
+			spin.Stop()
+			if err != nil {
+				return err
+			}
+			nonce = info.Nonce
+			if version == "" {
+				version = info.Version
+			}
+		}
+
+		multiSend := safe.MultiSendCallOnly
+		if safeProposeMultiSend != "" {
+			if multiSend, err = parseAddress(safeProposeMultiSend); err != nil {
+				return fmt.Errorf("invalid --multisend: %w", err)
+			}
+		}
+		tx, err := safe.BatchTx(multiSend, calls, nonce)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC().Format(time.RFC3339)
+		doc, err := safe.NewDocument(chainName, chainID, safeAddr, version, tx, safeProposeNote, now)
+		if err != nil {
+			return err
+		}
+
+		pairs := safeDocPairs(doc)
+		if len(calls) > 1 {
+			pairs = append(pairs, [2]string{"Batch", fmt.Sprintf("%d calls via MultiSendCallOnly", len(calls))})
+		}
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Safe Proposal · %s (%s)", c.DisplayName, cfg.NetworkMode), pairs))
+
+		if !safeProposeNoSign {
+			w, _, err := loadSigningWallet(safeWalletName())
+			if err != nil {
+				return err
+			}
+			if info != nil && !info.IsOwner(common.HexToAddress(w.Address)) {
+				return fmt.Errorf("wallet %q (%s) is not an owner of this Safe — pass --wallet or --no-sign", w.Name, w.Address)
+			}
+			warnIfNoSession()
+			if err := safeSignDoc(doc, w, now); err != nil {
+				return err
+			}
+			fmt.Println(ui.Success("Signed as " + w.Address))
+		}
+
+		out := safeProposeOut
+		if out == "" {
+			out = safeProposalPath(chainName, nonce)
+		}
+		if err := safe.Save(out, doc); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success("Written to " + out))
+		if info != nil {
+			fmt.Println(ui.Meta(fmt.Sprintf("Signatures: %d of %d", len(doc.Signatures), info.Threshold)))
+		}
+		fmt.Println(ui.Hint("Other owners: w3cli safe sign " + out))
+		return nil
+	},
+}
+
+var safeSignCmd = &cobra.Command{
+	Use:   "sign <proposal.json>",
+	Short: "Add an owner's signature to a proposal file",
+	Long: `Verify a proposal file, show what it does and sign its SafeTx hash with a
+local wallet. No RPC calls are made, so this works on an offline machine.
+
+A signature made elsewhere — a hardware wallet or the Safe web app — can be
+added with --signature; eth_sign style signatures are accepted too.
+
+The file is updated in place unless --out is given.
+
+Examples:
+  w3cli safe sign safe-base-12.json --wallet alice
+  w3cli safe sign safe-base-12.json --signature 0x… -o signed.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := safe.Load(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Sign Safe Transaction · %s (chain %d)", doc.Network, doc.ChainID), safeDocPairs(doc)))
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		if safeSignSignature != "" {
+			sig, err := hex.DecodeString(strings.TrimPrefix(safeSignSignature, "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid --signature: %w", err)
+			}
+			signer, err := doc.AddSignature(sig, now)
+			if err != nil {
+				return err
+			}
+			fmt.Println(ui.Success("Added signature by " + signer.Hex()))
+		} else {
+			w, _, err := loadSigningWallet(safeWalletName())
+			if err != nil {
+				return err
+			}
+			if doc.Signed(common.HexToAddress(w.Address)) {
+				fmt.Println(ui.Warn(w.Address + " already signed — signing again replaces it"))
+			}
+			if !safeSignYes && !ui.Confirm(fmt.Sprintf("Sign as %s?", w.Address)) {
+				fmt.Println(ui.Meta("Cancelled."))
+				return nil
+			}
+			warnIfNoSession()
+			if err := safeSignDoc(doc, w, now); err != nil {
+				return err
+			}
+			fmt.Println(ui.Success("Signed as " + w.Address))
+		}
+
+		out := safeSignOut
+		if out == "" {
+			out = args[0]
+		}
+		if err := safe.Save(out, doc); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("%d signature(s) → %s", len(doc.Signatures), out)))
+		fmt.Println(ui.Hint("Once the threshold is met: w3cli safe exec " + out))
+		return nil
+	},
+}
+
+var safeExecCmd = &cobra.Command{
+	Use:   "exec <proposal.json>",
+	Short: "Execute a proposal once enough owners have signed",
+	Long: `Check a proposal against the Safe — nonce, owners and threshold — then
+submit execTransaction from a local wallet, which pays the gas.
+
+The executing wallet need not be an owner. If it is and has not signed, it
+approves as the sender, so it counts towards the threshold without signing.
+Signatures from addresses that are no longer owners are left out.
+
+Examples:
+  w3cli safe exec safe-base-12.json
+  w3cli safe exec safe-base-12.json --wallet relayer --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := safe.Load(args[0])
+		if err != nil {
+			return err
+		}
+		chainName := safeChainName(doc.Network)
+		c, client, err := safeClient(chainName)
+		if err != nil {
+			return err
+		}
+		w, _, err := loadSigningWallet(safeWalletName())
+		if err != nil {
+			return err
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Checking Safe on %s...", c.DisplayName))
+		spin.Start()
+		chainID, err := client.ChainID()
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		if chainID != doc.ChainID {
+			spin.Stop()
+			return fmt.Errorf("proposal is for chain %d but %s (%s) is chain %d — check --testnet/--mainnet",
+				doc.ChainID, c.DisplayName, cfg.NetworkMode, chainID)
+		}
+		info, err := safe.ReadInfo(client, doc.Safe)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		switch {
+		case doc.Tx.Nonce < info.Nonce:
+			return fmt.Errorf("nonce %d was already used — the Safe is at nonce %d", doc.Tx.Nonce, info.Nonce)
+		case doc.Tx.Nonce > info.Nonce:
+			return fmt.Errorf("proposal has nonce %d but the Safe is at nonce %d — execute the earlier ones first", doc.Tx.Nonce, info.Nonce)
+		}
+
+		// Keep owner signatures only: one stale signer reverts the whole call.
+		owners := *doc
+		owners.Signatures = nil
+		for _, s := range doc.Signatures {
+			if info.IsOwner(common.HexToAddress(s.Signer)) {
+				owners.Signatures = append(owners.Signatures, s)
+			} else {
+				fmt.Println(ui.Warn(s.Signer + " is not an owner — skipping its signature"))
+			}
+		}
+		var approvedBy *common.Address
+		if executor := common.HexToAddress(w.Address); info.IsOwner(executor) && !owners.Signed(executor) {
+			approvedBy = &executor
+		}
+		have := uint64(len(owners.Signatures))
+		if approvedBy != nil {
+			have++
+		}
+		if have < info.Threshold {
+			return fmt.Errorf("%d of %d required signatures — collect %d more with `w3cli safe sign %s`",
+				have, info.Threshold, info.Threshold-have, args[0])
+		}
+
+		packed, err := owners.PackSignatures(approvedBy)
+		if err != nil {
+			return err
+		}
+		data, err := doc.Tx.ExecData(packed)
+		if err != nil {
+			return err
+		}
+		dataHex := "0x" + hex.EncodeToString(data)
+
+		spin = ui.NewSpinner("Estimating gas...")
+		spin.Start()
+		gasPrice, err := client.GasPrice()
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		gas, err := client.EstimateGas(w.Address, info.Address, dataHex, nil)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("execTransaction would fail: %w", err)
+		}
+
+		pairs := safeDocPairs(doc)
+		pairs = append(pairs,
+			[2]string{"Threshold", fmt.Sprintf("%d of %d (have %d)", info.Threshold, len(info.Owners), have)},
+			[2]string{"Executor", ui.Addr(w.Address)},
+			[2]string{"Gas Limit", fmt.Sprintf("%d", gas)},
+			[2]string{"Gas Price", fmt.Sprintf("%d Gwei", toGwei(gasPrice))},
+		)
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Execute Safe Transaction · %s (%s)", c.DisplayName, cfg.NetworkMode), pairs))
+		if !safeExecYes && !ui.Confirm("Execute this transaction?") {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
+		to := common.HexToAddress(info.Address)
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(chainID),
+			Nonce:     lease.Nonce,
+			GasTipCap: gasPrice,
+			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+			Gas:       gas * 12 / 10,
+			To:        &to,
+			Value:     big.NewInt(0),
+			Data:      data,
+		})
+
+		spin = ui.NewSpinner("Signing & sending execTransaction...")
+		spin.Start()
+		raw, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignTx(tx, big.NewInt(chainID))
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		hash, err := client.SendRawTransaction("0x" + hex.EncodeToString(raw))
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		lease.Used()
+
+		spin = ui.NewSpinner("Waiting for confirmation...")
+		spin.Start()
+		receipt, err := client.WaitForReceipt(hash, config.TxConfirmTimeout)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("tx %s: %w", hash, err)
+		}
+
+		explorer := c.Explorer(cfg.NetworkMode)
+		fmt.Println(ui.KeyValueBlock("Safe Transaction Executed ✓", [][2]string{
+			{"Hash", ui.Addr(hash)},
+			{"SafeTxHash", ui.Addr(doc.SafeTxHash)},
+			{"Block", fmt.Sprintf("%d", receipt.BlockNumber)},
+			{"Gas Used", fmt.Sprintf("%d", receipt.GasUsed)},
+			{"Explorer", explorer + "/tx/" + hash},
+		}))
+		return nil
+	},
+}
+
+// safeBatchItem is one call in a --batch file.
+type safeBatchItem struct {
+	To       string   `json:"to,omitempty"`
+	Value    string   `json:"value,omitempty"` // native units, e.g. "0.1"
+	Data     string   `json:"data,omitempty"`
+	Sig      string   `json:"sig,omitempty"`
+	Contract string   `json:"contract,omitempty"`
+	Function string   `json:"function,omitempty"`
+	Args     []string `json:"args,omitempty"`
+}
+
+// safeProposeCalls builds the calls for `safe propose` from its flags.
+func safeProposeCalls(network string) ([]safe.Call, error) {
+	if safeProposeBatch != "" {
+		if safeProposeTo != "" || safeProposeContract != "" || safeProposeData != "" || safeProposeSig != "" {
+			return nil, fmt.Errorf("--batch cannot be combined with --to, --contract, --data or --sig")
+		}
+		raw, err := os.ReadFile(safeProposeBatch)
+		if err != nil {
+			return nil, err
+		}
+		var items []safeBatchItem
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", safeProposeBatch, err)
+		}
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return nil, err
+		}
+		return safeBatchCalls(items, network, reg)
+	}
+
+	item := safeBatchItem{
+		To:       safeProposeTo,
+		Value:    safeProposeValue,
+		Data:     safeProposeData,
+		Sig:      safeProposeSig,
+		Contract: safeProposeContract,
+		Function: safeProposeFn,
+		Args:     safeProposeArgs,
+	}
+	var reg *contract.Registry
+	if item.Contract != "" {
+		reg = newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return nil, err
+		}
+	}
+	return safeBatchCalls([]safeBatchItem{item}, network, reg)
+}
+
+// safeBatchCalls turns batch items into calls, looking contracts up in reg.
+func safeBatchCalls(items []safeBatchItem, network string, reg *contract.Registry) ([]safe.Call, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("nothing to propose — pass --to, --contract or --batch")
+	}
+	calls := make([]safe.Call, 0, len(items))
+	for i, it := range items {
+		call, err := safeBatchCall(it, network, reg)
+		if err != nil {
+			if len(items) > 1 {
+				return nil, fmt.Errorf("call %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
+
+func safeBatchCall(it safeBatchItem, network string, reg *contract.Registry) (safe.Call, error) {
+	var call safe.Call
+	call.Value = big.NewInt(0)
+	if it.Value != "" {
+		v, err := ethToWei(it.Value)
+		if err != nil || v.Sign() < 0 {
+			return call, fmt.Errorf("invalid value %q", it.Value)
+		}
+		call.Value = v
+	}
+
+	if it.Contract != "" {
+		if it.To != "" || it.Data != "" || it.Sig != "" {
+			return call, fmt.Errorf("contract %q: use either a registry contract or to/data/sig", it.Contract)
+		}
+		if it.Function == "" {
+			return call, fmt.Errorf("contract %q: a function is required", it.Contract)
+		}
+		entry, err := reg.Get(it.Contract, network)
+		if err != nil {
+			return call, err
+		}
+		fn, err := safeFindFunction(entry.ABI, it.Function, len(it.Args))
+		if err != nil {
+			return call, fmt.Errorf("%s: %w", it.Contract, err)
+		}
+		_, data, err := contract.EncodeCalldata(*fn, it.Args)
+		if err != nil {
+			return call, err
+		}
+		call.To = common.HexToAddress(entry.Address)
+		call.Data = data
+		return call, nil
+	}
+
+	if it.To == "" {
+		return call, fmt.Errorf("a destination (to) or registry contract is required")
+	}
+	if it.Function != "" {
+		return call, fmt.Errorf("function %q needs a registry contract — use sig for a plain address", it.Function)
+	}
+	to, err := parseAddress(it.To)
+	if err != nil {
+		return call, fmt.Errorf("invalid to: %w", err)
+	}
+	if it.Data != "" && it.Sig != "" {
+		return call, fmt.Errorf("use either data or sig, not both")
+	}
+	data, err := buildTxData(it.Data, it.Sig, it.Args)
+	if err != nil {
+		return call, err
+	}
+	call.To = to
+	call.Data = data
+	return call, nil
+}
+
+// safeFindFunction finds a function by name (or full signature) and argument
+// count, so overloads resolve by arity.
+func safeFindFunction(abi []contract.ABIEntry, name string, nargs int) (*contract.ABIEntry, error) {
+	var byName []*contract.ABIEntry
+	for i := range abi {
+		e := &abi[i]
+		if e.Type != "function" {
+			continue
+		}
+		if strings.Contains(name, "(") {
+			types := make([]string, len(e.Inputs))
+			for j, p := range e.Inputs {
+				types[j] = p.Type
+			}
+			if normalizeSignature(name) == e.Name+"("+strings.Join(types, ",")+")" {
+				return e, nil
+			}
+			continue
+		}
+		if e.Name == name {
+			byName = append(byName, e)
+		}
+	}
+	var match []*contract.ABIEntry
+	for _, e := range byName {
+		if len(e.Inputs) == nargs {
+			match = append(match, e)
+		}
+	}
+	switch {
+	case len(byName) == 0:
+		return nil, fmt.Errorf("no function %q in the ABI", name)
+	case len(match) == 0:
+		return nil, fmt.Errorf("%s takes %d argument(s), got %d", name, len(byName[0].Inputs), nargs)
+	case len(match) > 1:
+		return nil, fmt.Errorf("%s is overloaded — pass the full signature, e.g. %q", name, name+"(address,uint256)")
+	}
+	return match[0], nil
+}
+
+// safeSignDoc signs the proposal's hash with w and records the signature.
+func safeSignDoc(doc *safe.Document, w *wallet.Wallet, signedAt string) error {
+	hash := common.HexToHash(doc.SafeTxHash)
+	sig, err := wallet.SignHash(w, wallet.DefaultKeystore(), hash[:])
+	if err != nil {
+		return err
+	}
+	_, err = doc.AddSignature(sig, signedAt)
+	return err
+}
+
+// safeDocPairs renders a proposal for review.
+func safeDocPairs(doc *safe.Document) [][2]string {
+	labels := newAddressLabels(doc.Network)
+	value, _ := new(big.Int).SetString(doc.Tx.Value, 10)
+	op := "Call"
+	if doc.Tx.Operation == safe.OpDelegateCall {
+		op = ui.Warn("DelegateCall")
+		if common.HexToAddress(doc.Tx.To) == safe.MultiSendCallOnly {
+			op = "DelegateCall (MultiSendCallOnly batch)"
+		}
+	}
+	pairs := [][2]string{
+		{"Safe", labels.Addr(doc.Safe)},
+		{"To", labels.Addr(doc.Tx.To)},
+		{"Value", formatTokenAmount(value, 18)},
+		{"Operation", op},
+		{"Nonce", fmt.Sprintf("%d", doc.Tx.Nonce)},
+	}
+	if doc.Tx.Data != "" {
+		data := doc.Tx.Data
+		if len(data) > 74 {
+			data = data[:74] + "…"
+		}
+		pairs = append(pairs, [2]string{"Data", data})
+	}
+	pairs = append(pairs,
+		[2]string{"SafeTxHash", ui.Addr(doc.SafeTxHash)},
+		[2]string{"Chain ID", fmt.Sprintf("%d", doc.ChainID)},
+	)
+	if doc.SafeVersion != "" {
+		pairs = append(pairs, [2]string{"Safe Version", doc.SafeVersion})
+	}
+	for _, s := range doc.Signatures {
+		pairs = append(pairs, [2]string{"Signed By", labels.Addr(s.Signer)})
+	}
+	if doc.Note != "" {
+		pairs = append(pairs, [2]string{"Note", doc.Note})
+	}
+	return pairs
+}
+
+// safeOptionalAddr renders an address, or "none".
+func safeOptionalAddr(labels *addressLabels, addr string) string {
+	if addr == "" {
+		return ui.Meta("none")
+	}
+	return labels.Addr(addr)
+}
+
+// safeProposalPath is the default proposal file name.
+func safeProposalPath(network string, nonce uint64) string {
+	return fmt.Sprintf("safe-%s-%d.json", network, nonce)
+}
+
+// safeChainName picks --network, then the file's network, then the default.
+func safeChainName(fromFile string) string {
+	switch {
+	case safeNetwork != "":
+		return safeNetwork
+	case fromFile != "":
+		return fromFile
+	}
+	return cfg.DefaultNetwork
+}
+
+func safeWalletName() string {
+	if safeWallet != "" {
+		return safeWallet
+	}
+	return cfg.DefaultWallet
+}
+
+// safeClient connects to an EVM chain.
+func safeClient(chainName string) (*chain.Chain, *chain.EVMClient, error) {
+	c, err := chain.NewRegistry().GetByName(chainName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+	}
+	if c.Type != chain.ChainTypeEVM {
+		return nil, nil, fmt.Errorf("Safe supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, chain.NewEVMClient(rpcURL), nil
+}
+
+func init() {
+	safeCmd.PersistentFlags().StringVar(&safeNetwork, "network", "", "chain (default: config, or the proposal's network)")
+	safeCmd.PersistentFlags().StringVar(&safeWallet, "wallet", "", "signing wallet (default: config)")
+
+	safeProposeCmd.Flags().StringVar(&safeProposeTo, "to", "", "destination address")
+	safeProposeCmd.Flags().StringVar(&safeProposeValue, "value", "", "native amount the Safe sends (e.g. 0.5)")
+	safeProposeCmd.Flags().StringVar(&safeProposeData, "data", "", "raw calldata hex")
+	safeProposeCmd.Flags().StringVar(&safeProposeSig, "sig", "", `function signature, e.g. "transfer(address,uint256)"`)
+	safeProposeCmd.Flags().StringArrayVar(&safeProposeArgs, "arg", nil, "function argument (repeat per parameter)")
+	safeProposeCmd.Flags().StringVar(&safeProposeContract, "contract", "", "registered contract name (see `w3cli contract list`)")
+	safeProposeCmd.Flags().StringVar(&safeProposeFn, "fn", "", "function to call on --contract")
+	safeProposeCmd.Flags().StringVar(&safeProposeBatch, "batch", "", "JSON file with an array of calls to batch")
+	safeProposeCmd.Flags().StringVar(&safeProposeMultiSend, "multisend", "", "MultiSendCallOnly address (default: canonical v1.3.0)")
+	safeProposeCmd.Flags().Int64Var(&safeProposeNonce, "nonce", -1, "Safe nonce; given, nothing is read from the chain")
+	safeProposeCmd.Flags().StringVar(&safeProposeSafeVersion, "safe-version", "", "Safe version when offline (default: 1.3.0 or later)")
+	safeProposeCmd.Flags().StringVar(&safeProposeNote, "note", "", "free-text note stored in the file")
+	safeProposeCmd.Flags().StringVarP(&safeProposeOut, "out", "o", "", "output file (default: safe-<network>-<nonce>.json)")
+	safeProposeCmd.Flags().BoolVar(&safeProposeNoSign, "no-sign", false, "write the proposal without signing it")
+
+	safeSignCmd.Flags().StringVar(&safeSignSignature, "signature", "", "add a signature made elsewhere instead of signing")
+	safeSignCmd.Flags().StringVarP(&safeSignOut, "out", "o", "", "output file (default: update in place)")
+	safeSignCmd.Flags().BoolVarP(&safeSignYes, "yes", "y", false, "skip the confirmation prompt")
+
+	safeExecCmd.Flags().BoolVarP(&safeExecYes, "yes", "y", false, "skip the confirmation prompt")
+
+	safeCmd.AddCommand(safeInfoCmd, safeProposeCmd, safeSignCmd, safeExecCmd)
+}
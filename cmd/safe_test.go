@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	safeTestToken = "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+	safeTestBob   = "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+)
+
+func safeTestRegistry(t *testing.T) *contract.Registry {
+	t.Helper()
+	reg := contract.NewRegistry(filepath.Join(t.TempDir(), "contracts.json"))
+	addr := contract.ABIParam{Name: "to", Type: "address"}
+	amount := contract.ABIParam{Name: "amount", Type: "uint256"}
+	reg.Add(&contract.Entry{
+		Name: "usdc", Network: "base", Address: safeTestToken,
+		ABI: []contract.ABIEntry{
+			{Type: "function", Name: "transfer", Inputs: []contract.ABIParam{addr, amount}},
+			{Type: "function", Name: "mint", Inputs: []contract.ABIParam{amount}},
+			{Type: "function", Name: "mint", Inputs: []contract.ABIParam{addr, amount}},
+			{Type: "function", Name: "burn", Inputs: []contract.ABIParam{amount}},
+			{Type: "function", Name: "burn", Inputs: []contract.ABIParam{{Type: "int256"}}},
+		},
+	})
+	return reg
+}
+
+func TestSafeBatchCalls(t *testing.T) {
+	reg := safeTestRegistry(t)
+	calls, err := safeBatchCalls([]safeBatchItem{
+		{To: safeTestBob, Value: "0.5"},
+		{Contract: "usdc", Function: "transfer", Args: []string{safeTestBob, "1000000"}},
+		{To: safeTestToken, Sig: "approve(address,uint256)", Args: []string{safeTestBob, "0"}},
+		{To: safeTestToken, Data: "0xdeadbeef"},
+	}, "base", reg)
+	require.NoError(t, err)
+	require.Len(t, calls, 4)
+
+	assert.Equal(t, common.HexToAddress(safeTestBob), calls[0].To)
+	assert.Equal(t, big.NewInt(5e17), calls[0].Value)
+	assert.Empty(t, calls[0].Data)
+
+	assert.Equal(t, common.HexToAddress(safeTestToken), calls[1].To, "registry address")
+	assert.Equal(t, "0xa9059cbb", hexutil.Encode(calls[1].Data[:4]))
+	assert.Len(t, calls[1].Data, 68)
+
+	assert.Equal(t, "0x095ea7b3", hexutil.Encode(calls[2].Data[:4]))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, calls[3].Data)
+	assert.Equal(t, int64(0), calls[3].Value.Int64())
+}
+
+func TestSafeBatchCallsErrors(t *testing.T) {
+	reg := safeTestRegistry(t)
+	for name, items := range map[string][]safeBatchItem{
+		"empty":            nil,
+		"no destination":   {{Value: "1"}},
+		"bad address":      {{To: "0x12"}},
+		"bad value":        {{To: safeTestBob, Value: "lots"}},
+		"data and sig":     {{To: safeTestBob, Data: "0x00", Sig: "f()"}},
+		"fn without entry": {{To: safeTestBob, Function: "transfer"}},
+		"unknown contract": {{Contract: "dai", Function: "transfer"}},
+		"other network":    {{Contract: "usdc", Function: "burn", Args: []string{"1"}}},
+		"mixed":            {{Contract: "usdc", To: safeTestBob, Function: "mint"}},
+		"wrong arity":      {{Contract: "usdc", Function: "transfer", Args: []string{"1"}}},
+	} {
+		network := "base"
+		if name == "other network" {
+			network = "optimism"
+		}
+		_, err := safeBatchCalls(items, network, reg)
+		assert.Error(t, err, name)
+	}
+
+	_, err := safeBatchCalls([]safeBatchItem{{To: safeTestBob}, {To: "nope"}}, "base", reg)
+	assert.ErrorContains(t, err, "call 2")
+}
+
+func TestSafeFindFunction(t *testing.T) {
+	abi, _ := safeTestRegistry(t).Get("usdc", "base")
+
+	fn, err := safeFindFunction(abi.ABI, "mint", 2)
+	require.NoError(t, err)
+	assert.Len(t, fn.Inputs, 2, "overloads resolve by arity")
+
+	fn, err = safeFindFunction(abi.ABI, "burn(int256)", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "int256", fn.Inputs[0].Type)
+
+	_, err = safeFindFunction(abi.ABI, "burn", 1)
+	assert.ErrorContains(t, err, "overloaded")
+	_, err = safeFindFunction(abi.ABI, "approve", 2)
+	assert.Error(t, err)
+	_, err = safeFindFunction(abi.ABI, "burn(uint8)", 1)
+	assert.Error(t, err)
+}
+
+func TestSafeProposalPath(t *testing.T) {
+	assert.Equal(t, "safe-base-12.json", safeProposalPath("base", 12))
+}
@@ -28,6 +28,9 @@ var sendCmd = &cobra.Command{
 	Use:   "send",
 	Short: "Send native tokens or ERC-20 tokens",
 	Long: `Send native tokens or ERC-20 tokens to an address or wallet name.
+On Solana, --token takes an SPL mint and the recipient's associated token
+account is created if it does not exist yet. On SUI, --token takes a coin
+type; the transaction is built by the node, dry-run, then signed locally.
 
 Uses the configured network mode (mainnet/testnet) by default.
 Override per-call with --testnet or --mainnet.
@@ -36,7 +39,10 @@ Examples:
   w3cli send --to 0x... --value 0.1
   w3cli send --to myOtherWallet --value 0.001 --network ethereum
   w3cli send --to 0x... --value 100 --token 0xUSDC --network base
-  w3cli send --to 0x... --value 0.1 --testnet --gas fast`,
+  w3cli send --to 0x... --value 0.1 --testnet --gas fast
+  w3cli send --to <base58> --value 0.5 --network solana
+  w3cli send --to <base58> --value 25 --token <mint> --network solana
+  w3cli send --to 0x<64 hex> --value 1.5 --network sui`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if sendTo == "" {
 			return fmt.Errorf("--to is required — specify a recipient address or wallet name")
@@ -62,19 +68,32 @@ Examples:
 
 		warnIfNoSession()
 
-		// Resolve --to as wallet name or raw address.
-		toAddress, err := resolveToAddress(sendTo, mgr)
-		if err != nil {
-			return err
-		}
-
-		// Resolve chain.
+		// Resolve chain. Solana and SUI wallets default to their own chain.
+		chainName = walletNetwork(w.Chain(), sendNetwork, chainName)
 		reg := chain.NewRegistry()
 		c, err := reg.GetByName(chainName)
 		if err != nil {
 			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
 		}
 
+		switch {
+		case c.Type == chain.ChainTypeSolana:
+			return runSolanaSend(c, w, mgr)
+		case c.Type == chain.ChainTypeSUI:
+			return runSUISend(c, w, mgr)
+		case c.Type != chain.ChainTypeEVM:
+			return fmt.Errorf("send is not supported on %s yet", c.DisplayName)
+		case w.Chain() != wallet.ChainEVM:
+			return fmt.Errorf("wallet %q is a %s wallet and cannot send on %s", walletName, w.Chain(), c.DisplayName)
+		}
+
+		// Resolve --to as an address, wallet, contract, contact or ENS name.
+		toAddress, err := resolveAddress(sendTo, chainName)
+		if err != nil {
+			return err
+		}
+		warnRecipient(toAddress, chainName)
+
 		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
 		if err != nil {
 			return err
@@ -105,11 +124,6 @@ Examples:
 			return err
 		}
 
-		nonce, err := client.GetPendingNonce(w.Address)
-		if err != nil {
-			spin.Stop()
-			return err
-		}
 		spin.Stop()
 
 		ks := wallet.DefaultKeystore()
@@ -119,7 +133,7 @@ Examples:
 		// ── ERC-20 send ──────────────────────────────────────────────────────
 		if sendToken != "" {
 			return runTokenSend(client, signer, w.Address, toAddress, sendToken,
-				sendValue, gasPrice, nonce, chainID, c, explorer)
+				sendValue, gasPrice, chainID, c, explorer)
 		}
 
 		// ── Native ETH send (EIP-1559) ────────────────────────────────────────
@@ -150,13 +164,19 @@ Examples:
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		spin = ui.NewSpinner(fmt.Sprintf("Broadcasting on %s...", c.DisplayName))
 		spin.Start()
 
 		toAddr := common.HexToAddress(toAddress)
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
@@ -177,6 +197,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		spin = ui.NewSpinner("Waiting for confirmation...")
 		spin.Start()
@@ -202,7 +223,7 @@ Examples:
 
 // runTokenSend handles ERC-20 token sends.
 func runTokenSend(client *chain.EVMClient, signer *wallet.Signer, from, to, tokenAddr, valueStr string,
-	gasPrice *big.Int, nonce uint64, chainID int64, c *chain.Chain, explorer string) error {
+	gasPrice *big.Int, chainID int64, c *chain.Chain, explorer string) error {
 
 	// ── Prepare: fetch token info + estimate gas ─────────────────────────
 	spin := ui.NewSpinner(fmt.Sprintf("Preparing token transfer on %s...", c.DisplayName))
@@ -263,10 +284,16 @@ func runTokenSend(client *chain.EVMClient, signer *wallet.Signer, from, to, toke
 		return nil
 	}
 
+	lease, err := reserveNonce(client, chainID, from)
+	if err != nil {
+		return err
+	}
+	defer lease.Release()
+
 	tokenAddrCommon := common.HexToAddress(tokenAddr)
 	tx := types.NewTx(&types.DynamicFeeTx{
 		ChainID:   big.NewInt(chainID),
-		Nonce:     nonce,
+		Nonce:     lease.Nonce,
 		GasTipCap: gasPrice,
 		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 		Gas:       gasLimit,
@@ -287,6 +314,7 @@ func runTokenSend(client *chain.EVMClient, signer *wallet.Signer, from, to, toke
 	if err != nil {
 		return err
 	}
+	lease.Used()
 
 	spin = ui.NewSpinner("Waiting for confirmation...")
 	spin.Start()
@@ -310,24 +338,12 @@ func runTokenSend(client *chain.EVMClient, signer *wallet.Signer, from, to, toke
 func init() {
 	sendCmd.Flags().StringVar(&sendTo, "to", "", "recipient address or wallet name (required)")
 	sendCmd.Flags().StringVar(&sendValue, "value", "", "amount to send (required)")
-	sendCmd.Flags().StringVar(&sendToken, "token", "", "ERC-20 token contract address")
+	sendCmd.Flags().StringVar(&sendToken, "token", "", "ERC-20 token contract address (SPL mint on Solana, coin type on SUI)")
 	sendCmd.Flags().StringVar(&sendGas, "gas", "standard", "gas speed: slow|standard|fast")
 	sendCmd.Flags().StringVar(&sendNetwork, "network", "", "chain (default: config)")
 	sendCmd.Flags().StringVar(&sendWallet, "wallet", "", "wallet name (default: config)")
 }
 
-// resolveToAddress returns a hex address from a wallet name or raw 0x address.
-func resolveToAddress(s string, mgr *wallet.Manager) (string, error) {
-	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
-		return s, nil
-	}
-	w, err := mgr.Get(s)
-	if err != nil {
-		return "", fmt.Errorf("recipient %q is not an 0x address and no wallet named %q was found", s, s)
-	}
-	return w.Address, nil
-}
-
 // ethToWei converts a human-readable ETH string (e.g. "0.001") to wei
 // using exact string-based decimal arithmetic — no floating-point loss.
 func ethToWei(ethStr string) (*big.Int, error) {
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+)
+
+// solanaFeeLamports is the base fee for a single-signature transaction.
+const solanaFeeLamports = 5000
+
+// solanaATARentLamports is the rent-exempt minimum for a 165-byte token
+// account, paid by the sender when the recipient has no token account yet.
+const solanaATARentLamports = 2039280
+
+// resolveSolanaAddress returns a base58 address from a Solana wallet name,
+// a contact with a Solana address or a raw address.
+func resolveSolanaAddress(s string, mgr *wallet.Manager) (string, error) {
+	if solana.IsAddress(s) {
+		return s, nil
+	}
+	w, err := mgr.Get(s)
+	if err != nil {
+		if addr, ok := contactAddressMatching(s, solana.IsAddress); ok {
+			return addr, nil
+		}
+		return "", fmt.Errorf("recipient %q is not a Solana address, wallet or contact", s)
+	}
+	if w.Chain() != wallet.ChainSolana {
+		return "", fmt.Errorf("wallet %q is a %s wallet, not Solana", s, w.Chain())
+	}
+	return w.Address, nil
+}
+
+// solanaExplorerURL links to a tx or account on the chain's explorer, keeping
+// the explorer's cluster query (e.g. ?cluster=devnet) at the end.
+func solanaExplorerURL(c *chain.Chain, mode, kind, id string) string {
+	explorer := c.Explorer(mode)
+	if explorer == "" {
+		return ""
+	}
+	base, query := explorer, ""
+	if i := strings.Index(explorer, "?"); i >= 0 {
+		base, query = explorer[:i], explorer[i:]
+	}
+	return strings.TrimSuffix(base, "/") + "/" + kind + "/" + id + query
+}
+
+// pickSolanaSource chooses the token account to send from: the owner's
+// associated account when it has one, else the one with the largest balance.
+func pickSolanaSource(accts []chain.SolanaTokenAccount, owner, mint solana.PublicKey) *chain.SolanaTokenAccount {
+	var best *chain.SolanaTokenAccount
+	for i := range accts {
+		a := &accts[i]
+		program, err := solana.ParsePublicKey(a.Program)
+		if err != nil {
+			continue
+		}
+		if ata, err := solana.AssociatedTokenAddress(owner, mint, program); err == nil && ata.String() == a.Address {
+			return a
+		}
+		if best == nil || a.Amount.Cmp(best.Amount) > 0 {
+			best = a
+		}
+	}
+	return best
+}
+
+// runSolanaSend sends SOL, or an SPL token when --token is a mint address.
+func runSolanaSend(c *chain.Chain, w *wallet.Wallet, mgr *wallet.Manager) error {
+	if w.Chain() != wallet.ChainSolana {
+		return fmt.Errorf("wallet %q is a %s wallet — add a Solana wallet with `w3cli wallet generate <name> --chain solana`", w.Name, w.Chain())
+	}
+	toAddress, err := resolveSolanaAddress(sendTo, mgr)
+	if err != nil {
+		return err
+	}
+	warnRecipient(toAddress, c.Name)
+	from, err := solana.ParsePublicKey(w.Address)
+	if err != nil {
+		return err
+	}
+	to, _ := solana.ParsePublicKey(toAddress)
+
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return err
+	}
+	client := chain.NewSolanaClient(rpcURL)
+	network := fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)
+
+	var (
+		ixs     []solana.Instruction
+		preview [][2]string
+		fee     uint64 = solanaFeeLamports
+	)
+
+	if sendToken == "" {
+		lamports, errMsg := scaleTokenInput(sendValue, 9)
+		if errMsg != "" {
+			return fmt.Errorf("invalid value %q: %s", sendValue, errMsg)
+		}
+		if !lamports.IsUint64() || lamports.Sign() == 0 {
+			return fmt.Errorf("invalid value %q", sendValue)
+		}
+		ixs = append(ixs, solana.SystemTransfer(from, to, lamports.Uint64()))
+		preview = [][2]string{
+			{"From", ui.Addr(w.Address)},
+			{"To", ui.Addr(toAddress)},
+			{"Value", sendValue + " SOL"},
+		}
+	} else {
+		mint, err := solana.ParsePublicKey(sendToken)
+		if err != nil {
+			return fmt.Errorf("--token on Solana must be a mint address: %w", err)
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Preparing token transfer on %s...", c.DisplayName))
+		spin.Start()
+		accts, err := client.GetTokenAccountsByOwner(w.Address, sendToken)
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		src := pickSolanaSource(accts, from, mint)
+		if src == nil {
+			spin.Stop()
+			return fmt.Errorf("wallet %q holds no token account for mint %s", w.Name, sendToken)
+		}
+		program, _ := solana.ParsePublicKey(src.Program)
+		srcPK, _ := solana.ParsePublicKey(src.Address)
+
+		amount, errMsg := scaleTokenInput(sendValue, src.Decimals)
+		if errMsg != "" || !amount.IsUint64() || amount.Sign() == 0 {
+			spin.Stop()
+			return fmt.Errorf("invalid value %q", sendValue)
+		}
+		if amount.Cmp(src.Amount) > 0 {
+			spin.Stop()
+			return fmt.Errorf("insufficient token balance: have %s, sending %s", formatTokenAmount(src.Amount, src.Decimals), sendValue)
+		}
+
+		dest, err := solana.AssociatedTokenAddress(to, mint, program)
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		exists, err := client.AccountExists(dest.String())
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+
+		preview = [][2]string{
+			{"From", ui.Addr(w.Address)},
+			{"To", ui.Addr(toAddress)},
+			{"Mint", ui.Addr(sendToken)},
+			{"Amount", fmt.Sprintf("%s (decimals: %d)", sendValue, src.Decimals)},
+		}
+		if !exists {
+			ixs = append(ixs, solana.CreateAssociatedTokenAccountIdempotent(from, dest, to, mint, program))
+			fee += solanaATARentLamports
+			preview = append(preview, [2]string{"Token Account", "created for recipient (rent paid by sender)"})
+		}
+		ixs = append(ixs, solana.TransferChecked(program, srcPK, mint, dest, from, amount.Uint64(), uint8(src.Decimals)))
+	}
+
+	preview = append(preview,
+		[2]string{"Max Fee", formatTokenAmount(new(big.Int).SetUint64(fee), 9) + " SOL"},
+		[2]string{"Network", network},
+	)
+	fmt.Println(ui.KeyValueBlock("Transaction Preview · "+network, preview))
+
+	if !ui.Confirm("Broadcast this transaction?") {
+		fmt.Println(ui.Meta("Cancelled."))
+		return nil
+	}
+
+	spin := ui.NewSpinner("Signing & sending transaction...")
+	spin.Start()
+	blockhash, _, err := client.GetLatestBlockhash()
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+	msg, err := solana.NewMessage(from, blockhash, ixs...)
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+	msgBytes := msg.Serialize()
+	sig, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignSolana(msgBytes)
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+	raw, err := solana.EncodeTransaction([][]byte{sig}, msgBytes)
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+	txSig, err := client.SendTransaction(raw)
+	spin.Stop()
+	if err != nil {
+		return err
+	}
+
+	spin = ui.NewSpinner("Waiting for confirmation...")
+	spin.Start()
+	status, err := client.WaitForConfirmation(txSig, config.TxConfirmTimeout)
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("tx %s: %w", txSig, err)
+	}
+
+	url := solanaExplorerURL(c, cfg.NetworkMode, "tx", txSig)
+	fmt.Println()
+	fmt.Println(ui.KeyValueBlock("Transaction Confirmed ✓", [][2]string{
+		{"Signature", ui.Addr(txSig)},
+		{"Slot", fmt.Sprintf("%d", status.Slot)},
+		{"Network", network},
+		{"Explorer", url},
+	}))
+	fmt.Println(ui.Hint("History: w3cli txs " + w.Name + " --network " + c.Name))
+	ui.OpenURL(url)
+	return nil
+}
+
+// printSolanaTokens lists the SPL token accounts owned by address. When mint
+// is set only that token is shown.
+func printSolanaTokens(client *chain.SolanaClient, address, mint string) error {
+	accts, err := client.GetTokenAccountsByOwner(address, mint)
+	if err != nil {
+		return err
+	}
+	if len(accts) == 0 {
+		if mint != "" {
+			fmt.Println(ui.Info("No token account for mint " + mint + " (balance 0)."))
+		} else {
+			fmt.Println(ui.Meta("No SPL token accounts."))
+		}
+		return nil
+	}
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "MINT", Width: 14},
+		{Title: "BALANCE", Width: 24},
+		{Title: "DEC", Width: 4},
+		{Title: "ACCOUNT", Width: 14},
+		{Title: "PROGRAM", Width: 10},
+	})
+	for _, a := range accts {
+		program := "token"
+		if a.Program == chain.SolanaToken2022Program {
+			program = "token-2022"
+		}
+		t.AddRow(ui.Row{
+			ui.TruncateAddr(a.Mint),
+			a.Formatted,
+			fmt.Sprintf("%d", a.Decimals),
+			ui.TruncateAddr(a.Address),
+			program,
+		})
+	}
+	fmt.Println(t.Render())
+	fmt.Println(ui.Meta(fmt.Sprintf("%d token account(s)", len(accts))))
+	return nil
+}
+
+// runSolanaTxs lists recent transactions for a Solana address.
+func runSolanaTxs(c *chain.Chain, address, networkMode string, limit int) error {
+	rpcURL, err := pickBestRPC(c, networkMode)
+	if err != nil {
+		return err
+	}
+	spin := ui.NewSpinner(fmt.Sprintf("Fetching last %d transactions on %s (%s)...", limit, c.DisplayName, networkMode))
+	spin.Start()
+	sigs, err := chain.NewSolanaClient(rpcURL).GetSignaturesForAddress(address, limit)
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("fetching transactions: %w", err)
+	}
+	if len(sigs) == 0 {
+		fmt.Println(ui.Info("No recent transactions found."))
+		return nil
+	}
+	fmt.Println(ui.Info(fmt.Sprintf("Found %d transaction(s) via getSignaturesForAddress.", len(sigs))))
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "SIGNATURE", Width: 14},
+		{Title: "ST", Width: 2},
+		{Title: "SLOT", Width: 12},
+		{Title: "MEMO", Width: 20},
+		{Title: "AGE", Width: 10},
+	})
+	now := time.Now().Unix()
+	rows := make([]ui.TxRow, 0, len(sigs))
+	for _, s := range sigs {
+		status := ui.StyleSuccess.Render("✓")
+		if !s.Success {
+			status = ui.StyleError.Render("✗")
+		}
+		memo := s.Memo
+		if len(memo) > 20 {
+			memo = memo[:18] + ".."
+		}
+		age := ""
+		if s.BlockTime > 0 && now > s.BlockTime {
+			age = relativeAge(uint64(now - s.BlockTime))
+		}
+		t.AddRow(ui.Row{ui.TruncateAddr(s.Signature), status, fmt.Sprintf("%d", s.Slot), memo, age})
+		rows = append(rows, ui.TxRow{
+			FullHash:    s.Signature,
+			ExplorerURL: solanaExplorerURL(c, networkMode, "tx", s.Signature),
+		})
+	}
+
+	title := ui.StyleTitle.Render(
+		fmt.Sprintf("📋 Recent Transactions  ·  %s  ·  %s · %s",
+			ui.TruncateAddr(address), c.Name, networkMode),
+	)
+	return ui.RunTxList(title, t, rows)
+}
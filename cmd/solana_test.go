@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolanaExplorerURLKeepsClusterQuery(t *testing.T) {
+	c := &chain.Chain{MainnetExplorer: "https://solscan.io", TestnetExplorer: "https://solscan.io/?cluster=devnet"}
+	assert.Equal(t, "https://solscan.io/tx/SIG", solanaExplorerURL(c, "mainnet", "tx", "SIG"))
+	assert.Equal(t, "https://solscan.io/tx/SIG?cluster=devnet", solanaExplorerURL(c, "testnet", "tx", "SIG"))
+	assert.Empty(t, solanaExplorerURL(&chain.Chain{}, "mainnet", "tx", "SIG"))
+}
+
+func TestWalletChainTypeDetection(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	cases := map[string]string{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266":                       wallet.ChainEVM,
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80": wallet.ChainEVM,
+		solana.PublicKeyOf(priv).String():                                  wallet.ChainSolana,
+		solana.EncodePrivateKey(priv):                                      wallet.ChainSolana,
+		"[1,2,3]":                                                          wallet.ChainSolana,
+	}
+	for value, want := range cases {
+		got, err := walletChainType("", value, false)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, value)
+	}
+
+	got, err := walletChainType("SOLANA", "0xabc", false)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainSolana, got, "explicit flag wins")
+
+	_, err = walletChainType("cosmos", "", false)
+	assert.Error(t, err)
+}
+
+func TestPickSolanaSourcePrefersAssociatedAccount(t *testing.T) {
+	owner := solana.PublicKeyOf(ed25519.NewKeyFromSeed(make([]byte, 32)))
+	mint := solana.AssociatedTokenProgramID // any 32-byte key works as a mint here
+	ata, err := solana.AssociatedTokenAddress(owner, mint, solana.TokenProgramID)
+	require.NoError(t, err)
+
+	accts := []chain.SolanaTokenAccount{
+		{Address: "Aux1111111111111111111111111111111111111111", Program: chain.SolanaTokenProgram, Amount: big.NewInt(500)},
+		{Address: ata.String(), Program: chain.SolanaTokenProgram, Amount: big.NewInt(1)},
+	}
+	assert.Equal(t, ata.String(), pickSolanaSource(accts, owner, mint).Address)
+
+	// Without an associated account the largest balance wins.
+	assert.Equal(t, accts[0].Address, pickSolanaSource(accts[:1], owner, mint).Address)
+	assert.Nil(t, pickSolanaSource(nil, owner, mint))
+}
+
+func TestRelativeAge(t *testing.T) {
+	assert.Equal(t, "42s ago", relativeAge(42))
+	assert.Equal(t, "5m ago", relativeAge(300))
+	assert.Equal(t, "3h ago", relativeAge(3*3600+10))
+	assert.Equal(t, "2d ago", relativeAge(2*86400))
+}
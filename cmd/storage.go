@@ -1,20 +1,26 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var storageNetwork string
+var (
+	storageNetwork string
+	storageVar     string // --var
+	storageLayout  string // --layout
+)
 
 var storageCmd = &cobra.Command{
-	Use:   "storage <address> <slot>",
-	Short: "Read a raw storage slot from a contract",
+	Use:   "storage <address-or-name> [slot]",
+	Short: "Read a storage slot or a named state variable from a contract",
 	Long: `Read the raw 32-byte value at a specific storage slot of a contract.
 
 Storage slots can be specified as decimal numbers or hex (0x-prefixed).
@@ -28,11 +34,35 @@ Common slots:
   0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103
          — EIP-1967 admin slot
 
+Named variables (--var):
+  With a solc storage layout the slot is computed from a variable path —
+  mappings, dynamic and static arrays, structs and packed variables — and the
+  value decoded by type. The layout comes from the artifact the contract was
+  registered with (deploy, import, import-deployments) or --layout. Compile
+  with the layout enabled:
+    Foundry:  extra_output = ["storageLayout"]
+    Hardhat:  outputSelection "*": { "*": ["storageLayout"] }
+  For proxies, pass the implementation's artifact with --layout.
+
 Examples:
   w3cli storage 0xContract 0
-  w3cli storage 0xProxy 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc`,
-	Args: cobra.ExactArgs(2),
+  w3cli storage 0xProxy 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc
+  w3cli storage myToken 0                        # registered contract name
+  w3cli storage myToken --var owner
+  w3cli storage myToken --var 'balances[0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045]'
+  w3cli storage vault --var 'positions[3].amount' --layout ./out/Vault.sol/Vault.json
+  w3cli storage dump myToken                     # all top-level variables`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if storageVar != "" {
+			if len(args) != 1 {
+				return fmt.Errorf("--var replaces the slot argument — pass only the contract")
+			}
+			return runStorageVar(args[0], storageVar)
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("provide a slot, or --var <name> with a storage layout")
+		}
 		address := args[0]
 		slot := args[1]
 
@@ -40,6 +70,10 @@ Examples:
 		if chainName == "" {
 			chainName = cfg.DefaultNetwork
 		}
+		address, err := resolveAddress(address, chainName)
+		if err != nil {
+			return err
+		}
 
 		reg := chain.NewRegistry()
 		c, err := reg.GetByName(chainName)
@@ -115,6 +149,183 @@ Examples:
 	},
 }
 
+// ── storage dump ─────────────────────────────────────────────────────────────
+
+var storageDumpCmd = &cobra.Command{
+	Use:   "dump <address-or-name>",
+	Short: "Decode all top-level state variables using the storage layout",
+	Long: `Print every top-level state variable of a contract, decoded by type.
+
+Mappings are listed but not enumerated (index them with --var), dynamic arrays
+show their length. See "w3cli storage --help" for where the layout comes from.
+
+Examples:
+  w3cli storage dump myToken --network base
+  w3cli storage dump 0xProxy --layout ./out/VaultV2.sol/VaultV2.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t, err := loadStorageTarget(args[0])
+		if err != nil {
+			return err
+		}
+
+		tbl := ui.NewTable([]ui.Column{
+			{Title: "Slot", Width: 6},
+			{Title: "Off", Width: 4},
+			{Title: "Variable", Width: 22},
+			{Title: "Type", Width: 26},
+			{Title: "Value", Width: 48},
+		})
+		spin := ui.NewSpinner(fmt.Sprintf("Reading storage on %s...", t.chain.DisplayName))
+		spin.Start()
+		for _, v := range t.layout.Storage {
+			loc, err := t.layout.Resolve(v.Label)
+			if err != nil {
+				spin.Stop()
+				return err
+			}
+			value, err := t.layout.Decode(loc, t.read)
+			if err != nil {
+				value = ui.Err(err.Error())
+			}
+			tbl.AddRow(ui.Row{v.Slot, fmt.Sprintf("%d", v.Offset), ui.Val(v.Label), ui.Meta(loc.Type.Label), value})
+		}
+		spin.Stop()
+
+		fmt.Println(ui.Meta(fmt.Sprintf("%s on %s (%s)", t.address, t.chain.DisplayName, cfg.NetworkMode)))
+		fmt.Println(tbl.Render())
+		fmt.Println(ui.Meta(fmt.Sprintf("%d variable(s)", len(t.layout.Storage))))
+		return nil
+	},
+}
+
+// storageTarget is a contract with a storage layout and a cached slot reader.
+type storageTarget struct {
+	address string
+	chain   *chain.Chain
+	layout  *contract.StorageLayout
+	read    contract.SlotReader
+}
+
+// loadStorageTarget resolves input to an address and loads its layout from
+// --layout or the artifact recorded in the contract registry.
+func loadStorageTarget(input string) (*storageTarget, error) {
+	chainName := storageNetwork
+	if chainName == "" {
+		chainName = cfg.DefaultNetwork
+	}
+	address, err := resolveAddress(input, chainName)
+	if err != nil {
+		return nil, err
+	}
+
+	source := storageLayout
+	if source == "" {
+		reg := newContractRegistry()
+		if err := reg.Load(); err != nil {
+			return nil, err
+		}
+		source = layoutSourceFor(reg.All(), address, chainName)
+		if source == "" {
+			return nil, fmt.Errorf("no artifact recorded for %s on %s — pass --layout <artifact>", address, chainName)
+		}
+	}
+	layout, err := contract.LoadStorageLayout(source)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := chain.NewRegistry().GetByName(chainName)
+	if err != nil {
+		return nil, fmt.Errorf("unknown chain %q", chainName)
+	}
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return nil, err
+	}
+	client := chain.NewEVMClient(rpcURL)
+
+	// Packed variables share slots — read each slot once.
+	cache := map[string][]byte{}
+	read := func(slot *big.Int) ([]byte, error) {
+		key := contract.SlotHex(slot)
+		if w, ok := cache[key]; ok {
+			return w, nil
+		}
+		value, err := client.GetStorageAt(address, key)
+		if err != nil {
+			return nil, fmt.Errorf("reading slot %s: %w", key, err)
+		}
+		w, err := storageWord(value)
+		if err != nil {
+			return nil, err
+		}
+		cache[key] = w
+		return w, nil
+	}
+	return &storageTarget{address: address, chain: c, layout: layout, read: read}, nil
+}
+
+// layoutSourceFor returns the artifact path registered for address on
+// network, or "" when there is none.
+func layoutSourceFor(entries []*contract.Entry, address, network string) string {
+	for _, e := range entries {
+		if e.Network == network && strings.EqualFold(e.Address, address) && e.ABISource != "" {
+			return e.ABISource
+		}
+	}
+	return ""
+}
+
+// storageWord decodes an eth_getStorageAt result into 32 bytes.
+func storageWord(value string) ([]byte, error) {
+	h := strings.TrimPrefix(value, "0x")
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) > 32 {
+		return nil, fmt.Errorf("invalid storage word %q", value)
+	}
+	return append(make([]byte, 32-len(b)), b...), nil
+}
+
+func runStorageVar(input, path string) error {
+	t, err := loadStorageTarget(input)
+	if err != nil {
+		return err
+	}
+	loc, err := t.layout.Resolve(path)
+	if err != nil {
+		return err
+	}
+
+	spin := ui.NewSpinner(fmt.Sprintf("Reading storage on %s...", t.chain.DisplayName))
+	spin.Start()
+	value, err := t.layout.Decode(loc, t.read)
+	spin.Stop()
+	if err != nil {
+		return err
+	}
+
+	pairs := [][2]string{
+		{"Contract", ui.Addr(t.address)},
+		{"Network", fmt.Sprintf("%s (%s)", t.chain.DisplayName, cfg.NetworkMode)},
+		{"Variable", ui.Val(loc.Path)},
+		{"Type", loc.Type.Label},
+		{"Slot", contract.SlotHex(loc.Slot)},
+	}
+	if loc.Offset > 0 {
+		pairs = append(pairs, [2]string{"Offset", fmt.Sprintf("%d bytes", loc.Offset)})
+	}
+	pairs = append(pairs, [2]string{"Value", ui.Val(value)})
+	fmt.Println(ui.KeyValueBlock("Storage Variable", pairs))
+	return nil
+}
+
 func init() {
-	storageCmd.Flags().StringVar(&storageNetwork, "network", "", "chain (default: config)")
+	storageCmd.PersistentFlags().StringVar(&storageNetwork, "network", "", "chain (default: config)")
+	storageCmd.PersistentFlags().StringVar(&storageLayout, "layout", "", "artifact or storageLayout JSON (default: the registered artifact)")
+	storageCmd.Flags().StringVar(&storageVar, "var", "", "state variable path, e.g. owner, balances[0xabc], positions[3].amount")
+	storageCmd.AddCommand(storageDumpCmd)
 }
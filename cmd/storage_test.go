@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageWord(t *testing.T) {
+	w, err := storageWord("0x1")
+	require.NoError(t, err)
+	require.Len(t, w, 32)
+	assert.Equal(t, byte(1), w[31])
+
+	w, err = storageWord("0x")
+	require.NoError(t, err)
+	assert.Len(t, w, 32)
+
+	_, err = storageWord("0xzz")
+	assert.Error(t, err)
+}
+
+func TestLayoutSourceFor(t *testing.T) {
+	entries := []*contract.Entry{
+		{Name: "a", Network: "base", Address: "0xAbC0000000000000000000000000000000000001", ABISource: "./out/A.json"},
+		{Name: "b", Network: "ethereum", Address: "0xabc0000000000000000000000000000000000001", ABISource: "./out/B.json"},
+		{Name: "c", Network: "base", Address: "0x0000000000000000000000000000000000000002"},
+	}
+	assert.Equal(t, "./out/A.json", layoutSourceFor(entries, "0xabc0000000000000000000000000000000000001", "base"))
+	assert.Equal(t, "./out/B.json", layoutSourceFor(entries, "0xabc0000000000000000000000000000000000001", "ethereum"))
+	assert.Empty(t, layoutSourceFor(entries, "0x0000000000000000000000000000000000000002", "base"))
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+)
+
+// resolveSUIAddress returns a 0x address from a SUI wallet name, a contact
+// with a SUI address or a raw 32-byte address.
+func resolveSUIAddress(s string, mgr *wallet.Manager) (string, error) {
+	if sui.IsAddress(s) {
+		return strings.ToLower(s), nil
+	}
+	w, err := mgr.Get(s)
+	if err != nil {
+		if addr, ok := contactAddressMatching(s, sui.IsAddress); ok {
+			return addr, nil
+		}
+		return "", fmt.Errorf("recipient %q is not a SUI address, wallet or contact", s)
+	}
+	if w.Chain() != wallet.ChainSUI {
+		return "", fmt.Errorf("wallet %q is a %s wallet, not SUI", s, w.Chain())
+	}
+	return w.Address, nil
+}
+
+// suiExplorerURL links to a tx, account or object on suiscan, which expects
+// the network as the first path segment.
+func suiExplorerURL(c *chain.Chain, mode, kind, id string) string {
+	base := strings.TrimSuffix(c.Explorer(mode), "/")
+	if base == "" {
+		return ""
+	}
+	if !strings.HasSuffix(base, "/mainnet") && !strings.HasSuffix(base, "/testnet") {
+		base += "/mainnet"
+	}
+	return base + "/" + kind + "/" + id
+}
+
+// selectSUICoins picks coins (largest first) until they cover need. Returns
+// the IDs and their total; the total is short of need if the wallet is.
+func selectSUICoins(coins []chain.SUICoin, need *big.Int) ([]string, *big.Int) {
+	var ids []string
+	total := new(big.Int)
+	for _, c := range coins {
+		if total.Cmp(need) >= 0 {
+			break
+		}
+		ids = append(ids, c.ObjectID)
+		total.Add(total, c.Balance)
+	}
+	return ids, total
+}
+
+// suiCoinDecimals returns the decimals and symbol for coinType, falling back
+// to the last segment of the type when no metadata is published.
+func suiCoinDecimals(client *chain.SUIClient, coinType string) (int, string) {
+	if coinType == chain.SUICoinType {
+		return 9, "SUI"
+	}
+	symbol := coinType[strings.LastIndex(coinType, ":")+1:]
+	md, err := client.GetCoinMetadata(coinType)
+	if err != nil || md == nil {
+		return 0, symbol
+	}
+	if md.Symbol != "" {
+		symbol = md.Symbol
+	}
+	return md.Decimals, symbol
+}
+
+// runSUISend sends SUI, or another coin when --token is a coin type. The
+// transaction is built by the node (unsafe_paySui / unsafe_pay), dry-run,
+// then signed locally.
+func runSUISend(c *chain.Chain, w *wallet.Wallet, mgr *wallet.Manager) error {
+	if w.Chain() != wallet.ChainSUI {
+		return fmt.Errorf("wallet %q is a %s wallet — add a SUI wallet with `w3cli wallet generate <name> --chain sui`", w.Name, w.Chain())
+	}
+	toAddress, err := resolveSUIAddress(sendTo, mgr)
+	if err != nil {
+		return err
+	}
+	warnRecipient(toAddress, c.Name)
+
+	rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+	if err != nil {
+		return err
+	}
+	client := chain.NewSUIClient(rpcURL)
+	network := fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)
+
+	coinType := sendToken
+	if coinType == "" {
+		coinType = chain.SUICoinType
+	}
+
+	spin := ui.NewSpinner(fmt.Sprintf("Preparing transfer on %s...", c.DisplayName))
+	spin.Start()
+
+	decimals, symbol := suiCoinDecimals(client, coinType)
+	amount, errMsg := scaleTokenInput(sendValue, decimals)
+	if errMsg != "" || amount.Sign() == 0 {
+		spin.Stop()
+		return fmt.Errorf("invalid value %q", sendValue)
+	}
+
+	coins, err := client.GetCoins(w.Address, coinType)
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+
+	var txBytes string
+	budget := uint64(chain.SUIDefaultGasBudget)
+	if coinType == chain.SUICoinType {
+		// paySui takes gas from the same coins.
+		need := new(big.Int).Add(amount, new(big.Int).SetUint64(budget))
+		ids, total := selectSUICoins(coins, need)
+		if total.Cmp(amount) < 0 {
+			spin.Stop()
+			return fmt.Errorf("insufficient SUI: have %s, sending %s", formatTokenAmount(total, 9), sendValue)
+		}
+		txBytes, err = client.PaySui(w.Address, ids, []string{toAddress}, []*big.Int{amount}, budget)
+	} else {
+		ids, total := selectSUICoins(coins, amount)
+		if total.Cmp(amount) < 0 {
+			spin.Stop()
+			return fmt.Errorf("insufficient %s: have %s, sending %s", symbol, formatTokenAmount(total, decimals), sendValue)
+		}
+		txBytes, err = client.Pay(w.Address, ids, []string{toAddress}, []*big.Int{amount}, budget)
+	}
+	if err != nil {
+		spin.Stop()
+		return err
+	}
+
+	dry, err := client.DryRun(txBytes)
+	spin.Stop()
+	if err != nil {
+		return err
+	}
+	if !dry.Success {
+		return fmt.Errorf("dry run failed: %s", dry.Error)
+	}
+
+	preview := [][2]string{
+		{"From", ui.Addr(w.Address)},
+		{"To", ui.Addr(toAddress)},
+		{"Amount", sendValue + " " + symbol},
+	}
+	if coinType != chain.SUICoinType {
+		preview = append(preview, [2]string{"Coin Type", coinType})
+	}
+	preview = append(preview,
+		[2]string{"Est. Gas", formatTokenAmount(dry.GasUsed, 9) + " SUI"},
+		[2]string{"Gas Budget", formatTokenAmount(new(big.Int).SetUint64(budget), 9) + " SUI"},
+		[2]string{"Network", network},
+	)
+	fmt.Println(ui.KeyValueBlock("Transaction Preview · "+network, preview))
+
+	if !ui.Confirm("Broadcast this transaction?") {
+		fmt.Println(ui.Meta("Cancelled."))
+		return nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(txBytes)
+	if err != nil {
+		return fmt.Errorf("decoding transaction bytes: %w", err)
+	}
+	sig, err := wallet.NewSigner(w, wallet.DefaultKeystore()).SignSUI(raw)
+	if err != nil {
+		return err
+	}
+
+	spin = ui.NewSpinner("Executing transaction...")
+	spin.Start()
+	fx, err := client.ExecuteTransaction(txBytes, sig)
+	spin.Stop()
+	if err != nil {
+		if fx != nil {
+			return fmt.Errorf("tx %s: %w", fx.Digest, err)
+		}
+		return err
+	}
+
+	url := suiExplorerURL(c, cfg.NetworkMode, "tx", fx.Digest)
+	fmt.Println()
+	fmt.Println(ui.KeyValueBlock("Transaction Confirmed ✓", [][2]string{
+		{"Digest", ui.Addr(fx.Digest)},
+		{"Gas Used", formatTokenAmount(fx.GasUsed, 9) + " SUI"},
+		{"Network", network},
+		{"Explorer", url},
+	}))
+	fmt.Println(ui.Hint("History: w3cli txs " + w.Name + " --network " + c.Name))
+	ui.OpenURL(url)
+	return nil
+}
+
+// printSUIBalances lists every coin type address holds. When coinType is set
+// only that coin is shown.
+func printSUIBalances(client *chain.SUIClient, address, coinType string) error {
+	bals, err := client.GetAllBalances(address)
+	if err != nil {
+		return err
+	}
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "COIN", Width: 10},
+		{Title: "BALANCE", Width: 24},
+		{Title: "OBJECTS", Width: 7},
+		{Title: "TYPE", Width: 40},
+	})
+	shown := 0
+	for _, b := range bals {
+		if coinType != "" && b.CoinType != coinType {
+			continue
+		}
+		decimals, symbol := suiCoinDecimals(client, b.CoinType)
+		typ := b.CoinType
+		if len(typ) > 40 {
+			typ = typ[:18] + "…" + typ[len(typ)-21:]
+		}
+		t.AddRow(ui.Row{symbol, formatTokenAmount(b.Total, decimals), fmt.Sprintf("%d", b.Objects), typ})
+		shown++
+	}
+	if shown == 0 {
+		if coinType != "" {
+			fmt.Println(ui.Info("No " + coinType + " coins (balance 0)."))
+		} else {
+			fmt.Println(ui.Meta("No coins."))
+		}
+		return nil
+	}
+	fmt.Println(t.Render())
+	fmt.Println(ui.Meta(fmt.Sprintf("%d coin type(s)", shown)))
+	return nil
+}
+
+// runSUITxs lists recent transactions for a SUI address.
+func runSUITxs(c *chain.Chain, address, networkMode string, limit int) error {
+	rpcURL, err := pickBestRPC(c, networkMode)
+	if err != nil {
+		return err
+	}
+	spin := ui.NewSpinner(fmt.Sprintf("Fetching last %d transactions on %s (%s)...", limit, c.DisplayName, networkMode))
+	spin.Start()
+	txs, err := chain.NewSUIClient(rpcURL).QueryTransactionBlocks(address, limit)
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("fetching transactions: %w", err)
+	}
+	if len(txs) == 0 {
+		fmt.Println(ui.Info("No recent transactions found."))
+		return nil
+	}
+	fmt.Println(ui.Info(fmt.Sprintf("Found %d transaction(s) via suix_queryTransactionBlocks.", len(txs))))
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "DIGEST", Width: 14},
+		{Title: "ST", Width: 2},
+		{Title: "DIR", Width: 4},
+		{Title: "CHECKPOINT", Width: 12},
+		{Title: "AGE", Width: 10},
+	})
+	now := time.Now().Unix()
+	rows := make([]ui.TxRow, 0, len(txs))
+	for _, tx := range txs {
+		status := ui.StyleSuccess.Render("✓")
+		if !tx.Success {
+			status = ui.StyleError.Render("✗")
+		}
+		dir := "in"
+		if strings.EqualFold(tx.Sender, address) {
+			dir = "out"
+		}
+		age := ""
+		if tx.Timestamp > 0 && now > tx.Timestamp {
+			age = relativeAge(uint64(now - tx.Timestamp))
+		}
+		t.AddRow(ui.Row{ui.TruncateAddr(tx.Digest), status, dir, fmt.Sprintf("%d", tx.Checkpoint), age})
+		rows = append(rows, ui.TxRow{
+			FullHash:    tx.Digest,
+			ExplorerURL: suiExplorerURL(c, networkMode, "tx", tx.Digest),
+		})
+	}
+
+	title := ui.StyleTitle.Render(
+		fmt.Sprintf("📋 Recent Transactions  ·  %s  ·  %s · %s",
+			ui.TruncateAddr(address), c.Name, networkMode),
+	)
+	return ui.RunTxList(title, t, rows)
+}
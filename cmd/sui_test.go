@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSUIExplorerURLAddsNetworkSegment(t *testing.T) {
+	c := &chain.Chain{MainnetExplorer: "https://suiscan.xyz", TestnetExplorer: "https://suiscan.xyz/testnet"}
+	assert.Equal(t, "https://suiscan.xyz/mainnet/tx/D1", suiExplorerURL(c, "mainnet", "tx", "D1"))
+	assert.Equal(t, "https://suiscan.xyz/testnet/tx/D1", suiExplorerURL(c, "testnet", "tx", "D1"))
+}
+
+func TestSelectSUICoins(t *testing.T) {
+	coins := []chain.SUICoin{
+		{ObjectID: "0xa", Balance: big.NewInt(100)},
+		{ObjectID: "0xb", Balance: big.NewInt(50)},
+		{ObjectID: "0xc", Balance: big.NewInt(5)},
+	}
+	ids, total := selectSUICoins(coins, big.NewInt(120))
+	assert.Equal(t, []string{"0xa", "0xb"}, ids)
+	assert.Equal(t, big.NewInt(150), total)
+
+	ids, total = selectSUICoins(coins, big.NewInt(1000))
+	assert.Len(t, ids, 3)
+	assert.Equal(t, big.NewInt(155), total, "short when the wallet is")
+}
+
+func TestWalletChainTypeDetectsSUI(t *testing.T) {
+	addr := "0x" + strings.Repeat("ab", 32)
+	got, err := walletChainType("", addr, false)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainSUI, got)
+
+	// The same shape as a key is an EVM private key.
+	got, err = walletChainType("", addr, true)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainEVM, got)
+
+	got, err = walletChainType("", "suiprivkey1qq...", true)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainSUI, got)
+}
+
+func TestWalletNetwork(t *testing.T) {
+	assert.Equal(t, "base", walletNetwork(wallet.ChainSUI, "base", "ethereum"), "flag wins")
+	assert.Equal(t, "sui", walletNetwork(wallet.ChainSUI, "", "ethereum"))
+	assert.Equal(t, "solana", walletNetwork(wallet.ChainSolana, "", "ethereum"))
+	assert.Equal(t, "ethereum", walletNetwork(wallet.ChainEVM, "", "ethereum"))
+}
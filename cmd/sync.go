@@ -2,27 +2,61 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	csync "github.com/Mohsinsiddi/w3cli/internal/sync"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
 	"github.com/spf13/cobra"
 )
 
-var syncWatch bool
+var (
+	syncWatch  bool
+	syncDryRun bool
+	syncPrune  bool
+	syncForce  bool
+	syncWallet string
+)
 
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync contracts from a remote manifest",
+	Long: `Sync contracts from a team deployments manifest.
+
+A manifest maps contract names to per-network addresses and ABIs:
+
+  {
+    "version": 12,
+    "contracts": {
+      "Vault": {
+        "base": {"address": "0x...", "abi_url": "abis/Vault.json", "abi_hash": "0x..."}
+      }
+    }
+  }
+
+version must not go down between syncs, abi_hash pins the ABI file
+(w3cli sync hash <file>), and relative abi_url paths are read next to the
+manifest. With a pinned signer (w3cli sync set-signer) the manifest must
+come with an EIP-191 signature in <manifest>.sig (w3cli sync sign), and
+ABIs without an abi_hash are not taken from it.`,
 }
 
 var syncSetSourceCmd = &cobra.Command{
-	Use:   "set-source <url>",
-	Short: "Set the remote deployments manifest URL",
-	Args:  cobra.ExactArgs(1),
+	Use:   "set-source <source>",
+	Short: "Set the deployments manifest source",
+	Long: `Set where the deployments manifest is read from.
+
+Examples:
+  w3cli sync set-source https://example.com/deployments.json
+  w3cli sync set-source file:///srv/deploys/deployments.json
+  w3cli sync set-source ./deployments.json
+  w3cli sync set-source "git+https://github.com/acme/deploys.git#main:deployments.json"`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		url := args[0]
 		reg := contract.NewRegistry(filepath.Join(cfg.Dir(), "contracts.json"))
@@ -31,7 +65,36 @@ var syncSetSourceCmd = &cobra.Command{
 			return err
 		}
 		fmt.Println(ui.Success(fmt.Sprintf("Sync source set to: %s", url)))
-		fmt.Println(ui.Hint("Run `w3cli sync run` to fetch contracts from this source."))
+		fmt.Println(ui.Hint("Run `w3cli sync run --dry-run` to preview the contracts from this source."))
+		return nil
+	},
+}
+
+var syncSetSignerCmd = &cobra.Command{
+	Use:   "set-signer <address|none>",
+	Short: "Pin the address manifests must be signed by",
+	Long: `Pin the team signer. Once set, sync refuses a manifest without a valid
+EIP-191 signature by this address in <manifest>.sig. Pass "none" to accept
+unsigned manifests again.
+
+Examples:
+  w3cli sync set-signer 0xTeamSigner...
+  w3cli sync set-signer none`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address := args[0]
+		if address == "none" {
+			address = ""
+		}
+		syncer := csync.New(cfg, contract.NewRegistry(filepath.Join(cfg.Dir(), "contracts.json")))
+		if err := syncer.SetSigner(address); err != nil {
+			return err
+		}
+		if address == "" {
+			fmt.Println(ui.Warn("Signer cleared — unsigned manifests will be accepted."))
+			return nil
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Manifests must now be signed by %s", address)))
 		return nil
 	},
 }
@@ -39,6 +102,17 @@ var syncSetSourceCmd = &cobra.Command{
 var syncRunCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Fetch latest contracts from the manifest",
+	Long: `Fetch the manifest, verify it and apply it to the contract registry.
+
+Contracts added by sync are updated in place; contracts you added yourself
+are never overwritten (use --force). Synced contracts that leave the manifest
+are only removed with --prune; preview the removals with --dry-run first.
+
+Examples:
+  w3cli sync run --dry-run      # preview added / changed / removed contracts
+  w3cli sync run
+  w3cli sync run --prune        # also remove contracts that left the manifest
+  w3cli sync run --watch`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reg := contract.NewRegistry(filepath.Join(cfg.Dir(), "contracts.json"))
 		if err := reg.Load(); err != nil {
@@ -46,6 +120,7 @@ var syncRunCmd = &cobra.Command{
 		}
 
 		syncer := csync.New(cfg, reg)
+		syncer.SetOptions(csync.Options{Prune: syncPrune, Force: syncForce})
 
 		if syncWatch {
 			fmt.Println(ui.Info("Watching for changes every 30s. Press Ctrl+C to stop."))
@@ -54,20 +129,152 @@ var syncRunCmd = &cobra.Command{
 			return syncer.Watch(ctx, 30*time.Second)
 		}
 
-		spin := ui.NewSpinner("Syncing contracts from manifest...")
+		spin := ui.NewSpinner("Fetching manifest...")
 		spin.Start()
-		err := syncer.Run(context.Background())
+		plan, err := syncer.Plan(context.Background())
 		spin.Stop()
 		if err != nil {
 			return err
 		}
+		printSyncPlan(plan)
+
+		if syncDryRun {
+			fmt.Println(ui.Hint("Dry run — nothing was changed. Run without --dry-run to apply."))
+			return nil
+		}
+		if err := syncer.Apply(plan); err != nil {
+			return err
+		}
 		fmt.Println(ui.Success("Contracts synced successfully!"))
 		fmt.Println(ui.Hint("Run `w3cli contract list` to see synced contracts."))
 		return nil
 	},
 }
 
+var syncSignCmd = &cobra.Command{
+	Use:   "sign <manifest>",
+	Short: "Sign a manifest file, writing <manifest>.sig",
+	Long: `Sign the exact bytes of a manifest with EIP-191 and write the signature
+next to it as <manifest>.sig. Publish both files together.
+
+Examples:
+  w3cli sync sign deployments.json --wallet deployer`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		walletName := syncWallet
+		if walletName == "" {
+			walletName = cfg.DefaultWallet
+		}
+		w, _, err := loadSigningWallet(walletName)
+		if err != nil {
+			return err
+		}
+		warnIfNoSession()
+
+		sig, err := wallet.SignMessage(w, wallet.DefaultKeystore(), data)
+		if err != nil {
+			return fmt.Errorf("signing failed: %w", err)
+		}
+		sigHex := "0x" + hex.EncodeToString(sig)
+		if err := os.WriteFile(path+".sig", []byte(sigHex+"\n"), 0o644); err != nil {
+			return err
+		}
+		fmt.Println(ui.KeyValueBlock("Manifest Signed", [][2]string{
+			{"Manifest", path},
+			{"Signer", ui.Addr(w.Address)},
+			{"Signature", path + ".sig"},
+		}))
+		fmt.Println(ui.Hint("Consumers pin this signer with: w3cli sync set-signer " + w.Address))
+		return nil
+	},
+}
+
+var syncHashCmd = &cobra.Command{
+	Use:   "hash <abi-file...>",
+	Short: "Print the abi_hash of ABI files",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s  %s\n", csync.HashABI(data), path)
+		}
+		return nil
+	},
+}
+
+// printSyncPlan prints the manifest's provenance and the changes it brings.
+func printSyncPlan(p *csync.Plan) {
+	signer := ui.Warn("unsigned")
+	if p.Signer != "" {
+		signer = ui.Success("signed by " + p.Signer)
+	}
+	version := fmt.Sprintf("%d", p.Version)
+	if p.PrevVersion != 0 && p.PrevVersion != p.Version {
+		version = fmt.Sprintf("%d → %d", p.PrevVersion, p.Version)
+	}
+	fmt.Println(ui.KeyValueBlock("Deployments Manifest", [][2]string{
+		{"Source", p.Source},
+		{"Version", version},
+		{"Signature", signer},
+	}))
+
+	for _, w := range p.Warnings {
+		fmt.Println(ui.Warn(w))
+	}
+	if len(p.Changes) == 0 {
+		fmt.Println(ui.Info("Registry is up to date."))
+		return
+	}
+
+	t := ui.NewTable([]ui.Column{
+		{Title: "Change", Width: 10},
+		{Title: "Contract", Width: 18},
+		{Title: "Network", Width: 12},
+		{Title: "Address", Width: 44},
+		{Title: "ABI", Width: 8},
+	})
+	for _, c := range p.Changes {
+		change, address, abi := syncChangeRow(c)
+		t.AddRow(ui.Row{change, ui.Val(c.Name), ui.ChainName(c.Network), address, abi})
+	}
+	fmt.Println(t.Render())
+}
+
+// syncChangeRow renders the change, address and ABI columns of a change.
+func syncChangeRow(c csync.Change) (change, address, abi string) {
+	switch c.Kind {
+	case csync.ChangeAdd:
+		change, address = "+ add", c.Address
+	case csync.ChangeRemove:
+		change, address = "- remove", c.OldAddress
+	case csync.ChangeConflict:
+		change, address = "! local", c.OldAddress+" (manifest: "+c.Address+")"
+	default:
+		change, address = "~ update", c.Address
+		if c.OldAddress != "" && c.OldAddress != c.Address {
+			address = c.OldAddress + " → " + c.Address
+		}
+	}
+	if c.ABIChanged {
+		abi = "changed"
+	}
+	return change, address, abi
+}
+
 func init() {
 	syncRunCmd.Flags().BoolVar(&syncWatch, "watch", false, "poll every 30s for changes")
-	syncCmd.AddCommand(syncSetSourceCmd, syncRunCmd)
+	syncRunCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "show what would change without applying it")
+	syncRunCmd.Flags().BoolVar(&syncPrune, "prune", false, "remove synced contracts that are no longer in the manifest")
+	syncRunCmd.Flags().BoolVar(&syncForce, "force", false, "accept an older manifest version and overwrite hand-added contracts")
+	syncSignCmd.Flags().StringVar(&syncWallet, "wallet", "", "signing wallet (default: config)")
+	syncCmd.AddCommand(syncSetSourceCmd, syncSetSignerCmd, syncRunCmd, syncSignCmd, syncHashCmd)
 }
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	csync "github.com/Mohsinsiddi/w3cli/internal/sync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncChangeRow(t *testing.T) {
+	change, address, abi := syncChangeRow(csync.Change{Kind: csync.ChangeUpdate, OldAddress: "0xA", Address: "0xB", ABIChanged: true})
+	assert.Equal(t, "~ update", change)
+	assert.Equal(t, "0xA → 0xB", address)
+	assert.Equal(t, "changed", abi)
+
+	_, address, abi = syncChangeRow(csync.Change{Kind: csync.ChangeUpdate, OldAddress: "0xA", Address: "0xA"})
+	assert.Equal(t, "0xA", address)
+	assert.Empty(t, abi)
+
+	change, address, _ = syncChangeRow(csync.Change{Kind: csync.ChangeRemove, OldAddress: "0xA"})
+	assert.Equal(t, "- remove", change)
+	assert.Equal(t, "0xA", address)
+
+	change, _, _ = syncChangeRow(csync.Change{Kind: csync.ChangeConflict, OldAddress: "0xA", Address: "0xB"})
+	assert.Equal(t, "! local", change)
+}
@@ -27,6 +27,7 @@ var (
 	tokenSupply   string
 	tokenNetwork  string
 	tokenWallet   string
+	tokenVerify   bool
 
 	// mint / burn
 	tokenContract string
@@ -57,8 +58,15 @@ var tokenCreateCmd = &cobra.Command{
 The deployer wallet becomes the owner and receives the entire initial supply.
 Owner can mint more later with: w3cli token mint
 
+With --create2 --salt the token is deployed through CreateX, so the same name,
+symbol, decimals and salt give the same address on every chain. CreateX hands
+ownership to the deployer in the same transaction; the initial supply is then
+minted in a second one (it is not part of the address).
+
 Examples:
   w3cli token create --name "MyToken" --symbol MTK --decimals 18 --supply 1000000 --network base
+  w3cli token create --name "MyToken" --symbol MTK --supply 1000000 --network base --verify
+  w3cli token create --name "MyToken" --symbol MTK --supply 1000000 --create2 --salt my-token
   w3cli token create   (interactive wizard)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// ── Interactive prompts for missing flags ──────────────────────────────
@@ -131,11 +139,17 @@ Examples:
 		client := chain.NewEVMClient(rpcURL)
 
 		// ── Build deploy data ─────────────────────────────────────────────────
-		deployData, err := chain.BuildERC20DeployData(tokenName, tokenSymbol, tokenDecimals, supplyWei)
+		// CREATE2 tokens start at zero supply (mint follows) so the address
+		// does not depend on it.
+		ctorSupply := supplyWei
+		if tokenCreate2 {
+			ctorSupply = big.NewInt(0)
+		}
+		deployData, err := chain.BuildERC20DeployData(tokenName, tokenSymbol, tokenDecimals, ctorSupply)
 		if err != nil {
 			return fmt.Errorf("building deploy data: %w", err)
 		}
-		deployHex := "0x" + hex.EncodeToString(deployData)
+		ctorArgs := hex.EncodeToString(deployData[len(chain.W3TokenInitCode()):])
 
 		// ── Fetch on-chain data for preview ──────────────────────────────────
 		spin := ui.NewSpinner(fmt.Sprintf("Preparing deployment on %s...", c.DisplayName))
@@ -146,55 +160,81 @@ Examples:
 			spin.Stop()
 			return err
 		}
-		gasLimit, err := client.EstimateGas(w.Address, "", deployHex, nil)
-		if err != nil {
-			gasLimit = config.GasLimitTokenDeploy
-		}
-
 		chainID, err := client.ChainID()
 		if err != nil {
 			spin.Stop()
 			return err
 		}
-		nonce, err := client.GetPendingNonce(w.Address)
+
+		var plan *create2Plan
+		var txTo *common.Address
+		txData := deployData
+		if tokenCreate2 {
+			plan, err = planCreate2(client, c, chainID, w.Address, deployData, chain.W3TokenTransferOwnershipCalldata(w.Address))
+			if err != nil {
+				spin.Stop()
+				return err
+			}
+			if plan.Exists {
+				spin.Stop()
+				return fmt.Errorf("a token is already deployed at %s on %s — use another --salt", plan.Address.Hex(), c.DisplayName)
+			}
+			txTo, txData = &plan.Factory.Address, plan.Data
+		}
+
+		to := ""
+		if txTo != nil {
+			to = txTo.Hex()
+		}
+		gasLimit, err := client.EstimateGas(w.Address, to, "0x"+hex.EncodeToString(txData), nil)
 		if err != nil {
-			spin.Stop()
-			return err
+			gasLimit = config.GasLimitTokenDeploy
 		}
 		spin.Stop()
 
 		// ── Preview ───────────────────────────────────────────────────────────
+		pairs := [][2]string{
+			{"Deployer", ui.Addr(w.Address)},
+			{"Name", tokenName},
+			{"Symbol", tokenSymbol},
+			{"Decimals", fmt.Sprintf("%d", tokenDecimals)},
+			{"Supply", fmt.Sprintf("%s %s", tokenSupply, tokenSymbol)},
+		}
+		if plan != nil {
+			pairs = append(pairs, plan.pairs()...)
+		}
+		pairs = append(pairs,
+			[2]string{"Gas Limit", fmt.Sprintf("%d", gasLimit)},
+			[2]string{"Gas Price", fmt.Sprintf("%d Gwei", toGwei(gasPrice))},
+			[2]string{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
+		)
 		fmt.Println(ui.KeyValueBlock(
-			fmt.Sprintf("Token Deploy Preview · %s (%s)", c.DisplayName, cfg.NetworkMode),
-			[][2]string{
-				{"Deployer", ui.Addr(w.Address)},
-				{"Name", tokenName},
-				{"Symbol", tokenSymbol},
-				{"Decimals", fmt.Sprintf("%d", tokenDecimals)},
-				{"Supply", fmt.Sprintf("%s %s", tokenSupply, tokenSymbol)},
-				{"Gas Limit", fmt.Sprintf("%d", gasLimit)},
-				{"Gas Price", fmt.Sprintf("%d Gwei", toGwei(gasPrice))},
-				{"Network", fmt.Sprintf("%s (%s)", c.DisplayName, cfg.NetworkMode)},
-			}))
+			fmt.Sprintf("Token Deploy Preview · %s (%s)", c.DisplayName, cfg.NetworkMode), pairs))
 
 		if !ui.Confirm("Deploy this token?") {
 			fmt.Println(ui.Meta("Cancelled."))
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		// ── Sign + broadcast ──────────────────────────────────────────────────
 		spin = ui.NewSpinner("Deploying token...")
 		spin.Start()
 
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
-			To:        nil, // contract creation
+			To:        txTo, // nil = contract creation
 			Value:     big.NewInt(0),
-			Data:      deployData,
+			Data:      txData,
 		})
 
 		ks := wallet.DefaultKeystore()
@@ -210,6 +250,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		// ── Wait for receipt ──────────────────────────────────────────────────
 		spin = ui.NewSpinner("Waiting for deployment confirmation...")
@@ -219,11 +260,29 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("deploy tx %s: %w", hash, err)
 		}
+		contractAddr := receipt.ContractAddress
+		if plan != nil {
+			contractAddr = plan.Address.Hex()
+			if code, err := client.GetCode(contractAddr); err != nil || !hasCode(code) {
+				return fmt.Errorf("factory call %s succeeded but no code at %s", hash, contractAddr)
+			}
+			if supplyWei.Sign() > 0 {
+				spin = ui.NewSpinner("Minting initial supply...")
+				spin.Start()
+				_, err := sendCall(client, w, chainID, contractAddr, chain.W3TokenMintCalldata(w.Address, supplyWei), gasPrice)
+				spin.Stop()
+				if err != nil {
+					fmt.Println(ui.Warn("Minting the initial supply failed: " + err.Error()))
+					fmt.Println(ui.Hint(fmt.Sprintf("Retry: w3cli token mint --contract %s --to %s --amount %s --network %s",
+						contractAddr, w.Address, tokenSupply, chainName)))
+				}
+			}
+		}
 
 		explorer := c.Explorer(cfg.NetworkMode)
 		fmt.Println()
 		fmt.Println(ui.KeyValueBlock("Token Deployed ✓", [][2]string{
-			{"Contract", ui.Addr(receipt.ContractAddress)},
+			{"Contract", ui.Addr(contractAddr)},
 			{"Tx Hash", ui.Addr(hash)},
 			{"Block", fmt.Sprintf("%d", receipt.BlockNumber)},
 			{"Gas Used", fmt.Sprintf("%d", receipt.GasUsed)},
@@ -232,26 +291,33 @@ Examples:
 			{"Decimals", fmt.Sprintf("%d", tokenDecimals)},
 			{"Supply", tokenSupply + " " + tokenSymbol},
 			{"Owner/Minter", ui.Addr(w.Address)},
-			{"Explorer", explorer + "/address/" + receipt.ContractAddress},
+			{"Explorer", explorer + "/address/" + contractAddr},
 		}))
 		fmt.Println(ui.Hint(fmt.Sprintf(
 			"Mint more: w3cli token mint --contract %s --to <addr> --amount <n> --network %s",
-			receipt.ContractAddress, chainName)))
+			contractAddr, chainName)))
 
 		// ── Auto-register in contract studio ──────────────────────────────────
 		contractReg := newContractRegistry()
 		if loadErr := contractReg.Load(); loadErr == nil {
-			contractReg.Add(&contract.Entry{
+			entry := &contract.Entry{
 				Name:       tokenSymbol,
 				Network:    chainName,
-				Address:    receipt.ContractAddress,
+				Address:    contractAddr,
 				ABI:        contract.GetBuiltinABI("w3token"),
 				Kind:       "builtin",
 				BuiltinID:  "w3token",
 				Deployer:   w.Address,
 				TxHash:     hash,
 				DeployedAt: time.Now().UTC().Format(time.RFC3339),
-			})
+
+				ConstructorArgs: ctorArgs,
+			}
+			if plan != nil {
+				entry.Salt = "0x" + hex.EncodeToString(plan.Salt[:])
+				entry.Factory = plan.Factory.Address.Hex()
+			}
+			contractReg.Add(entry)
 			if saveErr := contractReg.Save(); saveErr == nil {
 				fmt.Println(ui.Success(fmt.Sprintf(
 					"Registered in contract studio as %q — use: w3cli contract studio %s",
@@ -259,7 +325,19 @@ Examples:
 			}
 		}
 
-		ui.OpenURL(explorer + "/address/" + receipt.ContractAddress)
+		// ── Verify the bundled source ─────────────────────────────────────────
+		if tokenVerify {
+			src, err := contract.W3TokenVerifySource()
+			if err == nil {
+				err = verifyOnExplorer(c, chainName, contractAddr, src, ctorArgs)
+			}
+			if err != nil {
+				fmt.Println(ui.Warn("Verification failed: " + err.Error()))
+				fmt.Println(ui.Hint(fmt.Sprintf("Retry: w3cli contract verify %s --network %s", tokenSymbol, chainName)))
+			}
+		}
+
+		ui.OpenURL(explorer + "/address/" + contractAddr)
 		return nil
 	},
 }
@@ -272,7 +350,8 @@ var tokenMintCmd = &cobra.Command{
 	Long: `Mint new tokens to an address. Caller must be the contract owner.
 
 Examples:
-  w3cli token mint --contract 0x... --to 0xRecipient --amount 5000 --network base`,
+  w3cli token mint --contract 0x... --to 0xRecipient --amount 5000 --network base
+  w3cli token mint --contract MTK --to vitalik.eth --amount 100`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if tokenContract == "" {
 			tokenContract = ui.PromptInput("Token contract address")
@@ -294,14 +373,18 @@ Examples:
 			walletName = cfg.DefaultWallet
 		}
 
-		w, mgr, err := loadSigningWallet(walletName)
+		w, _, err := loadSigningWallet(walletName)
 		if err != nil {
 			return err
 		}
 
 		warnIfNoSession()
 
-		toAddress, err := resolveToAddress(tokenTo, mgr)
+		tokenContract, err = resolveAddress(tokenContract, chainName)
+		if err != nil {
+			return err
+		}
+		toAddress, err := resolveAddress(tokenTo, chainName)
 		if err != nil {
 			return err
 		}
@@ -354,11 +437,6 @@ Examples:
 			spin.Stop()
 			return err
 		}
-		nonce, err := client.GetPendingNonce(w.Address)
-		if err != nil {
-			spin.Stop()
-			return err
-		}
 		spin.Stop()
 
 		fmt.Println(ui.KeyValueBlock(
@@ -376,13 +454,19 @@ Examples:
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		contractAddr, err := parseAddress(tokenContract)
 		if err != nil {
 			return err
 		}
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
@@ -406,6 +490,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		spin = ui.NewSpinner("Waiting for confirmation...")
 		spin.Start()
@@ -510,11 +595,6 @@ Examples:
 			spin.Stop()
 			return err
 		}
-		nonce, err := client.GetPendingNonce(w.Address)
-		if err != nil {
-			spin.Stop()
-			return err
-		}
 		spin.Stop()
 
 		fmt.Println(ui.KeyValueBlock(
@@ -532,13 +612,19 @@ Examples:
 			return nil
 		}
 
+		lease, err := reserveNonce(client, chainID, w.Address)
+		if err != nil {
+			return err
+		}
+		defer lease.Release()
+
 		contractAddr, err := parseAddress(tokenContract)
 		if err != nil {
 			return err
 		}
 		tx := types.NewTx(&types.DynamicFeeTx{
 			ChainID:   big.NewInt(chainID),
-			Nonce:     nonce,
+			Nonce:     lease.Nonce,
 			GasTipCap: gasPrice,
 			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
 			Gas:       gasLimit,
@@ -562,6 +648,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		lease.Used()
 
 		spin = ui.NewSpinner("Waiting for confirmation...")
 		spin.Start()
@@ -604,6 +691,10 @@ func init() {
 	tokenCreateCmd.Flags().StringVar(&tokenSupply, "supply", "", "initial supply in token units")
 	tokenCreateCmd.Flags().StringVar(&tokenNetwork, "network", "", "chain (default: config)")
 	tokenCreateCmd.Flags().StringVar(&tokenWallet, "wallet", "", "signing wallet (default: config)")
+	tokenCreateCmd.Flags().BoolVar(&tokenVerify, "verify", false, "verify the token source on the block explorer after deploy")
+	tokenCreateCmd.Flags().BoolVar(&verifyEtherscan, "etherscan", false, "with --verify: use Etherscan V2 instead of BlockScout")
+	tokenCreateCmd.Flags().BoolVar(&tokenCreate2, "create2", false, "deploy through CreateX (same address on every chain)")
+	tokenCreateCmd.Flags().StringVar(&create2Salt, "salt", "", "with --create2: salt (0x-hex, decimal or text)")
 
 	// mint
 	tokenMintCmd.Flags().StringVar(&tokenContract, "contract", "", "token contract address")
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/resolve"
+	"github.com/Mohsinsiddi/w3cli/internal/tokenlist"
+	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var tokensListName string
+
+var tokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Manage token lists",
+	Long: `Subscribe to Uniswap-format token lists (https://tokenlists.org) so w3cli
+knows the tokens on each chain.
+
+Listed tokens are scanned by balance --tokens and allbal --tokens, priced
+in portfolio, and usable by symbol wherever --token is accepted:
+
+  w3cli balance --token USDC --network base`,
+}
+
+var tokensListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show subscribed token lists",
+	Long: `Show subscribed token lists. Lists are cached on disk; refresh them with
+w3cli tokens list update.
+
+Examples:
+  w3cli tokens list
+  w3cli tokens list add https://tokens.uniswap.org
+  w3cli tokens list add ./my-tokens.json --name mine
+  w3cli tokens list update
+  w3cli tokens list remove mine`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadTokenListStore()
+		if err != nil {
+			return err
+		}
+		subs := store.Subscriptions()
+		if len(subs) == 0 {
+			fmt.Println(ui.Info("No token lists."))
+			fmt.Println(ui.Hint("Add one: w3cli tokens list add https://tokens.uniswap.org"))
+			return nil
+		}
+
+		t := ui.NewTable([]ui.Column{
+			{Title: "Name", Width: 18},
+			{Title: "Title", Width: 24},
+			{Title: "Version", Width: 9},
+			{Title: "Tokens", Width: 7},
+			{Title: "Updated", Width: 21},
+			{Title: "Source", Width: 40},
+		})
+		for _, s := range subs {
+			t.AddRow(ui.Row{
+				ui.Val(s.Name),
+				s.Title,
+				s.Version,
+				fmt.Sprintf("%d", s.Tokens),
+				s.UpdatedAt,
+				s.Source,
+			})
+		}
+		fmt.Println(t.Render())
+		return nil
+	},
+}
+
+var tokensListAddCmd = &cobra.Command{
+	Use:   "add <url-or-file>",
+	Short: "Subscribe to a token list",
+	Long: `Fetch a token list from a URL or local file, cache it and subscribe to it.
+The name defaults to the list's own name, lowercased and dashed.
+
+Examples:
+  w3cli tokens list add https://tokens.uniswap.org
+  w3cli tokens list add https://tokens.coingecko.com/uniswap/all.json --name coingecko
+  w3cli tokens list add ./my-tokens.json --name mine`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadTokenListStore()
+		if err != nil {
+			return err
+		}
+		spin := ui.NewSpinner("Fetching token list...")
+		spin.Start()
+		sub, err := store.Add(tokensListName, args[0])
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Added %q — %s v%s, %d tokens", sub.Name, sub.Title, sub.Version, sub.Tokens)))
+		return nil
+	},
+}
+
+var tokensListRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unsubscribe from a token list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadTokenListStore()
+		if err != nil {
+			return err
+		}
+		if err := store.Remove(args[0]); err != nil {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Removed token list %q", args[0])))
+		return nil
+	},
+}
+
+var tokensListUpdateCmd = &cobra.Command{
+	Use:   "update [name]",
+	Short: "Re-fetch one or all token lists",
+	Long: `Re-fetch token lists from their source. A list that fails to fetch keeps
+its cached copy.
+
+Examples:
+  w3cli tokens list update
+  w3cli tokens list update uniswap-labs-default`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := loadTokenListStore()
+		if err != nil {
+			return err
+		}
+		var names []string
+		if len(args) == 1 {
+			names = args
+		} else {
+			for _, s := range store.Subscriptions() {
+				names = append(names, s.Name)
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println(ui.Info("No token lists to update."))
+			return nil
+		}
+
+		failed := 0
+		for _, name := range names {
+			sub, err := store.Update(name)
+			if err != nil {
+				failed++
+				fmt.Println(ui.Err(fmt.Sprintf("%s: %v", name, err)))
+				continue
+			}
+			fmt.Println(ui.Success(fmt.Sprintf("%s — v%s, %d tokens", sub.Name, sub.Version, sub.Tokens)))
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d list(s) failed to update", failed, len(names))
+		}
+		return nil
+	},
+}
+
+func newTokenListStore() *tokenlist.Store {
+	return tokenlist.NewStore(cfg.Dir())
+}
+
+func loadTokenListStore() (*tokenlist.Store, error) {
+	store := newTokenListStore()
+	if err := store.Load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// listedTokens returns the tokens the subscribed lists know on chainID.
+func listedTokens(lists *tokenlist.Store, chainID int64) []trackedToken {
+	if lists == nil || chainID == 0 {
+		return nil
+	}
+	var out []trackedToken
+	for _, t := range lists.Tokens(chainID) {
+		out = append(out, trackedToken{Address: t.Address, Symbol: t.Symbol, Decimals: t.Decimals})
+	}
+	return out
+}
+
+// tokenChainID returns the chain ID token lists are keyed by: the registry's
+// mainnet ID, or on testnet the ID the RPC reports.
+func tokenChainID(c *chain.Chain, networkMode string, client *chain.EVMClient) int64 {
+	if networkMode == "mainnet" {
+		return c.ChainID
+	}
+	id, err := client.ChainID()
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// tokensBySymbol returns the tokens called symbol on a chain: the
+// well-known tokens (mainnet only) and the listed ones, one per address.
+func tokensBySymbol(symbol, chainName string, mainnet bool, lists *tokenlist.Store, chainID int64) []trackedToken {
+	var candidates []trackedToken
+	if mainnet {
+		candidates = append(candidates, wellKnownTokens[chainName]...)
+	}
+	candidates = append(candidates, listedTokens(lists, chainID)...)
+
+	var out []trackedToken
+	seen := map[string]bool{}
+	for _, t := range candidates {
+		key := strings.ToLower(t.Address)
+		if !strings.EqualFold(t.Symbol, symbol) || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// resolveTokenArg resolves a --token argument on an EVM chain: a raw address,
+// a contract registered on the network, a token symbol from the well-known
+// or subscribed lists, or any other address name.
+func resolveTokenArg(input string, c *chain.Chain, networkMode string, client *chain.EVMClient) (string, error) {
+	if _, err := resolve.ParseAddress(input); err == nil {
+		return input, nil
+	}
+	contracts := newContractRegistry()
+	_ = contracts.Load()
+	if e, err := contracts.Get(input, c.Name); err == nil {
+		return e.Address, nil
+	}
+
+	lists := newTokenListStore()
+	matches := tokensBySymbol(input, c.Name, networkMode == "mainnet", lists, tokenChainID(c, networkMode, client))
+	switch len(matches) {
+	case 1:
+		fmt.Println(ui.Meta(fmt.Sprintf("↳ %s (token) → %s", input, matches[0].Address)))
+		return matches[0].Address, nil
+	case 0:
+		return resolveAddress(input, c.Name)
+	default:
+		addrs := make([]string, len(matches))
+		for i, m := range matches {
+			addrs[i] = m.Address
+		}
+		return "", fmt.Errorf("symbol %q is ambiguous on %s — pass the address: %s", input, c.Name, strings.Join(addrs, ", "))
+	}
+}
+
+func init() {
+	tokensListAddCmd.Flags().StringVar(&tokensListName, "name", "", "name to subscribe under (default: from the list)")
+
+	tokensListCmd.AddCommand(tokensListAddCmd, tokensListRemoveCmd, tokensListUpdateCmd)
+	tokensCmd.AddCommand(tokensListCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/tokenlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTokenLists(t *testing.T) *tokenlist.Store {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "list.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"name": "Test", "tokens": [
+		{"chainId": 8453, "address": "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913", "name": "USD Coin", "symbol": "USDC", "decimals": 6},
+		{"chainId": 8453, "address": "0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb", "name": "Dai", "symbol": "DAI", "decimals": 18},
+		{"chainId": 84532, "address": "0x036CbD53842c5426634e7929541eC2318f3dCF7e", "name": "USD Coin", "symbol": "USDC", "decimals": 6}
+	]}`), 0o600))
+	s := tokenlist.NewStore(dir)
+	_, err := s.Add("test", file)
+	require.NoError(t, err)
+	return s
+}
+
+func TestTokensBySymbol(t *testing.T) {
+	lists := testTokenLists(t)
+
+	// The listed USDC is the well-known one, so it resolves once.
+	got := tokensBySymbol("usdc", "base", true, lists, 8453)
+	require.Len(t, got, 1)
+	assert.Equal(t, "USDC", got[0].Symbol)
+
+	got = tokensBySymbol("DAI", "base", true, lists, 8453)
+	require.Len(t, got, 1)
+	assert.Equal(t, 18, got[0].Decimals)
+
+	// Testnet: only the list, keyed by the testnet chain ID.
+	got = tokensBySymbol("USDC", "base", false, lists, 84532)
+	require.Len(t, got, 1)
+	assert.Equal(t, "0x036CbD53842c5426634e7929541eC2318f3dCF7e", got[0].Address)
+
+	assert.Empty(t, tokensBySymbol("WETH", "base", false, lists, 84532))
+}
+
+func TestTrackedTokensIncludesListedTokens(t *testing.T) {
+	lists := testTokenLists(t)
+	tokens := trackedTokens("base", nil, lists)
+	assert.Len(t, tokens, len(wellKnownTokens["base"])+1, "listed USDC duplicates the well-known one")
+	assert.Equal(t, "DAI", tokens[len(tokens)-1].Symbol)
+}
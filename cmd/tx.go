@@ -1,15 +1,43 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/txfile"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
 var (
 	txNetwork string
+
+	txBuildFrom  string
+	txBuildTo    string
+	txBuildValue string
+	txBuildData  string
+	txBuildSig   string
+	txBuildArgs  []string
+	txBuildNonce int64
+	txBuildGas   uint64
+	txBuildNote  string
+	txBuildOut   string
+
+	txSignWallet string
+	txSignOut    string
+	txSignYes    bool
+
+	txBroadcastNoWait bool
 )
 
 var txCmd = &cobra.Command{
@@ -20,9 +48,16 @@ var txCmd = &cobra.Command{
 Uses the configured network mode (mainnet/testnet) by default.
 Override per-call with --testnet or --mainnet.
 
+For air-gapped signing, build/sign/broadcast are separate steps:
+  build      resolve nonce, fees, gas and calldata online → unsigned file
+  sign       sign the file on an offline machine
+  broadcast  send the signed file and wait for the receipt
+  inspect    decode any raw signed transaction
+
 Examples:
   w3cli tx 0xHASH --network base
-  w3cli tx 0xHASH --network ethereum --testnet`,
+  w3cli tx 0xHASH --network ethereum --testnet
+  w3cli tx build --from treasury --to 0xRecipient --value 1 -o pay.json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		hash := args[0]
@@ -55,13 +90,14 @@ Examples:
 		}
 
 		explorer := c.Explorer(networkMode)
+		labels := newAddressLabels(chainName)
 
 		fmt.Println(ui.KeyValueBlock(
 			fmt.Sprintf("Transaction Details · %s (%s)", c.DisplayName, networkMode),
 			[][2]string{
 				{"Hash", ui.Addr(tx.Hash)},
-				{"From", ui.Addr(tx.From)},
-				{"To", ui.Addr(tx.To)},
+				{"From", labels.Addr(tx.From)},
+				{"To", labels.Addr(tx.To)},
 				{"Value", tx.ValueETH + " " + c.NativeCurrency},
 				{"Gas Limit", fmt.Sprintf("%d", tx.Gas)},
 				{"Block", fmt.Sprintf("%d", tx.BlockNum)},
@@ -73,6 +109,471 @@ Examples:
 	},
 }
 
+var txBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build an unsigned transaction file for offline signing",
+	Long: `Resolve nonce, fees, gas, chain ID and calldata online and write an
+unsigned transaction file. Carry it to the offline machine and run
+` + "`w3cli tx sign`" + ` there; the sending wallet can be watch-only here.
+
+Calldata comes from --data (raw hex) or --sig plus one --arg per parameter.
+The nonce is reserved from the local nonce store; pass --nonce to override.
+
+Examples:
+  w3cli tx build --from treasury --to 0xRecipient --value 1.5 --network base
+  w3cli tx build --from treasury --to 0xToken --sig "transfer(address,uint256)" \
+      --arg 0xRecipient --arg 1000000 --out pay.json
+  w3cli tx build --from 0xCold... --to 0xSafe --data 0x6a761202... --gas 250000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		chainName := txNetwork
+		if chainName == "" {
+			chainName = cfg.DefaultNetwork
+		}
+		if txBuildTo == "" && txBuildData == "" {
+			return fmt.Errorf("--to is required (or --data for a contract creation)")
+		}
+		if txBuildData != "" && txBuildSig != "" {
+			return fmt.Errorf("use either --data or --sig, not both")
+		}
+
+		from, _, err := resolveWalletAndChain(txBuildFrom, chainName)
+		if err != nil {
+			return err
+		}
+		to := ""
+		if txBuildTo != "" {
+			if to, err = resolveAddress(txBuildTo, chainName); err != nil {
+				return err
+			}
+			if _, err := parseAddress(to); err != nil {
+				return fmt.Errorf("invalid --to: %w", err)
+			}
+		}
+
+		value := big.NewInt(0)
+		if txBuildValue != "" {
+			if value, err = ethToWei(txBuildValue); err != nil {
+				return fmt.Errorf("invalid --value: %w", err)
+			}
+		}
+
+		data, err := buildTxData(txBuildData, txBuildSig, txBuildArgs)
+		if err != nil {
+			return err
+		}
+		dataHex := ""
+		if len(data) > 0 {
+			dataHex = "0x" + hex.EncodeToString(data)
+		}
+
+		reg := chain.NewRegistry()
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
+		}
+		if c.Type != chain.ChainTypeEVM {
+			return fmt.Errorf("offline signing supports EVM chains only (%s is %s)", c.DisplayName, c.Type)
+		}
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return err
+		}
+		client := chain.NewEVMClient(rpcURL)
+
+		spin := ui.NewSpinner(fmt.Sprintf("Resolving transaction on %s...", c.DisplayName))
+		spin.Start()
+		chainID, err := client.ChainID()
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		gasPrice, err := client.GasPrice()
+		if err != nil {
+			spin.Stop()
+			return err
+		}
+		gas := txBuildGas
+		if gas == 0 {
+			if gas, err = client.EstimateGas(from, to, dataHex, value); err != nil {
+				gas = config.GasLimitETHTransfer
+				if len(data) > 0 {
+					gas = config.GasLimitContractCall
+				}
+				if to == "" {
+					gas = config.GasLimitContractDeploy
+				}
+			}
+		}
+		var nonce uint64
+		if txBuildNonce >= 0 {
+			nonce = uint64(txBuildNonce)
+		} else {
+			lease, err := reserveNonce(client, chainID, from)
+			if err != nil {
+				spin.Stop()
+				return err
+			}
+			// The tx leaves this process unsigned, so the nonce stays
+			// reserved until the chain catches up (see `w3cli nonce gaps`).
+			if err := lease.Hold(); err != nil {
+				lease.Release()
+				spin.Stop()
+				return err
+			}
+			nonce = lease.Nonce
+		}
+		spin.Stop()
+
+		u := &txfile.Unsigned{
+			Kind:                 txfile.KindUnsigned,
+			Version:              txfile.Version,
+			Network:              chainName,
+			ChainID:              chainID,
+			From:                 from,
+			To:                   to,
+			Value:                value.String(),
+			Data:                 dataHex,
+			Nonce:                nonce,
+			Gas:                  gas,
+			MaxFeePerGas:         new(big.Int).Mul(gasPrice, big.NewInt(2)).String(),
+			MaxPriorityFeePerGas: gasPrice.String(),
+			Note:                 txBuildNote,
+			CreatedAt:            time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := u.Validate(); err != nil {
+			return err
+		}
+
+		out := txBuildOut
+		if out == "" {
+			out = fmt.Sprintf("tx-%s-%d.unsigned.json", chainName, nonce)
+		}
+		if err := txfile.Save(out, u); err != nil {
+			return err
+		}
+
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Unsigned Transaction · %s (%s)", c.DisplayName, cfg.NetworkMode), unsignedPairs(u)))
+		fmt.Println(ui.Success("Written to " + out))
+		fmt.Println(ui.Hint("On the offline machine: w3cli tx sign " + out))
+		return nil
+	},
+}
+
+var txSignCmd = &cobra.Command{
+	Use:   "sign <unsigned.json>",
+	Short: "Sign a transaction file without network access",
+	Long: `Sign an unsigned transaction file produced by ` + "`w3cli tx build`" + `.
+
+No RPC calls are made — this is meant for an offline machine holding the key.
+The wallet defaults to the one whose address matches the file's "from".
+
+Examples:
+  w3cli tx sign tx-base-12.unsigned.json
+  w3cli tx sign pay.json --wallet cold --out pay.signed.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		u, err := txfile.LoadUnsigned(args[0])
+		if err != nil {
+			return err
+		}
+
+		mgr := newWalletManager()
+		name := txSignWallet
+		if name == "" {
+			for _, w := range mgr.List() {
+				if strings.EqualFold(w.Address, u.From) && w.Type == wallet.TypeSigning {
+					name = w.Name
+					break
+				}
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("no signing wallet for %s — import the key or pass --wallet", u.From)
+		}
+		w, _, err := loadSigningWallet(name)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(w.Address, u.From) {
+			return fmt.Errorf("wallet %q is %s but the transaction is from %s", name, w.Address, u.From)
+		}
+
+		fmt.Println(ui.KeyValueBlock(fmt.Sprintf("Sign Transaction · %s (chain %d)", u.Network, u.ChainID), unsignedPairs(u)))
+		if !txSignYes && !ui.Confirm("Sign this transaction?") {
+			fmt.Println(ui.Meta("Cancelled."))
+			return nil
+		}
+		warnIfNoSession()
+
+		signed, err := txfile.Sign(u, wallet.NewSigner(w, wallet.DefaultKeystore()), time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return err
+		}
+		out := txSignOut
+		if out == "" {
+			out = txfile.SignedPath(args[0])
+		}
+		if err := txfile.Save(out, signed); err != nil {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Signed %s → %s", ui.TruncateAddr(signed.Hash), out)))
+		fmt.Println(ui.Hint("On the online machine: w3cli tx broadcast " + out))
+		return nil
+	},
+}
+
+var txBroadcastCmd = &cobra.Command{
+	Use:   "broadcast <signed.json>",
+	Short: "Broadcast a signed transaction file and wait for the receipt",
+	Long: `Send a signed transaction produced by ` + "`w3cli tx sign`" + ` and wait for it
+to be mined. The network comes from the file; the node's chain ID must match.
+
+Examples:
+  w3cli tx broadcast tx-base-12.signed.json
+  w3cli tx broadcast pay.signed.json --no-wait`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := txfile.LoadSigned(args[0])
+		if err != nil {
+			return err
+		}
+		d, err := txfile.Decode(s.Raw)
+		if err != nil {
+			return err
+		}
+
+		chainName := txNetwork
+		if chainName == "" {
+			chainName = s.Network
+		}
+		reg := chain.NewRegistry()
+		c, err := reg.GetByName(chainName)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q — pass --network", chainName)
+		}
+		rpcURL, err := pickBestRPC(c, cfg.NetworkMode)
+		if err != nil {
+			return err
+		}
+		client := chain.NewEVMClient(rpcURL)
+
+		chainID, err := client.ChainID()
+		if err != nil {
+			return err
+		}
+		if d.ChainID.Int64() != chainID {
+			return fmt.Errorf("transaction is for chain %d but %s (%s) is chain %d — check --testnet/--mainnet",
+				d.ChainID.Int64(), c.DisplayName, cfg.NetworkMode, chainID)
+		}
+
+		spin := ui.NewSpinner(fmt.Sprintf("Broadcasting on %s...", c.DisplayName))
+		spin.Start()
+		hash, err := client.SendRawTransaction(s.Raw)
+		spin.Stop()
+		if err != nil {
+			return err
+		}
+		explorer := c.Explorer(cfg.NetworkMode)
+		fmt.Println(ui.Success("Broadcast " + hash))
+		if txBroadcastNoWait {
+			fmt.Println(ui.Meta(explorer + "/tx/" + hash))
+			return nil
+		}
+
+		spin = ui.NewSpinner("Waiting for confirmation...")
+		spin.Start()
+		receipt, err := client.WaitForReceipt(hash, config.TxConfirmTimeout)
+		spin.Stop()
+		if err != nil {
+			return fmt.Errorf("tx %s: %w", hash, err)
+		}
+
+		pairs := [][2]string{
+			{"Hash", ui.Addr(hash)},
+			{"Block", fmt.Sprintf("%d", receipt.BlockNumber)},
+			{"Gas Used", fmt.Sprintf("%d", receipt.GasUsed)},
+		}
+		if receipt.ContractAddress != "" {
+			pairs = append(pairs, [2]string{"Contract", ui.Addr(receipt.ContractAddress)})
+		}
+		pairs = append(pairs, [2]string{"Explorer", explorer + "/tx/" + hash})
+		fmt.Println(ui.KeyValueBlock("Transaction Confirmed ✓", pairs))
+		return nil
+	},
+}
+
+var txInspectCmd = &cobra.Command{
+	Use:   "inspect <raw-hex|file>",
+	Short: "Decode a raw signed transaction",
+	Long: `Decode a signed transaction and recover its sender. Accepts raw hex, a
+signed file from ` + "`w3cli tx sign`" + `, or a file containing raw hex. Works offline.
+
+Examples:
+  w3cli tx inspect 0x02f8b1...
+  w3cli tx inspect tx-base-12.signed.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := readRawTx(args[0])
+		if err != nil {
+			return err
+		}
+		d, err := txfile.Decode(raw)
+		if err != nil {
+			return err
+		}
+
+		network := ""
+		if c, err := chain.NewRegistry().GetByChainID(d.ChainID.Int64()); err == nil {
+			network = c.Name
+		}
+		labels := newAddressLabels(network)
+
+		to := d.To
+		if to == "" {
+			to = "(contract creation → " + crypto.CreateAddress(common.HexToAddress(d.From), d.Nonce).Hex() + ")"
+		} else {
+			to = labels.Addr(to)
+		}
+		pairs := [][2]string{
+			{"Type", d.Type},
+			{"Hash", ui.Addr(d.Hash)},
+			{"Chain ID", d.ChainID.String()},
+			{"From", labels.Addr(d.From)},
+			{"To", to},
+			{"Value", formatTokenAmount(d.Value, 18) + " (" + d.Value.String() + " wei)"},
+			{"Nonce", fmt.Sprintf("%d", d.Nonce)},
+			{"Gas Limit", fmt.Sprintf("%d", d.Gas)},
+		}
+		if d.GasPrice != nil {
+			pairs = append(pairs, [2]string{"Gas Price", fmt.Sprintf("%d Gwei", toGwei(d.GasPrice))})
+		} else {
+			pairs = append(pairs,
+				[2]string{"Max Fee", fmt.Sprintf("%d Gwei", toGwei(d.FeeCap))},
+				[2]string{"Priority Fee", fmt.Sprintf("%d Gwei", toGwei(d.TipCap))})
+		}
+		if len(d.Data) > 0 {
+			pairs = append(pairs, [2]string{"Data", fmt.Sprintf("%d bytes", len(d.Data))})
+			if len(d.Data) >= 4 {
+				pairs = append(pairs, [2]string{"Selector", "0x" + hex.EncodeToString(d.Data[:4])})
+			}
+		}
+		fmt.Println(ui.KeyValueBlock("Signed Transaction", pairs))
+		return nil
+	},
+}
+
+// buildTxData returns calldata from raw hex or a signature plus arguments.
+func buildTxData(dataHex, sig string, args []string) ([]byte, error) {
+	if dataHex != "" {
+		b, err := hex.DecodeString(strings.TrimPrefix(dataHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --data: %w", err)
+		}
+		return b, nil
+	}
+	if sig == "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("--arg needs --sig")
+		}
+		return nil, nil
+	}
+
+	open := strings.Index(sig, "(")
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return nil, fmt.Errorf("invalid --sig %q — expected format: name(type1,type2)", sig)
+	}
+	var params []contract.ABIParam
+	if inner := strings.TrimSpace(sig[open+1 : len(sig)-1]); inner != "" {
+		for _, t := range strings.Split(inner, ",") {
+			if f := strings.Fields(t); len(f) > 0 {
+				params = append(params, contract.ABIParam{Type: f[0]})
+			}
+		}
+	}
+	if len(params) != len(args) {
+		return nil, fmt.Errorf("%s takes %d argument(s), got %d", sig, len(params), len(args))
+	}
+	encoded, err := contract.EncodeConstructorArgs(params, args)
+	if err != nil {
+		return nil, err
+	}
+	selector := crypto.Keccak256([]byte(normalizeSignature(sig)))[:4]
+	return append(selector, encoded...), nil
+}
+
+// readRawTx accepts raw hex, a signed tx file, or a file holding raw hex.
+func readRawTx(arg string) (string, error) {
+	if strings.HasPrefix(arg, "0x") || strings.HasPrefix(arg, "0X") {
+		return arg, nil
+	}
+	if doc, err := txfile.Load(arg); err == nil {
+		if s, ok := doc.(*txfile.Signed); ok {
+			return s.Raw, nil
+		}
+		return "", fmt.Errorf("%s is unsigned — run `w3cli tx sign` first", arg)
+	}
+	data, err := os.ReadFile(arg)
+	if err != nil {
+		return "", fmt.Errorf("%q is neither raw hex nor a readable file", arg)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// unsignedPairs renders an unsigned tx for review before building or signing.
+func unsignedPairs(u *txfile.Unsigned) [][2]string {
+	value, _ := new(big.Int).SetString(u.Value, 10)
+	feeCap, _ := new(big.Int).SetString(u.MaxFeePerGas, 10)
+	tipCap, _ := new(big.Int).SetString(u.MaxPriorityFeePerGas, 10)
+	labels := newAddressLabels(u.Network)
+	to := u.To
+	if to == "" {
+		to = "(contract creation)"
+	} else {
+		to = labels.Addr(to)
+	}
+	pairs := [][2]string{
+		{"From", labels.Addr(u.From)},
+		{"To", to},
+		{"Value", formatTokenAmount(value, 18)},
+		{"Nonce", fmt.Sprintf("%d", u.Nonce)},
+		{"Gas Limit", fmt.Sprintf("%d", u.Gas)},
+		{"Max Fee", fmt.Sprintf("%d Gwei", toGwei(feeCap))},
+		{"Priority Fee", fmt.Sprintf("%d Gwei", toGwei(tipCap))},
+		{"Chain ID", fmt.Sprintf("%d", u.ChainID)},
+	}
+	if u.Data != "" {
+		data := u.Data
+		if len(data) > 74 {
+			data = data[:74] + "…"
+		}
+		pairs = append(pairs, [2]string{"Data", data})
+	}
+	if u.Note != "" {
+		pairs = append(pairs, [2]string{"Note", u.Note})
+	}
+	return pairs
+}
+
 func init() {
-	txCmd.Flags().StringVar(&txNetwork, "network", "", "chain (default: config)")
+	txCmd.PersistentFlags().StringVar(&txNetwork, "network", "", "chain (default: config)")
+
+	txBuildCmd.Flags().StringVar(&txBuildFrom, "from", "", "sending wallet name or address (default: config)")
+	txBuildCmd.Flags().StringVar(&txBuildTo, "to", "", "recipient or contract address / wallet name")
+	txBuildCmd.Flags().StringVar(&txBuildValue, "value", "", "native amount to send (e.g. 0.5)")
+	txBuildCmd.Flags().StringVar(&txBuildData, "data", "", "raw calldata hex")
+	txBuildCmd.Flags().StringVar(&txBuildSig, "sig", "", `function signature, e.g. "transfer(address,uint256)"`)
+	txBuildCmd.Flags().StringArrayVar(&txBuildArgs, "arg", nil, "function argument (repeat per parameter)")
+	txBuildCmd.Flags().Int64Var(&txBuildNonce, "nonce", -1, "nonce override (default: reserve the next one)")
+	txBuildCmd.Flags().Uint64Var(&txBuildGas, "gas", 0, "gas limit override (default: estimate)")
+	txBuildCmd.Flags().StringVar(&txBuildNote, "note", "", "free-text note stored in the file")
+	txBuildCmd.Flags().StringVarP(&txBuildOut, "out", "o", "", "output file (default: tx-<network>-<nonce>.unsigned.json)")
+
+	txSignCmd.Flags().StringVar(&txSignWallet, "wallet", "", "signing wallet (default: the one matching \"from\")")
+	txSignCmd.Flags().StringVarP(&txSignOut, "out", "o", "", "output file (default: <file>.signed.json)")
+	txSignCmd.Flags().BoolVarP(&txSignYes, "yes", "y", false, "skip the confirmation prompt")
+
+	txBroadcastCmd.Flags().BoolVar(&txBroadcastNoWait, "no-wait", false, "return after broadcasting without waiting for the receipt")
+
+	txCmd.AddCommand(txBuildCmd, txSignCmd, txBroadcastCmd, txInspectCmd)
 }
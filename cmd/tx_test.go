@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTxDataFromSignature(t *testing.T) {
+	data, err := buildTxData("", "transfer(address,uint256)", []string{
+		"0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+		"1000",
+	})
+	require.NoError(t, err)
+	require.Len(t, data, 4+64)
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(data[:4]))
+	assert.Equal(t, byte(0xe8), data[len(data)-1])
+}
+
+func TestBuildTxDataDynamicArg(t *testing.T) {
+	data, err := buildTxData("", "setName(string)", []string{"hello"})
+	require.NoError(t, err)
+	// selector + offset + length + padded string
+	assert.Len(t, data, 4+32*3)
+}
+
+func TestBuildTxDataRawAndErrors(t *testing.T) {
+	data, err := buildTxData("0xdeadbeef", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, data)
+
+	data, err = buildTxData("", "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = buildTxData("0xzz", "", nil)
+	assert.Error(t, err)
+	_, err = buildTxData("", "transfer(address,uint256)", []string{"0x1"})
+	assert.Error(t, err, "wrong arg count")
+	_, err = buildTxData("", "transfer", nil)
+	assert.Error(t, err)
+	_, err = buildTxData("", "", []string{"1"})
+	assert.Error(t, err, "--arg without --sig")
+}
+
+func TestReadRawTx(t *testing.T) {
+	raw, err := readRawTx("0x02f8")
+	require.NoError(t, err)
+	assert.Equal(t, "0x02f8", raw)
+
+	path := filepath.Join(t.TempDir(), "raw.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0xabcd\n"), 0o600))
+	raw, err = readRawTx(path)
+	require.NoError(t, err)
+	assert.Equal(t, "0xabcd", raw)
+
+	_, err = readRawTx(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
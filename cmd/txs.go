@@ -25,9 +25,13 @@ var txsCmd = &cobra.Command{
 Uses the configured network mode (mainnet/testnet) by default.
 Override per-call with --testnet or --mainnet.
 
+On Solana the history comes from getSignaturesForAddress, on SUI from
+suix_queryTransactionBlocks.
+
 Examples:
   w3cli txs 0xABC... --network base --last 10
-  w3cli txs --network ethereum --testnet --last 5`,
+  w3cli txs --network ethereum --testnet --last 5
+  w3cli txs mySolWallet --network solana --last 20`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 1 && txsWallet == "" {
@@ -45,6 +49,12 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("unknown chain %q — run `w3cli network list` to see all chains", chainName)
 		}
+		switch c.Type {
+		case chain.ChainTypeSolana:
+			return runSolanaTxs(c, address, networkMode, txsLast)
+		case chain.ChainTypeSUI:
+			return runSUITxs(c, address, networkMode, txsLast)
+		}
 
 		spin := ui.NewSpinner(fmt.Sprintf("Fetching last %d transactions on %s (%s)...", txsLast, ui.ChainName(chainName), networkMode))
 		spin.Start()
@@ -125,6 +135,7 @@ Examples:
 		now := uint64(time.Now().Unix())
 
 		txRowData := make([]ui.TxRow, 0, len(txs))
+		labels := newAddressLabels(chainName)
 
 		for _, tx := range txs {
 			// Status icon.
@@ -133,13 +144,13 @@ Examples:
 				status = ui.StyleError.Render("✗")
 			}
 
-			// To label: contract name, or truncated address.
-			toLabel := ui.TruncateAddr(tx.To)
-			if name, ok := contractNames[strings.ToLower(tx.To)]; ok {
+			// To label: contact or wallet name, contract name, or truncated address.
+			toLabel := labels.Short(tx.To)
+			if name, ok := contractNames[strings.ToLower(tx.To)]; ok && labels.Name(tx.To) == "" {
 				toLabel = name
-				if len(toLabel) > 20 {
-					toLabel = toLabel[:18] + ".."
-				}
+			}
+			if len(toLabel) > 20 {
+				toLabel = toLabel[:18] + ".."
 			}
 
 			// Value — show 4 decimal places, trim trailing zeros.
@@ -153,17 +164,7 @@ Examples:
 			// Relative age.
 			age := ""
 			if tx.Timestamp > 0 && now > tx.Timestamp {
-				diff := now - tx.Timestamp
-				switch {
-				case diff < 60:
-					age = fmt.Sprintf("%ds ago", diff)
-				case diff < 3600:
-					age = fmt.Sprintf("%dm ago", diff/60)
-				case diff < 86400:
-					age = fmt.Sprintf("%dh ago", diff/3600)
-				default:
-					age = fmt.Sprintf("%dd ago", diff/86400)
-				}
+				age = relativeAge(now - tx.Timestamp)
 			}
 
 			t.AddRow(ui.Row{
@@ -194,6 +195,20 @@ Examples:
 	},
 }
 
+// relativeAge renders an age in seconds as "42s ago", "5m ago", "3h ago" or "2d ago".
+func relativeAge(diff uint64) string {
+	switch {
+	case diff < 60:
+		return fmt.Sprintf("%ds ago", diff)
+	case diff < 3600:
+		return fmt.Sprintf("%dm ago", diff/60)
+	case diff < 86400:
+		return fmt.Sprintf("%dh ago", diff/3600)
+	default:
+		return fmt.Sprintf("%dd ago", diff/86400)
+	}
+}
+
 func init() {
 	txsCmd.Flags().StringVar(&txsWallet, "wallet", "", "wallet name or address")
 	txsCmd.Flags().StringVar(&txsNetwork, "network", "", "chain to query")
@@ -2,14 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/Mohsinsiddi/w3cli/internal/ui"
 	"github.com/Mohsinsiddi/w3cli/internal/wallet"
 	"github.com/spf13/cobra"
 )
 
-var walletKeyFlag string
+var (
+	walletKeyFlag     string
+	walletKeyFileFlag string
+	walletChainFlag   string
+	walletGenChain    string
+)
 
 var walletCmd = &cobra.Command{
 	Use:   "wallet",
@@ -19,28 +28,66 @@ var walletCmd = &cobra.Command{
 var walletAddCmd = &cobra.Command{
 	Use:   "add <name> [address]",
 	Short: "Add a wallet",
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `Add a watch-only wallet by address, or a signing wallet with --key.
+
+Solana keys are accepted as base58 (Phantom "Export Private Key") or as the
+JSON byte array written by solana-keygen; pass the file with --key-file.
+SUI keys are accepted as suiprivkey1… (sui keytool export) or a base64
+sui.keystore entry. The chain is detected from the address or key format;
+force it with --chain.
+
+Examples:
+  w3cli wallet add alice 0xABC...
+  w3cli wallet add deployer --key 0x...
+  w3cli wallet add phantom --key 4vJ9... --chain solana
+  w3cli wallet add cli --key-file ~/.config/solana/id.json
+  w3cli wallet add suiw --key suiprivkey1...`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		mgr := newWalletManager()
 
-		if walletKeyFlag != "" {
+		key := walletKeyFlag
+		if walletKeyFileFlag != "" {
+			data, err := os.ReadFile(walletKeyFileFlag)
+			if err != nil {
+				return fmt.Errorf("reading key file: %w", err)
+			}
+			key = strings.TrimSpace(string(data))
+		}
+
+		if key != "" {
 			// Signing wallet.
-			if err := mgr.AddWithKey(name, walletKeyFlag); err != nil {
+			chainType, err := walletChainType(walletChainFlag, key, true)
+			if err != nil {
+				return err
+			}
+			if err := mgr.AddWithKeyFor(name, chainType, key); err != nil {
 				return err
 			}
 			w, _ := mgr.Get(name)
-			fmt.Println(ui.Success(fmt.Sprintf("Signing wallet %q added: %s", name, ui.Addr(w.Address))))
+			fmt.Println(ui.Success(fmt.Sprintf("Signing %s wallet %q added: %s", chainType, name, ui.Addr(w.Address))))
 			fmt.Println(ui.Hint(fmt.Sprintf("Set as default with: w3cli wallet use %s", name)))
 		} else {
 			if len(args) < 2 {
 				return fmt.Errorf("address required for watch-only wallet\n  Usage: w3cli wallet add <name> <address>\n  Or for signing: w3cli wallet add <name> --key <private-key>")
 			}
 			address := args[1]
+			chainType, err := walletChainType(walletChainFlag, address, false)
+			if err != nil {
+				return err
+			}
+			if chainType == wallet.ChainSolana && !solana.IsAddress(address) {
+				return fmt.Errorf("%q is not a Solana address", address)
+			}
+			if chainType == wallet.ChainSUI && !sui.IsAddress(address) {
+				return fmt.Errorf("%q is not a SUI address (0x + 64 hex)", address)
+			}
 			if err := mgr.Add(name, &wallet.Wallet{
-				Name:    name,
-				Address: address,
-				Type:    wallet.TypeWatchOnly,
+				Name:      name,
+				Address:   address,
+				Type:      wallet.TypeWatchOnly,
+				ChainType: chainType,
 			}); err != nil {
 				return err
 			}
@@ -67,6 +114,7 @@ var walletListCmd = &cobra.Command{
 		t := ui.NewTable([]ui.Column{
 			{Title: "Name", Width: 16},
 			{Title: "Address", Width: 44},
+			{Title: "Chain", Width: 7},
 			{Title: "Type", Width: 12},
 			{Title: "Default", Width: 8},
 		})
@@ -79,6 +127,7 @@ var walletListCmd = &cobra.Command{
 			t.AddRow(ui.Row{
 				ui.Val(w.Name),
 				ui.Addr(w.Address),
+				ui.Meta(w.Chain()),
 				ui.Meta(walletTypeLabel(w.Type)),
 				def,
 			})
@@ -128,8 +177,10 @@ var walletUseCmd = &cobra.Command{
 
 var walletGenerateCmd = &cobra.Command{
 	Use:   "generate <name>",
-	Short: "Generate a new EVM wallet",
-	Long: `Generate a brand-new EVM keypair and store the private key in the OS keychain.
+	Short: "Generate a new wallet (EVM by default)",
+	Long: `Generate a brand-new keypair and store the private key in the OS keychain.
+Use --chain solana for an ed25519 Solana wallet (key shown in the base58
+format Phantom imports) or --chain sui for a SUI wallet (suiprivkey format).
 
 The private key is displayed ONCE immediately after creation.
 Copy it and store it in a password manager — if you lose it, the wallet is gone forever.
@@ -138,8 +189,12 @@ Re-export later with: w3cli wallet export <name>`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
+		chainType, err := walletChainType(walletGenChain, "", true)
+		if err != nil {
+			return err
+		}
 		mgr := newWalletManager()
-		w, hexKey, err := mgr.Generate(name)
+		w, hexKey, err := mgr.GenerateFor(name, chainType)
 		if err != nil {
 			return err
 		}
@@ -342,6 +397,9 @@ var walletLockCmd = &cobra.Command{
 
 func init() {
 	walletAddCmd.Flags().StringVar(&walletKeyFlag, "key", "", "private key for signing wallet (stored in OS keychain)")
+	walletAddCmd.Flags().StringVar(&walletKeyFileFlag, "key-file", "", "read the private key from a file (e.g. solana-keygen id.json)")
+	walletAddCmd.Flags().StringVar(&walletChainFlag, "chain", "", "wallet chain: evm|solana|sui (default: detected)")
+	walletGenerateCmd.Flags().StringVar(&walletGenChain, "chain", "evm", "wallet chain: evm|solana|sui")
 	walletUnlockCmd.Flags().BoolVar(&walletUnlockAll, "all", false, "unlock all signing wallets")
 	walletCmd.AddCommand(walletAddCmd, walletListCmd, walletRemoveCmd, walletUseCmd,
 		walletGenerateCmd, walletExportCmd, walletUnlockCmd, walletLockCmd)
@@ -360,6 +418,65 @@ func warnIfNoSession() {
 	}
 }
 
+// walletChainType validates --chain, or detects the chain from an address or
+// key when the flag is empty. 0x keys and 20-byte addresses are EVM, 32-byte
+// 0x addresses and suiprivkey keys are SUI, base58 values and JSON byte
+// arrays are Solana.
+func walletChainType(flag, value string, isKey bool) (string, error) {
+	switch strings.ToLower(flag) {
+	case wallet.ChainEVM:
+		return wallet.ChainEVM, nil
+	case wallet.ChainSolana:
+		return wallet.ChainSolana, nil
+	case wallet.ChainSUI:
+		return wallet.ChainSUI, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unsupported --chain %q (evm|solana|sui)", flag)
+	}
+
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(strings.ToLower(value), sui.PrivateKeyPrefix+"1"):
+		return wallet.ChainSUI, nil
+	case !isKey && sui.IsAddress(value):
+		return wallet.ChainSUI, nil
+	case strings.HasPrefix(value, "0x"), strings.HasPrefix(value, "0X"):
+		return wallet.ChainEVM, nil
+	case strings.HasPrefix(value, "["), solana.IsAddress(value):
+		return wallet.ChainSolana, nil
+	}
+	if b, err := solana.Base58Decode(value); err == nil && (len(b) == 64 || len(b) == 32) && !isHexString(value) {
+		return wallet.ChainSolana, nil
+	}
+	return wallet.ChainEVM, nil
+}
+
+// walletNetwork returns the network for a wallet when --network is not set:
+// Solana and SUI wallets use their own chain, EVM wallets the fallback.
+func walletNetwork(chainType, networkFlag, fallback string) string {
+	if networkFlag != "" {
+		return networkFlag
+	}
+	if chainType == wallet.ChainSolana || chainType == wallet.ChainSUI {
+		return chainType
+	}
+	return fallback
+}
+
+// isHexString reports whether s is non-empty and only hex digits.
+func isHexString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
 // walletTypeLabel converts an internal wallet type to a user-friendly label.
 func walletTypeLabel(t string) string {
 	switch t {
@@ -18,7 +18,7 @@ var (
 )
 
 var watchCmd = &cobra.Command{
-	Use:   "watch [address]",
+	Use:   "watch [address-or-name]",
 	Short: "Stream live transactions for an address",
 	Long: `Watch an address for incoming and outgoing transactions in real-time.
 
@@ -42,17 +42,14 @@ Examples:
   w3cli watch --network ethereum --testnet`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		address, chainName, err := resolveWalletAndChain(watchWallet, watchNetwork)
 		if len(args) == 1 {
-			address = args[0]
-			if watchNetwork == "" {
-				chainName = cfg.DefaultNetwork
-				if chainName == "" {
-					chainName = "ethereum"
-				}
+			if watchWallet != "" {
+				return fmt.Errorf("pass the address either positionally or with --wallet, not both")
 			}
+			watchWallet = args[0]
 		}
-		if err != nil && address == "" {
+		address, chainName, err := resolveWalletAndChain(watchWallet, watchNetwork)
+		if err != nil {
 			return err
 		}
 
@@ -75,6 +72,7 @@ func runWatch(address, chainName, mode string) error {
 
 	client := chain.NewEVMClient(rpcURL)
 	explorer := c.Explorer(mode)
+	labels := newAddressLabels(chainName)
 
 	m := ui.WatchModel{
 		Address: address,
@@ -140,7 +138,7 @@ func runWatch(address, chainName, mode string) error {
 					prog.Send(ui.WatchTxMsg{
 						Hash:        tx.Hash,
 						Direction:   direction,
-						Counterpart: ui.TruncateAddr(counterpart),
+						Counterpart: labels.Short(counterpart),
 						ValueStr:    valStr,
 						Currency:    c.NativeCurrency,
 						BlockNum:    blk,
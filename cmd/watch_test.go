@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchRejectsWalletAndPositionalAddress(t *testing.T) {
+	watchWallet = "main"
+	defer func() { watchWallet = "" }()
+
+	err := watchCmd.RunE(watchCmd, []string{"0x1111111111111111111111111111111111111111"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not both")
+}
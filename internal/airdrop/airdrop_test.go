@@ -0,0 +1,490 @@
+package airdrop
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const (
+	addrA = "0x1111111111111111111111111111111111111111"
+	addrB = "0x2222222222222222222222222222222222222222"
+	addrC = "0x3333333333333333333333333333333333333333"
+	token = "0x4444444444444444444444444444444444444444"
+)
+
+type keySigner struct{ key *ecdsa.PrivateKey }
+
+func newKeySigner(t *testing.T) *keySigner {
+	t.Helper()
+	k, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &keySigner{key: k}
+}
+
+func (s *keySigner) Address() string { return crypto.PubkeyToAddress(s.key.PublicKey).Hex() }
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	signed, err := types.SignTx(tx, types.NewLondonSigner(chainID), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+// fakeChain mines broadcasts instantly unless told otherwise.
+type fakeChain struct {
+	nonce    uint64
+	sent     []*types.Transaction
+	mined    map[string]uint64 // hash → receipt status
+	failSend int               // fail the Nth broadcast (1-based)
+	revertTo string            // receipts for txs to this address revert
+	unmined  bool              // WaitForReceipt times out
+	nonceLow bool              // re-broadcasts answer "nonce too low"
+
+	// The Nth broadcast (1-based) times out. The node keeps the tx unless
+	// dropTimedOut is set.
+	timeoutSend  int
+	dropTimedOut bool
+}
+
+func newFakeChain() *fakeChain { return &fakeChain{mined: make(map[string]uint64)} }
+
+func (f *fakeChain) GasPrice() (*big.Int, error) { return big.NewInt(1_000_000_000), nil }
+func (f *fakeChain) EstimateGas(string, string, string, *big.Int) (uint64, error) {
+	return 0, errors.New("estimate unavailable")
+}
+func (f *fakeChain) GetPendingNonce(string) (uint64, error) { return f.nonce, nil }
+
+func (f *fakeChain) SendRawTransaction(raw string) (string, error) {
+	b, _ := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return "", err
+	}
+	for _, s := range f.sent {
+		if s.Hash() == tx.Hash() {
+			return "", errors.New("already known")
+		}
+	}
+	if f.nonceLow && tx.Nonce() < f.nonce {
+		return "", &chain.RPCError{Code: -32000, Message: "nonce too low"}
+	}
+	if f.failSend > 0 && len(f.sent)+1 == f.failSend {
+		f.failSend = 0
+		return "", &chain.RPCError{Code: -32000, Message: "insufficient funds"}
+	}
+	timedOut := f.timeoutSend > 0 && len(f.sent)+1 == f.timeoutSend
+	if timedOut {
+		f.timeoutSend = 0
+		if f.dropTimedOut {
+			return "", errors.New("RPC request failed: context deadline exceeded")
+		}
+	}
+	f.sent = append(f.sent, tx)
+	f.nonce = tx.Nonce() + 1
+	if !f.unmined {
+		status := uint64(1)
+		if f.revertTo != "" && strings.EqualFold(tx.To().Hex(), f.revertTo) {
+			status = 0
+		}
+		f.mined[tx.Hash().Hex()] = status
+	}
+	if timedOut {
+		return "", errors.New("RPC request failed: context deadline exceeded")
+	}
+	return tx.Hash().Hex(), nil
+}
+
+func (f *fakeChain) GetTransactionReceipt(hash string) (*chain.TxReceipt, error) {
+	status, ok := f.mined[hash]
+	if !ok {
+		return nil, nil
+	}
+	return &chain.TxReceipt{Hash: hash, Status: status, BlockNumber: 100}, nil
+}
+
+func (f *fakeChain) WaitForReceipt(hash string, _ time.Duration) (*chain.TxReceipt, error) {
+	r, _ := f.GetTransactionReceipt(hash)
+	if r == nil {
+		return nil, fmt.Errorf("transaction %s not mined", hash)
+	}
+	if r.Status == 0 {
+		return r, fmt.Errorf("transaction reverted (hash: %s)", hash)
+	}
+	return r, nil
+}
+
+func recipients(t *testing.T, rows ...[2]string) []Recipient {
+	t.Helper()
+	lines := make([]int, len(rows))
+	for i := range rows {
+		lines[i] = i + 2
+	}
+	rs, problems := Validate(rows, lines, 18, nil)
+	require.Empty(t, problems)
+	return rs
+}
+
+func newTestAirdropper(t *testing.T, fc *fakeChain, rs []Recipient) *Airdropper {
+	t.Helper()
+	l, err := LoadLedger(filepath.Join(t.TempDir(), "r.csv.ledger.json"))
+	require.NoError(t, err)
+	return &Airdropper{Recipients: rs, Ledger: l, Client: fc, Signer: newKeySigner(t), ChainID: 8453}
+}
+
+// ---------------------------------------------------------------------------
+// CSV + Validate
+// ---------------------------------------------------------------------------
+
+func TestParseCSVSkipsHeaderAndComments(t *testing.T) {
+	data := "address,amount\n# team\n" + addrA + ",1.5\n\n" + addrB + ", 2\n"
+	rows, lines, err := parseCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, [2]string{addrA, "1.5"}, rows[0])
+	assert.Equal(t, [2]string{addrB, "2"}, rows[1])
+	assert.Equal(t, []int{3, 5}, lines)
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	_, _, err := parseCSV(strings.NewReader("address,amount\n"))
+	assert.Error(t, err)
+	_, _, err = parseCSV(strings.NewReader(addrA + "\n"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	resolve := func(name string) (string, error) {
+		if name == "alice.eth" {
+			return addrC, nil
+		}
+		return "", errors.New("not found")
+	}
+	rows := [][2]string{
+		{addrA, "1"},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "2"}, // valid checksum
+		{"0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "2"}, // bad checksum
+		{"0x0000000000000000000000000000000000000000", "1"},
+		{"alice.eth", "0.25"},
+		{"bob.eth", "1"},
+		{"bob", "1"},
+		{addrB, "0"},
+		{addrB, "1.2.3"},
+		{strings.ToUpper(addrA[:2]) + addrA[2:], "3"}, // duplicate of line 1
+	}
+	lines := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	rs, problems := Validate(rows, lines, 18, resolve)
+	require.Len(t, rs, 3)
+	assert.Equal(t, addrC, strings.ToLower(rs[2].Address))
+	assert.Equal(t, "250000000000000000", rs[2].Wei.String())
+
+	var bad []int
+	for _, p := range problems {
+		bad = append(bad, p.Line)
+	}
+	assert.Equal(t, []int{3, 4, 6, 7, 8, 9, 10}, bad)
+	assert.Contains(t, problems[0].Message, "checksum")
+	assert.Contains(t, problems[len(problems)-1].Message, "line 1")
+	assert.Equal(t, "3250000000000000000", Total(rs).String())
+}
+
+func TestKeyIsAddress(t *testing.T) {
+	a := Recipient{Line: 2, Address: addrA, Wei: big.NewInt(5)}
+	b := Recipient{Line: 9, Address: strings.ToUpper(addrA), Wei: big.NewInt(5)}
+	c := Recipient{Line: 2, Address: addrA, Wei: big.NewInt(6)}
+	d := Recipient{Line: 2, Address: addrB, Wei: big.NewInt(5)}
+	assert.Equal(t, a.Key(), b.Key())
+	assert.Equal(t, a.Key(), c.Key(), "an edited amount is the same recipient")
+	assert.NotEqual(t, a.Key(), d.Key())
+}
+
+// ---------------------------------------------------------------------------
+// Ledger
+// ---------------------------------------------------------------------------
+
+func TestLedgerBindRefusesOtherRun(t *testing.T) {
+	l, err := LoadLedger(filepath.Join(t.TempDir(), "x.json"))
+	require.NoError(t, err)
+	require.NoError(t, l.Bind("base", token, addrA, ModeSequential))
+
+	rs := recipients(t, [2]string{addrB, "1"})
+	l.Row(&rs[0])
+	require.NoError(t, l.Save())
+
+	again, err := LoadLedger(l.Path())
+	require.NoError(t, err)
+	assert.NoError(t, again.Bind("base", strings.ToUpper(token), addrA, ModeDisperse))
+	assert.Error(t, again.Bind("ethereum", token, addrA, ModeSequential))
+	assert.Error(t, again.Bind("base", "", addrA, ModeSequential))
+}
+
+func TestLedgerReport(t *testing.T) {
+	dir := t.TempDir()
+	l, err := LoadLedger(filepath.Join(dir, "x.json"))
+	require.NoError(t, err)
+	rs := recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"})
+	l.Row(&rs[1]).Status = StatusConfirmed
+	l.Row(&rs[0])
+
+	path := filepath.Join(dir, "report.csv")
+	require.NoError(t, l.WriteReport(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "line,address,amount,status,tx_hash,error", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "2,"+addrA+",1,pending"))
+	assert.Equal(t, map[string]int{StatusPending: 1, StatusConfirmed: 1}, l.Counts())
+}
+
+// ---------------------------------------------------------------------------
+// Encoding
+// ---------------------------------------------------------------------------
+
+func TestEncodeDisperse(t *testing.T) {
+	amounts := []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+	ether := hex.EncodeToString(EncodeDisperseEther([]string{addrA, addrB}, amounts))
+	assert.True(t, strings.HasPrefix(ether, selDisperseEther))
+	assert.Len(t, ether, 8+64*(2+1+2+1+2))
+	assert.Equal(t, fmt.Sprintf("%064x", 64), ether[8:72])
+	assert.Equal(t, fmt.Sprintf("%064x", 160), ether[72:136])
+
+	tok := hex.EncodeToString(EncodeDisperseToken(token, []string{addrA, addrB}, amounts))
+	assert.True(t, strings.HasPrefix(tok, selDisperseToken))
+	assert.Len(t, tok, 8+64*(3+1+2+1+2))
+	assert.Equal(t, fmt.Sprintf("%064x", 96), tok[72:136])
+	assert.Equal(t, fmt.Sprintf("%064x", 192), tok[136:200])
+	assert.Equal(t, fmt.Sprintf("%064x", 2), tok[len(tok)-64:])
+}
+
+// ---------------------------------------------------------------------------
+// Run
+// ---------------------------------------------------------------------------
+
+func TestRunSequentialNative(t *testing.T) {
+	fc := newFakeChain()
+	fc.nonce = 4
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"}))
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 2)
+	assert.Equal(t, uint64(4), fc.sent[0].Nonce())
+	assert.Equal(t, uint64(5), fc.sent[1].Nonce())
+	assert.Equal(t, "2000000000000000000", fc.sent[1].Value().String())
+	assert.Equal(t, uint64(21000), fc.sent[0].Gas())
+	assert.Equal(t, map[string]int{StatusConfirmed: 2}, a.Ledger.Counts())
+
+	// A second run pays nobody.
+	require.NoError(t, a.Run())
+	assert.Len(t, fc.sent, 2)
+	assert.Empty(t, a.Pending())
+}
+
+func TestRunSequentialToken(t *testing.T) {
+	fc := newFakeChain()
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}))
+	a.Token = token
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, token, strings.ToLower(fc.sent[0].To().Hex()))
+	assert.Equal(t, selTransfer, hex.EncodeToString(fc.sent[0].Data()[:4]))
+	assert.Equal(t, int64(0), fc.sent[0].Value().Int64())
+}
+
+func TestRunDisperseBatches(t *testing.T) {
+	fc := newFakeChain()
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"}, [2]string{addrC, "3"}))
+	a.Mode = ModeDisperse
+	a.BatchSize = 2
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 2)
+	assert.Equal(t, DefaultDisperse, fc.sent[0].To().Hex())
+	assert.Equal(t, "3000000000000000000", fc.sent[0].Value().String(), "value covers the batch")
+	assert.Equal(t, "3000000000000000000", fc.sent[1].Value().String())
+
+	rows := a.rowsByHash(fc.sent[0].Hash().Hex())
+	assert.Len(t, rows, 2)
+	assert.Equal(t, map[string]int{StatusConfirmed: 3}, a.Ledger.Counts())
+}
+
+func TestRunBroadcastFailureStopsAndResumes(t *testing.T) {
+	fc := newFakeChain()
+	fc.failSend = 2
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"}, [2]string{addrC, "3"}))
+
+	err := a.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient funds")
+	assert.Len(t, fc.sent, 1)
+	assert.Equal(t, map[string]int{StatusConfirmed: 1, StatusFailed: 1, StatusPending: 1}, a.Ledger.Counts())
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 3)
+	assert.Equal(t, uint64(1), fc.sent[1].Nonce(), "failed broadcast did not consume a nonce")
+	assert.Equal(t, map[string]int{StatusConfirmed: 3}, a.Ledger.Counts())
+}
+
+func TestRunRefusesEditedAmount(t *testing.T) {
+	fc := newFakeChain()
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"}))
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 2)
+
+	// Bumping a paid row must not send the new amount on top.
+	a.Recipients = recipients(t, [2]string{addrB, "2"}, [2]string{addrA, "1.5"}, [2]string{addrC, "3"})
+	err := a.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1.5 in the CSV, 1 in the ledger")
+	assert.Len(t, fc.sent, 2)
+
+	// Reordering rows and adding new ones is fine.
+	a.Recipients = recipients(t, [2]string{addrC, "3"}, [2]string{addrB, "2"}, [2]string{addrA, "1"})
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 3)
+	assert.Equal(t, addrC, fc.sent[2].To().Hex())
+}
+
+func TestLedgerSaveLeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+	l, err := LoadLedger(filepath.Join(dir, "r.csv.ledger.json"))
+	require.NoError(t, err)
+	rs := recipients(t, [2]string{addrA, "1"})
+	l.Row(&rs[0])
+	require.NoError(t, l.Save())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	again, err := LoadLedger(l.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", again.Rows[rs[0].Key()].Wei)
+}
+
+func TestRunBroadcastTimeoutNeverPaysTwice(t *testing.T) {
+	fc := newFakeChain()
+	fc.timeoutSend = 1
+	rs := recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"})
+	a := newTestAirdropper(t, fc, rs)
+
+	// The node took the tx even though the broadcast timed out.
+	err := a.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deadline exceeded")
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, StatusConfirmed, a.Ledger.Rows[rs[0].Key()].Status)
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 2)
+	assert.Equal(t, addrB, fc.sent[1].To().Hex())
+	assert.Equal(t, map[string]int{StatusConfirmed: 2}, a.Ledger.Counts())
+}
+
+func TestRunBroadcastTimeoutResumesSameTx(t *testing.T) {
+	fc := newFakeChain()
+	fc.timeoutSend = 1
+	fc.dropTimedOut = true
+	rs := recipients(t, [2]string{addrA, "1"})
+	a := newTestAirdropper(t, fc, rs)
+
+	require.Error(t, a.Run())
+	assert.Empty(t, fc.sent)
+	row := a.Ledger.Rows[rs[0].Key()]
+	assert.Equal(t, StatusSent, row.Status)
+	assert.NotEmpty(t, row.RawTx, "signed tx is kept for the resume")
+	assert.Empty(t, a.Pending())
+
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, row.Hash, fc.sent[0].Hash().Hex(), "resume replays the same tx")
+	assert.Equal(t, StatusConfirmed, row.Status)
+}
+
+func TestRunResumeRejectedTxIsFailed(t *testing.T) {
+	fc := newFakeChain()
+	fc.timeoutSend = 1
+	fc.dropTimedOut = true
+	rs := recipients(t, [2]string{addrA, "1"})
+	a := newTestAirdropper(t, fc, rs)
+	require.Error(t, a.Run())
+
+	// On resume the node refuses the saved tx outright, so nothing was paid
+	// and the row is retried with a new tx.
+	fc.failSend = 1
+	require.NoError(t, a.Run())
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, map[string]int{StatusConfirmed: 1}, a.Ledger.Counts())
+}
+
+func TestRunRevertMarksFailed(t *testing.T) {
+	fc := newFakeChain()
+	fc.revertTo = addrB
+	a := newTestAirdropper(t, fc, recipients(t, [2]string{addrA, "1"}, [2]string{addrB, "2"}))
+
+	require.NoError(t, a.Run())
+	row := a.Ledger.Rows[a.Recipients[1].Key()]
+	assert.Equal(t, StatusFailed, row.Status)
+	assert.Empty(t, row.RawTx)
+	assert.Len(t, a.Pending(), 1)
+}
+
+func TestRunResumeReplaysUnminedTx(t *testing.T) {
+	fc := newFakeChain()
+	fc.unmined = true
+	rs := recipients(t, [2]string{addrA, "1"})
+	a := newTestAirdropper(t, fc, rs)
+
+	require.Error(t, a.Run(), "unconfirmed tx is reported")
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, StatusSent, a.Ledger.Rows[rs[0].Key()].Status)
+
+	// Reload the ledger as a fresh process would and let the tx mine.
+	l, err := LoadLedger(a.Ledger.Path())
+	require.NoError(t, err)
+	a.Ledger = l
+	fc.unmined = false
+	fc.mined[fc.sent[0].Hash().Hex()] = 1
+
+	require.NoError(t, a.Run())
+	assert.Len(t, fc.sent, 1, "no second payment")
+	assert.Equal(t, StatusConfirmed, l.Rows[rs[0].Key()].Status)
+}
+
+func TestRunResumeNonceReusedIsUnknown(t *testing.T) {
+	fc := newFakeChain()
+	fc.unmined = true
+	rs := recipients(t, [2]string{addrA, "1"})
+	a := newTestAirdropper(t, fc, rs)
+	require.Error(t, a.Run())
+
+	// Our tx was dropped and the nonce used elsewhere.
+	fc.sent = nil
+	fc.nonce = 10
+	fc.nonceLow = true
+
+	require.NoError(t, a.Run())
+	assert.Empty(t, fc.sent)
+	assert.Equal(t, StatusUnknown, a.Ledger.Rows[rs[0].Key()].Status)
+	assert.Empty(t, a.Pending(), "unknown rows are never re-paid automatically")
+}
@@ -0,0 +1,168 @@
+package airdrop
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Row statuses.
+const (
+	StatusPending   = "pending"
+	StatusSent      = "sent"      // signed + broadcast, receipt not yet seen
+	StatusConfirmed = "confirmed" // receipt status 1
+	StatusFailed    = "failed"    // reverted or rejected by the node — safe to retry
+	StatusUnknown   = "unknown"   // nonce consumed by a different tx — check manually
+)
+
+// Ledger is the per-row record of an airdrop, persisted after every change.
+type Ledger struct {
+	Network string                `json:"network"`
+	Token   string                `json:"token"` // "" for native
+	From    string                `json:"from"`
+	Mode    string                `json:"mode"`
+	Rows    map[string]*LedgerRow `json:"rows"` // key: Recipient.Key()
+
+	path string
+}
+
+// LedgerRow records what happened to one recipient.
+type LedgerRow struct {
+	Line      int    `json:"line"`
+	Address   string `json:"address"`
+	Amount    string `json:"amount"`
+	Wei       string `json:"wei"`
+	Status    string `json:"status"`
+	Hash      string `json:"hash,omitempty"`
+	Nonce     uint64 `json:"nonce,omitempty"`
+	RawTx     string `json:"raw_tx,omitempty"`
+	Error     string `json:"error,omitempty"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// LedgerPath returns the default ledger path for a CSV file.
+func LedgerPath(csvPath string) string { return csvPath + ".ledger.json" }
+
+// LoadLedger reads the ledger at path, or returns an empty one.
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{Rows: make(map[string]*LedgerRow), path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("parsing ledger %s: %w", path, err)
+	}
+	if l.Rows == nil {
+		l.Rows = make(map[string]*LedgerRow)
+	}
+	return l, nil
+}
+
+// Path returns the ledger file path.
+func (l *Ledger) Path() string { return l.path }
+
+// Save writes the ledger via a temp file + rename so a crash never leaves a
+// torn file.
+func (l *Ledger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
+
+// Bind attaches the run parameters, refusing to mix a ledger from a different
+// token, network or sender — that would make "already paid" meaningless.
+func (l *Ledger) Bind(network, token, from, mode string) error {
+	if len(l.Rows) > 0 {
+		if l.Network != network || !strings.EqualFold(l.Token, token) || !strings.EqualFold(l.From, from) {
+			return fmt.Errorf("ledger %s belongs to a different run (%s, token %q, from %s) — move it aside to start over",
+				l.path, l.Network, l.Token, l.From)
+		}
+	}
+	l.Network, l.Token, l.From, l.Mode = network, token, from, mode
+	return nil
+}
+
+// Check refuses recipients whose amount differs from their ledger row. Rows
+// are keyed by address, so the ledger cannot tell whether the old amount was
+// already paid in full.
+func (l *Ledger) Check(rs []Recipient) error {
+	var bad []string
+	for i := range rs {
+		row, ok := l.Rows[rs[i].Key()]
+		if ok && row.Wei != rs[i].Wei.String() {
+			bad = append(bad, fmt.Sprintf("line %d (%s): %s in the CSV, %s in the ledger (%s)",
+				rs[i].Line, rs[i].Address, rs[i].Amount, row.Amount, row.Status))
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("amounts changed since ledger %s was written — restore them or move the ledger aside:\n  %s",
+			l.path, strings.Join(bad, "\n  "))
+	}
+	return nil
+}
+
+// Row returns the ledger row for r, creating a pending row when missing.
+func (l *Ledger) Row(r *Recipient) *LedgerRow {
+	row, ok := l.Rows[r.Key()]
+	if !ok {
+		row = &LedgerRow{Line: r.Line, Address: r.Address, Amount: r.Amount, Wei: r.Wei.String(), Status: StatusPending}
+		l.Rows[r.Key()] = row
+	}
+	return row
+}
+
+// Counts returns the number of rows in each status.
+func (l *Ledger) Counts() map[string]int {
+	out := make(map[string]int)
+	for _, r := range l.Rows {
+		out[r.Status]++
+	}
+	return out
+}
+
+// WriteReport writes a CSV report (line,address,amount,status,tx_hash,error)
+// sorted by CSV line.
+func (l *Ledger) WriteReport(path string) error {
+	rows := make([]*LedgerRow, 0, len(l.Rows))
+	for _, r := range l.Rows {
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Line < rows[j].Line })
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"line", "address", "amount", "status", "tx_hash", "error"}) //nolint:errcheck
+	for _, r := range rows {
+		w.Write([]string{fmt.Sprintf("%d", r.Line), r.Address, r.Amount, r.Status, r.Hash, r.Error}) //nolint:errcheck
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (l *Ledger) touch(rows []*LedgerRow, fn func(*LedgerRow)) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, r := range rows {
+		fn(r)
+		r.UpdatedAt = now
+	}
+	return l.Save()
+}
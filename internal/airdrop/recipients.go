@@ -0,0 +1,187 @@
+// Package airdrop distributes native coins or ERC-20 tokens to a list of
+// recipients from a CSV file, either one transfer per row or batched through
+// a Disperse contract, with a ledger that makes interrupted runs resumable.
+package airdrop
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/batch"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Recipient is one validated CSV row.
+type Recipient struct {
+	Line    int      // 1-based line number in the CSV
+	Input   string   // address or ENS name as written
+	Address string   // checksummed address
+	Amount  string   // amount as written (token units)
+	Wei     *big.Int // amount scaled by token decimals
+}
+
+// Key identifies a row in the ledger. It is the address alone (Validate allows
+// each address once), so reordering rows or editing an amount between runs
+// never re-pays anyone; Ledger.Check refuses a changed amount.
+func (r *Recipient) Key() string {
+	return strings.ToLower(r.Address)
+}
+
+// Problem is a validation error tied to a CSV line.
+type Problem struct {
+	Line    int
+	Input   string
+	Message string
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("line %d (%s): %s", p.Line, p.Input, p.Message)
+}
+
+// ResolveFunc resolves a name (e.g. an ENS name) to an address.
+type ResolveFunc func(name string) (string, error)
+
+// ReadCSV reads "address,amount" rows from path. A header row is skipped when
+// its second column is not a number. Blank lines and lines starting with # are ignored.
+func ReadCSV(path string) ([][2]string, []int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening CSV: %w", err)
+	}
+	defer f.Close()
+	return parseCSV(f)
+}
+
+func parseCSV(r io.Reader) ([][2]string, []int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	var rows [][2]string
+	var lines []int
+	for i := 0; ; i++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		if len(rec) < 2 {
+			return nil, nil, fmt.Errorf("line %d: expected address,amount", line)
+		}
+		addr, amt := strings.TrimSpace(rec[0]), strings.TrimSpace(rec[1])
+		if len(rows) == 0 && i == 0 && !looksNumeric(amt) {
+			continue // header
+		}
+		rows = append(rows, [2]string{addr, amt})
+		lines = append(lines, line)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("CSV has no recipient rows")
+	}
+	return rows, lines, nil
+}
+
+// Validate checks every row: addresses must be valid (and correctly
+// checksummed if mixed-case), names are resolved via resolve, amounts must be
+// positive with at most `decimals` places, and each address may appear once.
+func Validate(rows [][2]string, lines []int, decimals int, resolve ResolveFunc) ([]Recipient, []Problem) {
+	var out []Recipient
+	var problems []Problem
+	seen := make(map[string]int)
+
+	for i, row := range rows {
+		input, amount := row[0], row[1]
+		line := lines[i]
+
+		addr, msg := checkAddress(input, resolve)
+		if msg != "" {
+			problems = append(problems, Problem{Line: line, Input: input, Message: msg})
+			continue
+		}
+
+		wei, err := batch.ParseUnits(amount, decimals)
+		if err != nil {
+			problems = append(problems, Problem{Line: line, Input: input, Message: err.Error()})
+			continue
+		}
+		if wei.Sign() == 0 {
+			problems = append(problems, Problem{Line: line, Input: input, Message: "amount is zero"})
+			continue
+		}
+
+		lower := strings.ToLower(addr)
+		if prev, ok := seen[lower]; ok {
+			problems = append(problems, Problem{Line: line, Input: input, Message: fmt.Sprintf("duplicate recipient (also on line %d)", prev)})
+			continue
+		}
+		seen[lower] = line
+
+		out = append(out, Recipient{Line: line, Input: input, Address: addr, Amount: amount, Wei: wei})
+	}
+	return out, problems
+}
+
+func checkAddress(input string, resolve ResolveFunc) (string, string) {
+	if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+		if !common.IsHexAddress(input) {
+			return "", "not a valid address"
+		}
+		addr := common.HexToAddress(input)
+		if addr == (common.Address{}) {
+			return "", "zero address"
+		}
+		body := input[2:]
+		mixed := strings.ToLower(body) != body && strings.ToUpper(body) != body
+		if mixed && addr.Hex() != "0x"+body {
+			return "", "bad EIP-55 checksum (expected " + addr.Hex() + ")"
+		}
+		return addr.Hex(), ""
+	}
+
+	if !strings.Contains(input, ".") {
+		return "", "not an address or ENS name"
+	}
+	if resolve == nil {
+		return "", "ENS names need a resolver"
+	}
+	resolved, err := resolve(input)
+	if err != nil {
+		return "", "ENS: " + err.Error()
+	}
+	if !common.IsHexAddress(resolved) || common.HexToAddress(resolved) == (common.Address{}) {
+		return "", "ENS name has no address record"
+	}
+	return common.HexToAddress(resolved).Hex(), ""
+}
+
+// Total returns the sum of all amounts.
+func Total(rs []Recipient) *big.Int {
+	sum := new(big.Int)
+	for i := range rs {
+		sum.Add(sum, rs[i].Wei)
+	}
+	return sum
+}
+
+func looksNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && c != '.' {
+			return false
+		}
+	}
+	return true
+}
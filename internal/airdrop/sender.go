@@ -0,0 +1,472 @@
+package airdrop
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/nonce"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Send modes.
+const (
+	ModeSequential = "sequential" // one transfer per recipient
+	ModeDisperse   = "disperse"   // batches through a Disperse contract
+)
+
+// DefaultDisperse is the Disperse.app contract, deployed at the same address
+// on most EVM chains.
+const DefaultDisperse = "0xD152f549545093347A162Dce210e7293f1452150"
+
+// DefaultBatchSize is the number of recipients per disperse transaction.
+const DefaultBatchSize = 100
+
+// Function selectors.
+const (
+	selTransfer      = "a9059cbb" // transfer(address,uint256)
+	selDisperseEther = "e63d38ed" // disperseEther(address[],uint256[])
+	selDisperseToken = "c73a2d60" // disperseToken(address,address[],uint256[])
+)
+
+// Client is the subset of chain.EVMClient the airdrop needs.
+type Client interface {
+	GasPrice() (*big.Int, error)
+	EstimateGas(from, to, data string, value *big.Int) (uint64, error)
+	GetPendingNonce(address string) (uint64, error)
+	SendRawTransaction(rawTx string) (string, error)
+	GetTransactionReceipt(hash string) (*chain.TxReceipt, error)
+	WaitForReceipt(hash string, timeout time.Duration) (*chain.TxReceipt, error)
+}
+
+// Signer signs transactions for the sending wallet.
+type Signer interface {
+	Address() string
+	SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error)
+}
+
+// Progress reports the outcome of one transaction.
+type Progress struct {
+	Rows   []*LedgerRow
+	Status string
+	Hash   string
+	Detail string
+}
+
+// Airdropper pays a validated recipient list, recording every transaction in
+// the ledger before it is broadcast so that a re-run never pays a row twice.
+type Airdropper struct {
+	Recipients []Recipient
+	Ledger     *Ledger
+	Client     Client
+	Signer     Signer
+	ChainID    int64
+
+	Token     string // ERC-20 address; "" sends the native coin
+	Mode      string // ModeSequential (default) or ModeDisperse
+	Disperse  string // disperse contract (default DefaultDisperse)
+	BatchSize int    // recipients per disperse tx (default DefaultBatchSize)
+
+	Nonces         nonce.Allocator // optional
+	ConfirmTimeout time.Duration
+
+	// OnProgress is called after every transaction changes state (optional).
+	OnProgress func(Progress)
+}
+
+// group is the set of rows paid by one transaction.
+type group struct {
+	recipients []*Recipient
+	rows       []*LedgerRow
+}
+
+// Pending returns the recipients that still need to be paid: rows never sent
+// and rows whose transaction failed without spending funds.
+func (a *Airdropper) Pending() []Recipient {
+	var out []Recipient
+	for i := range a.Recipients {
+		row, ok := a.Ledger.Rows[a.Recipients[i].Key()]
+		if !ok || row.Status == StatusPending || row.Status == StatusFailed {
+			out = append(out, a.Recipients[i])
+		}
+	}
+	return out
+}
+
+// Run reconciles in-flight rows with the chain, pays every pending row with
+// locally sequenced nonces, then waits for all receipts.
+func (a *Airdropper) Run() error {
+	if err := a.Ledger.Check(a.Recipients); err != nil {
+		return err
+	}
+	for i := range a.Recipients {
+		a.Ledger.Row(&a.Recipients[i])
+	}
+	if err := a.Ledger.Save(); err != nil {
+		return fmt.Errorf("saving ledger: %w", err)
+	}
+
+	inflight, err := a.reconcile()
+	if err != nil {
+		return err
+	}
+
+	groups := a.groups()
+	var sendErr error
+	if len(groups) > 0 {
+		nonce, err := a.Client.GetPendingNonce(a.Signer.Address())
+		if err != nil {
+			return fmt.Errorf("fetching nonce: %w", err)
+		}
+		gasPrice, err := a.Client.GasPrice()
+		if err != nil {
+			return fmt.Errorf("fetching gas price: %w", err)
+		}
+
+		for _, g := range groups {
+			if a.Nonces != nil {
+				if nonce, err = a.Nonces.Next(); err != nil {
+					sendErr = err
+					break
+				}
+			}
+			hash, err := a.send(g, nonce, gasPrice)
+			if hash != "" {
+				inflight = append(inflight, hash)
+			}
+			if err != nil {
+				// Stop so later rows don't queue up behind a hole. Only a
+				// rejected tx (no hash) gives its nonce back.
+				if hash == "" && a.Nonces != nil {
+					a.Nonces.Release(nonce) //nolint:errcheck
+				}
+				sendErr = err
+				break
+			}
+			nonce++
+		}
+	}
+
+	waitErr := a.wait(inflight)
+	if sendErr != nil {
+		return sendErr
+	}
+	return waitErr
+}
+
+// reconcile resolves rows left in "sent" by an earlier run. Mined
+// transactions are settled from their receipt; unmined ones are re-broadcast
+// from the saved raw bytes. It returns the hashes that still need waiting on.
+func (a *Airdropper) reconcile() ([]string, error) {
+	byHash := make(map[string][]*LedgerRow)
+	var order []string
+	for i := range a.Recipients {
+		row := a.Ledger.Rows[a.Recipients[i].Key()]
+		if row.Status != StatusSent {
+			continue
+		}
+		if _, ok := byHash[row.Hash]; !ok {
+			order = append(order, row.Hash)
+		}
+		byHash[row.Hash] = append(byHash[row.Hash], row)
+	}
+
+	var inflight []string
+	for _, hash := range order {
+		rows := byHash[hash]
+		receipt, err := a.Client.GetTransactionReceipt(hash)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s: %w", hash, err)
+		}
+		if receipt != nil {
+			if err := a.settle(rows, hash, receipt, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		raw := rows[0].RawTx
+		if raw == "" {
+			if err := a.mark(rows, hash, StatusUnknown, "no receipt and no saved transaction"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := a.Client.SendRawTransaction(raw); err != nil && !chain.IsAlreadyKnown(err) {
+			switch {
+			case chain.IsNonceTooLow(err):
+				// Ours may have been mined since the receipt check above.
+				receipt, rerr := a.Client.GetTransactionReceipt(hash)
+				if rerr != nil {
+					return nil, fmt.Errorf("checking %s: %w", hash, rerr)
+				}
+				if receipt != nil {
+					if err := a.settle(rows, hash, receipt, nil); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				// The nonce went to some other transaction. Paying again
+				// could double-pay if ours was dropped and re-mined, so leave
+				// it for a human.
+				if err := a.mark(rows, hash, StatusUnknown, "nonce reused by another transaction"); err != nil {
+					return nil, err
+				}
+			case chain.IsRejected(err):
+				// The node refuses the tx and has no receipt for it: nothing
+				// was paid.
+				if err := a.mark(rows, hash, StatusFailed, err.Error()); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("re-broadcasting %s: %w", hash, err)
+			}
+			continue
+		}
+		inflight = append(inflight, hash)
+	}
+	return inflight, nil
+}
+
+// groups splits the pending rows into one group per transaction.
+func (a *Airdropper) groups() []group {
+	size := 1
+	if a.Mode == ModeDisperse {
+		size = a.BatchSize
+		if size <= 0 {
+			size = DefaultBatchSize
+		}
+	}
+
+	var out []group
+	var cur group
+	for i := range a.Recipients {
+		r := &a.Recipients[i]
+		row := a.Ledger.Rows[r.Key()]
+		if row.Status != StatusPending && row.Status != StatusFailed {
+			continue
+		}
+		cur.recipients = append(cur.recipients, r)
+		cur.rows = append(cur.rows, row)
+		if len(cur.rows) == size {
+			out = append(out, cur)
+			cur = group{}
+		}
+	}
+	if len(cur.rows) > 0 {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// send signs the group's transaction, records it in the ledger, then
+// broadcasts it. When the broadcast fails without a clear rejection the node
+// may still have the tx, so the rows stay "sent" and the hash is returned
+// along with the error.
+func (a *Airdropper) send(g group, nonce uint64, gasPrice *big.Int) (string, error) {
+	to, data, value, fallback := a.build(g.recipients)
+	from := a.Signer.Address()
+
+	dataHex := ""
+	if len(data) > 0 {
+		dataHex = "0x" + hex.EncodeToString(data)
+	}
+	gasLimit, err := a.Client.EstimateGas(from, to, dataHex, value)
+	if err != nil {
+		gasLimit = fallback
+	}
+
+	toAddr := common.HexToAddress(to)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(a.ChainID),
+		Nonce:     nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gasLimit,
+		To:        &toAddr,
+		Value:     value,
+		Data:      data,
+	})
+	raw, err := a.Signer.SignTx(tx, big.NewInt(a.ChainID))
+	if err != nil {
+		return "", fmt.Errorf("signing: %w", err)
+	}
+	hash := crypto.Keccak256Hash(raw).Hex()
+	rawHex := "0x" + hex.EncodeToString(raw)
+
+	if err := a.Ledger.touch(g.rows, func(r *LedgerRow) {
+		r.Status = StatusSent
+		r.Hash = hash
+		r.Nonce = nonce
+		r.RawTx = rawHex
+		r.Error = ""
+	}); err != nil {
+		return "", fmt.Errorf("saving ledger: %w", err)
+	}
+
+	if _, err := a.Client.SendRawTransaction(rawHex); err != nil && !chain.IsAlreadyKnown(err) {
+		if !chain.IsRejected(err) {
+			a.progress(g.rows, StatusSent, hash, err.Error())
+			return hash, fmt.Errorf("broadcasting: %w", err)
+		}
+		a.Ledger.touch(g.rows, func(r *LedgerRow) { //nolint:errcheck
+			r.Status = StatusFailed
+			r.RawTx = ""
+			r.Error = err.Error()
+		})
+		a.progress(g.rows, StatusFailed, hash, err.Error())
+		return "", fmt.Errorf("broadcasting: %w", err)
+	}
+	a.progress(g.rows, StatusSent, hash, "")
+	return hash, nil
+}
+
+// wait blocks on every in-flight hash and settles its rows.
+func (a *Airdropper) wait(hashes []string) error {
+	timeout := a.ConfirmTimeout
+	if timeout == 0 {
+		timeout = config.TxConfirmTimeout
+	}
+
+	pending := 0
+	for _, hash := range hashes {
+		rows := a.rowsByHash(hash)
+		receipt, err := a.Client.WaitForReceipt(hash, timeout)
+		if receipt == nil && err != nil {
+			// Not mined yet: keep the row "sent" so a re-run picks it up.
+			pending++
+			a.progress(rows, StatusSent, hash, err.Error())
+			continue
+		}
+		if err := a.settle(rows, hash, receipt, err); err != nil {
+			return err
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d transaction(s) not confirmed yet — re-run the same command to resume", pending)
+	}
+	return nil
+}
+
+// settle records a mined receipt against rows.
+func (a *Airdropper) settle(rows []*LedgerRow, hash string, receipt *chain.TxReceipt, waitErr error) error {
+	if receipt.Status == 1 && waitErr == nil {
+		if err := a.Ledger.touch(rows, func(r *LedgerRow) {
+			r.Status = StatusConfirmed
+			r.RawTx = ""
+			r.Error = ""
+		}); err != nil {
+			return fmt.Errorf("saving ledger: %w", err)
+		}
+		a.progress(rows, StatusConfirmed, hash, "")
+		return nil
+	}
+	// Reverted: nothing was paid, so the rows can be retried with a new tx.
+	return a.mark(rows, hash, StatusFailed, "transaction reverted")
+}
+
+func (a *Airdropper) mark(rows []*LedgerRow, hash, status, detail string) error {
+	if err := a.Ledger.touch(rows, func(r *LedgerRow) {
+		r.Status = status
+		r.RawTx = ""
+		r.Error = detail
+	}); err != nil {
+		return fmt.Errorf("saving ledger: %w", err)
+	}
+	a.progress(rows, status, hash, detail)
+	return nil
+}
+
+func (a *Airdropper) rowsByHash(hash string) []*LedgerRow {
+	var rows []*LedgerRow
+	for i := range a.Recipients {
+		if row := a.Ledger.Rows[a.Recipients[i].Key()]; row.Hash == hash {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (a *Airdropper) progress(rows []*LedgerRow, status, hash, detail string) {
+	if a.OnProgress != nil {
+		a.OnProgress(Progress{Rows: rows, Status: status, Hash: hash, Detail: detail})
+	}
+}
+
+// build returns the destination, calldata, value and fallback gas limit for
+// a transaction paying rs.
+func (a *Airdropper) build(rs []*Recipient) (string, []byte, *big.Int, uint64) {
+	if a.Mode == ModeDisperse {
+		contract := a.Disperse
+		if contract == "" {
+			contract = DefaultDisperse
+		}
+		addrs := make([]string, len(rs))
+		amounts := make([]*big.Int, len(rs))
+		sum := new(big.Int)
+		for i, r := range rs {
+			addrs[i] = r.Address
+			amounts[i] = r.Wei
+			sum.Add(sum, r.Wei)
+		}
+		fallback := config.GasLimitContractCall + uint64(len(rs))*config.GasLimitERC20Transfer
+		if a.Token == "" {
+			return contract, EncodeDisperseEther(addrs, amounts), sum, fallback
+		}
+		return contract, EncodeDisperseToken(a.Token, addrs, amounts), new(big.Int), fallback
+	}
+
+	r := rs[0]
+	if a.Token == "" {
+		return r.Address, nil, new(big.Int).Set(r.Wei), config.GasLimitETHTransfer
+	}
+	data := append(mustHex(selTransfer), word(common.HexToAddress(r.Address).Bytes())...)
+	data = append(data, word(r.Wei.Bytes())...)
+	return a.Token, data, new(big.Int), config.GasLimitERC20Transfer
+}
+
+// EncodeDisperseEther encodes disperseEther(address[],uint256[]).
+func EncodeDisperseEther(addrs []string, amounts []*big.Int) []byte {
+	out := mustHex(selDisperseEther)
+	out = append(out, word(big.NewInt(64).Bytes())...)
+	out = append(out, word(big.NewInt(int64(64+32*(1+len(addrs)))).Bytes())...)
+	return append(out, encodeArrays(addrs, amounts)...)
+}
+
+// EncodeDisperseToken encodes disperseToken(address,address[],uint256[]).
+func EncodeDisperseToken(token string, addrs []string, amounts []*big.Int) []byte {
+	out := mustHex(selDisperseToken)
+	out = append(out, word(common.HexToAddress(token).Bytes())...)
+	out = append(out, word(big.NewInt(96).Bytes())...)
+	out = append(out, word(big.NewInt(int64(96+32*(1+len(addrs)))).Bytes())...)
+	return append(out, encodeArrays(addrs, amounts)...)
+}
+
+// encodeArrays encodes the tails of an address[] followed by a uint256[].
+func encodeArrays(addrs []string, amounts []*big.Int) []byte {
+	var out []byte
+	out = append(out, word(big.NewInt(int64(len(addrs))).Bytes())...)
+	for _, a := range addrs {
+		out = append(out, word(common.HexToAddress(a).Bytes())...)
+	}
+	out = append(out, word(big.NewInt(int64(len(amounts))).Bytes())...)
+	for _, v := range amounts {
+		out = append(out, word(v.Bytes())...)
+	}
+	return out
+}
+
+func word(b []byte) []byte { return common.LeftPadBytes(b, 32) }
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,274 @@
+// Package batch runs an ordered plan of transactions (sends, token transfers,
+// contract calls and deploys) with variables, a dry-run, sequential nonces and
+// resumable progress.
+package batch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Step actions.
+const (
+	ActionSend     = "send"
+	ActionTransfer = "transfer"
+	ActionCall     = "call"
+	ActionDeploy   = "deploy"
+)
+
+// Plan is the parsed content of a plan file.
+//
+//	network: base
+//	wallet: deployer
+//	vars:
+//	  treasury: "0xabc..."
+//	steps:
+//	  - id: token
+//	    action: deploy
+//	    name: MyToken
+//	    artifact: ./out/Token.sol/Token.json
+//	    args: ["My Token", "MTK", "18", "1000000000000000000000000"]
+//	  - id: seed
+//	    action: transfer
+//	    token: ${steps.token.address}
+//	    to: ${vars.treasury}
+//	    amount: "1000"
+type Plan struct {
+	Network string            `yaml:"network" json:"network"`
+	Wallet  string            `yaml:"wallet" json:"wallet"`
+	Vars    map[string]string `yaml:"vars" json:"vars"`
+	Steps   []Step            `yaml:"steps" json:"steps"`
+
+	// Hash is the sha256 of the raw plan file, used to detect edits between
+	// a failed run and its resume.
+	Hash string `yaml:"-" json:"-"`
+}
+
+// Step is a single transaction in a plan. Which fields apply depends on Action.
+type Step struct {
+	ID     string `yaml:"id" json:"id"`
+	Action string `yaml:"action" json:"action"`
+
+	// send / transfer
+	To     string `yaml:"to" json:"to"`
+	Value  string `yaml:"value" json:"value"` // native units, e.g. "0.1" (send, payable call/deploy)
+	Token  string `yaml:"token" json:"token"`
+	Amount string `yaml:"amount" json:"amount"` // token units, scaled by decimals()
+
+	// call
+	Contract string   `yaml:"contract" json:"contract"` // registered name or 0x address
+	Function string   `yaml:"function" json:"function"` // name, or full signature for unregistered addresses
+	Args     []string `yaml:"args" json:"args"`
+
+	// deploy
+	Name     string `yaml:"name" json:"name"` // registry name (default: id)
+	Artifact string `yaml:"artifact" json:"artifact"`
+
+	Gas uint64 `yaml:"gas" json:"gas"` // optional gas limit override
+}
+
+// LoadPlan reads and validates a plan file (YAML or JSON).
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	return ParsePlan(data)
+}
+
+// ParsePlan parses and validates plan content.
+func ParsePlan(data []byte) (*Plan, error) {
+	var p Plan
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	p.Hash = hex.EncodeToString(sum[:])
+
+	if len(p.Steps) == 0 {
+		return nil, fmt.Errorf("plan has no steps")
+	}
+
+	ids := make(map[string]bool)
+	for i := range p.Steps {
+		s := &p.Steps[i]
+		if s.ID == "" {
+			s.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if ids[s.ID] {
+			return nil, fmt.Errorf("duplicate step id %q", s.ID)
+		}
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("step %q: %w", s.ID, err)
+		}
+		// References may only point at earlier steps.
+		for _, ref := range stepRefs(s) {
+			if !ids[ref] {
+				return nil, fmt.Errorf("step %q references step %q, which does not run before it", s.ID, ref)
+			}
+		}
+		ids[s.ID] = true
+	}
+	return &p, nil
+}
+
+func (s *Step) validate() error {
+	s.Action = strings.ToLower(s.Action)
+	switch s.Action {
+	case ActionSend:
+		if s.To == "" || s.Value == "" {
+			return fmt.Errorf("send needs to and value")
+		}
+	case ActionTransfer:
+		if s.Token == "" || s.To == "" || s.Amount == "" {
+			return fmt.Errorf("transfer needs token, to and amount")
+		}
+	case ActionCall:
+		if s.Contract == "" || s.Function == "" {
+			return fmt.Errorf("call needs contract and function")
+		}
+	case ActionDeploy:
+		if s.Artifact == "" {
+			return fmt.Errorf("deploy needs an artifact path")
+		}
+		if s.Name == "" {
+			s.Name = s.ID
+		}
+	case "":
+		return fmt.Errorf("missing action (send, transfer, call or deploy)")
+	default:
+		return fmt.Errorf("unknown action %q (use send, transfer, call or deploy)", s.Action)
+	}
+	return nil
+}
+
+// fields returns pointers to every string field that may contain variables.
+func (s *Step) fields() []*string {
+	out := []*string{&s.To, &s.Value, &s.Token, &s.Amount, &s.Contract, &s.Function, &s.Artifact, &s.Name}
+	for i := range s.Args {
+		out = append(out, &s.Args[i])
+	}
+	return out
+}
+
+var varPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// stepRefs returns the ids of steps referenced via ${steps.<id>.<key>}.
+func stepRefs(s *Step) []string {
+	var refs []string
+	for _, f := range s.fields() {
+		for _, m := range varPattern.FindAllStringSubmatch(*f, -1) {
+			parts := strings.Split(strings.TrimSpace(m[1]), ".")
+			if len(parts) == 3 && parts[0] == "steps" {
+				refs = append(refs, parts[1])
+			}
+		}
+	}
+	return refs
+}
+
+// Scope resolves ${...} references while a plan runs.
+type Scope struct {
+	Vars    map[string]string
+	Outputs map[string]map[string]string // step id → output key → value
+	Wallet  string
+	Network string
+}
+
+// Interpolate expands every ${...} reference in s. Supported forms:
+//
+//	${vars.NAME}  ${steps.ID.KEY}  ${env.NAME}  ${wallet}  ${network}
+func (sc *Scope) Interpolate(s string) (string, error) {
+	var firstErr error
+	out := varPattern.ReplaceAllStringFunc(s, func(m string) string {
+		ref := strings.TrimSpace(m[2 : len(m)-1])
+		v, err := sc.lookup(ref)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return v
+	})
+	return out, firstErr
+}
+
+func (sc *Scope) lookup(ref string) (string, error) {
+	switch ref {
+	case "wallet":
+		return sc.Wallet, nil
+	case "network":
+		return sc.Network, nil
+	}
+	parts := strings.Split(ref, ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "vars":
+		if v, ok := sc.Vars[parts[1]]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("undefined variable %q", parts[1])
+	case len(parts) == 2 && parts[0] == "env":
+		if v, ok := os.LookupEnv(parts[1]); ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("environment variable %s is not set", parts[1])
+	case len(parts) == 3 && parts[0] == "steps":
+		outs, ok := sc.Outputs[parts[1]]
+		if !ok {
+			return "", fmt.Errorf("step %q has no outputs yet", parts[1])
+		}
+		if v, ok := outs[parts[2]]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("step %q has no output %q", parts[1], parts[2])
+	}
+	return "", fmt.Errorf("unknown reference ${%s}", ref)
+}
+
+// resolveStep returns a copy of s with all references expanded.
+func (sc *Scope) resolveStep(s Step) (Step, error) {
+	s.Args = append([]string(nil), s.Args...)
+	for _, f := range s.fields() {
+		v, err := sc.Interpolate(*f)
+		if err != nil {
+			return s, err
+		}
+		*f = v
+	}
+	return s, nil
+}
+
+// ParseUnits converts a decimal amount string into an integer scaled by
+// 10^decimals using exact decimal arithmetic ("1.5", 6 → 1500000).
+func ParseUnits(amount string, decimals int) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" || strings.HasPrefix(amount, "-") {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	parts := strings.SplitN(amount, ".", 2)
+	whole, frac := parts[0], ""
+	if len(parts) == 2 {
+		frac = parts[1]
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(frac) > decimals {
+		if strings.Trim(frac[decimals:], "0") != "" {
+			return nil, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+		}
+		frac = frac[:decimals]
+	}
+	frac += strings.Repeat("0", decimals-len(frac))
+
+	n, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	return n, nil
+}
@@ -0,0 +1,114 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// ParsePlan
+// ---------------------------------------------------------------------------
+
+func TestParsePlanValid(t *testing.T) {
+	data := `
+network: base
+wallet: deployer
+vars:
+  treasury: "0x1111111111111111111111111111111111111111"
+steps:
+  - id: token
+    action: deploy
+    artifact: ./Token.json
+    args: ["1000"]
+  - action: send
+    to: ${vars.treasury}
+    value: "0.01"
+  - id: seed
+    action: transfer
+    token: ${steps.token.address}
+    to: ${vars.treasury}
+    amount: "5"
+`
+	p, err := ParsePlan([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, "base", p.Network)
+	assert.Equal(t, "deployer", p.Wallet)
+	require.Len(t, p.Steps, 3)
+	assert.Equal(t, "token", p.Steps[0].Name, "deploy name defaults to id")
+	assert.Equal(t, "step2", p.Steps[1].ID, "missing id defaults to position")
+	assert.Len(t, p.Hash, 64)
+}
+
+func TestParsePlanErrors(t *testing.T) {
+	cases := map[string]string{
+		"no steps":       `steps: []`,
+		"no action":      `steps: [{id: a, to: "0x1"}]`,
+		"bad action":     `steps: [{id: a, action: swap}]`,
+		"send missing":   `steps: [{id: a, action: send, to: "0x1"}]`,
+		"transfer miss":  `steps: [{id: a, action: transfer, token: "0x1", to: "0x2"}]`,
+		"call missing":   `steps: [{id: a, action: call, contract: Foo}]`,
+		"deploy missing": `steps: [{id: a, action: deploy}]`,
+		"duplicate id":   `steps: [{id: a, action: send, to: "0x1", value: "1"}, {id: a, action: send, to: "0x1", value: "1"}]`,
+		"forward ref":    `steps: [{id: a, action: send, to: "${steps.b.address}", value: "1"}, {id: b, action: deploy, artifact: x.json}]`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePlan([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Scope.Interpolate
+// ---------------------------------------------------------------------------
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("W3CLI_BATCH_TEST", "fromenv")
+	sc := &Scope{
+		Vars:    map[string]string{"who": "alice"},
+		Outputs: map[string]map[string]string{"dep": {"address": "0xabc"}},
+		Wallet:  "0xme",
+		Network: "base",
+	}
+
+	out, err := sc.Interpolate("${vars.who} ${steps.dep.address} ${env.W3CLI_BATCH_TEST} ${wallet}@${network}")
+	require.NoError(t, err)
+	assert.Equal(t, "alice 0xabc fromenv 0xme@base", out)
+
+	for _, bad := range []string{"${vars.nope}", "${steps.dep.hash}", "${steps.x.address}", "${env.W3CLI_UNSET_VAR_XYZ}", "${what}"} {
+		_, err := sc.Interpolate(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ParseUnits
+// ---------------------------------------------------------------------------
+
+func TestParseUnits(t *testing.T) {
+	cases := []struct {
+		in       string
+		decimals int
+		want     string
+	}{
+		{"1", 18, "1000000000000000000"},
+		{"0.5", 18, "500000000000000000"},
+		{"1.5", 6, "1500000"},
+		{".25", 2, "25"},
+		{"1.500", 2, "150"},
+		{"42", 0, "42"},
+	}
+	for _, c := range cases {
+		got, err := ParseUnits(c.in, c.decimals)
+		require.NoError(t, err, c.in)
+		assert.Equal(t, c.want, got.String(), c.in)
+	}
+
+	for _, bad := range []string{"", "-1", "abc", "1.234"} {
+		_, err := ParseUnits(bad, 2)
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,633 @@
+package batch
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/Mohsinsiddi/w3cli/internal/nonce"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Client is the subset of chain.EVMClient the runner needs.
+type Client interface {
+	GasPrice() (*big.Int, error)
+	EstimateGas(from, to, data string, value *big.Int) (uint64, error)
+	GetPendingNonce(address string) (uint64, error)
+	SimulateCall(from, to, data string, value *big.Int) (bool, string, error)
+	CallContract(to, calldata string) (string, error)
+	SendRawTransaction(rawTx string) (string, error)
+	GetTransactionReceipt(hash string) (*chain.TxReceipt, error)
+	WaitForReceipt(hash string, timeout time.Duration) (*chain.TxReceipt, error)
+}
+
+// Signer signs transactions for the plan's sending wallet.
+type Signer interface {
+	Address() string
+	SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error)
+}
+
+// StepResult is the outcome of one step in a dry-run or execution.
+type StepResult struct {
+	ID      string
+	Action  string
+	Status  string
+	Target  string
+	Hash    string
+	Nonce   uint64
+	GasUsed uint64
+	Outputs map[string]string
+	Detail  string // revert reason, error, or note
+}
+
+// Runner executes a plan against one chain with one signing wallet.
+type Runner struct {
+	Plan      *Plan
+	Network   string
+	ChainID   int64
+	Client    Client
+	Signer    Signer
+	Contracts *contract.Registry // registered contracts for call-by-name; deploys are added here
+	State     *State
+	BaseDir   string          // artifact paths are relative to the plan file
+	Nonces    nonce.Allocator // optional
+
+	// OnStep is called after every step completes (optional).
+	OnStep func(StepResult)
+
+	ConfirmTimeout time.Duration
+	DeployTimeout  time.Duration
+}
+
+// prepared is a fully-resolved transaction for a step.
+type prepared struct {
+	to          string // "" for contract creation
+	data        []byte
+	value       *big.Int
+	gasFallback uint64
+	abi         []contract.ABIEntry // deploy only
+	artifact    string              // deploy only
+}
+
+func (p *prepared) dataHex() string {
+	if len(p.data) == 0 {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(p.data)
+}
+
+func (r *Runner) scope() *Scope {
+	return &Scope{
+		Vars:    r.Plan.Vars,
+		Outputs: make(map[string]map[string]string),
+		Wallet:  r.Signer.Address(),
+		Network: r.Network,
+	}
+}
+
+// Simulate dry-runs every pending step via eth_call from the signing wallet.
+// Deploy addresses are predicted from the sender's nonce so later steps can
+// reference them; steps that target a contract deployed earlier in the same
+// plan are reported as skipped, since the contract does not exist yet.
+func (r *Runner) Simulate() ([]StepResult, error) {
+	from := r.Signer.Address()
+	nonce, err := r.Client.GetPendingNonce(from)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce: %w", err)
+	}
+
+	sc := r.scope()
+	predicted := make(map[string]string) // lowercase address → step id
+	planDeploys := make(map[string]*contract.Entry)
+
+	var results []StepResult
+	for _, step := range r.Plan.Steps {
+		res := StepResult{ID: step.ID, Action: step.Action}
+
+		if st := r.doneState(step.ID); st != nil {
+			sc.Outputs[step.ID] = st.Outputs
+			res.Status = StatusConfirmed
+			res.Hash = st.Hash
+			res.Detail = "already confirmed"
+			results = append(results, res)
+			continue
+		}
+
+		rs, err := sc.resolveStep(step)
+		if err != nil {
+			return results, fmt.Errorf("step %q: %w", step.ID, err)
+		}
+		p, err := r.prepare(rs, planDeploys, true)
+		if err != nil {
+			return results, fmt.Errorf("step %q: %w", step.ID, err)
+		}
+		res.Target = p.to
+		res.Nonce = nonce
+
+		outputs := map[string]string{"hash": "(pending)", "nonce": fmt.Sprintf("%d", nonce)}
+		if rs.Action == ActionDeploy {
+			addr := crypto.CreateAddress(common.HexToAddress(from), nonce).Hex()
+			outputs["address"] = addr
+			predicted[strings.ToLower(addr)] = step.ID
+			planDeploys[rs.Name] = &contract.Entry{Name: rs.Name, Network: r.Network, Address: addr, ABI: p.abi}
+			res.Target = addr
+		} else {
+			outputs["to"] = p.to
+		}
+		sc.Outputs[step.ID] = outputs
+
+		if dep, ok := predicted[strings.ToLower(p.to)]; ok && p.to != "" {
+			res.Status = StatusSkipped
+			res.Detail = fmt.Sprintf("target is deployed by step %q", dep)
+		} else {
+			ok, ret, err := r.Client.SimulateCall(from, p.to, p.dataHex(), p.value)
+			switch {
+			case err != nil:
+				res.Status = StatusFailed
+				res.Detail = err.Error()
+			case !ok:
+				res.Status = StatusFailed
+				res.Detail = ret
+			default:
+				res.Status = "ok"
+			}
+		}
+
+		res.Outputs = outputs
+		results = append(results, res)
+		nonce++
+	}
+	return results, nil
+}
+
+// Execute runs every step in order, waiting for each receipt before moving on.
+// Progress is written to the state file after each transition, so a failed or
+// interrupted run resumes from the first unconfirmed step.
+func (r *Runner) Execute() ([]StepResult, error) {
+	from := r.Signer.Address()
+	if err := r.State.CheckCompatible(r.Plan.Hash, r.Network, from); err != nil {
+		return nil, err
+	}
+	r.State.PlanHash = r.Plan.Hash
+	r.State.Network = r.Network
+	r.State.From = from
+
+	nonce, err := r.Client.GetPendingNonce(from)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce: %w", err)
+	}
+
+	sc := r.scope()
+	planDeploys := make(map[string]*contract.Entry)
+
+	var results []StepResult
+	for _, step := range r.Plan.Steps {
+		res, err := r.executeStep(step, sc, planDeploys, &nonce)
+		results = append(results, res)
+		if r.OnStep != nil {
+			r.OnStep(res)
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func (r *Runner) executeStep(step Step, sc *Scope, planDeploys map[string]*contract.Entry, nonce *uint64) (StepResult, error) {
+	res := StepResult{ID: step.ID, Action: step.Action}
+
+	st := r.State.step(step.ID)
+	if st.Status == StatusConfirmed {
+		sc.Outputs[step.ID] = st.Outputs
+		if step.Action == ActionDeploy {
+			r.rememberDeploy(step, st.Outputs["address"], planDeploys)
+		}
+		res.Status = StatusConfirmed
+		res.Hash = st.Hash
+		res.Outputs = st.Outputs
+		res.Detail = "already confirmed"
+		return res, nil
+	}
+
+	rs, err := sc.resolveStep(step)
+	if err != nil {
+		return r.fail(res, step.ID, err)
+	}
+	p, err := r.prepare(rs, planDeploys, false)
+	if err != nil {
+		return r.fail(res, step.ID, err)
+	}
+	res.Target = p.to
+
+	hash := st.Hash
+	if st.Status == StatusSent && st.RawTx != "" {
+		// Interrupted after signing: replay the exact same tx. A node that
+		// already has it answers "already known", which is fine.
+		if _, err := r.Client.SendRawTransaction(st.RawTx); err != nil && !chain.IsAlreadyKnown(err) {
+			switch {
+			case chain.IsNonceTooLow(err):
+				// The nonce is spent. That is only our tx if it has a receipt.
+				receipt, rerr := r.Client.GetTransactionReceipt(hash)
+				if rerr != nil {
+					return r.fail(res, step.ID, fmt.Errorf("checking %s: %w", hash, rerr))
+				}
+				if receipt == nil {
+					return r.fail(res, step.ID, fmt.Errorf("nonce %d was used by another transaction and %s has no receipt — check the account before re-running", st.Nonce, hash))
+				}
+			case chain.IsRejected(err):
+				// The node refuses it, so the step never ran: sign anew.
+				r.State.update(step.ID, func(s *StepState) { s.RawTx = "" }) //nolint:errcheck
+				return r.fail(res, step.ID, fmt.Errorf("re-broadcasting: %w", err))
+			default:
+				return r.fail(res, step.ID, fmt.Errorf("re-broadcasting: %w", err))
+			}
+		}
+		res.Nonce = st.Nonce
+		if st.Nonce >= *nonce {
+			*nonce = st.Nonce + 1
+		}
+	} else {
+		if r.Nonces != nil {
+			n, err := r.Nonces.Next()
+			if err != nil {
+				return r.fail(res, step.ID, err)
+			}
+			*nonce = n
+		}
+		raw, txHash, err := r.sign(rs, p, *nonce)
+		if err != nil {
+			r.releaseNonce(*nonce)
+			return r.fail(res, step.ID, err)
+		}
+		res.Nonce = *nonce
+		hash = txHash
+		if err := r.State.update(step.ID, func(s *StepState) {
+			s.Status = StatusSent
+			s.Hash = txHash
+			s.Nonce = res.Nonce
+			s.RawTx = raw
+			s.Error = ""
+		}); err != nil {
+			return res, fmt.Errorf("saving state: %w", err)
+		}
+		if _, err := r.Client.SendRawTransaction(raw); err != nil && !chain.IsAlreadyKnown(err) {
+			if !chain.IsRejected(err) {
+				// The node may have the tx anyway. Keep it signed so a
+				// resume replays it instead of running the step twice.
+				res.Hash = hash
+				*nonce++
+				return r.fail(res, step.ID, fmt.Errorf("broadcasting: %w", err))
+			}
+			r.State.update(step.ID, func(s *StepState) { s.RawTx = "" }) //nolint:errcheck
+			r.releaseNonce(*nonce)
+			return r.fail(res, step.ID, fmt.Errorf("broadcasting: %w", err))
+		}
+		*nonce++
+	}
+	res.Hash = hash
+
+	timeout := r.ConfirmTimeout
+	if rs.Action == ActionDeploy {
+		timeout = r.DeployTimeout
+	}
+	if timeout == 0 {
+		timeout = config.TxConfirmTimeout
+	}
+	receipt, err := r.Client.WaitForReceipt(hash, timeout)
+	if err != nil {
+		if receipt != nil {
+			// Mined but reverted: the nonce is spent, so a resume must sign anew.
+			r.State.update(step.ID, func(s *StepState) { s.RawTx = "" }) //nolint:errcheck
+		}
+		return r.fail(res, step.ID, err)
+	}
+
+	outputs := map[string]string{
+		"hash":     hash,
+		"nonce":    fmt.Sprintf("%d", res.Nonce),
+		"block":    fmt.Sprintf("%d", receipt.BlockNumber),
+		"gas_used": fmt.Sprintf("%d", receipt.GasUsed),
+	}
+	if rs.Action == ActionDeploy {
+		outputs["address"] = receipt.ContractAddress
+		res.Target = receipt.ContractAddress
+		entry := r.rememberDeploy(rs, receipt.ContractAddress, planDeploys)
+		entry.ABI = p.abi
+		entry.ABISource = p.artifact
+		entry.TxHash = hash
+		if r.Contracts != nil {
+			r.Contracts.Add(entry)
+			if err := r.Contracts.Save(); err != nil {
+				res.Detail = "registry not saved: " + err.Error()
+			}
+		}
+	} else {
+		outputs["to"] = p.to
+	}
+	sc.Outputs[step.ID] = outputs
+
+	res.Status = StatusConfirmed
+	res.GasUsed = receipt.GasUsed
+	res.Outputs = outputs
+	if err := r.State.update(step.ID, func(s *StepState) {
+		s.Status = StatusConfirmed
+		s.Outputs = outputs
+		s.RawTx = ""
+		s.Error = ""
+	}); err != nil {
+		return res, fmt.Errorf("saving state: %w", err)
+	}
+	return res, nil
+}
+
+func (r *Runner) fail(res StepResult, id string, err error) (StepResult, error) {
+	res.Status = StatusFailed
+	res.Detail = err.Error()
+	r.State.update(id, func(s *StepState) { //nolint:errcheck
+		if s.Status != StatusSent || s.RawTx == "" {
+			s.Status = StatusFailed
+		}
+		s.Error = err.Error()
+	})
+	return res, fmt.Errorf("step %q: %w", id, err)
+}
+
+func (r *Runner) doneState(id string) *StepState {
+	if r.State == nil {
+		return nil
+	}
+	if st, ok := r.State.Steps[id]; ok && st.Status == StatusConfirmed {
+		return st
+	}
+	return nil
+}
+
+func (r *Runner) rememberDeploy(step Step, addr string, planDeploys map[string]*contract.Entry) *contract.Entry {
+	e := &contract.Entry{
+		Name:       step.Name,
+		Network:    r.Network,
+		Address:    addr,
+		Kind:       "deployed",
+		Deployer:   r.Signer.Address(),
+		DeployedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if step.Name == "" {
+		e.Name = step.ID
+	}
+	if prev, ok := planDeploys[e.Name]; ok && len(prev.ABI) > 0 {
+		e.ABI = prev.ABI
+	} else {
+		if art, err := contract.LoadArtifactFull(r.artifactPath(step.Artifact)); err == nil {
+			e.ABI = art.ABI
+		}
+	}
+	planDeploys[e.Name] = e
+	return e
+}
+
+// sign builds and signs the EIP-1559 transaction for a prepared step.
+// The hash is returned alongside the raw tx so it can be recorded before broadcast.
+func (r *Runner) sign(step Step, p *prepared, nonce uint64) (rawHex, hash string, err error) {
+	from := r.Signer.Address()
+
+	gas := step.Gas
+	if gas == 0 {
+		gas, err = r.Client.EstimateGas(from, p.to, p.dataHex(), p.value)
+		if err != nil {
+			gas = p.gasFallback
+		}
+	}
+
+	gasPrice, err := r.Client.GasPrice()
+	if err != nil {
+		return "", "", fmt.Errorf("getting gas price: %w", err)
+	}
+
+	inner := &types.DynamicFeeTx{
+		ChainID:   big.NewInt(r.ChainID),
+		Nonce:     nonce,
+		GasTipCap: gasPrice,
+		GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+		Gas:       gas,
+		Value:     p.value,
+		Data:      p.data,
+	}
+	if p.to != "" {
+		to := common.HexToAddress(p.to)
+		inner.To = &to
+	}
+
+	raw, err := r.Signer.SignTx(types.NewTx(inner), big.NewInt(r.ChainID))
+	if err != nil {
+		return "", "", fmt.Errorf("signing: %w", err)
+	}
+	return "0x" + hex.EncodeToString(raw), crypto.Keccak256Hash(raw).Hex(), nil
+}
+
+// prepare turns a resolved step into to/data/value.
+func (r *Runner) prepare(s Step, planDeploys map[string]*contract.Entry, dryRun bool) (*prepared, error) {
+	p := &prepared{value: big.NewInt(0)}
+	if s.Value != "" && s.Action != ActionTransfer {
+		v, err := ParseUnits(s.Value, 18)
+		if err != nil {
+			return nil, fmt.Errorf("value: %w", err)
+		}
+		p.value = v
+	}
+
+	switch s.Action {
+	case ActionSend:
+		if !common.IsHexAddress(s.To) {
+			return nil, fmt.Errorf("invalid recipient %q", s.To)
+		}
+		p.to = s.To
+		p.gasFallback = config.GasLimitETHTransfer
+
+	case ActionTransfer:
+		if !common.IsHexAddress(s.Token) {
+			return nil, fmt.Errorf("invalid token address %q", s.Token)
+		}
+		if !common.IsHexAddress(s.To) {
+			return nil, fmt.Errorf("invalid recipient %q", s.To)
+		}
+		decimals := 18
+		if !dryRun || !r.isPredicted(s.Token, planDeploys) {
+			d, err := r.tokenDecimals(s.Token)
+			if err != nil {
+				return nil, err
+			}
+			decimals = d
+		}
+		amount, err := ParseUnits(s.Amount, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("amount: %w", err)
+		}
+		data := []byte{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+		data = append(data, common.LeftPadBytes(common.HexToAddress(s.To).Bytes(), 32)...)
+		data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+		p.to = s.Token
+		p.data = data
+		p.gasFallback = config.GasLimitERC20Transfer
+
+	case ActionCall:
+		addr, abi, err := r.resolveContract(s.Contract, planDeploys)
+		if err != nil {
+			return nil, err
+		}
+		fn, err := findFunction(abi, s.Function, len(s.Args))
+		if err != nil {
+			return nil, err
+		}
+		args, err := contract.EncodeConstructorArgs(fn.Inputs, s.Args)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s args: %w", fn.Name, err)
+		}
+		sel, _ := hex.DecodeString(strings.TrimPrefix(fn.Selector(), "0x"))
+		p.to = addr
+		p.data = append(sel, args...)
+		p.gasFallback = config.GasLimitContractCall
+
+	case ActionDeploy:
+		path := r.artifactPath(s.Artifact)
+		art, err := contract.LoadArtifactFull(path)
+		if err != nil {
+			return nil, err
+		}
+		var args []byte
+		for _, e := range art.ABI {
+			if e.Type == "constructor" {
+				args, err = contract.EncodeConstructorArgs(e.Inputs, s.Args)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		p.data = append(append([]byte{}, art.Bytecode...), args...)
+		p.abi = art.ABI
+		p.artifact = path
+		p.gasFallback = config.GasLimitContractDeploy
+	}
+	return p, nil
+}
+
+func (r *Runner) artifactPath(p string) string {
+	if filepath.IsAbs(p) || r.BaseDir == "" {
+		return p
+	}
+	return filepath.Join(r.BaseDir, p)
+}
+
+func (r *Runner) isPredicted(addr string, planDeploys map[string]*contract.Entry) bool {
+	for _, e := range planDeploys {
+		if strings.EqualFold(e.Address, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveContract finds a call target: a contract deployed earlier in the
+// plan, a registered contract on this network, or a raw 0x address (in which
+// case the function must be given as a full signature).
+func (r *Runner) resolveContract(ref string, planDeploys map[string]*contract.Entry) (string, []contract.ABIEntry, error) {
+	if e, ok := planDeploys[ref]; ok {
+		return e.Address, e.ABI, nil
+	}
+	if common.IsHexAddress(ref) {
+		for _, e := range planDeploys {
+			if strings.EqualFold(e.Address, ref) {
+				return ref, e.ABI, nil
+			}
+		}
+		if r.Contracts != nil {
+			for _, e := range r.Contracts.All() {
+				if e.Network == r.Network && strings.EqualFold(e.Address, ref) {
+					return ref, e.ABI, nil
+				}
+			}
+		}
+		return ref, nil, nil
+	}
+	if r.Contracts != nil {
+		if e, err := r.Contracts.Get(ref, r.Network); err == nil {
+			return e.Address, e.ABI, nil
+		}
+	}
+	return "", nil, fmt.Errorf("contract %q is not registered on %s — run `w3cli contract add`", ref, r.Network)
+}
+
+// findFunction picks the ABI function by name and arity, or parses a full
+// signature like "mint(address,uint256)" when given one.
+func findFunction(abi []contract.ABIEntry, fn string, nArgs int) (*contract.ABIEntry, error) {
+	if strings.Contains(fn, "(") {
+		return parseSignature(fn)
+	}
+	var byName []*contract.ABIEntry
+	for i := range abi {
+		if abi[i].Type == "function" && abi[i].Name == fn {
+			byName = append(byName, &abi[i])
+		}
+	}
+	if len(byName) == 0 {
+		if abi == nil {
+			return nil, fmt.Errorf("no ABI for this address — give the function as a signature, e.g. %s(address,uint256)", fn)
+		}
+		return nil, fmt.Errorf("function %q not found in ABI", fn)
+	}
+	for _, e := range byName {
+		if len(e.Inputs) == nArgs {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s takes %d args, got %d", fn, len(byName[0].Inputs), nArgs)
+}
+
+func parseSignature(sig string) (*contract.ABIEntry, error) {
+	open := strings.Index(sig, "(")
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return nil, fmt.Errorf("invalid function signature %q", sig)
+	}
+	e := &contract.ABIEntry{Name: strings.TrimSpace(sig[:open]), Type: "function", StateMutability: "nonpayable"}
+	inner := strings.TrimSpace(sig[open+1 : len(sig)-1])
+	if inner != "" {
+		for _, t := range strings.Split(inner, ",") {
+			fields := strings.Fields(t)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("invalid function signature %q", sig)
+			}
+			e.Inputs = append(e.Inputs, contract.ABIParam{Type: fields[0]})
+		}
+	}
+	return e, nil
+}
+
+// tokenDecimals reads decimals() from token. A guess would scale the amount
+// wrongly, so failures fail the step before anything is signed.
+func (r *Runner) tokenDecimals(token string) (int, error) {
+	raw, err := r.Client.CallContract(token, "0x313ce567")
+	if err != nil {
+		return 0, fmt.Errorf("reading decimals() of %s: %w", token, err)
+	}
+	d, ok := new(big.Int).SetString(strings.TrimPrefix(raw, "0x"), 16)
+	if len(raw) < 66 || !ok || !d.IsInt64() || d.Int64() > 77 {
+		return 0, fmt.Errorf("%s returned no valid decimals() — is it an ERC-20?", token)
+	}
+	return int(d.Int64()), nil
+}
+
+// releaseNonce returns an unbroadcast nonce to the shared source.
+func (r *Runner) releaseNonce(n uint64) {
+	if r.Nonces != nil {
+		r.Nonces.Release(n) //nolint:errcheck
+	}
+}
@@ -0,0 +1,479 @@
+package batch
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const recipient = "0x1111111111111111111111111111111111111111"
+
+type keySigner struct{ key *ecdsa.PrivateKey }
+
+func newKeySigner(t *testing.T) *keySigner {
+	t.Helper()
+	k, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &keySigner{key: k}
+}
+
+func (s *keySigner) Address() string { return crypto.PubkeyToAddress(s.key.PublicKey).Hex() }
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	signed, err := types.SignTx(tx, types.NewLondonSigner(chainID), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+// fakeChain records broadcasts and mines them instantly.
+type fakeChain struct {
+	nonce     uint64
+	sent      []*types.Transaction
+	simulated []string // "to|data"
+	revertOn  string   // simulate/receipt revert when calldata has this prefix
+	failSend  int      // fail the Nth broadcast (1-based)
+	decimals  int
+	noERC20   bool // decimals() returns nothing
+
+	// The Nth broadcast (1-based) times out. The node keeps the tx unless
+	// dropTimedOut is set.
+	timeoutSend  int
+	dropTimedOut bool
+}
+
+func (f *fakeChain) GasPrice() (*big.Int, error) { return big.NewInt(1_000_000_000), nil }
+func (f *fakeChain) EstimateGas(string, string, string, *big.Int) (uint64, error) {
+	return 50_000, nil
+}
+func (f *fakeChain) GetPendingNonce(string) (uint64, error) { return f.nonce, nil }
+
+func (f *fakeChain) SimulateCall(_, to, data string, _ *big.Int) (bool, string, error) {
+	f.simulated = append(f.simulated, to+"|"+data)
+	if f.revertOn != "" && strings.HasPrefix(data, f.revertOn) {
+		return false, "execution reverted: nope", nil
+	}
+	return true, "0x", nil
+}
+
+func (f *fakeChain) CallContract(string, string) (string, error) {
+	if f.noERC20 {
+		return "0x", nil
+	}
+	return fmt.Sprintf("0x%064x", f.decimals), nil
+}
+
+func (f *fakeChain) SendRawTransaction(raw string) (string, error) {
+	if f.failSend > 0 && len(f.sent)+1 == f.failSend {
+		f.failSend = 0
+		return "", &chain.RPCError{Code: -32000, Message: "insufficient funds"}
+	}
+	b, _ := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return "", err
+	}
+	for _, s := range f.sent {
+		if s.Hash() == tx.Hash() {
+			return "", errors.New("already known")
+		}
+	}
+	if tx.Nonce() < f.nonce {
+		return "", &chain.RPCError{Code: -32000, Message: "nonce too low"}
+	}
+	timedOut := f.timeoutSend > 0 && len(f.sent)+1 == f.timeoutSend
+	if timedOut {
+		f.timeoutSend = 0
+		if f.dropTimedOut {
+			return "", errors.New("RPC request failed: context deadline exceeded")
+		}
+	}
+	f.sent = append(f.sent, tx)
+	f.nonce = tx.Nonce() + 1
+	if timedOut {
+		return "", errors.New("RPC request failed: context deadline exceeded")
+	}
+	return tx.Hash().Hex(), nil
+}
+
+func (f *fakeChain) GetTransactionReceipt(hash string) (*chain.TxReceipt, error) {
+	for i, tx := range f.sent {
+		if tx.Hash().Hex() != hash {
+			continue
+		}
+		r := &chain.TxReceipt{Hash: hash, Status: 1, BlockNumber: uint64(100 + i), GasUsed: 21000}
+		if tx.To() == nil {
+			r.ContractAddress = fmt.Sprintf("0x%040x", 0xc0de+i)
+		}
+		return r, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeChain) WaitForReceipt(hash string, _ time.Duration) (*chain.TxReceipt, error) {
+	if r, _ := f.GetTransactionReceipt(hash); r != nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("transaction %s not mined", hash)
+}
+
+func writeArtifact(t *testing.T, dir string) {
+	t.Helper()
+	art := `{
+		"abi": [
+			{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]},
+			{"type":"function","name":"setOwner","inputs":[{"name":"o","type":"address"}],"outputs":[],"stateMutability":"nonpayable"}
+		],
+		"bytecode": "0x6080604052"
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Token.json"), []byte(art), 0o600))
+}
+
+func newTestRunner(t *testing.T, planYAML string, fc *fakeChain) (*Runner, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeArtifact(t, dir)
+	p, err := ParsePlan([]byte(planYAML))
+	require.NoError(t, err)
+
+	planPath := filepath.Join(dir, "plan.yaml")
+	st, err := LoadState(StatePath(planPath))
+	require.NoError(t, err)
+
+	reg := contract.NewRegistry(filepath.Join(dir, "contracts.json"))
+	return &Runner{
+		Plan:      p,
+		Network:   "base",
+		ChainID:   8453,
+		Client:    fc,
+		Signer:    newKeySigner(t),
+		Contracts: reg,
+		State:     st,
+		BaseDir:   dir,
+	}, planPath
+}
+
+const deployAndUsePlan = `
+vars:
+  owner: "0x1111111111111111111111111111111111111111"
+steps:
+  - id: token
+    action: deploy
+    name: MyToken
+    artifact: Token.json
+    args: ["1000"]
+  - id: own
+    action: call
+    contract: MyToken
+    function: setOwner
+    args: ["${vars.owner}"]
+  - id: pay
+    action: send
+    to: ${vars.owner}
+    value: "0.5"
+`
+
+// ---------------------------------------------------------------------------
+// Simulate
+// ---------------------------------------------------------------------------
+
+func TestSimulatePredictsDeployAndSkipsDependents(t *testing.T) {
+	fc := &fakeChain{nonce: 7}
+	r, _ := newTestRunner(t, deployAndUsePlan, fc)
+
+	results, err := r.Simulate()
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	want := crypto.CreateAddress(common.HexToAddress(r.Signer.Address()), 7).Hex()
+	assert.Equal(t, "ok", results[0].Status)
+	assert.Equal(t, want, results[0].Outputs["address"])
+
+	assert.Equal(t, StatusSkipped, results[1].Status)
+	assert.Equal(t, want, results[1].Target)
+
+	assert.Equal(t, "ok", results[2].Status)
+	assert.Equal(t, uint64(9), results[2].Nonce)
+
+	// Deploy simulated with empty to, send simulated against recipient.
+	require.Len(t, fc.simulated, 2)
+	assert.True(t, strings.HasPrefix(fc.simulated[0], "|0x6080604052"))
+	assert.True(t, strings.HasPrefix(fc.simulated[1], recipient+"|"))
+	assert.Empty(t, fc.sent, "dry-run must not broadcast")
+}
+
+func TestSimulateReportsRevert(t *testing.T) {
+	fc := &fakeChain{revertOn: "0xa9059cbb", decimals: 6}
+	plan := `
+steps:
+  - action: transfer
+    token: "0x2222222222222222222222222222222222222222"
+    to: "0x1111111111111111111111111111111111111111"
+    amount: "1.5"
+`
+	r, _ := newTestRunner(t, plan, fc)
+	results, err := r.Simulate()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Contains(t, results[0].Detail, "nope")
+
+	// 1.5 with 6 decimals = 1500000 = 0x16e360
+	assert.Contains(t, fc.simulated[0], fmt.Sprintf("%064x", 1500000))
+}
+
+// ---------------------------------------------------------------------------
+// Execute
+// ---------------------------------------------------------------------------
+
+func TestExecuteFailsTransferWithoutDecimals(t *testing.T) {
+	fc := &fakeChain{noERC20: true}
+	plan := `
+steps:
+  - action: transfer
+    token: "0x2222222222222222222222222222222222222222"
+    to: "0x1111111111111111111111111111111111111111"
+    amount: "1.5"
+`
+	r, _ := newTestRunner(t, plan, fc)
+	results, err := r.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decimals()")
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Empty(t, fc.sent, "nothing is signed with a guessed amount")
+}
+
+func TestExecuteRunsStepsWithSequentialNonces(t *testing.T) {
+	fc := &fakeChain{nonce: 3}
+	r, planPath := newTestRunner(t, deployAndUsePlan, fc)
+
+	var seen []string
+	r.OnStep = func(res StepResult) { seen = append(seen, res.ID+":"+res.Status) }
+
+	results, err := r.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"token:confirmed", "own:confirmed", "pay:confirmed"}, seen)
+
+	require.Len(t, fc.sent, 3)
+	for i, tx := range fc.sent {
+		assert.Equal(t, uint64(3+i), tx.Nonce())
+	}
+
+	// Deploy output flows into the call target.
+	deployed := results[0].Outputs["address"]
+	require.NotEmpty(t, deployed)
+	assert.Equal(t, strings.ToLower(deployed), strings.ToLower(fc.sent[1].To().Hex()))
+
+	// setOwner(address) selector + padded owner.
+	data := hex.EncodeToString(fc.sent[1].Data())
+	assert.True(t, strings.HasPrefix(data, "13af4035"))
+	assert.Equal(t, big.NewInt(500000000000000000), fc.sent[2].Value())
+
+	// Deploy registered in the contract registry.
+	e, err := r.Contracts.Get("MyToken", "base")
+	require.NoError(t, err)
+	assert.Equal(t, deployed, e.Address)
+	assert.Equal(t, "deployed", e.Kind)
+
+	// State file records confirmation.
+	st, err := LoadState(StatePath(planPath))
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, st.Steps["pay"].Status)
+	assert.Equal(t, r.Plan.Hash, st.PlanHash)
+}
+
+func TestExecuteResumesAfterFailure(t *testing.T) {
+	fc := &fakeChain{failSend: 2}
+	r, planPath := newTestRunner(t, deployAndUsePlan, fc)
+
+	_, err := r.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient funds")
+	require.Len(t, fc.sent, 1)
+
+	st, err := LoadState(StatePath(planPath))
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, st.Steps["token"].Status)
+	assert.Equal(t, StatusFailed, st.Steps["own"].Status)
+
+	// Resume with the reloaded state: the deploy is not repeated.
+	r.State = st
+	results, err := r.Execute()
+	require.NoError(t, err)
+	require.Len(t, fc.sent, 3)
+	assert.Equal(t, "already confirmed", results[0].Detail)
+	assert.Equal(t, strings.ToLower(results[0].Outputs["address"]), strings.ToLower(fc.sent[1].To().Hex()))
+}
+
+// countingNonces is an in-memory nonce.Allocator.
+type countingNonces struct {
+	next     uint64
+	released []uint64
+}
+
+func (c *countingNonces) Next() (uint64, error) { c.next++; return c.next - 1, nil }
+func (c *countingNonces) Release(n uint64) error {
+	c.released = append(c.released, n)
+	return nil
+}
+
+func TestExecuteUsesNonceSource(t *testing.T) {
+	fc := &fakeChain{failSend: 2}
+	r, _ := newTestRunner(t, deployAndUsePlan, fc)
+	src := &countingNonces{next: 40}
+	r.Nonces = src
+
+	_, err := r.Execute()
+	require.Error(t, err)
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, uint64(40), fc.sent[0].Nonce())
+	assert.Equal(t, []uint64{41}, src.released, "unbroadcast nonce is returned")
+}
+
+func TestExecuteReplaysSignedButUnsentTx(t *testing.T) {
+	fc := &fakeChain{}
+	r, _ := newTestRunner(t, payPlan, fc)
+
+	// Simulate a crash between signing and broadcast.
+	p := &prepared{to: recipient, value: big.NewInt(1)}
+	raw, hash, err := r.sign(r.Plan.Steps[0], p, 0)
+	require.NoError(t, err)
+	r.State.PlanHash = r.Plan.Hash
+	r.State.Network = "base"
+	r.State.Steps["pay"] = &StepState{Status: StatusSent, Hash: hash, RawTx: raw}
+
+	results, err := r.Execute()
+	require.NoError(t, err)
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, hash, fc.sent[0].Hash().Hex())
+	assert.Equal(t, StatusConfirmed, results[0].Status)
+}
+
+const payPlan = `
+steps:
+  - id: pay
+    action: send
+    to: "0x1111111111111111111111111111111111111111"
+    value: "1"
+`
+
+func TestExecuteBroadcastTimeoutKeepsSignedTx(t *testing.T) {
+	fc := &fakeChain{timeoutSend: 1, dropTimedOut: true}
+	r, planPath := newTestRunner(t, payPlan, fc)
+
+	_, err := r.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deadline exceeded")
+	st, err := LoadState(StatePath(planPath))
+	require.NoError(t, err)
+	require.Equal(t, StatusSent, st.Steps["pay"].Status)
+	require.NotEmpty(t, st.Steps["pay"].RawTx)
+
+	// The resume replays the saved tx rather than signing the step again.
+	r.State = st
+	results, err := r.Execute()
+	require.NoError(t, err)
+	require.Len(t, fc.sent, 1)
+	assert.Equal(t, st.Steps["pay"].Hash, fc.sent[0].Hash().Hex())
+	assert.Equal(t, StatusConfirmed, results[0].Status)
+}
+
+func TestExecuteBroadcastTimeoutNeverRunsStepTwice(t *testing.T) {
+	fc := &fakeChain{timeoutSend: 1}
+	r, _ := newTestRunner(t, payPlan, fc)
+
+	// The node took the tx even though the broadcast timed out.
+	_, err := r.Execute()
+	require.Error(t, err)
+
+	results, err := r.Execute()
+	require.NoError(t, err)
+	assert.Len(t, fc.sent, 1)
+	assert.Equal(t, StatusConfirmed, results[0].Status)
+}
+
+func TestExecuteReportsNonceConflict(t *testing.T) {
+	fc := &fakeChain{}
+	r, _ := newTestRunner(t, payPlan, fc)
+
+	p := &prepared{to: recipient, value: big.NewInt(1)}
+	raw, hash, err := r.sign(r.Plan.Steps[0], p, 0)
+	require.NoError(t, err)
+	r.State.PlanHash = r.Plan.Hash
+	r.State.Network = "base"
+	r.State.Steps["pay"] = &StepState{Status: StatusSent, Hash: hash, RawTx: raw}
+
+	// Another transaction took nonce 0 and ours was never mined.
+	fc.nonce = 1
+
+	for i := 0; i < 2; i++ {
+		_, err := r.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "used by another transaction")
+		assert.Empty(t, fc.sent, "the step is not signed again")
+		assert.Equal(t, raw, r.State.Steps["pay"].RawTx)
+	}
+}
+
+func TestExecuteRefusesChangedPlan(t *testing.T) {
+	fc := &fakeChain{}
+	r, _ := newTestRunner(t, deployAndUsePlan, fc)
+	r.State.PlanHash = "different"
+	r.State.Network = "base"
+	r.State.Steps["token"] = &StepState{Status: StatusConfirmed}
+
+	_, err := r.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--restart")
+}
+
+func TestCallWithSignatureOnRawAddress(t *testing.T) {
+	fc := &fakeChain{}
+	plan := `
+steps:
+  - action: call
+    contract: "0x3333333333333333333333333333333333333333"
+    function: "mint(address,uint256)"
+    args: ["0x1111111111111111111111111111111111111111", "42"]
+`
+	r, _ := newTestRunner(t, plan, fc)
+	_, err := r.Execute()
+	require.NoError(t, err)
+	require.Len(t, fc.sent, 1)
+	assert.True(t, strings.HasPrefix(hex.EncodeToString(fc.sent[0].Data()), "40c10f19"))
+}
+
+func TestCallUnknownContract(t *testing.T) {
+	fc := &fakeChain{}
+	plan := `
+steps:
+  - action: call
+    contract: Missing
+    function: foo
+`
+	r, _ := newTestRunner(t, plan, fc)
+	_, err := r.Simulate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
@@ -0,0 +1,120 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Step statuses recorded in the state file.
+const (
+	StatusPending   = "pending"
+	StatusSent      = "sent"
+	StatusConfirmed = "confirmed"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// State is the resumable progress of a plan run, persisted next to the plan.
+type State struct {
+	PlanHash string                `json:"plan_hash"`
+	Network  string                `json:"network"`
+	From     string                `json:"from"`
+	Steps    map[string]*StepState `json:"steps"`
+
+	path string
+}
+
+// StepState records what happened to one step.
+type StepState struct {
+	Status    string            `json:"status"`
+	Hash      string            `json:"hash,omitempty"`
+	Nonce     uint64            `json:"nonce"`
+	RawTx     string            `json:"raw_tx,omitempty"` // kept so a crashed broadcast can be replayed as-is
+	Outputs   map[string]string `json:"outputs,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+// StatePath returns the default state file path for a plan file.
+func StatePath(planPath string) string {
+	return planPath + ".state.json"
+}
+
+// LoadState reads the state file at path, returning an empty state when it
+// does not exist yet.
+func LoadState(path string) (*State, error) {
+	st := &State{Steps: make(map[string]*StepState), path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	if st.Steps == nil {
+		st.Steps = make(map[string]*StepState)
+	}
+	return st, nil
+}
+
+// Save writes the state to disk.
+func (s *State) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// Path returns the file the state is persisted to.
+func (s *State) Path() string { return s.path }
+
+// Started reports whether any step has progressed past pending.
+func (s *State) Started() bool { return len(s.Steps) > 0 }
+
+// Reset clears all recorded progress.
+func (s *State) Reset() {
+	s.Steps = make(map[string]*StepState)
+	s.PlanHash = ""
+}
+
+// CheckCompatible refuses to resume a run whose plan, network or sender changed.
+func (s *State) CheckCompatible(planHash, network, from string) error {
+	if !s.Started() {
+		return nil
+	}
+	if s.PlanHash != planHash {
+		return fmt.Errorf("plan file changed since the last run — use --restart to start over (state: %s)", s.path)
+	}
+	if s.Network != network {
+		return fmt.Errorf("previous run used network %s, not %s — use --restart to start over", s.Network, network)
+	}
+	if s.From != "" && s.From != from {
+		return fmt.Errorf("previous run used wallet %s — use --restart to start over", s.From)
+	}
+	return nil
+}
+
+func (s *State) step(id string) *StepState {
+	st, ok := s.Steps[id]
+	if !ok {
+		st = &StepState{Status: StatusPending}
+		s.Steps[id] = st
+	}
+	return st
+}
+
+func (s *State) update(id string, fn func(*StepState)) error {
+	st := s.step(id)
+	fn(st)
+	st.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	return s.Save()
+}
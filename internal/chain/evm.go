@@ -3,6 +3,7 @@ package chain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -21,9 +22,9 @@ type EVMClient struct {
 
 // Balance holds a native balance result.
 type Balance struct {
-	Wei       *big.Int
-	ETH       string
-	USD       float64
+	Wei *big.Int
+	ETH string
+	USD float64
 }
 
 // TokenBalance holds an ERC-20 token balance result.
@@ -49,6 +50,7 @@ type Transaction struct {
 	Success      bool   // true = confirmed success, false = failed/unknown
 	FunctionName string // decoded method name, e.g. "transfer", "swap", "0xa9059cbb"
 	IsContract   bool   // true when input data is present (contract call)
+	Input        string // raw calldata hex (only populated from block scans)
 }
 
 // NewEVMClient creates a new EVM JSON-RPC client pointed at url.
@@ -214,10 +216,13 @@ func (c *EVMClient) SendRawTransaction(rawTx string) (string, error) {
 }
 
 // EstimateGas estimates gas for a transaction.
+// An empty to estimates a contract creation.
 func (c *EVMClient) EstimateGas(from, to, data string, value *big.Int) (uint64, error) {
 	params := map[string]string{
 		"from": from,
-		"to":   to,
+	}
+	if to != "" {
+		params["to"] = to
 	}
 	if data != "" {
 		params["data"] = data
@@ -315,8 +320,41 @@ type rpcResponse struct {
 }
 
 type rpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// RPCError is a JSON-RPC error returned by the node. Data holds the revert
+// data when an eth_call reverts and the node reports it (most do).
+type RPCError struct {
+	Code    int
+	Message string
+	Data    string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// IsRejected reports whether a broadcast error came from the node refusing
+// the transaction. Any other error (timeout, dropped connection, bad
+// response) leaves it unknown whether the node accepted the tx.
+func IsRejected(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr)
+}
+
+// IsAlreadyKnown reports whether the node already has this exact transaction,
+// e.g. when a broadcast is retried after a timeout.
+func IsAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "already imported")
+}
+
+// IsNonceTooLow reports whether the node has already used the tx's nonce.
+func IsNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
 }
 
 func (c *EVMClient) call(method string, params ...interface{}) (interface{}, error) {
@@ -347,7 +385,9 @@ func (c *EVMClient) call(method string, params ...interface{}) (interface{}, err
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		rpcErr := &RPCError{Code: rpcResp.Error.Code, Message: rpcResp.Error.Message}
+		json.Unmarshal(rpcResp.Error.Data, &rpcErr.Data) //nolint:errcheck // data is optional and not always a string
+		return nil, rpcErr
 	}
 
 	var result interface{}
@@ -359,21 +399,23 @@ func (c *EVMClient) call(method string, params ...interface{}) (interface{}, err
 }
 
 type rawTx struct {
-	Hash      string `json:"hash"`
-	From      string `json:"from"`
-	To        string `json:"to"`
-	Value     string `json:"value"`
-	Gas       string `json:"gas"`
-	GasPrice  string `json:"gasPrice"`
-	Nonce     string `json:"nonce"`
-	BlockNum  string `json:"blockNumber"`
+	Hash     string `json:"hash"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Value    string `json:"value"`
+	Gas      string `json:"gas"`
+	GasPrice string `json:"gasPrice"`
+	Nonce    string `json:"nonce"`
+	BlockNum string `json:"blockNumber"`
+	Input    string `json:"input"`
 }
 
 func (rt *rawTx) toTx() *Transaction {
 	tx := &Transaction{
-		Hash: rt.Hash,
-		From: rt.From,
-		To:   rt.To,
+		Hash:  rt.Hash,
+		From:  rt.From,
+		To:    rt.To,
+		Input: rt.Input,
 	}
 	if v, ok := parseBigHex(rt.Value); ok {
 		tx.Value = v
@@ -600,10 +642,13 @@ func (c *EVMClient) GetPendingNonce(address string) (uint64, error) {
 // SimulateCall simulates a contract call using eth_call (with from field).
 // Returns (true, returnData, nil) on success or (false, revertReason, nil) if
 // the call reverts. Network errors return (false, "", err).
+// An empty to simulates a contract creation.
 func (c *EVMClient) SimulateCall(from, to, data string, value *big.Int) (bool, string, error) {
 	params := map[string]string{
 		"from": from,
-		"to":   to,
+	}
+	if to != "" {
+		params["to"] = to
 	}
 	if data != "" {
 		params["data"] = data
@@ -0,0 +1,100 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// maxBatchCalls caps the requests per JSON-RPC batch; public RPCs commonly
+// reject batches above 50–100.
+const maxBatchCalls = 50
+
+// GetTokenBalances returns the raw ERC-20 balance of wallet for each token,
+// keyed by lowercased token address, using JSON-RPC batches of eth_call so
+// hundreds of list tokens cost a handful of round trips. Tokens whose call
+// fails (not a contract, reverts) are left out. Endpoints that don't support
+// batching fall back to one call per token. An error is only returned when
+// no balance could be read at all.
+func (c *EVMClient) GetTokenBalances(tokens []string, wallet string) (map[string]*big.Int, error) {
+	data := "0x70a08231" + fmt.Sprintf("%064s", strings.ToLower(strings.TrimPrefix(wallet, "0x")))
+	out := make(map[string]*big.Int, len(tokens))
+	var lastErr error
+	for start := 0; start < len(tokens); start += maxBatchCalls {
+		end := min(start+maxBatchCalls, len(tokens))
+		chunk := tokens[start:end]
+
+		results, err := c.batchCall(chunk, data)
+		if err != nil {
+			// Batching unsupported — fall back to sequential calls.
+			results = make([]string, len(chunk))
+			for i, t := range chunk {
+				if results[i], err = c.CallContract(t, data); err != nil {
+					lastErr = err
+				}
+			}
+		}
+		for i, hexStr := range results {
+			word := strings.TrimPrefix(hexStr, "0x")
+			if word == "" {
+				continue
+			}
+			raw, ok := new(big.Int).SetString(word[:min(64, len(word))], 16)
+			if !ok {
+				continue
+			}
+			out[strings.ToLower(chunk[i])] = raw
+		}
+	}
+	if len(out) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return out, nil
+}
+
+// batchCall sends one eth_call per target with the same calldata as a single
+// JSON-RPC batch. Results are in target order; failed calls are "".
+func (c *EVMClient) batchCall(targets []string, data string) ([]string, error) {
+	reqs := make([]rpcRequest, len(targets))
+	for i, t := range targets {
+		reqs[i] = rpcRequest{
+			JSONRPC: "2.0",
+			Method:  "eth_call",
+			Params:  []interface{}{map[string]string{"to": t, "data": data}, "latest"},
+			ID:      i + 1,
+		}
+	}
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("RPC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	var resps []rpcResponse
+	if err := json.Unmarshal(raw, &resps); err != nil {
+		return nil, fmt.Errorf("batch not supported: %w", err)
+	}
+
+	out := make([]string, len(targets))
+	for _, r := range resps {
+		if r.Error != nil || r.ID < 1 || r.ID > len(targets) {
+			continue
+		}
+		var s string
+		if json.Unmarshal(r.Result, &s) == nil {
+			out[r.ID-1] = s
+		}
+	}
+	return out, nil
+}
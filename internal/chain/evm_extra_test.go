@@ -1,7 +1,13 @@
 package chain
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,6 +73,35 @@ func TestGetAllowanceRPCError(t *testing.T) {
 	assert.Contains(t, err.Error(), "RPC error")
 }
 
+func TestCallContractRevertData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+			"jsonrpc": "2.0",
+			"id":      1,
+			"error":   map[string]interface{}{"code": 3, "message": "execution reverted", "data": "0x556f1830"},
+		})
+	}))
+	defer srv.Close()
+
+	_, err := NewEVMClient(srv.URL).CallContract("0xResolver", "0x3b3b57de")
+	var rpcErr *RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, 3, rpcErr.Code)
+	assert.Equal(t, "0x556f1830", rpcErr.Data)
+	assert.Equal(t, "RPC error 3: execution reverted", err.Error())
+}
+
+func TestIsRejected(t *testing.T) {
+	srv := rpcErrorServer(t, -32000, "insufficient funds for gas * price + value")
+	defer srv.Close()
+	_, err := NewEVMClient(srv.URL).SendRawTransaction("0x01")
+	assert.True(t, IsRejected(err))
+
+	_, err = NewEVMClient("http://127.0.0.1:1").SendRawTransaction("0x01")
+	require.Error(t, err)
+	assert.False(t, IsRejected(err), "a transport failure says nothing about the tx")
+}
+
 // ---------------------------------------------------------------------------
 // EVMClient — GetPendingNonce
 // ---------------------------------------------------------------------------
@@ -297,3 +332,52 @@ func TestGetLogsRPCError(t *testing.T) {
 	_, err := NewEVMClient(srv.URL).GetLogs("0xtoken", nil, "0x0", "latest")
 	require.Error(t, err)
 }
+
+// ---------------------------------------------------------------------------
+// EVMClient — GetTokenBalances (batched balanceOf)
+// ---------------------------------------------------------------------------
+
+func TestGetTokenBalancesBatch(t *testing.T) {
+	batches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		var reqs []rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		out := make([]map[string]interface{}, 0, len(reqs))
+		// Reply in reverse order; the client must match by id.
+		for i := len(reqs) - 1; i >= 0; i-- {
+			resp := map[string]interface{}{"jsonrpc": "2.0", "id": reqs[i].ID}
+			if reqs[i].ID == 2 {
+				resp["error"] = map[string]interface{}{"code": -32000, "message": "execution reverted"}
+			} else {
+				resp["result"] = "0x00000000000000000000000000000000000000000000000000000000000003e8"
+			}
+			out = append(out, resp)
+		}
+		json.NewEncoder(w).Encode(out) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	tokens := make([]string, 60)
+	for i := range tokens {
+		tokens[i] = "0x" + strings.Repeat("0", 38) + fmt.Sprintf("%02X", i)
+	}
+	bals, err := NewEVMClient(srv.URL).GetTokenBalances(tokens, "0xOwner")
+	require.NoError(t, err)
+	assert.Equal(t, 2, batches, "60 tokens split into batches of 50")
+	assert.Len(t, bals, 58, "id 2 of each batch reverted")
+	assert.Equal(t, int64(1000), bals[strings.ToLower(tokens[0])].Int64())
+	_, ok := bals[strings.ToLower(tokens[1])]
+	assert.False(t, ok)
+}
+
+func TestGetTokenBalancesFallsBackWithoutBatching(t *testing.T) {
+	srv := rpcMock(t, map[string]interface{}{
+		"eth_call": "0x0000000000000000000000000000000000000000000000000000000000000007",
+	})
+	defer srv.Close()
+
+	bals, err := NewEVMClient(srv.URL).GetTokenBalances([]string{"0xAA"}, "0xOwner")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), bals["0xaa"].Int64())
+}
@@ -29,6 +29,7 @@ type Chain struct {
 	MainnetExplorer     string    `json:"mainnet_explorer"`
 	TestnetExplorer     string    `json:"testnet_explorer"`
 	TestnetName         string    `json:"testnet_name"`
+	TestnetChainID      int64     `json:"testnet_chain_id,omitempty"`
 	// Etherscan-compatible tx API endpoints (no key required for basic use).
 	MainnetExplorerAPI  string    `json:"mainnet_explorer_api,omitempty"`
 	TestnetExplorerAPI  string    `json:"testnet_explorer_api,omitempty"`
@@ -57,6 +58,9 @@ func NewRegistry() *Registry {
 		if c.ChainID != 0 {
 			r.byID[c.ChainID] = c
 		}
+		if c.TestnetChainID != 0 {
+			r.byID[c.TestnetChainID] = c
+		}
 	}
 	return r
 }
@@ -75,7 +79,7 @@ func (r *Registry) GetByName(name string) (*Chain, error) {
 	return c, nil
 }
 
-// GetByChainID finds an EVM chain by its numeric chain ID.
+// GetByChainID finds an EVM chain by its mainnet or testnet chain ID.
 func (r *Registry) GetByChainID(id int64) (*Chain, error) {
 	c, ok := r.byID[id]
 	if !ok {
@@ -84,6 +88,23 @@ func (r *Registry) GetByChainID(id int64) (*Chain, error) {
 	return c, nil
 }
 
+// ModeOf returns "testnet" when id is the chain's testnet ID, else "mainnet".
+func (c *Chain) ModeOf(id int64) string {
+	if id != 0 && id == c.TestnetChainID {
+		return "testnet"
+	}
+	return "mainnet"
+}
+
+// ChainIDFor returns the chain ID for the given mode (0 if the testnet ID is
+// unknown).
+func (c *Chain) ChainIDFor(mode string) int64 {
+	if mode == "testnet" {
+		return c.TestnetChainID
+	}
+	return c.ChainID
+}
+
 // RPCs returns the RPC list for a chain in the given mode ("mainnet"/"testnet").
 func (c *Chain) RPCs(mode string) []string {
 	if mode == "testnet" {
@@ -122,6 +143,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://etherscan.io",
 			TestnetExplorer: "https://sepolia.etherscan.io",
 			TestnetName:    "Sepolia",
+			TestnetChainID: 11155111,
 			MainnetExplorerAPI: "https://eth.blockscout.com/api",
 			TestnetExplorerAPI: "https://eth-sepolia.blockscout.com/api",
 			FaucetURL:      "https://sepoliafaucet.com",
@@ -135,6 +157,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://basescan.org",
 			TestnetExplorer: "https://sepolia.basescan.org",
 			TestnetName:    "Base Sepolia",
+			TestnetChainID: 84532,
 			MainnetExplorerAPI: "https://base.blockscout.com/api",
 			TestnetExplorerAPI: "https://base-sepolia.blockscout.com/api",
 			FaucetURL:      "https://www.alchemy.com/faucets/base-sepolia",
@@ -148,6 +171,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://polygonscan.com",
 			TestnetExplorer: "https://amoy.polygonscan.com",
 			TestnetName:    "Amoy",
+			TestnetChainID: 80002,
 			MainnetExplorerAPI: "https://polygon.blockscout.com/api",
 			TestnetExplorerAPI: "https://polygon-amoy.blockscout.com/api",
 			FaucetURL:      "https://faucet.polygon.technology",
@@ -161,6 +185,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://arbiscan.io",
 			TestnetExplorer: "https://sepolia.arbiscan.io",
 			TestnetName:    "Arb Sepolia",
+			TestnetChainID: 421614,
 			MainnetExplorerAPI: "https://arbitrum.blockscout.com/api",
 			TestnetExplorerAPI: "https://arbitrum-sepolia.blockscout.com/api",
 			FaucetURL:      "https://www.alchemy.com/faucets/arbitrum-sepolia",
@@ -174,6 +199,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://optimistic.etherscan.io",
 			TestnetExplorer: "https://sepolia-optimism.etherscan.io",
 			TestnetName:    "OP Sepolia",
+			TestnetChainID: 11155420,
 			MainnetExplorerAPI: "https://explorer.optimism.io/api",
 			TestnetExplorerAPI: "https://optimism-sepolia.blockscout.com/api",
 			FaucetURL:      "https://www.alchemy.com/faucets/optimism-sepolia",
@@ -187,6 +213,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://bscscan.com",
 			TestnetExplorer: "https://testnet.bscscan.com",
 			TestnetName:    "BSC Testnet",
+			TestnetChainID: 97,
 			// No free no-key explorer API for BNB — falls through to RPC scan.
 			// Phase 2: Ankr free provider will cover this.
 			FaucetURL:      "https://www.bnbchain.org/en/testnet-faucet",
@@ -200,6 +227,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://snowtrace.io",
 			TestnetExplorer: "https://testnet.snowtrace.io",
 			TestnetName:    "Fuji",
+			TestnetChainID: 43113,
 			MainnetExplorerAPI: "https://api.snowtrace.io/api",
 			TestnetExplorerAPI: "https://api-testnet.snowtrace.io/api",
 			FaucetURL:      "https://faucet.avax.network",
@@ -213,6 +241,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://ftmscan.com",
 			TestnetExplorer: "https://testnet.ftmscan.com",
 			TestnetName:    "FTM Testnet",
+			TestnetChainID: 4002,
 			// No free no-key explorer API for Fantom — falls through to RPC scan.
 			// Phase 2: Ankr free provider will cover this.
 			FaucetURL:      "https://faucet.fantom.network",
@@ -226,6 +255,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://lineascan.build",
 			TestnetExplorer: "https://sepolia.lineascan.build",
 			TestnetName:    "Linea Sepolia",
+			TestnetChainID: 59141,
 			// No free no-key explorer API for Linea — falls through to RPC scan.
 			// Phase 2: Ankr free provider will cover this.
 			FaucetURL:      "https://www.infura.io/faucet/linea", // official MetaMask/Consensys faucet
@@ -239,6 +269,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://explorer.zksync.io",
 			TestnetExplorer: "https://sepolia.explorer.zksync.io",
 			TestnetName:    "zkSync Sepolia",
+			TestnetChainID: 300,
 			MainnetExplorerAPI: "https://zksync.blockscout.com/api",
 			TestnetExplorerAPI: "https://zksync-sepolia.blockscout.com/api",
 			FaucetURL:      "https://faucet.quicknode.com/zksync/sepolia",
@@ -252,6 +283,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://scrollscan.com",
 			TestnetExplorer: "https://sepolia.scrollscan.com",
 			TestnetName:    "Scroll Sepolia",
+			TestnetChainID: 534351,
 			MainnetExplorerAPI: "https://scroll.blockscout.com/api",
 			TestnetExplorerAPI: "https://scroll-sepolia.blockscout.com/api",
 			FaucetURL:      "https://faucet.quicknode.com/scroll/sepolia",
@@ -265,6 +297,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://mantlescan.xyz",
 			TestnetExplorer: "https://sepolia.mantlescan.xyz",
 			TestnetName:    "Mantle Sepolia",
+			TestnetChainID: 5003,
 			// No free no-key explorer API for Mantle — falls through to RPC scan.
 			// Phase 2: Ankr free provider will cover this.
 			FaucetURL:      "https://faucet.sepolia.mantle.xyz",
@@ -278,6 +311,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://celoscan.io",
 			TestnetExplorer: "https://celo-sepolia.blockscout.com",
 			TestnetName:    "Celo Sepolia",
+			TestnetChainID: 11142220,
 			MainnetExplorerAPI: "https://celo.blockscout.com/api",
 			TestnetExplorerAPI: "https://celo-sepolia.blockscout.com/api",
 			FaucetURL:      "https://faucet.celo.org",
@@ -291,6 +325,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://gnosisscan.io",
 			TestnetExplorer: "https://gnosis-chiado.blockscout.com",
 			TestnetName:    "Chiado",
+			TestnetChainID: 10200,
 			MainnetExplorerAPI: "https://gnosis.blockscout.com/api",
 			TestnetExplorerAPI: "https://gnosis-chiado.blockscout.com/api",
 			FaucetURL:      "https://faucet.chiadochain.net", // official Chiado testnet faucet
@@ -304,6 +339,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://blastscan.io",
 			TestnetExplorer: "https://testnet.blastscan.io",
 			TestnetName:    "Blast Sepolia",
+			TestnetChainID: 168587773,
 			MainnetExplorerAPI: "https://blast.blockscout.com/api",
 			TestnetExplorerAPI: "https://blast-sepolia.blockscout.com/api",
 			FaucetURL:      "https://faucet.quicknode.com/blast/sepolia",
@@ -317,6 +353,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://explorer.mode.network",
 			TestnetExplorer: "https://sepolia.explorer.mode.network",
 			TestnetName:    "Mode Sepolia",
+			TestnetChainID: 919,
 			MainnetExplorerAPI: "https://explorer.mode.network/api",
 			TestnetExplorerAPI: "https://sepolia.explorer.mode.network/api",
 			FaucetURL:      "https://app.optimism.io/faucet",
@@ -330,6 +367,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://explorer.zora.energy",
 			TestnetExplorer: "https://sepolia.explorer.zora.energy",
 			TestnetName:    "Zora Sepolia",
+			TestnetChainID: 999999999,
 			MainnetExplorerAPI: "https://explorer.zora.energy/api",
 			TestnetExplorerAPI: "https://sepolia.explorer.zora.energy/api",
 			FaucetURL:      "https://www.l2faucet.com/zora",
@@ -343,6 +381,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://moonscan.io",
 			TestnetExplorer: "https://moonbase.moonscan.io",
 			TestnetName:    "Moonbase Alpha",
+			TestnetChainID: 1287,
 			MainnetExplorerAPI: "https://moonbeam.blockscout.com/api",
 			TestnetExplorerAPI: "https://moonbase.blockscout.com/api",
 			FaucetURL:      "https://apps.moonbeam.network/moonbase-alpha/faucet",
@@ -356,6 +395,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://cronoscan.com",
 			TestnetExplorer: "https://testnet.cronoscan.com",
 			TestnetName:    "Cronos Testnet",
+			TestnetChainID: 338,
 			MainnetExplorerAPI: "https://cronos.blockscout.com/api",
 			TestnetExplorerAPI: "https://cronos-testnet.blockscout.com/api",
 			FaucetURL:      "https://cronos.org/faucet",
@@ -369,6 +409,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://kaiascan.io",
 			TestnetExplorer: "https://kairos.kaiascan.io",
 			TestnetName:    "Kairos",
+			TestnetChainID: 1001,
 			FaucetURL:      "https://faucet.kaia.io", // official post-rebrand Kaia faucet
 		},
 		// 21. Aurora
@@ -380,6 +421,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://aurorascan.dev",
 			TestnetExplorer: "https://testnet.aurorascan.dev",
 			TestnetName:    "Aurora Testnet",
+			TestnetChainID: 1313161555,
 			MainnetExplorerAPI: "https://explorer.aurora.dev/api",
 			TestnetExplorerAPI: "https://explorer.testnet.aurora.dev/api",
 			FaucetURL:      "https://aurora.dev/faucet",
@@ -393,6 +435,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://zkevm.polygonscan.com",
 			TestnetExplorer: "https://cardona-zkevm.polygonscan.com",
 			TestnetName:    "Cardona",
+			TestnetChainID: 2442,
 			MainnetExplorerAPI: "https://zkevm.blockscout.com/api",
 			TestnetExplorerAPI: "https://zkevm-cardona.blockscout.com/api",
 			FaucetURL:      "https://faucet.polygon.technology",
@@ -406,6 +449,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://app.hyperliquid.xyz/explorer",
 			TestnetExplorer: "https://app.hyperliquid-testnet.xyz/explorer",
 			TestnetName:    "HyperEVM Testnet",
+			TestnetChainID: 998,
 			FaucetURL:      "https://app.hyperliquid-testnet.xyz/drip",
 		},
 		// 24. Boba Network
@@ -417,6 +461,7 @@ func allChains() []Chain {
 			MainnetExplorer: "https://bobascan.com",
 			TestnetExplorer: "https://testnet.bobascan.com",
 			TestnetName:    "Boba Sepolia",
+			TestnetChainID: 28882,
 			MainnetExplorerAPI: "https://blockexplorer.boba.network/api",
 			TestnetExplorerAPI: "https://blockexplorer.sepolia.boba.network/api",
 			FaucetURL:      "https://hub.boba.network", // Boba Hub (gateway.boba.network redirects here)
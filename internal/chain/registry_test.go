@@ -76,6 +76,27 @@ func TestGetByChainID(t *testing.T) {
 	assert.Equal(t, "base", c.Name)
 }
 
+func TestGetByChainIDTestnet(t *testing.T) {
+	registry := chain.NewRegistry()
+	c, err := registry.GetByChainID(84532)
+	require.NoError(t, err)
+	assert.Equal(t, "base", c.Name)
+	assert.Equal(t, "testnet", c.ModeOf(84532))
+	assert.Equal(t, "mainnet", c.ModeOf(8453))
+	assert.Equal(t, int64(84532), c.ChainIDFor("testnet"))
+	assert.Equal(t, int64(8453), c.ChainIDFor("mainnet"))
+
+	// Testnet IDs never shadow a mainnet ID.
+	for _, c := range registry.All() {
+		if c.ChainID == 0 {
+			continue
+		}
+		got, err := registry.GetByChainID(c.ChainID)
+		require.NoError(t, err)
+		assert.Equal(t, c.Name, got.Name)
+	}
+}
+
 func TestGetByChainIDUnknown(t *testing.T) {
 	registry := chain.NewRegistry()
 	_, err := registry.GetByChainID(99999999)
@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -75,6 +76,213 @@ func (c *SolanaClient) Ping(ctx context.Context) (time.Duration, uint64, error)
 	return latency, slot, err
 }
 
+// SPL token program IDs (classic and Token-2022).
+const (
+	SolanaTokenProgram     = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	SolanaToken2022Program = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+)
+
+// SolanaTokenAccount is an SPL token account owned by a wallet.
+type SolanaTokenAccount struct {
+	Address   string   // token account address
+	Mint      string   // token mint
+	Program   string   // owning token program
+	Amount    *big.Int // raw base units
+	Decimals  int
+	Formatted string // human-readable amount
+}
+
+// GetTokenAccountsByOwner lists the SPL token accounts owned by owner. With a
+// mint only accounts for that mint are returned; otherwise both the classic
+// token program and Token-2022 are searched.
+func (c *SolanaClient) GetTokenAccountsByOwner(owner, mint string) ([]SolanaTokenAccount, error) {
+	filters := []map[string]string{{"programId": SolanaTokenProgram}, {"programId": SolanaToken2022Program}}
+	if mint != "" {
+		filters = []map[string]string{{"mint": mint}}
+	}
+
+	var out []SolanaTokenAccount
+	for _, filter := range filters {
+		var resp struct {
+			Value []struct {
+				Pubkey  string `json:"pubkey"`
+				Account struct {
+					Owner string `json:"owner"`
+					Data  struct {
+						Parsed struct {
+							Info struct {
+								Mint        string `json:"mint"`
+								TokenAmount struct {
+									Amount         string `json:"amount"`
+									Decimals       int    `json:"decimals"`
+									UIAmountString string `json:"uiAmountString"`
+								} `json:"tokenAmount"`
+							} `json:"info"`
+						} `json:"parsed"`
+					} `json:"data"`
+				} `json:"account"`
+			} `json:"value"`
+		}
+		if err := c.callInto(&resp, "getTokenAccountsByOwner", owner, filter,
+			map[string]string{"encoding": "jsonParsed", "commitment": "confirmed"}); err != nil {
+			return nil, err
+		}
+		for _, v := range resp.Value {
+			info := v.Account.Data.Parsed.Info
+			amount, ok := new(big.Int).SetString(info.TokenAmount.Amount, 10)
+			if !ok {
+				amount = new(big.Int)
+			}
+			out = append(out, SolanaTokenAccount{
+				Address:   v.Pubkey,
+				Mint:      info.Mint,
+				Program:   v.Account.Owner,
+				Amount:    amount,
+				Decimals:  info.TokenAmount.Decimals,
+				Formatted: info.TokenAmount.UIAmountString,
+			})
+		}
+	}
+	return out, nil
+}
+
+// AccountExists reports whether address holds an account (has lamports).
+func (c *SolanaClient) AccountExists(address string) (bool, error) {
+	var resp struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := c.callInto(&resp, "getAccountInfo", address,
+		map[string]string{"encoding": "base64", "commitment": "confirmed"}); err != nil {
+		return false, err
+	}
+	return len(resp.Value) > 0 && string(resp.Value) != "null", nil
+}
+
+// GetLatestBlockhash returns a recent blockhash to build a transaction with.
+// Transactions referencing it expire after lastValidBlockHeight.
+func (c *SolanaClient) GetLatestBlockhash() (string, uint64, error) {
+	var resp struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
+	if err := c.callInto(&resp, "getLatestBlockhash", map[string]string{"commitment": "confirmed"}); err != nil {
+		return "", 0, err
+	}
+	if resp.Value.Blockhash == "" {
+		return "", 0, fmt.Errorf("node returned no blockhash")
+	}
+	return resp.Value.Blockhash, resp.Value.LastValidBlockHeight, nil
+}
+
+// SendTransaction submits a signed wire transaction and returns its
+// signature. The node simulates it first, so most failures surface here.
+func (c *SolanaClient) SendTransaction(raw []byte) (string, error) {
+	var sig string
+	err := c.callInto(&sig, "sendTransaction", base64.StdEncoding.EncodeToString(raw),
+		map[string]string{"encoding": "base64", "preflightCommitment": "confirmed"})
+	return sig, err
+}
+
+// SolanaSignatureStatus is the confirmation state of a transaction.
+type SolanaSignatureStatus struct {
+	Slot               uint64
+	ConfirmationStatus string // processed | confirmed | finalized
+	Err                string // empty on success
+}
+
+// GetSignatureStatus returns the status of sig, or nil if the node has not
+// seen it.
+func (c *SolanaClient) GetSignatureStatus(sig string) (*SolanaSignatureStatus, error) {
+	var resp struct {
+		Value []*struct {
+			Slot               uint64          `json:"slot"`
+			ConfirmationStatus string          `json:"confirmationStatus"`
+			Err                json.RawMessage `json:"err"`
+		} `json:"value"`
+	}
+	if err := c.callInto(&resp, "getSignatureStatuses", []string{sig},
+		map[string]bool{"searchTransactionHistory": true}); err != nil {
+		return nil, err
+	}
+	if len(resp.Value) == 0 || resp.Value[0] == nil {
+		return nil, nil
+	}
+	v := resp.Value[0]
+	st := &SolanaSignatureStatus{Slot: v.Slot, ConfirmationStatus: v.ConfirmationStatus}
+	if len(v.Err) > 0 && string(v.Err) != "null" {
+		st.Err = string(v.Err)
+	}
+	return st, nil
+}
+
+// WaitForConfirmation polls until sig reaches "confirmed" or the timeout
+// expires. A transaction that landed but failed returns its status and an error.
+func (c *SolanaClient) WaitForConfirmation(sig string, timeout time.Duration) (*SolanaSignatureStatus, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		st, err := c.GetSignatureStatus(sig)
+		if err != nil {
+			return nil, err
+		}
+		if st != nil {
+			if st.Err != "" {
+				return st, fmt.Errorf("transaction failed: %s", st.Err)
+			}
+			if st.ConfirmationStatus == "confirmed" || st.ConfirmationStatus == "finalized" {
+				return st, nil
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	return nil, fmt.Errorf("transaction %s not confirmed within %s", sig, timeout)
+}
+
+// SolanaSignature is one entry of an address's transaction history.
+type SolanaSignature struct {
+	Signature          string
+	Slot               uint64
+	BlockTime          int64 // unix seconds, 0 when unknown
+	Success            bool
+	Memo               string
+	ConfirmationStatus string
+}
+
+// GetSignaturesForAddress returns the most recent transactions that touched
+// address, newest first.
+func (c *SolanaClient) GetSignaturesForAddress(address string, limit int) ([]SolanaSignature, error) {
+	var resp []struct {
+		Signature          string          `json:"signature"`
+		Slot               uint64          `json:"slot"`
+		BlockTime          *int64          `json:"blockTime"`
+		Err                json.RawMessage `json:"err"`
+		Memo               *string         `json:"memo"`
+		ConfirmationStatus string          `json:"confirmationStatus"`
+	}
+	if err := c.callInto(&resp, "getSignaturesForAddress", address,
+		map[string]interface{}{"limit": limit, "commitment": "confirmed"}); err != nil {
+		return nil, err
+	}
+	out := make([]SolanaSignature, 0, len(resp))
+	for _, r := range resp {
+		s := SolanaSignature{
+			Signature:          r.Signature,
+			Slot:               r.Slot,
+			Success:            len(r.Err) == 0 || string(r.Err) == "null",
+			ConfirmationStatus: r.ConfirmationStatus,
+		}
+		if r.BlockTime != nil {
+			s.BlockTime = *r.BlockTime
+		}
+		if r.Memo != nil {
+			s.Memo = *r.Memo
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
 // --- internal ---
 
 type solanaRequest struct {
@@ -93,6 +301,31 @@ type solanaResponse struct {
 }
 
 func (c *SolanaClient) call(method string, params ...interface{}) (interface{}, error) {
+	raw, err := c.callRaw(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	json.Unmarshal(raw, &result)
+	return result, nil
+}
+
+// callInto decodes the result of method into out.
+func (c *SolanaClient) callInto(out interface{}, method string, params ...interface{}) error {
+	raw, err := c.callRaw(method, params...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("parsing solana %s: %w", method, err)
+	}
+	return nil
+}
+
+func (c *SolanaClient) callRaw(method string, params ...interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
 	body, _ := json.Marshal(solanaRequest{
 		JSONRPC: "2.0",
 		ID:      1,
@@ -114,10 +347,7 @@ func (c *SolanaClient) call(method string, params ...interface{}) (interface{},
 	if rpcResp.Error != nil {
 		return nil, fmt.Errorf("solana RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
 	}
-
-	var result interface{}
-	json.Unmarshal(rpcResp.Result, &result)
-	return result, nil
+	return rpcResp.Result, nil
 }
 
 func solanaLamportsToSOL(lamports *big.Int) string {
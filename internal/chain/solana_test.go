@@ -1,11 +1,13 @@
 package chain
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -159,3 +161,142 @@ func TestSolanaClientInvalidJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "parsing solana response")
 }
+
+// ---------------------------------------------------------------------------
+// SolanaClient — tokens, transactions and history
+// ---------------------------------------------------------------------------
+
+// solanaMethodServer answers each JSON-RPC method with a canned result and
+// records the params it was called with.
+func solanaMethodServer(t *testing.T, results map[string]string, calls *[]string) *httptest.Server {
+	t.Helper()
+	return solanaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if calls != nil {
+			params, _ := json.Marshal(req.Params)
+			*calls = append(*calls, req.Method+" "+string(params))
+		}
+		res, ok := results[req.Method]
+		if !ok {
+			w.Write([]byte(solanaErrorResp(-32601, "Method not found"))) //nolint:errcheck
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%s}`, res)
+	})
+}
+
+func TestSolanaClientGetTokenAccountsByOwner(t *testing.T) {
+	var calls []string
+	srv := solanaMethodServer(t, map[string]string{
+		"getTokenAccountsByOwner": `{"context":{"slot":1},"value":[{"pubkey":"TokAcct1","account":{"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","data":{"parsed":{"info":{"mint":"Mint1","tokenAmount":{"amount":"1500000","decimals":6,"uiAmountString":"1.5"}}}}}}]}`,
+	}, &calls)
+	defer srv.Close()
+
+	c := NewSolanaClient(srv.URL)
+	accts, err := c.GetTokenAccountsByOwner("Owner1", "")
+	require.NoError(t, err)
+
+	// Both token programs are searched when no mint is given.
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[0], SolanaTokenProgram)
+	assert.Contains(t, calls[1], SolanaToken2022Program)
+	require.Len(t, accts, 2)
+	assert.Equal(t, "TokAcct1", accts[0].Address)
+	assert.Equal(t, "Mint1", accts[0].Mint)
+	assert.Equal(t, SolanaTokenProgram, accts[0].Program)
+	assert.Equal(t, big.NewInt(1_500_000), accts[0].Amount)
+	assert.Equal(t, 6, accts[0].Decimals)
+	assert.Equal(t, "1.5", accts[0].Formatted)
+
+	calls = nil
+	_, err = c.GetTokenAccountsByOwner("Owner1", "Mint1")
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Contains(t, calls[0], `{"mint":"Mint1"}`)
+}
+
+func TestSolanaClientAccountExists(t *testing.T) {
+	srv := solanaMethodServer(t, map[string]string{
+		"getAccountInfo": `{"context":{"slot":1},"value":null}`,
+	}, nil)
+	defer srv.Close()
+	ok, err := NewSolanaClient(srv.URL).AccountExists("Nobody")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	srv2 := solanaMethodServer(t, map[string]string{
+		"getAccountInfo": `{"context":{"slot":1},"value":{"lamports":1,"data":["","base64"]}}`,
+	}, nil)
+	defer srv2.Close()
+	ok, err = NewSolanaClient(srv2.URL).AccountExists("Somebody")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSolanaClientBlockhashAndSend(t *testing.T) {
+	var calls []string
+	srv := solanaMethodServer(t, map[string]string{
+		"getLatestBlockhash": `{"context":{"slot":1},"value":{"blockhash":"Hash111","lastValidBlockHeight":99}}`,
+		"sendTransaction":    `"Sig111"`,
+	}, &calls)
+	defer srv.Close()
+
+	c := NewSolanaClient(srv.URL)
+	bh, last, err := c.GetLatestBlockhash()
+	require.NoError(t, err)
+	assert.Equal(t, "Hash111", bh)
+	assert.Equal(t, uint64(99), last)
+
+	sig, err := c.SendTransaction([]byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, "Sig111", sig)
+	assert.Contains(t, calls[1], `"AQID"`, "raw tx is base64 encoded")
+	assert.Contains(t, calls[1], `"encoding":"base64"`)
+}
+
+func TestSolanaClientWaitForConfirmation(t *testing.T) {
+	srv := solanaMethodServer(t, map[string]string{
+		"getSignatureStatuses": `{"context":{"slot":1},"value":[{"slot":7,"confirmations":0,"err":null,"confirmationStatus":"confirmed"}]}`,
+	}, nil)
+	defer srv.Close()
+	st, err := NewSolanaClient(srv.URL).WaitForConfirmation("Sig", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), st.Slot)
+
+	failed := solanaMethodServer(t, map[string]string{
+		"getSignatureStatuses": `{"context":{"slot":1},"value":[{"slot":8,"err":{"InstructionError":[0,"Custom"]},"confirmationStatus":"confirmed"}]}`,
+	}, nil)
+	defer failed.Close()
+	st, err = NewSolanaClient(failed.URL).WaitForConfirmation("Sig", time.Second)
+	require.Error(t, err)
+	require.NotNil(t, st)
+	assert.Contains(t, st.Err, "InstructionError")
+
+	unseen := solanaMethodServer(t, map[string]string{
+		"getSignatureStatuses": `{"context":{"slot":1},"value":[null]}`,
+	}, nil)
+	defer unseen.Close()
+	_, err = NewSolanaClient(unseen.URL).WaitForConfirmation("Sig", 10*time.Millisecond)
+	assert.ErrorContains(t, err, "not confirmed")
+}
+
+func TestSolanaClientGetSignaturesForAddress(t *testing.T) {
+	var calls []string
+	srv := solanaMethodServer(t, map[string]string{
+		"getSignaturesForAddress": `[{"signature":"S1","slot":10,"blockTime":1700000000,"err":null,"memo":null,"confirmationStatus":"finalized"},{"signature":"S2","slot":9,"blockTime":null,"err":{"InstructionError":[0,"Custom"]},"memo":"hi","confirmationStatus":"finalized"}]`,
+	}, &calls)
+	defer srv.Close()
+
+	sigs, err := NewSolanaClient(srv.URL).GetSignaturesForAddress("Addr", 5)
+	require.NoError(t, err)
+	assert.Contains(t, calls[0], `"limit":5`)
+	require.Len(t, sigs, 2)
+	assert.Equal(t, SolanaSignature{Signature: "S1", Slot: 10, BlockTime: 1700000000, Success: true, ConfirmationStatus: "finalized"}, sigs[0])
+	assert.False(t, sigs[1].Success)
+	assert.Equal(t, "hi", sigs[1].Memo)
+	assert.Zero(t, sigs[1].BlockTime)
+}
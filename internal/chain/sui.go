@@ -7,6 +7,8 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -80,6 +82,305 @@ func (c *SUIClient) Ping(ctx context.Context) (time.Duration, uint64, error) {
 	return latency, cp, err
 }
 
+// SUICoinType is the coin type of native SUI.
+const SUICoinType = "0x2::sui::SUI"
+
+// SUIDefaultGasBudget is the gas budget (in MIST) used for simple transfers.
+// Only the gas actually used is charged.
+const SUIDefaultGasBudget = 10_000_000
+
+// SUICoinBalance is the total balance of one coin type.
+type SUICoinBalance struct {
+	CoinType string
+	Objects  int // number of coin objects
+	Total    *big.Int
+}
+
+// GetAllBalances returns the balance of every coin type owned by address.
+func (c *SUIClient) GetAllBalances(address string) ([]SUICoinBalance, error) {
+	var resp []struct {
+		CoinType        string `json:"coinType"`
+		CoinObjectCount int    `json:"coinObjectCount"`
+		TotalBalance    string `json:"totalBalance"`
+	}
+	if err := c.callInto(&resp, "suix_getAllBalances", address); err != nil {
+		return nil, err
+	}
+	out := make([]SUICoinBalance, 0, len(resp))
+	for _, r := range resp {
+		total, ok := new(big.Int).SetString(r.TotalBalance, 10)
+		if !ok {
+			total = new(big.Int)
+		}
+		out = append(out, SUICoinBalance{CoinType: r.CoinType, Objects: r.CoinObjectCount, Total: total})
+	}
+	return out, nil
+}
+
+// SUICoinMetadata describes a coin type.
+type SUICoinMetadata struct {
+	Decimals int    `json:"decimals"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+}
+
+// GetCoinMetadata returns metadata for coinType, or nil if none is published.
+func (c *SUIClient) GetCoinMetadata(coinType string) (*SUICoinMetadata, error) {
+	var md *SUICoinMetadata
+	if err := c.callInto(&md, "suix_getCoinMetadata", coinType); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// SUICoin is a single coin object.
+type SUICoin struct {
+	ObjectID string
+	Balance  *big.Int
+}
+
+// GetCoins returns every coin object of coinType owned by address, largest
+// first.
+func (c *SUIClient) GetCoins(address, coinType string) ([]SUICoin, error) {
+	var out []SUICoin
+	var cursor interface{}
+	for {
+		var resp struct {
+			Data []struct {
+				CoinObjectID string `json:"coinObjectId"`
+				Balance      string `json:"balance"`
+			} `json:"data"`
+			NextCursor  *string `json:"nextCursor"`
+			HasNextPage bool    `json:"hasNextPage"`
+		}
+		if err := c.callInto(&resp, "suix_getCoins", address, coinType, cursor, 50); err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Data {
+			bal, ok := new(big.Int).SetString(d.Balance, 10)
+			if !ok {
+				bal = new(big.Int)
+			}
+			out = append(out, SUICoin{ObjectID: d.CoinObjectID, Balance: bal})
+		}
+		if !resp.HasNextPage || resp.NextCursor == nil {
+			break
+		}
+		cursor = *resp.NextCursor
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Balance.Cmp(out[j].Balance) > 0 })
+	return out, nil
+}
+
+// SUIObject is an object owned by an address.
+type SUIObject struct {
+	ObjectID string
+	Version  string
+	Type     string
+}
+
+// GetOwnedObjects lists up to limit objects owned by address.
+func (c *SUIClient) GetOwnedObjects(address string, limit int) ([]SUIObject, error) {
+	var out []SUIObject
+	var cursor interface{}
+	for len(out) < limit {
+		var resp struct {
+			Data []struct {
+				Data struct {
+					ObjectID string `json:"objectId"`
+					Version  string `json:"version"`
+					Type     string `json:"type"`
+				} `json:"data"`
+			} `json:"data"`
+			NextCursor  *string `json:"nextCursor"`
+			HasNextPage bool    `json:"hasNextPage"`
+		}
+		query := map[string]interface{}{"options": map[string]bool{"showType": true}}
+		if err := c.callInto(&resp, "suix_getOwnedObjects", address, query, cursor, min(50, limit-len(out))); err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Data {
+			out = append(out, SUIObject{ObjectID: d.Data.ObjectID, Version: d.Data.Version, Type: d.Data.Type})
+		}
+		if !resp.HasNextPage || resp.NextCursor == nil {
+			break
+		}
+		cursor = *resp.NextCursor
+	}
+	return out, nil
+}
+
+// SUITransaction is one entry of an address's transaction history.
+type SUITransaction struct {
+	Digest     string
+	Sender     string
+	Timestamp  int64 // unix seconds, 0 when unknown
+	Checkpoint uint64
+	Success    bool
+}
+
+// QueryTransactionBlocks returns the most recent transactions sent from or
+// to address, newest first. The node can't OR filters, so both directions
+// are queried and merged.
+func (c *SUIClient) QueryTransactionBlocks(address string, limit int) ([]SUITransaction, error) {
+	seen := make(map[string]bool)
+	var out []SUITransaction
+	for _, filter := range []string{"FromAddress", "ToAddress"} {
+		var resp struct {
+			Data []struct {
+				Digest      string `json:"digest"`
+				TimestampMs string `json:"timestampMs"`
+				Checkpoint  string `json:"checkpoint"`
+				Transaction struct {
+					Data struct {
+						Sender string `json:"sender"`
+					} `json:"data"`
+				} `json:"transaction"`
+				Effects struct {
+					Status struct {
+						Status string `json:"status"`
+					} `json:"status"`
+				} `json:"effects"`
+			} `json:"data"`
+		}
+		query := map[string]interface{}{
+			"filter":  map[string]string{filter: address},
+			"options": map[string]bool{"showEffects": true, "showInput": true},
+		}
+		if err := c.callInto(&resp, "suix_queryTransactionBlocks", query, nil, limit, true); err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Data {
+			if seen[d.Digest] {
+				continue
+			}
+			seen[d.Digest] = true
+			tx := SUITransaction{
+				Digest:  d.Digest,
+				Sender:  d.Transaction.Data.Sender,
+				Success: d.Effects.Status.Status == "success",
+			}
+			if ms, err := strconv.ParseInt(d.TimestampMs, 10, 64); err == nil {
+				tx.Timestamp = ms / 1000
+			}
+			tx.Checkpoint, _ = strconv.ParseUint(d.Checkpoint, 10, 64)
+			out = append(out, tx)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp > out[j].Timestamp })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// PaySui builds a native SUI transfer with unsafe_paySui. The input coins
+// are merged, the first one also pays gas. Returns base64 BCS tx bytes.
+func (c *SUIClient) PaySui(signer string, coins, recipients []string, amounts []*big.Int, gasBudget uint64) (string, error) {
+	return c.buildTx("unsafe_paySui", signer, coins, recipients, amountStrings(amounts), strconv.FormatUint(gasBudget, 10))
+}
+
+// Pay builds a transfer of a non-SUI coin with unsafe_pay. The node picks a
+// SUI gas coin. Returns base64 BCS tx bytes.
+func (c *SUIClient) Pay(signer string, coins, recipients []string, amounts []*big.Int, gasBudget uint64) (string, error) {
+	return c.buildTx("unsafe_pay", signer, coins, recipients, amountStrings(amounts), nil, strconv.FormatUint(gasBudget, 10))
+}
+
+func (c *SUIClient) buildTx(method string, params ...interface{}) (string, error) {
+	var resp struct {
+		TxBytes string `json:"txBytes"`
+	}
+	if err := c.callInto(&resp, method, params...); err != nil {
+		return "", err
+	}
+	if resp.TxBytes == "" {
+		return "", fmt.Errorf("%s returned no transaction bytes", method)
+	}
+	return resp.TxBytes, nil
+}
+
+// SUIEffects is the outcome of a dry run or execution.
+type SUIEffects struct {
+	Digest  string
+	Success bool
+	Error   string
+	GasUsed *big.Int // computation + storage - rebate, in MIST
+}
+
+type suiEffectsJSON struct {
+	TransactionDigest string `json:"transactionDigest"`
+	Status            struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	} `json:"status"`
+	GasUsed struct {
+		ComputationCost string `json:"computationCost"`
+		StorageCost     string `json:"storageCost"`
+		StorageRebate   string `json:"storageRebate"`
+	} `json:"gasUsed"`
+}
+
+func (e suiEffectsJSON) effects(digest string) *SUIEffects {
+	gas := new(big.Int)
+	for i, v := range []string{e.GasUsed.ComputationCost, e.GasUsed.StorageCost, e.GasUsed.StorageRebate} {
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			continue
+		}
+		if i == 2 {
+			gas.Sub(gas, n)
+		} else {
+			gas.Add(gas, n)
+		}
+	}
+	if digest == "" {
+		digest = e.TransactionDigest
+	}
+	return &SUIEffects{
+		Digest:  digest,
+		Success: e.Status.Status == "success",
+		Error:   e.Status.Error,
+		GasUsed: gas,
+	}
+}
+
+// DryRun simulates base64 tx bytes without signing.
+func (c *SUIClient) DryRun(txBytes string) (*SUIEffects, error) {
+	var resp struct {
+		Effects suiEffectsJSON `json:"effects"`
+	}
+	if err := c.callInto(&resp, "sui_dryRunTransactionBlock", txBytes); err != nil {
+		return nil, err
+	}
+	return resp.Effects.effects(""), nil
+}
+
+// ExecuteTransaction submits signed tx bytes and waits for local execution.
+// A transaction that executed but failed returns its effects and an error.
+func (c *SUIClient) ExecuteTransaction(txBytes, signature string) (*SUIEffects, error) {
+	var resp struct {
+		Digest  string         `json:"digest"`
+		Effects suiEffectsJSON `json:"effects"`
+	}
+	if err := c.callInto(&resp, "sui_executeTransactionBlock", txBytes, []string{signature},
+		map[string]bool{"showEffects": true}, "WaitForLocalExecution"); err != nil {
+		return nil, err
+	}
+	fx := resp.Effects.effects(resp.Digest)
+	if !fx.Success {
+		return fx, fmt.Errorf("transaction failed: %s", fx.Error)
+	}
+	return fx, nil
+}
+
+func amountStrings(amounts []*big.Int) []string {
+	out := make([]string, len(amounts))
+	for i, a := range amounts {
+		out[i] = a.String()
+	}
+	return out
+}
+
 // --- internal ---
 
 type suiRequest struct {
@@ -98,6 +399,31 @@ type suiResponse struct {
 }
 
 func (c *SUIClient) call(method string, params ...interface{}) (interface{}, error) {
+	raw, err := c.callRaw(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	json.Unmarshal(raw, &result)
+	return result, nil
+}
+
+// callInto decodes the result of method into out.
+func (c *SUIClient) callInto(out interface{}, method string, params ...interface{}) error {
+	raw, err := c.callRaw(method, params...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("parsing SUI %s: %w", method, err)
+	}
+	return nil
+}
+
+func (c *SUIClient) callRaw(method string, params ...interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
 	body, _ := json.Marshal(suiRequest{
 		JSONRPC: "2.0",
 		ID:      1,
@@ -119,10 +445,7 @@ func (c *SUIClient) call(method string, params ...interface{}) (interface{}, err
 	if rpcResp.Error != nil {
 		return nil, fmt.Errorf("SUI RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
 	}
-
-	var result interface{}
-	json.Unmarshal(rpcResp.Result, &result)
-	return result, nil
+	return rpcResp.Result, nil
 }
 
 func suiMistToSUI(mist *big.Int) string {
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -176,3 +177,160 @@ func TestSUIClientBalanceInvalidMist(t *testing.T) {
 	require.NoError(t, err) // falls back to 0, no error
 	assert.Equal(t, "0.000000000", bal.ETH)
 }
+
+// ---------------------------------------------------------------------------
+// SUIClient — coins, objects, history and transfers
+// ---------------------------------------------------------------------------
+
+// suiMethodServer answers each JSON-RPC method with a canned result (or a
+// sequence of results for paged calls) and records the params.
+func suiMethodServer(t *testing.T, results map[string][]string, calls *[]string) *httptest.Server {
+	t.Helper()
+	return suiServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		params, _ := json.Marshal(req.Params)
+		if calls != nil {
+			*calls = append(*calls, req.Method+" "+string(params))
+		}
+		queue := results[req.Method]
+		if len(queue) == 0 {
+			w.Write([]byte(suiErrorResp(-32601, "Method not found"))) //nolint:errcheck
+			return
+		}
+		res := queue[0]
+		if len(queue) > 1 {
+			results[req.Method] = queue[1:]
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%s}`, res)
+	})
+}
+
+func TestSUIClientGetAllBalances(t *testing.T) {
+	srv := suiMethodServer(t, map[string][]string{
+		"suix_getAllBalances": {`[{"coinType":"0x2::sui::SUI","coinObjectCount":3,"totalBalance":"1500000000","lockedBalance":{}},{"coinType":"0xabc::usdc::USDC","coinObjectCount":1,"totalBalance":"42"}]`},
+	}, nil)
+	defer srv.Close()
+
+	bals, err := NewSUIClient(srv.URL).GetAllBalances("0x1")
+	require.NoError(t, err)
+	require.Len(t, bals, 2)
+	assert.Equal(t, SUICoinType, bals[0].CoinType)
+	assert.Equal(t, 3, bals[0].Objects)
+	assert.Equal(t, big.NewInt(1_500_000_000), bals[0].Total)
+	assert.Equal(t, big.NewInt(42), bals[1].Total)
+}
+
+func TestSUIClientGetCoinMetadata(t *testing.T) {
+	srv := suiMethodServer(t, map[string][]string{
+		"suix_getCoinMetadata": {`{"decimals":6,"symbol":"USDC","name":"USD Coin"}`, `null`},
+	}, nil)
+	defer srv.Close()
+
+	c := NewSUIClient(srv.URL)
+	md, err := c.GetCoinMetadata("0xabc::usdc::USDC")
+	require.NoError(t, err)
+	assert.Equal(t, &SUICoinMetadata{Decimals: 6, Symbol: "USDC", Name: "USD Coin"}, md)
+
+	md, err = c.GetCoinMetadata("0xdef::x::X")
+	require.NoError(t, err)
+	assert.Nil(t, md)
+}
+
+func TestSUIClientGetCoinsPagesAndSorts(t *testing.T) {
+	var calls []string
+	srv := suiMethodServer(t, map[string][]string{
+		"suix_getCoins": {
+			`{"data":[{"coinObjectId":"0xa","balance":"5"}],"nextCursor":"0xa","hasNextPage":true}`,
+			`{"data":[{"coinObjectId":"0xb","balance":"50"}],"nextCursor":null,"hasNextPage":false}`,
+		},
+	}, &calls)
+	defer srv.Close()
+
+	coins, err := NewSUIClient(srv.URL).GetCoins("0x1", SUICoinType)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[1], `"0xa"`, "second page uses the cursor")
+	require.Len(t, coins, 2)
+	assert.Equal(t, "0xb", coins[0].ObjectID, "largest coin first")
+}
+
+func TestSUIClientGetOwnedObjects(t *testing.T) {
+	srv := suiMethodServer(t, map[string][]string{
+		"suix_getOwnedObjects": {`{"data":[{"data":{"objectId":"0xo1","version":"7","type":"0x2::coin::Coin<0x2::sui::SUI>"}},{"data":{"objectId":"0xo2","version":"9","type":"0xnft::Hero"}}],"nextCursor":"0xo2","hasNextPage":true}`},
+	}, nil)
+	defer srv.Close()
+
+	objs, err := NewSUIClient(srv.URL).GetOwnedObjects("0x1", 2)
+	require.NoError(t, err)
+	require.Len(t, objs, 2, "stops at the limit even with more pages")
+	assert.Equal(t, SUIObject{ObjectID: "0xo2", Version: "9", Type: "0xnft::Hero"}, objs[1])
+}
+
+func TestSUIClientQueryTransactionBlocksMergesDirections(t *testing.T) {
+	var calls []string
+	srv := suiMethodServer(t, map[string][]string{
+		"suix_queryTransactionBlocks": {
+			`{"data":[{"digest":"D1","timestampMs":"1700000000000","checkpoint":"10","transaction":{"data":{"sender":"0x1"}},"effects":{"status":{"status":"success"}}}]}`,
+			`{"data":[{"digest":"D2","timestampMs":"1700000100000","checkpoint":"11","transaction":{"data":{"sender":"0x2"}},"effects":{"status":{"status":"failure"}}}, {"digest":"D1","timestampMs":"1700000000000"}]}`,
+		},
+	}, &calls)
+	defer srv.Close()
+
+	txs, err := NewSUIClient(srv.URL).QueryTransactionBlocks("0x1", 10)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[0], "FromAddress")
+	assert.Contains(t, calls[1], "ToAddress")
+	require.Len(t, txs, 2)
+	assert.Equal(t, "D2", txs[0].Digest, "newest first")
+	assert.False(t, txs[0].Success)
+	assert.Equal(t, int64(1700000000), txs[1].Timestamp)
+	assert.Equal(t, uint64(10), txs[1].Checkpoint)
+}
+
+func TestSUIClientPaySuiAndExecute(t *testing.T) {
+	var calls []string
+	srv := suiMethodServer(t, map[string][]string{
+		"unsafe_paySui":               {`{"txBytes":"AAEC","gas":[],"inputObjects":[]}`},
+		"unsafe_pay":                  {`{"txBytes":"AAED"}`},
+		"sui_dryRunTransactionBlock":  {`{"effects":{"status":{"status":"success"},"gasUsed":{"computationCost":"1000","storageCost":"2000","storageRebate":"500"}}}`},
+		"sui_executeTransactionBlock": {`{"digest":"Dig1","effects":{"status":{"status":"success"},"gasUsed":{"computationCost":"1000","storageCost":"2000","storageRebate":"500"}}}`},
+	}, &calls)
+	defer srv.Close()
+
+	c := NewSUIClient(srv.URL)
+	txBytes, err := c.PaySui("0xme", []string{"0xc1"}, []string{"0xyou"}, []*big.Int{big.NewInt(123)}, SUIDefaultGasBudget)
+	require.NoError(t, err)
+	assert.Equal(t, "AAEC", txBytes)
+	assert.Contains(t, calls[0], `["0xme",["0xc1"],["0xyou"],["123"],"10000000"]`)
+
+	_, err = c.Pay("0xme", []string{"0xt1"}, []string{"0xyou"}, []*big.Int{big.NewInt(5)}, SUIDefaultGasBudget)
+	require.NoError(t, err)
+	assert.Contains(t, calls[1], `["0xme",["0xt1"],["0xyou"],["5"],null,"10000000"]`)
+
+	dry, err := c.DryRun(txBytes)
+	require.NoError(t, err)
+	assert.True(t, dry.Success)
+	assert.Equal(t, big.NewInt(2500), dry.GasUsed)
+
+	fx, err := c.ExecuteTransaction(txBytes, "sig")
+	require.NoError(t, err)
+	assert.Equal(t, "Dig1", fx.Digest)
+	assert.Contains(t, calls[3], `"WaitForLocalExecution"`)
+}
+
+func TestSUIClientExecuteFailure(t *testing.T) {
+	srv := suiMethodServer(t, map[string][]string{
+		"sui_executeTransactionBlock": {`{"digest":"Dig2","effects":{"status":{"status":"failure","error":"InsufficientGas"}}}`},
+	}, nil)
+	defer srv.Close()
+
+	fx, err := NewSUIClient(srv.URL).ExecuteTransaction("AA", "sig")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "InsufficientGas")
+	assert.Equal(t, "Dig2", fx.Digest)
+}
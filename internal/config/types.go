@@ -4,12 +4,18 @@ package config
 type Config struct {
 	DefaultNetwork string              `json:"default_network" mapstructure:"default_network"`
 	DefaultWallet  string              `json:"default_wallet"  mapstructure:"default_wallet"`
-	NetworkMode    string              `json:"network_mode"    mapstructure:"network_mode"`    // "mainnet" | "testnet"
-	RPCAlgorithm   string              `json:"rpc_algorithm"   mapstructure:"rpc_algorithm"`   // "fastest" | "round-robin" | "failover"
+	NetworkMode    string              `json:"network_mode"    mapstructure:"network_mode"`  // "mainnet" | "testnet"
+	RPCAlgorithm   string              `json:"rpc_algorithm"   mapstructure:"rpc_algorithm"` // "fastest" | "round-robin" | "failover"
 	PriceCurrency  string              `json:"price_currency"  mapstructure:"price_currency"`
-	WatchInterval  int                 `json:"watch_interval"  mapstructure:"watch_interval"`  // seconds
+	WatchInterval  int                 `json:"watch_interval"  mapstructure:"watch_interval"` // seconds
 	CustomRPCs     map[string][]string `json:"custom_rpcs"      mapstructure:"custom_rpcs"`
 
+	// PriceSources orders the token price sources tried by balance, allbal and
+	// portfolio: "coingecko", "defillama", "chainlink", "uniswap". Empty means
+	// all four in that order.
+	// Set via: w3cli config set-price-sources <a,b,...>
+	PriceSources []string `json:"price_sources,omitempty" mapstructure:"price_sources"`
+
 	// Explorer API keys — used to unlock higher rate limits.
 	// ExplorerAPIKey is a global fallback (e.g. an Etherscan V2 key works for
 	// all chains).  ExplorerAPIKeys overrides per chain (key = chain slug).
@@ -30,13 +36,13 @@ type Config struct {
 
 // Wallet represents a stored wallet entry.
 type Wallet struct {
-	Name       string `json:"name"`
-	Address    string `json:"address"`
-	Type       string `json:"type"` // "watch-only" | "signing"
-	KeyRef     string `json:"key_ref,omitempty"` // keychain reference for signing wallets
-	ChainType  string `json:"chain_type,omitempty"` // "evm" | "solana" | "sui"
-	IsDefault  bool   `json:"is_default"`
-	CreatedAt  string `json:"created_at"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	Type      string `json:"type"`                 // "watch-only" | "signing"
+	KeyRef    string `json:"key_ref,omitempty"`    // keychain reference for signing wallets
+	ChainType string `json:"chain_type,omitempty"` // "evm" | "solana" | "sui"
+	IsDefault bool   `json:"is_default"`
+	CreatedAt string `json:"created_at"`
 }
 
 // WalletsFile is the structure of wallets.json.
@@ -46,20 +52,20 @@ type WalletsFile struct {
 
 // ContractEntry is a registered contract.
 type ContractEntry struct {
-	Name    string          `json:"name"`
-	Network string          `json:"network"`
-	Address string          `json:"address"`
-	ABI     []ABIEntry      `json:"abi"`
-	ABIUrl  string          `json:"abi_url,omitempty"`
+	Name    string     `json:"name"`
+	Network string     `json:"network"`
+	Address string     `json:"address"`
+	ABI     []ABIEntry `json:"abi"`
+	ABIUrl  string     `json:"abi_url,omitempty"`
 }
 
 // ABIEntry is a single ABI function/event entry.
 type ABIEntry struct {
-	Name            string      `json:"name"`
-	Type            string      `json:"type"`
-	Inputs          []ABIParam  `json:"inputs"`
-	Outputs         []ABIParam  `json:"outputs"`
-	StateMutability string      `json:"stateMutability"`
+	Name            string     `json:"name"`
+	Type            string     `json:"type"`
+	Inputs          []ABIParam `json:"inputs"`
+	Outputs         []ABIParam `json:"outputs"`
+	StateMutability string     `json:"stateMutability"`
 }
 
 // ABIParam is a parameter in an ABI entry.
@@ -75,6 +81,8 @@ type ContractsFile struct {
 
 // SyncConfig is the structure of sync.json.
 type SyncConfig struct {
-	Source      string `json:"source"`
-	LastSynced  string `json:"last_synced"`
+	Source     string `json:"source"`
+	LastSynced string `json:"last_synced"`
+	Signer     string `json:"signer,omitempty"`  // pinned manifest signer; empty = unsigned manifests accepted
+	Version    int    `json:"version,omitempty"` // last applied manifest version
 }
@@ -0,0 +1,237 @@
+// Package contacts is the address book: labelled counterparties (exchanges,
+// multisigs, partner contracts) with tags, notes and per-chain addresses.
+package contacts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned when a label is not in the book.
+var ErrNotFound = errors.New("contact not found")
+
+// Contact is one address-book entry.
+type Contact struct {
+	Label   string            `json:"label"`
+	Address string            `json:"address,omitempty"` // default, used on any chain without an override
+	Chains  map[string]string `json:"chains,omitempty"`  // network name → address
+	Tags    []string          `json:"tags,omitempty"`
+	Notes   string            `json:"notes,omitempty"`
+	AddedAt string            `json:"added_at,omitempty"`
+}
+
+// AddressOn returns the contact's address on network: the per-chain address
+// when there is one, else the default.
+func (c *Contact) AddressOn(network string) string {
+	if a, ok := c.Chains[network]; ok {
+		return a
+	}
+	return c.Address
+}
+
+// Networks returns the networks with a per-chain address, sorted.
+func (c *Contact) Networks() []string {
+	out := make([]string, 0, len(c.Chains))
+	for n := range c.Chains {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// HasTag reports whether the contact carries tag (case-insensitive).
+func (c *Contact) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge folds other into c: a non-empty default address and per-chain
+// addresses overwrite, tags are unioned and notes are replaced when set.
+func (c *Contact) Merge(other *Contact) {
+	if other.Address != "" {
+		c.Address = other.Address
+	}
+	for n, a := range other.Chains {
+		if c.Chains == nil {
+			c.Chains = map[string]string{}
+		}
+		c.Chains[n] = a
+	}
+	for _, t := range other.Tags {
+		if !c.HasTag(t) {
+			c.Tags = append(c.Tags, t)
+		}
+	}
+	if other.Notes != "" {
+		c.Notes = other.Notes
+	}
+}
+
+// ValidateLabel rejects labels that would be mistaken for something else
+// where addresses are accepted: hex addresses, ENS names and source prefixes.
+func ValidateLabel(label string) error {
+	switch {
+	case label == "":
+		return fmt.Errorf("label is empty")
+	case strings.HasPrefix(label, "0x") || strings.HasPrefix(label, "0X"):
+		return fmt.Errorf("label %q looks like an address", label)
+	case strings.ContainsAny(label, ".:"):
+		return fmt.Errorf("label %q may not contain '.' or ':' (they mean ENS names and source prefixes)", label)
+	case strings.ContainsAny(label, " \t\n"):
+		return fmt.Errorf("label %q may not contain whitespace", label)
+	}
+	return nil
+}
+
+// Book stores contacts in a JSON file.
+type Book struct {
+	path     string
+	contacts map[string]*Contact // key: label
+}
+
+// NewBook creates a Book backed by a JSON file.
+func NewBook(path string) *Book {
+	return &Book{
+		path:     path,
+		contacts: make(map[string]*Contact),
+	}
+}
+
+// Load reads the book from disk. A missing file is an empty book.
+func (b *Book) Load() error {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []Contact
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parsing %s: %w", b.path, err)
+	}
+	for i := range entries {
+		b.contacts[entries[i].Label] = &entries[i]
+	}
+	return nil
+}
+
+// Save writes the book to disk, sorted by label.
+func (b *Book) Save() error {
+	all := b.All()
+	entries := make([]Contact, len(all))
+	for i, c := range all {
+		entries[i] = *c
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, append(data, '\n'), 0o600)
+}
+
+// Get returns the contact with label.
+func (b *Book) Get(label string) (*Contact, error) {
+	c, ok := b.contacts[label]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, label)
+	}
+	return c, nil
+}
+
+// Put adds or replaces a contact.
+func (b *Book) Put(c *Contact) {
+	b.contacts[c.Label] = c
+}
+
+// Remove deletes a contact.
+func (b *Book) Remove(label string) error {
+	if _, ok := b.contacts[label]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, label)
+	}
+	delete(b.contacts, label)
+	return nil
+}
+
+// All returns every contact sorted by label.
+func (b *Book) All() []*Contact {
+	out := make([]*Contact, 0, len(b.contacts))
+	for _, c := range b.contacts {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Label < out[j].Label })
+	return out
+}
+
+// LabelFor returns the label of the contact whose address on network is
+// address.
+func (b *Book) LabelFor(address, network string) (string, bool) {
+	for _, c := range b.All() {
+		if a := c.AddressOn(network); a != "" && sameAddress(a, address) {
+			return c.Label, true
+		}
+	}
+	return "", false
+}
+
+// Match is a contact address that looks like, but is not, another address.
+type Match struct {
+	Label   string
+	Address string
+}
+
+// Similar returns the contacts whose address on network looks like address
+// without being it — the signature of an address-poisoning attack.
+func (b *Book) Similar(address, network string) []Match {
+	var out []Match
+	for _, c := range b.All() {
+		if a := c.AddressOn(network); a != "" && LookAlike(a, address) {
+			out = append(out, Match{Label: c.Label, Address: a})
+		}
+	}
+	return out
+}
+
+// lookAlikeChars is how many leading and trailing characters two addresses
+// must share to count as look-alikes. Wallets and explorers usually show
+// about this much, which is what poisoning vanity addresses are ground to match.
+const lookAlikeChars = 4
+
+// LookAlike reports whether a and b are different addresses that share their
+// first and last few characters (after any 0x prefix).
+func LookAlike(a, b string) bool {
+	if sameAddress(a, b) {
+		return false
+	}
+	a, b = strip0x(a), strip0x(b)
+	if len(a) < 2*lookAlikeChars || len(b) < 2*lookAlikeChars {
+		return false
+	}
+	return strings.EqualFold(a[:lookAlikeChars], b[:lookAlikeChars]) &&
+		strings.EqualFold(a[len(a)-lookAlikeChars:], b[len(b)-lookAlikeChars:])
+}
+
+// sameAddress compares 0x addresses case-insensitively and anything else
+// (base58) exactly.
+func sameAddress(a, b string) bool {
+	if strings.HasPrefix(a, "0x") || strings.HasPrefix(a, "0X") {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+func strip0x(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s[2:]
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+package contacts
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	binance = "0x28C6c06298d514Db089934071355E5743bf21d60"
+	poison  = "0x28C6aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1d60"
+)
+
+func TestBookRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contacts.json")
+	b := NewBook(path)
+	require.NoError(t, b.Load())
+
+	b.Put(&Contact{Label: "binance", Address: binance, Tags: []string{"cex"}, Notes: "hot wallet"})
+	b.Put(&Contact{Label: "alice", Chains: map[string]string{"base": "0x1111111111111111111111111111111111111111"}})
+	require.NoError(t, b.Save())
+
+	b2 := NewBook(path)
+	require.NoError(t, b2.Load())
+	all := b2.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "alice", all[0].Label)
+	assert.Equal(t, "binance", all[1].Label)
+
+	c, err := b2.Get("binance")
+	require.NoError(t, err)
+	assert.Equal(t, "hot wallet", c.Notes)
+	assert.True(t, c.HasTag("CEX"))
+
+	require.NoError(t, b2.Remove("alice"))
+	_, err = b2.Get("alice")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(b2.Remove("alice"), ErrNotFound))
+}
+
+func TestAddressOn(t *testing.T) {
+	c := &Contact{
+		Label:   "treasury",
+		Address: "0x1111111111111111111111111111111111111111",
+		Chains:  map[string]string{"base": "0x2222222222222222222222222222222222222222"},
+	}
+	assert.Equal(t, "0x2222222222222222222222222222222222222222", c.AddressOn("base"))
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", c.AddressOn("ethereum"))
+	assert.Equal(t, []string{"base"}, c.Networks())
+}
+
+func TestLabelFor(t *testing.T) {
+	b := NewBook(filepath.Join(t.TempDir(), "contacts.json"))
+	b.Put(&Contact{Label: "binance", Address: binance})
+	b.Put(&Contact{Label: "solfriend", Chains: map[string]string{"solana": "7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV"}})
+
+	label, ok := b.LabelFor("0x28c6c06298d514db089934071355e5743bf21d60", "ethereum")
+	assert.True(t, ok)
+	assert.Equal(t, "binance", label)
+
+	label, ok = b.LabelFor("7EcDhSYGxXyscszYEp35KHN8vvw3svAuLKTzXwCFLtV", "solana")
+	assert.True(t, ok)
+	assert.Equal(t, "solfriend", label)
+
+	// Base58 is case-sensitive.
+	_, ok = b.LabelFor("7ecdhsygxxyscszyep35khn8vvw3svaulktzxwcfltv", "solana")
+	assert.False(t, ok)
+
+	_, ok = b.LabelFor(poison, "ethereum")
+	assert.False(t, ok)
+}
+
+func TestLookAlike(t *testing.T) {
+	assert.True(t, LookAlike(binance, poison))
+	assert.False(t, LookAlike(binance, binance))
+	assert.False(t, LookAlike(binance, "0x28c6c06298d514db089934071355e5743bf21d60"), "same address, different case")
+	assert.False(t, LookAlike(binance, "0x28C6aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1d61"))
+	assert.False(t, LookAlike(binance, "0x29C6aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1d60"))
+	assert.False(t, LookAlike("0x1", "0x1"))
+
+	b := NewBook(filepath.Join(t.TempDir(), "contacts.json"))
+	b.Put(&Contact{Label: "binance", Address: binance})
+	b.Put(&Contact{Label: "other", Address: "0x1111111111111111111111111111111111111111"})
+	matches := b.Similar(poison, "ethereum")
+	require.Len(t, matches, 1)
+	assert.Equal(t, "binance", matches[0].Label)
+	assert.Empty(t, b.Similar(binance, "ethereum"))
+}
+
+func TestValidateLabel(t *testing.T) {
+	assert.NoError(t, ValidateLabel("binance-hot"))
+	for _, bad := range []string{"", "0xabc", "vitalik.eth", "wallet:alice", "two words"} {
+		assert.Error(t, ValidateLabel(bad), bad)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := &Contact{Label: "x", Address: binance, Tags: []string{"cex"}}
+	c.Merge(&Contact{Chains: map[string]string{"base": poison}, Tags: []string{"CEX", "hot"}, Notes: "n"})
+	assert.Equal(t, binance, c.Address)
+	assert.Equal(t, poison, c.Chains["base"])
+	assert.Equal(t, []string{"cex", "hot"}, c.Tags)
+	assert.Equal(t, "n", c.Notes)
+}
+
+func TestParseImportCSV(t *testing.T) {
+	data := []byte(`label,address,network,tags,notes
+binance,` + binance + `,,cex;hot,main deposit
+alice,0x1111111111111111111111111111111111111111,ethereum,friend,
+alice,0x2222222222222222222222222222222222222222,base,friend|dev,
+
+`)
+	list, err := ParseImport(data)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	assert.Equal(t, "binance", list[0].Label)
+	assert.Equal(t, binance, list[0].Address)
+	assert.Equal(t, []string{"cex", "hot"}, list[0].Tags)
+	assert.Equal(t, "main deposit", list[0].Notes)
+
+	alice := list[1]
+	assert.Empty(t, alice.Address)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", alice.Chains["ethereum"])
+	assert.Equal(t, "0x2222222222222222222222222222222222222222", alice.Chains["base"])
+	assert.Equal(t, []string{"friend", "dev"}, alice.Tags)
+}
+
+func TestParseImportCSVErrors(t *testing.T) {
+	_, err := ParseImport([]byte("name,address\nx,0x1\n"))
+	assert.ErrorContains(t, err, "label column")
+
+	_, err = ParseImport([]byte("label,address\nx.eth,0x1\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = ParseImport([]byte("label,address\nx,\n"))
+	assert.ErrorContains(t, err, "missing address")
+}
+
+func TestParseImportJSON(t *testing.T) {
+	data := []byte(`[{"label":"binance","address":"` + binance + `","tags":["cex"]}]`)
+	list, err := ParseImport(data)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, binance, list[0].Address)
+
+	_, err = ParseImport([]byte(`[{"label":"0xbad","address":"x"}]`))
+	assert.ErrorContains(t, err, "entry 1")
+}
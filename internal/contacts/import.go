@@ -0,0 +1,100 @@
+package contacts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseImport reads contacts from a JSON array (the book's own format) or a
+// CSV with a header naming some of: label, address, network, tags, notes.
+// Tags in CSV are separated by ";" or "|". CSV rows sharing a label merge,
+// so one row per chain builds a multi-chain contact.
+func ParseImport(data []byte) ([]*Contact, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []*Contact
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %w", err)
+		}
+		for i, c := range list {
+			if err := ValidateLabel(c.Label); err != nil {
+				return nil, fmt.Errorf("entry %d: %w", i+1, err)
+			}
+		}
+		return list, nil
+	}
+	return parseCSV(trimmed)
+}
+
+func parseCSV(data []byte) ([]*Contact, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["label"]; !ok {
+		return nil, fmt.Errorf("CSV header must include a label column")
+	}
+	if _, ok := col["address"]; !ok {
+		return nil, fmt.Errorf("CSV header must include an address column")
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var out []*Contact
+	byLabel := map[string]*Contact{}
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		label, address := field(rec, "label"), field(rec, "address")
+		if label == "" && address == "" {
+			continue
+		}
+		if err := ValidateLabel(label); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if address == "" {
+			return nil, fmt.Errorf("line %d: missing address", line)
+		}
+
+		row := &Contact{Label: label, Notes: field(rec, "notes")}
+		if network := field(rec, "network"); network != "" {
+			row.Chains = map[string]string{network: address}
+		} else {
+			row.Address = address
+		}
+		for _, t := range strings.FieldsFunc(field(rec, "tags"), func(r rune) bool { return r == ';' || r == '|' }) {
+			if t = strings.TrimSpace(t); t != "" {
+				row.Tags = append(row.Tags, t)
+			}
+		}
+
+		if c, ok := byLabel[label]; ok {
+			c.Merge(row)
+			continue
+		}
+		byLabel[label] = row
+		out = append(out, row)
+	}
+	return out, nil
+}
@@ -0,0 +1,267 @@
+package contract
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// Value is a decoded ABI value. Scalars carry their text; arrays and tuples
+// carry their elements in Items.
+type Value struct {
+	Name  string  // parameter or tuple member name, "" for array elements
+	Type  string  // canonical type
+	Text  string  // scalars only
+	Items []Value // arrays and tuples only
+}
+
+// IsComposite reports whether the value is an array or a tuple.
+func (v Value) IsComposite() bool {
+	return v.Items != nil
+}
+
+// String returns the value on one line: tuples as "(a, b)" and arrays as
+// "[a, b]".
+func (v Value) String() string {
+	if !v.IsComposite() {
+		return v.Text
+	}
+	parts := make([]string, len(v.Items))
+	for i, it := range v.Items {
+		parts[i] = it.String()
+	}
+	if strings.HasPrefix(v.Type, "(") && !strings.HasSuffix(v.Type, "]") {
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// DecodeValues decodes ABI-encoded data (return data, or the data of an
+// event) into one Value per parameter.
+func DecodeValues(params []ABIParam, data []byte) ([]Value, error) {
+	types := make([]*abiType, len(params))
+	names := make([]string, len(params))
+	for i, p := range params {
+		t, err := parseABIParam(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", paramLabel(p, i), err)
+		}
+		types[i] = t
+		names[i] = p.Name
+	}
+	return decodeSequence(types, names, data)
+}
+
+// Topic returns the event's topic0: the keccak256 hash of its signature.
+func (e ABIEntry) Topic() string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(e.Signature()))
+	return "0x" + hex.EncodeToString(h.Sum(nil))
+}
+
+// DecodedEvent is a log decoded against an ABI event.
+type DecodedEvent struct {
+	Name      string
+	Signature string
+	Args      []Value // in declaration order, indexed and non-indexed
+}
+
+// DecodeLog decodes a log's topics and data against the events in abi.
+// Indexed strings, bytes, arrays and tuples are only stored as their hash,
+// which is returned as a bytes32 value.
+func DecodeLog(abi []ABIEntry, topics []string, data string) (*DecodedEvent, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+	var ev *ABIEntry
+	for i := range abi {
+		e := &abi[i]
+		if e.Type == "event" && !e.Anonymous && strings.EqualFold(e.Topic(), topics[0]) {
+			ev = e
+			break
+		}
+	}
+	if ev == nil {
+		return nil, fmt.Errorf("no event with topic %s in the ABI", topics[0])
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid log data: %w", ev.Name, err)
+	}
+	var plain []ABIParam
+	for _, p := range ev.Inputs {
+		if !p.Indexed {
+			plain = append(plain, p)
+		}
+	}
+	values, err := DecodeValues(plain, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ev.Name, err)
+	}
+
+	out := &DecodedEvent{Name: ev.Name, Signature: ev.Signature()}
+	topic := 1
+	for i, p := range ev.Inputs {
+		if !p.Indexed {
+			out.Args = append(out.Args, values[0])
+			values = values[1:]
+			continue
+		}
+		if topic >= len(topics) {
+			return nil, fmt.Errorf("%s: missing topic for indexed %s", ev.Name, paramLabel(p, i))
+		}
+		word, err := hex.DecodeString(strings.TrimPrefix(topics[topic], "0x"))
+		if err != nil || len(word) != 32 {
+			return nil, fmt.Errorf("%s: invalid topic %s", ev.Name, topics[topic])
+		}
+		topic++
+		t, err := parseABIParam(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ev.Name, err)
+		}
+		if t.dynamic() || t.kind == "array" || t.kind == "tuple" {
+			t = &abiType{kind: "bytesN", size: 32}
+		}
+		v, err := decodeValue(t, word, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ev.Name, err)
+		}
+		v.Name = p.Name
+		out.Args = append(out.Args, v)
+	}
+	return out, nil
+}
+
+// decodeSequence decodes a tuple body: static values in place, dynamic ones
+// at an offset relative to the start of data.
+func decodeSequence(types []*abiType, names []string, data []byte) ([]Value, error) {
+	out := make([]Value, len(types))
+	head := 0
+	for i, t := range types {
+		var (
+			v   Value
+			err error
+		)
+		if t.dynamic() {
+			var off int
+			if off, err = readLength(data, head); err == nil {
+				if off > len(data) {
+					err = fmt.Errorf("offset %d past the end of %d bytes", off, len(data))
+				} else {
+					v, err = decodeValue(t, data[off:], 0)
+				}
+			}
+		} else {
+			v, err = decodeValue(t, data, head)
+		}
+		if err != nil {
+			if names[i] != "" {
+				return nil, fmt.Errorf("%s: %w", names[i], err)
+			}
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		v.Name = names[i]
+		out[i] = v
+		head += t.headSize()
+	}
+	return out, nil
+}
+
+// decodeValue decodes t at pos in data. For dynamic types pos is 0 and data
+// starts at the value's own encoding.
+func decodeValue(t *abiType, data []byte, pos int) (Value, error) {
+	v := Value{Type: t.String()}
+	if pos > len(data) {
+		return v, fmt.Errorf("%s: data too short", t)
+	}
+	switch t.kind {
+	case "tuple":
+		body := data[pos:]
+		items, err := decodeSequence(t.fields, t.names, body)
+		if err != nil {
+			return v, err
+		}
+		v.Items = items
+		return v, nil
+	case "array":
+		n := t.length
+		body := data[pos:]
+		if n < 0 {
+			length, err := readLength(body, 0)
+			if err != nil {
+				return v, err
+			}
+			// Each element takes at least one word, which bounds n by the data.
+			if length > (len(body)-32)/32 {
+				return v, fmt.Errorf("%s: length %d exceeds the data", t, length)
+			}
+			n, body = length, body[32:]
+		}
+		types := make([]*abiType, n)
+		for i := range types {
+			types[i] = t.elem
+		}
+		items, err := decodeSequence(types, make([]string, n), body)
+		if err != nil {
+			return v, err
+		}
+		v.Items = items
+		return v, nil
+	case "bytes", "string":
+		length, err := readLength(data, pos)
+		if err != nil {
+			return v, err
+		}
+		if length > len(data)-pos-32 {
+			return v, fmt.Errorf("%s: length %d exceeds the data", t, length)
+		}
+		b := data[pos+32 : pos+32+length]
+		if t.kind == "string" {
+			v.Text = string(b)
+		} else {
+			v.Text = "0x" + hex.EncodeToString(b)
+		}
+		return v, nil
+	}
+
+	if pos+32 > len(data) {
+		return v, fmt.Errorf("%s: data too short", t)
+	}
+	word := data[pos : pos+32]
+	switch t.kind {
+	case "address":
+		v.Text = common.BytesToAddress(word[12:]).Hex()
+	case "bool":
+		v.Text = fmt.Sprintf("%t", word[31] != 0)
+	case "uint":
+		v.Text = new(big.Int).SetBytes(word).String()
+	case "int":
+		n := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		v.Text = n.String()
+	case "bytesN":
+		v.Text = "0x" + hex.EncodeToString(word[:t.size])
+	default:
+		return v, fmt.Errorf("unsupported ABI type %s", t)
+	}
+	return v, nil
+}
+
+// readLength reads the word at pos as a length or offset that fits in an int.
+func readLength(data []byte, pos int) (int, error) {
+	if pos+32 > len(data) {
+		return 0, fmt.Errorf("data too short at byte %d", pos)
+	}
+	n := new(big.Int).SetBytes(data[pos : pos+32])
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("offset or length %s out of range", n)
+	}
+	return int(n.Int64()), nil
+}
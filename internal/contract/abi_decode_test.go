@@ -0,0 +1,123 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeValuesRoundTrip(t *testing.T) {
+	abi, err := parseABI([]byte(fillABI))
+	require.NoError(t, err)
+	params := abi[0].Inputs
+	vals, err := ParseArgsJSON(params, `[[["0x1111111111111111111111111111111111111111", 5, [1, 2]], ["0x2222222222222222222222222222222222222222", 7, [3, 4]]], "hi"]`)
+	require.NoError(t, err)
+	data, err := EncodeArgs(params, vals)
+	require.NoError(t, err)
+
+	got, err := DecodeValues(params, data)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	orders := got[0]
+	assert.Equal(t, "orders", orders.Name)
+	assert.Equal(t, "(address,uint256,uint16[2])[]", orders.Type)
+	require.Len(t, orders.Items, 2)
+	second := orders.Items[1]
+	assert.Equal(t, "(0x2222222222222222222222222222222222222222, 7, [3, 4])", second.String())
+	assert.Equal(t, "maker", second.Items[0].Name)
+	assert.Equal(t, "amount", second.Items[1].Name)
+	assert.Equal(t, "uint16[2]", second.Items[2].Type)
+
+	assert.Equal(t, "memo", got[1].Name)
+	assert.Equal(t, "hi", got[1].String())
+	assert.False(t, got[1].IsComposite())
+}
+
+func TestDecodeValuesSpecExample(t *testing.T) {
+	g, err := ParseSignature("g(uint256[][],string[])")
+	require.NoError(t, err)
+	vals, err := ParseArgsJSON(g.Inputs, `[[[1, 2], [3]], ["one", "two", "three"]]`)
+	require.NoError(t, err)
+	data, err := EncodeArgs(g.Inputs, vals)
+	require.NoError(t, err)
+
+	got, err := DecodeValues(g.Inputs, data)
+	require.NoError(t, err)
+	assert.Equal(t, "[[1, 2], [3]]", got[0].String())
+	assert.Equal(t, "[one, two, three]", got[1].String())
+}
+
+func TestDecodeValuesScalars(t *testing.T) {
+	params := []ABIParam{{Type: "int8"}, {Type: "bool"}, {Type: "bytes4"}, {Type: "bytes"}, {Type: "address"}}
+	vals, err := ParseArgStrings(params, []string{"-5", "true", "0xdeadbeef", "0x0102", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"})
+	require.NoError(t, err)
+	data, err := EncodeArgs(params, vals)
+	require.NoError(t, err)
+
+	got, err := DecodeValues(params, data)
+	require.NoError(t, err)
+	texts := make([]string, len(got))
+	for i, v := range got {
+		texts[i] = v.String()
+	}
+	assert.Equal(t, []string{"-5", "true", "0xdeadbeef", "0x0102", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}, texts)
+
+	// Empty arrays are still composite.
+	arr, err := DecodeValues([]ABIParam{{Type: "uint256[]"}}, mustHex(t, words(`
+		0000000000000000000000000000000000000000000000000000000000000020
+		0000000000000000000000000000000000000000000000000000000000000000`)))
+	require.NoError(t, err)
+	assert.True(t, arr[0].IsComposite())
+	assert.Equal(t, "[]", arr[0].String())
+}
+
+func TestDecodeValuesMalformed(t *testing.T) {
+	_, err := DecodeValues([]ABIParam{{Name: "x", Type: "uint256"}}, []byte{1, 2})
+	assert.ErrorContains(t, err, "x: uint256: data too short")
+
+	// An array length far beyond the data must not allocate.
+	_, err = DecodeValues([]ABIParam{{Type: "uint256[]"}}, mustHex(t, words(`
+		0000000000000000000000000000000000000000000000000000000000000020
+		00000000000000000000000000000000000000000000000000000000ffffffff`)))
+	assert.Error(t, err)
+
+	_, err = DecodeValues([]ABIParam{{Type: "string"}}, mustHex(t, words(`
+		00000000000000000000000000000000000000000000000000000000000000ff`)))
+	assert.Error(t, err)
+}
+
+func TestDecodeLog(t *testing.T) {
+	transfer := erc20ABI[len(erc20ABI)-2]
+	require.Equal(t, "Transfer", transfer.Name)
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", transfer.Topic())
+
+	topics := []string{
+		transfer.Topic(),
+		"0x0000000000000000000000001111111111111111111111111111111111111111",
+		"0x0000000000000000000000002222222222222222222222222222222222222222",
+	}
+	ev, err := DecodeLog(erc20ABI, topics, "0x"+strings.Repeat("0", 62)+"64")
+	require.NoError(t, err)
+	assert.Equal(t, "Transfer", ev.Name)
+	assert.Equal(t, "Transfer(address,address,uint256)", ev.Signature)
+	require.Len(t, ev.Args, 3)
+	assert.Equal(t, "from", ev.Args[0].Name)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", ev.Args[0].Text)
+	assert.Equal(t, "value", ev.Args[2].Name)
+	assert.Equal(t, "100", ev.Args[2].Text)
+
+	_, err = DecodeLog(erc20ABI, topics[:2], "0x"+strings.Repeat("0", 64))
+	assert.ErrorContains(t, err, "missing topic")
+	_, err = DecodeLog(erc20ABI, []string{"0x" + strings.Repeat("ab", 32)}, "0x")
+	assert.ErrorContains(t, err, "no event")
+
+	// Indexed dynamic values are only available as their hash.
+	named := []ABIEntry{{Type: "event", Name: "Named", Inputs: []ABIParam{{Name: "name", Type: "string", Indexed: true}}}}
+	hash := "0x" + strings.Repeat("cd", 32)
+	ev, err = DecodeLog(named, []string{named[0].Topic(), hash}, "0x")
+	require.NoError(t, err)
+	assert.Equal(t, hash, ev.Args[0].Text)
+}
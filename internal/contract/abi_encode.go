@@ -0,0 +1,556 @@
+package contract
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// abiType is a parsed ABI type, with tuples and arrays nested to any depth.
+type abiType struct {
+	kind   string // address, uint, int, bool, bytesN, bytes, string, array, tuple
+	size   int    // bits for uint/int, bytes for bytesN
+	length int    // array length; -1 for T[]
+	elem   *abiType
+	fields []*abiType
+	names  []string // tuple component names, "" when unknown
+}
+
+// String returns the canonical type, as used in function signatures.
+func (t *abiType) String() string {
+	switch t.kind {
+	case "uint", "int":
+		return t.kind + strconv.Itoa(t.size)
+	case "bytesN":
+		return "bytes" + strconv.Itoa(t.size)
+	case "array":
+		if t.length < 0 {
+			return t.elem.String() + "[]"
+		}
+		return t.elem.String() + "[" + strconv.Itoa(t.length) + "]"
+	case "tuple":
+		parts := make([]string, len(t.fields))
+		for i, f := range t.fields {
+			parts[i] = f.String()
+		}
+		return "(" + strings.Join(parts, ",") + ")"
+	}
+	return t.kind
+}
+
+func (t *abiType) dynamic() bool {
+	switch t.kind {
+	case "string", "bytes":
+		return true
+	case "array":
+		return t.length < 0 || t.elem.dynamic()
+	case "tuple":
+		for _, f := range t.fields {
+			if f.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize is the number of bytes t takes in the head of its enclosing tuple.
+func (t *abiType) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+	switch t.kind {
+	case "array":
+		return t.length * t.elem.headSize()
+	case "tuple":
+		n := 0
+		for _, f := range t.fields {
+			n += f.headSize()
+		}
+		return n
+	}
+	return 32
+}
+
+// parseABIParam parses a JSON ABI parameter ("tuple[]" with components) or a
+// signature type ("(address,uint256)[]").
+func parseABIParam(p ABIParam) (*abiType, error) {
+	typ := strings.TrimSpace(p.Type)
+	if !strings.HasPrefix(typ, "tuple") {
+		t, _, err := parseTypeString(typ)
+		return t, err
+	}
+	base := &abiType{kind: "tuple"}
+	for _, c := range p.Components {
+		f, err := parseABIParam(c)
+		if err != nil {
+			return nil, err
+		}
+		base.fields = append(base.fields, f)
+		base.names = append(base.names, c.Name)
+	}
+	t, _, err := parseArraySuffix(base, typ[len("tuple"):])
+	return t, err
+}
+
+// parseTypeString parses a type as written in a signature, optionally
+// followed by a parameter name: "(address to, uint256 amount)[] orders".
+func parseTypeString(s string) (*abiType, string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") {
+		params, rest, err := splitTypeList(s)
+		if err != nil {
+			return nil, "", err
+		}
+		base := &abiType{kind: "tuple"}
+		for _, p := range params {
+			f, name, err := parseTypeString(p.Type)
+			if err != nil {
+				return nil, "", err
+			}
+			base.fields = append(base.fields, f)
+			base.names = append(base.names, name)
+		}
+		return parseArraySuffix(base, rest)
+	}
+	end := strings.IndexAny(s, "[ \t")
+	if end < 0 {
+		end = len(s)
+	}
+	base, err := parseElementaryType(s[:end])
+	if err != nil {
+		return nil, "", err
+	}
+	return parseArraySuffix(base, s[end:])
+}
+
+// parseArraySuffix applies "[]" and "[n]" suffixes to base and returns the
+// parameter name after them, skipping data locations and "payable".
+func parseArraySuffix(base *abiType, rest string) (*abiType, string, error) {
+	t := base
+	rest = strings.TrimSpace(rest)
+	for strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated [ in type %s%s", t, rest)
+		}
+		length := -1
+		if n := strings.TrimSpace(rest[1:end]); n != "" {
+			v, err := strconv.Atoi(n)
+			if err != nil || v <= 0 {
+				return nil, "", fmt.Errorf("invalid array length %q", n)
+			}
+			length = v
+		}
+		t = &abiType{kind: "array", length: length, elem: t}
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	name := ""
+	for _, word := range strings.Fields(rest) {
+		switch word {
+		case "memory", "calldata", "storage", "indexed", "payable":
+		default:
+			name = word
+		}
+	}
+	return t, name, nil
+}
+
+func parseElementaryType(s string) (*abiType, error) {
+	switch s {
+	case "address", "bool", "string", "bytes":
+		return &abiType{kind: s}, nil
+	case "uint", "int":
+		return &abiType{kind: s, size: 256}, nil
+	case "byte":
+		return &abiType{kind: "bytesN", size: 1}, nil
+	}
+	for _, kind := range []string{"uint", "int", "bytes"} {
+		digits, ok := strings.CutPrefix(s, kind)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		if kind == "bytes" {
+			if n < 1 || n > 32 {
+				break
+			}
+			return &abiType{kind: "bytesN", size: n}, nil
+		}
+		if n < 8 || n > 256 || n%8 != 0 {
+			break
+		}
+		return &abiType{kind: kind, size: n}, nil
+	}
+	return nil, fmt.Errorf("unsupported ABI type %q", s)
+}
+
+// CanonicalType returns the type as used in function signatures: tuples are
+// spelled out as "(address,uint256)[]" and parameter names dropped. Types it
+// cannot parse are returned unchanged.
+func (p ABIParam) CanonicalType() string {
+	t, err := parseABIParam(p)
+	if err != nil {
+		return p.Type
+	}
+	return t.String()
+}
+
+// IsComposite reports whether p is an array or tuple, whose values are
+// given as JSON on the command line.
+func (p ABIParam) IsComposite() bool {
+	t, err := parseABIParam(p)
+	return err == nil && (t.kind == "array" || t.kind == "tuple")
+}
+
+// needsFullEncoding reports whether any of params is dynamic or nested.
+func needsFullEncoding(params []ABIParam) bool {
+	for _, p := range params {
+		t, err := parseABIParam(p)
+		if err == nil && (t.dynamic() || t.kind == "array" || t.kind == "tuple") {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeArgs ABI-encodes values for params, as in calldata after the
+// selector or constructor arguments after the init code. Values are Go
+// values as decoded from JSON: strings or json.Number for numbers, bool,
+// []any for arrays and tuples, map[string]any for tuples by member name.
+func EncodeArgs(params []ABIParam, values []any) ([]byte, error) {
+	if len(values) != len(params) {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", len(params), len(values))
+	}
+	types := make([]*abiType, len(params))
+	paths := make([]string, len(params))
+	for i, p := range params {
+		t, err := parseABIParam(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", paramLabel(p, i), err)
+		}
+		types[i] = t
+		paths[i] = paramLabel(p, i)
+	}
+	return encodeSequence(types, values, paths)
+}
+
+// EncodeFunctionCall returns fn's selector followed by its encoded arguments.
+func EncodeFunctionCall(fn ABIEntry, values []any) ([]byte, error) {
+	sel, err := hex.DecodeString(strings.TrimPrefix(fn.Selector(), "0x"))
+	if err != nil || len(sel) != 4 {
+		return nil, fmt.Errorf("%s has no selector", fn.Name)
+	}
+	args, err := EncodeArgs(fn.Inputs, values)
+	if err != nil {
+		return nil, err
+	}
+	return append(sel, args...), nil
+}
+
+// ParseArgsJSON decodes arguments for params given as a JSON array, or as an
+// object keyed by parameter name.
+func ParseArgsJSON(params []ABIParam, data string) ([]any, error) {
+	v, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+	}
+	switch v := v.(type) {
+	case []any:
+		return v, nil
+	case map[string]any:
+		names := make([]string, len(params))
+		for i, p := range params {
+			names[i] = p.Name
+		}
+		return valuesByName(v, names)
+	}
+	return nil, fmt.Errorf("JSON arguments must be an array or an object keyed by parameter name")
+}
+
+// ParseArgStrings converts command-line arguments for params: arrays and
+// tuples are given as JSON, everything else as plain text.
+func ParseArgStrings(params []ABIParam, args []string) ([]any, error) {
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", len(params), len(args))
+	}
+	values := make([]any, len(args))
+	for i, p := range params {
+		if !p.IsComposite() {
+			values[i] = args[i]
+			continue
+		}
+		v, err := decodeJSONValue(args[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s) must be JSON, e.g. [\"0x…\", 1]: %w", paramLabel(p, i), p.CanonicalType(), err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// FindFunction looks a function up by name or full signature. An
+// overloaded name is resolved by argument count when nargs >= 0.
+func FindFunction(abi []ABIEntry, nameOrSig string, nargs int) (ABIEntry, error) {
+	nameOrSig = strings.TrimSpace(nameOrSig)
+	if strings.Contains(nameOrSig, "(") {
+		want, err := ParseSignature(nameOrSig)
+		if err != nil {
+			return ABIEntry{}, fmt.Errorf("invalid signature %q: %w", nameOrSig, err)
+		}
+		for _, e := range abi {
+			if e.Type == "function" && e.Selector() == want.Selector() {
+				return e, nil
+			}
+		}
+		return ABIEntry{}, fmt.Errorf("function %s not found in ABI", want.Signature())
+	}
+
+	var matches []ABIEntry
+	for _, e := range abi {
+		if e.Type == "function" && e.Name == nameOrSig {
+			matches = append(matches, e)
+		}
+	}
+	if len(matches) > 1 && nargs >= 0 {
+		var byCount []ABIEntry
+		for _, e := range matches {
+			if len(e.Inputs) == nargs {
+				byCount = append(byCount, e)
+			}
+		}
+		if len(byCount) > 0 {
+			matches = byCount
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ABIEntry{}, fmt.Errorf("function %q not found in ABI", nameOrSig)
+	case 1:
+		return matches[0], nil
+	}
+	sigs := make([]string, len(matches))
+	for i, e := range matches {
+		sigs[i] = e.Signature()
+	}
+	return ABIEntry{}, fmt.Errorf("%q is overloaded — pass the full signature: %s", nameOrSig, strings.Join(sigs, ", "))
+}
+
+func paramLabel(p ABIParam, i int) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return "arg" + strconv.Itoa(i)
+}
+
+func decodeJSONValue(data string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// valuesByName orders the values of an object by names.
+func valuesByName(m map[string]any, names []string) ([]any, error) {
+	out := make([]any, len(names))
+	for i, n := range names {
+		if n == "" {
+			return nil, fmt.Errorf("member %d has no name — pass the values as an array", i)
+		}
+		v, ok := m[n]
+		if !ok {
+			return nil, fmt.Errorf("missing %q", n)
+		}
+		out[i] = v
+	}
+	if len(m) > len(names) {
+		for k := range m {
+			if !contains(names, k) {
+				return nil, fmt.Errorf("unknown member %q", k)
+			}
+		}
+	}
+	return out, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeSequence encodes values as a tuple of types: static values in the
+// head, dynamic ones in the tail with their offsets in the head.
+func encodeSequence(types []*abiType, values []any, paths []string) ([]byte, error) {
+	headLen := 0
+	for _, t := range types {
+		headLen += t.headSize()
+	}
+	head := make([]byte, 0, headLen)
+	var tail []byte
+	for i, t := range types {
+		enc, err := encodeValue(t, values[i], paths[i])
+		if err != nil {
+			return nil, err
+		}
+		if t.dynamic() {
+			head = appendUint256Big(head, big.NewInt(int64(headLen+len(tail))))
+			tail = append(tail, enc...)
+		} else {
+			head = append(head, enc...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+func encodeValue(t *abiType, v any, path string) ([]byte, error) {
+	switch t.kind {
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a JSON array for %s", path, t)
+		}
+		if t.length >= 0 && len(items) != t.length {
+			return nil, fmt.Errorf("%s: %s needs %d element(s), got %d", path, t, t.length, len(items))
+		}
+		types := make([]*abiType, len(items))
+		paths := make([]string, len(items))
+		for i := range items {
+			types[i] = t.elem
+			paths[i] = fmt.Sprintf("%s[%d]", path, i)
+		}
+		enc, err := encodeSequence(types, items, paths)
+		if err != nil {
+			return nil, err
+		}
+		if t.length < 0 {
+			enc = append(appendUint256Big(nil, big.NewInt(int64(len(items)))), enc...)
+		}
+		return enc, nil
+
+	case "tuple":
+		var items []any
+		switch v := v.(type) {
+		case []any:
+			items = v
+		case map[string]any:
+			var err error
+			if items, err = valuesByName(v, t.names); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s: expected a JSON array or object for %s", path, t)
+		}
+		if len(items) != len(t.fields) {
+			return nil, fmt.Errorf("%s: %s has %d member(s), got %d", path, t, len(t.fields), len(items))
+		}
+		paths := make([]string, len(items))
+		for i := range items {
+			if i < len(t.names) && t.names[i] != "" {
+				paths[i] = path + "." + t.names[i]
+			} else {
+				paths[i] = fmt.Sprintf("%s.%d", path, i)
+			}
+		}
+		return encodeSequence(t.fields, items, paths)
+	}
+
+	s, err := scalarString(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	enc, err := encodeScalar(t, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return enc, nil
+}
+
+func scalarString(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", fmt.Errorf("value is missing")
+	}
+	return "", fmt.Errorf("expected a single value, got %T", v)
+}
+
+func encodeScalar(t *abiType, s string) ([]byte, error) {
+	if t.kind != "string" {
+		s = strings.TrimSpace(s)
+	}
+	switch t.kind {
+	case "address":
+		b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+		if err != nil || len(b) != 20 {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+		return append(make([]byte, 12), b...), nil
+
+	case "uint", "int":
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		lo, hi := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(t.size))
+		if t.kind == "int" {
+			hi.Rsh(hi, 1)
+			lo.Neg(hi)
+		}
+		if n.Cmp(lo) < 0 || n.Cmp(hi) >= 0 {
+			return nil, fmt.Errorf("%s out of range for %s", s, t)
+		}
+		return padInt256(n), nil
+
+	case "bool":
+		word := make([]byte, 32)
+		switch strings.ToLower(s) {
+		case "true", "1":
+			word[31] = 1
+		case "false", "0":
+		default:
+			return nil, fmt.Errorf("invalid bool %q", s)
+		}
+		return word, nil
+
+	case "bytesN":
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil || len(b) > t.size {
+			return nil, fmt.Errorf("invalid %s %q — expected at most %d hex bytes", t, s, t.size)
+		}
+		word := make([]byte, 32)
+		copy(word, b)
+		return word, nil
+
+	case "bytes":
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes hex %q", s)
+		}
+		return encodeBytesData(b), nil
+
+	case "string":
+		return encodeBytesData([]byte(s)), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
@@ -0,0 +1,178 @@
+package contract
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// words joins 32-byte hex words, ignoring white space.
+func words(s string) string { return strings.Join(strings.Fields(s), "") }
+
+func TestEncodeFunctionCallSpecExamples(t *testing.T) {
+	// Examples from the Solidity ABI specification.
+	f, err := ParseSignature("f(uint256,uint32[],bytes10,bytes)")
+	require.NoError(t, err)
+	vals, err := ParseArgStrings(f.Inputs, []string{"0x123", "[1110, 1929]", "0x31323334353637383930", "0x48656c6c6f2c20776f726c6421"})
+	require.NoError(t, err)
+	got, err := EncodeFunctionCall(f, vals)
+	require.NoError(t, err)
+	assert.Equal(t, words(`8be65246
+		0000000000000000000000000000000000000000000000000000000000000123
+		0000000000000000000000000000000000000000000000000000000000000080
+		3132333435363738393000000000000000000000000000000000000000000000
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000456
+		0000000000000000000000000000000000000000000000000000000000000789
+		000000000000000000000000000000000000000000000000000000000000000d
+		48656c6c6f2c20776f726c642100000000000000000000000000000000000000`), hex.EncodeToString(got))
+
+	g, err := ParseSignature("g(uint256[][],string[])")
+	require.NoError(t, err)
+	vals, err = ParseArgsJSON(g.Inputs, `[[[1, 2], [3]], ["one", "two", "three"]]`)
+	require.NoError(t, err)
+	got, err = EncodeFunctionCall(g, vals)
+	require.NoError(t, err)
+	assert.Equal(t, words(`2289b18c
+		0000000000000000000000000000000000000000000000000000000000000040
+		0000000000000000000000000000000000000000000000000000000000000140
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000040
+		00000000000000000000000000000000000000000000000000000000000000a0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000060
+		00000000000000000000000000000000000000000000000000000000000000a0
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000003
+		6f6e650000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000003
+		74776f0000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000005
+		7468726565000000000000000000000000000000000000000000000000000000`), hex.EncodeToString(got))
+}
+
+const fillABI = `[{"type":"function","name":"fill","stateMutability":"nonpayable","inputs":[
+	{"name":"orders","type":"tuple[]","components":[
+		{"name":"maker","type":"address"},
+		{"name":"amount","type":"uint256"},
+		{"name":"ids","type":"uint16[2]"}]},
+	{"name":"memo","type":"string"}]}]`
+
+func TestEncodeTuplesFromJSONABI(t *testing.T) {
+	abi, err := parseABI([]byte(fillABI))
+	require.NoError(t, err)
+	fn := abi[0]
+	assert.Equal(t, "fill((address,uint256,uint16[2])[],string)", fn.Signature())
+	assert.Equal(t, "(address,uint256,uint16[2])[]", fn.Inputs[0].CanonicalType())
+	assert.True(t, fn.Inputs[0].IsComposite())
+	assert.False(t, fn.Inputs[1].IsComposite())
+
+	// Tuples as arrays or objects, numbers as JSON numbers or strings.
+	byPosition, err := ParseArgsJSON(fn.Inputs, `[[["0x1111111111111111111111111111111111111111", 5, [1, 2]]], "hi"]`)
+	require.NoError(t, err)
+	byName, err := ParseArgsJSON(fn.Inputs, `{"memo": "hi", "orders": [{"maker": "0x1111111111111111111111111111111111111111", "amount": "0x5", "ids": ["1", 2]}]}`)
+	require.NoError(t, err)
+	a, err := EncodeFunctionCall(fn, byPosition)
+	require.NoError(t, err)
+	b, err := EncodeFunctionCall(fn, byName)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// Same function from a signature with parameter names.
+	sig, err := ParseSignature("fill((address maker, uint256 amount, uint16[2] ids)[] orders, string memo)")
+	require.NoError(t, err)
+	assert.Equal(t, fn.Selector(), sig.Selector())
+	c, err := EncodeFunctionCall(sig, byPosition)
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+}
+
+func TestEncodeArgsErrors(t *testing.T) {
+	abi, err := parseABI([]byte(fillABI))
+	require.NoError(t, err)
+	params := abi[0].Inputs
+
+	cases := map[string]string{
+		`[[["0x11", 5, [1, 2]]], "hi"]`:                                           "orders[0].maker: invalid address",
+		`[[["0x1111111111111111111111111111111111111111", 5, [1, 70000]]], "hi"]`: "orders[0].ids[1]: 70000 out of range for uint16",
+		`[[["0x1111111111111111111111111111111111111111", 5, [1]]], "hi"]`:        "orders[0].ids: uint16[2] needs 2 element(s), got 1",
+		`[[["0x1111111111111111111111111111111111111111", 5]], "hi"]`:             "has 3 member(s), got 2",
+		`[[]]`: "expected 2 argument(s), got 1",
+	}
+	for in, want := range cases {
+		vals, err := ParseArgsJSON(params, in)
+		require.NoError(t, err, in)
+		_, err = EncodeArgs(params, vals)
+		require.Error(t, err, in)
+		assert.Contains(t, err.Error(), want, in)
+	}
+
+	// A JSON number for a string is taken as its text.
+	vals, err := ParseArgsJSON(params, `[[], 42]`)
+	require.NoError(t, err)
+	_, err = EncodeArgs(params, vals)
+	assert.NoError(t, err)
+
+	_, err = ParseArgsJSON(params, `{"orders": []}`)
+	assert.ErrorContains(t, err, `missing "memo"`)
+	_, err = ParseArgsJSON(params, `[1] [2]`)
+	assert.Error(t, err)
+	_, err = ParseArgStrings(params, []string{"not json", "hi"})
+	assert.ErrorContains(t, err, "orders ((address,uint256,uint16[2])[]) must be JSON")
+}
+
+func TestEncodeScalarRanges(t *testing.T) {
+	_, err := encodeScalar(&abiType{kind: "int", size: 8}, "-128")
+	assert.NoError(t, err)
+	_, err = encodeScalar(&abiType{kind: "int", size: 8}, "128")
+	assert.Error(t, err)
+	_, err = encodeScalar(&abiType{kind: "uint", size: 256}, "-1")
+	assert.Error(t, err)
+	_, err = encodeScalar(&abiType{kind: "bytesN", size: 4}, "0x0102030405")
+	assert.Error(t, err)
+	w, err := encodeScalar(&abiType{kind: "string"}, " padded ")
+	require.NoError(t, err)
+	assert.Equal(t, " padded ", string(w[32:40]))
+}
+
+func TestFindFunction(t *testing.T) {
+	abi := []ABIEntry{
+		{Type: "function", Name: "safeTransferFrom", Inputs: []ABIParam{{Type: "address"}, {Type: "address"}, {Type: "uint256"}}},
+		{Type: "function", Name: "safeTransferFrom", Inputs: []ABIParam{{Type: "address"}, {Type: "address"}, {Type: "uint256"}, {Type: "bytes"}}},
+		{Type: "function", Name: "ownerOf", Inputs: []ABIParam{{Type: "uint256"}}},
+	}
+	fn, err := FindFunction(abi, "ownerOf", -1)
+	require.NoError(t, err)
+	assert.Equal(t, "ownerOf(uint256)", fn.Signature())
+
+	_, err = FindFunction(abi, "safeTransferFrom", -1)
+	assert.ErrorContains(t, err, "overloaded")
+	fn, err = FindFunction(abi, "safeTransferFrom", 4)
+	require.NoError(t, err)
+	assert.Len(t, fn.Inputs, 4)
+	fn, err = FindFunction(abi, "safeTransferFrom(address,address,uint256)", -1)
+	require.NoError(t, err)
+	assert.Len(t, fn.Inputs, 3)
+
+	_, err = FindFunction(abi, "burn", -1)
+	assert.Error(t, err)
+}
+
+func TestEncodeCallDynamicArgs(t *testing.T) {
+	fn := &ABIEntry{Name: "setName", Type: "function", Inputs: []ABIParam{{Name: "name", Type: "string"}}}
+	got, err := encodeCall(fn, []string{"hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "0xc47f0027"+words(`
+		0000000000000000000000000000000000000000000000000000000000000020
+		0000000000000000000000000000000000000000000000000000000000000005
+		68656c6c6f000000000000000000000000000000000000000000000000000000`), got)
+}
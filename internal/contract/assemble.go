@@ -0,0 +1,86 @@
+package contract
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// opcodes maps mnemonics to opcodes (the inverse of opNames). It is built
+// on first use, after init has named the PUSH, DUP and SWAP opcodes.
+var opcodes = sync.OnceValue(func() map[string]byte {
+	m := make(map[string]byte)
+	for i, name := range opNames {
+		if name != "" {
+			m[name] = byte(i)
+		}
+	}
+	return m
+})
+
+// assemble turns EVM assembly into bytecode. Tokens are separated by white
+// space and ";" starts a comment:
+//
+//	PUSH1 0x20   an opcode, with its immediate for PUSHn
+//	@name        a JUMPDEST labelled name
+//	>name        PUSH2 of the label's address
+//	$name        a push of vars[name]; its length picks the PUSHn
+//
+// It panics on malformed source: callers assemble fixed code that is
+// covered by tests.
+func assemble(src string, vars map[string][]byte) []byte {
+	var tokens []string
+	for _, line := range strings.Split(src, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	// Two passes: the first only places labels.
+	labels := map[string]int{}
+	var code []byte
+	for pass := 0; pass < 2; pass++ {
+		code = code[:0]
+		for i := 0; i < len(tokens); i++ {
+			tok := tokens[i]
+			switch tok[0] {
+			case '@':
+				labels[tok[1:]] = len(code)
+				code = append(code, opJUMPDEST)
+			case '>':
+				addr, ok := labels[tok[1:]]
+				if !ok && pass == 1 {
+					panic(fmt.Sprintf("assemble: undefined label %q", tok[1:]))
+				}
+				code = append(code, opPUSH1+1, byte(addr>>8), byte(addr))
+			case '$':
+				v, ok := vars[tok[1:]]
+				if !ok || len(v) == 0 || len(v) > 32 {
+					panic(fmt.Sprintf("assemble: bad variable %q", tok[1:]))
+				}
+				code = append(code, opPUSH1+byte(len(v)-1))
+				code = append(code, v...)
+			default:
+				op, ok := opcodes()[tok]
+				if !ok {
+					panic(fmt.Sprintf("assemble: unknown opcode %q", tok))
+				}
+				code = append(code, op)
+				if !isPush(op) {
+					continue
+				}
+				if i++; i == len(tokens) {
+					panic(fmt.Sprintf("assemble: %s needs an immediate", tok))
+				}
+				n := int(op-opPUSH1) + 1
+				imm, err := hex.DecodeString(strings.TrimPrefix(tokens[i], "0x"))
+				if err != nil || len(imm) > n {
+					panic(fmt.Sprintf("assemble: bad immediate %q for %s", tokens[i], tok))
+				}
+				code = append(code, make([]byte, n-len(imm))...)
+				code = append(code, imm...)
+			}
+		}
+	}
+	return code
+}
@@ -0,0 +1,295 @@
+package contract
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+)
+
+// Instruction is one decoded EVM instruction.
+type Instruction struct {
+	PC  int
+	Op  byte
+	Arg []byte // PUSH immediate; shorter than the opcode says if the code is truncated
+}
+
+// Name returns the opcode mnemonic, or "INVALID(0x..)" for unassigned bytes.
+func (i Instruction) Name() string {
+	if name := opNames[i.Op]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("INVALID(0x%02x)", i.Op)
+}
+
+// String renders the instruction as "PUSH4 0xa9059cbb".
+func (i Instruction) String() string {
+	if len(i.Arg) == 0 {
+		return i.Name()
+	}
+	return i.Name() + " 0x" + hex.EncodeToString(i.Arg)
+}
+
+// Value returns the PUSH immediate as an int, or -1 if it does not fit.
+func (i Instruction) Value() int {
+	if len(i.Arg) == 0 || len(i.Arg) > 4 {
+		return -1
+	}
+	v := 0
+	for _, b := range i.Arg {
+		v = v<<8 | int(b)
+	}
+	return v
+}
+
+// Opcodes used by the bytecode analysis.
+const (
+	opSTOP         = 0x00
+	opEQ           = 0x14
+	opXOR          = 0x18
+	opCALLVALUE    = 0x34
+	opSSTORE       = 0x55
+	opJUMP         = 0x56
+	opJUMPI        = 0x57
+	opJUMPDEST     = 0x5b
+	opTSTORE       = 0x5d
+	opPUSH1        = 0x60
+	opPUSH4        = 0x63
+	opPUSH32       = 0x7f
+	opLOG0         = 0xa0
+	opLOG4         = 0xa4
+	opCREATE       = 0xf0
+	opCALL         = 0xf1
+	opCALLCODE     = 0xf2
+	opRETURN       = 0xf3
+	opDELEGATECALL = 0xf4
+	opCREATE2      = 0xf5
+	opREVERT       = 0xfd
+	opINVALID      = 0xfe
+	opSELFDESTRUCT = 0xff
+)
+
+var opNames = [256]string{
+	0x00: "STOP", 0x01: "ADD", 0x02: "MUL", 0x03: "SUB", 0x04: "DIV", 0x05: "SDIV",
+	0x06: "MOD", 0x07: "SMOD", 0x08: "ADDMOD", 0x09: "MULMOD", 0x0a: "EXP", 0x0b: "SIGNEXTEND",
+	0x10: "LT", 0x11: "GT", 0x12: "SLT", 0x13: "SGT", 0x14: "EQ", 0x15: "ISZERO",
+	0x16: "AND", 0x17: "OR", 0x18: "XOR", 0x19: "NOT", 0x1a: "BYTE", 0x1b: "SHL",
+	0x1c: "SHR", 0x1d: "SAR",
+	0x20: "KECCAK256",
+	0x30: "ADDRESS", 0x31: "BALANCE", 0x32: "ORIGIN", 0x33: "CALLER", 0x34: "CALLVALUE",
+	0x35: "CALLDATALOAD", 0x36: "CALLDATASIZE", 0x37: "CALLDATACOPY", 0x38: "CODESIZE",
+	0x39: "CODECOPY", 0x3a: "GASPRICE", 0x3b: "EXTCODESIZE", 0x3c: "EXTCODECOPY",
+	0x3d: "RETURNDATASIZE", 0x3e: "RETURNDATACOPY", 0x3f: "EXTCODEHASH",
+	0x40: "BLOCKHASH", 0x41: "COINBASE", 0x42: "TIMESTAMP", 0x43: "NUMBER",
+	0x44: "PREVRANDAO", 0x45: "GASLIMIT", 0x46: "CHAINID", 0x47: "SELFBALANCE",
+	0x48: "BASEFEE", 0x49: "BLOBHASH", 0x4a: "BLOBBASEFEE",
+	0x50: "POP", 0x51: "MLOAD", 0x52: "MSTORE", 0x53: "MSTORE8", 0x54: "SLOAD",
+	0x55: "SSTORE", 0x56: "JUMP", 0x57: "JUMPI", 0x58: "PC", 0x59: "MSIZE", 0x5a: "GAS",
+	0x5b: "JUMPDEST", 0x5c: "TLOAD", 0x5d: "TSTORE", 0x5e: "MCOPY", 0x5f: "PUSH0",
+	0xa0: "LOG0", 0xa1: "LOG1", 0xa2: "LOG2", 0xa3: "LOG3", 0xa4: "LOG4",
+	0xf0: "CREATE", 0xf1: "CALL", 0xf2: "CALLCODE", 0xf3: "RETURN", 0xf4: "DELEGATECALL",
+	0xf5: "CREATE2", 0xfa: "STATICCALL", 0xfd: "REVERT", 0xfe: "INVALID", 0xff: "SELFDESTRUCT",
+}
+
+func init() {
+	for i := 0; i < 32; i++ {
+		opNames[opPUSH1+i] = fmt.Sprintf("PUSH%d", i+1)
+	}
+	for i := 0; i < 16; i++ {
+		opNames[0x80+i] = fmt.Sprintf("DUP%d", i+1)
+		opNames[0x90+i] = fmt.Sprintf("SWAP%d", i+1)
+	}
+}
+
+// Disassemble decodes code into instructions.
+func Disassemble(code []byte) []Instruction {
+	var out []Instruction
+	for pc := 0; pc < len(code); {
+		op := code[pc]
+		ins := Instruction{PC: pc, Op: op}
+		pc++
+		if op >= opPUSH1 && op <= opPUSH32 {
+			end := pc + int(op-opPUSH1) + 1
+			if end > len(code) {
+				end = len(code)
+			}
+			ins.Arg = code[pc:end]
+			pc = end
+		}
+		out = append(out, ins)
+	}
+	return out
+}
+
+// DecodeCode parses 0x-prefixed hex bytecode as returned by eth_getCode.
+func DecodeCode(code string) ([]byte, error) {
+	code = strings.TrimPrefix(strings.TrimSpace(code), "0x")
+	if code == "0" {
+		code = ""
+	}
+	b, err := hex.DecodeString(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+	return b, nil
+}
+
+// SplitMetadata separates the CBOR metadata solc and vyper append to runtime
+// code (ending in its own 2-byte length) from the executable part. metadata
+// is nil when there is none.
+func SplitMetadata(code []byte) (runtime, metadata []byte) {
+	if len(code) < 2 {
+		return code, nil
+	}
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - n
+	if n == 0 || start < 0 {
+		return code, nil
+	}
+	// solc: a CBOR map of 1–5 entries ("ipfs", "bzzr1", "solc", ...);
+	// vyper ≥ 0.3.10: a CBOR array ending in {"vyper": [major, minor, patch]}.
+	if h := code[start]; (h < 0xa1 || h > 0xa5) && (h < 0x81 || h > 0x85) {
+		return code, nil
+	}
+	return code[:start], code[start:]
+}
+
+// Metadata is the compiler metadata appended to runtime code.
+type Metadata struct {
+	Compiler     string // "solc 0.8.26", "vyper 0.3.10"; "" if not recorded
+	IPFS         string // CIDv0 of the metadata JSON, e.g. "Qm..."
+	Swarm        string // bzzr0/bzzr1 hash (hex) used by older solc
+	Experimental bool
+}
+
+// DecodeMetadata decodes the CBOR block returned by SplitMetadata (with or
+// without its trailing length).
+func DecodeMetadata(meta []byte) (*Metadata, error) {
+	item, end, err := cborItem(meta, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	if end != len(meta) && end != len(meta)-2 {
+		return nil, fmt.Errorf("decoding metadata: trailing bytes")
+	}
+	// vyper's array form carries the version map as its last element.
+	if arr, ok := item.([]any); ok && len(arr) > 0 {
+		item = arr[len(arr)-1]
+	}
+	m, ok := item.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("decoding metadata: not a CBOR map")
+	}
+
+	md := &Metadata{}
+	switch v := m["solc"].(type) {
+	case []byte:
+		if len(v) == 3 {
+			md.Compiler = fmt.Sprintf("solc %d.%d.%d", v[0], v[1], v[2])
+		}
+	case string:
+		md.Compiler = "solc " + v
+	}
+	if v, ok := m["vyper"].([]any); ok && len(v) == 3 {
+		md.Compiler = fmt.Sprintf("vyper %v.%v.%v", v[0], v[1], v[2])
+	}
+	if v, ok := m["ipfs"].([]byte); ok {
+		md.IPFS = solana.Base58Encode(v)
+	}
+	for _, k := range []string{"bzzr1", "bzzr0"} {
+		if v, ok := m[k].([]byte); ok {
+			md.Swarm = hex.EncodeToString(v)
+			break
+		}
+	}
+	md.Experimental, _ = m["experimental"].(bool)
+	return md, nil
+}
+
+// cborItem decodes the CBOR item at pos: the subset compilers emit —
+// unsigned ints, byte and text strings, arrays, maps and booleans.
+func cborItem(b []byte, pos, depth int) (any, int, error) {
+	if depth > 8 {
+		return nil, 0, fmt.Errorf("nested too deep")
+	}
+	if pos >= len(b) {
+		return nil, 0, fmt.Errorf("truncated")
+	}
+	major, info := b[pos]>>5, b[pos]&0x1f
+	pos++
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info <= 27:
+		size := 1 << (info - 24)
+		if pos+size > len(b) {
+			return nil, 0, fmt.Errorf("truncated")
+		}
+		for _, c := range b[pos : pos+size] {
+			n = n<<8 | uint64(c)
+		}
+		pos += size
+	default:
+		return nil, 0, fmt.Errorf("unsupported length encoding")
+	}
+
+	switch major {
+	case 0:
+		return n, pos, nil
+	case 2, 3:
+		if n > uint64(len(b)-pos) {
+			return nil, 0, fmt.Errorf("truncated")
+		}
+		v := b[pos : pos+int(n)]
+		if major == 3 {
+			return string(v), pos + int(n), nil
+		}
+		return v, pos + int(n), nil
+	case 4:
+		if n > uint64(len(b)) {
+			return nil, 0, fmt.Errorf("truncated")
+		}
+		arr := make([]any, 0, n)
+		for i := uint64(0); i < n; i++ {
+			v, next, err := cborItem(b, pos, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			arr = append(arr, v)
+			pos = next
+		}
+		return arr, pos, nil
+	case 5:
+		if n > uint64(len(b)) {
+			return nil, 0, fmt.Errorf("truncated")
+		}
+		m := make(map[string]any, n)
+		for i := uint64(0); i < n; i++ {
+			k, next, err := cborItem(b, pos, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("non-string map key")
+			}
+			v, next, err := cborItem(b, next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			pos = next
+		}
+		return m, pos, nil
+	case 7:
+		switch info {
+		case 20:
+			return false, pos, nil
+		case 21:
+			return true, pos, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("unsupported CBOR type %d", major)
+}
@@ -0,0 +1,92 @@
+package contract
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	// PUSH1 0x80 PUSH1 0x40 MSTORE PUSH4 0xa9059cbb DUP1 0x0c PUSH2 0x01 (truncated)
+	code, _ := hex.DecodeString("6080604052" + "63a9059cbb" + "80" + "0c" + "6101")
+	ins := Disassemble(code)
+	require.Len(t, ins, 7)
+
+	assert.Equal(t, "PUSH1 0x80", ins[0].String())
+	assert.Equal(t, "MSTORE", ins[2].String())
+	assert.Equal(t, 5, ins[3].PC)
+	assert.Equal(t, "PUSH4 0xa9059cbb", ins[3].String())
+	assert.Equal(t, 0xa9059cbb, ins[3].Value())
+	assert.Equal(t, "DUP1", ins[4].Name())
+	assert.Equal(t, "INVALID(0x0c)", ins[5].Name())
+	assert.Equal(t, []byte{0x01}, ins[6].Arg, "truncated push keeps the bytes present")
+	assert.Equal(t, -1, ins[4].Value())
+}
+
+func TestDecodeCode(t *testing.T) {
+	b, err := DecodeCode("0x6080")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x80}, b)
+
+	for _, empty := range []string{"0x", "", "0x0"} {
+		b, err := DecodeCode(empty)
+		require.NoError(t, err)
+		assert.Empty(t, b)
+	}
+	_, err = DecodeCode("0xzz")
+	assert.Error(t, err)
+}
+
+func TestSplitMetadata(t *testing.T) {
+	code, _ := hex.DecodeString("6080604052" + "a264697066735822" + "0008")
+	runtime, meta := SplitMetadata(code)
+	assert.Equal(t, code[:5], runtime)
+	assert.Equal(t, code[5:], meta)
+
+	// No CBOR map where the length points.
+	plain, _ := hex.DecodeString("60806040520002")
+	runtime, meta = SplitMetadata(plain)
+	assert.Equal(t, plain, runtime)
+	assert.Nil(t, meta)
+
+	runtime, meta = SplitMetadata([]byte{0x00})
+	assert.Equal(t, []byte{0x00}, runtime)
+	assert.Nil(t, meta)
+}
+
+func TestDecodeMetadataSolc(t *testing.T) {
+	initCode := chain.W3TokenInitCode()
+	_, meta := SplitMetadata(initCode)
+	require.NotNil(t, meta)
+
+	md, err := DecodeMetadata(meta)
+	require.NoError(t, err)
+	assert.Equal(t, "solc 0.8.26", md.Compiler)
+	assert.True(t, strings.HasPrefix(md.IPFS, "Qm"), md.IPFS)
+	assert.Len(t, md.IPFS, 46)
+	assert.Empty(t, md.Swarm)
+	assert.False(t, md.Experimental)
+}
+
+func TestDecodeMetadataLegacyAndVyper(t *testing.T) {
+	// solc 0.5: {"bzzr1": <32 bytes>, "solc": 0x000511}
+	bzz := strings.Repeat("ab", 32)
+	legacy, _ := hex.DecodeString("a265627a7a72315820" + bzz + "64736f6c6343000511")
+	md, err := DecodeMetadata(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "solc 0.5.17", md.Compiler)
+	assert.Equal(t, bzz, md.Swarm)
+
+	// vyper 0.4: [.., {"vyper": [0, 4, 0]}]
+	vy, _ := hex.DecodeString("8319012a80" + "a165767970657283000400")
+	md, err = DecodeMetadata(vy)
+	require.NoError(t, err)
+	assert.Equal(t, "vyper 0.4.0", md.Compiler)
+
+	_, err = DecodeMetadata([]byte{0xa1, 0x64})
+	assert.Error(t, err)
+}
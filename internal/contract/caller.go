@@ -75,6 +75,20 @@ func (c *Caller) findFunction(name string) *ABIEntry {
 
 // encodeCall builds calldata: 4-byte selector + encoded args.
 func encodeCall(fn *ABIEntry, args []string) (string, error) {
+	// The word-per-argument encoding below only covers static types;
+	// strings, bytes, arrays and tuples go through the full encoder.
+	if needsFullEncoding(fn.Inputs) {
+		values, err := ParseArgStrings(fn.Inputs, args)
+		if err != nil {
+			return "", err
+		}
+		raw, err := EncodeFunctionCall(*fn, values)
+		if err != nil {
+			return "", err
+		}
+		return "0x" + hex.EncodeToString(raw), nil
+	}
+
 	selector := functionSelector(fn)
 
 	var encoded strings.Builder
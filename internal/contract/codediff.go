@@ -0,0 +1,204 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ByteRange is a span of runtime code, such as an immutable's slot or a
+// linked library address.
+type ByteRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// DeployedArtifact is the runtime code of a compiled contract.
+type DeployedArtifact struct {
+	Code  []byte      // library placeholders are zeroed
+	Links []ByteRange // library addresses filled in when linking
+	// Immutables lists the slots filled in at deploy time (zeros in Code).
+	// nil when the artifact does not record them (Hardhat), in which case
+	// comparisons fall back to a heuristic.
+	Immutables []ByteRange
+}
+
+// LoadDeployedArtifact reads the runtime bytecode from a Hardhat or Foundry
+// artifact, or from a solc standard-JSON contract output.
+func LoadDeployedArtifact(path string) (*DeployedArtifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read artifact file: %w", err)
+	}
+
+	var raw struct {
+		Deployed json.RawMessage `json:"deployedBytecode"`
+		EVM      *struct {
+			Deployed json.RawMessage `json:"deployedBytecode"`
+		} `json:"evm"` // solc standard JSON
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid artifact JSON: %w", err)
+	}
+	field := raw.Deployed
+	if len(field) == 0 && raw.EVM != nil {
+		field = raw.EVM.Deployed
+	}
+	if len(field) == 0 {
+		return nil, fmt.Errorf("artifact has no deployedBytecode: %s", path)
+	}
+
+	// Foundry and solc wrap the code in an object carrying the references.
+	var obj struct {
+		Object     string                 `json:"object"`
+		Immutables map[string][]ByteRange `json:"immutableReferences"`
+	}
+	var codeHex string
+	if err := json.Unmarshal(field, &codeHex); err != nil {
+		if err := json.Unmarshal(field, &obj); err != nil || obj.Object == "" {
+			return nil, fmt.Errorf("deployedBytecode is neither a hex string nor a {\"object\":\"0x...\"} object")
+		}
+		codeHex = obj.Object
+	}
+
+	codeHex, links := zeroLinkPlaceholders(strings.TrimPrefix(strings.TrimSpace(codeHex), "0x"))
+	code, err := hex.DecodeString(codeHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployedBytecode hex: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("artifact deployedBytecode is empty — is this an interface or abstract contract? %s", path)
+	}
+
+	a := &DeployedArtifact{Code: code, Links: links}
+	if obj.Immutables != nil {
+		a.Immutables = []ByteRange{}
+		for _, refs := range obj.Immutables {
+			a.Immutables = append(a.Immutables, refs...)
+		}
+	}
+	return a, nil
+}
+
+// zeroLinkPlaceholders replaces unlinked library placeholders
+// ("__$<hash>$__" or "__Lib____...") with zeros and returns their ranges.
+func zeroLinkPlaceholders(codeHex string) (string, []ByteRange) {
+	if !strings.Contains(codeHex, "__") {
+		return codeHex, nil
+	}
+	var ranges []ByteRange
+	b := []byte(codeHex)
+	for i := 0; i+40 <= len(b); {
+		if b[i] == '_' && b[i+1] == '_' && i%2 == 0 {
+			copy(b[i:i+40], strings.Repeat("0", 40))
+			ranges = append(ranges, ByteRange{Start: i / 2, Length: 20})
+			i += 40
+			continue
+		}
+		i++
+	}
+	return string(b), ranges
+}
+
+// CodeChange is one difference between two pieces of code.
+type CodeChange struct {
+	PC   int
+	A, B string // the instructions (or immutable values) on each side
+}
+
+// maxCodeChanges caps the differences CompareCode records.
+const maxCodeChanges = 20
+
+// CodeDiff is the result of CompareCode.
+type CodeDiff struct {
+	SizeA, SizeB    int  // executable sizes, metadata excluded
+	Identical       bool // byte-for-byte equal, metadata included
+	MetadataDiffers bool
+	Immutables      []CodeChange // differing immutable values, ignored for Match
+	Changes         []CodeChange // first maxCodeChanges differing instructions
+	ChangeCount     int
+}
+
+// Match reports whether the code is the same apart from metadata and
+// immutables.
+func (d *CodeDiff) Match() bool { return d.ChangeCount == 0 }
+
+// CompareCode compares two deployed runtime codes instruction by
+// instruction, ignoring the appended compiler metadata and immutable values.
+// Without an artifact the immutable slots are unknown, so every differing
+// PUSH32 immediate is treated as one — solc always loads immutables with
+// PUSH32 — and listed in Immutables so a changed constant is still visible.
+func CompareCode(a, b []byte) *CodeDiff {
+	return compareCode(a, b, nil, true)
+}
+
+// Compare compares deployed runtime code against the artifact, ignoring
+// metadata, linked library addresses and immutables.
+func (art *DeployedArtifact) Compare(code []byte) *CodeDiff {
+	masks := append(append([]ByteRange(nil), art.Immutables...), art.Links...)
+	return compareCode(code, art.Code, masks, art.Immutables == nil)
+}
+
+// compareCode masks the ranges on both sides, then compares instructions.
+// guessImmutables enables the PUSH32 heuristic.
+func compareCode(a, b []byte, masks []ByteRange, guessImmutables bool) *CodeDiff {
+	d := &CodeDiff{Identical: bytes.Equal(a, b)}
+	ra, ma := SplitMetadata(a)
+	rb, mb := SplitMetadata(b)
+	d.SizeA, d.SizeB = len(ra), len(rb)
+	d.MetadataDiffers = !bytes.Equal(ma, mb)
+	if d.Identical {
+		return d
+	}
+
+	if len(masks) > 0 {
+		ra = append([]byte(nil), ra...)
+		rb = append([]byte(nil), rb...)
+		for _, r := range masks {
+			if r.Start < 0 || r.Length <= 0 || r.Start+r.Length > len(ra) || r.Start+r.Length > len(rb) {
+				continue
+			}
+			va, vb := ra[r.Start:r.Start+r.Length], rb[r.Start:r.Start+r.Length]
+			if !bytes.Equal(va, vb) {
+				d.Immutables = append(d.Immutables, CodeChange{
+					PC: r.Start, A: "0x" + hex.EncodeToString(va), B: "0x" + hex.EncodeToString(vb),
+				})
+				copy(va, make([]byte, r.Length))
+				copy(vb, make([]byte, r.Length))
+			}
+		}
+	}
+
+	ia, ib := Disassemble(ra), Disassemble(rb)
+	n := max(len(ia), len(ib))
+	for i := 0; i < n; i++ {
+		var x, y Instruction
+		var sx, sy string
+		if i < len(ia) {
+			x, sx = ia[i], ia[i].String()
+		}
+		if i < len(ib) {
+			y, sy = ib[i], ib[i].String()
+		}
+		if sx == sy {
+			continue
+		}
+		pc := x.PC
+		if i >= len(ia) {
+			pc = y.PC
+		}
+		change := CodeChange{PC: pc, A: sx, B: sy}
+		if guessImmutables && x.Op == opPUSH32 && y.Op == opPUSH32 {
+			d.Immutables = append(d.Immutables, change)
+			continue
+		}
+		d.ChangeCount++
+		if len(d.Changes) < maxCodeChanges {
+			d.Changes = append(d.Changes, change)
+		}
+	}
+	return d
+}
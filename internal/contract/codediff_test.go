@@ -0,0 +1,148 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArtifact(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// Runtime code loading an immutable with PUSH32, followed by metadata.
+const (
+	diffPrefix = "6080604052" + "7f"
+	diffSuffix = "60005260206000f3"
+	diffMetaA  = "a1647465737441aa" + "0008"
+	diffMetaB  = "a1647465737441bb" + "0008"
+)
+
+func TestCompareCodeIdenticalAndMetadata(t *testing.T) {
+	imm := strings.Repeat("11", 32)
+	a := mustHex(t, diffPrefix+imm+diffSuffix+diffMetaA)
+
+	d := CompareCode(a, a)
+	assert.True(t, d.Identical)
+	assert.True(t, d.Match())
+	assert.False(t, d.MetadataDiffers)
+
+	b := mustHex(t, diffPrefix+imm+diffSuffix+diffMetaB)
+	d = CompareCode(a, b)
+	assert.False(t, d.Identical)
+	assert.True(t, d.Match())
+	assert.True(t, d.MetadataDiffers)
+	assert.Equal(t, len(a)-10, d.SizeA)
+}
+
+func TestCompareCodeImmutablesHeuristic(t *testing.T) {
+	a := mustHex(t, diffPrefix+strings.Repeat("11", 32)+diffSuffix)
+	b := mustHex(t, diffPrefix+strings.Repeat("22", 32)+diffSuffix)
+
+	d := CompareCode(a, b)
+	assert.True(t, d.Match())
+	require.Len(t, d.Immutables, 1)
+	assert.Equal(t, 5, d.Immutables[0].PC)
+	assert.Equal(t, "PUSH32 0x"+strings.Repeat("11", 32), d.Immutables[0].A)
+}
+
+func TestCompareCodeChanges(t *testing.T) {
+	a := mustHex(t, "6001600201"+"00")
+	b := mustHex(t, "6001600302"+"00"+"00")
+
+	d := CompareCode(a, b)
+	assert.False(t, d.Match())
+	assert.Equal(t, 3, d.ChangeCount)
+	assert.Equal(t, CodeChange{PC: 2, A: "PUSH1 0x02", B: "PUSH1 0x03"}, d.Changes[0])
+	assert.Equal(t, CodeChange{PC: 4, A: "ADD", B: "MUL"}, d.Changes[1])
+	assert.Equal(t, CodeChange{PC: 6, A: "", B: "STOP"}, d.Changes[2])
+
+	// Changes beyond the cap are counted, not kept.
+	long := bytes.Repeat([]byte{0x01}, maxCodeChanges+5)
+	d = CompareCode(long, bytes.Repeat([]byte{0x02}, maxCodeChanges+5))
+	assert.Equal(t, maxCodeChanges+5, d.ChangeCount)
+	assert.Len(t, d.Changes, maxCodeChanges)
+}
+
+func TestLoadDeployedArtifactFoundry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Vault.json")
+	zero := strings.Repeat("00", 32)
+	writeArtifact(t, path, `{
+		"abi": [],
+		"deployedBytecode": {
+			"object": "0x`+diffPrefix+zero+diffSuffix+`",
+			"immutableReferences": {"12": [{"start": 6, "length": 32}]}
+		}
+	}`)
+
+	art, err := LoadDeployedArtifact(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ByteRange{{Start: 6, Length: 32}}, art.Immutables)
+	assert.Empty(t, art.Links)
+
+	deployed := mustHex(t, diffPrefix+strings.Repeat("ab", 32)+diffSuffix+diffMetaA)
+	d := art.Compare(deployed)
+	assert.True(t, d.Match())
+	require.Len(t, d.Immutables, 1)
+	assert.Equal(t, "0x"+strings.Repeat("ab", 32), d.Immutables[0].A)
+	assert.Equal(t, "0x"+zero, d.Immutables[0].B)
+
+	// Known immutables disable the PUSH32 heuristic: a changed constant
+	// elsewhere is a real difference.
+	other := mustHex(t, "7f"+strings.Repeat("01", 32))
+	d = (&DeployedArtifact{Code: mustHex(t, "7f"+zero), Immutables: []ByteRange{}}).Compare(other)
+	assert.False(t, d.Match())
+}
+
+func TestLoadDeployedArtifactHardhatWithLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Lib.json")
+	placeholder := "__$" + strings.Repeat("a", 34) + "$__"
+	writeArtifact(t, path, `{"deployedBytecode": "0x73`+placeholder+`6000"}`)
+
+	art, err := LoadDeployedArtifact(path)
+	require.NoError(t, err)
+	assert.Nil(t, art.Immutables)
+	assert.Equal(t, []ByteRange{{Start: 1, Length: 20}}, art.Links)
+	assert.Equal(t, mustHex(t, "73"+strings.Repeat("00", 20)+"6000"), art.Code)
+
+	d := art.Compare(mustHex(t, "73"+strings.Repeat("cd", 20)+"6000"))
+	assert.True(t, d.Match())
+}
+
+func TestLoadDeployedArtifactSolcOutputAndErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solc.json")
+	writeArtifact(t, path, `{"evm": {"deployedBytecode": {"object": "6080604052", "immutableReferences": {}}}}`)
+	art, err := LoadDeployedArtifact(path)
+	require.NoError(t, err)
+	assert.Equal(t, mustHex(t, "6080604052"), art.Code)
+	assert.NotNil(t, art.Immutables)
+
+	for name, body := range map[string]string{
+		"none.json":   `{"abi": []}`,
+		"empty.json":  `{"deployedBytecode": "0x"}`,
+		"bad.json":    `{"deployedBytecode": 5}`,
+		"badhex.json": `{"deployedBytecode": "0xzz"}`,
+	} {
+		p := filepath.Join(dir, name)
+		writeArtifact(t, p, body)
+		_, err := LoadDeployedArtifact(p)
+		assert.Error(t, err, name)
+	}
+	_, err = LoadDeployedArtifact(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,361 @@
+package contract
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deployment record formats understood by ScanDeployments.
+const (
+	FormatHardhatDeploy = "hardhat-deploy" // deployments/<network>/<Name>.json
+	FormatFoundry       = "foundry"        // broadcast/<Script>/<chainId>/run-latest.json
+	FormatIgnition      = "ignition"       // ignition/deployments/chain-<id>/deployed_addresses.json
+)
+
+// Deployment is one contract found in a deployment record.
+type Deployment struct {
+	Name       string
+	ChainID    int64  // 0 when the record doesn't say (old hardhat-deploy folders)
+	Network    string // hardhat-deploy folder name, used when ChainID is 0
+	Address    string
+	ABI        []ABIEntry
+	TxHash     string
+	Deployer   string
+	DeployedAt string // RFC3339, when the record has a timestamp
+	Format     string
+	Source     string // record file
+	Artifact   string // compiler artifact, when found (used for verification)
+}
+
+// skipDirs are never descended into: dependencies, build output and
+// compiler inputs hold plenty of JSON but no deployment records.
+var skipDirs = map[string]bool{
+	"node_modules": true, ".git": true, "lib": true, "cache": true, "out": true,
+	"artifacts": true, "typechain": true, "typechain-types": true, "solcInputs": true,
+}
+
+// ScanDeployments walks dir (a project root or any directory inside one) and
+// returns every contract in the hardhat-deploy, Foundry broadcast and Hardhat
+// Ignition records it finds. Records that can't be read become warnings.
+func ScanDeployments(dir string) ([]Deployment, []string, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, nil, err
+	}
+
+	var out []Deployment
+	var warnings []string
+	warn := func(path string, err error) {
+		warnings = append(warnings, fmt.Sprintf("%s: %v", path, err))
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		parent := filepath.Base(filepath.Dir(path))
+		switch {
+		case d.Name() == "run-latest.json" && isNumeric(parent):
+			deps, err := readFoundryRun(path)
+			if err != nil {
+				warn(path, err)
+			}
+			out = append(out, deps...)
+		case d.Name() == "deployed_addresses.json" && strings.HasPrefix(parent, "chain-"):
+			deps, err := readIgnition(path)
+			if err != nil {
+				warn(path, err)
+			}
+			out = append(out, deps...)
+		case strings.HasSuffix(d.Name(), ".json") && isHardhatDeployDir(filepath.Dir(path)):
+			dep, ok, err := readHardhatDeploy(path)
+			if err != nil {
+				warn(path, err)
+			} else if ok {
+				out = append(out, dep)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, warnings, err
+	}
+	return uniqueNames(out), warnings, nil
+}
+
+// ── hardhat-deploy ───────────────────────────────────────────────────────────
+
+// isHardhatDeployDir reports whether dir is a hardhat-deploy network folder:
+// it has a .chainId file, or sits directly under a "deployments" folder.
+func isHardhatDeployDir(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".chainId")); err == nil {
+		return true
+	}
+	return filepath.Base(filepath.Dir(dir)) == "deployments"
+}
+
+func readHardhatDeploy(path string) (Deployment, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Deployment{}, false, err
+	}
+	var rec struct {
+		Address         string          `json:"address"`
+		ABI             json.RawMessage `json:"abi"`
+		TransactionHash string          `json:"transactionHash"`
+		Receipt         struct {
+			From            string `json:"from"`
+			TransactionHash string `json:"transactionHash"`
+		} `json:"receipt"`
+	}
+	// Other JSON in the folder (e.g. .migrations.json) is not a deployment.
+	if json.Unmarshal(data, &rec) != nil || rec.Address == "" || len(rec.ABI) == 0 {
+		return Deployment{}, false, nil
+	}
+	abi, err := parseABI(rec.ABI)
+	if err != nil {
+		return Deployment{}, false, err
+	}
+
+	dir := filepath.Dir(path)
+	dep := Deployment{
+		Name:     strings.TrimSuffix(filepath.Base(path), ".json"),
+		Network:  filepath.Base(dir),
+		Address:  rec.Address,
+		ABI:      abi,
+		TxHash:   rec.TransactionHash,
+		Deployer: rec.Receipt.From,
+		Format:   FormatHardhatDeploy,
+		Source:   path,
+	}
+	if dep.TxHash == "" {
+		dep.TxHash = rec.Receipt.TransactionHash
+	}
+	if raw, err := os.ReadFile(filepath.Join(dir, ".chainId")); err == nil {
+		dep.ChainID, _ = strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+	}
+	return dep, true, nil
+}
+
+// ── Foundry ──────────────────────────────────────────────────────────────────
+
+func readFoundryRun(path string) ([]Deployment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run struct {
+		Transactions []struct {
+			Hash            string `json:"hash"`
+			TransactionType string `json:"transactionType"`
+			ContractName    string `json:"contractName"`
+			ContractAddress string `json:"contractAddress"`
+			Transaction     struct {
+				From string `json:"from"`
+			} `json:"transaction"`
+		} `json:"transactions"`
+		Chain     int64 `json:"chain"`
+		Timestamp int64 `json:"timestamp"` // unix millis (seconds in old versions)
+	}
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parsing broadcast: %w", err)
+	}
+	chainID := run.Chain
+	if chainID == 0 {
+		chainID, _ = strconv.ParseInt(filepath.Base(filepath.Dir(path)), 10, 64)
+	}
+	deployedAt := ""
+	if ts := run.Timestamp; ts > 0 {
+		if ts > 1e12 {
+			ts /= 1000
+		}
+		deployedAt = time.Unix(ts, 0).UTC().Format(time.RFC3339)
+	}
+
+	// broadcast/<Script>/<chainId>/run-latest.json → the project root is
+	// above broadcast/, with the compiled artifacts in out/.
+	root := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(path))))
+
+	var out []Deployment
+	seen := map[string]bool{}
+	for _, tx := range run.Transactions {
+		if tx.TransactionType != "CREATE" && tx.TransactionType != "CREATE2" {
+			continue
+		}
+		if tx.ContractName == "" || tx.ContractAddress == "" || seen[strings.ToLower(tx.ContractAddress)] {
+			continue
+		}
+		seen[strings.ToLower(tx.ContractAddress)] = true
+		artifact, abi, _ := foundryArtifactABI(root, tx.ContractName)
+		out = append(out, Deployment{
+			Name:       tx.ContractName,
+			ChainID:    chainID,
+			Address:    tx.ContractAddress,
+			ABI:        abi,
+			TxHash:     tx.Hash,
+			Deployer:   tx.Transaction.From,
+			DeployedAt: deployedAt,
+			Format:     FormatFoundry,
+			Source:     path,
+			Artifact:   artifact,
+		})
+	}
+	return out, nil
+}
+
+// foundryArtifactABI finds out/<File>.sol/<name>.json under root and returns
+// its path and ABI.
+func foundryArtifactABI(root, name string) (string, []ABIEntry, error) {
+	matches, _ := filepath.Glob(filepath.Join(root, "out", "*", name+".json"))
+	if len(matches) == 0 {
+		return "", nil, fmt.Errorf("no artifact for %s in %s", name, filepath.Join(root, "out"))
+	}
+	sort.Strings(matches)
+	abi, err := LoadFromArtifact(matches[0])
+	return matches[0], abi, err
+}
+
+// ── Hardhat Ignition ─────────────────────────────────────────────────────────
+
+func readIgnition(path string) ([]Deployment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var addresses map[string]string // "Module#Contract" → address
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("parsing deployed addresses: %w", err)
+	}
+	dir := filepath.Dir(path)
+	chainID, _ := strconv.ParseInt(strings.TrimPrefix(filepath.Base(dir), "chain-"), 10, 64)
+	hashes, senders := ignitionJournal(filepath.Join(dir, "journal.jsonl"))
+
+	var out []Deployment
+	for _, futureID := range sortedKeys(addresses) {
+		name := futureID
+		if _, after, ok := strings.Cut(futureID, "#"); ok {
+			name = after
+		}
+		artifact := filepath.Join(dir, "artifacts", futureID+".json")
+		abi, err := LoadFromArtifact(artifact)
+		if err != nil {
+			artifact = ""
+		}
+		out = append(out, Deployment{
+			Name:     name,
+			ChainID:  chainID,
+			Address:  addresses[futureID],
+			ABI:      abi,
+			TxHash:   hashes[futureID],
+			Deployer: senders[futureID],
+			Format:   FormatIgnition,
+			Source:   path,
+			Artifact: artifact,
+		})
+	}
+	return out, nil
+}
+
+// ignitionJournal reads the last sent transaction hash and sender of each
+// future from an Ignition journal. A missing journal yields empty maps.
+func ignitionJournal(path string) (hashes, senders map[string]string) {
+	hashes, senders = map[string]string{}, map[string]string{}
+	f, err := os.Open(path)
+	if err != nil {
+		return hashes, senders
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var line struct {
+			Type        string `json:"type"`
+			FutureID    string `json:"futureId"`
+			From        string `json:"from"`
+			Transaction struct {
+				Hash string `json:"hash"`
+			} `json:"transaction"`
+		}
+		if json.Unmarshal(sc.Bytes(), &line) != nil || line.FutureID == "" {
+			continue
+		}
+		switch line.Type {
+		case "TRANSACTION_SEND":
+			if line.Transaction.Hash != "" {
+				hashes[line.FutureID] = line.Transaction.Hash
+			}
+		case "NETWORK_INTERACTION_REQUEST":
+			if line.From != "" {
+				senders[line.FutureID] = line.From
+			}
+		}
+	}
+	return hashes, senders
+}
+
+// ── helpers ──────────────────────────────────────────────────────────────────
+
+// uniqueNames suffixes repeated names on the same chain (Token, Token_2, ...)
+// so two deployments of one contract don't overwrite each other. The same
+// address found twice is kept once.
+func uniqueNames(deps []Deployment) []Deployment {
+	type key struct {
+		chain string
+		name  string
+	}
+	chainKey := func(d Deployment) string {
+		if d.ChainID != 0 {
+			return strconv.FormatInt(d.ChainID, 10)
+		}
+		return d.Network
+	}
+	names := map[key]int{}
+	addrs := map[string]bool{}
+	var out []Deployment
+	for _, d := range deps {
+		ak := chainKey(d) + "|" + strings.ToLower(d.Address)
+		if addrs[ak] {
+			continue
+		}
+		addrs[ak] = true
+		k := key{chainKey(d), d.Name}
+		names[k]++
+		if n := names[k]; n > 1 {
+			d.Name = fmt.Sprintf("%s_%d", d.Name, n)
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
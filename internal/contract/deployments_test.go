@@ -0,0 +1,151 @@
+package contract_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const counterABI = `[{"type":"function","name":"increment","inputs":[],"outputs":[],"stateMutability":"nonpayable"}]`
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+func byName(deps []contract.Deployment) map[string]contract.Deployment {
+	m := map[string]contract.Deployment{}
+	for _, d := range deps {
+		m[d.Name] = d
+	}
+	return m
+}
+
+func TestScanDeploymentsHardhatDeploy(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "deployments", "sepolia", ".chainId"), "11155111\n")
+	writeFile(t, filepath.Join(root, "deployments", "sepolia", "Counter.json"), `{
+		"address": "0x1111111111111111111111111111111111111111",
+		"abi": `+counterABI+`,
+		"transactionHash": "0xaaa",
+		"receipt": {"from": "0xdeployer", "transactionHash": "0xaaa"}
+	}`)
+	writeFile(t, filepath.Join(root, "deployments", "sepolia", ".migrations.json"), `{"Counter": 1}`)
+	writeFile(t, filepath.Join(root, "deployments", "localhost", "Counter.json"), `{
+		"address": "0x2222222222222222222222222222222222222222",
+		"abi": `+counterABI+`,
+		"receipt": {"transactionHash": "0xbbb"}
+	}`)
+	// Dependencies are never scanned.
+	writeFile(t, filepath.Join(root, "node_modules", "pkg", "deployments", "mainnet", "X.json"),
+		`{"address": "0x3333333333333333333333333333333333333333", "abi": []}`)
+
+	deps, warnings, err := contract.ScanDeployments(root)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, deps, 2)
+
+	var sepolia, local contract.Deployment
+	for _, d := range deps {
+		if d.Network == "sepolia" {
+			sepolia = d
+		} else {
+			local = d
+		}
+	}
+	assert.Equal(t, "Counter", sepolia.Name)
+	assert.Equal(t, int64(11155111), sepolia.ChainID)
+	assert.Equal(t, "0xaaa", sepolia.TxHash)
+	assert.Equal(t, "0xdeployer", sepolia.Deployer)
+	assert.Equal(t, contract.FormatHardhatDeploy, sepolia.Format)
+	require.Len(t, sepolia.ABI, 1)
+	assert.Equal(t, "increment", sepolia.ABI[0].Name)
+
+	assert.Equal(t, "localhost", local.Network)
+	assert.Zero(t, local.ChainID)
+	assert.Equal(t, "0xbbb", local.TxHash, "falls back to the receipt hash")
+}
+
+func TestScanDeploymentsFoundry(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "out", "Counter.sol", "Counter.json"), `{"abi": `+counterABI+`}`)
+	writeFile(t, filepath.Join(root, "broadcast", "Deploy.s.sol", "84532", "run-latest.json"), `{
+		"transactions": [
+			{"hash": "0x01", "transactionType": "CREATE", "contractName": "Counter",
+			 "contractAddress": "0x1111111111111111111111111111111111111111", "transaction": {"from": "0xdep"}},
+			{"hash": "0x02", "transactionType": "CALL", "contractName": "Counter",
+			 "contractAddress": "0x1111111111111111111111111111111111111111"},
+			{"hash": "0x03", "transactionType": "CREATE2", "contractName": "Counter",
+			 "contractAddress": "0x4444444444444444444444444444444444444444"}
+		],
+		"chain": 84532,
+		"timestamp": 1700000000000
+	}`)
+	// Older runs are ignored.
+	writeFile(t, filepath.Join(root, "broadcast", "Deploy.s.sol", "84532", "run-1699999999.json"), `{"transactions": []}`)
+
+	deps, warnings, err := contract.ScanDeployments(root)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, deps, 2)
+
+	m := byName(deps)
+	first, second := m["Counter"], m["Counter_2"]
+	assert.Equal(t, int64(84532), first.ChainID)
+	assert.Equal(t, "0x01", first.TxHash)
+	assert.Equal(t, "0xdep", first.Deployer)
+	assert.Equal(t, "2023-11-14T22:13:20Z", first.DeployedAt)
+	assert.Equal(t, contract.FormatFoundry, first.Format)
+	assert.Len(t, first.ABI, 1)
+	assert.Equal(t, filepath.Join(root, "out", "Counter.sol", "Counter.json"), first.Artifact)
+	assert.Equal(t, "0x03", second.TxHash)
+	assert.Equal(t, "0x4444444444444444444444444444444444444444", second.Address)
+}
+
+func TestScanDeploymentsIgnition(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "ignition", "deployments", "chain-8453")
+	writeFile(t, filepath.Join(dir, "deployed_addresses.json"), `{
+		"CounterModule#Counter": "0x5555555555555555555555555555555555555555"
+	}`)
+	writeFile(t, filepath.Join(dir, "artifacts", "CounterModule#Counter.json"), `{"abi": `+counterABI+`}`)
+	writeFile(t, filepath.Join(dir, "journal.jsonl"),
+		`{"type":"DEPLOYMENT_INITIALIZE","chainId":8453}
+{"type":"NETWORK_INTERACTION_REQUEST","futureId":"CounterModule#Counter","from":"0xsender"}
+{"type":"TRANSACTION_SEND","futureId":"CounterModule#Counter","transaction":{"hash":"0xccc"}}
+`)
+
+	deps, warnings, err := contract.ScanDeployments(root)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, deps, 1)
+
+	d := deps[0]
+	assert.Equal(t, "Counter", d.Name)
+	assert.Equal(t, int64(8453), d.ChainID)
+	assert.Equal(t, "0xccc", d.TxHash)
+	assert.Equal(t, "0xsender", d.Deployer)
+	assert.Equal(t, contract.FormatIgnition, d.Format)
+	assert.Len(t, d.ABI, 1)
+	assert.Equal(t, filepath.Join(dir, "artifacts", "CounterModule#Counter.json"), d.Artifact)
+}
+
+func TestScanDeploymentsBadRecordWarns(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "broadcast", "Deploy.s.sol", "1", "run-latest.json"), `{not json`)
+
+	deps, warnings, err := contract.ScanDeployments(root)
+	require.NoError(t, err)
+	assert.Empty(t, deps)
+	assert.Len(t, warnings, 1)
+}
+
+func TestScanDeploymentsMissingDir(t *testing.T) {
+	_, _, err := contract.ScanDeployments(filepath.Join(t.TempDir(), "nope"))
+	assert.Error(t, err)
+}
@@ -80,11 +80,11 @@ var erc20ABI = []ABIEntry{
 	{
 		Name:   "Transfer",
 		Type:   "event",
-		Inputs: []ABIParam{{Name: "from", Type: "address"}, {Name: "to", Type: "address"}, {Name: "value", Type: "uint256"}},
+		Inputs: []ABIParam{{Name: "from", Type: "address", Indexed: true}, {Name: "to", Type: "address", Indexed: true}, {Name: "value", Type: "uint256"}},
 	},
 	{
 		Name:   "Approval",
 		Type:   "event",
-		Inputs: []ABIParam{{Name: "owner", Type: "address"}, {Name: "spender", Type: "address"}, {Name: "value", Type: "uint256"}},
+		Inputs: []ABIParam{{Name: "owner", Type: "address", Indexed: true}, {Name: "spender", Type: "address", Indexed: true}, {Name: "value", Type: "uint256"}},
 	},
 }
@@ -0,0 +1,312 @@
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEVM is a small EVM interpreter for running the hand-written proxies
+// and the test contracts around them. It has no gas accounting, balances or
+// precompiles, and fails the test on any opcode it does not implement.
+type testEVM struct {
+	t        *testing.T
+	accounts map[common.Address]*evmAccount
+	logs     []evmLog
+	nonce    uint64
+}
+
+type evmAccount struct {
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+type evmLog struct {
+	addr   common.Address
+	topics []common.Hash
+	data   []byte
+}
+
+// evmFrame is one call: address owns the storage and logs, code runs.
+type evmFrame struct {
+	address common.Address
+	code    []byte
+	caller  common.Address
+	input   []byte
+	depth   int
+}
+
+var word256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+func newTestEVM(t *testing.T) *testEVM {
+	return &testEVM{t: t, accounts: make(map[common.Address]*evmAccount)}
+}
+
+func (e *testEVM) account(a common.Address) *evmAccount {
+	acc, ok := e.accounts[a]
+	if !ok {
+		acc = &evmAccount{storage: make(map[common.Hash]common.Hash)}
+		e.accounts[a] = acc
+	}
+	return acc
+}
+
+func (e *testEVM) setCode(a common.Address, code []byte) { e.account(a).code = code }
+
+func (e *testEVM) code(a common.Address) []byte { return e.account(a).code }
+
+func (e *testEVM) sload(a common.Address, slot common.Hash) common.Hash {
+	return e.account(a).storage[slot]
+}
+
+// create runs initCode from `from` and installs the code it returns.
+func (e *testEVM) create(from common.Address, initCode []byte) (common.Address, []byte, bool) {
+	addr := crypto.CreateAddress(from, e.nonce)
+	e.nonce++
+	ret, ok := e.run(evmFrame{address: addr, code: initCode, caller: from})
+	if ok {
+		e.setCode(addr, ret)
+	}
+	return addr, ret, ok
+}
+
+// call sends input from `from` to `to`.
+func (e *testEVM) call(from, to common.Address, input []byte) ([]byte, bool) {
+	return e.run(evmFrame{address: to, code: e.code(to), caller: from, input: input})
+}
+
+// run executes f and rolls back its storage writes and logs if it fails.
+func (e *testEVM) run(f evmFrame) ([]byte, bool) {
+	saved := make(map[common.Address]map[common.Hash]common.Hash, len(e.accounts))
+	for a, acc := range e.accounts {
+		st := make(map[common.Hash]common.Hash, len(acc.storage))
+		for k, v := range acc.storage {
+			st[k] = v
+		}
+		saved[a] = st
+	}
+	nlogs := len(e.logs)
+
+	ret, ok := e.exec(f)
+	if !ok {
+		for a, acc := range e.accounts {
+			acc.storage = saved[a]
+			if acc.storage == nil {
+				acc.storage = make(map[common.Hash]common.Hash)
+			}
+		}
+		e.logs = e.logs[:nlogs]
+	}
+	return ret, ok
+}
+
+func (e *testEVM) exec(f evmFrame) ([]byte, bool) {
+	if f.depth > 8 {
+		return nil, false
+	}
+	jumpdests := make(map[uint64]bool)
+	for _, ins := range Disassemble(f.code) {
+		if ins.Op == opJUMPDEST {
+			jumpdests[uint64(ins.PC)] = true
+		}
+	}
+
+	var (
+		stack   []*big.Int
+		mem     []byte
+		retData []byte
+	)
+	push := func(v *big.Int) { stack = append(stack, new(big.Int).Mod(v, word256)) }
+	pop := func() *big.Int {
+		if len(stack) == 0 {
+			e.t.Fatalf("evm: stack underflow")
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+	// memory returns mem[off:off+size], growing it as the EVM would.
+	memory := func(off, size *big.Int) []byte {
+		n := size.Uint64()
+		if n == 0 {
+			return nil
+		}
+		end := off.Uint64() + n
+		if end > uint64(len(mem)) {
+			mem = append(mem, make([]byte, (end+31)/32*32-uint64(len(mem)))...)
+		}
+		return mem[off.Uint64():end]
+	}
+	// slice returns src[off:off+size], zero-padded past the end.
+	slice := func(src []byte, off, size uint64) []byte {
+		out := make([]byte, size)
+		if off < uint64(len(src)) {
+			copy(out, src[off:])
+		}
+		return out
+	}
+	boolean := func(b bool) *big.Int {
+		if b {
+			return big.NewInt(1)
+		}
+		return new(big.Int)
+	}
+
+	for pc, steps := uint64(0), 0; ; steps++ {
+		if steps > 100000 {
+			e.t.Fatalf("evm: too many steps")
+		}
+		if pc >= uint64(len(f.code)) {
+			return nil, true
+		}
+		op := f.code[pc]
+		name := opNames[op]
+		switch {
+		case isPush(op):
+			n := uint64(op-opPUSH1) + 1
+			push(new(big.Int).SetBytes(slice(f.code, pc+1, n)))
+			pc += 1 + n
+			continue
+		case op >= 0x80 && op <= 0x8f: // DUPn
+			n := int(op-0x80) + 1
+			push(stack[len(stack)-n])
+			pc++
+			continue
+		case op >= 0x90 && op <= 0x9f: // SWAPn
+			n := int(op-0x90) + 1
+			top := len(stack) - 1
+			stack[top], stack[top-n] = stack[top-n], stack[top]
+			pc++
+			continue
+		case op >= opLOG0 && op <= opLOG4:
+			off, size := pop(), pop()
+			l := evmLog{addr: f.address, data: append([]byte(nil), memory(off, size)...)}
+			for i := 0; i < int(op-opLOG0); i++ {
+				l.topics = append(l.topics, common.BigToHash(pop()))
+			}
+			e.logs = append(e.logs, l)
+			pc++
+			continue
+		}
+
+		switch name {
+		case "STOP":
+			return nil, true
+		case "ADD":
+			push(new(big.Int).Add(pop(), pop()))
+		case "EQ":
+			push(boolean(pop().Cmp(pop()) == 0))
+		case "ISZERO":
+			push(boolean(pop().Sign() == 0))
+		case "SHR":
+			shift, v := pop(), pop()
+			if shift.Cmp(big.NewInt(256)) >= 0 {
+				push(new(big.Int))
+			} else {
+				push(new(big.Int).Rsh(v, uint(shift.Uint64())))
+			}
+		case "POP":
+			pop()
+		case "CALLER":
+			push(new(big.Int).SetBytes(f.caller.Bytes()))
+		case "CALLVALUE":
+			push(new(big.Int))
+		case "GAS":
+			push(big.NewInt(30_000_000))
+		case "CALLDATASIZE":
+			push(big.NewInt(int64(len(f.input))))
+		case "CALLDATALOAD":
+			push(new(big.Int).SetBytes(slice(f.input, pop().Uint64(), 32)))
+		case "CALLDATACOPY", "CODECOPY":
+			src := f.input
+			if name == "CODECOPY" {
+				src = f.code
+			}
+			dst, off, size := pop(), pop(), pop()
+			copy(memory(dst, size), slice(src, off.Uint64(), size.Uint64()))
+		case "RETURNDATASIZE":
+			push(big.NewInt(int64(len(retData))))
+		case "RETURNDATACOPY":
+			dst, off, size := pop(), pop(), pop()
+			if off.Uint64()+size.Uint64() > uint64(len(retData)) {
+				return nil, false
+			}
+			copy(memory(dst, size), retData[off.Uint64():])
+		case "EXTCODESIZE":
+			push(big.NewInt(int64(len(e.code(common.BigToAddress(pop()))))))
+		case "MLOAD":
+			push(new(big.Int).SetBytes(memory(pop(), big.NewInt(32))))
+		case "MSTORE":
+			off, v := pop(), pop()
+			copy(memory(off, big.NewInt(32)), common.BigToHash(v).Bytes())
+		case "SLOAD":
+			push(e.sload(f.address, common.BigToHash(pop())).Big())
+		case "SSTORE":
+			slot, v := pop(), pop()
+			e.account(f.address).storage[common.BigToHash(slot)] = common.BigToHash(v)
+		case "JUMP":
+			dest := pop().Uint64()
+			if !jumpdests[dest] {
+				return nil, false
+			}
+			pc = dest
+			continue
+		case "JUMPI":
+			dest, cond := pop(), pop()
+			if cond.Sign() != 0 {
+				if !jumpdests[dest.Uint64()] {
+					return nil, false
+				}
+				pc = dest.Uint64()
+				continue
+			}
+		case "JUMPDEST":
+		case "DELEGATECALL":
+			_, to := pop(), common.BigToAddress(pop())
+			inOff, inSize, outOff, outSize := pop(), pop(), pop(), pop()
+			ret, ok := e.run(evmFrame{
+				address: f.address,
+				code:    e.code(to),
+				caller:  f.caller,
+				input:   append([]byte(nil), memory(inOff, inSize)...),
+				depth:   f.depth + 1,
+			})
+			retData = ret
+			copy(memory(outOff, outSize), ret)
+			push(boolean(ok))
+		case "RETURN", "REVERT":
+			off, size := pop(), pop()
+			return append([]byte(nil), memory(off, size)...), name == "RETURN"
+		default:
+			e.t.Fatalf("evm: opcode %s (0x%02x) not implemented", name, op)
+		}
+		pc++
+	}
+}
+
+func TestTestEVM(t *testing.T) {
+	e := newTestEVM(t)
+	from := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	// Stores calldata word 0 in slot 1 and returns CALLER.
+	runtime := assemble(`
+		PUSH1 0x00 CALLDATALOAD PUSH1 0x01 SSTORE
+		CALLER PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN`, nil)
+	// Returns the runtime that follows it.
+	initCode := assemble(`$size DUP1 PUSH1 0x0b PUSH1 0x00 CODECOPY PUSH1 0x00 RETURN`,
+		map[string][]byte{"size": {byte(len(runtime))}})
+	require.Len(t, initCode, 0x0b)
+
+	addr, _, ok := e.create(from, append(initCode, runtime...))
+	require.True(t, ok)
+	assert.Equal(t, runtime, e.code(addr))
+
+	ret, ok := e.call(from, addr, common.LeftPadBytes([]byte{7}, 32))
+	require.True(t, ok)
+	assert.Equal(t, from, common.BytesToAddress(ret))
+	assert.Equal(t, int64(7), e.sload(addr, common.BigToHash(big.NewInt(1))).Big().Int64())
+}
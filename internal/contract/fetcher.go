@@ -70,6 +70,67 @@ func (f *Fetcher) FetchFromURL(url string) ([]ABIEntry, error) {
 	return parseABI(body)
 }
 
+// SourcifyServer is the public Sourcify API.
+const SourcifyServer = "https://sourcify.dev/server"
+
+// Sourcify match levels returned by FetchFromSourcify.
+const (
+	SourcifyFull    = "full"    // metadata hash matches too
+	SourcifyPartial = "partial" // bytecode matches, metadata differs
+)
+
+// FetchFromSourcify fetches the ABI of a contract verified on Sourcify and
+// reports whether it is a full or partial match.
+func (f *Fetcher) FetchFromSourcify(server string, chainID int64, address string) ([]ABIEntry, string, error) {
+	url := fmt.Sprintf("%s/v2/contract/%d/%s?fields=abi", strings.TrimSuffix(server, "/"), chainID, address)
+	resp, err := f.client.Get(url)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching ABI from Sourcify: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("not verified on Sourcify")
+	}
+	var result struct {
+		ABI     json.RawMessage `json:"abi"`
+		Match   string          `json:"match"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", fmt.Errorf("parsing Sourcify response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("sourcify: %s (HTTP %d)", result.Message, resp.StatusCode)
+	}
+
+	match := SourcifyPartial
+	if result.Match == "exact_match" {
+		match = SourcifyFull
+	}
+	abi, err := parseABI(result.ABI)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(abi) == 0 {
+		return nil, "", fmt.Errorf("sourcify returned no ABI")
+	}
+	return abi, match, nil
+}
+
+// ParseABI parses an ABI from either a raw JSON array or a Hardhat/Foundry
+// artifact with an "abi" key.
+func ParseABI(data []byte) ([]ABIEntry, error) {
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if json.Unmarshal(data, &artifact) == nil && len(artifact.ABI) > 1 && artifact.ABI[0] == '[' {
+		return parseABI(artifact.ABI)
+	}
+	return parseABI(data)
+}
+
 // LoadFromFile loads a raw ABI JSON array from a local file path.
 func LoadFromFile(path string) ([]ABIEntry, error) {
 	data, err := os.ReadFile(path)
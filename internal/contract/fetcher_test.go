@@ -331,3 +331,35 @@ func TestFetchFromURLConnectionRefused(t *testing.T) {
 	_, err := f.FetchFromURL("http://127.0.0.1:1")
 	assert.Error(t, err)
 }
+
+func TestFetchFromSourcify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abi", r.URL.Query().Get("fields"))
+		switch r.URL.Path {
+		case "/v2/contract/8453/0xFull":
+			w.Write([]byte(`{"match":"exact_match","abi":[{"name":"owner","type":"function","stateMutability":"view"}]}`)) //nolint:errcheck
+		case "/v2/contract/8453/0xPartial":
+			w.Write([]byte(`{"match":"match","abi":[{"name":"owner","type":"function"},{"name":"pause","type":"function"}]}`)) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"customCode":"not_found","message":"Contract not found"}`)) //nolint:errcheck
+		}
+	}))
+	defer server.Close()
+
+	f := NewFetcher("")
+	f.client = server.Client()
+
+	abi, match, err := f.FetchFromSourcify(server.URL+"/", 8453, "0xFull")
+	require.NoError(t, err)
+	assert.Equal(t, SourcifyFull, match)
+	assert.Len(t, abi, 1)
+
+	abi, match, err = f.FetchFromSourcify(server.URL, 8453, "0xPartial")
+	require.NoError(t, err)
+	assert.Equal(t, SourcifyPartial, match)
+	assert.Len(t, abi, 2)
+
+	_, _, err = f.FetchFromSourcify(server.URL, 1, "0xFull")
+	assert.ErrorContains(t, err, "not verified")
+}
@@ -0,0 +1,167 @@
+package contract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Proxy kinds reported by DetectProxy.
+const (
+	ProxyEIP1967     = "EIP-1967"
+	ProxyTransparent = "Transparent (OpenZeppelin)"
+	ProxyUUPS        = "UUPS (EIP-1822)"
+	ProxyBeacon      = "Beacon (EIP-1967)"
+	ProxyMinimal     = "Minimal clone (EIP-1167)"
+	ProxySafe        = "Safe proxy"
+)
+
+// Well-known proxy storage slots.
+const (
+	SlotEIP1967Implementation = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc" // keccak("eip1967.proxy.implementation") - 1
+	SlotEIP1967Admin          = "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103" // keccak("eip1967.proxy.admin") - 1
+	SlotEIP1967Beacon         = "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50" // keccak("eip1967.proxy.beacon") - 1
+	SlotEIP1822Proxiable      = "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7" // keccak("PROXIABLE")
+	SlotZeppelinOSImpl        = "0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3" // keccak("org.zeppelinos.proxy.implementation")
+	SlotZeppelinOSAdmin       = "0x10d6a54a4754c8869d6886b5f5d7fbfa5b4522237ea5c60d11bc4e7a1ff9390b" // keccak("org.zeppelinos.proxy.admin")
+)
+
+const (
+	selImplementation = "0x5c60da1b" // implementation() — beacons
+	selMasterCopy     = "0xa619486e" // masterCopy() — Safe proxies
+	selProxiableUUID  = "52d1902d"   // proxiableUUID() — UUPS implementations
+)
+
+// ProxyInfo describes a detected proxy. Admin and Beacon are empty when the
+// pattern has none.
+type ProxyInfo struct {
+	Kind           string
+	Implementation string
+	Admin          string
+	Beacon         string
+}
+
+// ChainReader is the subset of chain.EVMClient proxy detection needs.
+type ChainReader interface {
+	GetCode(address string) (string, error)
+	GetStorageAt(address, slot string) (string, error)
+	CallContract(toAddr, calldata string) (string, error)
+}
+
+// DetectProxy inspects the contract at address and returns its proxy
+// pattern, or nil when it is not a recognised proxy (or has no code).
+func DetectProxy(r ChainReader, address string) (*ProxyInfo, error) {
+	code, err := r.GetCode(address)
+	if err != nil {
+		return nil, fmt.Errorf("querying code: %w", err)
+	}
+	code = strings.ToLower(strings.TrimPrefix(code, "0x"))
+	if code == "" || code == "0" {
+		return nil, nil
+	}
+
+	if impl := minimalProxyTarget(code); impl != "" {
+		return &ProxyInfo{Kind: ProxyMinimal, Implementation: impl}, nil
+	}
+
+	slot := func(s string) string {
+		v, err := r.GetStorageAt(address, s)
+		if err != nil {
+			return ""
+		}
+		return wordAddress(v)
+	}
+
+	if beacon := slot(SlotEIP1967Beacon); beacon != "" {
+		info := &ProxyInfo{Kind: ProxyBeacon, Beacon: beacon, Admin: slot(SlotEIP1967Admin)}
+		if out, err := r.CallContract(beacon, selImplementation); err == nil {
+			info.Implementation = wordAddress(out)
+		}
+		return info, nil
+	}
+	if impl := slot(SlotEIP1967Implementation); impl != "" {
+		info := &ProxyInfo{Kind: ProxyEIP1967, Implementation: impl}
+		if admin := slot(SlotEIP1967Admin); admin != "" {
+			info.Kind, info.Admin = ProxyTransparent, admin
+		} else if implCode, err := r.GetCode(impl); err == nil && strings.Contains(strings.ToLower(implCode), "63"+selProxiableUUID) {
+			info.Kind = ProxyUUPS
+		}
+		return info, nil
+	}
+	if impl := slot(SlotEIP1822Proxiable); impl != "" {
+		return &ProxyInfo{Kind: ProxyUUPS, Implementation: impl}, nil
+	}
+	if impl := slot(SlotZeppelinOSImpl); impl != "" {
+		return &ProxyInfo{Kind: ProxyTransparent, Implementation: impl, Admin: slot(SlotZeppelinOSAdmin)}, nil
+	}
+
+	// Safe proxies keep the singleton in slot 0 and answer masterCopy().
+	if out, err := r.CallContract(address, selMasterCopy); err == nil {
+		if singleton := wordAddress(out); singleton != "" && singleton == slot("0x0") {
+			return &ProxyInfo{Kind: ProxySafe, Implementation: singleton}, nil
+		}
+	}
+	return nil, nil
+}
+
+// minimalProxyTarget returns the implementation baked into EIP-1167 runtime
+// code (363d3d373d3d3d363d <PUSHn addr> 5af43d82803e903d91 ...), including
+// the variants with a shortened (vanity) address.
+func minimalProxyTarget(code string) string {
+	const prefix = "363d3d373d3d3d363d"
+	if !strings.HasPrefix(code, prefix) || len(code) < len(prefix)+2 {
+		return ""
+	}
+	push, err := strconv.ParseUint(code[len(prefix):len(prefix)+2], 16, 8)
+	if err != nil || push < 0x60 || push > 0x73 {
+		return ""
+	}
+	n := int(push) - 0x5f // PUSHn
+	start := len(prefix) + 2
+	end := start + 2*n
+	if len(code) < end || !strings.HasPrefix(code[end:], "5af43d82803e903d91") {
+		return ""
+	}
+	addr := code[start:end]
+	return "0x" + strings.Repeat("0", 40-len(addr)) + addr
+}
+
+// wordAddress reads an address from a 32-byte word; zero yields "".
+func wordAddress(word string) string {
+	w := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(word)), "0x")
+	if len(w) < 40 {
+		w = strings.Repeat("0", 40-len(w)) + w
+	}
+	addr := w[len(w)-40:]
+	if strings.Trim(addr, "0") == "" {
+		return ""
+	}
+	return "0x" + addr
+}
+
+// MergeABI returns base followed by the entries of extra whose signature is
+// not in base already — used to give a proxy its implementation's ABI while
+// keeping the proxy's own functions (admin, upgradeTo, ...).
+func MergeABI(base, extra []ABIEntry) []ABIEntry {
+	key := func(e ABIEntry) string {
+		if e.Type == "constructor" || e.Type == "fallback" || e.Type == "receive" {
+			return e.Type // one of each is enough
+		}
+		types := make([]string, len(e.Inputs))
+		for i, p := range e.Inputs {
+			types[i] = p.Type
+		}
+		return e.Type + " " + e.Name + "(" + strings.Join(types, ",") + ")"
+	}
+	seen := map[string]bool{}
+	out := make([]ABIEntry, 0, len(base)+len(extra))
+	for _, list := range [][]ABIEntry{base, extra} {
+		for _, e := range list {
+			if k := key(e); !seen[k] {
+				seen[k] = true
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package contract
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Proxy patterns `proxy deploy` can create.
+const (
+	ProxyDeployUUPS        = "uups"
+	ProxyDeployTransparent = "transparent"
+)
+
+// The proxies below are small hand-written ERC-1967 proxies, so explorers,
+// DetectProxy and OpenZeppelin tooling all find their implementation and
+// admin in the standard slots.
+
+// proxyCtor stores the implementation, optionally the admin, then calls the
+// implementation with the init data and returns the runtime code. Its
+// arguments follow the runtime code, ABI-encoded as
+// (address implementation, [address admin,] bytes data).
+const proxyCtor = `
+	PUSH1 0x20 $implArg PUSH1 0x00 CODECOPY
+	PUSH1 0x00 MLOAD                        ; implementation
+	DUP1 EXTCODESIZE ISZERO >fail JUMPI     ; must be a contract
+	DUP1 $implSlot SSTORE
+	DUP1 $upgraded PUSH1 0x00 DUP1 LOG2     ; Upgraded(implementation)
+	$admin
+	PUSH1 0x20 $dataLen PUSH1 0x00 CODECOPY
+	PUSH1 0x00 MLOAD                        ; len(data)
+	DUP1 ISZERO >done JUMPI
+	DUP1 $data PUSH1 0x00 CODECOPY          ; mem[0:len] = data
+	PUSH1 0x00 DUP1 DUP3 DUP2 DUP6 GAS DELEGATECALL
+	>done JUMPI
+	RETURNDATASIZE PUSH1 0x00 DUP1 RETURNDATACOPY
+	RETURNDATASIZE PUSH1 0x00 REVERT        ; bubble up the initializer's revert
+@done
+	$runtimeSize DUP1 $runtimeOffset PUSH1 0x00 CODECOPY
+	PUSH1 0x00 RETURN
+@fail
+	PUSH1 0x00 DUP1 REVERT
+`
+
+// proxyCtorAdmin is spliced into proxyCtor for transparent proxies.
+const proxyCtorAdmin = `
+	PUSH1 0x20 $adminArg PUSH1 0x00 CODECOPY
+	PUSH1 0x00 MLOAD                        ; admin
+	DUP1 ISZERO >fail JUMPI
+	DUP1 $adminSlot SSTORE
+	PUSH1 0x20 MSTORE
+	PUSH1 0x00 DUP1 MSTORE
+	$adminChanged PUSH1 0x40 PUSH1 0x00 LOG1 ; AdminChanged(0, admin)
+`
+
+// proxyDelegate forwards the call to the implementation.
+const proxyDelegate = `
+	CALLDATASIZE PUSH1 0x00 DUP1 CALLDATACOPY
+	PUSH1 0x00 DUP1 CALLDATASIZE DUP2 $implSlot SLOAD GAS DELEGATECALL
+	RETURNDATASIZE PUSH1 0x00 DUP1 RETURNDATACOPY
+	>return JUMPI
+	RETURNDATASIZE PUSH1 0x00 REVERT
+@return
+	RETURNDATASIZE PUSH1 0x00 RETURN
+`
+
+// proxyAdmin handles calls from the admin of a transparent proxy, which
+// can only upgradeToAndCall(address,bytes) and changeAdmin(address).
+const proxyAdmin = `
+	$adminSlot SLOAD CALLER EQ >admin JUMPI
+` + proxyDelegate + `
+@admin
+	PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR
+	DUP1 $selUpgradeToAndCall EQ >upgrade JUMPI
+	$selChangeAdmin EQ >changeAdmin JUMPI
+@deny
+	PUSH1 0x00 DUP1 REVERT
+@upgrade
+	PUSH1 0x04 CALLDATALOAD                 ; new implementation
+	DUP1 EXTCODESIZE ISZERO >deny JUMPI
+	DUP1 $implSlot SSTORE
+	DUP1 $upgraded PUSH1 0x00 DUP1 LOG2
+	PUSH1 0x24 CALLDATALOAD PUSH1 0x04 ADD  ; position of len(data)
+	DUP1 CALLDATALOAD                       ; len(data)
+	DUP1 ISZERO >stop JUMPI
+	DUP1 DUP3 PUSH1 0x20 ADD PUSH1 0x00 CALLDATACOPY
+	PUSH1 0x00 DUP1 DUP3 DUP2 DUP7 GAS DELEGATECALL
+	>stop JUMPI
+	RETURNDATASIZE PUSH1 0x00 DUP1 RETURNDATACOPY
+	RETURNDATASIZE PUSH1 0x00 REVERT
+@stop
+	STOP
+@changeAdmin
+	PUSH1 0x04 CALLDATALOAD                 ; new admin
+	DUP1 ISZERO >deny JUMPI
+	$adminSlot SLOAD PUSH1 0x00 MSTORE
+	DUP1 PUSH1 0x20 MSTORE
+	$adminSlot SSTORE
+	$adminChanged PUSH1 0x40 PUSH1 0x00 LOG1 ; AdminChanged(previous, new)
+	STOP
+`
+
+// Function selectors used by upgrades.
+var (
+	selUpgradeTo           = selectorOf("upgradeTo(address)")
+	selUpgradeToAndCall    = selectorOf("upgradeToAndCall(address,bytes)")
+	selChangeAdmin         = selectorOf("changeAdmin(address)")
+	selAdminUpgrade        = selectorOf("upgrade(address,address)")
+	selAdminUpgradeAndCall = selectorOf("upgradeAndCall(address,address,bytes)")
+)
+
+func selectorOf(sig string) []byte { return crypto.Keccak256([]byte(sig))[:4] }
+
+// ERC1967ProxyInitCode returns the init code of an ERC-1967 proxy for the
+// UUPS pattern — upgrades are done by the implementation — pointing at
+// impl and calling it with data when non-empty.
+func ERC1967ProxyInitCode(impl common.Address, data []byte) []byte {
+	return proxyInitCode(false, encodeAddressesAndBytes([]common.Address{impl}, data))
+}
+
+// TransparentProxyInitCode returns the init code of a transparent proxy:
+// admin may only upgradeToAndCall and changeAdmin, every other caller is
+// forwarded to the implementation.
+func TransparentProxyInitCode(impl, admin common.Address, data []byte) []byte {
+	return proxyInitCode(true, encodeAddressesAndBytes([]common.Address{impl, admin}, data))
+}
+
+func proxyInitCode(transparent bool, args []byte) []byte {
+	vars := map[string][]byte{
+		"implSlot":            common.HexToHash(SlotEIP1967Implementation).Bytes(),
+		"adminSlot":           common.HexToHash(SlotEIP1967Admin).Bytes(),
+		"upgraded":            crypto.Keccak256([]byte("Upgraded(address)")),
+		"adminChanged":        crypto.Keccak256([]byte("AdminChanged(address,address)")),
+		"selUpgradeToAndCall": selUpgradeToAndCall,
+		"selChangeAdmin":      selChangeAdmin,
+	}
+	ctor, runtimeSrc := proxyCtor, proxyDelegate
+	words := 1
+	if transparent {
+		ctor, runtimeSrc = strings.Replace(proxyCtor, "$admin\n", proxyCtorAdmin, 1), proxyAdmin
+		words = 2
+	} else {
+		ctor = strings.Replace(proxyCtor, "$admin\n", "", 1)
+	}
+	runtime := assemble(runtimeSrc, vars)
+
+	// Every offset is a PUSH2, so the constructor's size does not depend on
+	// them: assemble once to measure, then with the real values.
+	set := func(ctorSize int) {
+		argStart := ctorSize + len(runtime)
+		vars["runtimeSize"] = uint16Bytes(len(runtime))
+		vars["runtimeOffset"] = uint16Bytes(ctorSize)
+		vars["implArg"] = uint16Bytes(argStart)
+		vars["adminArg"] = uint16Bytes(argStart + 32)
+		vars["dataLen"] = uint16Bytes(argStart + 32*(words+1))
+		vars["data"] = uint16Bytes(argStart + 32*(words+2))
+	}
+	set(0)
+	set(len(assemble(ctor, vars)))
+
+	code := assemble(ctor, vars)
+	code = append(code, runtime...)
+	return append(code, args...)
+}
+
+func uint16Bytes(n int) []byte {
+	if n > 0xffff {
+		panic(fmt.Sprintf("offset %d does not fit in PUSH2", n))
+	}
+	return []byte{byte(n >> 8), byte(n)}
+}
+
+// encodeAddressesAndBytes ABI-encodes (address..., bytes data).
+func encodeAddressesAndBytes(addrs []common.Address, data []byte) []byte {
+	out := make([]byte, 0, 32*(len(addrs)+2)+len(data)+31)
+	for _, a := range addrs {
+		out = append(out, common.LeftPadBytes(a.Bytes(), 32)...)
+	}
+	out = append(out, common.LeftPadBytes(uint16Bytes(32*(len(addrs)+1)), 32)...)
+	out = append(out, common.LeftPadBytes(bigEndian(len(data)), 32)...)
+	out = append(out, data...)
+	if pad := len(data) % 32; pad != 0 {
+		out = append(out, make([]byte, 32-pad)...)
+	}
+	return out
+}
+
+func bigEndian(n int) []byte {
+	return []byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+}
+
+// UpgradeCalldata returns the call that points a proxy at impl: sent to a
+// UUPS proxy by its owner, or to a transparent proxy by its admin. Without
+// data the older upgradeTo(address) is used when legacy is set, since
+// OpenZeppelin 4.x upgradeToAndCall always calls the new implementation.
+func UpgradeCalldata(impl common.Address, data []byte, legacy bool) []byte {
+	if len(data) == 0 && legacy {
+		return append(append([]byte{}, selUpgradeTo...), common.LeftPadBytes(impl.Bytes(), 32)...)
+	}
+	return append(append([]byte{}, selUpgradeToAndCall...), encodeAddressesAndBytes([]common.Address{impl}, data)...)
+}
+
+// ProxyAdminUpgradeCalldata returns the call to an OpenZeppelin ProxyAdmin
+// that upgrades proxy to impl. v5 admins only have upgradeAndCall; with v4
+// and no data upgrade(proxy, impl) is used.
+func ProxyAdminUpgradeCalldata(proxy, impl common.Address, data []byte, v4 bool) []byte {
+	if len(data) == 0 && v4 {
+		out := append([]byte{}, selAdminUpgrade...)
+		out = append(out, common.LeftPadBytes(proxy.Bytes(), 32)...)
+		return append(out, common.LeftPadBytes(impl.Bytes(), 32)...)
+	}
+	return append(append([]byte{}, selAdminUpgradeAndCall...), encodeAddressesAndBytes([]common.Address{proxy, impl}, data)...)
+}
+
+// CheckUUPSImplementation reports whether abi keeps the UUPS upgrade
+// functions. Without them the proxy can never be upgraded again.
+func CheckUUPSImplementation(abi []ABIEntry) error {
+	var missing []string
+	for _, want := range []string{"upgradeToAndCall(address,bytes)", "proxiableUUID()"} {
+		found := false
+		for _, e := range abi {
+			if e.Type == "function" && e.Selector() == "0x"+hex.EncodeToString(selectorOf(want)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("implementation is not UUPS-upgradeable: missing %s — inherit UUPSUpgradeable, or use --kind transparent", strings.Join(missing, " and "))
+	}
+	return nil
+}
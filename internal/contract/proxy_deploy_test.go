@@ -0,0 +1,261 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssemble(t *testing.T) {
+	code := assemble(`
+		PUSH1 0x01 >end JUMP   ; comment
+	@end
+		$v POP STOP`, map[string][]byte{"v": {0xaa, 0xbb}})
+	// PUSH1 01, PUSH2 0006, JUMP, JUMPDEST, PUSH2 aabb, POP, STOP
+	assert.Equal(t, "6001610006565b61aabb5000", hex.EncodeToString(code))
+
+	assert.Panics(t, func() { assemble("NOPE", nil) })
+	assert.Panics(t, func() { assemble(">missing", nil) })
+	assert.Panics(t, func() { assemble("PUSH1 0x0102", nil) })
+	assert.Panics(t, func() { assemble("$x", nil) })
+}
+
+func TestProxyInitCode(t *testing.T) {
+	impl := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	admin := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := mustHex(t, "8129fc1c") // initialize()
+	implSlot := common.HexToHash(SlotEIP1967Implementation).Bytes()
+	adminSlot := common.HexToHash(SlotEIP1967Admin).Bytes()
+
+	uups := ERC1967ProxyInitCode(impl, data)
+	uupsArgs := encodeAddressesAndBytes([]common.Address{impl}, data)
+	require.True(t, bytes.HasSuffix(uups, uupsArgs))
+	assert.Len(t, uupsArgs, 32*4)
+	assert.True(t, bytes.Contains(uups, implSlot))
+	assert.False(t, bytes.Contains(uups, adminSlot))
+
+	transparent := TransparentProxyInitCode(impl, admin, nil)
+	transparentArgs := encodeAddressesAndBytes([]common.Address{impl, admin}, nil)
+	require.True(t, bytes.HasSuffix(transparent, transparentArgs))
+	assert.True(t, bytes.Contains(transparent, adminSlot))
+	assert.True(t, bytes.Contains(transparent, selUpgradeToAndCall))
+
+	// The code before the arguments is all complete, valid instructions.
+	for _, code := range [][]byte{
+		uups[:len(uups)-len(uupsArgs)],
+		transparent[:len(transparent)-len(transparentArgs)],
+	} {
+		for _, ins := range Disassemble(code) {
+			assert.NotContains(t, ins.Name(), "INVALID", "pc %d", ins.PC)
+			if isPush(ins.Op) {
+				assert.Len(t, ins.Arg, int(ins.Op-opPUSH1)+1, "pc %d", ins.PC)
+			}
+		}
+	}
+}
+
+func TestUpgradeCalldata(t *testing.T) {
+	impl := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	proxy := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	legacy := UpgradeCalldata(impl, nil, true)
+	assert.Equal(t, "3659cfe6", hex.EncodeToString(legacy[:4]))
+	assert.Len(t, legacy, 4+32)
+
+	call := UpgradeCalldata(impl, mustHex(t, "8129fc1c"), true)
+	assert.Equal(t, "4f1ef286", hex.EncodeToString(call[:4]))
+	assert.Len(t, call, 4+32*4)
+	assert.Equal(t, "4f1ef286", hex.EncodeToString(UpgradeCalldata(impl, nil, false)[:4]))
+
+	v4 := ProxyAdminUpgradeCalldata(proxy, impl, nil, true)
+	assert.Equal(t, "99a88ec4", hex.EncodeToString(v4[:4]))
+	assert.Len(t, v4, 4+64)
+	v5 := ProxyAdminUpgradeCalldata(proxy, impl, nil, false)
+	assert.Equal(t, "9623609d", hex.EncodeToString(v5[:4]))
+	assert.Len(t, v5, 4+32*4)
+}
+
+func TestCheckUUPSImplementation(t *testing.T) {
+	fn := func(name string, inputs ...string) ABIEntry {
+		e := ABIEntry{Type: "function", Name: name}
+		for _, in := range inputs {
+			e.Inputs = append(e.Inputs, ABIParam{Type: in})
+		}
+		return e
+	}
+	assert.NoError(t, CheckUUPSImplementation([]ABIEntry{
+		fn("upgradeToAndCall", "address", "bytes"), fn("proxiableUUID"),
+	}))
+	err := CheckUUPSImplementation([]ABIEntry{fn("upgradeTo", "address")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upgradeToAndCall(address,bytes) and proxiableUUID()")
+}
+
+// Contracts the proxies below delegate to, run with testEVM.
+var (
+	testImplA     = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	testImplB     = common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	testDeployer  = common.HexToAddress("0x00000000000000000000000000000000000000dd")
+	testUser      = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	testProxyAdm  = common.HexToAddress("0x00000000000000000000000000000000000000ad")
+	selInitialize = selectorOf("initialize()")
+	selFail       = selectorOf("fail()")
+)
+
+// newProxyEVM returns an EVM with two implementations deployed:
+//
+//	A: initialize() sets slot 0 to 42, fail() reverts with 0xcafebabe, and
+//	   anything else records the caller in slot 1 and echoes the calldata.
+//	B: sets slot 2 to 7 and returns 2.
+func newProxyEVM(t *testing.T) *testEVM {
+	e := newTestEVM(t)
+	e.setCode(testImplA, assemble(`
+		PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR
+		DUP1 $init EQ >init JUMPI
+		$fail EQ >fail JUMPI
+		CALLER PUSH1 0x01 SSTORE
+		CALLDATASIZE PUSH1 0x00 DUP1 CALLDATACOPY
+		CALLDATASIZE PUSH1 0x00 RETURN
+	@init
+		PUSH1 0x2a PUSH1 0x00 SSTORE STOP
+	@fail
+		PUSH4 0xcafebabe PUSH1 0x00 MSTORE PUSH1 0x04 PUSH1 0x1c REVERT`,
+		map[string][]byte{"init": selInitialize, "fail": selFail}))
+	e.setCode(testImplB, assemble(`
+		PUSH1 0x07 PUSH1 0x02 SSTORE
+		PUSH1 0x02 PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN`, nil))
+	return e
+}
+
+func storedAddr(e *testEVM, proxy common.Address, slot string) common.Address {
+	return common.BytesToAddress(e.sload(proxy, common.HexToHash(slot)).Bytes())
+}
+
+func storedInt(e *testEVM, proxy common.Address, slot int64) int64 {
+	return e.sload(proxy, common.BigToHash(big.NewInt(slot))).Big().Int64()
+}
+
+func TestERC1967ProxyRuns(t *testing.T) {
+	e := newProxyEVM(t)
+	proxy, _, ok := e.create(testDeployer, ERC1967ProxyInitCode(testImplA, selInitialize))
+	require.True(t, ok)
+
+	assert.Equal(t, testImplA, storedAddr(e, proxy, SlotEIP1967Implementation))
+	assert.Equal(t, int64(42), storedInt(e, proxy, 0), "initializer ran in the proxy's storage")
+	assert.Empty(t, e.account(testImplA).storage)
+	require.Len(t, e.logs, 1)
+	assert.Equal(t, []common.Hash{crypto.Keccak256Hash([]byte("Upgraded(address)")), common.BytesToHash(testImplA.Bytes())}, e.logs[0].topics)
+
+	// Calldata goes through unchanged, msg.sender is kept, the result comes back.
+	input := append(mustHex(t, "12345678"), common.LeftPadBytes([]byte{9}, 64)...)
+	ret, ok := e.call(testUser, proxy, input)
+	require.True(t, ok)
+	assert.Equal(t, input, ret)
+	assert.Equal(t, testUser, common.BytesToAddress(e.sload(proxy, common.BigToHash(big.NewInt(1))).Bytes()))
+
+	// Reverts come back with their data.
+	ret, ok = e.call(testUser, proxy, selFail)
+	assert.False(t, ok)
+	assert.Equal(t, mustHex(t, "cafebabe"), ret)
+}
+
+func TestERC1967ProxyConstructor(t *testing.T) {
+	e := newProxyEVM(t)
+
+	// No init data: nothing is called.
+	proxy, _, ok := e.create(testDeployer, ERC1967ProxyInitCode(testImplA, nil))
+	require.True(t, ok)
+	assert.NotEmpty(t, e.code(proxy))
+	assert.Equal(t, int64(0), storedInt(e, proxy, 0))
+
+	// A reverting initializer aborts the deploy with its revert data.
+	proxy, ret, ok := e.create(testDeployer, ERC1967ProxyInitCode(testImplA, selFail))
+	assert.False(t, ok)
+	assert.Equal(t, mustHex(t, "cafebabe"), ret)
+	assert.Empty(t, e.code(proxy))
+	assert.Equal(t, common.Address{}, storedAddr(e, proxy, SlotEIP1967Implementation))
+
+	// The implementation must be a contract.
+	_, _, ok = e.create(testDeployer, ERC1967ProxyInitCode(testUser, nil))
+	assert.False(t, ok)
+}
+
+func TestTransparentProxyRuns(t *testing.T) {
+	e := newProxyEVM(t)
+	proxy, _, ok := e.create(testDeployer, TransparentProxyInitCode(testImplA, testProxyAdm, nil))
+	require.True(t, ok)
+
+	assert.Equal(t, testImplA, storedAddr(e, proxy, SlotEIP1967Implementation))
+	assert.Equal(t, testProxyAdm, storedAddr(e, proxy, SlotEIP1967Admin))
+	adminChanged := crypto.Keccak256Hash([]byte("AdminChanged(address,address)"))
+	require.Len(t, e.logs, 2)
+	assert.Equal(t, []common.Hash{adminChanged}, e.logs[1].topics)
+	assert.Equal(t, append(make([]byte, 32), common.LeftPadBytes(testProxyAdm.Bytes(), 32)...), e.logs[1].data)
+
+	changeAdmin := func(a common.Address) []byte {
+		return append(append([]byte{}, selChangeAdmin...), common.LeftPadBytes(a.Bytes(), 32)...)
+	}
+
+	// Everyone but the admin reaches the implementation, admin selectors
+	// included.
+	for _, in := range [][]byte{UpgradeCalldata(testImplB, nil, false), changeAdmin(testUser), mustHex(t, "12345678")} {
+		ret, ok := e.call(testUser, proxy, in)
+		require.True(t, ok)
+		assert.Equal(t, in, ret)
+	}
+	assert.Equal(t, testImplA, storedAddr(e, proxy, SlotEIP1967Implementation))
+	assert.Equal(t, testProxyAdm, storedAddr(e, proxy, SlotEIP1967Admin))
+
+	// The admin can't call the implementation.
+	_, ok = e.call(testProxyAdm, proxy, mustHex(t, "12345678"))
+	assert.False(t, ok)
+
+	// upgradeToAndCall without data only switches the implementation.
+	_, ok = e.call(testProxyAdm, proxy, UpgradeCalldata(testImplB, nil, false))
+	require.True(t, ok)
+	assert.Equal(t, testImplB, storedAddr(e, proxy, SlotEIP1967Implementation))
+	assert.Equal(t, int64(0), storedInt(e, proxy, 2))
+	ret, ok := e.call(testUser, proxy, nil)
+	require.True(t, ok)
+	assert.Equal(t, common.LeftPadBytes([]byte{2}, 32), ret)
+
+	// A reverting call rolls the upgrade back and bubbles the reason up.
+	ret, ok = e.call(testProxyAdm, proxy, UpgradeCalldata(testImplA, selFail, false))
+	assert.False(t, ok)
+	assert.Equal(t, mustHex(t, "cafebabe"), ret)
+	assert.Equal(t, testImplB, storedAddr(e, proxy, SlotEIP1967Implementation))
+
+	// With data the new implementation is called in the proxy's context.
+	_, ok = e.call(testProxyAdm, proxy, UpgradeCalldata(testImplA, selInitialize, false))
+	require.True(t, ok)
+	assert.Equal(t, testImplA, storedAddr(e, proxy, SlotEIP1967Implementation))
+	assert.Equal(t, int64(42), storedInt(e, proxy, 0))
+
+	// The new implementation must be a contract; the new admin non-zero.
+	_, ok = e.call(testProxyAdm, proxy, UpgradeCalldata(testUser, nil, false))
+	assert.False(t, ok)
+	_, ok = e.call(testProxyAdm, proxy, changeAdmin(common.Address{}))
+	assert.False(t, ok)
+	assert.Equal(t, testImplA, storedAddr(e, proxy, SlotEIP1967Implementation))
+
+	// changeAdmin hands over: the old admin is now an ordinary caller.
+	newAdmin := common.HexToAddress("0x00000000000000000000000000000000000000ae")
+	_, ok = e.call(testProxyAdm, proxy, changeAdmin(newAdmin))
+	require.True(t, ok)
+	assert.Equal(t, newAdmin, storedAddr(e, proxy, SlotEIP1967Admin))
+	last := e.logs[len(e.logs)-1]
+	assert.Equal(t, []common.Hash{adminChanged}, last.topics)
+	assert.Equal(t, append(common.LeftPadBytes(testProxyAdm.Bytes(), 32), common.LeftPadBytes(newAdmin.Bytes(), 32)...), last.data)
+
+	ret, ok = e.call(testProxyAdm, proxy, mustHex(t, "12345678"))
+	require.True(t, ok)
+	assert.Equal(t, mustHex(t, "12345678"), ret)
+	_, ok = e.call(newAdmin, proxy, mustHex(t, "12345678"))
+	assert.False(t, ok)
+}
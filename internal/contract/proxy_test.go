@@ -0,0 +1,187 @@
+package contract_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is an in-memory contract.ChainReader.
+type fakeChain struct {
+	code    map[string]string // address → runtime code
+	storage map[string]string // address + slot → word
+	calls   map[string]string // address + calldata → return data
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{code: map[string]string{}, storage: map[string]string{}, calls: map[string]string{}}
+}
+
+func (f *fakeChain) GetCode(address string) (string, error) {
+	if c, ok := f.code[address]; ok {
+		return c, nil
+	}
+	return "0x", nil
+}
+
+func (f *fakeChain) GetStorageAt(address, slot string) (string, error) {
+	if v, ok := f.storage[address+slot]; ok {
+		return v, nil
+	}
+	return "0x" + strings.Repeat("0", 64), nil
+}
+
+func (f *fakeChain) CallContract(to, data string) (string, error) {
+	if v, ok := f.calls[to+data]; ok {
+		return v, nil
+	}
+	return "", errors.New("execution reverted")
+}
+
+func word(addr string) string {
+	return "0x" + strings.Repeat("0", 24) + strings.TrimPrefix(addr, "0x")
+}
+
+const (
+	proxyAddr = "0x1111111111111111111111111111111111111111"
+	implAddr  = "0x2222222222222222222222222222222222222222"
+	adminAddr = "0x3333333333333333333333333333333333333333"
+)
+
+func TestDetectProxyMinimalClone(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x363d3d373d3d3d363d73" + strings.TrimPrefix(implAddr, "0x") + "5af43d82803e903d91602b57fd5bf3"
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyMinimal, p.Kind)
+	assert.Equal(t, implAddr, p.Implementation)
+}
+
+func TestDetectProxyMinimalCloneVanity(t *testing.T) {
+	f := newFakeChain()
+	// PUSH18: implementation with two leading zero bytes.
+	f.code[proxyAddr] = "0x363d3d373d3d3d363d71" + strings.Repeat("ab", 18) + "5af43d82803e903d91602957fd5bf3"
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "0x0000"+strings.Repeat("ab", 18), p.Implementation)
+}
+
+func TestDetectProxyTransparent(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.storage[proxyAddr+contract.SlotEIP1967Implementation] = word(implAddr)
+	f.storage[proxyAddr+contract.SlotEIP1967Admin] = word(adminAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyTransparent, p.Kind)
+	assert.Equal(t, implAddr, p.Implementation)
+	assert.Equal(t, adminAddr, p.Admin)
+}
+
+func TestDetectProxyUUPS(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.code[implAddr] = "0x608060405263" + "52d1902d" + "14"
+	f.storage[proxyAddr+contract.SlotEIP1967Implementation] = word(implAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyUUPS, p.Kind)
+	assert.Empty(t, p.Admin)
+}
+
+func TestDetectProxyPlainEIP1967(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.code[implAddr] = "0x6080"
+	f.storage[proxyAddr+contract.SlotEIP1967Implementation] = word(implAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyEIP1967, p.Kind)
+}
+
+func TestDetectProxyBeacon(t *testing.T) {
+	beacon := "0x4444444444444444444444444444444444444444"
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.storage[proxyAddr+contract.SlotEIP1967Beacon] = word(beacon)
+	f.calls[beacon+"0x5c60da1b"] = word(implAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyBeacon, p.Kind)
+	assert.Equal(t, beacon, p.Beacon)
+	assert.Equal(t, implAddr, p.Implementation)
+}
+
+func TestDetectProxyEIP1822(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.storage[proxyAddr+contract.SlotEIP1822Proxiable] = word(implAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxyUUPS, p.Kind)
+	assert.Equal(t, implAddr, p.Implementation)
+}
+
+func TestDetectProxySafe(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+	f.storage[proxyAddr+"0x0"] = word(implAddr)
+	f.calls[proxyAddr+"0xa619486e"] = word(implAddr)
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, contract.ProxySafe, p.Kind)
+	assert.Equal(t, implAddr, p.Implementation)
+}
+
+func TestDetectProxyNone(t *testing.T) {
+	f := newFakeChain()
+	f.code[proxyAddr] = "0x6080"
+
+	p, err := contract.DetectProxy(f, proxyAddr)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = contract.DetectProxy(f, adminAddr) // EOA
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestMergeABI(t *testing.T) {
+	impl := []contract.ABIEntry{
+		{Type: "constructor"},
+		{Type: "function", Name: "transfer", Inputs: []contract.ABIParam{{Type: "address"}, {Type: "uint256"}}},
+		{Type: "function", Name: "upgradeTo", Inputs: []contract.ABIParam{{Type: "address"}}},
+	}
+	own := []contract.ABIEntry{
+		{Type: "constructor", Inputs: []contract.ABIParam{{Type: "address"}}},
+		{Type: "function", Name: "upgradeTo", Inputs: []contract.ABIParam{{Type: "address"}}},
+		{Type: "function", Name: "admin"},
+		{Type: "fallback"},
+	}
+
+	merged := contract.MergeABI(impl, own)
+	require.Len(t, merged, 5)
+	assert.Empty(t, merged[0].Inputs, "base constructor wins")
+	assert.Equal(t, "admin", merged[3].Name)
+	assert.Equal(t, "fallback", merged[4].Type)
+}
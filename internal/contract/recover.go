@@ -0,0 +1,252 @@
+package contract
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// RecoveredFunction is a function found in a contract's dispatcher.
+type RecoveredFunction struct {
+	Selector string    // "0xa9059cbb"
+	Entry    *ABIEntry // nil when the selector is not in the signature database
+	// Mutability is inferred from the function body: "view" when no
+	// state-changing opcode is reachable, else "payable" or "nonpayable".
+	// Empty when the body could not be located.
+	Mutability string
+}
+
+// Signature returns "name(types)", or "" for an unknown selector.
+func (f RecoveredFunction) Signature() string {
+	if f.Entry == nil {
+		return ""
+	}
+	types := make([]string, len(f.Entry.Inputs))
+	for i, p := range f.Entry.Inputs {
+		types[i] = p.Type
+	}
+	return f.Entry.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// BytecodeAnalysis is the best-effort interface recovered from runtime code.
+type BytecodeAnalysis struct {
+	Size      int // runtime code size in bytes, metadata included
+	Functions []RecoveredFunction
+}
+
+// ABI returns the functions whose selectors were identified. Inferred
+// mutability wins over the database's, except that view is refined to pure.
+func (a *BytecodeAnalysis) ABI() []ABIEntry {
+	var abi []ABIEntry
+	for _, f := range a.Functions {
+		if f.Entry == nil {
+			continue
+		}
+		e := *f.Entry
+		if f.Mutability != "" && !(f.Mutability == "view" && e.StateMutability == "pure") {
+			e.StateMutability = f.Mutability
+		}
+		abi = append(abi, e)
+	}
+	return abi
+}
+
+// Unknown returns the selectors the signature database could not name.
+func (a *BytecodeAnalysis) Unknown() []string {
+	var out []string
+	for _, f := range a.Functions {
+		if f.Entry == nil {
+			out = append(out, f.Selector)
+		}
+	}
+	return out
+}
+
+// AnalyzeBytecode extracts the selectors a contract dispatches on, names
+// them from db and infers whether each can change state.
+//
+// Dispatchers compare the calldata selector against PUSH4 constants:
+// solc emits "PUSH4 sel EQ PUSH2 dest JUMPI" (optionally with a DUP/SWAP
+// before EQ) and vyper "PUSH4 sel XOR PUSH2 next JUMPI".
+func AnalyzeBytecode(code []byte, db *SignatureDB) *BytecodeAnalysis {
+	runtime, _ := SplitMetadata(code)
+	p := newProgram(Disassemble(runtime))
+	a := &BytecodeAnalysis{Size: len(code)}
+
+	type found struct {
+		selector string
+		entry    int
+	}
+	var sels []found
+	seen := make(map[string]bool)
+	firstCmp := -1
+	for i, ins := range p.ins {
+		if ins.Op != opPUSH4 || len(ins.Arg) != 4 || ins.Value() == 0xffffffff {
+			continue
+		}
+		entry, ok := p.dispatchTarget(i)
+		if !ok {
+			continue
+		}
+		if firstCmp < 0 {
+			firstCmp = i
+		}
+		sel := "0x" + hex.EncodeToString(ins.Arg)
+		if !seen[sel] {
+			seen[sel] = true
+			sels = append(sels, found{sel, entry})
+		}
+	}
+
+	// With no payable functions solc checks CALLVALUE once, before the
+	// dispatcher, instead of in every function.
+	globalNonPayable := false
+	for i := 0; i < firstCmp; i++ {
+		if p.ins[i].Op == opCALLVALUE {
+			globalNonPayable = true
+			break
+		}
+	}
+
+	for _, s := range sels {
+		f := RecoveredFunction{Selector: s.selector}
+		if e, ok := db.Lookup(s.selector); ok {
+			f.Entry = &e
+		}
+		if _, ok := p.index[s.entry]; ok {
+			switch {
+			case !p.writes(s.entry):
+				f.Mutability = "view"
+			case globalNonPayable || p.checksCallValue(s.entry):
+				f.Mutability = "nonpayable"
+			default:
+				f.Mutability = "payable"
+			}
+		}
+		a.Functions = append(a.Functions, f)
+	}
+	return a
+}
+
+// program is disassembled code indexed for control-flow walks.
+type program struct {
+	ins       []Instruction
+	index     map[int]int // pc → instruction index
+	jumpdests map[int]bool
+}
+
+func newProgram(ins []Instruction) *program {
+	p := &program{ins: ins, index: make(map[int]int, len(ins)), jumpdests: make(map[int]bool)}
+	for i, in := range ins {
+		p.index[in.PC] = i
+		if in.Op == opJUMPDEST {
+			p.jumpdests[in.PC] = true
+		}
+	}
+	return p
+}
+
+// dispatchTarget matches a selector comparison starting at the PUSH4 at i
+// and returns the pc where the function's code starts.
+func (p *program) dispatchTarget(i int) (int, bool) {
+	k := i + 1
+	for ; k < len(p.ins) && k <= i+2 && isDupOrSwap(p.ins[k].Op); k++ {
+	}
+	if k+2 >= len(p.ins) {
+		return 0, false
+	}
+	cmp, push, jumpi := p.ins[k], p.ins[k+1], p.ins[k+2]
+	if (cmp.Op != opEQ && cmp.Op != opXOR) || !isPush(push.Op) || jumpi.Op != opJUMPI {
+		return 0, false
+	}
+	if cmp.Op == opEQ {
+		return push.Value(), push.Value() >= 0
+	}
+	// XOR jumps away when the selector differs; the function falls through.
+	if k+3 >= len(p.ins) {
+		return 0, false
+	}
+	return p.ins[k+3].PC, true
+}
+
+// maxWalk bounds the instructions visited per function.
+const maxWalk = 50000
+
+// writes reports whether a state-changing opcode is reachable from entry.
+// Jump targets are over-approximated: every pushed value that is a
+// JUMPDEST and is used as one (a PUSH2, or a push right before a jump) is
+// followed, so internal calls and their return addresses are covered.
+func (p *program) writes(entry int) bool {
+	visited := map[int]bool{entry: true}
+	stack := []int{entry}
+	steps := 0
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for k := p.index[pc]; k < len(p.ins); k++ {
+			ins := p.ins[k]
+			if steps++; steps > maxWalk {
+				return true // too big to tell; assume it writes
+			}
+			if ins.Op == opJUMPDEST && ins.PC != pc {
+				if visited[ins.PC] {
+					break
+				}
+				visited[ins.PC] = true
+			}
+			if isStateChanging(ins.Op) {
+				return true
+			}
+			if isPush(ins.Op) {
+				if v := ins.Value(); p.jumpdests[v] && !visited[v] {
+					next := byte(0)
+					if k+1 < len(p.ins) {
+						next = p.ins[k+1].Op
+					}
+					if len(ins.Arg) == 2 || next == opJUMP || next == opJUMPI {
+						visited[v] = true
+						stack = append(stack, v)
+					}
+				}
+			}
+			if isTerminal(ins.Op) {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// checksCallValue reports whether the function rejects ETH: solc starts
+// non-payable functions with "CALLVALUE DUP1 ISZERO PUSH2 JUMPI".
+func (p *program) checksCallValue(entry int) bool {
+	start := p.index[entry]
+	for k := start; k < len(p.ins) && k < start+8; k++ {
+		switch p.ins[k].Op {
+		case opCALLVALUE:
+			return true
+		case opJUMP, opSTOP, opRETURN, opREVERT:
+			return false
+		}
+	}
+	return false
+}
+
+func isPush(op byte) bool { return op >= opPUSH1 && op <= opPUSH32 }
+
+func isDupOrSwap(op byte) bool { return op >= 0x80 && op <= 0x9f }
+
+func isTerminal(op byte) bool {
+	switch op {
+	case opSTOP, opJUMP, opRETURN, opREVERT, opINVALID, opSELFDESTRUCT:
+		return true
+	}
+	return false
+}
+
+func isStateChanging(op byte) bool {
+	switch op {
+	case opSSTORE, opTSTORE, opCREATE, opCREATE2, opCALL, opCALLCODE, opDELEGATECALL, opSELFDESTRUCT:
+		return true
+	}
+	return op >= opLOG0 && op <= opLOG4
+}
@@ -0,0 +1,169 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// asm assembles test bytecode. A byte is an opcode, []byte is raw code,
+// "@name" places a JUMPDEST label and ">name" pushes its address (PUSH2).
+func asm(t *testing.T, parts ...any) []byte {
+	t.Helper()
+	labels := map[string]int{}
+	pc := 0
+	for _, p := range parts {
+		switch v := p.(type) {
+		case byte:
+			pc++
+		case []byte:
+			pc += len(v)
+		case string:
+			if v[0] == '@' {
+				labels[v[1:]] = pc
+				pc++
+			} else {
+				pc += 3
+			}
+		}
+	}
+	var out []byte
+	for _, p := range parts {
+		switch v := p.(type) {
+		case byte:
+			out = append(out, v)
+		case []byte:
+			out = append(out, v...)
+		case string:
+			if v[0] == '@' {
+				out = append(out, opJUMPDEST)
+				continue
+			}
+			addr, ok := labels[v[1:]]
+			require.True(t, ok, v)
+			out = append(out, 0x61, byte(addr>>8), byte(addr))
+		}
+	}
+	return out
+}
+
+func push4(sel string) []byte {
+	b, _ := hex.DecodeString(sel[2:])
+	return append([]byte{opPUSH4}, b...)
+}
+
+const (
+	opSHR          = 0x1c
+	opISZERO       = 0x15
+	opPOP          = 0x50
+	opMSTORE       = 0x52
+	opSLOAD        = 0x54
+	opCALLER       = 0x33
+	opCALLDATALOAD = 0x35
+	opDUP1         = 0x80
+	opDUP2         = 0x81
+)
+
+var (
+	p0    = []byte{opPUSH1, 0x00}
+	sel   = []byte{opPUSH1, 0x00, opCALLDATALOAD, opPUSH1, 0xe0, opSHR}
+	ret32 = []byte{opPUSH1, 0x00, opMSTORE, opPUSH1, 0x20, opPUSH1, 0x00, opRETURN}
+	nopay = []byte{opCALLVALUE, opDUP1, opISZERO}
+)
+
+func TestAnalyzeBytecodeSolcStyle(t *testing.T) {
+	code := asm(t,
+		[]byte{opPUSH1, 0x80, opPUSH1, 0x40, opMSTORE}, sel,
+		byte(opDUP1), push4("0x8da5cb5b"), byte(opEQ), ">owner", byte(opJUMPI),
+		byte(opDUP1), push4("0xf2fde38b"), byte(opEQ), ">transfer", byte(opJUMPI),
+		byte(opDUP1), push4("0xd0e30db0"), byte(opEQ), ">deposit", byte(opJUMPI),
+		byte(opDUP1), push4("0x12345678"), byte(opEQ), ">mystery", byte(opJUMPI),
+		// A mask and a GT pivot are not selectors.
+		byte(opDUP1), push4("0xffffffff"), byte(opEQ), ">mystery", byte(opJUMPI),
+		byte(opDUP1), push4("0x70a08231"), byte(0x11), ">mystery", byte(opJUMPI),
+		p0, byte(opDUP1), byte(opREVERT),
+
+		"@owner", nopay, ">ownerOK", byte(opJUMPI), p0, byte(opDUP1), byte(opREVERT),
+		"@ownerOK", byte(opPOP), p0, byte(opSLOAD), ">ret", byte(opJUMP),
+		"@ret", ret32,
+
+		// Writes through an internal call that returns with a dynamic JUMP.
+		"@transfer", nopay, ">transferOK", byte(opJUMPI), p0, byte(opDUP1), byte(opREVERT),
+		"@transferOK", byte(opPOP), ">done", []byte{opPUSH1, 0x04, opCALLDATALOAD}, ">store", byte(opJUMP),
+		"@done", byte(opSTOP),
+		"@store", p0, byte(opSSTORE), byte(opJUMP),
+
+		// No CALLVALUE check: payable.
+		"@deposit", byte(opCALLER), p0, p0, byte(opLOG0+1), byte(opSTOP),
+
+		"@mystery", []byte{opPUSH1, 0x01}, ret32,
+		[]byte{0xa1, 0x00, 0x00, 0x02}, // metadata: must not be decoded
+	)
+
+	a := AnalyzeBytecode(code, NewSignatureDB())
+	require.Len(t, a.Functions, 4)
+	got := map[string]RecoveredFunction{}
+	for _, f := range a.Functions {
+		got[f.Selector] = f
+	}
+	assert.Equal(t, "owner()", got["0x8da5cb5b"].Signature())
+	assert.Equal(t, "view", got["0x8da5cb5b"].Mutability)
+	assert.Equal(t, "transferOwnership(address)", got["0xf2fde38b"].Signature())
+	assert.Equal(t, "nonpayable", got["0xf2fde38b"].Mutability)
+	assert.Equal(t, "payable", got["0xd0e30db0"].Mutability)
+	assert.Equal(t, "", got["0x12345678"].Signature())
+	assert.Equal(t, "view", got["0x12345678"].Mutability)
+	assert.Equal(t, []string{"0x12345678"}, a.Unknown())
+
+	abi := a.ABI()
+	require.Len(t, abi, 3)
+	for _, e := range abi {
+		if e.Name == "deposit" {
+			assert.Equal(t, "payable", e.StateMutability)
+		}
+	}
+}
+
+func TestAnalyzeBytecodeVyperStyle(t *testing.T) {
+	code := asm(t,
+		sel,
+		push4("0x8da5cb5b"), byte(opDUP2), byte(opXOR), ">next", byte(opJUMPI),
+		byte(opCALLVALUE), ">bad", byte(opJUMPI), p0, byte(opSLOAD), ret32,
+		"@next",
+		push4("0x8456cb59"), byte(opDUP2), byte(opXOR), ">bad", byte(opJUMPI),
+		byte(opCALLVALUE), ">bad", byte(opJUMPI), []byte{opPUSH1, 0x01}, p0, byte(opSSTORE), byte(opSTOP),
+		"@bad", p0, byte(opDUP1), byte(opREVERT),
+	)
+
+	a := AnalyzeBytecode(code, NewSignatureDB())
+	require.Len(t, a.Functions, 2)
+	assert.Equal(t, "owner()", a.Functions[0].Signature())
+	assert.Equal(t, "view", a.Functions[0].Mutability)
+	assert.Equal(t, "pause()", a.Functions[1].Signature())
+	assert.Equal(t, "nonpayable", a.Functions[1].Mutability)
+}
+
+func TestAnalyzeBytecodeW3Token(t *testing.T) {
+	// The runtime code follows the constructor in the init code.
+	initCode := chain.W3TokenInitCode()
+	i := bytes.Index(initCode[1:], []byte{0x60, 0x80, 0x60, 0x40, 0x52}) + 1
+	require.Positive(t, i)
+
+	a := AnalyzeBytecode(initCode[i:], NewSignatureDB())
+	assert.Empty(t, a.Unknown())
+	got := map[string]string{}
+	for _, e := range a.ABI() {
+		got[e.Name] = e.StateMutability
+	}
+	want := map[string]string{}
+	for _, e := range w3TokenABI {
+		if e.Type == "function" {
+			want[e.Name] = e.StateMutability
+		}
+	}
+	assert.Equal(t, want, got, "every function found, with the right mutability")
+}
@@ -21,12 +21,15 @@ type ABIEntry struct {
 	Inputs          []ABIParam `json:"inputs"`
 	Outputs         []ABIParam `json:"outputs"`
 	StateMutability string     `json:"stateMutability"`
+	Anonymous       bool       `json:"anonymous,omitempty"` // events only
 }
 
 // ABIParam is a parameter in an ABI entry.
 type ABIParam struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Components []ABIParam `json:"components,omitempty"` // tuple members
+	Indexed    bool       `json:"indexed,omitempty"`    // event parameters only
 }
 
 // IsReadFunction returns true if the function is read-only (view/pure).
@@ -48,14 +51,19 @@ func (e ABIEntry) Selector() string {
 	if e.Type == "event" || e.Type == "constructor" || e.Type == "receive" || e.Type == "fallback" {
 		return ""
 	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(e.Signature()))
+	return "0x" + hex.EncodeToString(h.Sum(nil)[:4])
+}
+
+// Signature returns the canonical signature, e.g. "transfer(address,uint256)",
+// with tuple parameters spelled out as "(address,uint256)".
+func (e ABIEntry) Signature() string {
 	types := make([]string, len(e.Inputs))
 	for i, p := range e.Inputs {
-		types[i] = p.Type
+		types[i] = p.CanonicalType()
 	}
-	sig := e.Name + "(" + strings.Join(types, ",") + ")"
-	h := sha3.NewLegacyKeccak256()
-	h.Write([]byte(sig))
-	return "0x" + hex.EncodeToString(h.Sum(nil)[:4])
+	return e.Name + "(" + strings.Join(types, ",") + ")"
 }
 
 // Entry is a stored contract.
@@ -65,19 +73,66 @@ type Entry struct {
 	Address string     `json:"address"`
 	ABI     []ABIEntry `json:"abi"`
 	ABIUrl  string     `json:"abi_url,omitempty"`
+	ABIHash string     `json:"abi_hash,omitempty"` // keccak256 of the synced ABI file
 
 	// Metadata added by `token create` and `contract add/import`.
-	Kind       string `json:"kind,omitempty"`        // "builtin", "imported", "custom"
+	Kind       string `json:"kind,omitempty"`        // "builtin", "imported", "fetched", "custom", "synced"
 	BuiltinID  string `json:"builtin_id,omitempty"`  // e.g. "w3token", "erc20"
 	ABISource  string `json:"abi_source,omitempty"`  // path to ABI file (imported contracts)
 	Deployer   string `json:"deployer,omitempty"`    // deployer wallet address
 	TxHash     string `json:"tx_hash,omitempty"`     // deployment tx hash
 	DeployedAt string `json:"deployed_at,omitempty"` // RFC3339 timestamp
+
+	// ConstructorArgs is the ABI-encoded constructor args (hex) used at deploy,
+	// kept for source verification.
+	ConstructorArgs string `json:"constructor_args,omitempty"`
+
+	// Proxy details, when the address is a proxy (see DetectProxy). ABI then
+	// holds the implementation's ABI merged with the proxy's own.
+	ProxyKind      string `json:"proxy_kind,omitempty"`
+	Implementation string `json:"implementation,omitempty"`
+	// ImplementationHistory lists the implementations the proxy has pointed
+	// to, oldest first; the last one is Implementation.
+	ImplementationHistory []ImplementationRecord `json:"implementation_history,omitempty"`
+
+	// CREATE2 deployments: the salt (hex) and factory address used, so the
+	// same address can be reproduced on other chains.
+	Salt    string `json:"salt,omitempty"`
+	Factory string `json:"factory,omitempty"`
+}
+
+// ImplementationRecord is one implementation a proxy has pointed to.
+type ImplementationRecord struct {
+	Address  string `json:"address"`
+	Artifact string `json:"artifact,omitempty"` // artifact it was deployed from, for layout checks
+	TxHash   string `json:"tx_hash,omitempty"`  // proxy deploy or upgrade tx; empty when detected by sync
+	At       string `json:"at"`                 // RFC3339: when deployed or first seen
+}
+
+// RecordImplementation makes rec the entry's implementation, appending it to
+// the history unless it already is the latest record.
+func (e *Entry) RecordImplementation(rec ImplementationRecord) {
+	e.Implementation = rec.Address
+	if n := len(e.ImplementationHistory); n > 0 && strings.EqualFold(e.ImplementationHistory[n-1].Address, rec.Address) {
+		return
+	}
+	e.ImplementationHistory = append(e.ImplementationHistory, rec)
+}
+
+// ImplementationArtifact returns the artifact the current implementation was
+// deployed from, or "" if w3cli did not deploy it.
+func (e *Entry) ImplementationArtifact() string {
+	if n := len(e.ImplementationHistory); n > 0 {
+		if last := e.ImplementationHistory[n-1]; strings.EqualFold(last.Address, e.Implementation) {
+			return last.Artifact
+		}
+	}
+	return ""
 }
 
 // Registry stores and retrieves contract entries.
 type Registry struct {
-	path     string
+	path      string
 	contracts map[string]*Entry // key: "name@network"
 }
 
@@ -334,3 +334,22 @@ func TestRegistrySaveEmptyRegistry(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, &entries))
 	assert.Empty(t, entries)
 }
+
+func TestEntryRecordImplementation(t *testing.T) {
+	e := &contract.Entry{Name: "Vault", Network: "base", Address: "0xProxy"}
+	assert.Equal(t, "", e.ImplementationArtifact())
+
+	e.RecordImplementation(contract.ImplementationRecord{Address: "0xAAA", Artifact: "out/Vault.json", At: "2026-01-01T00:00:00Z"})
+	e.RecordImplementation(contract.ImplementationRecord{Address: "0xaaa", At: "2026-01-02T00:00:00Z"})
+	require.Len(t, e.ImplementationHistory, 1)
+	assert.Equal(t, "out/Vault.json", e.ImplementationArtifact())
+
+	e.RecordImplementation(contract.ImplementationRecord{Address: "0xBBB", Artifact: "out/VaultV2.json", At: "2026-02-01T00:00:00Z"})
+	require.Len(t, e.ImplementationHistory, 2)
+	assert.Equal(t, "0xBBB", e.Implementation)
+	assert.Equal(t, "out/VaultV2.json", e.ImplementationArtifact())
+
+	// Upgraded elsewhere: the recorded artifact no longer applies.
+	e.Implementation = "0xCCC"
+	assert.Equal(t, "", e.ImplementationArtifact())
+}
@@ -0,0 +1,212 @@
+package contract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// knownSignatures are widely deployed functions beyond the built-in ABIs,
+// used to name selectors recovered from unverified bytecode. Format:
+// "name(types) [view|pure|payable] [returns (types)]".
+var knownSignatures = []string{
+	// ERC-20 extensions
+	"increaseAllowance(address,uint256) returns (bool)",
+	"decreaseAllowance(address,uint256) returns (bool)",
+	"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)",
+	"nonces(address) view returns (uint256)",
+	"DOMAIN_SEPARATOR() view returns (bytes32)",
+	"eip712Domain() view returns (bytes1,string,string,uint256,address,bytes32,uint256[])",
+	"cap() view returns (uint256)",
+	"mint(uint256)",
+	"burn(address,uint256)",
+	"version() view returns (string)",
+
+	// WETH
+	"deposit() payable",
+	"withdraw(uint256)",
+
+	// Ownable / AccessControl / Pausable
+	"pendingOwner() view returns (address)",
+	"acceptOwnership()",
+	"hasRole(bytes32,address) view returns (bool)",
+	"getRoleAdmin(bytes32) view returns (bytes32)",
+	"grantRole(bytes32,address)",
+	"revokeRole(bytes32,address)",
+	"renounceRole(bytes32,address)",
+	"DEFAULT_ADMIN_ROLE() view returns (bytes32)",
+	"MINTER_ROLE() view returns (bytes32)",
+	"PAUSER_ROLE() view returns (bytes32)",
+	"paused() view returns (bool)",
+	"pause()",
+	"unpause()",
+
+	// ERC-165 / ERC-721 / ERC-1155 / ERC-2981
+	"supportsInterface(bytes4) view returns (bool)",
+	"ownerOf(uint256) view returns (address)",
+	"getApproved(uint256) view returns (address)",
+	"setApprovalForAll(address,bool)",
+	"isApprovedForAll(address,address) view returns (bool)",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"tokenURI(uint256) view returns (string)",
+	"baseURI() view returns (string)",
+	"tokenByIndex(uint256) view returns (uint256)",
+	"tokenOfOwnerByIndex(address,uint256) view returns (uint256)",
+	"balanceOf(address,uint256) view returns (uint256)",
+	"balanceOfBatch(address[],uint256[]) view returns (uint256[])",
+	"uri(uint256) view returns (string)",
+	"safeTransferFrom(address,address,uint256,uint256,bytes)",
+	"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+	"royaltyInfo(uint256,uint256) view returns (address,uint256)",
+
+	// ERC-4626
+	"asset() view returns (address)",
+	"totalAssets() view returns (uint256)",
+	"convertToShares(uint256) view returns (uint256)",
+	"convertToAssets(uint256) view returns (uint256)",
+	"maxDeposit(address) view returns (uint256)",
+	"maxMint(address) view returns (uint256)",
+	"maxWithdraw(address) view returns (uint256)",
+	"maxRedeem(address) view returns (uint256)",
+	"previewDeposit(uint256) view returns (uint256)",
+	"previewMint(uint256) view returns (uint256)",
+	"previewWithdraw(uint256) view returns (uint256)",
+	"previewRedeem(uint256) view returns (uint256)",
+	"deposit(uint256,address) returns (uint256)",
+	"mint(uint256,address) returns (uint256)",
+	"withdraw(uint256,address,address) returns (uint256)",
+	"redeem(uint256,address,address) returns (uint256)",
+
+	// Proxies and upgrades
+	"implementation() view returns (address)",
+	"admin() view returns (address)",
+	"upgradeTo(address)",
+	"upgradeToAndCall(address,bytes) payable",
+	"proxiableUUID() view returns (bytes32)",
+	"changeAdmin(address)",
+	"initialize()",
+
+	// Multicall / Safe / Uniswap V2
+	"multicall(bytes[]) returns (bytes[])",
+	"getOwners() view returns (address[])",
+	"getThreshold() view returns (uint256)",
+	"nonce() view returns (uint256)",
+	"VERSION() view returns (string)",
+	"factory() view returns (address)",
+	"token0() view returns (address)",
+	"token1() view returns (address)",
+	"getReserves() view returns (uint112,uint112,uint32)",
+	"price0CumulativeLast() view returns (uint256)",
+	"price1CumulativeLast() view returns (uint256)",
+	"kLast() view returns (uint256)",
+	"sync()",
+	"skim(address)",
+	"swap(uint256,uint256,address,bytes)",
+	"getPair(address,address) view returns (address)",
+	"allPairsLength() view returns (uint256)",
+	"WETH() view returns (address)",
+}
+
+// SignatureDB maps 4-byte selectors to function ABI entries.
+type SignatureDB struct {
+	entries map[string]ABIEntry // key: "0x" + selector
+}
+
+// NewSignatureDB returns a database seeded with knownSignatures and every
+// built-in ABI.
+func NewSignatureDB() *SignatureDB {
+	db := &SignatureDB{entries: make(map[string]ABIEntry)}
+	for _, s := range knownSignatures {
+		e, err := ParseSignature(s)
+		if err != nil {
+			panic(fmt.Sprintf("bad known signature %q: %v", s, err))
+		}
+		db.entries[e.Selector()] = e
+	}
+	for _, b := range AllBuiltins() {
+		db.Add(b.ABI)
+	}
+	return db
+}
+
+// Add indexes the functions in abi, replacing earlier entries with the
+// same selector — real ABIs carry parameter names the signature list lacks.
+func (db *SignatureDB) Add(abi []ABIEntry) {
+	for _, e := range abi {
+		if e.Type == "function" {
+			db.entries[e.Selector()] = e
+		}
+	}
+}
+
+// Lookup returns the function for a 0x-prefixed selector.
+func (db *SignatureDB) Lookup(selector string) (ABIEntry, bool) {
+	e, ok := db.entries[strings.ToLower(selector)]
+	return e, ok
+}
+
+// Len returns the number of known selectors.
+func (db *SignatureDB) Len() int { return len(db.entries) }
+
+// ParseSignature parses "name(types) [view|pure|payable] [returns (types)]"
+// into a function entry. Without a mutability keyword it is nonpayable.
+func ParseSignature(sig string) (ABIEntry, error) {
+	sig = strings.TrimSpace(sig)
+	open := strings.Index(sig, "(")
+	if open <= 0 {
+		return ABIEntry{}, fmt.Errorf("expected name(types)")
+	}
+	inputs, rest, err := splitTypeList(sig[open:])
+	if err != nil {
+		return ABIEntry{}, err
+	}
+	e := ABIEntry{Type: "function", Name: sig[:open], Inputs: inputs, StateMutability: "nonpayable"}
+
+	rest = strings.TrimSpace(rest)
+	for rest != "" {
+		word, tail, _ := strings.Cut(rest, " ")
+		switch word {
+		case "view", "pure", "payable", "nonpayable":
+			e.StateMutability = word
+			rest = strings.TrimSpace(tail)
+		case "returns":
+			if e.Outputs, rest, err = splitTypeList(strings.TrimSpace(tail)); err != nil {
+				return ABIEntry{}, err
+			}
+			rest = strings.TrimSpace(rest)
+		default:
+			return ABIEntry{}, fmt.Errorf("unexpected %q", word)
+		}
+	}
+	return e, nil
+}
+
+// splitTypeList parses "(t1,t2,...)" at the start of s, keeping tuple types
+// whole, and returns the remainder after the closing parenthesis.
+func splitTypeList(s string) ([]ABIParam, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("expected ( in %q", s)
+	}
+	var params []ABIParam
+	depth, start := 0, 1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				if t := strings.TrimSpace(s[start:i]); t != "" {
+					params = append(params, ABIParam{Type: t})
+				}
+				return params, s[i+1:], nil
+			}
+		case ',':
+			if depth == 1 {
+				params = append(params, ABIParam{Type: strings.TrimSpace(s[start:i])})
+				start = i + 1
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("unbalanced parentheses in %q", s)
+}
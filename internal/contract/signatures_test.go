@@ -0,0 +1,58 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignature(t *testing.T) {
+	e, err := ParseSignature("balanceOfBatch(address[],uint256[]) view returns (uint256[])")
+	require.NoError(t, err)
+	assert.Equal(t, "balanceOfBatch", e.Name)
+	assert.Equal(t, []ABIParam{{Type: "address[]"}, {Type: "uint256[]"}}, e.Inputs)
+	assert.Equal(t, []ABIParam{{Type: "uint256[]"}}, e.Outputs)
+	assert.Equal(t, "view", e.StateMutability)
+	assert.Equal(t, "0x4e1273f4", e.Selector())
+
+	e, err = ParseSignature("deposit() payable")
+	require.NoError(t, err)
+	assert.Empty(t, e.Inputs)
+	assert.Equal(t, "payable", e.StateMutability)
+
+	e, err = ParseSignature("submit((address,uint256)[],bytes)")
+	require.NoError(t, err)
+	assert.Equal(t, []ABIParam{{Type: "(address,uint256)[]"}, {Type: "bytes"}}, e.Inputs, "tuples stay whole")
+	assert.Equal(t, "nonpayable", e.StateMutability)
+
+	for _, bad := range []string{"", "noparens", "(uint256)", "f(uint256", "f() constant", "f() returns uint256"} {
+		_, err := ParseSignature(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSignatureDB(t *testing.T) {
+	db := NewSignatureDB()
+	assert.Greater(t, db.Len(), len(knownSignatures))
+
+	e, ok := db.Lookup("0xA9059CBB")
+	require.True(t, ok)
+	assert.Equal(t, "transfer", e.Name)
+	assert.Equal(t, "to", e.Inputs[0].Name, "built-in ABIs replace the bare signature list")
+
+	e, ok = db.Lookup("0x8456cb59")
+	require.True(t, ok)
+	assert.Equal(t, "pause", e.Name)
+
+	_, ok = db.Lookup("0xdeadbeef")
+	assert.False(t, ok)
+
+	db.Add([]ABIEntry{
+		{Type: "function", Name: "harvest", Inputs: []ABIParam{{Name: "pool", Type: "uint256"}}},
+		{Type: "event", Name: "Harvested"},
+	})
+	e, ok = db.Lookup(ABIEntry{Name: "harvest", Inputs: []ABIParam{{Type: "uint256"}}}.Selector())
+	require.True(t, ok)
+	assert.Equal(t, "pool", e.Inputs[0].Name)
+}
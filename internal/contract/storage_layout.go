@@ -0,0 +1,468 @@
+package contract
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// StorageLayout is solc's storageLayout output for one contract.
+type StorageLayout struct {
+	Storage []StorageVar            `json:"storage"`
+	Types   map[string]*StorageType `json:"types"`
+}
+
+// StorageVar is a state variable (or struct member) in a StorageLayout.
+type StorageVar struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// StorageType describes a type ID referenced from a StorageLayout.
+type StorageType struct {
+	Encoding      string       `json:"encoding"` // inplace, mapping, dynamic_array, bytes
+	Label         string       `json:"label"`
+	NumberOfBytes string       `json:"numberOfBytes"`
+	Base          string       `json:"base,omitempty"`
+	Key           string       `json:"key,omitempty"`
+	Value         string       `json:"value,omitempty"`
+	Members       []StorageVar `json:"members,omitempty"`
+}
+
+// size returns the type's byte size.
+func (t *StorageType) size() int {
+	n, _ := strconv.Atoi(t.NumberOfBytes)
+	return n
+}
+
+// StorageLocation is where a variable lives: a slot, a byte offset from the
+// right of the slot, and its type.
+type StorageLocation struct {
+	Path   string
+	Slot   *big.Int
+	Offset int
+	Type   *StorageType
+}
+
+// SlotReader reads one 32-byte storage word.
+type SlotReader func(slot *big.Int) ([]byte, error)
+
+// maxDecodeWords caps how many slots a single string/bytes/array read may
+// touch, so a corrupt length never turns into thousands of RPC calls.
+const maxDecodeWords = 64
+
+// LoadStorageLayout reads the storage layout behind an artifact:
+//
+//   - Foundry artifact / hardhat-deploy record with a "storageLayout" key
+//   - Hardhat artifact: the build-info output for the contract
+//   - a raw storageLayout JSON file ({"storage": [...], "types": {...}})
+func LoadStorageLayout(path string) (*StorageLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read artifact file: %w", err)
+	}
+	var art struct {
+		StorageLayout *StorageLayout          `json:"storageLayout"`
+		Storage       []StorageVar            `json:"storage"`
+		Types         map[string]*StorageType `json:"types"`
+		ContractName  string                  `json:"contractName"`
+		SourceName    string                  `json:"sourceName"`
+	}
+	if err := json.Unmarshal(data, &art); err != nil {
+		return nil, fmt.Errorf("invalid artifact JSON: %w", err)
+	}
+
+	switch {
+	case art.StorageLayout != nil:
+		return art.StorageLayout, nil
+	case art.Storage != nil:
+		return &StorageLayout{Storage: art.Storage, Types: art.Types}, nil
+	case art.SourceName != "" && art.ContractName != "":
+		return hardhatStorageLayout(path, art.SourceName, art.ContractName)
+	}
+	return nil, fmt.Errorf("%s has no storage layout — Foundry: build with extra_output = [\"storageLayout\"]; Hardhat: add \"storageLayout\" to outputSelection", path)
+}
+
+func hardhatStorageLayout(path, sourceName, contractName string) (*StorageLayout, error) {
+	buildInfo, err := findHardhatBuildInfo(path, sourceName, contractName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(buildInfo)
+	if err != nil {
+		return nil, err
+	}
+	var bi struct {
+		Output struct {
+			Contracts map[string]map[string]struct {
+				StorageLayout *StorageLayout `json:"storageLayout"`
+			} `json:"contracts"`
+		} `json:"output"`
+	}
+	if err := json.Unmarshal(data, &bi); err != nil {
+		return nil, fmt.Errorf("parsing build info %s: %w", buildInfo, err)
+	}
+	layout := bi.Output.Contracts[sourceName][contractName].StorageLayout
+	if layout == nil {
+		return nil, fmt.Errorf("build info for %s:%s has no storage layout — add \"storageLayout\" to outputSelection in hardhat.config and recompile", sourceName, contractName)
+	}
+	return layout, nil
+}
+
+// ── Slot resolution ──────────────────────────────────────────────────────────
+
+// Resolve computes the location of a variable path such as "owner",
+// "balances[0xabc…]", "positions[3].amount" or "allowance[0xa][0xb]".
+func (l *StorageLayout) Resolve(path string) (*StorageLocation, error) {
+	name, rest := splitIdent(path)
+	if name == "" {
+		return nil, fmt.Errorf("invalid variable path %q", path)
+	}
+	var loc *StorageLocation
+	for _, v := range l.Storage {
+		if v.Label == name {
+			var err error
+			if loc, err = l.location(name, big.NewInt(0), v); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if loc == nil {
+		return nil, fmt.Errorf("no state variable %q in the storage layout", name)
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %q", path)
+			}
+			var err error
+			if loc, err = l.index(loc, strings.TrimSpace(rest[1:end])); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		case '.':
+			var member string
+			member, rest = splitIdent(rest[1:])
+			var err error
+			if loc, err = l.member(loc, member); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest, path)
+		}
+	}
+	return loc, nil
+}
+
+func (l *StorageLayout) location(path string, base *big.Int, v StorageVar) (*StorageLocation, error) {
+	t := l.Types[v.Type]
+	if t == nil {
+		return nil, fmt.Errorf("storage layout has no type %q", v.Type)
+	}
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid slot %q for %s", v.Slot, v.Label)
+	}
+	return &StorageLocation{Path: path, Slot: slot.Add(slot, base), Offset: v.Offset, Type: t}, nil
+}
+
+func (l *StorageLayout) member(loc *StorageLocation, name string) (*StorageLocation, error) {
+	for _, m := range loc.Type.Members {
+		if m.Label == name {
+			return l.location(loc.Path+"."+name, loc.Slot, m)
+		}
+	}
+	return nil, fmt.Errorf("%s (%s) has no member %q", loc.Path, loc.Type.Label, name)
+}
+
+func (l *StorageLayout) index(loc *StorageLocation, key string) (*StorageLocation, error) {
+	t := loc.Type
+	path := loc.Path + "[" + key + "]"
+	switch {
+	case t.Encoding == "mapping":
+		keyType, valType := l.Types[t.Key], l.Types[t.Value]
+		if keyType == nil || valType == nil {
+			return nil, fmt.Errorf("storage layout is missing the types of %s", t.Label)
+		}
+		enc, err := encodeMappingKey(keyType, key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		slot := keccakInt(append(enc, word32(loc.Slot)...))
+		return &StorageLocation{Path: path, Slot: slot, Type: valType}, nil
+
+	case t.Encoding == "dynamic_array" || (t.Encoding == "inplace" && t.Base != ""):
+		elem := l.Types[t.Base]
+		if elem == nil {
+			return nil, fmt.Errorf("storage layout is missing the element type of %s", t.Label)
+		}
+		i, ok := new(big.Int).SetString(key, 0)
+		if !ok || i.Sign() < 0 {
+			return nil, fmt.Errorf("%s: index must be a non-negative integer", path)
+		}
+		start := loc.Slot
+		if t.Encoding == "dynamic_array" {
+			start = keccakInt(word32(loc.Slot))
+		} else if n := staticArrayLen(t.Label); n >= 0 && i.Cmp(big.NewInt(n)) >= 0 {
+			return nil, fmt.Errorf("%s: index out of range for %s", path, t.Label)
+		}
+		slot, offset := arrayElement(start, i, elem.size())
+		return &StorageLocation{Path: path, Slot: slot, Offset: offset, Type: elem}, nil
+	}
+	return nil, fmt.Errorf("%s (%s) cannot be indexed", loc.Path, t.Label)
+}
+
+// arrayElement returns the slot and offset of element i of an array whose
+// data starts at start. Elements smaller than 32 bytes are packed.
+func arrayElement(start, i *big.Int, size int) (*big.Int, int) {
+	if size <= 0 {
+		size = 32
+	}
+	if size < 32 {
+		per := big.NewInt(int64(32 / size))
+		q, r := new(big.Int).QuoRem(i, per, new(big.Int))
+		return q.Add(q, start), int(r.Int64()) * size
+	}
+	words := big.NewInt(int64((size + 31) / 32))
+	return new(big.Int).Add(start, new(big.Int).Mul(i, words)), 0
+}
+
+// staticArrayLen parses N from a label like "uint256[4]"; -1 when unknown.
+func staticArrayLen(label string) int64 {
+	open := strings.LastIndexByte(label, '[')
+	if open < 0 || !strings.HasSuffix(label, "]") {
+		return -1
+	}
+	n, err := strconv.ParseInt(label[open+1:len(label)-1], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// encodeMappingKey encodes a mapping key the way solc hashes it: value types
+// padded to 32 bytes, string and bytes keys unpadded.
+func encodeMappingKey(t *StorageType, key string) ([]byte, error) {
+	key = strings.Trim(key, `"'`)
+	label := t.Label
+	switch {
+	case label == "string":
+		return []byte(key), nil
+	case label == "bytes":
+		return hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	case label == "bool":
+		switch key {
+		case "true", "1":
+			return word32(big.NewInt(1)), nil
+		case "false", "0":
+			return word32(big.NewInt(0)), nil
+		}
+		return nil, fmt.Errorf("invalid bool key %q", key)
+	case strings.HasPrefix(label, "address") || strings.HasPrefix(label, "contract "):
+		b, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(key), "0x"))
+		if err != nil || len(b) != 20 {
+			return nil, fmt.Errorf("invalid address key %q", key)
+		}
+		return append(make([]byte, 12), b...), nil
+	case strings.HasPrefix(label, "bytes"):
+		b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(b) > 32 {
+			return nil, fmt.Errorf("invalid %s key %q", label, key)
+		}
+		return append(b, make([]byte, 32-len(b))...), nil
+	default: // uintN, intN, enums
+		n, ok := new(big.Int).SetString(key, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s key %q", label, key)
+		}
+		return word32(n), nil // Mod wraps negatives to two's complement
+	}
+}
+
+// ── Decoding ─────────────────────────────────────────────────────────────────
+
+// Decode reads the value at loc and renders it by type. Mappings render as a
+// hint (they cannot be enumerated), dynamic arrays as their length, structs
+// and static arrays as their members.
+func (l *StorageLayout) Decode(loc *StorageLocation, read SlotReader) (string, error) {
+	t := loc.Type
+	switch t.Encoding {
+	case "mapping":
+		return fmt.Sprintf("<%s> — index with [key]", t.Label), nil
+
+	case "dynamic_array":
+		w, err := read(loc.Slot)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("length %s — index with [i]", new(big.Int).SetBytes(w)), nil
+
+	case "bytes":
+		return l.decodeBytes(loc, read)
+	}
+
+	if len(t.Members) > 0 {
+		parts := make([]string, 0, len(t.Members))
+		for _, m := range t.Members {
+			ml, err := l.location(loc.Path+"."+m.Label, loc.Slot, m)
+			if err != nil {
+				return "", err
+			}
+			v, err := l.Decode(ml, read)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, m.Label+": "+v)
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	}
+
+	if t.Base != "" {
+		n := staticArrayLen(t.Label)
+		shown := n
+		if shown > maxDecodeWords {
+			shown = maxDecodeWords
+		}
+		parts := make([]string, 0, shown)
+		for i := int64(0); i < shown; i++ {
+			el, err := l.index(loc, strconv.FormatInt(i, 10))
+			if err != nil {
+				return "", err
+			}
+			v, err := l.Decode(el, read)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, v)
+		}
+		if shown < n {
+			parts = append(parts, "…")
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	}
+
+	w, err := read(loc.Slot)
+	if err != nil {
+		return "", err
+	}
+	size := t.size()
+	if size <= 0 || loc.Offset+size > 32 {
+		return "0x" + hex.EncodeToString(w), nil
+	}
+	return decodeStorageValue(t.Label, w[32-loc.Offset-size:32-loc.Offset]), nil
+}
+
+// decodeBytes handles string/bytes storage: short values live in the slot
+// (length*2 in the lowest byte), long ones at keccak(slot) with length*2+1.
+func (l *StorageLayout) decodeBytes(loc *StorageLocation, read SlotReader) (string, error) {
+	w, err := read(loc.Slot)
+	if err != nil {
+		return "", err
+	}
+	var data []byte
+	truncated := false
+	if w[31]&1 == 0 {
+		n := int(w[31] / 2)
+		if n > 31 {
+			n = 31
+		}
+		data = w[:n]
+	} else {
+		length := new(big.Int).Rsh(new(big.Int).SetBytes(w), 1)
+		n := maxDecodeWords * 32
+		if length.IsInt64() && length.Int64() < int64(n) {
+			n = int(length.Int64())
+		} else {
+			truncated = true
+		}
+		start := keccakInt(word32(loc.Slot))
+		for i := 0; len(data) < n; i++ {
+			chunk, err := read(new(big.Int).Add(start, big.NewInt(int64(i))))
+			if err != nil {
+				return "", err
+			}
+			data = append(data, chunk...)
+		}
+		data = data[:n]
+	}
+
+	var out string
+	if loc.Type.Label == "string" {
+		out = strconv.Quote(string(data))
+	} else {
+		out = "0x" + hex.EncodeToString(data)
+	}
+	if truncated {
+		out += " …"
+	}
+	return out, nil
+}
+
+// decodeStorageValue renders the packed bytes of a value type.
+func decodeStorageValue(label string, b []byte) string {
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(b[len(b)-1] != 0)
+	case strings.HasPrefix(label, "address") || strings.HasPrefix(label, "contract "):
+		if len(b) > 20 {
+			b = b[len(b)-20:]
+		}
+		return "0x" + hex.EncodeToString(b)
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(b).String()
+	case strings.HasPrefix(label, "int"):
+		n := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return n.String()
+	default: // bytesN, user-defined value types
+		return "0x" + hex.EncodeToString(b)
+	}
+}
+
+// ── helpers ──────────────────────────────────────────────────────────────────
+
+// splitIdent splits a leading identifier off s.
+func splitIdent(s string) (string, string) {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			i++
+			continue
+		}
+		break
+	}
+	return s[:i], s[i:]
+}
+
+// slotSpace is 2^256; slot arithmetic wraps around it.
+var slotSpace = new(big.Int).Lsh(big.NewInt(1), 256)
+
+func word32(n *big.Int) []byte {
+	return new(big.Int).Mod(n, slotSpace).FillBytes(make([]byte, 32))
+}
+
+// SlotHex formats a storage slot as a 0x-prefixed 32-byte hex word.
+func SlotHex(slot *big.Int) string {
+	return "0x" + hex.EncodeToString(word32(slot))
+}
+
+func keccakInt(data []byte) *big.Int {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
@@ -0,0 +1,203 @@
+package contract_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vaultLayout = `{
+	"storage": [
+		{"label": "owner",     "offset": 0,  "slot": "0", "type": "t_address"},
+		{"label": "paused",    "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "balances",  "offset": 0,  "slot": "1", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "positions", "offset": 0,  "slot": "2", "type": "t_array(t_struct(Position)dyn_storage"},
+		{"label": "name",      "offset": 0,  "slot": "3", "type": "t_string_storage"},
+		{"label": "small",     "offset": 0,  "slot": "4", "type": "t_array(t_uint8)4_storage"},
+		{"label": "allowance", "offset": 0,  "slot": "5", "type": "t_mapping(t_address,t_mapping(t_address,t_uint256))"},
+		{"label": "delta",     "offset": 0,  "slot": "6", "type": "t_int64"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_bool":    {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_uint8":   {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+		"t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_int64":   {"encoding": "inplace", "label": "int64", "numberOfBytes": "8"},
+		"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "label": "mapping(address => uint256)", "numberOfBytes": "32", "key": "t_address", "value": "t_uint256"},
+		"t_mapping(t_address,t_mapping(t_address,t_uint256))": {"encoding": "mapping", "label": "mapping(address => mapping(address => uint256))", "numberOfBytes": "32", "key": "t_address", "value": "t_mapping(t_address,t_uint256)"},
+		"t_array(t_struct(Position)dyn_storage": {"encoding": "dynamic_array", "label": "struct Vault.Position[]", "numberOfBytes": "32", "base": "t_struct(Position)"},
+		"t_array(t_uint8)4_storage": {"encoding": "inplace", "label": "uint8[4]", "numberOfBytes": "32", "base": "t_uint8"},
+		"t_struct(Position)": {"encoding": "inplace", "label": "struct Vault.Position", "numberOfBytes": "64", "members": [
+			{"label": "amount", "offset": 0,  "slot": "0", "type": "t_uint128"},
+			{"label": "debt",   "offset": 16, "slot": "0", "type": "t_uint128"},
+			{"label": "user",   "offset": 0,  "slot": "1", "type": "t_address"}
+		]}
+	}
+}`
+
+const (
+	holder  = "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+	spender = "0x5555555555555555555555555555555555555555"
+)
+
+func loadVaultLayout(t *testing.T) *contract.StorageLayout {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "Vault.json")
+	writeFile(t, p, `{"abi": [], "storageLayout": `+vaultLayout+`}`)
+	l, err := contract.LoadStorageLayout(p)
+	require.NoError(t, err)
+	return l
+}
+
+func slotOf(n int64) []byte { return common.BigToHash(big.NewInt(n)).Bytes() }
+
+func mappingSlot(key []byte, slot []byte) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(common.LeftPadBytes(key, 32), slot))
+}
+
+func TestStorageLayoutResolve(t *testing.T) {
+	l := loadVaultLayout(t)
+
+	loc, err := l.Resolve("paused")
+	require.NoError(t, err)
+	assert.Zero(t, loc.Slot.Sign())
+	assert.Equal(t, 20, loc.Offset)
+
+	loc, err = l.Resolve("balances[" + holder + "]")
+	require.NoError(t, err)
+	assert.Equal(t, mappingSlot(common.HexToAddress(holder).Bytes(), slotOf(1)), loc.Slot)
+	assert.Equal(t, "uint256", loc.Type.Label)
+
+	// Nested mapping: keccak(spender . keccak(holder . 5)).
+	outer := mappingSlot(common.HexToAddress(holder).Bytes(), slotOf(5))
+	loc, err = l.Resolve("allowance[" + holder + "][" + spender + "]")
+	require.NoError(t, err)
+	assert.Equal(t, mappingSlot(common.HexToAddress(spender).Bytes(), common.BigToHash(outer).Bytes()), loc.Slot)
+
+	// Dynamic array of 2-slot structs: keccak(2) + 3*2 (+1 for user).
+	data := new(big.Int).SetBytes(crypto.Keccak256(slotOf(2)))
+	loc, err = l.Resolve("positions[3].debt")
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(data, big.NewInt(6)), loc.Slot)
+	assert.Equal(t, 16, loc.Offset)
+	loc, err = l.Resolve("positions[3].user")
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(data, big.NewInt(7)), loc.Slot)
+
+	// Packed static array: uint8[4] fits in one slot.
+	loc, err = l.Resolve("small[2]")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), loc.Slot.Int64())
+	assert.Equal(t, 2, loc.Offset)
+}
+
+func TestStorageLayoutResolveErrors(t *testing.T) {
+	l := loadVaultLayout(t)
+	for _, path := range []string{
+		"missing",
+		"owner[1]",
+		"balances[0x12]",
+		"small[4]",
+		"positions[-1]",
+		"positions[0].nope",
+		"balances[" + holder,
+	} {
+		_, err := l.Resolve(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestStorageLayoutDecode(t *testing.T) {
+	l := loadVaultLayout(t)
+	storage := map[string][]byte{}
+	set := func(slot *big.Int, hexWord string) {
+		w, err := hex.DecodeString(strings.Repeat("0", 64-len(hexWord)) + hexWord)
+		require.NoError(t, err)
+		storage[contract.SlotHex(slot)] = w
+	}
+	read := func(slot *big.Int) ([]byte, error) {
+		if w, ok := storage[contract.SlotHex(slot)]; ok {
+			return w, nil
+		}
+		return make([]byte, 32), nil
+	}
+	decode := func(path string) string {
+		loc, err := l.Resolve(path)
+		require.NoError(t, err)
+		v, err := l.Decode(loc, read)
+		require.NoError(t, err)
+		return v
+	}
+
+	// slot 0: paused=true packed above owner.
+	set(big.NewInt(0), "01"+strings.TrimPrefix(holder, "0x"))
+	assert.Equal(t, holder, decode("owner"))
+	assert.Equal(t, "true", decode("paused"))
+
+	set(mappingSlot(common.HexToAddress(holder).Bytes(), slotOf(1)), "0de0b6b3a7640000")
+	assert.Equal(t, "1000000000000000000", decode("balances["+holder+"]"))
+	assert.Contains(t, decode("balances"), "index with [key]")
+
+	set(big.NewInt(2), "05")
+	assert.Contains(t, decode("positions"), "length 5")
+	data := new(big.Int).SetBytes(crypto.Keccak256(slotOf(2)))
+	set(data, "0000000000000000000000000000000700000000000000000000000000000009") // debt=7, amount=9
+	assert.Equal(t, "{amount: 9, debt: 7, user: 0x0000000000000000000000000000000000000000}", decode("positions[0]"))
+
+	// Short string: data left-aligned, length*2 in the last byte.
+	set(big.NewInt(3), hex.EncodeToString([]byte("Vault"))+strings.Repeat("0", 64-10-2)+"0a")
+	assert.Equal(t, `"Vault"`, decode("name"))
+
+	set(big.NewInt(4), "04030201")
+	assert.Equal(t, "[1, 2, 3, 4]", decode("small"))
+
+	set(big.NewInt(6), "fffffffffffffffe")
+	assert.Equal(t, "-2", decode("delta"))
+}
+
+func TestStorageLayoutDecodeLongString(t *testing.T) {
+	l := loadVaultLayout(t)
+	long := strings.Repeat("abcdefghij", 5) // 50 bytes → two data slots
+	start := new(big.Int).SetBytes(crypto.Keccak256(slotOf(3)))
+	storage := map[string][]byte{
+		contract.SlotHex(big.NewInt(3)):                          common.BigToHash(big.NewInt(int64(len(long)*2 + 1))).Bytes(),
+		contract.SlotHex(start):                                  []byte(long[:32]),
+		contract.SlotHex(new(big.Int).Add(start, big.NewInt(1))): common.RightPadBytes([]byte(long[32:]), 32),
+	}
+	read := func(slot *big.Int) ([]byte, error) { return storage[contract.SlotHex(slot)], nil }
+
+	loc, err := l.Resolve("name")
+	require.NoError(t, err)
+	v, err := l.Decode(loc, read)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+long+`"`, v)
+}
+
+func TestLoadStorageLayoutHardhatBuildInfo(t *testing.T) {
+	root := t.TempDir()
+	art := filepath.Join(root, "artifacts", "contracts", "Vault.sol", "Vault.json")
+	writeFile(t, art, `{"contractName":"Vault","sourceName":"contracts/Vault.sol","abi":[]}`)
+	writeFile(t, filepath.Join(root, "artifacts", "build-info", "x.json"),
+		`{"output":{"contracts":{"contracts/Vault.sol":{"Vault":{"storageLayout":`+vaultLayout+`}}}}}`)
+
+	l, err := contract.LoadStorageLayout(art)
+	require.NoError(t, err)
+	assert.Len(t, l.Storage, 8)
+}
+
+func TestLoadStorageLayoutMissing(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "abi.json")
+	writeFile(t, p, `{"abi": []}`)
+	_, err := contract.LoadStorageLayout(p)
+	assert.Error(t, err)
+}
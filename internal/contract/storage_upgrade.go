@@ -0,0 +1,208 @@
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LayoutIssue is a storage incompatibility between two implementations.
+type LayoutIssue struct {
+	Label   string // variable in the old layout ("" for layout-wide notes)
+	Message string
+	Unsafe  bool // the upgrade would corrupt storage; otherwise a warning
+}
+
+func (i LayoutIssue) String() string {
+	if i.Label == "" {
+		return i.Message
+	}
+	return i.Label + ": " + i.Message
+}
+
+// CheckStorageUpgrade compares the storage layout of the current
+// implementation with the one that replaces it. Every existing variable
+// must keep its slot, offset and type; new variables may only be appended
+// or take space from a trailing "__gap" array that keeps its end slot.
+func CheckStorageUpgrade(old, next *StorageLayout) []LayoutIssue {
+	var issues []LayoutIssue
+	nextVars := sortedVars(next.Storage)
+	at := map[string]StorageVar{}
+	for _, v := range nextVars {
+		at[v.Slot+":"+fmt.Sprint(v.Offset)] = v
+	}
+
+	for _, o := range sortedVars(old.Storage) {
+		if isGap(o.Label) {
+			issues = append(issues, checkGap(old, next, o, nextVars)...)
+			continue
+		}
+		n, ok := at[o.Slot+":"+fmt.Sprint(o.Offset)]
+		// A variable inserted before o shows up as o "renamed" when the
+		// types happen to match; o itself is then found further down.
+		if m := findVar(nextVars, o.Label); m != nil && (!ok || n.Label != o.Label) {
+			issues = append(issues, LayoutIssue{
+				Label:   o.Label,
+				Message: fmt.Sprintf("moved from slot %s offset %d to slot %s offset %d", o.Slot, o.Offset, m.Slot, m.Offset),
+				Unsafe:  true,
+			})
+			continue
+		}
+		if !ok {
+			issues = append(issues, LayoutIssue{
+				Label:   o.Label,
+				Message: fmt.Sprintf("removed or moved (was slot %s, offset %d)", o.Slot, o.Offset),
+				Unsafe:  true,
+			})
+			continue
+		}
+		if !typesCompatible(old, next, o.Type, n.Type, false) {
+			issues = append(issues, LayoutIssue{
+				Label:   o.Label,
+				Message: fmt.Sprintf("type changed from %s to %s", typeLabel(old, o.Type), typeLabel(next, n.Type)),
+				Unsafe:  true,
+			})
+		}
+		if n.Label != o.Label {
+			issues = append(issues, LayoutIssue{Label: o.Label, Message: fmt.Sprintf("renamed to %s", n.Label)})
+		}
+	}
+	return issues
+}
+
+// checkGap checks the variables of next that fall inside the old gap g.
+func checkGap(old, next *StorageLayout, g StorageVar, nextVars []StorageVar) []LayoutIssue {
+	start := slotInt(g.Slot)
+	end := new(big.Int).Add(start, big.NewInt(int64(slotsOf(old, g.Type))))
+	var issues []LayoutIssue
+	var newGap *StorageVar
+	for i, v := range nextVars {
+		s := slotInt(v.Slot)
+		if s.Cmp(start) < 0 || s.Cmp(end) >= 0 {
+			continue
+		}
+		vEnd := new(big.Int).Add(s, big.NewInt(int64(slotsOf(next, v.Type))))
+		if vEnd.Cmp(end) > 0 {
+			issues = append(issues, LayoutIssue{
+				Label:   v.Label,
+				Message: fmt.Sprintf("overruns %s: ends at slot %s, the gap ended at %s — shrink the gap", g.Label, vEnd, end),
+				Unsafe:  true,
+			})
+		}
+		if isGap(v.Label) {
+			newGap = &nextVars[i]
+		}
+	}
+	if newGap != nil {
+		gEnd := new(big.Int).Add(slotInt(newGap.Slot), big.NewInt(int64(slotsOf(next, newGap.Type))))
+		if gEnd.Cmp(end) < 0 {
+			issues = append(issues, LayoutIssue{
+				Label:   g.Label,
+				Message: fmt.Sprintf("shrunk too far: now ends at slot %s instead of %s, moving every later variable", gEnd, end),
+				Unsafe:  true,
+			})
+		}
+	}
+	return issues
+}
+
+// typesCompatible reports whether a value stored as type o (in old) reads
+// back the same as type n (in next). Structs may gain trailing members only
+// where they are not stored in place (mapping values, dynamic arrays).
+func typesCompatible(old, next *StorageLayout, o, n string, appendable bool) bool {
+	ot, nt := old.Types[o], next.Types[n]
+	if ot == nil || nt == nil {
+		return o == n
+	}
+	if ot.Encoding != nt.Encoding {
+		return false
+	}
+	switch ot.Encoding {
+	case "mapping":
+		return normalizeTypeLabel(typeLabel(old, ot.Key)) == normalizeTypeLabel(typeLabel(next, nt.Key)) &&
+			typesCompatible(old, next, ot.Value, nt.Value, true)
+	case "dynamic_array":
+		return typesCompatible(old, next, ot.Base, nt.Base, true)
+	case "bytes":
+		return true
+	}
+
+	if len(ot.Members) > 0 || len(nt.Members) > 0 {
+		if len(nt.Members) < len(ot.Members) {
+			return false
+		}
+		for i, om := range ot.Members {
+			nm := nt.Members[i]
+			if om.Slot != nm.Slot || om.Offset != nm.Offset || !typesCompatible(old, next, om.Type, nm.Type, false) {
+				return false
+			}
+		}
+		return ot.NumberOfBytes == nt.NumberOfBytes || (appendable && len(nt.Members) > len(ot.Members))
+	}
+	if ot.NumberOfBytes != nt.NumberOfBytes {
+		return false
+	}
+	if ot.Base != "" {
+		return typesCompatible(old, next, ot.Base, nt.Base, false)
+	}
+	// Enums keep their size as they grow; contracts are stored as addresses.
+	if strings.HasPrefix(ot.Label, "enum ") && strings.HasPrefix(nt.Label, "enum ") {
+		return true
+	}
+	return normalizeTypeLabel(ot.Label) == normalizeTypeLabel(nt.Label)
+}
+
+var contractTypeRE = regexp.MustCompile(`\b(contract|interface) [A-Za-z0-9_$.]+`)
+
+// normalizeTypeLabel makes contract and interface types plain addresses.
+func normalizeTypeLabel(label string) string {
+	return contractTypeRE.ReplaceAllString(label, "address")
+}
+
+func typeLabel(l *StorageLayout, id string) string {
+	if t := l.Types[id]; t != nil && t.Label != "" {
+		return t.Label
+	}
+	return id
+}
+
+// slotsOf returns how many slots a type occupies.
+func slotsOf(l *StorageLayout, id string) int {
+	t := l.Types[id]
+	if t == nil {
+		return 1
+	}
+	return max(1, (t.size()+31)/32)
+}
+
+func isGap(label string) bool { return strings.HasPrefix(label, "__gap") }
+
+func slotInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
+
+func sortedVars(vars []StorageVar) []StorageVar {
+	out := append([]StorageVar(nil), vars...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if c := slotInt(out[i].Slot).Cmp(slotInt(out[j].Slot)); c != 0 {
+			return c < 0
+		}
+		return out[i].Offset < out[j].Offset
+	})
+	return out
+}
+
+func findVar(vars []StorageVar, label string) *StorageVar {
+	for i := range vars {
+		if vars[i].Label == label {
+			return &vars[i]
+		}
+	}
+	return nil
+}
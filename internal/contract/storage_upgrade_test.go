@@ -0,0 +1,156 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upgradeTypes = `
+	"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+	"t_bool":    {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+	"t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+	"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+	"t_contract(IERC20)": {"encoding": "inplace", "label": "contract IERC20", "numberOfBytes": "20"},
+	"t_array(t_uint256)50_storage": {"encoding": "inplace", "label": "uint256[50]", "numberOfBytes": "1600", "base": "t_uint256"},
+	"t_array(t_uint256)48_storage": {"encoding": "inplace", "label": "uint256[48]", "numberOfBytes": "1536", "base": "t_uint256"},
+	"t_array(t_uint256)47_storage": {"encoding": "inplace", "label": "uint256[47]", "numberOfBytes": "1504", "base": "t_uint256"},
+	"t_struct(Pos)": {"encoding": "inplace", "label": "struct Vault.Pos", "numberOfBytes": "32", "members": [
+		{"label": "amount", "offset": 0, "slot": "0", "type": "t_uint128"}
+	]},
+	"t_struct(Pos)v2": {"encoding": "inplace", "label": "struct Vault.Pos", "numberOfBytes": "64", "members": [
+		{"label": "amount", "offset": 0, "slot": "0", "type": "t_uint128"},
+		{"label": "opened", "offset": 0, "slot": "1", "type": "t_uint256"}
+	]},
+	"t_mapping(t_address,t_struct(Pos))": {"encoding": "mapping", "label": "mapping(address => struct Vault.Pos)", "numberOfBytes": "32", "key": "t_address", "value": "t_struct(Pos)"},
+	"t_mapping(t_address,t_struct(Pos)v2)": {"encoding": "mapping", "label": "mapping(address => struct Vault.Pos)", "numberOfBytes": "32", "key": "t_address", "value": "t_struct(Pos)v2"}
+`
+
+func layout(t *testing.T, storage string) *contract.StorageLayout {
+	t.Helper()
+	var l contract.StorageLayout
+	require.NoError(t, json.Unmarshal([]byte(`{"storage": [`+storage+`], "types": {`+upgradeTypes+`}}`), &l))
+	return &l
+}
+
+const baseVars = `
+	{"label": "owner",  "offset": 0,  "slot": "0", "type": "t_address"},
+	{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+	{"label": "total",  "offset": 0,  "slot": "1", "type": "t_uint256"}`
+
+func unsafeIssues(issues []contract.LayoutIssue) []string {
+	var out []string
+	for _, i := range issues {
+		if i.Unsafe {
+			out = append(out, i.String())
+		}
+	}
+	return out
+}
+
+func TestCheckStorageUpgradeAppend(t *testing.T) {
+	old := layout(t, baseVars)
+	next := layout(t, baseVars+`,
+		{"label": "fee", "offset": 0, "slot": "2", "type": "t_uint256"}`)
+	assert.Empty(t, contract.CheckStorageUpgrade(old, next))
+}
+
+func TestCheckStorageUpgradeInsertIsUnsafe(t *testing.T) {
+	old := layout(t, baseVars)
+	next := layout(t, `
+		{"label": "owner",  "offset": 0,  "slot": "0", "type": "t_address"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "fee",    "offset": 0,  "slot": "1", "type": "t_uint256"},
+		{"label": "total",  "offset": 0,  "slot": "2", "type": "t_uint256"}`)
+	issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0], "total: moved from slot 1")
+}
+
+func TestCheckStorageUpgradeRemoved(t *testing.T) {
+	old := layout(t, baseVars)
+	next := layout(t, `{"label": "owner", "offset": 0, "slot": "0", "type": "t_address"}`)
+	issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+	require.Len(t, issues, 2)
+	assert.Contains(t, issues[0], "paused: removed or moved")
+}
+
+func TestCheckStorageUpgradeTypeChange(t *testing.T) {
+	old := layout(t, baseVars)
+	next := layout(t, `
+		{"label": "owner",  "offset": 0,  "slot": "0", "type": "t_address"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "total",  "offset": 0,  "slot": "1", "type": "t_uint128"}`)
+	issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "total: type changed from uint256 to uint128", issues[0])
+}
+
+func TestCheckStorageUpgradeRenameAndContractType(t *testing.T) {
+	old := layout(t, baseVars)
+	next := layout(t, `
+		{"label": "token",  "offset": 0,  "slot": "0", "type": "t_contract(IERC20)"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "total",  "offset": 0,  "slot": "1", "type": "t_uint256"}`)
+	issues := contract.CheckStorageUpgrade(old, next)
+	require.Len(t, issues, 1)
+	assert.False(t, issues[0].Unsafe)
+	assert.Equal(t, "owner: renamed to token", issues[0].String())
+}
+
+func TestCheckStorageUpgradeGap(t *testing.T) {
+	old := layout(t, baseVars+`,
+		{"label": "__gap", "offset": 0, "slot": "2", "type": "t_array(t_uint256)50_storage"}`)
+
+	t.Run("consumed", func(t *testing.T) {
+		next := layout(t, baseVars+`,
+			{"label": "fee",   "offset": 0, "slot": "2", "type": "t_uint256"},
+			{"label": "cap",   "offset": 0, "slot": "3", "type": "t_uint256"},
+			{"label": "__gap", "offset": 0, "slot": "4", "type": "t_array(t_uint256)48_storage"}`)
+		assert.Empty(t, contract.CheckStorageUpgrade(old, next))
+	})
+
+	t.Run("shrunk too far", func(t *testing.T) {
+		next := layout(t, baseVars+`,
+			{"label": "fee",   "offset": 0, "slot": "2", "type": "t_uint256"},
+			{"label": "cap",   "offset": 0, "slot": "3", "type": "t_uint256"},
+			{"label": "__gap", "offset": 0, "slot": "4", "type": "t_array(t_uint256)47_storage"}`)
+		issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0], "__gap: shrunk too far")
+	})
+
+	t.Run("overrun", func(t *testing.T) {
+		next := layout(t, baseVars+`,
+			{"label": "fee",   "offset": 0, "slot": "2", "type": "t_uint256"},
+			{"label": "__gap", "offset": 0, "slot": "3", "type": "t_array(t_uint256)50_storage"}`)
+		issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+		require.Len(t, issues, 1)
+		assert.True(t, strings.HasPrefix(issues[0], "__gap: overruns __gap"))
+	})
+}
+
+func TestCheckStorageUpgradeStructs(t *testing.T) {
+	t.Run("appended member in mapping value", func(t *testing.T) {
+		old := layout(t, `{"label": "pos", "offset": 0, "slot": "0", "type": "t_mapping(t_address,t_struct(Pos))"}`)
+		next := layout(t, `{"label": "pos", "offset": 0, "slot": "0", "type": "t_mapping(t_address,t_struct(Pos)v2)"}`)
+		assert.Empty(t, contract.CheckStorageUpgrade(old, next))
+	})
+
+	t.Run("grown struct in place", func(t *testing.T) {
+		old := layout(t, `
+			{"label": "pos",   "offset": 0, "slot": "0", "type": "t_struct(Pos)"},
+			{"label": "total", "offset": 0, "slot": "1", "type": "t_uint256"}`)
+		next := layout(t, `
+			{"label": "pos",   "offset": 0, "slot": "0", "type": "t_struct(Pos)v2"},
+			{"label": "total", "offset": 0, "slot": "2", "type": "t_uint256"}`)
+		issues := unsafeIssues(contract.CheckStorageUpgrade(old, next))
+		require.Len(t, issues, 2)
+		assert.Contains(t, issues[0], "pos: type changed")
+		assert.Contains(t, issues[1], "total: moved")
+	})
+}
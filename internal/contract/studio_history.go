@@ -0,0 +1,117 @@
+package contract
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+)
+
+// maxStudioHistory is the number of calls kept per contract.
+const maxStudioHistory = 50
+
+// StudioCall is one call made from the contract studio.
+type StudioCall struct {
+	Selector string   `json:"selector"`
+	Sig      string   `json:"sig"`
+	Args     []string `json:"args,omitempty"`
+	Value    string   `json:"value,omitempty"`   // ETH sent with a payable call
+	Results  []string `json:"results,omitempty"` // read calls
+	TxHash   string   `json:"tx_hash,omitempty"` // write calls
+	Error    string   `json:"error,omitempty"`
+	At       string   `json:"at"` // RFC 3339
+}
+
+// StudioState is the studio's saved state for one contract.
+type StudioState struct {
+	Name       string       `json:"name"`
+	Network    string       `json:"network"`
+	Favourites []string     `json:"favourites,omitempty"` // function selectors
+	History    []StudioCall `json:"history,omitempty"`    // newest first
+}
+
+// Record adds a call to the front of the history, dropping the oldest calls
+// beyond the limit.
+func (s *StudioState) Record(c StudioCall) {
+	s.History = append([]StudioCall{c}, s.History...)
+	if len(s.History) > maxStudioHistory {
+		s.History = s.History[:maxStudioHistory]
+	}
+}
+
+// IsFavourite reports whether the function with this selector is pinned.
+func (s *StudioState) IsFavourite(selector string) bool {
+	for _, f := range s.Favourites {
+		if f == selector {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFavourites replaces the pinned functions.
+func (s *StudioState) SetFavourites(selectors []string) {
+	s.Favourites = append([]string(nil), selectors...)
+	sort.Strings(s.Favourites)
+}
+
+// StudioStore persists studio state per contract.
+type StudioStore struct {
+	path      string
+	contracts map[string]*StudioState // key: "name@network"
+}
+
+// NewStudioStore creates a store backed by the file at path.
+func NewStudioStore(path string) *StudioStore {
+	return &StudioStore{
+		path:      path,
+		contracts: make(map[string]*StudioState),
+	}
+}
+
+// Load reads the saved state from disk.
+func (s *StudioStore) Load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var states []StudioState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return err
+	}
+	for i := range states {
+		st := &states[i]
+		s.contracts[key(st.Name, st.Network)] = st
+	}
+	return nil
+}
+
+// Save writes the state of all contracts to disk.
+func (s *StudioStore) Save() error {
+	states := make([]StudioState, 0, len(s.contracts))
+	for _, st := range s.contracts {
+		states = append(states, *st)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return key(states[i].Name, states[i].Network) < key(states[j].Name, states[j].Network)
+	})
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// For returns the state of a contract, creating it on first use.
+func (s *StudioStore) For(name, network string) *StudioState {
+	k := key(name, network)
+	st, ok := s.contracts[k]
+	if !ok {
+		st = &StudioState{Name: name, Network: network}
+		s.contracts[k] = st
+	}
+	return st
+}
@@ -0,0 +1,45 @@
+package contract_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudioStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "studio.json")
+	store := contract.NewStudioStore(path)
+	require.NoError(t, store.Load()) // missing file is fine
+
+	st := store.For("usdc", "base")
+	st.SetFavourites([]string{"0x70a08231", "0x18160ddd"})
+	st.Record(contract.StudioCall{Selector: "0x18160ddd", Sig: "totalSupply()", Results: []string{"100"}, At: "2026-01-01T00:00:00Z"})
+	st.Record(contract.StudioCall{Selector: "0xa9059cbb", Sig: "transfer(address,uint256)", Args: []string{"0xabc", "1"}, TxHash: "0xhash", At: "2026-01-02T00:00:00Z"})
+	store.For("usdc", "ethereum")
+	require.NoError(t, store.Save())
+
+	loaded := contract.NewStudioStore(path)
+	require.NoError(t, loaded.Load())
+	got := loaded.For("usdc", "base")
+	assert.Equal(t, []string{"0x18160ddd", "0x70a08231"}, got.Favourites)
+	assert.True(t, got.IsFavourite("0x70a08231"))
+	assert.False(t, got.IsFavourite("0xa9059cbb"))
+	require.Len(t, got.History, 2)
+	assert.Equal(t, "0xhash", got.History[0].TxHash, "newest first")
+	assert.Equal(t, []string{"100"}, got.History[1].Results)
+	assert.Empty(t, loaded.For("usdc", "ethereum").History)
+}
+
+func TestStudioStateHistoryLimit(t *testing.T) {
+	st := &contract.StudioState{}
+	for i := 0; i < 60; i++ {
+		st.Record(contract.StudioCall{Sig: fmt.Sprintf("f%d()", i)})
+	}
+	assert.Len(t, st.History, 50)
+	assert.Equal(t, "f59()", st.History[0].Sig)
+	assert.Equal(t, "f10()", st.History[49].Sig)
+}
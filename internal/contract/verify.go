@@ -0,0 +1,155 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrAlreadyVerified is returned when the explorer already has the source.
+var ErrAlreadyVerified = errors.New("contract is already verified")
+
+// EtherscanV2API is the single Etherscan V2 endpoint for every chain; the
+// chain is picked with ?chainid=.
+const EtherscanV2API = "https://api.etherscan.io/v2/api"
+
+// Verifier submits contract sources to an Etherscan-compatible
+// verifysourcecode API (Etherscan V2, BlockScout).
+type Verifier struct {
+	client       *http.Client
+	apiURL       string
+	apiKey       string
+	pollInterval time.Duration
+}
+
+// NewVerifier creates a Verifier. apiURL is the explorer API endpoint, e.g.
+// "https://base.blockscout.com/api" or EtherscanV2API + "?chainid=8453".
+func NewVerifier(apiURL, apiKey string) *Verifier {
+	return &Verifier{
+		client:       &http.Client{Timeout: 30 * time.Second},
+		apiURL:       apiURL,
+		apiKey:       apiKey,
+		pollInterval: 5 * time.Second,
+	}
+}
+
+// SetPollInterval changes how often Wait checks the verification status.
+func (v *Verifier) SetPollInterval(d time.Duration) {
+	v.pollInterval = d
+}
+
+// Submit sends src for the contract at address. constructorArgs is the
+// ABI-encoded hex without 0x (empty when there are none). It returns the
+// GUID to poll with Wait, or ErrAlreadyVerified.
+func (v *Verifier) Submit(address string, src *VerifySource, constructorArgs string) (string, error) {
+	form := url.Values{
+		"module":                {"contract"},
+		"action":                {"verifysourcecode"},
+		"contractaddress":       {address},
+		"sourceCode":            {string(src.Input)},
+		"codeformat":            {"solidity-standard-json-input"},
+		"contractname":          {src.ContractName},
+		"compilerversion":       {src.CompilerVersion},
+		"constructorArguements": {strings.TrimPrefix(constructorArgs, "0x")}, // sic — the API's spelling
+	}
+	if v.apiKey != "" {
+		form.Set("apikey", v.apiKey)
+	}
+
+	resp, err := v.client.PostForm(v.apiURL, form)
+	if err != nil {
+		return "", fmt.Errorf("submitting verification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result, ok, err := decodeVerifyResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	if isAlreadyVerified(result) {
+		return "", ErrAlreadyVerified
+	}
+	if !ok {
+		return "", fmt.Errorf("explorer rejected verification: %s", result)
+	}
+	return result, nil
+}
+
+// Status checks a submission once. pending is true while the explorer is
+// still compiling; a failed verification is returned as an error.
+func (v *Verifier) Status(guid string) (pending bool, err error) {
+	sep := "?"
+	if strings.Contains(v.apiURL, "?") {
+		sep = "&"
+	}
+	u := fmt.Sprintf("%s%smodule=contract&action=checkverifystatus&guid=%s", v.apiURL, sep, url.QueryEscape(guid))
+	if v.apiKey != "" {
+		u += "&apikey=" + v.apiKey
+	}
+
+	resp, err := v.client.Get(u)
+	if err != nil {
+		return false, fmt.Errorf("checking verification status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result, _, err := decodeVerifyResponse(resp)
+	if err != nil {
+		return false, err
+	}
+	lower := strings.ToLower(result)
+	switch {
+	case strings.Contains(lower, "pending"), strings.Contains(lower, "in progress"), strings.Contains(lower, "queue"):
+		return true, nil
+	case strings.HasPrefix(lower, "pass"), isAlreadyVerified(result):
+		return false, nil
+	default:
+		return false, fmt.Errorf("verification failed: %s", result)
+	}
+}
+
+// Wait polls Status until the verification passes or fails.
+func (v *Verifier) Wait(ctx context.Context, guid string) error {
+	ticker := time.NewTicker(v.pollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := v.Status(guid)
+		if err != nil || !pending {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("verification still pending (guid %s): %w", guid, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// decodeVerifyResponse returns the result string of an explorer response and
+// whether the status was "1".
+func decodeVerifyResponse(resp *http.Response) (string, bool, error) {
+	body, _ := io.ReadAll(resp.Body)
+	var envelope struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", false, fmt.Errorf("parsing explorer response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	var result string
+	if json.Unmarshal(envelope.Result, &result) != nil || result == "" {
+		result = envelope.Message
+	}
+	return result, envelope.Status == "1", nil
+}
+
+func isAlreadyVerified(result string) bool {
+	return strings.Contains(strings.ToLower(result), "already verified")
+}
@@ -0,0 +1,340 @@
+package contract
+
+import (
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+)
+
+// VerifySource is everything a block explorer needs to recompile a contract
+// and compare it with the deployed bytecode.
+type VerifySource struct {
+	ContractName    string          // fully qualified, e.g. "contracts/Token.sol:Token"
+	CompilerVersion string          // e.g. "v0.8.26+commit.8a97fa7a"
+	Input           json.RawMessage // solc standard-JSON input
+	Bytecode        []byte          // creation bytecode, when the artifact has it
+}
+
+// solcMetadata is the subset of the solc metadata JSON needed to rebuild the
+// standard-JSON input.
+type solcMetadata struct {
+	Compiler struct {
+		Version string `json:"version"`
+	} `json:"compiler"`
+	Language string                     `json:"language"`
+	Settings map[string]json.RawMessage `json:"settings"`
+	Sources  map[string]struct {
+		Content string `json:"content"`
+	} `json:"sources"`
+}
+
+// LoadVerifySource reads the compiler input behind an artifact:
+//
+//   - Hardhat artifact:      build-info referenced by <Name>.dbg.json
+//   - hardhat-deploy record: deployments/<network>/solcInputs/<hash>.json
+//   - Foundry artifact:      metadata + sources read from the project root
+func LoadVerifySource(path string) (*VerifySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read artifact file: %w", err)
+	}
+	var art struct {
+		ContractName  string          `json:"contractName"`
+		SourceName    string          `json:"sourceName"`
+		SolcInputHash string          `json:"solcInputHash"`
+		Metadata      json.RawMessage `json:"metadata"`
+		RawMetadata   string          `json:"rawMetadata"`
+		Bytecode      json.RawMessage `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &art); err != nil {
+		return nil, fmt.Errorf("invalid artifact JSON: %w", err)
+	}
+
+	var src *VerifySource
+	switch {
+	case art.SolcInputHash != "" && len(art.Metadata) > 0:
+		src, err = hardhatDeploySource(path, art.SolcInputHash, art.Metadata)
+	case art.SourceName != "" && art.ContractName != "":
+		src, err = hardhatSource(path, art.SourceName, art.ContractName)
+	case len(art.Metadata) > 0 || art.RawMetadata != "":
+		raw := art.Metadata
+		if len(raw) == 0 {
+			raw, _ = json.Marshal(art.RawMetadata)
+		}
+		src, err = foundrySource(path, raw)
+	default:
+		return nil, fmt.Errorf("%s has no compiler metadata — use a Hardhat or Foundry artifact (Foundry: build with extra_output = [\"metadata\"] if missing)", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(art.Bytecode) > 0 {
+		if bc, err := extractBytecodeHex(art.Bytecode); err == nil {
+			src.Bytecode, _ = hex.DecodeString(strings.TrimPrefix(bc, "0x"))
+		}
+	}
+	return src, nil
+}
+
+// ── Hardhat ──────────────────────────────────────────────────────────────────
+
+func hardhatSource(path, sourceName, contractName string) (*VerifySource, error) {
+	buildInfo, err := findHardhatBuildInfo(path, sourceName, contractName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(buildInfo)
+	if err != nil {
+		return nil, err
+	}
+	var bi struct {
+		SolcLongVersion string          `json:"solcLongVersion"`
+		Input           json.RawMessage `json:"input"`
+	}
+	if err := json.Unmarshal(data, &bi); err != nil {
+		return nil, fmt.Errorf("parsing build info %s: %w", buildInfo, err)
+	}
+	if len(bi.Input) == 0 || bi.SolcLongVersion == "" {
+		return nil, fmt.Errorf("build info %s has no compiler input", buildInfo)
+	}
+	return &VerifySource{
+		ContractName:    sourceName + ":" + contractName,
+		CompilerVersion: "v" + bi.SolcLongVersion,
+		Input:           bi.Input,
+	}, nil
+}
+
+// findHardhatBuildInfo follows <Name>.dbg.json to its build-info file, or
+// searches artifacts/build-info for one that compiled the contract.
+func findHardhatBuildInfo(path, sourceName, contractName string) (string, error) {
+	dbgPath := strings.TrimSuffix(path, ".json") + ".dbg.json"
+	if data, err := os.ReadFile(dbgPath); err == nil {
+		var dbg struct {
+			BuildInfo string `json:"buildInfo"`
+		}
+		if json.Unmarshal(data, &dbg) == nil && dbg.BuildInfo != "" {
+			bi := filepath.Join(filepath.Dir(dbgPath), filepath.FromSlash(dbg.BuildInfo))
+			if _, err := os.Stat(bi); err == nil {
+				return bi, nil
+			}
+		}
+	}
+
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		matches, _ := filepath.Glob(filepath.Join(dir, "build-info", "*.json"))
+		sort.Strings(matches)
+		for _, m := range matches {
+			data, err := os.ReadFile(m)
+			if err != nil {
+				continue
+			}
+			var bi struct {
+				Output struct {
+					Contracts map[string]map[string]json.RawMessage `json:"contracts"`
+				} `json:"output"`
+			}
+			if json.Unmarshal(data, &bi) == nil && bi.Output.Contracts[sourceName][contractName] != nil {
+				return m, nil
+			}
+		}
+		if filepath.Base(dir) == "artifacts" {
+			break
+		}
+	}
+	return "", fmt.Errorf("no Hardhat build info found for %s:%s — run `npx hardhat compile`", sourceName, contractName)
+}
+
+// ── hardhat-deploy ───────────────────────────────────────────────────────────
+
+func hardhatDeploySource(path, inputHash string, rawMeta json.RawMessage) (*VerifySource, error) {
+	meta, err := parseMetadata(rawMeta)
+	if err != nil {
+		return nil, err
+	}
+	target, err := meta.target()
+	if err != nil {
+		return nil, err
+	}
+	inputPath := filepath.Join(filepath.Dir(path), "solcInputs", inputHash+".json")
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading compiler input: %w", err)
+	}
+	return &VerifySource{
+		ContractName:    target,
+		CompilerVersion: "v" + meta.Compiler.Version,
+		Input:           input,
+	}, nil
+}
+
+// ── Foundry ──────────────────────────────────────────────────────────────────
+
+// foundrySource rebuilds the standard-JSON input from the artifact metadata.
+// Source paths in the metadata are relative to the project root, the parent
+// of out/.
+func foundrySource(path string, rawMeta json.RawMessage) (*VerifySource, error) {
+	meta, err := parseMetadata(rawMeta)
+	if err != nil {
+		return nil, err
+	}
+	target, err := meta.target()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Dir(filepath.Dir(filepath.Dir(path)))
+
+	sources := map[string]map[string]string{}
+	for name, s := range meta.Sources {
+		content := s.Content
+		if content == "" {
+			data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, fmt.Errorf("reading source %s: %w", name, err)
+			}
+			content = string(data)
+		}
+		sources[name] = map[string]string{"content": content}
+	}
+
+	language := meta.Language
+	if language == "" {
+		language = "Solidity"
+	}
+	input, err := json.Marshal(map[string]interface{}{
+		"language": language,
+		"sources":  sources,
+		"settings": meta.standardSettings(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &VerifySource{
+		ContractName:    target,
+		CompilerVersion: "v" + meta.Compiler.Version,
+		Input:           input,
+	}, nil
+}
+
+// parseMetadata accepts the metadata as a JSON object or as a JSON string
+// holding one (hardhat-deploy and rawMetadata).
+func parseMetadata(raw json.RawMessage) (*solcMetadata, error) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		raw = json.RawMessage(s)
+	}
+	var meta solcMetadata
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, fmt.Errorf("parsing compiler metadata: %w", err)
+	}
+	if meta.Compiler.Version == "" {
+		return nil, fmt.Errorf("compiler metadata has no compiler version")
+	}
+	return &meta, nil
+}
+
+// target returns the compilation target as "path:Name".
+func (m *solcMetadata) target() (string, error) {
+	var targets map[string]string
+	if err := json.Unmarshal(m.Settings["compilationTarget"], &targets); err != nil || len(targets) != 1 {
+		return "", fmt.Errorf("compiler metadata has no single compilation target")
+	}
+	for path, name := range targets {
+		return path + ":" + name, nil
+	}
+	return "", nil
+}
+
+// standardSettings converts metadata settings to standard-JSON settings:
+// compilationTarget goes, libraries are regrouped by file and an output
+// selection is added.
+func (m *solcMetadata) standardSettings() map[string]json.RawMessage {
+	settings := map[string]json.RawMessage{}
+	for k, v := range m.Settings {
+		if k != "compilationTarget" {
+			settings[k] = v
+		}
+	}
+	var flat map[string]string // "path:Name" → address
+	if json.Unmarshal(m.Settings["libraries"], &flat) == nil && len(flat) > 0 {
+		grouped := map[string]map[string]string{}
+		for fq, addr := range flat {
+			file, name, ok := strings.Cut(fq, ":")
+			if !ok {
+				file, name = "", fq
+			}
+			if grouped[file] == nil {
+				grouped[file] = map[string]string{}
+			}
+			grouped[file][name] = addr
+		}
+		settings["libraries"], _ = json.Marshal(grouped)
+	}
+	settings["outputSelection"] = json.RawMessage(`{"*":{"*":["abi","evm.bytecode.object","evm.deployedBytecode.object","metadata"]}}`)
+	return settings
+}
+
+// ── W3Token ──────────────────────────────────────────────────────────────────
+
+//go:embed all:w3token
+var w3TokenSources embed.FS
+
+// W3TokenVerifySource returns the bundled source of the token deployed by
+// `token create`, with the settings it was compiled with.
+func W3TokenVerifySource() (*VerifySource, error) {
+	sources := map[string]map[string]string{}
+	err := fs.WalkDir(w3TokenSources, "w3token", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := w3TokenSources.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sources[strings.TrimPrefix(path, "w3token/")] = map[string]string{"content": string(data)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	input, err := json.Marshal(map[string]interface{}{
+		"language": "Solidity",
+		"sources":  sources,
+		"settings": map[string]interface{}{
+			"optimizer":       map[string]interface{}{"enabled": true, "runs": 200},
+			"evmVersion":      "cancun",
+			"outputSelection": json.RawMessage(`{"*":{"*":["abi","evm.bytecode.object","evm.deployedBytecode.object","metadata"]}}`),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &VerifySource{
+		ContractName:    "contracts/W3Token.sol:W3Token",
+		CompilerVersion: "v0.8.26+commit.8a97fa7a",
+		Input:           input,
+		Bytecode:        chain.W3TokenInitCode(),
+	}, nil
+}
+
+// ConstructorArgsFromInput splits the ABI-encoded constructor args (hex, no
+// 0x) off a deploy transaction's input, which is bytecode followed by args.
+func ConstructorArgsFromInput(input string, bytecode []byte) (string, error) {
+	in := strings.ToLower(strings.TrimPrefix(input, "0x"))
+	code := hex.EncodeToString(bytecode)
+	if len(code) == 0 {
+		return "", fmt.Errorf("artifact has no bytecode to compare against")
+	}
+	if !strings.HasPrefix(in, code) {
+		return "", fmt.Errorf("deploy transaction input does not start with the artifact bytecode — was the contract recompiled since? Pass --args")
+	}
+	return in[len(code):], nil
+}
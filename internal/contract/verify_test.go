@@ -0,0 +1,208 @@
+package contract_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// LoadVerifySource
+// ---------------------------------------------------------------------------
+
+func TestLoadVerifySourceHardhat(t *testing.T) {
+	root := t.TempDir()
+	art := filepath.Join(root, "artifacts", "contracts", "Token.sol", "Token.json")
+	writeFile(t, art, `{"contractName":"Token","sourceName":"contracts/Token.sol","abi":[],"bytecode":"0x6080"}`)
+	writeFile(t, filepath.Join(root, "artifacts", "contracts", "Token.sol", "Token.dbg.json"),
+		`{"_format":"hh-sol-dbg-1","buildInfo":"../../build-info/abc.json"}`)
+	writeFile(t, filepath.Join(root, "artifacts", "build-info", "abc.json"),
+		`{"solcLongVersion":"0.8.24+commit.e11b9ed9","input":{"language":"Solidity","sources":{"contracts/Token.sol":{"content":"contract Token {}"}}}}`)
+
+	src, err := contract.LoadVerifySource(art)
+	require.NoError(t, err)
+	assert.Equal(t, "contracts/Token.sol:Token", src.ContractName)
+	assert.Equal(t, "v0.8.24+commit.e11b9ed9", src.CompilerVersion)
+	assert.Contains(t, string(src.Input), "contract Token {}")
+	assert.Equal(t, []byte{0x60, 0x80}, src.Bytecode)
+}
+
+func TestLoadVerifySourceHardhatScansBuildInfo(t *testing.T) {
+	root := t.TempDir()
+	art := filepath.Join(root, "artifacts", "contracts", "Token.sol", "Token.json")
+	writeFile(t, art, `{"contractName":"Token","sourceName":"contracts/Token.sol","abi":[]}`)
+	writeFile(t, filepath.Join(root, "artifacts", "build-info", "a.json"),
+		`{"solcLongVersion":"0.8.20+commit.a1b79de6","input":{},"output":{"contracts":{"contracts/Other.sol":{"Other":{}}}}}`)
+	writeFile(t, filepath.Join(root, "artifacts", "build-info", "b.json"),
+		`{"solcLongVersion":"0.8.24+commit.e11b9ed9","input":{"language":"Solidity"},"output":{"contracts":{"contracts/Token.sol":{"Token":{}}}}}`)
+
+	src, err := contract.LoadVerifySource(art)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.8.24+commit.e11b9ed9", src.CompilerVersion)
+}
+
+func TestLoadVerifySourceHardhatDeploy(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "deployments", "base")
+	meta, _ := json.Marshal(`{"compiler":{"version":"0.8.26+commit.8a97fa7a"},"language":"Solidity","settings":{"compilationTarget":{"contracts/Vault.sol":"Vault"}},"sources":{}}`)
+	writeFile(t, filepath.Join(dir, "Vault.json"),
+		`{"address":"0x1","abi":[],"solcInputHash":"feed","metadata":`+string(meta)+`}`)
+	writeFile(t, filepath.Join(dir, "solcInputs", "feed.json"), `{"language":"Solidity","sources":{}}`)
+
+	src, err := contract.LoadVerifySource(filepath.Join(dir, "Vault.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "contracts/Vault.sol:Vault", src.ContractName)
+	assert.Equal(t, "v0.8.26+commit.8a97fa7a", src.CompilerVersion)
+	assert.JSONEq(t, `{"language":"Solidity","sources":{}}`, string(src.Input))
+}
+
+func TestLoadVerifySourceFoundry(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "src", "Counter.sol"), "contract Counter {}")
+	writeFile(t, filepath.Join(root, "src", "Lib.sol"), "library Lib {}")
+	art := filepath.Join(root, "out", "Counter.sol", "Counter.json")
+	writeFile(t, art, `{
+		"abi": [],
+		"bytecode": {"object": "0x6001"},
+		"metadata": {
+			"compiler": {"version": "0.8.26+commit.8a97fa7a"},
+			"language": "Solidity",
+			"settings": {
+				"compilationTarget": {"src/Counter.sol": "Counter"},
+				"optimizer": {"enabled": true, "runs": 200},
+				"evmVersion": "cancun",
+				"libraries": {"src/Lib.sol:Lib": "0x00000000000000000000000000000000000000aa"}
+			},
+			"sources": {"src/Counter.sol": {"keccak256": "0x"}, "src/Lib.sol": {"keccak256": "0x"}}
+		}
+	}`)
+
+	src, err := contract.LoadVerifySource(art)
+	require.NoError(t, err)
+	assert.Equal(t, "src/Counter.sol:Counter", src.ContractName)
+	assert.Equal(t, "v0.8.26+commit.8a97fa7a", src.CompilerVersion)
+	assert.Equal(t, []byte{0x60, 0x01}, src.Bytecode)
+
+	var input struct {
+		Sources  map[string]struct{ Content string } `json:"sources"`
+		Settings map[string]json.RawMessage          `json:"settings"`
+	}
+	require.NoError(t, json.Unmarshal(src.Input, &input))
+	assert.Equal(t, "contract Counter {}", input.Sources["src/Counter.sol"].Content)
+	assert.Equal(t, "library Lib {}", input.Sources["src/Lib.sol"].Content)
+	assert.NotContains(t, input.Settings, "compilationTarget")
+	assert.Contains(t, input.Settings, "outputSelection")
+	assert.JSONEq(t, `{"src/Lib.sol":{"Lib":"0x00000000000000000000000000000000000000aa"}}`, string(input.Settings["libraries"]))
+}
+
+func TestLoadVerifySourceNoMetadata(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "abi.json")
+	writeFile(t, p, `{"abi": []}`)
+	_, err := contract.LoadVerifySource(p)
+	assert.Error(t, err)
+}
+
+func TestW3TokenVerifySourceImportsResolve(t *testing.T) {
+	src, err := contract.W3TokenVerifySource()
+	require.NoError(t, err)
+	assert.Equal(t, "contracts/W3Token.sol:W3Token", src.ContractName)
+	assert.NotEmpty(t, src.Bytecode)
+
+	var input struct {
+		Sources map[string]struct{ Content string } `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal(src.Input, &input))
+	require.Contains(t, input.Sources, "contracts/W3Token.sol")
+
+	importRe := regexp.MustCompile(`import\s+\{[^}]*\}\s+from\s+"([^"]+)"`)
+	for name, s := range input.Sources {
+		for _, m := range importRe.FindAllStringSubmatch(s.Content, -1) {
+			target := m[1]
+			if target[0] == '.' {
+				target = path.Join(path.Dir(name), target)
+			}
+			assert.Contains(t, input.Sources, target, "%s imports %s", name, m[1])
+		}
+	}
+}
+
+func TestConstructorArgsFromInput(t *testing.T) {
+	args, err := contract.ConstructorArgsFromInput("0x6080ABCD0000000000000000000000000000000000000000000000000000000000000001", []byte{0x60, 0x80, 0xab, 0xcd})
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001", args)
+
+	_, err = contract.ConstructorArgsFromInput("0x6000", []byte{0x60, 0x80})
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// Verifier
+// ---------------------------------------------------------------------------
+
+func TestVerifierSubmitAndWait(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.Form.Get("action") {
+		case "verifysourcecode":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "8453", r.URL.Query().Get("chainid"))
+			assert.Equal(t, "0xabc", r.Form.Get("contractaddress"))
+			assert.Equal(t, "solidity-standard-json-input", r.Form.Get("codeformat"))
+			assert.Equal(t, "src/A.sol:A", r.Form.Get("contractname"))
+			assert.Equal(t, "v0.8.26+commit.8a97fa7a", r.Form.Get("compilerversion"))
+			assert.Equal(t, "00ff", r.Form.Get("constructorArguements"))
+			assert.Equal(t, "key", r.Form.Get("apikey"))
+			_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"guid-1"}`))
+		case "checkverifystatus":
+			assert.Equal(t, "guid-1", r.Form.Get("guid"))
+			if atomic.AddInt32(&polls, 1) < 2 {
+				_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Pending in queue"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"Pass - Verified"}`))
+		}
+	}))
+	defer srv.Close()
+
+	v := contract.NewVerifier(srv.URL+"?chainid=8453", "key")
+	v.SetPollInterval(time.Millisecond)
+	src := &contract.VerifySource{ContractName: "src/A.sol:A", CompilerVersion: "v0.8.26+commit.8a97fa7a", Input: json.RawMessage(`{}`)}
+
+	guid, err := v.Submit("0xabc", src, "0x00ff")
+	require.NoError(t, err)
+	assert.Equal(t, "guid-1", guid)
+	require.NoError(t, v.Wait(context.Background(), guid))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&polls))
+}
+
+func TestVerifierAlreadyVerified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Contract source code already verified"}`))
+	}))
+	defer srv.Close()
+
+	_, err := contract.NewVerifier(srv.URL, "").Submit("0xabc", &contract.VerifySource{}, "")
+	assert.ErrorIs(t, err, contract.ErrAlreadyVerified)
+}
+
+func TestVerifierFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Fail - Unable to verify. Compiled contract deployment bytecode does NOT match"}`))
+	}))
+	defer srv.Close()
+
+	err := contract.NewVerifier(srv.URL, "").Wait(context.Background(), "guid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does NOT match")
+}
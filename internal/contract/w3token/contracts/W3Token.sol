@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.22;
+
+import {ERC20}         from "@openzeppelin/contracts/token/ERC20/ERC20.sol";
+import {ERC20Burnable} from "@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol";
+import {Ownable}       from "@openzeppelin/contracts/access/Ownable.sol";
+
+/// @title W3Token — mintable & burnable ERC-20 deployed by w3cli
+contract W3Token is ERC20, ERC20Burnable, Ownable {
+    uint8 private _decimals;
+
+    constructor(
+        string memory tokenName,
+        string memory tokenSymbol,
+        uint8  tokenDecimals,
+        uint256 initialSupply
+    ) ERC20(tokenName, tokenSymbol) Ownable(msg.sender) {
+        _decimals = tokenDecimals;
+        _mint(msg.sender, initialSupply);   // mints initialSupply to deployer
+    }
+
+    // Override to expose custom decimals (default OZ ERC20 is 18).
+    function decimals() public view virtual override returns (uint8) {
+        return _decimals;
+    }
+
+    // Owner-only mint — lets the deployer mint additional tokens later.
+    function mint(address to, uint256 amount) external onlyOwner {
+        _mint(to, amount);
+    }
+
+    // burn() and burnFrom() are inherited from ERC20Burnable.
+    // Any holder can burn their own tokens; burnFrom requires approval.
+}
@@ -124,16 +124,16 @@ var w3TokenABI = []ABIEntry{
 	{
 		Name:   "Transfer",
 		Type:   "event",
-		Inputs: []ABIParam{{Name: "from", Type: "address"}, {Name: "to", Type: "address"}, {Name: "value", Type: "uint256"}},
+		Inputs: []ABIParam{{Name: "from", Type: "address", Indexed: true}, {Name: "to", Type: "address", Indexed: true}, {Name: "value", Type: "uint256"}},
 	},
 	{
 		Name:   "Approval",
 		Type:   "event",
-		Inputs: []ABIParam{{Name: "owner", Type: "address"}, {Name: "spender", Type: "address"}, {Name: "value", Type: "uint256"}},
+		Inputs: []ABIParam{{Name: "owner", Type: "address", Indexed: true}, {Name: "spender", Type: "address", Indexed: true}, {Name: "value", Type: "uint256"}},
 	},
 	{
 		Name:   "OwnershipTransferred",
 		Type:   "event",
-		Inputs: []ABIParam{{Name: "previousOwner", Type: "address"}, {Name: "newOwner", Type: "address"}},
+		Inputs: []ABIParam{{Name: "previousOwner", Type: "address", Indexed: true}, {Name: "newOwner", Type: "address", Indexed: true}},
 	},
 }
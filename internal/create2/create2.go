@@ -0,0 +1,202 @@
+// Package create2 computes and builds deterministic CREATE2 deployments
+// through the factories that exist at the same address on every EVM chain:
+// the deterministic-deployment proxy (what Foundry uses) and CreateX.
+package create2
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Factory is a CREATE2 factory deployed at the same address across chains.
+type Factory struct {
+	Name    string
+	Address common.Address
+}
+
+var (
+	// DeterministicDeployer is Arachnid's deterministic-deployment proxy.
+	// Calldata is salt ‖ initCode; constructors see it as msg.sender.
+	DeterministicDeployer = Factory{
+		Name:    "deterministic",
+		Address: common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C"),
+	}
+	// CreateX guards salts (see EffectiveSalt) and can call the new
+	// contract right after deployment.
+	CreateX = Factory{
+		Name:    "createx",
+		Address: common.HexToAddress("0xba5Ed099633D3B313e4D5F7bdc1305d3c28ba5Ed"),
+	}
+)
+
+// Factories lists the supported factories; the first is the default.
+var Factories = []Factory{DeterministicDeployer, CreateX}
+
+var (
+	selDeployCreate2        = crypto.Keccak256([]byte("deployCreate2(bytes32,bytes)"))[:4]
+	selDeployCreate2AndInit = crypto.Keccak256([]byte("deployCreate2AndInit(bytes32,bytes,bytes,(uint256,uint256))"))[:4]
+)
+
+// FactoryByName returns a factory by name or address; "" is the default.
+func FactoryByName(name string) (Factory, error) {
+	if name == "" {
+		return Factories[0], nil
+	}
+	for _, f := range Factories {
+		if strings.EqualFold(name, f.Name) || strings.EqualFold(name, f.Address.Hex()) {
+			return f, nil
+		}
+	}
+	return Factory{}, fmt.Errorf("unknown CREATE2 factory %q — use deterministic or createx", name)
+}
+
+// ParseSalt parses a salt: 0x-hex (up to 32 bytes, left-padded), a decimal
+// number, or any other text, which is hashed with keccak256.
+func ParseSalt(s string) ([32]byte, error) {
+	var salt [32]byte
+	switch {
+	case s == "":
+		return salt, fmt.Errorf("salt is required")
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		h := s[2:]
+		if len(h)%2 == 1 {
+			h = "0" + h
+		}
+		b, err := hex.DecodeString(h)
+		if err != nil || len(b) > 32 {
+			return salt, fmt.Errorf("invalid hex salt %q — at most 32 bytes", s)
+		}
+		copy(salt[32-len(b):], b)
+	default:
+		if n, ok := new(big.Int).SetString(s, 10); ok && n.Sign() >= 0 && n.BitLen() <= 256 {
+			n.FillBytes(salt[:])
+		} else {
+			copy(salt[:], crypto.Keccak256([]byte(s)))
+		}
+	}
+	return salt, nil
+}
+
+// Address is the CREATE2 formula: keccak(0xff ‖ deployer ‖ salt ‖ keccak(initCode))[12:].
+func Address(deployer common.Address, salt [32]byte, initCodeHash []byte) common.Address {
+	return crypto.CreateAddress2(deployer, salt, initCodeHash)
+}
+
+// ChainBound reports whether salt only yields the same address on one chain
+// (CreateX cross-chain redeploy protection).
+func (f Factory) ChainBound(salt [32]byte, sender common.Address) bool {
+	prefix := common.BytesToAddress(salt[:20])
+	return f == CreateX && salt[20] == 0x01 && (prefix == sender || prefix == common.Address{})
+}
+
+// Permissioned returns a CreateX salt only sender can deploy with. Salts
+// that already start with sender are kept; any other salt is rewritten to
+// sender ‖ flag ‖ keccak(salt)[:11], keeping a zero-prefix salt chain-bound.
+// Without it, anyone who sees the deploy in the mempool can take the address
+// with their own init calldata, here or on another chain.
+func Permissioned(salt [32]byte, sender common.Address) (out [32]byte, rewritten bool) {
+	prefix := common.BytesToAddress(salt[:20])
+	if prefix == sender {
+		return salt, false
+	}
+	copy(out[:20], sender.Bytes())
+	if prefix == (common.Address{}) && salt[20] == 0x01 {
+		out[20] = 0x01
+	}
+	copy(out[21:], crypto.Keccak256(salt[:])[:11])
+	return out, true
+}
+
+// EffectiveSalt returns the salt the factory passes to CREATE2. CreateX
+// rewrites it depending on the first 20 bytes (msg.sender, zero or other)
+// and byte 21 (0x01 = chain-bound, 0x00 = cross-chain):
+//
+//	sender  ‖ 01 → keccak(sender ‖ chainid ‖ salt)
+//	sender  ‖ 00 → keccak(sender ‖ salt)
+//	zero    ‖ 01 → keccak(chainid ‖ salt)
+//	anything else → keccak(salt)
+func (f Factory) EffectiveSalt(salt [32]byte, sender common.Address, chainID int64) ([32]byte, error) {
+	if f != CreateX {
+		return salt, nil
+	}
+	var out [32]byte
+	prefix := common.BytesToAddress(salt[:20])
+	permissioned := prefix == sender && sender != (common.Address{})
+	switch {
+	case permissioned && salt[20] == 0x01:
+		copy(out[:], crypto.Keccak256(common.LeftPadBytes(sender.Bytes(), 32), word(chainID), salt[:]))
+	case permissioned && salt[20] == 0x00:
+		copy(out[:], crypto.Keccak256(common.LeftPadBytes(sender.Bytes(), 32), salt[:]))
+	case permissioned:
+		return out, fmt.Errorf("salt starts with the sender but byte 21 is %#02x — use 0x00 (cross-chain) or 0x01 (chain-bound)", salt[20])
+	case prefix == (common.Address{}) && salt[20] == 0x01:
+		copy(out[:], crypto.Keccak256(word(chainID), salt[:]))
+	case prefix == (common.Address{}) && salt[20] != 0x00:
+		return out, fmt.Errorf("salt with a zero-address prefix needs byte 21 = 0x00 or 0x01")
+	default:
+		copy(out[:], crypto.Keccak256(salt[:]))
+	}
+	return out, nil
+}
+
+// Predict returns the address initCode deploys to through the factory.
+// sender and chainID only matter for CreateX permissioned/chain-bound salts.
+func (f Factory) Predict(salt [32]byte, initCode []byte, sender common.Address, chainID int64) (common.Address, error) {
+	eff, err := f.EffectiveSalt(salt, sender, chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return Address(f.Address, eff, crypto.Keccak256(initCode)), nil
+}
+
+// DeployData is the calldata for a plain CREATE2 deployment of initCode.
+func (f Factory) DeployData(salt [32]byte, initCode []byte) []byte {
+	if f == CreateX {
+		out := append([]byte{}, selDeployCreate2...)
+		out = append(out, salt[:]...)
+		out = append(out, word(0x40)...)
+		return append(out, dynBytes(initCode)...)
+	}
+	return append(append([]byte{}, salt[:]...), initCode...)
+}
+
+// DeployAndInitData is the CreateX calldata that deploys initCode and then
+// calls the new contract with init, with the factory as msg.sender — e.g. to
+// hand ownership from the factory to the deployer.
+func (f Factory) DeployAndInitData(salt [32]byte, initCode, init []byte) ([]byte, error) {
+	if f != CreateX {
+		return nil, fmt.Errorf("%s cannot call the contract after deployment — use createx", f.Name)
+	}
+	code := dynBytes(initCode)
+	out := append([]byte{}, selDeployCreate2AndInit...)
+	out = append(out, salt[:]...)
+	out = append(out, word(5*32)...)                  // initCode offset
+	out = append(out, word(int64(5*32+len(code)))...) // data offset
+	out = append(out, word(0)...)                     // values.constructorAmount
+	out = append(out, word(0)...)                     // values.initCallAmount
+	out = append(out, code...)
+	return append(out, dynBytes(init)...), nil
+}
+
+// word left-pads n to 32 bytes.
+func word(n int64) []byte {
+	w := make([]byte, 32)
+	binary.BigEndian.PutUint64(w[24:], uint64(n))
+	return w
+}
+
+// dynBytes ABI-encodes a dynamic bytes value: length word + right-padded data.
+func dynBytes(b []byte) []byte {
+	out := word(int64(len(b)))
+	out = append(out, b...)
+	if pad := (32 - len(b)%32) % 32; pad > 0 {
+		out = append(out, make([]byte, pad)...)
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+package create2
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sender = common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+
+func TestAddressEIP1014Vectors(t *testing.T) {
+	tests := []struct {
+		deployer, salt, code, want string
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x00", "00", "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x00", "00", "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"0x00000000000000000000000000000000deadbeef", "0xcafebabe", "deadbeef", "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+	}
+	for _, tt := range tests {
+		salt, err := ParseSalt(tt.salt)
+		require.NoError(t, err)
+		code, _ := hex.DecodeString(tt.code)
+		got := Address(common.HexToAddress(tt.deployer), salt, crypto.Keccak256(code))
+		assert.Equal(t, tt.want, got.Hex())
+	}
+}
+
+func TestParseSalt(t *testing.T) {
+	s, err := ParseSalt("0x01")
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), s[31])
+
+	s, err = ParseSalt("256")
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), s[30])
+
+	s, err = ParseSalt("my-token-v1")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256([]byte("my-token-v1")), s[:])
+
+	_, err = ParseSalt("")
+	assert.Error(t, err)
+	_, err = ParseSalt("0x" + strings.Repeat("ab", 33))
+	assert.Error(t, err)
+}
+
+func TestFactoryByName(t *testing.T) {
+	f, err := FactoryByName("")
+	require.NoError(t, err)
+	assert.Equal(t, DeterministicDeployer, f)
+
+	f, err = FactoryByName("CreateX")
+	require.NoError(t, err)
+	assert.Equal(t, CreateX, f)
+
+	f, err = FactoryByName(strings.ToLower(CreateX.Address.Hex()))
+	require.NoError(t, err)
+	assert.Equal(t, CreateX, f)
+
+	_, err = FactoryByName("nope")
+	assert.Error(t, err)
+}
+
+func TestDeployData(t *testing.T) {
+	salt, _ := ParseSalt("0x2a")
+	code := []byte{0x60, 0x80}
+
+	data := DeterministicDeployer.DeployData(salt, code)
+	assert.Equal(t, append(salt[:], code...), data)
+
+	data = CreateX.DeployData(salt, code)
+	require.Len(t, data, 4+32*4)
+	assert.Equal(t, crypto.Keccak256([]byte("deployCreate2(bytes32,bytes)"))[:4], data[:4])
+	assert.Equal(t, salt[:], data[4:36])
+	assert.Equal(t, byte(0x40), data[67])
+	assert.Equal(t, byte(2), data[99])
+	assert.Equal(t, code, data[100:102])
+}
+
+func TestDeployAndInitData(t *testing.T) {
+	salt, _ := ParseSalt("0x2a")
+	_, err := DeterministicDeployer.DeployAndInitData(salt, []byte{1}, []byte{2})
+	assert.Error(t, err)
+
+	data, err := CreateX.DeployAndInitData(salt, make([]byte, 33), []byte{0xf2, 0xfd})
+	require.NoError(t, err)
+	// head (5 words) + initCode (len + 2 words) + data (len + 1 word)
+	require.Len(t, data, 4+32*5+32*3+32*2)
+	assert.Equal(t, uint64(0xa0), binary.BigEndian.Uint64(data[4+32+24:4+64]))    // initCode offset
+	assert.Equal(t, uint64(0xa0+96), binary.BigEndian.Uint64(data[4+64+24:4+96])) // data offset
+	assert.Equal(t, []byte{0xf2, 0xfd}, data[4+0xa0+96+32:4+0xa0+96+34])
+}
+
+func TestEffectiveSalt(t *testing.T) {
+	var random [32]byte
+	copy(random[:], crypto.Keccak256([]byte("x")))
+	eff, err := CreateX.EffectiveSalt(random, sender, 1)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256(random[:]), eff[:])
+
+	eff, err = DeterministicDeployer.EffectiveSalt(random, sender, 1)
+	require.NoError(t, err)
+	assert.Equal(t, random, eff)
+
+	var permissioned [32]byte
+	copy(permissioned[:20], sender.Bytes())
+	permissioned[31] = 7
+	eff, err = CreateX.EffectiveSalt(permissioned, sender, 1)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256(common.LeftPadBytes(sender.Bytes(), 32), permissioned[:]), eff[:])
+	assert.False(t, CreateX.ChainBound(permissioned, sender))
+
+	permissioned[20] = 0x01
+	a, err := CreateX.Predict(permissioned, []byte{0}, sender, 1)
+	require.NoError(t, err)
+	b, err := CreateX.Predict(permissioned, []byte{0}, sender, 8453)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b, "chain-bound salts differ per chain")
+	assert.True(t, CreateX.ChainBound(permissioned, sender))
+
+	permissioned[20] = 0x02
+	_, err = CreateX.EffectiveSalt(permissioned, sender, 1)
+	assert.Error(t, err)
+}
+
+func TestMine(t *testing.T) {
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	hash := crypto.Keccak256(code)
+
+	res, err := Mine(context.Background(), MineOptions{
+		Factory: DeterministicDeployer, InitCodeHash: hash, Prefix: "0xA", Suffix: "b", Workers: 2,
+	}, nil)
+	require.NoError(t, err)
+	addr := strings.ToLower(res.Address.Hex())
+	assert.True(t, strings.HasPrefix(addr, "0xa") && strings.HasSuffix(addr, "b"), addr)
+	got, err := DeterministicDeployer.Predict(res.Salt, code, common.Address{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, res.Address, got)
+
+	res, err = Mine(context.Background(), MineOptions{
+		Factory: CreateX, InitCodeHash: hash, Sender: sender, Prefix: "00", Workers: 2,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sender.Bytes(), res.Salt[:20])
+	assert.Zero(t, res.Salt[20])
+	got, err = CreateX.Predict(res.Salt, code, sender, 1)
+	require.NoError(t, err)
+	assert.Equal(t, res.Address, got)
+}
+
+func TestMineValidatesPattern(t *testing.T) {
+	hash := crypto.Keccak256(nil)
+	for _, opts := range []MineOptions{
+		{InitCodeHash: hash},
+		{InitCodeHash: hash, Prefix: "xyz"},
+		{InitCodeHash: hash, Prefix: strings.Repeat("a", 41)},
+		{Prefix: "a"},
+	} {
+		_, err := Mine(context.Background(), opts, nil)
+		assert.Error(t, err)
+	}
+}
+
+func TestMineCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Mine(ctx, MineOptions{
+		Factory: DeterministicDeployer, InitCodeHash: crypto.Keccak256(nil), Prefix: strings.Repeat("0", 40), Workers: 1,
+	}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDifficulty(t *testing.T) {
+	assert.Equal(t, float64(16*16*16), Difficulty("0xabc", ""))
+	assert.Equal(t, float64(256), Difficulty("a", "b"))
+}
+
+func TestPermissioned(t *testing.T) {
+	salt, _ := ParseSalt("my-token")
+	guarded, rewritten := Permissioned(salt, sender)
+	assert.True(t, rewritten)
+	assert.Equal(t, sender.Bytes(), guarded[:20])
+	assert.Equal(t, byte(0x00), guarded[20], "cross-chain unless asked otherwise")
+	assert.Equal(t, crypto.Keccak256(salt[:])[:11], guarded[21:])
+	assert.False(t, CreateX.ChainBound(guarded, sender))
+
+	again, rewritten := Permissioned(guarded, sender)
+	assert.False(t, rewritten)
+	assert.Equal(t, guarded, again)
+
+	var bound [32]byte
+	bound[20], bound[31] = 0x01, 7
+	guarded, rewritten = Permissioned(bound, sender)
+	assert.True(t, rewritten)
+	assert.True(t, CreateX.ChainBound(guarded, sender), "zero-prefix chain-bound salts stay chain-bound")
+
+	other := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	var theirs [32]byte
+	copy(theirs[:20], other.Bytes())
+	guarded, rewritten = Permissioned(theirs, sender)
+	assert.True(t, rewritten)
+	assert.Equal(t, sender.Bytes(), guarded[:20])
+}
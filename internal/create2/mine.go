@@ -0,0 +1,155 @@
+package create2
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MineOptions configures a vanity salt search.
+type MineOptions struct {
+	Factory      Factory
+	InitCodeHash []byte
+	Sender       common.Address // CreateX: mine sender-permissioned cross-chain salts when set
+	Prefix       string         // hex the address must start with (0x optional, case-insensitive)
+	Suffix       string         // hex the address must end with
+	Workers      int            // default: runtime.NumCPU()
+}
+
+// MineResult is a salt whose CREATE2 address matches the pattern.
+type MineResult struct {
+	Salt     [32]byte
+	Address  common.Address
+	Attempts uint64
+}
+
+// progressEvery is how often Mine reports the attempt count.
+var progressEvery = 500 * time.Millisecond
+
+// Difficulty is the expected number of attempts to match prefix and suffix.
+func Difficulty(prefix, suffix string) float64 {
+	n := len(strings.TrimPrefix(strings.ToLower(prefix), "0x")) + len(suffix)
+	return math.Pow(16, float64(n))
+}
+
+// Mine searches salts on opts.Workers goroutines until one yields an address
+// matching the prefix/suffix or ctx is done. progress, when non-nil, is
+// called periodically with the total attempts so far.
+func Mine(ctx context.Context, opts MineOptions, progress func(attempts uint64)) (*MineResult, error) {
+	prefix := strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(opts.Prefix, "0x"), "0X"))
+	suffix := strings.ToLower(opts.Suffix)
+	for _, p := range []string{prefix, suffix} {
+		if _, err := hex.DecodeString(p + strings.Repeat("0", len(p)%2)); err != nil {
+			return nil, fmt.Errorf("pattern %q is not hex", p)
+		}
+	}
+	if prefix == "" && suffix == "" {
+		return nil, fmt.Errorf("give a prefix and/or suffix to mine for")
+	}
+	if len(prefix)+len(suffix) > 40 {
+		return nil, fmt.Errorf("prefix + suffix is longer than an address")
+	}
+	if len(opts.InitCodeHash) != 32 {
+		return nil, fmt.Errorf("init code hash must be 32 bytes")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	prefixB, suffixB := []byte(prefix), []byte(suffix)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts atomic.Uint64
+		once     sync.Once
+		found    *MineResult
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		var base [32]byte
+		if _, err := rand.Read(base[:]); err != nil {
+			return nil, err
+		}
+		if opts.Factory == CreateX && opts.Sender != (common.Address{}) {
+			copy(base[:20], opts.Sender.Bytes())
+			base[20] = 0x00 // cross-chain: same address on every chain
+		}
+		wg.Add(1)
+		go func(salt [32]byte) {
+			defer wg.Done()
+			h := crypto.NewKeccakState()
+			buf := make([]byte, 0, 85)
+			sum := make([]byte, 32)
+			var hexAddr [40]byte
+			counter := binary.BigEndian.Uint64(salt[24:])
+			for n := uint64(1); ; n++ {
+				if n%4096 == 0 {
+					attempts.Add(4096)
+					if ctx.Err() != nil {
+						return
+					}
+				}
+				counter++
+				binary.BigEndian.PutUint64(salt[24:], counter)
+				eff, err := opts.Factory.EffectiveSalt(salt, opts.Sender, 0)
+				if err != nil {
+					return
+				}
+
+				buf = append(buf[:0], 0xff)
+				buf = append(buf, opts.Factory.Address.Bytes()...)
+				buf = append(buf, eff[:]...)
+				buf = append(buf, opts.InitCodeHash...)
+				h.Reset()
+				h.Write(buf)
+				_, _ = h.Read(sum)
+
+				hex.Encode(hexAddr[:], sum[12:])
+				if bytes.HasPrefix(hexAddr[:], prefixB) && bytes.HasSuffix(hexAddr[:], suffixB) {
+					once.Do(func() {
+						found = &MineResult{Salt: salt, Address: common.BytesToAddress(sum[12:])}
+						cancel()
+					})
+					return
+				}
+			}
+		}(base)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	tick := time.NewTicker(progressEvery)
+	defer tick.Stop()
+	for {
+		select {
+		case <-done:
+			if found == nil {
+				return nil, ctx.Err()
+			}
+			found.Attempts = attempts.Load()
+			return found, nil
+		case <-tick.C:
+			if progress != nil {
+				progress(attempts.Load())
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package ens
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// Function selectors used against the registry, resolvers and NFT contracts.
+const (
+	selResolver       = "0178b8bf" // resolver(bytes32)
+	selAddr           = "3b3b57de" // addr(bytes32)
+	selAddrCoin       = "f1cb7e06" // addr(bytes32,uint256)
+	selName           = "691f3431" // name(bytes32)
+	selText           = "59d1d43c" // text(bytes32,string)
+	selContenthash    = "bc1c58d1" // contenthash(bytes32)
+	selResolve        = "9061b923" // resolve(bytes,bytes) — ENSIP-10
+	selOffchainLookup = "556f1830" // OffchainLookup(address,string[],bytes,bytes4,bytes)
+	selOwnerOf        = "6352211e" // ownerOf(uint256)
+	selTokenURI       = "c87b56dd" // tokenURI(uint256)
+	selURI            = "0e89341c" // uri(uint256)
+	selBalanceOf1155  = "00fdd58e" // balanceOf(address,uint256)
+)
+
+// maxABIDynamicBytes bounds offsets and lengths read from untrusted return
+// data (resolvers and gateways are arbitrary contracts and servers).
+const maxABIDynamicBytes = 1 << 20
+
+// abiArg is one argument of an ABI-encoded call: a static 32-byte word or a
+// dynamic bytes/string value.
+type abiArg struct {
+	word    []byte
+	dynamic []byte
+	isDyn   bool
+}
+
+// word left-pads b (an address, uint or bytes32) to a static 32-byte word.
+func word(b []byte) abiArg {
+	w := make([]byte, 32)
+	copy(w[32-len(b):], b)
+	return abiArg{word: w}
+}
+
+// fixedBytes right-pads b (a bytesN value) to a static 32-byte word.
+func fixedBytes(b []byte) abiArg {
+	w := make([]byte, 32)
+	copy(w, b)
+	return abiArg{word: w}
+}
+
+// uintArg encodes n as a uint256 word.
+func uintArg(n *big.Int) abiArg {
+	return word(n.Bytes())
+}
+
+// dyn encodes b as a dynamic bytes (or string) argument.
+func dyn(b []byte) abiArg {
+	return abiArg{dynamic: b, isDyn: true}
+}
+
+// encodeCall ABI-encodes a call to selector (8 hex chars) with args.
+func encodeCall(selector string, args ...abiArg) []byte {
+	out, _ := hex.DecodeString(selector)
+	head := make([]byte, 0, 32*len(args))
+	var tail []byte
+	for _, a := range args {
+		if !a.isDyn {
+			head = append(head, a.word...)
+			continue
+		}
+		head = append(head, word(big.NewInt(int64(32*len(args)+len(tail))).Bytes()).word...)
+		tail = append(tail, word(big.NewInt(int64(len(a.dynamic))).Bytes()).word...)
+		tail = append(tail, a.dynamic...)
+		if pad := len(a.dynamic) % 32; pad != 0 {
+			tail = append(tail, make([]byte, 32-pad)...)
+		}
+	}
+	return append(append(out, head...), tail...)
+}
+
+// readWord returns the 32-byte word at byte offset off.
+func readWord(data []byte, off int) ([]byte, error) {
+	if off < 0 || off+32 > len(data) {
+		return nil, fmt.Errorf("ABI data too short (%d bytes, need %d)", len(data), off+32)
+	}
+	return data[off : off+32], nil
+}
+
+// readInt reads the word at off as an offset or length, rejecting values
+// that can't index into data.
+func readInt(data []byte, off int) (int, error) {
+	w, err := readWord(data, off)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range w[:24] {
+		if b != 0 {
+			return 0, fmt.Errorf("ABI offset out of range")
+		}
+	}
+	n := binary.BigEndian.Uint64(w[24:])
+	if n > maxABIDynamicBytes {
+		return 0, fmt.Errorf("ABI offset out of range")
+	}
+	return int(n), nil
+}
+
+// decodeBytes decodes argument i of data as dynamic bytes (or a string).
+func decodeBytes(data []byte, i int) ([]byte, error) {
+	off, err := readInt(data, 32*i)
+	if err != nil {
+		return nil, err
+	}
+	n, err := readInt(data, off)
+	if err != nil {
+		return nil, err
+	}
+	if off+32+n > len(data) {
+		return nil, fmt.Errorf("ABI bytes overrun (%d bytes at %d)", n, off+32)
+	}
+	return data[off+32 : off+32+n], nil
+}
+
+// decodeStringArray decodes argument i of data as a string[].
+func decodeStringArray(data []byte, i int) ([]string, error) {
+	off, err := readInt(data, 32*i)
+	if err != nil {
+		return nil, err
+	}
+	n, err := readInt(data, off)
+	if err != nil {
+		return nil, err
+	}
+	if off+32 > len(data) {
+		return nil, fmt.Errorf("ABI array overrun")
+	}
+	elems := data[off+32:]
+	out := make([]string, 0, n)
+	for j := 0; j < n; j++ {
+		s, err := decodeBytes(elems, j)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, string(s))
+	}
+	return out, nil
+}
@@ -0,0 +1,214 @@
+package ens
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+)
+
+// IPFSGateway is the HTTP gateway ipfs:// and ipns:// URIs are rewritten to.
+var IPFSGateway = "https://ipfs.io/"
+
+// Avatar is a resolved ENSIP-12 avatar record.
+type Avatar struct {
+	Record string     // the raw "avatar" text record
+	URL    string     // http(s) or data: URL of the image; empty if unresolved
+	NFT    *NFTAvatar // set when the record points at an NFT
+}
+
+// NFTAvatar is an avatar record of the form eip155:<chain>/<erc721|erc1155>:<contract>/<id>.
+type NFTAvatar struct {
+	ChainID  int64
+	Standard string // "erc721" or "erc1155"
+	Contract string
+	TokenID  *big.Int
+	Owned    bool // the name's address owns the token
+}
+
+// ResolveAvatar reads name's avatar record and resolves it to an image URL.
+// NFT avatars are followed through tokenURI/uri and the token metadata, and
+// checked against the name's address. client must be on Ethereum mainnet;
+// NFTs on other chains are returned with an empty URL.
+func ResolveAvatar(name string, client *chain.EVMClient) (*Avatar, error) {
+	record, err := Text(name, "avatar", client)
+	if err != nil {
+		return nil, err
+	}
+	if record == "" {
+		return nil, fmt.Errorf("no avatar record for %q", name)
+	}
+
+	a := &Avatar{Record: record}
+	if !strings.HasPrefix(strings.ToLower(record), "eip155:") {
+		a.URL = GatewayURL(record)
+		return a, nil
+	}
+
+	nft, err := ParseNFTAvatar(record)
+	if err != nil {
+		return nil, err
+	}
+	a.NFT = nft
+	if nft.ChainID != 1 {
+		return a, nil
+	}
+
+	if owner, err := Resolve(name, client); err == nil {
+		nft.Owned = ownsNFT(client, nft, owner)
+	}
+	tokenURI, err := nftTokenURI(client, nft)
+	if err != nil {
+		return nil, err
+	}
+	image, err := fetchMetadataImage(GatewayURL(tokenURI))
+	if err != nil {
+		return nil, err
+	}
+	a.URL = GatewayURL(image)
+	return a, nil
+}
+
+// ParseNFTAvatar parses an ENSIP-12 NFT avatar URI such as
+// eip155:1/erc721:0xb47e…/2430.
+func ParseNFTAvatar(record string) (*NFTAvatar, error) {
+	parts := strings.Split(record, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed NFT avatar %q", record)
+	}
+	chainID, err := strconv.ParseInt(strings.TrimPrefix(strings.ToLower(parts[0]), "eip155:"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed NFT avatar chain in %q", record)
+	}
+	standard, contract, ok := strings.Cut(parts[1], ":")
+	standard = strings.ToLower(standard)
+	if !ok || (standard != "erc721" && standard != "erc1155") {
+		return nil, fmt.Errorf("unsupported NFT standard in avatar %q", record)
+	}
+	if len(contract) != 42 || !strings.HasPrefix(contract, "0x") {
+		return nil, fmt.Errorf("malformed NFT contract in avatar %q", record)
+	}
+	id, ok := new(big.Int).SetString(parts[2], 10)
+	if !ok {
+		return nil, fmt.Errorf("malformed NFT token ID in avatar %q", record)
+	}
+	return &NFTAvatar{ChainID: chainID, Standard: standard, Contract: contract, TokenID: id}, nil
+}
+
+// GatewayURL rewrites ipfs://, ipns:// and bare CIDv0 URIs to IPFSGateway.
+// Other URIs are returned as-is.
+func GatewayURL(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://ipfs/"):
+		return IPFSGateway + "ipfs/" + strings.TrimPrefix(uri, "ipfs://ipfs/")
+	case strings.HasPrefix(uri, "ipfs://"):
+		return IPFSGateway + "ipfs/" + strings.TrimPrefix(uri, "ipfs://")
+	case strings.HasPrefix(uri, "ipns://"):
+		return IPFSGateway + "ipns/" + strings.TrimPrefix(uri, "ipns://")
+	case strings.HasPrefix(uri, "Qm") && len(uri) == 46:
+		return IPFSGateway + "ipfs/" + uri
+	}
+	return uri
+}
+
+func ownsNFT(client *chain.EVMClient, nft *NFTAvatar, owner string) bool {
+	if nft.Standard == "erc721" {
+		out, err := client.CallContract(nft.Contract, "0x"+hex.EncodeToString(encodeCall(selOwnerOf, uintArg(nft.TokenID))))
+		return err == nil && strings.EqualFold(parseAddress(out), owner)
+	}
+	ownerBytes, _ := hex.DecodeString(strings.TrimPrefix(owner, "0x"))
+	out, err := client.CallContract(nft.Contract, "0x"+hex.EncodeToString(encodeCall(selBalanceOf1155, word(ownerBytes), uintArg(nft.TokenID))))
+	if err != nil {
+		return false
+	}
+	bal, ok := new(big.Int).SetString(strings.TrimPrefix(out, "0x"), 16)
+	return ok && bal.Sign() > 0
+}
+
+// nftTokenURI returns the metadata URI of nft, substituting ERC-1155's {id}.
+func nftTokenURI(client *chain.EVMClient, nft *NFTAvatar) (string, error) {
+	sel := selTokenURI
+	if nft.Standard == "erc1155" {
+		sel = selURI
+	}
+	out, err := client.CallContract(nft.Contract, "0x"+hex.EncodeToString(encodeCall(sel, uintArg(nft.TokenID))))
+	if err != nil {
+		return "", fmt.Errorf("reading NFT metadata URI: %w", err)
+	}
+	raw, _ := hex.DecodeString(strings.TrimPrefix(out, "0x"))
+	uri, err := decodeBytes(raw, 0)
+	if err != nil || len(uri) == 0 {
+		return "", fmt.Errorf("NFT %s #%s has no metadata URI", nft.Contract, nft.TokenID)
+	}
+	s := string(uri)
+	if nft.Standard == "erc1155" {
+		s = strings.ReplaceAll(s, "{id}", fmt.Sprintf("%064x", nft.TokenID))
+	}
+	return s, nil
+}
+
+// fetchMetadataImage loads NFT metadata (http(s) or a data: URI) and returns
+// its image. Inline SVG (image_data) comes back as a data: URL.
+func fetchMetadataImage(metaURL string) (string, error) {
+	var body []byte
+	if strings.HasPrefix(metaURL, "data:") {
+		b, err := decodeDataURI(metaURL)
+		if err != nil {
+			return "", err
+		}
+		body = b
+	} else {
+		resp, err := httpClient.Get(metaURL)
+		if err != nil {
+			return "", fmt.Errorf("fetching NFT metadata: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return "", fmt.Errorf("fetching NFT metadata: HTTP %d", resp.StatusCode)
+		}
+		if body, err = io.ReadAll(io.LimitReader(resp.Body, maxABIDynamicBytes)); err != nil {
+			return "", fmt.Errorf("fetching NFT metadata: %w", err)
+		}
+	}
+
+	var meta struct {
+		Image     string `json:"image"`
+		ImageURL  string `json:"image_url"`
+		ImageData string `json:"image_data"`
+	}
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return "", fmt.Errorf("parsing NFT metadata: %w", err)
+	}
+	switch {
+	case meta.Image != "":
+		return meta.Image, nil
+	case meta.ImageURL != "":
+		return meta.ImageURL, nil
+	case meta.ImageData != "":
+		return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(meta.ImageData)), nil
+	}
+	return "", fmt.Errorf("NFT metadata has no image")
+}
+
+// decodeDataURI returns the payload of a data: URI (base64 or percent-encoded).
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("malformed data URI")
+	}
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(payload)
+	}
+	s, err := url.PathUnescape(payload)
+	if err != nil {
+		return []byte(payload), nil
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,154 @@
+package ens
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+)
+
+// maxCCIPLookups caps how many OffchainLookup rounds one call may trigger
+// (EIP-3668 recommends at least 4).
+const maxCCIPLookups = 4
+
+// httpClient fetches CCIP-Read gateways and avatar metadata.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// offchainLookup is a decoded EIP-3668 OffchainLookup revert.
+type offchainLookup struct {
+	sender    string
+	urls      []string
+	callData  []byte
+	callback  []byte
+	extraData []byte
+}
+
+// ccipCall runs an eth_call of data against to. When the contract reverts
+// with OffchainLookup it queries the named gateway and calls the contract's
+// callback with the answer, as EIP-3668 (CCIP-Read) specifies.
+func ccipCall(client *chain.EVMClient, to string, data []byte) ([]byte, error) {
+	for i := 0; i <= maxCCIPLookups; i++ {
+		result, err := client.CallContract(to, "0x"+hex.EncodeToString(data))
+		if err == nil {
+			return hex.DecodeString(strings.TrimPrefix(result, "0x"))
+		}
+		lookup, ok := parseOffchainLookup(err)
+		if !ok {
+			return nil, err
+		}
+		if !strings.EqualFold(lookup.sender, to) {
+			return nil, fmt.Errorf("OffchainLookup sender %s does not match %s", lookup.sender, to)
+		}
+		resp, err := fetchGateway(lookup)
+		if err != nil {
+			return nil, err
+		}
+		data = encodeCall(hex.EncodeToString(lookup.callback), dyn(resp), dyn(lookup.extraData))
+	}
+	return nil, fmt.Errorf("too many CCIP-Read lookups calling %s", to)
+}
+
+// parseOffchainLookup extracts an OffchainLookup revert from an eth_call error.
+func parseOffchainLookup(err error) (*offchainLookup, bool) {
+	var rpcErr *chain.RPCError
+	if !errors.As(err, &rpcErr) {
+		return nil, false
+	}
+	raw, decErr := hex.DecodeString(strings.TrimPrefix(rpcErr.Data, "0x"))
+	if decErr != nil || len(raw) < 4 || hex.EncodeToString(raw[:4]) != selOffchainLookup {
+		return nil, false
+	}
+	args := raw[4:]
+	sender, err := readWord(args, 0)
+	if err != nil {
+		return nil, false
+	}
+	callback, err := readWord(args, 96)
+	if err != nil {
+		return nil, false
+	}
+	urls, err := decodeStringArray(args, 1)
+	if err != nil {
+		return nil, false
+	}
+	callData, err := decodeBytes(args, 2)
+	if err != nil {
+		return nil, false
+	}
+	extraData, err := decodeBytes(args, 4)
+	if err != nil {
+		return nil, false
+	}
+	return &offchainLookup{
+		sender:    "0x" + hex.EncodeToString(sender[12:]),
+		urls:      urls,
+		callData:  callData,
+		callback:  callback[:4],
+		extraData: extraData,
+	}, true
+}
+
+// fetchGateway tries each gateway URL in order. URLs containing {data} are
+// fetched with GET, the rest with a JSON POST. A 4xx answer is final; other
+// failures move on to the next URL.
+func fetchGateway(l *offchainLookup) ([]byte, error) {
+	if len(l.urls) == 0 {
+		return nil, fmt.Errorf("OffchainLookup from %s lists no gateway URLs", l.sender)
+	}
+	sender := strings.ToLower(l.sender)
+	data := "0x" + hex.EncodeToString(l.callData)
+
+	var lastErr error
+	for _, tmpl := range l.urls {
+		url := strings.ReplaceAll(strings.ReplaceAll(tmpl, "{sender}", sender), "{data}", data)
+		var (
+			resp *http.Response
+			err  error
+		)
+		if strings.Contains(tmpl, "{data}") {
+			resp, err = httpClient.Get(url)
+		} else {
+			body, _ := json.Marshal(map[string]string{"data": data, "sender": sender})
+			resp, err = httpClient.Post(url, "application/json", bytes.NewReader(body))
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("CCIP gateway %s: %w", url, err)
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxABIDynamicBytes))
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("CCIP gateway %s: %w", url, err)
+			continue
+		}
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return nil, fmt.Errorf("CCIP gateway %s: HTTP %d: %s", url, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("CCIP gateway %s: HTTP %d", url, resp.StatusCode)
+			continue
+		}
+
+		var out struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal(body, &out); err != nil {
+			lastErr = fmt.Errorf("CCIP gateway %s: parsing response: %w", url, err)
+			continue
+		}
+		decoded, err := hex.DecodeString(strings.TrimPrefix(out.Data, "0x"))
+		if err != nil {
+			lastErr = fmt.Errorf("CCIP gateway %s: response data is not hex", url)
+			continue
+		}
+		return decoded, nil
+	}
+	return nil, lastErr
+}
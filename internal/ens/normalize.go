@@ -0,0 +1,59 @@
+package ens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedName marks a name Normalize refuses because it contains
+// characters outside ASCII. Normalizing those needs ENSIP-15's mapping, emoji
+// and confusable tables, which w3cli does not bundle; guessing could resolve a
+// look-alike of the name the user meant.
+var ErrUnsupportedName = errors.New("only ASCII ENS names are supported — use the 0x address instead")
+
+// Normalize returns name in ENSIP-15 normalized form. Only ASCII names are
+// accepted, where ENSIP-15 reduces to: A–Z map to a–z; a label may contain
+// a–z, 0–9, "-", "$" and leading underscores; labels are non-empty and do
+// not have "--" in the third and fourth positions. Every other character is
+// refused rather than mapped.
+func Normalize(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty name")
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		n, err := normalizeLabel(label)
+		if err != nil {
+			return "", fmt.Errorf("invalid name %q: %w", name, err)
+		}
+		labels[i] = n
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func normalizeLabel(label string) (string, error) {
+	if label == "" {
+		return "", fmt.Errorf("empty label")
+	}
+	b := []byte(label)
+	for i, c := range b {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b[i] = c + 'a' - 'A'
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '$':
+		case c == '_':
+			if strings.TrimLeft(label[:i], "_") != "" {
+				return "", fmt.Errorf("underscore allowed only at the start of a label: %q", label)
+			}
+		case c >= 0x80:
+			return "", ErrUnsupportedName
+		default:
+			return "", fmt.Errorf("disallowed character %q in %q", c, label)
+		}
+	}
+	if len(b) >= 4 && b[2] == '-' && b[3] == '-' {
+		return "", fmt.Errorf("invalid label extension %q", b)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,176 @@
+package ens
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+)
+
+// SLIP-44 coin types for addr(bytes32,uint256) (ENSIP-9).
+const (
+	CoinTypeETH    uint64 = 60
+	CoinTypeSolana uint64 = 501
+	CoinTypeSUI    uint64 = 784
+)
+
+// CoinTypeDefaultEVM is the ENSIP-19 default EVM address, used for any EVM
+// chain without its own record.
+const CoinTypeDefaultEVM uint64 = 0x80000000
+
+// EVMCoinType returns the ENSIP-11 coin type for an EVM chain ID.
+func EVMCoinType(chainID int64) uint64 {
+	if chainID == 1 {
+		return CoinTypeETH
+	}
+	return CoinTypeDefaultEVM | uint64(chainID)
+}
+
+// isEVMCoinType reports whether coinType holds a 20-byte EVM address.
+func isEVMCoinType(coinType uint64) bool {
+	return coinType == CoinTypeETH || coinType&CoinTypeDefaultEVM != 0
+}
+
+// ResolveCoin returns name's address for coinType, e.g. EVMCoinType(8453)
+// for Base. EVM chains without their own record fall back to the ENSIP-19
+// default EVM address. Addresses come back in the chain's native format
+// (0x hex for EVM and SUI, base58 for Solana).
+func ResolveCoin(name string, coinType uint64, client *chain.EVMClient) (string, error) {
+	norm, err := Normalize(name)
+	if err != nil {
+		return "", err
+	}
+	if coinType == CoinTypeETH {
+		return Resolve(norm, client)
+	}
+
+	raw, err := addrRecord(norm, coinType, client)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) == 0 && isEVMCoinType(coinType) && coinType != CoinTypeDefaultEVM {
+		if raw, err = addrRecord(norm, CoinTypeDefaultEVM, client); err != nil {
+			return "", err
+		}
+	}
+	if len(raw) == 0 {
+		return "", fmt.Errorf("no address record for %q (coin type %d)", norm, coinType)
+	}
+
+	switch {
+	case isEVMCoinType(coinType) && len(raw) == 20:
+		return "0x" + hex.EncodeToString(raw), nil
+	case coinType == CoinTypeSolana && len(raw) == 32:
+		return solana.Base58Encode(raw), nil
+	}
+	return "0x" + hex.EncodeToString(raw), nil
+}
+
+func addrRecord(name string, coinType uint64, client *chain.EVMClient) ([]byte, error) {
+	out, err := query(name, client, selAddrCoin, uintArg(new(big.Int).SetUint64(coinType)))
+	if err != nil {
+		return nil, err
+	}
+	return decodeBytes(out, 0)
+}
+
+// Text returns name's text record for key, e.g. "com.twitter", "url" or
+// "avatar". An unset record returns "".
+func Text(name, key string, client *chain.EVMClient) (string, error) {
+	norm, err := Normalize(name)
+	if err != nil {
+		return "", err
+	}
+	out, err := query(norm, client, selText, dyn([]byte(key)))
+	if err != nil {
+		return "", err
+	}
+	value, err := decodeBytes(out, 0)
+	if err != nil {
+		return "", fmt.Errorf("decoding text record %q: %w", key, err)
+	}
+	return string(value), nil
+}
+
+// Contenthash returns name's contenthash record as a URI (ipfs://, ipns://
+// or bzz://). An unset record returns "".
+func Contenthash(name string, client *chain.EVMClient) (string, error) {
+	norm, err := Normalize(name)
+	if err != nil {
+		return "", err
+	}
+	out, err := query(norm, client, selContenthash)
+	if err != nil {
+		return "", err
+	}
+	raw, err := decodeBytes(out, 0)
+	if err != nil {
+		return "", fmt.Errorf("decoding contenthash: %w", err)
+	}
+	if len(raw) == 0 {
+		return "", nil
+	}
+	return DecodeContenthash(raw)
+}
+
+// Contenthash namespace multicodecs (ENSIP-7).
+const (
+	codecIPFS  = 0xe3
+	codecSwarm = 0xe4
+	codecIPNS  = 0xe5
+
+	codecDagPB   = 0x70
+	hashSHA256   = 0x12
+	hashKeccak   = 0x1b
+	cidVersion1  = 0x01
+	digestLength = 0x20
+)
+
+// DecodeContenthash decodes a raw ENSIP-7 contenthash into a URI. IPFS
+// content in the classic dag-pb/sha2-256 form renders as a CIDv0 (Qm…);
+// other CIDs render as base32 CIDv1 (b…).
+func DecodeContenthash(raw []byte) (string, error) {
+	ns, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return "", fmt.Errorf("malformed contenthash")
+	}
+	cid := raw[n:]
+
+	switch ns {
+	case codecIPFS, codecIPNS:
+		scheme := "ipfs://"
+		if ns == codecIPNS {
+			scheme = "ipns://"
+		}
+		if len(cid) == 34 && cid[0] == hashSHA256 && cid[1] == digestLength {
+			return scheme + solana.Base58Encode(cid), nil // bare multihash = CIDv0
+		}
+		version, vn := binary.Uvarint(cid)
+		if vn <= 0 || version != cidVersion1 {
+			return "", fmt.Errorf("unsupported CID version in contenthash")
+		}
+		codec, cn := binary.Uvarint(cid[vn:])
+		if cn <= 0 {
+			return "", fmt.Errorf("malformed CID in contenthash")
+		}
+		mh := cid[vn+cn:]
+		if ns == codecIPFS && codec == codecDagPB && len(mh) == 34 && mh[0] == hashSHA256 && mh[1] == digestLength {
+			return scheme + solana.Base58Encode(mh), nil
+		}
+		enc := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(cid)
+		return scheme + "b" + strings.ToLower(enc), nil
+
+	case codecSwarm:
+		// <cid-version> <swarm-manifest> <keccak-256> <32> <digest>
+		if len(cid) < digestLength+2 || cid[len(cid)-digestLength-2] != hashKeccak {
+			return "", fmt.Errorf("malformed swarm contenthash")
+		}
+		return "bzz://" + hex.EncodeToString(cid[len(cid)-digestLength:]), nil
+	}
+	return "", fmt.Errorf("unsupported contenthash codec 0x%x", ns)
+}
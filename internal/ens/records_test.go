@@ -0,0 +1,414 @@
+package ens
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Normalize — ENSIP-15
+// ---------------------------------------------------------------------------
+
+func TestNormalize_Lowercases(t *testing.T) {
+	n, err := Normalize("Vitalik.ETH")
+	require.NoError(t, err)
+	assert.Equal(t, "vitalik.eth", n)
+}
+
+func TestNormalize_AllowsASCII(t *testing.T) {
+	for name, want := range map[string]string{
+		"123.eth":      "123.eth",
+		"$Money.eth":   "$money.eth",
+		"__dev.eth":    "__dev.eth",
+		"-a-b-.eth":    "-a-b-.eth",
+		"ab-c--d.eth":  "ab-c--d.eth",
+		"sub.Base.ETH": "sub.base.eth",
+	} {
+		n, err := Normalize(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, n, name)
+	}
+}
+
+func TestNormalize_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"":            "empty name",
+		"foo..eth":    "empty label",
+		".eth":        "empty label",
+		"foo bar.eth": "disallowed character",
+		"foo!.eth":    "disallowed character",
+		"a_b.eth":     "underscore",
+		"xn--abc.eth": "label extension",
+		"AB--c.eth":   "label extension",
+	}
+	for name, want := range cases {
+		_, err := Normalize(name)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), want, name)
+	}
+}
+
+func TestNormalize_RefusesNonASCII(t *testing.T) {
+	for _, name := range []string{
+		"caf\u00e9.eth",
+		"\u2764\ufe0f.eth",
+		"\U0001F680.eth",
+		"p\u0430y.eth", // Cyrillic "а"
+		"foo\u3002eth", // ideographic full stop
+		"ab\u200b.eth", // zero-width space
+	} {
+		_, err := Normalize(name)
+		assert.ErrorIs(t, err, ErrUnsupportedName, name)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ABI helpers
+// ---------------------------------------------------------------------------
+
+func TestEncodeCall_DynamicRoundTrip(t *testing.T) {
+	node := make([]byte, 32)
+	node[31] = 7
+	data := encodeCall(selText, word(node), dyn([]byte("com.twitter")))
+	assert.Equal(t, selText, hex.EncodeToString(data[:4]))
+	assert.Equal(t, node, data[4:36])
+
+	key, err := decodeBytes(data[4:], 1)
+	require.NoError(t, err)
+	assert.Equal(t, "com.twitter", string(key))
+	assert.Zero(t, len(data[4:])%32, "encoding must be word-aligned")
+}
+
+func TestDecodeBytes_RejectsOverrun(t *testing.T) {
+	data := encodeCall(selText, dyn([]byte("hello")))[4:]
+	_, err := decodeBytes(data[:40], 0)
+	assert.Error(t, err)
+}
+
+func TestDNSEncode(t *testing.T) {
+	b, err := dnsEncode("sub.test.eth")
+	require.NoError(t, err)
+	assert.Equal(t, "\x03sub\x04test\x03eth\x00", string(b))
+}
+
+// ---------------------------------------------------------------------------
+// DecodeContenthash — ENSIP-7
+// ---------------------------------------------------------------------------
+
+func TestDecodeContenthash_IPFS(t *testing.T) {
+	// ipfs-ns, CIDv1, dag-pb, sha2-256 → rendered as CIDv0.
+	raw, _ := hex.DecodeString("e3010170122029f2d17be6139079dc48696d1f582a8530eb9805b561eda517e22a892c7e3f1f")
+	uri, err := DecodeContenthash(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4", uri)
+}
+
+func TestDecodeContenthash_IPNS(t *testing.T) {
+	// ipns-ns, CIDv1, libp2p-key, identity multihash.
+	raw, _ := hex.DecodeString("e5010172000f6170702e756e69737761702e6f7267")
+	uri, err := DecodeContenthash(raw)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(uri, "ipns://b"), uri)
+}
+
+func TestDecodeContenthash_Swarm(t *testing.T) {
+	raw, _ := hex.DecodeString("e40101fa011b20d1de9994b4d039f6548d191eb26786769f580809256b4685ef316805265ea162")
+	uri, err := DecodeContenthash(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "bzz://d1de9994b4d039f6548d191eb26786769f580809256b4685ef316805265ea162", uri)
+}
+
+func TestDecodeContenthash_Unsupported(t *testing.T) {
+	_, err := DecodeContenthash([]byte{0x90, 0x01, 0x00})
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// Avatars — ENSIP-12
+// ---------------------------------------------------------------------------
+
+func TestParseNFTAvatar(t *testing.T) {
+	nft, err := ParseNFTAvatar("eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/2430")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), nft.ChainID)
+	assert.Equal(t, "erc721", nft.Standard)
+	assert.Equal(t, "0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB", nft.Contract)
+	assert.Equal(t, int64(2430), nft.TokenID.Int64())
+
+	_, err = ParseNFTAvatar("eip155:1/erc20:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1")
+	assert.Error(t, err)
+	_, err = ParseNFTAvatar("https://example.com/a.png")
+	assert.Error(t, err)
+}
+
+func TestGatewayURL(t *testing.T) {
+	assert.Equal(t, IPFSGateway+"ipfs/bafyabc/1.png", GatewayURL("ipfs://bafyabc/1.png"))
+	assert.Equal(t, IPFSGateway+"ipfs/QmX", GatewayURL("ipfs://ipfs/QmX"))
+	assert.Equal(t, IPFSGateway+"ipns/name.eth", GatewayURL("ipns://name.eth"))
+	assert.Equal(t, "https://x.io/a.png", GatewayURL("https://x.io/a.png"))
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	b, err := decodeDataURI("data:application/json;base64,eyJpbWFnZSI6ImEifQ==")
+	require.NoError(t, err)
+	assert.Equal(t, `{"image":"a"}`, string(b))
+
+	b, err = decodeDataURI(`data:application/json,{"image":"b"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"image":"b"}`, string(b))
+}
+
+func TestEVMCoinType(t *testing.T) {
+	assert.Equal(t, CoinTypeETH, EVMCoinType(1))
+	assert.Equal(t, uint64(0x80002105), EVMCoinType(8453))
+	assert.True(t, isEVMCoinType(EVMCoinType(10)))
+	assert.False(t, isEVMCoinType(CoinTypeSolana))
+}
+
+// ---------------------------------------------------------------------------
+// Contract-level mock: registry, resolver and a CCIP-Read gateway
+// ---------------------------------------------------------------------------
+
+const (
+	mockResolver = "0x4976fb03c32e5b8cfe2b6ccb31c09ba78ebaba41"
+	mockTarget   = "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+)
+
+type callResult struct {
+	result  []byte
+	revert  []byte // OffchainLookup data
+	failure bool
+}
+
+// ensContractMock routes eth_call by target and calldata through handle.
+func ensContractMock(t *testing.T, handle func(to string, data []byte) callResult) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     int               `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		json.Unmarshal(req.Params[0], &call) //nolint:errcheck
+		data, _ := hex.DecodeString(strings.TrimPrefix(call.Data, "0x"))
+
+		res := handle(strings.ToLower(call.To), data)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case res.revert != nil:
+			json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+				"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": 3, "message": "execution reverted", "data": "0x" + hex.EncodeToString(res.revert)},
+			})
+		case res.failure:
+			json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+				"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": 3, "message": "execution reverted"},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{ //nolint:errcheck
+				"jsonrpc": "2.0", "id": req.ID, "result": "0x" + hex.EncodeToString(res.result),
+			})
+		}
+	}))
+}
+
+func addrWord(addr string) []byte {
+	b, _ := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+	return word(b).word
+}
+
+func nodeOf(name string) []byte {
+	b, _ := hex.DecodeString(Namehash(name))
+	return b
+}
+
+// registryHandler answers resolver(bytes32) from resolvers (node → address).
+func registryHandler(resolvers map[string]string, data []byte) callResult {
+	if r, ok := resolvers[hex.EncodeToString(data[4:36])]; ok {
+		return callResult{result: addrWord(r)}
+	}
+	return callResult{result: make([]byte, 32)}
+}
+
+// abiReturn encodes a single dynamic (bytes or string) return value.
+func abiReturn(b []byte) []byte {
+	return encodeCall("00000000", dyn(b))[4:]
+}
+
+func TestText_DirectResolver(t *testing.T) {
+	srv := ensContractMock(t, func(to string, data []byte) callResult {
+		if to == strings.ToLower(registryAddr) {
+			return registryHandler(map[string]string{hex.EncodeToString(nodeOf("nick.eth")): mockResolver}, data)
+		}
+		require.Equal(t, selText, hex.EncodeToString(data[:4]))
+		key, _ := decodeBytes(data[4:], 1)
+		if string(key) == "com.twitter" {
+			return callResult{result: abiReturn([]byte("nicksdjohnson"))}
+		}
+		return callResult{result: abiReturn(nil)}
+	})
+	defer srv.Close()
+
+	client := chain.NewEVMClient(srv.URL)
+	v, err := Text("Nick.eth", "com.twitter", client)
+	require.NoError(t, err)
+	assert.Equal(t, "nicksdjohnson", v)
+
+	v, err = Text("nick.eth", "url", client)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+func TestResolveCoin_FallsBackToDefaultEVM(t *testing.T) {
+	srv := ensContractMock(t, func(to string, data []byte) callResult {
+		if to == strings.ToLower(registryAddr) {
+			return registryHandler(map[string]string{hex.EncodeToString(nodeOf("multi.eth")): mockResolver}, data)
+		}
+		require.Equal(t, selAddrCoin, hex.EncodeToString(data[:4]))
+		coin := new(big.Int).SetBytes(data[36:68]).Uint64()
+		switch coin {
+		case CoinTypeDefaultEVM:
+			b, _ := hex.DecodeString(strings.TrimPrefix(mockTarget, "0x"))
+			return callResult{result: abiReturn(b)}
+		case CoinTypeSolana:
+			return callResult{result: abiReturn(make([]byte, 32))}
+		}
+		return callResult{result: abiReturn(nil)}
+	})
+	defer srv.Close()
+
+	client := chain.NewEVMClient(srv.URL)
+	addr, err := ResolveCoin("multi.eth", EVMCoinType(8453), client)
+	require.NoError(t, err)
+	assert.Equal(t, mockTarget, addr)
+
+	sol, err := ResolveCoin("multi.eth", CoinTypeSolana, client)
+	require.NoError(t, err)
+	assert.Equal(t, "11111111111111111111111111111111", sol)
+
+	_, err = ResolveCoin("multi.eth", CoinTypeSUI, client)
+	assert.ErrorContains(t, err, "no address record")
+}
+
+// offchainLookupRevert hand-encodes OffchainLookup(sender, [url], callData, callback, extraData).
+func offchainLookupRevert(sender, url string, callData, callback, extraData []byte) []byte {
+	enc := func(b []byte) []byte { return encodeCall("00000000", dyn(b))[4+32:] } // length + padded data
+	urls := append(word(big.NewInt(1).Bytes()).word, word(big.NewInt(32).Bytes()).word...)
+	urls = append(urls, enc([]byte(url))...)
+
+	head := 5 * 32
+	offURLs := head
+	offCall := offURLs + len(urls)
+	offExtra := offCall + len(enc(callData))
+
+	out, _ := hex.DecodeString(selOffchainLookup)
+	out = append(out, addrWord(sender)...)
+	out = append(out, word(big.NewInt(int64(offURLs)).Bytes()).word...)
+	out = append(out, word(big.NewInt(int64(offCall)).Bytes()).word...)
+	out = append(out, fixedBytes(callback).word...)
+	out = append(out, word(big.NewInt(int64(offExtra)).Bytes()).word...)
+	out = append(out, urls...)
+	out = append(out, enc(callData)...)
+	return append(out, enc(extraData)...)
+}
+
+func TestResolve_WildcardCCIPRead(t *testing.T) {
+	callback, _ := hex.DecodeString("f4d4d2f8")
+	extra := []byte("extra")
+	var gatewayHits int
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gatewayHits++
+		assert.Contains(t, r.URL.Path, mockResolver)
+		// Answer with the ABI-encoded resolve() result: bytes(addr word).
+		resp := abiReturn(addrWord(mockTarget))
+		json.NewEncoder(w).Encode(map[string]string{"data": "0x" + hex.EncodeToString(resp)}) //nolint:errcheck
+	}))
+	defer gateway.Close()
+
+	srv := ensContractMock(t, func(to string, data []byte) callResult {
+		if to == strings.ToLower(registryAddr) {
+			// Only the parent has a resolver: wildcard.
+			return registryHandler(map[string]string{hex.EncodeToString(nodeOf("cb.id")): mockResolver}, data)
+		}
+		switch hex.EncodeToString(data[:4]) {
+		case selResolve:
+			dns, _ := decodeBytes(data[4:], 0)
+			assert.Equal(t, "\x05alice\x02cb\x02id\x00", string(dns))
+			inner, _ := decodeBytes(data[4:], 1)
+			assert.Equal(t, selAddr, hex.EncodeToString(inner[:4]))
+			return callResult{revert: offchainLookupRevert(mockResolver, gateway.URL+"/{sender}/{data}.json", inner, callback, extra)}
+		case hex.EncodeToString(callback):
+			resp, _ := decodeBytes(data[4:], 0)
+			gotExtra, _ := decodeBytes(data[4:], 1)
+			assert.Equal(t, extra, gotExtra)
+			return callResult{result: resp}
+		}
+		return callResult{failure: true}
+	})
+	defer srv.Close()
+
+	addr, err := Resolve("alice.cb.id", chain.NewEVMClient(srv.URL))
+	require.NoError(t, err)
+	assert.Equal(t, mockTarget, addr)
+	assert.Equal(t, 1, gatewayHits)
+}
+
+func TestCCIPCall_RejectsForeignSender(t *testing.T) {
+	srv := ensContractMock(t, func(to string, data []byte) callResult {
+		return callResult{revert: offchainLookupRevert(mockTarget, "http://127.0.0.1:1/{data}", nil, []byte{1, 2, 3, 4}, nil)}
+	})
+	defer srv.Close()
+
+	_, err := ccipCall(chain.NewEVMClient(srv.URL), mockResolver, []byte{0, 0, 0, 0})
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestPrimaryName_ForwardMismatch(t *testing.T) {
+	const claimer = "0x1234567890abcdef1234567890abcdef12345678"
+	reverseNode := hex.EncodeToString(nodeOf(strings.TrimPrefix(claimer, "0x") + ".addr.reverse"))
+
+	srv := ensContractMock(t, func(to string, data []byte) callResult {
+		if to == strings.ToLower(registryAddr) {
+			return registryHandler(map[string]string{
+				reverseNode: mockResolver,
+				hex.EncodeToString(nodeOf("vitalik.eth")): mockResolver,
+			}, data)
+		}
+		switch hex.EncodeToString(data[:4]) {
+		case selName:
+			return callResult{result: abiReturn([]byte("vitalik.eth"))}
+		case selAddr:
+			return callResult{result: addrWord(mockTarget)}
+		}
+		return callResult{failure: true}
+	})
+	defer srv.Close()
+
+	client := chain.NewEVMClient(srv.URL)
+	name, err := ReverseLookup(claimer, client)
+	require.NoError(t, err)
+	assert.Equal(t, "vitalik.eth", name)
+
+	_, err = PrimaryName(claimer, client)
+	require.ErrorIs(t, err, ErrUnverified)
+	assert.Contains(t, err.Error(), mockTarget)
+
+	assert.NoError(t, VerifyReverse(mockTarget, "vitalik.eth", client))
+	assert.ErrorIs(t, VerifyReverse(mockTarget, "Vitalik.eth", client), ErrUnverified)
+}
@@ -1,6 +1,8 @@
 package ens
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,73 +13,160 @@ import (
 // ENS Registry address — same on Ethereum mainnet and Sepolia.
 const registryAddr = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
 
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// errNoResolver is returned by findResolver when neither the name nor any of
+// its parents has a resolver.
+var errNoResolver = errors.New("no resolver")
+
 // Resolve resolves an ENS name to an address.
-// It queries the ENS registry for the resolver, then calls addr(bytes32) on it.
+// The name is normalized (ENSIP-15) first, so "Vitalik.ETH" works.
 func Resolve(name string, client *chain.EVMClient) (string, error) {
-	node := Namehash(name)
-
-	// Step 1: Get resolver address from registry.
-	// resolver(bytes32) = 0x0178b8bf
-	resolverCalldata := "0x0178b8bf" + node
-	resolverResult, err := client.CallContract(registryAddr, resolverCalldata)
+	norm, err := Normalize(name)
 	if err != nil {
-		return "", fmt.Errorf("querying ENS registry: %w", err)
+		return "", err
 	}
-
-	resolverAddr := parseAddress(resolverResult)
-	if resolverAddr == "" || resolverAddr == "0x0000000000000000000000000000000000000000" {
-		return "", fmt.Errorf("no resolver set for %q", name)
-	}
-
-	// Step 2: Call addr(bytes32) on the resolver.
-	// addr(bytes32) = 0x3b3b57de
-	addrCalldata := "0x3b3b57de" + node
-	addrResult, err := client.CallContract(resolverAddr, addrCalldata)
+	out, err := query(norm, client, selAddr)
 	if err != nil {
-		return "", fmt.Errorf("querying ENS resolver: %w", err)
+		return "", err
 	}
 
-	resolved := parseAddress(addrResult)
-	if resolved == "" || resolved == "0x0000000000000000000000000000000000000000" {
-		return "", fmt.Errorf("no address record for %q", name)
+	resolved := parseAddress(hex.EncodeToString(out))
+	if resolved == "" || resolved == zeroAddress {
+		return "", fmt.Errorf("no address record for %q", norm)
 	}
-
 	return resolved, nil
 }
 
-// ReverseLookup resolves an address to an ENS name.
-// It uses the addr.reverse registry.
+// ReverseLookup returns the name in address's reverse record. Anyone can
+// claim any name there, so callers showing it as the address's identity
+// should use PrimaryName, which forward-checks it.
 func ReverseLookup(address string, client *chain.EVMClient) (string, error) {
 	clean := strings.ToLower(strings.TrimPrefix(address, "0x"))
 	reverseName := clean + ".addr.reverse"
-	node := Namehash(reverseName)
+	node, _ := hex.DecodeString(Namehash(reverseName))
 
-	// Step 1: Get resolver from registry.
-	resolverCalldata := "0x0178b8bf" + node
-	resolverResult, err := client.CallContract(registryAddr, resolverCalldata)
+	// Reverse records are set per address, so no wildcard walk.
+	out, err := resolveRecord(reverseName, encodeCall(selName, word(node)), false, client)
+	if errors.Is(err, errNoResolver) {
+		return "", fmt.Errorf("no reverse record for %s", address)
+	}
 	if err != nil {
-		return "", fmt.Errorf("querying reverse registry: %w", err)
+		return "", err
 	}
 
-	resolverAddr := parseAddress(resolverResult)
-	if resolverAddr == "" || resolverAddr == "0x0000000000000000000000000000000000000000" {
-		return "", fmt.Errorf("no reverse record for %s", address)
+	name, err := decodeBytes(out, 0)
+	if err != nil || len(name) == 0 {
+		return "", fmt.Errorf("no reverse name for %s", address)
 	}
+	return string(name), nil
+}
 
-	// Step 2: Call name(bytes32) on the resolver.
-	// name(bytes32) = 0x691f3431
-	nameCalldata := "0x691f3431" + node
-	nameResult, err := client.CallContract(resolverAddr, nameCalldata)
+// ErrUnverified marks a reverse record that doesn't hold up: the name isn't
+// normalized or doesn't resolve back to the address.
+var ErrUnverified = errors.New("reverse record not verified")
+
+// PrimaryName returns address's primary ENS name: its reverse record, but
+// only after VerifyReverse has checked it.
+func PrimaryName(address string, client *chain.EVMClient) (string, error) {
+	name, err := ReverseLookup(address, client)
 	if err != nil {
-		return "", fmt.Errorf("querying reverse resolver: %w", err)
+		return "", err
+	}
+	if err := VerifyReverse(address, name, client); err != nil {
+		return "", err
 	}
+	return name, nil
+}
 
-	name := decodeString(nameResult)
-	if name == "" {
-		return "", fmt.Errorf("no reverse name for %s", address)
+// VerifyReverse checks that name, read from address's reverse record, is
+// normalized and resolves forward to address. Failures wrap ErrUnverified.
+func VerifyReverse(address, name string, client *chain.EVMClient) error {
+	norm, err := Normalize(name)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a valid name: %v", ErrUnverified, name, err)
+	}
+	if norm != name {
+		return fmt.Errorf("%w: %q is not normalized (should be %q)", ErrUnverified, name, norm)
+	}
+	fwd, err := Resolve(norm, client)
+	if err != nil {
+		return fmt.Errorf("%w: %q does not resolve: %v", ErrUnverified, name, err)
+	}
+	if !strings.EqualFold(fwd, address) {
+		return fmt.Errorf("%w: %q resolves to %s", ErrUnverified, name, fwd)
 	}
+	return nil
+}
 
-	return name, nil
+// findResolver returns the resolver for name. When name has none and walk is
+// set it walks up to its parents (ENSIP-10 wildcard resolution), stopping at
+// second-level names — TLDs don't carry wildcard resolvers. wildcard reports
+// whether the resolver was found on a parent.
+func findResolver(name string, walk bool, client *chain.EVMClient) (resolver string, wildcard bool, err error) {
+	labels := strings.Split(name, ".")
+	for i := 0; i == 0 || (walk && len(labels)-i >= 2); i++ {
+		node := Namehash(strings.Join(labels[i:], "."))
+		result, err := client.CallContract(registryAddr, "0x"+selResolver+node)
+		if err != nil {
+			return "", false, fmt.Errorf("querying ENS registry: %w", err)
+		}
+		if addr := parseAddress(result); addr != "" && addr != zeroAddress {
+			return addr, i > 0, nil
+		}
+	}
+	return "", false, errNoResolver
+}
+
+// resolveRecord runs calldata (a resolver function over name's node) against
+// name's resolver, found as findResolver does. Wildcard resolvers get it wrapped in resolve(bytes,bytes),
+// and offchain resolvers are followed through CCIP-Read.
+func resolveRecord(name string, calldata []byte, walk bool, client *chain.EVMClient) ([]byte, error) {
+	resolver, wildcard, err := findResolver(name, walk, client)
+	if err != nil {
+		return nil, err
+	}
+	if wildcard {
+		dns, err := dnsEncode(name)
+		if err != nil {
+			return nil, err
+		}
+		calldata = encodeCall(selResolve, dyn(dns), dyn(calldata))
+	}
+
+	out, err := ccipCall(client, resolver, calldata)
+	if err != nil {
+		return nil, fmt.Errorf("querying ENS resolver: %w", err)
+	}
+	if wildcard {
+		if out, err = decodeBytes(out, 0); err != nil {
+			return nil, fmt.Errorf("decoding resolve() result: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// query calls selector(node, args...) on the resolver of the normalized name.
+func query(name string, client *chain.EVMClient, selector string, args ...abiArg) ([]byte, error) {
+	node, _ := hex.DecodeString(Namehash(name))
+	out, err := resolveRecord(name, encodeCall(selector, append([]abiArg{word(node)}, args...)...), true, client)
+	if errors.Is(err, errNoResolver) {
+		return nil, fmt.Errorf("no resolver set for %q", name)
+	}
+	return out, err
+}
+
+// dnsEncode encodes name in DNS wire format, as resolve(bytes,bytes) expects.
+func dnsEncode(name string) ([]byte, error) {
+	var out []byte
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 255 {
+			return nil, fmt.Errorf("label too long to DNS-encode: %q", label)
+		}
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	return append(out, 0), nil
 }
 
 // Namehash implements EIP-137 namehash algorithm.
@@ -125,7 +214,7 @@ func parseAddress(hexResult string) string {
 		}
 	}
 	if allZero {
-		return "0x0000000000000000000000000000000000000000"
+		return zeroAddress
 	}
 	return "0x" + addr
 }
@@ -0,0 +1,340 @@
+// Package monitor evaluates alert rules against a chain by polling new blocks,
+// event logs and balances, and hands fired alerts to a Dispatcher.
+package monitor
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+)
+
+// Client is the subset of chain.EVMClient the monitor needs.
+type Client interface {
+	GetBlockNumber() (uint64, error)
+	GetBlockTransactions(blockNum uint64) ([]*chain.Transaction, error)
+	GetLogs(address string, topics []string, fromBlock, toBlock string) ([]chain.LogEntry, error)
+	GetBalance(address string) (*chain.Balance, error)
+}
+
+// maxBlocksPerPoll bounds how far a single poll will catch up after an RPC
+// outage, so one poll never scans thousands of blocks.
+const maxBlocksPerPoll = 50
+
+// Watcher evaluates all rules for one network.
+type Watcher struct {
+	Network  string
+	Currency string
+	// EventNames maps topic hashes to display names (optional).
+	EventNames map[string]string
+
+	client    Client
+	rules     []Rule
+	lastBlock uint64
+	anchored  bool
+	firing    map[string]bool
+	// emitted holds the keys of tx and event alerts already returned for
+	// blocks after lastBlock, so a poll that failed partway and retries the
+	// range does not return them twice.
+	emitted map[string]uint64
+	now     func() time.Time
+}
+
+// NewWatcher creates a watcher for the rules that target network.
+func NewWatcher(network, currency string, client Client, rules []Rule) *Watcher {
+	var mine []Rule
+	for _, r := range rules {
+		if r.Network == network {
+			mine = append(mine, r)
+		}
+	}
+	return &Watcher{
+		Network:  network,
+		Currency: currency,
+		client:   client,
+		rules:    mine,
+		firing:   make(map[string]bool),
+		emitted:  make(map[string]uint64),
+		now:      time.Now,
+	}
+}
+
+// Rules returns the rules evaluated by this watcher.
+func (w *Watcher) Rules() []Rule { return w.rules }
+
+// LastBlock returns the last block fully evaluated.
+func (w *Watcher) LastBlock() uint64 { return w.lastBlock }
+
+// Poll evaluates every rule once. The first call anchors to the current block
+// so history is not replayed; later calls scan blocks since the last poll.
+func (w *Watcher) Poll() ([]Alert, error) {
+	var alerts []Alert
+
+	for i := range w.rules {
+		if w.rules[i].Kind() != KindBalance {
+			continue
+		}
+		a, err := w.checkBalance(&w.rules[i])
+		if err != nil {
+			return alerts, fmt.Errorf("rule %q: %w", w.rules[i].Name, err)
+		}
+		if a != nil {
+			alerts = append(alerts, *a)
+		}
+	}
+
+	if !w.needsBlocks() {
+		return alerts, nil
+	}
+
+	latest, err := w.client.GetBlockNumber()
+	if err != nil {
+		return alerts, fmt.Errorf("getting block number: %w", err)
+	}
+	if !w.anchored {
+		w.lastBlock = latest
+		w.anchored = true
+		return alerts, nil
+	}
+	if latest <= w.lastBlock {
+		return alerts, nil
+	}
+
+	from := w.lastBlock + 1
+	to := latest
+	if to-from+1 > maxBlocksPerPoll {
+		to = from + maxBlocksPerPoll - 1
+	}
+
+	txAlerts, err := w.scanTxs(from, to)
+	alerts = append(alerts, w.unseen(txAlerts)...)
+	if err != nil {
+		return alerts, err
+	}
+
+	evAlerts, err := w.scanEvents(from, to)
+	alerts = append(alerts, w.unseen(evAlerts)...)
+	if err != nil {
+		return alerts, err
+	}
+
+	w.lastBlock = to
+	for k, blk := range w.emitted {
+		if blk <= to {
+			delete(w.emitted, k)
+		}
+	}
+	return alerts, nil
+}
+
+// unseen drops alerts an earlier, failed poll of the same blocks already
+// returned, and records the rest.
+func (w *Watcher) unseen(alerts []Alert) []Alert {
+	out := alerts[:0]
+	for _, a := range alerts {
+		if _, ok := w.emitted[a.Key]; ok {
+			continue
+		}
+		w.emitted[a.Key] = a.Block
+		out = append(out, a)
+	}
+	return out
+}
+
+func (w *Watcher) needsBlocks() bool {
+	for i := range w.rules {
+		if w.rules[i].Kind() != KindBalance {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBalance fires once when a balance crosses into the alerting range and
+// re-arms when it leaves it, so a drained wallet does not page every poll.
+func (w *Watcher) checkBalance(r *Rule) (*Alert, error) {
+	bal, err := w.client.GetBalance(r.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason string
+	if r.BalanceBelow != "" {
+		limit, _ := parseUnits(r.BalanceBelow)
+		if bal.Wei.Cmp(limit) < 0 {
+			reason = fmt.Sprintf("balance %s %s is below %s", trimAmount(bal.ETH), w.Currency, r.BalanceBelow)
+		}
+	}
+	if reason == "" && r.BalanceAbove != "" {
+		limit, _ := parseUnits(r.BalanceAbove)
+		if bal.Wei.Cmp(limit) > 0 {
+			reason = fmt.Sprintf("balance %s %s is above %s", trimAmount(bal.ETH), w.Currency, r.BalanceAbove)
+		}
+	}
+
+	if reason == "" {
+		w.firing[r.Name] = false
+		return nil, nil
+	}
+	if w.firing[r.Name] {
+		return nil, nil
+	}
+	w.firing[r.Name] = true
+
+	a := w.alert(r, reason)
+	a.Key = r.Name + "|balance"
+	return &a, nil
+}
+
+func (w *Watcher) scanTxs(from, to uint64) ([]Alert, error) {
+	var txRules []*Rule
+	for i := range w.rules {
+		if w.rules[i].Kind() == KindTx {
+			txRules = append(txRules, &w.rules[i])
+		}
+	}
+	if len(txRules) == 0 {
+		return nil, nil
+	}
+
+	var alerts []Alert
+	for blk := from; blk <= to; blk++ {
+		txs, err := w.client.GetBlockTransactions(blk)
+		if err != nil {
+			return alerts, fmt.Errorf("fetching block %d: %w", blk, err)
+		}
+		for _, tx := range txs {
+			for _, r := range txRules {
+				if msg, ok := matchTx(r, tx, w.Currency); ok {
+					a := w.alert(r, msg)
+					a.TxHash = tx.Hash
+					a.Block = blk
+					a.Key = r.Name + "|" + tx.Hash
+					alerts = append(alerts, a)
+				}
+			}
+		}
+	}
+	return alerts, nil
+}
+
+func (w *Watcher) scanEvents(from, to uint64) ([]Alert, error) {
+	var alerts []Alert
+	for i := range w.rules {
+		r := &w.rules[i]
+		if r.Kind() != KindEvent {
+			continue
+		}
+		topic := r.Topic()
+		logs, err := w.client.GetLogs(r.Address, []string{topic}, fmt.Sprintf("0x%x", from), fmt.Sprintf("0x%x", to))
+		if err != nil {
+			return alerts, fmt.Errorf("rule %q: querying logs: %w", r.Name, err)
+		}
+
+		name := r.Event
+		if n, ok := w.EventNames[topic]; ok {
+			name = n
+		}
+		for _, log := range logs {
+			var blk uint64
+			if bn, ok := new(big.Int).SetString(strings.TrimPrefix(log.BlockNumber, "0x"), 16); ok {
+				blk = bn.Uint64()
+			}
+			a := w.alert(r, fmt.Sprintf("%s emitted %s", r.Address, name))
+			a.TxHash = log.TxHash
+			a.Block = blk
+			a.Key = r.Name + "|" + log.TxHash + "|" + log.LogIndex
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts, nil
+}
+
+// matchTx reports whether tx satisfies a tx rule and describes why.
+func matchTx(r *Rule, tx *chain.Transaction, currency string) (string, bool) {
+	isFrom := strings.EqualFold(tx.From, r.Address)
+	isTo := strings.EqualFold(tx.To, r.Address)
+
+	switch strings.ToLower(r.Direction) {
+	case "in":
+		if !isTo {
+			return "", false
+		}
+	case "out":
+		if !isFrom {
+			return "", false
+		}
+	default:
+		if !isFrom && !isTo {
+			return "", false
+		}
+	}
+
+	if sel := r.Selector(); sel != "" {
+		input := strings.ToLower(tx.Input)
+		if len(input) < 10 || input[:10] != sel {
+			return "", false
+		}
+	}
+
+	if r.ValueAbove != "" {
+		limit, _ := parseUnits(r.ValueAbove)
+		if tx.Value == nil || tx.Value.Cmp(limit) <= 0 {
+			return "", false
+		}
+	}
+
+	dir := "sent"
+	counterpart := tx.To
+	if isTo && !isFrom {
+		dir = "received"
+		counterpart = tx.From
+	}
+	if counterpart == "" {
+		counterpart = "contract creation"
+	}
+
+	msg := fmt.Sprintf("%s %s %s", r.Address, dir, trimAmount(tx.ValueETH))
+	if currency != "" {
+		msg += " " + currency
+	}
+	if dir == "sent" {
+		msg += " to " + counterpart
+	} else {
+		msg += " from " + counterpart
+	}
+	if r.Method != "" {
+		msg += " via " + r.Method
+	}
+	return msg, true
+}
+
+func (w *Watcher) alert(r *Rule, msg string) Alert {
+	sev := r.Severity
+	if sev == "" {
+		sev = "warn"
+	}
+	return Alert{
+		Rule:     r.Name,
+		Kind:     r.Kind(),
+		Severity: sev,
+		Network:  w.Network,
+		Address:  r.Address,
+		Message:  msg,
+		Time:     w.now(),
+	}
+}
+
+// trimAmount drops trailing zeros from a fixed-point decimal string.
+func trimAmount(s string) string {
+	if s == "" {
+		return "0"
+	}
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	return s
+}
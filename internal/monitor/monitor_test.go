@@ -0,0 +1,327 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mohsinsiddi/w3cli/internal/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const (
+	hotWallet = "0x1111111111111111111111111111111111111111"
+	token     = "0x2222222222222222222222222222222222222222"
+	stranger  = "0x3333333333333333333333333333333333333333"
+)
+
+type fakeClient struct {
+	block    uint64
+	balance  *big.Int
+	blockTxs map[uint64][]*chain.Transaction
+	logs     []chain.LogEntry
+	topics   []string
+	logsErr  error
+}
+
+func (f *fakeClient) GetBlockNumber() (uint64, error) { return f.block, nil }
+
+func (f *fakeClient) GetBlockTransactions(n uint64) ([]*chain.Transaction, error) {
+	return f.blockTxs[n], nil
+}
+
+func (f *fakeClient) GetLogs(_ string, topics []string, _, _ string) ([]chain.LogEntry, error) {
+	if f.logsErr != nil {
+		return nil, f.logsErr
+	}
+	f.topics = topics
+	out := f.logs
+	f.logs = nil
+	return out, nil
+}
+
+func (f *fakeClient) GetBalance(string) (*chain.Balance, error) {
+	return &chain.Balance{Wei: f.balance, ETH: chain.WeiToETH(f.balance)}, nil
+}
+
+func eth(s string) *big.Int {
+	v, _ := parseUnits(s)
+	return v
+}
+
+// ---------------------------------------------------------------------------
+// Watcher
+// ---------------------------------------------------------------------------
+
+func TestWatcherBalanceFiresOnceAndRearms(t *testing.T) {
+	fc := &fakeClient{balance: eth("1")}
+	w := NewWatcher("base", "ETH", fc, []Rule{{Name: "low", Network: "base", Address: hotWallet, BalanceBelow: "0.5"}})
+
+	alerts, err := w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+
+	fc.balance = eth("0.1")
+	alerts, err = w.Poll()
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Contains(t, alerts[0].Message, "0.1 ETH is below 0.5")
+
+	// Still low: no repeat.
+	alerts, _ = w.Poll()
+	assert.Empty(t, alerts)
+
+	// Recovers, then drops again: fires again.
+	fc.balance = eth("2")
+	alerts, _ = w.Poll()
+	assert.Empty(t, alerts)
+	fc.balance = eth("0.2")
+	alerts, _ = w.Poll()
+	assert.Len(t, alerts, 1)
+}
+
+func TestWatcherIgnoresOtherNetworks(t *testing.T) {
+	w := NewWatcher("base", "ETH", &fakeClient{}, []Rule{
+		{Name: "a", Network: "base", Address: hotWallet, Direction: "out"},
+		{Name: "b", Network: "ethereum", Address: hotWallet, Direction: "out"},
+	})
+	require.Len(t, w.Rules(), 1)
+	assert.Equal(t, "a", w.Rules()[0].Name)
+}
+
+func TestWatcherTxRules(t *testing.T) {
+	fc := &fakeClient{block: 100, blockTxs: map[uint64][]*chain.Transaction{
+		101: {
+			{Hash: "0xaa", From: hotWallet, To: stranger, Value: eth("3"), ValueETH: "3.000000000000000000"},
+			{Hash: "0xbb", From: stranger, To: hotWallet, Value: eth("20"), ValueETH: "20.000000000000000000"},
+			{Hash: "0xcc", From: hotWallet, To: token, Value: big.NewInt(0), ValueETH: "0", Input: "0xa9059cbb0000"},
+		},
+	}}
+	w := NewWatcher("base", "ETH", fc, []Rule{
+		{Name: "any-out", Network: "base", Address: hotWallet, Direction: "out"},
+		{Name: "big-in", Network: "base", Address: hotWallet, Direction: "in", ValueAbove: "10"},
+		{Name: "transfers", Network: "base", Address: hotWallet, Method: "transfer(address,uint256)"},
+	})
+
+	// First poll anchors.
+	alerts, err := w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+	assert.Equal(t, uint64(100), w.LastBlock())
+
+	fc.block = 101
+	alerts, err = w.Poll()
+	require.NoError(t, err)
+
+	got := map[string][]string{}
+	for _, a := range alerts {
+		got[a.Rule] = append(got[a.Rule], a.TxHash)
+		assert.Equal(t, uint64(101), a.Block)
+	}
+	assert.Equal(t, []string{"0xaa", "0xcc"}, got["any-out"])
+	assert.Equal(t, []string{"0xbb"}, got["big-in"])
+	assert.Equal(t, []string{"0xcc"}, got["transfers"])
+	assert.Equal(t, uint64(101), w.LastBlock())
+}
+
+func TestWatcherEventRule(t *testing.T) {
+	fc := &fakeClient{block: 10}
+	r := Rule{Name: "paused", Network: "base", Address: token, Event: "Paused(address)", Severity: "critical"}
+	w := NewWatcher("base", "ETH", fc, []Rule{r})
+	w.EventNames = map[string]string{r.Topic(): "Paused"}
+
+	_, err := w.Poll()
+	require.NoError(t, err)
+
+	fc.block = 12
+	fc.logs = []chain.LogEntry{{Address: token, BlockNumber: "0xc", TxHash: "0xdead", LogIndex: "0x0"}}
+	alerts, err := w.Poll()
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, []string{r.Topic()}, fc.topics)
+	assert.Equal(t, "critical", alerts[0].Severity)
+	assert.Equal(t, uint64(12), alerts[0].Block)
+	assert.Contains(t, alerts[0].Message, "emitted Paused")
+	assert.Equal(t, "paused|0xdead|0x0", alerts[0].Key)
+}
+
+func TestWatcherRetryAfterFailedScanDoesNotRepeat(t *testing.T) {
+	fc := &fakeClient{block: 100, blockTxs: map[uint64][]*chain.Transaction{
+		101: {{Hash: "0xaa", From: hotWallet, To: stranger, Value: eth("1"), ValueETH: "1"}},
+	}}
+	w := NewWatcher("base", "ETH", fc, []Rule{
+		{Name: "out", Network: "base", Address: hotWallet, Direction: "out"},
+		{Name: "paused", Network: "base", Address: token, Event: "Paused(address)"},
+	})
+	_, err := w.Poll()
+	require.NoError(t, err)
+
+	// The tx scan succeeds, the log query fails: the block is retried.
+	fc.block = 101
+	fc.logsErr = errors.New("rpc down")
+	alerts, err := w.Poll()
+	require.Error(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, uint64(100), w.LastBlock())
+
+	fc.logsErr = nil
+	fc.logs = []chain.LogEntry{{Address: token, BlockNumber: "0x65", TxHash: "0xbeef", LogIndex: "0x1"}}
+	alerts, err = w.Poll()
+	require.NoError(t, err)
+	require.Len(t, alerts, 1, "the tx alert is not emitted again")
+	assert.Equal(t, "paused", alerts[0].Rule)
+	assert.Equal(t, uint64(101), w.LastBlock())
+	assert.Empty(t, w.emitted, "keys are dropped once the range is done")
+}
+
+func TestWatcherCatchUpIsBounded(t *testing.T) {
+	fc := &fakeClient{block: 1}
+	w := NewWatcher("base", "ETH", fc, []Rule{{Name: "a", Network: "base", Address: hotWallet, Direction: "out"}})
+	_, _ = w.Poll()
+
+	fc.block = 1 + maxBlocksPerPoll*2
+	_, err := w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1+maxBlocksPerPoll), w.LastBlock())
+}
+
+// ---------------------------------------------------------------------------
+// Dispatcher
+// ---------------------------------------------------------------------------
+
+type recordNotifier struct {
+	got []Alert
+	err error
+}
+
+func (r *recordNotifier) Name() string { return "record" }
+func (r *recordNotifier) Notify(_ context.Context, a Alert) error {
+	r.got = append(r.got, a)
+	return r.err
+}
+
+func TestDispatcherDedup(t *testing.T) {
+	rec := &recordNotifier{}
+	d := NewDispatcher([]Notifier{rec}, time.Minute, 0, 0)
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	a := Alert{Rule: "r", Key: "r|0xaa"}
+	assert.True(t, d.Dispatch(context.Background(), a))
+	assert.False(t, d.Dispatch(context.Background(), a))
+
+	now = now.Add(2 * time.Minute)
+	assert.True(t, d.Dispatch(context.Background(), a))
+	assert.Len(t, rec.got, 2)
+}
+
+func TestDispatcherRateLimit(t *testing.T) {
+	rec := &recordNotifier{}
+	d := NewDispatcher([]Notifier{rec}, time.Minute, 2, time.Minute)
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		d.Dispatch(context.Background(), Alert{Rule: "r", Key: k})
+	}
+	assert.Len(t, rec.got, 2)
+	assert.Equal(t, 2, d.Suppressed())
+
+	now = now.Add(61 * time.Second)
+	assert.True(t, d.Dispatch(context.Background(), Alert{Rule: "r", Key: "e"}))
+}
+
+func TestDispatcherErrorDoesNotBlockOtherSinks(t *testing.T) {
+	bad := &recordNotifier{err: errors.New("boom")}
+	good := &recordNotifier{}
+	d := NewDispatcher([]Notifier{bad, good}, time.Minute, 0, 0)
+
+	var failures int
+	d.OnError(func(Notifier, error) { failures++ })
+
+	assert.True(t, d.Dispatch(context.Background(), Alert{Rule: "r", Key: "x"}))
+	assert.Equal(t, 1, failures)
+	assert.Len(t, good.got, 1)
+}
+
+// ---------------------------------------------------------------------------
+// Notifiers
+// ---------------------------------------------------------------------------
+
+func TestWriterNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewWriterNotifier("buf", &buf)
+	err := n.Notify(context.Background(), Alert{Rule: "low", Severity: "warn", Network: "base", Message: "balance low", TxHash: "0xaa", Time: time.Unix(0, 0).UTC()})
+	require.NoError(t, err)
+	assert.Equal(t, "1970-01-01T00:00:00Z [WARN] base low: balance low tx=0xaa\n", buf.String())
+}
+
+func TestFileNotifierAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.log")
+	n, err := NewNotifier(SinkSpec{Type: "file", Path: path})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), Alert{Rule: "a", Message: "one"}))
+	require.NoError(t, n.Notify(context.Background(), Alert{Rule: "b", Message: "two"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"rule":"b"`)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body) //nolint:errcheck
+		body = buf.String()
+		auth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	t.Setenv("W3CLI_TEST_TOKEN", "secret")
+	n, err := NewNotifier(SinkSpec{Type: "webhook", URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer $W3CLI_TEST_TOKEN"}})
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), Alert{Rule: "paused", Message: "token paused"}))
+
+	assert.Contains(t, body, `"rule":"paused"`)
+	assert.Contains(t, body, `"text":`)
+	assert.Equal(t, "Bearer secret", auth)
+}
+
+func TestWebhookNotifierHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n, err := NewNotifier(SinkSpec{Type: "webhook", URL: srv.URL})
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), Alert{Rule: "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 500")
+}
+
+func TestNewNotifierErrors(t *testing.T) {
+	_, err := NewNotifier(SinkSpec{Type: "file"})
+	assert.Error(t, err)
+	_, err = NewNotifier(SinkSpec{Type: "webhook"})
+	assert.Error(t, err)
+	_, err = NewNotifier(SinkSpec{Type: "pager"})
+	assert.Error(t, err)
+}
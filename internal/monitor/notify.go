@@ -0,0 +1,283 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alert is a single fired rule condition.
+type Alert struct {
+	Rule     string    `json:"rule"`
+	Kind     string    `json:"kind"`
+	Severity string    `json:"severity"`
+	Network  string    `json:"network"`
+	Address  string    `json:"address"`
+	Message  string    `json:"message"`
+	TxHash   string    `json:"tx_hash,omitempty"`
+	Block    uint64    `json:"block,omitempty"`
+	Time     time.Time `json:"time"`
+
+	// Key identifies the underlying occurrence for deduplication
+	// (e.g. rule + tx hash + log index). Not serialised.
+	Key string `json:"-"`
+}
+
+// String renders the alert as a single log line.
+func (a Alert) String() string {
+	line := fmt.Sprintf("%s [%s] %s %s: %s", a.Time.Format(time.RFC3339), strings.ToUpper(a.Severity), a.Network, a.Rule, a.Message)
+	if a.TxHash != "" {
+		line += " tx=" + a.TxHash
+	}
+	return line
+}
+
+// Notifier delivers alerts to a single destination.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NewNotifier builds a notifier from its spec.
+func NewNotifier(spec SinkSpec) (Notifier, error) {
+	switch strings.ToLower(spec.Type) {
+	case "stdout", "":
+		return &WriterNotifier{name: "stdout", w: os.Stdout}, nil
+	case "file":
+		if spec.Path == "" {
+			return nil, fmt.Errorf("file sink requires a path")
+		}
+		return &FileNotifier{path: spec.Path}, nil
+	case "webhook":
+		if spec.URL == "" {
+			return nil, fmt.Errorf("webhook sink requires a url")
+		}
+		return &WebhookNotifier{
+			url:     spec.URL,
+			headers: spec.Headers,
+			client:  &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	case "desktop":
+		return &DesktopNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q (use stdout, file, webhook or desktop)", spec.Type)
+	}
+}
+
+// WriterNotifier writes one line per alert to an io.Writer.
+type WriterNotifier struct {
+	name string
+	w    io.Writer
+}
+
+// NewWriterNotifier creates a notifier that writes alert lines to w.
+func NewWriterNotifier(name string, w io.Writer) *WriterNotifier {
+	return &WriterNotifier{name: name, w: w}
+}
+
+func (n *WriterNotifier) Name() string { return n.name }
+
+func (n *WriterNotifier) Notify(_ context.Context, a Alert) error {
+	_, err := fmt.Fprintln(n.w, a.String())
+	return err
+}
+
+// FileNotifier appends alerts as JSON lines to a local file.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (n *FileNotifier) Name() string { return "file:" + n.path }
+
+func (n *FileNotifier) Notify(_ context.Context, a Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// WebhookNotifier POSTs each alert as JSON to a generic HTTP endpoint.
+// The payload includes a "text" field so Slack/Discord-style incoming
+// webhooks render something useful without a custom adapter.
+type WebhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	payload := struct {
+		Alert
+		Text string `json:"text"`
+	}{a, a.String()}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DesktopNotifier shows a native desktop notification.
+type DesktopNotifier struct{}
+
+func (n *DesktopNotifier) Name() string { return "desktop" }
+
+func (n *DesktopNotifier) Notify(_ context.Context, a Alert) error {
+	title := "w3cli · " + a.Rule
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", a.Message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(
+			"[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null; "+
+				"$n = New-Object System.Windows.Forms.NotifyIcon; $n.Icon = [System.Drawing.SystemIcons]::Information; "+
+				"$n.Visible = $true; $n.ShowBalloonTip(10000, '%s', '%s', 'Info')",
+			strings.ReplaceAll(title, "'", "''"), strings.ReplaceAll(a.Message, "'", "''"))
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		cmd = exec.Command("notify-send", title, a.Message)
+	}
+	return cmd.Run()
+}
+
+// Dispatcher fans alerts out to notifiers, suppressing duplicates seen within
+// the dedup window and dropping alerts beyond the rate limit.
+type Dispatcher struct {
+	notifiers []Notifier
+	dedup     time.Duration
+	rateMax   int
+	ratePer   time.Duration
+
+	mu         sync.Mutex
+	seen       map[string]time.Time
+	sent       []time.Time
+	suppressed int
+	now        func() time.Time
+	onErr      func(Notifier, error)
+}
+
+// NewDispatcher creates a dispatcher. rateMax == 0 disables rate limiting.
+func NewDispatcher(notifiers []Notifier, dedup time.Duration, rateMax int, ratePer time.Duration) *Dispatcher {
+	if ratePer <= 0 {
+		ratePer = time.Minute
+	}
+	return &Dispatcher{
+		notifiers: notifiers,
+		dedup:     dedup,
+		rateMax:   rateMax,
+		ratePer:   ratePer,
+		seen:      make(map[string]time.Time),
+		now:       time.Now,
+		onErr:     func(Notifier, error) {},
+	}
+}
+
+// OnError registers a callback for delivery failures. Delivery errors never
+// stop the monitor; a broken webhook must not silence the other sinks.
+func (d *Dispatcher) OnError(fn func(Notifier, error)) { d.onErr = fn }
+
+// Suppressed returns how many alerts were dropped by the rate limiter.
+func (d *Dispatcher) Suppressed() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.suppressed
+}
+
+// Dispatch delivers a to every notifier unless it is a duplicate or over the
+// rate limit. It reports whether the alert was delivered.
+func (d *Dispatcher) Dispatch(ctx context.Context, a Alert) bool {
+	if !d.admit(a) {
+		return false
+	}
+	for _, n := range d.notifiers {
+		if err := n.Notify(ctx, a); err != nil {
+			d.onErr(n, err)
+		}
+	}
+	return true
+}
+
+func (d *Dispatcher) admit(a Alert) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	key := a.Key
+	if key == "" {
+		key = a.Rule + "|" + a.Message
+	}
+
+	if last, ok := d.seen[key]; ok && now.Sub(last) < d.dedup {
+		return false
+	}
+
+	if d.rateMax > 0 {
+		cutoff := now.Add(-d.ratePer)
+		kept := d.sent[:0]
+		for _, t := range d.sent {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		d.sent = kept
+		if len(d.sent) >= d.rateMax {
+			d.suppressed++
+			return false
+		}
+		d.sent = append(d.sent, now)
+	}
+
+	d.seen[key] = now
+	for k, t := range d.seen {
+		if now.Sub(t) >= d.dedup {
+			delete(d.seen, k)
+		}
+	}
+	return true
+}
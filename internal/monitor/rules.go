@@ -0,0 +1,249 @@
+package monitor
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule kinds, derived from which condition fields a rule sets.
+const (
+	KindBalance = "balance"
+	KindEvent   = "event"
+	KindTx      = "tx"
+)
+
+// RulesFile is the on-disk structure of a monitor rules file (YAML or JSON).
+//
+//	interval: 15s
+//	dedup: 10m
+//	rate_limit: { max: 20, per: 1m }
+//	sinks:
+//	  - type: stdout
+//	  - type: webhook
+//	    url: https://hooks.example.com/w3cli
+//	rules:
+//	  - name: hot-wallet-low
+//	    network: base
+//	    address: 0xabc...
+//	    balance_below: "0.5"
+type RulesFile struct {
+	Interval  string     `yaml:"interval" json:"interval"`
+	Dedup     string     `yaml:"dedup" json:"dedup"`
+	RateLimit RateLimit  `yaml:"rate_limit" json:"rate_limit"`
+	Sinks     []SinkSpec `yaml:"sinks" json:"sinks"`
+	Rules     []Rule     `yaml:"rules" json:"rules"`
+}
+
+// RateLimit caps how many alerts are delivered per window.
+// Max == 0 disables rate limiting.
+type RateLimit struct {
+	Max int    `yaml:"max" json:"max"`
+	Per string `yaml:"per" json:"per"`
+}
+
+// SinkSpec configures a single alert destination.
+type SinkSpec struct {
+	Type    string            `yaml:"type" json:"type"` // stdout | file | webhook | desktop
+	Path    string            `yaml:"path" json:"path"`
+	URL     string            `yaml:"url" json:"url"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+}
+
+// Rule is a single monitoring condition on an address or contract.
+//
+// Exactly one kind of condition is allowed per rule:
+//   - balance: balance_below and/or balance_above (native units, e.g. "0.5")
+//   - event:   event signature ("Paused(address)") or topic hash
+//   - tx:      any of direction, method, value_above
+type Rule struct {
+	Name     string `yaml:"name" json:"name"`
+	Network  string `yaml:"network" json:"network"`
+	Address  string `yaml:"address" json:"address"`
+	Severity string `yaml:"severity" json:"severity"`
+
+	BalanceBelow string `yaml:"balance_below" json:"balance_below"`
+	BalanceAbove string `yaml:"balance_above" json:"balance_above"`
+
+	Event string `yaml:"event" json:"event"`
+
+	Direction  string `yaml:"direction" json:"direction"` // in | out | any
+	Method     string `yaml:"method" json:"method"`       // signature or 0x selector
+	ValueAbove string `yaml:"value_above" json:"value_above"`
+}
+
+// Kind returns the rule kind based on which condition fields are set.
+func (r *Rule) Kind() string {
+	switch {
+	case r.BalanceBelow != "" || r.BalanceAbove != "":
+		return KindBalance
+	case r.Event != "":
+		return KindEvent
+	default:
+		return KindTx
+	}
+}
+
+// Topic returns the keccak256 topic hash for an event rule. A rule may give
+// either a full signature or an already-hashed 0x topic.
+func (r *Rule) Topic() string {
+	if strings.HasPrefix(r.Event, "0x") && len(r.Event) == 66 {
+		return strings.ToLower(r.Event)
+	}
+	return "0x" + hex.EncodeToString(keccak([]byte(r.Event)))
+}
+
+// Selector returns the 4-byte method selector (0x-prefixed, lowercase) for a
+// tx rule's method filter, or "" when no method filter is set.
+func (r *Rule) Selector() string {
+	m := strings.TrimSpace(r.Method)
+	if m == "" {
+		return ""
+	}
+	if strings.HasPrefix(m, "0x") && len(m) == 10 {
+		return strings.ToLower(m)
+	}
+	return "0x" + hex.EncodeToString(keccak([]byte(m))[:4])
+}
+
+// Validate checks that the rule is complete and its thresholds parse.
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule has no name")
+	}
+	if r.Address == "" {
+		return fmt.Errorf("rule %q: address is required", r.Name)
+	}
+	for _, v := range []string{r.BalanceBelow, r.BalanceAbove, r.ValueAbove} {
+		if v == "" {
+			continue
+		}
+		if _, err := parseUnits(v); err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+	}
+	if r.Event != "" && (r.BalanceBelow != "" || r.BalanceAbove != "") {
+		return fmt.Errorf("rule %q: a rule can watch a balance or an event, not both", r.Name)
+	}
+	switch r.Kind() {
+	case KindEvent:
+		if !strings.HasPrefix(r.Event, "0x") && !strings.Contains(r.Event, "(") {
+			return fmt.Errorf("rule %q: event must be a signature like Paused(address) or a topic hash", r.Name)
+		}
+	case KindTx:
+		switch strings.ToLower(r.Direction) {
+		case "", "any", "in", "out":
+		default:
+			return fmt.Errorf("rule %q: direction must be in, out or any", r.Name)
+		}
+		if r.Direction == "" && r.Method == "" && r.ValueAbove == "" {
+			return fmt.Errorf("rule %q: no condition set (balance_below, event, method, value_above or direction)", r.Name)
+		}
+	}
+	return nil
+}
+
+// LoadRules reads and validates a rules file. JSON is accepted as a subset of YAML.
+func LoadRules(path string) (*RulesFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates rules file content.
+func ParseRules(data []byte) (*RulesFile, error) {
+	var rf RulesFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		return nil, fmt.Errorf("parsing rules file: %w", err)
+	}
+	if len(rf.Rules) == 0 {
+		return nil, fmt.Errorf("rules file has no rules")
+	}
+	seen := make(map[string]bool)
+	for i := range rf.Rules {
+		if err := rf.Rules[i].Validate(); err != nil {
+			return nil, err
+		}
+		if seen[rf.Rules[i].Name] {
+			return nil, fmt.Errorf("duplicate rule name %q", rf.Rules[i].Name)
+		}
+		seen[rf.Rules[i].Name] = true
+	}
+	for _, d := range []string{rf.Interval, rf.Dedup, rf.RateLimit.Per} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(d); err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", d, err)
+		}
+	}
+	if len(rf.Sinks) == 0 {
+		rf.Sinks = []SinkSpec{{Type: "stdout"}}
+	}
+	return &rf, nil
+}
+
+// IntervalOr returns the configured poll interval, or def when unset.
+func (rf *RulesFile) IntervalOr(def time.Duration) time.Duration {
+	return durationOr(rf.Interval, def)
+}
+
+// DedupWindow returns the deduplication window (default 10 minutes).
+func (rf *RulesFile) DedupWindow() time.Duration {
+	return durationOr(rf.Dedup, 10*time.Minute)
+}
+
+// Networks returns the distinct networks referenced by the rules, in order of
+// first appearance. Rules without a network use def.
+func (rf *RulesFile) Networks(def string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for i := range rf.Rules {
+		n := rf.Rules[i].Network
+		if n == "" {
+			n = def
+			rf.Rules[i].Network = def
+		}
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func durationOr(s string, def time.Duration) time.Duration {
+	if s == "" {
+		return def
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+// parseUnits converts a decimal amount in whole units (e.g. "0.5") to wei.
+func parseUnits(s string) (*big.Int, error) {
+	f, ok := new(big.Float).SetPrec(256).SetString(strings.TrimSpace(s))
+	if !ok || f.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	f.Mul(f, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)))
+	wei, _ := f.Int(nil)
+	return wei, nil
+}
+
+func keccak(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
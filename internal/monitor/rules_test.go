@@ -0,0 +1,102 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// ParseRules
+// ---------------------------------------------------------------------------
+
+func TestParseRulesYAML(t *testing.T) {
+	data := `
+interval: 20s
+dedup: 5m
+rate_limit:
+  max: 3
+  per: 1m
+sinks:
+  - type: file
+    path: /tmp/alerts.log
+rules:
+  - name: hot-low
+    network: base
+    address: "0x1111111111111111111111111111111111111111"
+    balance_below: "0.5"
+  - name: paused
+    address: "0x2222222222222222222222222222222222222222"
+    event: Paused(address)
+    severity: critical
+`
+	rf, err := ParseRules([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, rf.Rules, 2)
+	assert.Equal(t, KindBalance, rf.Rules[0].Kind())
+	assert.Equal(t, KindEvent, rf.Rules[1].Kind())
+	assert.Equal(t, 20*time.Second, rf.IntervalOr(time.Minute))
+	assert.Equal(t, 5*time.Minute, rf.DedupWindow())
+	assert.Equal(t, 3, rf.RateLimit.Max)
+	require.Len(t, rf.Sinks, 1)
+	assert.Equal(t, "file", rf.Sinks[0].Type)
+}
+
+func TestParseRulesJSON(t *testing.T) {
+	data := `{"rules":[{"name":"out","address":"0x1111111111111111111111111111111111111111","direction":"out"}]}`
+	rf, err := ParseRules([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, KindTx, rf.Rules[0].Kind())
+	// Default sink is stdout.
+	require.Len(t, rf.Sinks, 1)
+	assert.Equal(t, "stdout", rf.Sinks[0].Type)
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	cases := map[string]string{
+		"empty":        `rules: []`,
+		"no address":   `rules: [{name: a, direction: out}]`,
+		"no condition": `rules: [{name: a, address: "0x1"}]`,
+		"bad amount":   `rules: [{name: a, address: "0x1", balance_below: "abc"}]`,
+		"bad event":    `rules: [{name: a, address: "0x1", event: Paused}]`,
+		"bad dir":      `rules: [{name: a, address: "0x1", direction: sideways}]`,
+		"duplicate":    `rules: [{name: a, address: "0x1", direction: out}, {name: a, address: "0x2", direction: in}]`,
+		"bad interval": "interval: soon\nrules: [{name: a, address: \"0x1\", direction: out}]",
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRules([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNetworksDefaultsMissing(t *testing.T) {
+	rf := &RulesFile{Rules: []Rule{
+		{Name: "a", Network: "base"},
+		{Name: "b"},
+		{Name: "c", Network: "base"},
+	}}
+	assert.Equal(t, []string{"base", "ethereum"}, rf.Networks("ethereum"))
+	assert.Equal(t, "ethereum", rf.Rules[1].Network)
+}
+
+// ---------------------------------------------------------------------------
+// Topic / Selector
+// ---------------------------------------------------------------------------
+
+func TestRuleTopic(t *testing.T) {
+	r := Rule{Event: "Transfer(address,address,uint256)"}
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", r.Topic())
+
+	r = Rule{Event: "0xDDF252AD1BE2C89B69C2B068FC378DAA952BA7F163C4A11628F55A4DF523B3EF"}
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", r.Topic())
+}
+
+func TestRuleSelector(t *testing.T) {
+	assert.Equal(t, "0xa9059cbb", (&Rule{Method: "transfer(address,uint256)"}).Selector())
+	assert.Equal(t, "0x095ea7b3", (&Rule{Method: "0x095EA7B3"}).Selector())
+	assert.Equal(t, "", (&Rule{}).Selector())
+}
@@ -0,0 +1,366 @@
+// Package nonce hands out transaction nonces from a local store so that
+// several w3cli processes sending from the same wallet never pick the same
+// nonce. State lives in nonces.json in the config dir, guarded by a lock file.
+package nonce
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	storeFile = "nonces.json"
+	lockFile  = "nonces.lock"
+)
+
+// Lock timing. The lock is an OS file lock, so it is released when the
+// process holding it exits, however it exits.
+var (
+	lockWait  = 10 * time.Second
+	lockRetry = 25 * time.Millisecond
+)
+
+// leaseTTL is how long an issued nonce the node has not seen stays reserved.
+// After that the process that took it is assumed to have died before
+// broadcasting (e.g. Ctrl-C), and the nonce is handed out again. Held nonces
+// never expire.
+var leaseTTL = 10 * time.Minute
+
+// Chain is the subset of chain.EVMClient the manager needs.
+type Chain interface {
+	GetNonce(address string) (uint64, error)        // confirmed (latest)
+	GetPendingNonce(address string) (uint64, error) // including mempool
+}
+
+// Entry is the stored state for one wallet on one chain.
+type Entry struct {
+	Next     uint64            `json:"next"`           // next never-issued nonce
+	Released []uint64          `json:"released"`       // issued but returned unused; handed out first
+	Issued   map[uint64]string `json:"issued"`         // nonce → RFC3339 time it was handed out
+	Held     map[uint64]string `json:"held,omitempty"` // issued nonces handed off to be signed elsewhere; never reclaimed
+	Updated  string            `json:"updated_at"`
+}
+
+// Status is a snapshot of local and chain state used by `nonce gaps`.
+type Status struct {
+	Confirmed uint64
+	Pending   uint64
+	Next      uint64   // next nonce the manager would hand out after Released
+	Released  []uint64 // returned nonces below Next
+	Gaps      []Gap    // nonces handed out that the node has not seen
+}
+
+// Gap is a nonce that was issued locally but is not in the node's pending
+// count. Either the tx is still propagating, it is queued behind a lower gap,
+// or it was never broadcast and is blocking everything above it.
+type Gap struct {
+	Nonce    uint64
+	IssuedAt time.Time // zero when unknown (e.g. released)
+	Held     bool      // handed off for signing elsewhere
+}
+
+// Manager reads and writes the nonce store in a directory.
+type Manager struct {
+	dir string
+	now func() time.Time
+}
+
+// NewManager creates a Manager backed by dir (normally the config dir).
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir, now: time.Now}
+}
+
+// Key identifies a wallet on a chain. Chain IDs (not names) are used so that
+// testnet and mainnet never share a counter.
+func Key(chainID int64, address string) string {
+	return fmt.Sprintf("%d:%s", chainID, strings.ToLower(address))
+}
+
+// Next reserves the next nonce for address. It first reconciles with the
+// chain: nonces the node already counts are dropped, and if another tool sent
+// transactions the counter jumps forward. Released nonces are reused lowest
+// first so a cancelled send doesn't leave a hole.
+func (m *Manager) Next(c Chain, chainID int64, address string) (uint64, error) {
+	pending, err := c.GetPendingNonce(address)
+	if err != nil {
+		return 0, fmt.Errorf("getting pending nonce: %w", err)
+	}
+
+	var out uint64
+	err = m.update(func(entries map[string]*Entry) error {
+		e := entryFor(entries, Key(chainID, address))
+		reconcile(e, pending, m.now())
+
+		if len(e.Released) > 0 {
+			out = e.Released[0]
+			e.Released = e.Released[1:]
+		} else {
+			out = e.Next
+			e.Next++
+		}
+		e.Issued[out] = m.now().UTC().Format(time.RFC3339)
+		e.Updated = m.now().UTC().Format(time.RFC3339)
+		return nil
+	})
+	return out, err
+}
+
+// Release returns an issued nonce that was never broadcast (signing failed,
+// the user cancelled, or the node rejected the tx) so it is reused next.
+func (m *Manager) Release(chainID int64, address string, n uint64) error {
+	return m.update(func(entries map[string]*Entry) error {
+		e := entryFor(entries, Key(chainID, address))
+		delete(e.Issued, n)
+		if n < e.Next {
+			e.Released = insertSorted(e.Released, n)
+			trim(e)
+		}
+		e.Updated = m.now().UTC().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// Hold marks an issued nonce as handed off: the transaction leaves this
+// process unsigned (e.g. `tx build`), so it may be broadcast long after the
+// lease would expire. Held nonces are kept until the node counts them or the
+// wallet is reset.
+func (m *Manager) Hold(chainID int64, address string, n uint64) error {
+	return m.update(func(entries map[string]*Entry) error {
+		e := entryFor(entries, Key(chainID, address))
+		at, ok := e.Issued[n]
+		if !ok {
+			return fmt.Errorf("nonce %d is not reserved", n)
+		}
+		delete(e.Issued, n)
+		e.Held[n] = at
+		e.Updated = m.now().UTC().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// Reset forgets everything stored for address so the next send starts from
+// the chain's pending nonce.
+func (m *Manager) Reset(chainID int64, address string) error {
+	return m.update(func(entries map[string]*Entry) error {
+		delete(entries, Key(chainID, address))
+		return nil
+	})
+}
+
+// Status reconciles with the chain and reports issued nonces the node has
+// not seen.
+func (m *Manager) Status(c Chain, chainID int64, address string) (*Status, error) {
+	confirmed, err := c.GetNonce(address)
+	if err != nil {
+		return nil, fmt.Errorf("getting confirmed nonce: %w", err)
+	}
+	pending, err := c.GetPendingNonce(address)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending nonce: %w", err)
+	}
+
+	st := &Status{Confirmed: confirmed, Pending: pending}
+	err = m.update(func(entries map[string]*Entry) error {
+		e := entryFor(entries, Key(chainID, address))
+		reconcile(e, pending, m.now())
+		st.Next = e.Next
+		st.Released = append([]uint64(nil), e.Released...)
+		for n := pending; n < e.Next; n++ {
+			g := Gap{Nonce: n}
+			if ts, ok := e.Issued[n]; ok {
+				g.IssuedAt, _ = time.Parse(time.RFC3339, ts)
+			} else if ts, ok := e.Held[n]; ok {
+				g.IssuedAt, _ = time.Parse(time.RFC3339, ts)
+				g.Held = true
+			}
+			st.Gaps = append(st.Gaps, g)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// reconcile drops everything the node already counts and takes back issued
+// nonces whose lease expired without the node seeing them. Held nonces are
+// only dropped once the node counts them.
+func reconcile(e *Entry, pending uint64, now time.Time) {
+	if pending > e.Next {
+		e.Next = pending
+	}
+	kept := e.Released[:0]
+	for _, n := range e.Released {
+		if n >= pending {
+			kept = append(kept, n)
+		}
+	}
+	e.Released = kept
+	for n, ts := range e.Issued {
+		if n < pending {
+			delete(e.Issued, n)
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339, ts); err == nil && now.Sub(at) > leaseTTL {
+			delete(e.Issued, n)
+			if n < e.Next {
+				e.Released = insertSorted(e.Released, n)
+			}
+		}
+	}
+	for n := range e.Held {
+		if n < pending {
+			delete(e.Held, n)
+		}
+	}
+	trim(e)
+}
+
+// trim folds released nonces at the top of the range back into Next.
+func trim(e *Entry) {
+	for len(e.Released) > 0 && e.Released[len(e.Released)-1]+1 == e.Next {
+		e.Next--
+		e.Released = e.Released[:len(e.Released)-1]
+	}
+}
+
+func entryFor(entries map[string]*Entry, key string) *Entry {
+	e, ok := entries[key]
+	if !ok {
+		e = &Entry{}
+		entries[key] = e
+	}
+	if e.Issued == nil {
+		e.Issued = make(map[uint64]string)
+	}
+	if e.Held == nil {
+		e.Held = make(map[uint64]string)
+	}
+	return e
+}
+
+func insertSorted(s []uint64, n uint64) []uint64 {
+	i := sort.Search(len(s), func(i int) bool { return s[i] >= n })
+	if i < len(s) && s[i] == n {
+		return s
+	}
+	s = append(s, 0)
+	copy(s[i+1:], s[i:])
+	s[i] = n
+	return s
+}
+
+// update runs fn on the stored entries while holding the lock, then saves.
+func (m *Manager) update(fn func(map[string]*Entry) error) error {
+	unlock, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := m.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(entries); err != nil {
+		return err
+	}
+	return m.save(entries)
+}
+
+func (m *Manager) load() (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+	data, err := os.ReadFile(filepath.Join(m.dir, storeFile))
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", storeFile, err)
+	}
+	return entries, nil
+}
+
+// save writes via a temp file + rename so a crash never leaves a torn file.
+func (m *Manager) save(entries map[string]*Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(m.dir, storeFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// lock takes the OS lock on the lock file. The file itself is never
+// removed: deleting it while another process waits on it would let two
+// processes lock different files.
+func (m *Manager) lock() (func(), error) {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(m.dir, lockFile)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening nonce lock: %w", err)
+	}
+	deadline := time.Now().Add(lockWait)
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		if ok {
+			return func() {
+				unlockFile(f) //nolint:errcheck
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("timed out waiting for nonce lock %s — another w3cli is still using it", path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// Allocator hands out nonces and takes back the ones that were never
+// broadcast. *Source implements it.
+type Allocator interface {
+	Next() (uint64, error)
+	Release(n uint64) error
+}
+
+// Source binds a Manager to one wallet on one chain.
+type Source struct {
+	m       *Manager
+	chain   Chain
+	chainID int64
+	address string
+}
+
+// For returns a Source for address on the chain with chainID.
+func (m *Manager) For(c Chain, chainID int64, address string) *Source {
+	return &Source{m: m, chain: c, chainID: chainID, address: address}
+}
+
+// Next reserves the next nonce.
+func (s *Source) Next() (uint64, error) { return s.m.Next(s.chain, s.chainID, s.address) }
+
+// Release returns an unused nonce.
+func (s *Source) Release(n uint64) error { return s.m.Release(s.chainID, s.address, n) }
+
+// Hold keeps a nonce reserved until the node counts it.
+func (s *Source) Hold(n uint64) error { return s.m.Hold(s.chainID, s.address, n) }
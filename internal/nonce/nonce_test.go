@@ -0,0 +1,253 @@
+package nonce
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wallet = "0xAbC0000000000000000000000000000000000001"
+
+type fakeChain struct {
+	mu        sync.Mutex
+	confirmed uint64
+	pending   uint64
+}
+
+func (f *fakeChain) GetNonce(string) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.confirmed, nil
+}
+
+func (f *fakeChain) GetPendingNonce(string) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pending, nil
+}
+
+// ---------------------------------------------------------------------------
+// Next / Release
+// ---------------------------------------------------------------------------
+
+func TestNextIsSequentialAndStartsAtPending(t *testing.T) {
+	m := NewManager(t.TempDir())
+	c := &fakeChain{pending: 5}
+
+	for want := uint64(5); want < 8; want++ {
+		n, err := m.Next(c, 1, wallet)
+		require.NoError(t, err)
+		assert.Equal(t, want, n)
+	}
+}
+
+func TestNextJumpsForwardWhenChainIsAhead(t *testing.T) {
+	m := NewManager(t.TempDir())
+	c := &fakeChain{pending: 1}
+	_, err := m.Next(c, 1, wallet)
+	require.NoError(t, err)
+
+	c.pending = 10 // sent elsewhere
+	n, err := m.Next(c, 1, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), n)
+}
+
+func TestKeySeparatesChainsAndIgnoresCase(t *testing.T) {
+	m := NewManager(t.TempDir())
+	c := &fakeChain{}
+	a, _ := m.Next(c, 1, wallet)
+	b, _ := m.Next(c, 8453, wallet)
+	d, _ := m.Next(c, 1, "0xabc0000000000000000000000000000000000001")
+	assert.Equal(t, uint64(0), a)
+	assert.Equal(t, uint64(0), b)
+	assert.Equal(t, uint64(1), d)
+}
+
+func TestReleaseReusesNonce(t *testing.T) {
+	m := NewManager(t.TempDir())
+	c := &fakeChain{}
+	n0, _ := m.Next(c, 1, wallet)
+	n1, _ := m.Next(c, 1, wallet)
+	n2, _ := m.Next(c, 1, wallet)
+	require.Equal(t, []uint64{0, 1, 2}, []uint64{n0, n1, n2})
+
+	// Releasing a middle nonce leaves a hole that is filled first.
+	require.NoError(t, m.Release(1, wallet, 1))
+	n, _ := m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(1), n)
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(3), n)
+
+	// Releasing the top nonces just lowers the counter.
+	require.NoError(t, m.Release(1, wallet, 2))
+	require.NoError(t, m.Release(1, wallet, 3))
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(2), n)
+}
+
+func TestExpiredLeaseIsReclaimed(t *testing.T) {
+	m := NewManager(t.TempDir())
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	c := &fakeChain{}
+
+	// Nonce 0 is broadcast; 1 and 2 are taken by a process that is killed
+	// before it can broadcast or release them.
+	for i := 0; i < 3; i++ {
+		_, err := m.Next(c, 1, wallet)
+		require.NoError(t, err)
+	}
+	c.pending = 1
+
+	// Within the lease they stay reserved.
+	now = now.Add(leaseTTL / 2)
+	n, _ := m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(3), n)
+
+	// Once 1 and 2 expire they are handed out again; 3 is still leased.
+	now = now.Add(leaseTTL/2 + time.Second)
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(1), n)
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(2), n)
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(4), n)
+}
+
+func TestHeldNonceSurvivesLease(t *testing.T) {
+	m := NewManager(t.TempDir())
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	c := &fakeChain{}
+
+	// Nonce 0 goes out in an unsigned tx file; 1 is leased and abandoned.
+	for i := 0; i < 2; i++ {
+		_, err := m.Next(c, 1, wallet)
+		require.NoError(t, err)
+	}
+	require.NoError(t, m.Hold(1, wallet, 0))
+	assert.Error(t, m.Hold(1, wallet, 5), "only reserved nonces can be held")
+
+	now = now.Add(2 * leaseTTL)
+	n, err := m.Next(c, 1, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n, "the abandoned lease is reclaimed, the held one is not")
+	n, _ = m.Next(c, 1, wallet)
+	assert.Equal(t, uint64(2), n)
+
+	st, err := m.Status(c, 1, wallet)
+	require.NoError(t, err)
+	require.NotEmpty(t, st.Gaps)
+	assert.True(t, st.Gaps[0].Held)
+
+	// Once the signed tx reaches the node the hold is dropped.
+	c.pending = 1
+	_, err = m.Next(c, 1, wallet)
+	require.NoError(t, err)
+	st, err = m.Status(c, 1, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), st.Gaps[0].Nonce)
+	assert.False(t, st.Gaps[0].Held)
+}
+
+func TestConcurrentNextNeverCollides(t *testing.T) {
+	dir := t.TempDir()
+	c := &fakeChain{pending: 100}
+
+	const workers = 8
+	results := make(chan uint64, workers*5)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := NewManager(dir) // separate managers, shared files — like separate processes
+			for j := 0; j < 5; j++ {
+				n, err := m.Next(c, 1, wallet)
+				assert.NoError(t, err)
+				results <- n
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := make(map[uint64]bool)
+	for n := range results {
+		assert.False(t, seen[n], "nonce %d handed out twice", n)
+		seen[n] = true
+	}
+	assert.Len(t, seen, workers*5)
+}
+
+// ---------------------------------------------------------------------------
+// Status / Reset
+// ---------------------------------------------------------------------------
+
+func TestStatusReportsGaps(t *testing.T) {
+	m := NewManager(t.TempDir())
+	fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.now = func() time.Time { return fixed }
+	c := &fakeChain{confirmed: 3, pending: 3}
+
+	for i := 0; i < 3; i++ {
+		_, err := m.Next(c, 1, wallet)
+		require.NoError(t, err)
+	}
+	c.pending = 4 // only nonce 3 reached the node
+
+	st, err := m.Status(c, 1, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), st.Confirmed)
+	assert.Equal(t, uint64(4), st.Pending)
+	assert.Equal(t, uint64(6), st.Next)
+	require.Len(t, st.Gaps, 2)
+	assert.Equal(t, uint64(4), st.Gaps[0].Nonce)
+	assert.Equal(t, fixed, st.Gaps[0].IssuedAt)
+}
+
+func TestResetForgetsWallet(t *testing.T) {
+	m := NewManager(t.TempDir())
+	c := &fakeChain{}
+	for i := 0; i < 3; i++ {
+		m.Next(c, 1, wallet) //nolint:errcheck
+	}
+	require.NoError(t, m.Reset(1, wallet))
+	n, err := m.Next(c, 1, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), n)
+}
+
+// ---------------------------------------------------------------------------
+// Locking
+// ---------------------------------------------------------------------------
+
+func TestLeftoverLockFileDoesNotBlock(t *testing.T) {
+	// A process that crashed leaves the file behind but not the lock.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, lockFile), []byte("999\n"), 0o600))
+
+	_, err := NewManager(dir).Next(&fakeChain{}, 1, wallet)
+	require.NoError(t, err)
+}
+
+func TestHeldLockTimesOut(t *testing.T) {
+	dir := t.TempDir()
+	unlock, err := NewManager(dir).lock()
+	require.NoError(t, err)
+	defer unlock()
+
+	saved := lockWait
+	lockWait = 50 * time.Millisecond
+	defer func() { lockWait = saved }()
+
+	_, err = NewManager(dir).Next(&fakeChain{}, 1, wallet)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+}
@@ -0,0 +1,95 @@
+package price
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached price is used before refetching.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Cache is an on-disk price cache so repeated commands don't hit rate-limited
+// APIs. Entries are keyed by currency, chain and token.
+type Cache struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	loaded  bool
+}
+
+type cacheEntry struct {
+	Price     float64 `json:"price"`
+	Source    string  `json:"source,omitempty"`
+	FetchedAt int64   `json:"fetched_at"`
+}
+
+// NewCache creates a Cache backed by a JSON file. It loads lazily.
+func NewCache(path string, ttl time.Duration) *Cache {
+	return &Cache{path: path, ttl: ttl, now: time.Now, entries: make(map[string]cacheEntry)}
+}
+
+func cacheKey(currency, chainName, token string) string {
+	return currency + "|" + chainName + "|" + TokenKey(token)
+}
+
+// load reads the file once. A missing or corrupt file is an empty cache.
+func (c *Cache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(data, &c.entries)
+	if c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+}
+
+// Get returns a fresh cached price and the source that produced it.
+func (c *Cache) Get(currency, chainName, token string) (float64, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	e, ok := c.entries[cacheKey(currency, chainName, token)]
+	if !ok || c.now().Sub(time.Unix(e.FetchedAt, 0)) > c.ttl {
+		return 0, "", false
+	}
+	return e.Price, e.Source, true
+}
+
+// Put stores a price.
+func (c *Cache) Put(currency, chainName, token string, price float64, source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	c.entries[cacheKey(currency, chainName, token)] = cacheEntry{Price: price, Source: source, FetchedAt: c.now().Unix()}
+}
+
+// Save writes the cache to disk, dropping expired entries.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	for k, e := range c.entries {
+		if c.now().Sub(time.Unix(e.FetchedAt, 0)) > c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing price cache: %w", err)
+	}
+	return nil
+}
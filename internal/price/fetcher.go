@@ -9,23 +9,38 @@ import (
 	"time"
 )
 
-// Fetcher retrieves token prices from CoinGecko.
+// Fetcher retrieves native prices from CoinGecko and token prices from a
+// chain of Sources, optionally through an on-disk Cache.
 type Fetcher struct {
 	client   *http.Client
 	currency string
+	sources  []Source
+	cache    *Cache
 }
 
-// NewFetcher creates a new price fetcher.
+// NewFetcher creates a new price fetcher. Token prices come from CoinGecko,
+// then DefiLlama, until SetSources says otherwise.
 func NewFetcher(currency string) *Fetcher {
 	if currency == "" {
 		currency = "usd"
 	}
+	client := &http.Client{Timeout: 10 * time.Second}
 	return &Fetcher{
-		client:   &http.Client{Timeout: 10 * time.Second},
+		client:   client,
 		currency: strings.ToLower(currency),
+		sources:  []Source{NewCoinGecko(client), NewDefiLlama(client)},
 	}
 }
 
+// Currency returns the lowercased currency prices are quoted in.
+func (f *Fetcher) Currency() string { return f.currency }
+
+// SetSources replaces the token price sources, tried in order.
+func (f *Fetcher) SetSources(sources ...Source) { f.sources = sources }
+
+// SetCache makes the fetcher read and write prices through c.
+func (f *Fetcher) SetCache(c *Cache) { f.cache = c }
+
 // coinGeckoIDs maps chain names to CoinGecko coin IDs.
 var coinGeckoIDs = map[string]string{
 	"ethereum":     "ethereum",
@@ -74,9 +89,14 @@ func (f *Fetcher) GetPrices(chainNames []string) (map[string]float64, error) {
 		}
 	}
 
-	// Collect unique coin IDs.
+	// Collect unique coin IDs, skipping those cached.
+	prices := make(map[string]float64)
 	uniqueIDs := make(map[string]struct{})
 	for _, id := range ids {
+		if p, _, ok := f.cached(nativeCacheChain, id); ok {
+			prices[id] = p
+			continue
+		}
 		uniqueIDs[id] = struct{}{}
 	}
 	idList := make([]string, 0, len(uniqueIDs))
@@ -84,9 +104,16 @@ func (f *Fetcher) GetPrices(chainNames []string) (map[string]float64, error) {
 		idList = append(idList, id)
 	}
 
-	prices, err := f.fetchBatch(idList)
-	if err != nil {
-		return nil, err
+	if len(idList) > 0 {
+		fetched, err := f.fetchBatch(idList)
+		if err != nil {
+			return nil, err
+		}
+		for id, p := range fetched {
+			prices[id] = p
+			f.store(nativeCacheChain, id, p, "coingecko")
+		}
+		f.saveCache()
 	}
 
 	result := make(map[string]float64)
@@ -99,6 +126,9 @@ func (f *Fetcher) GetPrices(chainNames []string) (map[string]float64, error) {
 }
 
 func (f *Fetcher) getByID(id string) (float64, error) {
+	if p, _, ok := f.cached(nativeCacheChain, id); ok {
+		return p, nil
+	}
 	prices, err := f.fetchBatch([]string{id})
 	if err != nil {
 		return 0, err
@@ -107,9 +137,137 @@ func (f *Fetcher) getByID(id string) (float64, error) {
 	if !ok {
 		return 0, fmt.Errorf("price not available for: %s", id)
 	}
+	f.store(nativeCacheChain, id, p, "coingecko")
+	f.saveCache()
 	return p, nil
 }
 
+// Quote is a token price and the source it came from.
+type Quote struct {
+	Price  float64
+	Source string
+}
+
+// GetTokenPrices prices tokens on chainName, keyed by TokenKey. Each source is
+// asked only for the tokens earlier sources (and the cache) couldn't price.
+// Tokens no source knows are simply absent; an error is returned only when
+// nothing was priced and a source failed.
+func (f *Fetcher) GetTokenPrices(chainName string, tokens []string) (map[string]Quote, error) {
+	chainName = strings.ToLower(chainName)
+	out := make(map[string]Quote)
+	var missing []string
+	seen := make(map[string]bool)
+	for _, t := range tokens {
+		if seen[TokenKey(t)] {
+			continue
+		}
+		seen[TokenKey(t)] = true
+		if p, src, ok := f.cached(chainName, t); ok {
+			out[TokenKey(t)] = Quote{Price: p, Source: src}
+			continue
+		}
+		missing = append(missing, t)
+	}
+
+	var lastErr error
+	for _, src := range f.sources {
+		if len(missing) == 0 {
+			break
+		}
+		prices, quoted, err := src.TokenPrices(chainName, missing, f.currency)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		rate := 1.0
+		if quoted != f.currency && len(prices) > 0 {
+			if rate, err = f.fxRate(quoted); err != nil {
+				lastErr = err
+				continue
+			}
+		}
+		var still []string
+		for _, t := range missing {
+			p, ok := prices[TokenKey(t)]
+			if !ok || p <= 0 {
+				still = append(still, t)
+				continue
+			}
+			out[TokenKey(t)] = Quote{Price: p * rate, Source: src.Name()}
+			f.store(chainName, t, p*rate, src.Name())
+		}
+		missing = still
+	}
+	f.saveCache()
+
+	if len(out) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return out, nil
+}
+
+// fxRate converts 1 unit of from into the fetcher's currency using
+// CoinGecko's BTC-denominated exchange rates.
+func (f *Fetcher) fxRate(from string) (float64, error) {
+	if p, _, ok := f.cached(fxCacheChain, from); ok {
+		return p, nil
+	}
+	var raw struct {
+		Rates map[string]struct {
+			Value float64 `json:"value"`
+		} `json:"rates"`
+	}
+	if err := getJSON(f.client, "https://api.coingecko.com/api/v3/exchange_rates", &raw); err != nil {
+		return 0, fmt.Errorf("fetching exchange rates: %w", err)
+	}
+	src, dst := raw.Rates[from].Value, raw.Rates[f.currency].Value
+	if src <= 0 || dst <= 0 {
+		return 0, fmt.Errorf("no exchange rate from %s to %s", from, f.currency)
+	}
+	f.store(fxCacheChain, from, dst/src, "coingecko")
+	return dst / src, nil
+}
+
+// Pseudo-chains for cache entries that aren't tokens.
+const (
+	nativeCacheChain = "native"
+	fxCacheChain     = "fx"
+)
+
+func (f *Fetcher) cached(chainName, token string) (float64, string, bool) {
+	if f.cache == nil {
+		return 0, "", false
+	}
+	return f.cache.Get(f.currency, chainName, token)
+}
+
+func (f *Fetcher) store(chainName, token string, p float64, source string) {
+	if f.cache != nil {
+		f.cache.Put(f.currency, chainName, token, p, source)
+	}
+}
+
+// saveCache persists the cache; a failure only costs a refetch next time.
+func (f *Fetcher) saveCache() {
+	if f.cache != nil {
+		_ = f.cache.Save()
+	}
+}
+
+// currencySymbols are prefixes for common fiat currencies.
+var currencySymbols = map[string]string{
+	"usd": "$", "eur": "€", "gbp": "£", "jpy": "¥", "inr": "₹", "krw": "₩", "cny": "¥",
+}
+
+// Format renders an amount in currency, e.g. "$1234.56" or "1234.56 CHF".
+func Format(v float64, currency string) string {
+	currency = strings.ToLower(currency)
+	if sym, ok := currencySymbols[currency]; ok {
+		return fmt.Sprintf("%s%.2f", sym, v)
+	}
+	return fmt.Sprintf("%.2f %s", v, strings.ToUpper(currency))
+}
+
 func (f *Fetcher) fetchBatch(ids []string) (map[string]float64, error) {
 	url := fmt.Sprintf(
 		"https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s",
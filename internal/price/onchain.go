@@ -0,0 +1,348 @@
+package price
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Caller runs a read-only contract call (eth_call) and returns the hex
+// result. chain.EVMClient satisfies it.
+type Caller interface {
+	CallContract(to, calldata string) (string, error)
+}
+
+// Dialer returns a Caller for a chain's mainnet.
+type Dialer func(chainName string) (Caller, error)
+
+// ── Chainlink ────────────────────────────────────────────────────────────────
+
+// chainlinkFeeds maps chain → token → USD price feed for tokens whose feed is
+// well known. Wrapped native tokens use the native/USD feed.
+var chainlinkFeeds = map[string]map[string]string{
+	"ethereum": {
+		"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48": "0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6", // USDC
+		"0xdac17f958d2ee523a2206206994597c13d831ec7": "0x3E7d1eAB13ad0104d2750B8863b489D65364e32D", // USDT
+		"0x6b175474e89094c44da98b954eedeac495271d0f": "0xAed0c38402a5d19df6E4c03F4E2DceD6e29c1ee9", // DAI
+		"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2": "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419", // WETH → ETH/USD
+		"0x2260fac5e5542a773aa44fbcfedf7c193bc2c599": "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c", // WBTC → BTC/USD
+		"0x514910771af9ca656af840dff83e8264ecf986ca": "0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c", // LINK
+	},
+	"base": {
+		"0x833589fcd6edb6e08f4c7c32d4f71b54bda02913": "0x7e860098F58bBFC8648a4311b374B1D669a2bc6B", // USDC
+		"0x4200000000000000000000000000000000000006": "0x71041dddad3595F9CEd3DcCFBe3D1F4b0a16Bb70", // WETH → ETH/USD
+	},
+	"arbitrum": {
+		"0x82af49447d8a07e3bd95bd0d56f35241523fbab1": "0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612", // WETH → ETH/USD
+	},
+}
+
+const (
+	selDecimals        = "0x313ce567"
+	selLatestRoundData = "0xfeaf968c"
+)
+
+// Chainlink reads USD prices from Chainlink aggregator feeds on-chain.
+type Chainlink struct {
+	dial Dialer
+	// Feeds overrides or extends the built-in feed table: chain → token → feed.
+	Feeds map[string]map[string]string
+	// MaxAge rejects answers older than this; feeds update at least daily.
+	MaxAge time.Duration
+	now    func() time.Time
+}
+
+// NewChainlink creates a Chainlink source.
+func NewChainlink(dial Dialer) *Chainlink {
+	return &Chainlink{dial: dial, MaxAge: 25 * time.Hour, now: time.Now}
+}
+
+// Name implements Source.
+func (s *Chainlink) Name() string { return "chainlink" }
+
+func (s *Chainlink) feed(chainName, token string) string {
+	if f, ok := s.Feeds[chainName][TokenKey(token)]; ok {
+		return f
+	}
+	return chainlinkFeeds[chainName][TokenKey(token)]
+}
+
+// TokenPrices implements Source.
+func (s *Chainlink) TokenPrices(chainName string, tokens []string, _ string) (map[string]float64, string, error) {
+	chainName = strings.ToLower(chainName)
+	var client Caller
+	prices := make(map[string]float64)
+	for _, t := range tokens {
+		feed := s.feed(chainName, t)
+		if feed == "" {
+			continue
+		}
+		if client == nil {
+			c, err := s.dial(chainName)
+			if err != nil {
+				return nil, "usd", fmt.Errorf("chainlink: %w", err)
+			}
+			client = c
+		}
+		p, err := s.read(client, feed)
+		if err != nil {
+			continue
+		}
+		prices[TokenKey(t)] = p
+	}
+	return prices, "usd", nil
+}
+
+// read returns a feed's latest answer scaled by its decimals.
+func (s *Chainlink) read(client Caller, feed string) (float64, error) {
+	raw, err := client.CallContract(feed, selLatestRoundData)
+	if err != nil {
+		return 0, err
+	}
+	words, err := hexWords(raw)
+	if err != nil || len(words) < 5 {
+		return 0, fmt.Errorf("unexpected latestRoundData result %q", raw)
+	}
+	answer := signedWord(words[1])
+	updatedAt := words[3].Int64()
+	if answer.Sign() <= 0 {
+		return 0, fmt.Errorf("feed %s answered %s", feed, answer)
+	}
+	if s.MaxAge > 0 && s.now().Sub(time.Unix(updatedAt, 0)) > s.MaxAge {
+		return 0, fmt.Errorf("feed %s is stale (updated %s)", feed, time.Unix(updatedAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	decRaw, err := client.CallContract(feed, selDecimals)
+	if err != nil {
+		return 0, err
+	}
+	dec, err := hexWords(decRaw)
+	if err != nil || len(dec) < 1 {
+		return 0, fmt.Errorf("unexpected decimals result %q", decRaw)
+	}
+	return scaleDown(answer, int(dec[0].Int64())), nil
+}
+
+// ── Uniswap V3 TWAP ──────────────────────────────────────────────────────────
+
+// uniswapMarket is where the TWAP source looks for pools on a chain: the V3
+// factory and the USD stablecoin prices are quoted against.
+type uniswapMarket struct {
+	Factory       string
+	Quote         string
+	QuoteDecimals int
+}
+
+var uniswapMarkets = map[string]uniswapMarket{
+	"ethereum": {"0x1F98431c8aD98523631AE4a59f267346ea31F984", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", 6},
+	"arbitrum": {"0x1F98431c8aD98523631AE4a59f267346ea31F984", "0xaf88d065e77c8cC2239327C5EDb3A432268e5831", 6},
+	"optimism": {"0x1F98431c8aD98523631AE4a59f267346ea31F984", "0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85", 6},
+	"polygon":  {"0x1F98431c8aD98523631AE4a59f267346ea31F984", "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", 6},
+	"base":     {"0x33128a8fC17869897dcE68Ed026d694621f6FDfD", "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", 6},
+}
+
+// uniswapFeeTiers are the pool fees (in hundredths of a bip) searched, most
+// liquid pool wins.
+var uniswapFeeTiers = []int64{500, 3000, 10000, 100}
+
+const (
+	selGetPool   = "0x1698ee82" // getPool(address,address,uint24)
+	selLiquidity = "0x1a686502" // liquidity()
+	selObserve   = "0x883bdbfd" // observe(uint32[])
+)
+
+// UniswapTWAP prices tokens from the time-weighted average tick of their most
+// liquid Uniswap V3 pool against USDC, read with eth_call. Manipulating a
+// TWAP costs holding the price for the whole window, unlike a spot price.
+type UniswapTWAP struct {
+	dial   Dialer
+	Window time.Duration
+}
+
+// NewUniswapTWAP creates a Uniswap V3 TWAP source with a 30-minute window.
+func NewUniswapTWAP(dial Dialer) *UniswapTWAP {
+	return &UniswapTWAP{dial: dial, Window: 30 * time.Minute}
+}
+
+// Name implements Source.
+func (s *UniswapTWAP) Name() string { return "uniswap" }
+
+// TokenPrices implements Source.
+func (s *UniswapTWAP) TokenPrices(chainName string, tokens []string, _ string) (map[string]float64, string, error) {
+	market, ok := uniswapMarkets[strings.ToLower(chainName)]
+	if !ok {
+		return nil, "usd", fmt.Errorf("uniswap: unsupported chain %s", chainName)
+	}
+	client, err := s.dial(strings.ToLower(chainName))
+	if err != nil {
+		return nil, "usd", fmt.Errorf("uniswap: %w", err)
+	}
+
+	prices := make(map[string]float64)
+	for _, t := range tokens {
+		if TokenKey(t) == TokenKey(market.Quote) {
+			prices[TokenKey(t)] = 1
+			continue
+		}
+		p, err := s.price(client, market, t)
+		if err != nil {
+			continue
+		}
+		prices[TokenKey(t)] = p
+	}
+	return prices, "usd", nil
+}
+
+// price returns token's TWAP in the market's quote token.
+func (s *UniswapTWAP) price(client Caller, market uniswapMarket, token string) (float64, error) {
+	pool, err := bestPool(client, market.Factory, token, market.Quote)
+	if err != nil {
+		return 0, err
+	}
+
+	window := uint32(s.Window / time.Second)
+	if window == 0 {
+		return 0, fmt.Errorf("TWAP window must be at least 1s")
+	}
+	// observe([window, 0]): offset, length 2, then the two uint32s.
+	calldata := selObserve + encWord(big.NewInt(32)) + encWord(big.NewInt(2)) +
+		encWord(big.NewInt(int64(window))) + encWord(big.NewInt(0))
+	raw, err := client.CallContract(pool, calldata)
+	if err != nil {
+		return 0, fmt.Errorf("observe on %s: %w", pool, err)
+	}
+	cumulatives, err := decodeIntArray(raw, 0)
+	if err != nil || len(cumulatives) != 2 {
+		return 0, fmt.Errorf("unexpected observe result from %s", pool)
+	}
+	tick := meanTick(cumulatives[0], cumulatives[1], int64(window))
+
+	decRaw, err := client.CallContract(token, selDecimals)
+	if err != nil {
+		return 0, err
+	}
+	dec, err := hexWords(decRaw)
+	if err != nil || len(dec) < 1 {
+		return 0, fmt.Errorf("token %s has no decimals()", token)
+	}
+	tokenIsToken0 := strings.ToLower(token) < strings.ToLower(market.Quote)
+	return tickPrice(tick, tokenIsToken0, int(dec[0].Int64()), market.QuoteDecimals), nil
+}
+
+// bestPool returns the pool for token/quote with the most in-range liquidity.
+func bestPool(client Caller, factory, token, quote string) (string, error) {
+	best, bestLiq := "", new(big.Int)
+	for _, fee := range uniswapFeeTiers {
+		raw, err := client.CallContract(factory, selGetPool+encAddress(token)+encAddress(quote)+encWord(big.NewInt(fee)))
+		if err != nil {
+			continue
+		}
+		words, err := hexWords(raw)
+		if err != nil || len(words) < 1 || words[0].Sign() == 0 {
+			continue
+		}
+		pool := fmt.Sprintf("0x%040x", words[0])
+		liqRaw, err := client.CallContract(pool, selLiquidity)
+		if err != nil {
+			continue
+		}
+		liq, err := hexWords(liqRaw)
+		if err != nil || len(liq) < 1 {
+			continue
+		}
+		if liq[0].Cmp(bestLiq) > 0 {
+			best, bestLiq = pool, liq[0]
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no liquid Uniswap V3 pool for %s", token)
+	}
+	return best, nil
+}
+
+// meanTick is the arithmetic mean tick over the window, rounded towards
+// negative infinity as in Uniswap's OracleLibrary.
+func meanTick(older, newer *big.Int, window int64) int64 {
+	delta := new(big.Int).Sub(newer, older)
+	w := big.NewInt(window)
+	q, m := new(big.Int).QuoRem(delta, w, new(big.Int))
+	tick := q.Int64()
+	if delta.Sign() < 0 && m.Sign() != 0 {
+		tick--
+	}
+	return tick
+}
+
+// tickPrice converts a pool tick into the human price of token in the quote
+// token. The raw pool price is token1/token0 = 1.0001^tick in base units.
+func tickPrice(tick int64, tokenIsToken0 bool, tokenDecimals, quoteDecimals int) float64 {
+	raw := math.Pow(1.0001, float64(tick))
+	if tokenIsToken0 {
+		return raw * math.Pow10(tokenDecimals-quoteDecimals)
+	}
+	return math.Pow10(tokenDecimals-quoteDecimals) / raw
+}
+
+// ── ABI helpers ──────────────────────────────────────────────────────────────
+
+func encWord(v *big.Int) string { return fmt.Sprintf("%064x", v) }
+
+func encAddress(addr string) string {
+	return fmt.Sprintf("%064s", strings.ToLower(strings.TrimPrefix(addr, "0x")))
+}
+
+// hexWords splits an eth_call result into 32-byte words.
+func hexWords(raw string) ([]*big.Int, error) {
+	s := strings.TrimPrefix(raw, "0x")
+	if len(s)%64 != 0 {
+		return nil, fmt.Errorf("result is not word-aligned")
+	}
+	words := make([]*big.Int, len(s)/64)
+	for i := range words {
+		w, ok := new(big.Int).SetString(s[i*64:(i+1)*64], 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid hex in result")
+		}
+		words[i] = w
+	}
+	return words, nil
+}
+
+// signedWord interprets a word as a two's-complement int256.
+func signedWord(w *big.Int) *big.Int {
+	if w.Bit(255) == 0 {
+		return w
+	}
+	return new(big.Int).Sub(w, new(big.Int).Lsh(big.NewInt(1), 256))
+}
+
+// decodeIntArray decodes the signed dynamic array whose offset is in head word i.
+func decodeIntArray(raw string, i int) ([]*big.Int, error) {
+	words, err := hexWords(raw)
+	if err != nil {
+		return nil, err
+	}
+	if i >= len(words) {
+		return nil, fmt.Errorf("short result")
+	}
+	start := int(words[i].Int64() / 32)
+	if start >= len(words) {
+		return nil, fmt.Errorf("array offset out of range")
+	}
+	n := int(words[start].Int64())
+	if start+1+n > len(words) {
+		return nil, fmt.Errorf("array length out of range")
+	}
+	out := make([]*big.Int, n)
+	for j := range out {
+		out[j] = signedWord(words[start+1+j])
+	}
+	return out, nil
+}
+
+func scaleDown(v *big.Int, decimals int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetFloat64(math.Pow10(decimals))).Float64()
+	return f
+}
@@ -0,0 +1,210 @@
+package price
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Source prices tokens on one chain. Tokens are contract addresses (mints on
+// Solana, coin types on SUI); results are keyed by TokenKey. Sources return
+// prices in the currency they were asked for when they can, and report the
+// currency they actually used so the Fetcher can convert.
+type Source interface {
+	Name() string
+	TokenPrices(chainName string, tokens []string, currency string) (prices map[string]float64, quoted string, err error)
+}
+
+// TokenKey normalizes a token address for map lookups: 0x addresses are
+// case-insensitive, base58 mints and SUI coin types are not.
+func TokenKey(token string) string {
+	if len(token) == 42 && strings.HasPrefix(strings.ToLower(token), "0x") {
+		return strings.ToLower(token)
+	}
+	return token
+}
+
+// ── CoinGecko ────────────────────────────────────────────────────────────────
+
+// coinGeckoPlatforms maps chain names to CoinGecko asset platform IDs.
+var coinGeckoPlatforms = map[string]string{
+	"ethereum":      "ethereum",
+	"base":          "base",
+	"polygon":       "polygon-pos",
+	"arbitrum":      "arbitrum-one",
+	"optimism":      "optimistic-ethereum",
+	"bnb":           "binance-smart-chain",
+	"avalanche":     "avalanche",
+	"fantom":        "fantom",
+	"linea":         "linea",
+	"zksync":        "zksync",
+	"scroll":        "scroll",
+	"mantle":        "mantle",
+	"celo":          "celo",
+	"gnosis":        "xdai",
+	"blast":         "blast",
+	"mode":          "mode",
+	"zora":          "zora-network",
+	"moonbeam":      "moonbeam",
+	"cronos":        "cronos",
+	"klaytn":        "klay-token",
+	"aurora":        "aurora",
+	"polygon-zkevm": "polygon-zkevm",
+	"boba":          "boba",
+	"solana":        "solana",
+	"sui":           "sui",
+}
+
+// CoinGecko prices tokens through the public /simple/token_price API, in any
+// currency CoinGecko supports.
+type CoinGecko struct {
+	client *http.Client
+	base   string
+}
+
+// NewCoinGecko creates a CoinGecko source. A nil client gets a default one.
+func NewCoinGecko(client *http.Client) *CoinGecko {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &CoinGecko{client: client, base: "https://api.coingecko.com/api/v3"}
+}
+
+// Name implements Source.
+func (s *CoinGecko) Name() string { return "coingecko" }
+
+// TokenPrices implements Source.
+func (s *CoinGecko) TokenPrices(chainName string, tokens []string, currency string) (map[string]float64, string, error) {
+	platform, ok := coinGeckoPlatforms[strings.ToLower(chainName)]
+	if !ok {
+		return nil, currency, fmt.Errorf("coingecko: unsupported chain %s", chainName)
+	}
+	u := fmt.Sprintf("%s/simple/token_price/%s?contract_addresses=%s&vs_currencies=%s",
+		s.base, platform, url.QueryEscape(strings.Join(tokens, ",")), currency)
+
+	// Response: {"0xa0b8…":{"usd":0.9998}, …}
+	var raw map[string]map[string]float64
+	if err := getJSON(s.client, u, &raw); err != nil {
+		return nil, currency, fmt.Errorf("coingecko: %w", err)
+	}
+
+	// CoinGecko lowercases keys, so match them back case-insensitively.
+	byLower := make(map[string]map[string]float64, len(raw))
+	for k, v := range raw {
+		byLower[strings.ToLower(k)] = v
+	}
+	prices := make(map[string]float64)
+	for _, t := range tokens {
+		if p, ok := byLower[strings.ToLower(t)][currency]; ok && p > 0 {
+			prices[TokenKey(t)] = p
+		}
+	}
+	return prices, currency, nil
+}
+
+// ── DefiLlama ────────────────────────────────────────────────────────────────
+
+// defiLlamaChains maps chain names to DefiLlama chain prefixes.
+var defiLlamaChains = map[string]string{
+	"ethereum":      "ethereum",
+	"base":          "base",
+	"polygon":       "polygon",
+	"arbitrum":      "arbitrum",
+	"optimism":      "optimism",
+	"bnb":           "bsc",
+	"avalanche":     "avax",
+	"fantom":        "fantom",
+	"linea":         "linea",
+	"zksync":        "era",
+	"scroll":        "scroll",
+	"mantle":        "mantle",
+	"celo":          "celo",
+	"gnosis":        "xdai",
+	"blast":         "blast",
+	"mode":          "mode",
+	"zora":          "zora",
+	"moonbeam":      "moonbeam",
+	"cronos":        "cronos",
+	"klaytn":        "klaytn",
+	"aurora":        "aurora",
+	"polygon-zkevm": "polygon_zkevm",
+	"boba":          "boba",
+	"solana":        "solana",
+	"sui":           "sui",
+}
+
+// DefiLlama prices tokens through coins.llama.fi. Prices are always USD.
+type DefiLlama struct {
+	client *http.Client
+	base   string
+}
+
+// NewDefiLlama creates a DefiLlama source. A nil client gets a default one.
+func NewDefiLlama(client *http.Client) *DefiLlama {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &DefiLlama{client: client, base: "https://coins.llama.fi"}
+}
+
+// Name implements Source.
+func (s *DefiLlama) Name() string { return "defillama" }
+
+// TokenPrices implements Source.
+func (s *DefiLlama) TokenPrices(chainName string, tokens []string, _ string) (map[string]float64, string, error) {
+	prefix, ok := defiLlamaChains[strings.ToLower(chainName)]
+	if !ok {
+		return nil, "usd", fmt.Errorf("defillama: unsupported chain %s", chainName)
+	}
+	ids := make([]string, len(tokens))
+	for i, t := range tokens {
+		ids[i] = prefix + ":" + t
+	}
+
+	// Response: {"coins":{"ethereum:0xA0b8…":{"price":0.9998,"symbol":"USDC",…}}}
+	var raw struct {
+		Coins map[string]struct {
+			Price float64 `json:"price"`
+		} `json:"coins"`
+	}
+	if err := getJSON(s.client, s.base+"/prices/current/"+strings.Join(ids, ","), &raw); err != nil {
+		return nil, "usd", fmt.Errorf("defillama: %w", err)
+	}
+
+	byLower := make(map[string]float64, len(raw.Coins))
+	for k, v := range raw.Coins {
+		byLower[strings.ToLower(k)] = v.Price
+	}
+	prices := make(map[string]float64)
+	for i, t := range tokens {
+		if p := byLower[strings.ToLower(ids[i])]; p > 0 {
+			prices[TokenKey(t)] = p
+		}
+	}
+	return prices, "usd", nil
+}
+
+// getJSON GETs u and decodes a 200 response into out.
+func getJSON(client *http.Client, u string, out interface{}) error {
+	resp, err := client.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package price
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usdc = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	weth = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+)
+
+// routeTransport answers requests by URL substring.
+type routeTransport struct {
+	routes map[string]string
+	hits   map[string]int
+}
+
+func (rt *routeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for frag, body := range rt.routes {
+		if strings.Contains(req.URL.String(), frag) {
+			if rt.hits != nil {
+				rt.hits[frag]++
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		}
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("{}")), Header: make(http.Header)}, nil
+}
+
+func routedClient(routes map[string]string) (*http.Client, map[string]int) {
+	hits := map[string]int{}
+	return &http.Client{Transport: &routeTransport{routes: routes, hits: hits}}, hits
+}
+
+// stubSource returns fixed prices in a fixed currency.
+type stubSource struct {
+	name   string
+	quoted string
+	prices map[string]float64
+	asked  [][]string
+}
+
+func (s *stubSource) Name() string { return s.name }
+
+func (s *stubSource) TokenPrices(_ string, tokens []string, _ string) (map[string]float64, string, error) {
+	s.asked = append(s.asked, tokens)
+	out := map[string]float64{}
+	for _, t := range tokens {
+		if p, ok := s.prices[TokenKey(t)]; ok {
+			out[TokenKey(t)] = p
+		}
+	}
+	return out, s.quoted, nil
+}
+
+func TestTokenKey(t *testing.T) {
+	assert.Equal(t, strings.ToLower(usdc), TokenKey(usdc))
+	mint := "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	assert.Equal(t, mint, TokenKey(mint))
+	coin := "0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN"
+	assert.Equal(t, coin, TokenKey(coin))
+}
+
+func TestCoinGeckoTokenPrices(t *testing.T) {
+	client, _ := routedClient(map[string]string{
+		"/simple/token_price/ethereum": `{"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48":{"eur":0.92}}`,
+	})
+	prices, quoted, err := NewCoinGecko(client).TokenPrices("ethereum", []string{usdc, weth}, "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "eur", quoted)
+	assert.InDelta(t, 0.92, prices[TokenKey(usdc)], 1e-9)
+	assert.NotContains(t, prices, TokenKey(weth))
+
+	_, _, err = NewCoinGecko(client).TokenPrices("nochain", []string{usdc}, "usd")
+	assert.ErrorContains(t, err, "unsupported chain")
+}
+
+func TestDefiLlamaTokenPrices(t *testing.T) {
+	client, _ := routedClient(map[string]string{
+		"/prices/current/bsc:": `{"coins":{"bsc:0x55d398326f99059fF775485246999027B3197955":{"price":1.001,"symbol":"USDT"}}}`,
+	})
+	prices, quoted, err := NewDefiLlama(client).TokenPrices("bnb", []string{"0x55d398326f99059ff775485246999027b3197955"}, "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "usd", quoted)
+	assert.InDelta(t, 1.001, prices["0x55d398326f99059ff775485246999027b3197955"], 1e-9)
+}
+
+func TestGetTokenPricesFallsThroughSources(t *testing.T) {
+	first := &stubSource{name: "first", quoted: "usd", prices: map[string]float64{TokenKey(usdc): 1}}
+	second := &stubSource{name: "second", quoted: "usd", prices: map[string]float64{TokenKey(weth): 3000, TokenKey(usdc): 5}}
+	f := NewFetcher("usd")
+	f.SetSources(first, second)
+
+	quotes, err := f.GetTokenPrices("ethereum", []string{usdc, weth, weth})
+	require.NoError(t, err)
+	assert.Equal(t, Quote{Price: 1, Source: "first"}, quotes[TokenKey(usdc)])
+	assert.Equal(t, Quote{Price: 3000, Source: "second"}, quotes[TokenKey(weth)])
+	require.Len(t, second.asked, 1)
+	assert.Equal(t, []string{weth}, second.asked[0], "later sources are only asked for what's missing")
+}
+
+func TestGetTokenPricesConvertsCurrency(t *testing.T) {
+	f := NewFetcher("eur")
+	client, _ := routedClient(map[string]string{
+		"/exchange_rates": `{"rates":{"usd":{"value":60000},"eur":{"value":54000}}}`,
+	})
+	f.client = client
+	f.SetSources(&stubSource{name: "llama", quoted: "usd", prices: map[string]float64{TokenKey(weth): 3000}})
+
+	quotes, err := f.GetTokenPrices("ethereum", []string{weth})
+	require.NoError(t, err)
+	assert.InDelta(t, 2700, quotes[TokenKey(weth)].Price, 1e-6)
+}
+
+func TestGetTokenPricesUsesCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	src := &stubSource{name: "s", quoted: "usd", prices: map[string]float64{TokenKey(weth): 3000}}
+
+	f := NewFetcher("usd")
+	f.SetSources(src)
+	f.SetCache(NewCache(path, time.Minute))
+	_, err := f.GetTokenPrices("ethereum", []string{weth})
+	require.NoError(t, err)
+
+	// A fresh fetcher with the same cache file doesn't ask the source again.
+	f2 := NewFetcher("usd")
+	f2.SetSources(src)
+	f2.SetCache(NewCache(path, time.Minute))
+	quotes, err := f2.GetTokenPrices("ethereum", []string{weth})
+	require.NoError(t, err)
+	assert.Equal(t, Quote{Price: 3000, Source: "s"}, quotes[TokenKey(weth)])
+	assert.Len(t, src.asked, 1)
+}
+
+func TestCacheExpires(t *testing.T) {
+	c := NewCache(filepath.Join(t.TempDir(), "prices.json"), time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	c.now = func() time.Time { return now }
+	c.Put("usd", "ethereum", weth, 3000, "s")
+
+	p, src, ok := c.Get("usd", "ethereum", strings.ToLower(weth))
+	assert.True(t, ok)
+	assert.Equal(t, 3000.0, p)
+	assert.Equal(t, "s", src)
+
+	_, _, ok = c.Get("eur", "ethereum", weth)
+	assert.False(t, ok, "currency is part of the key")
+
+	now = now.Add(2 * time.Minute)
+	_, _, ok = c.Get("usd", "ethereum", weth)
+	assert.False(t, ok)
+}
+
+func TestGetPricesUsesCacheForNative(t *testing.T) {
+	callCount := 0
+	f := NewFetcher("usd")
+	f.client = &http.Client{Transport: &countingTransport{body: `{"ethereum":{"usd":3000}}`, count: &callCount}}
+	f.SetCache(NewCache(filepath.Join(t.TempDir(), "prices.json"), time.Minute))
+
+	_, err := f.GetPrices([]string{"ethereum"})
+	require.NoError(t, err)
+	p, err := f.GetPrice("base")
+	require.NoError(t, err)
+	assert.InDelta(t, 3000, p, 1e-9)
+	assert.Equal(t, 1, callCount)
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "$12.50", Format(12.5, "USD"))
+	assert.Equal(t, "€3.00", Format(3, "eur"))
+	assert.Equal(t, "7.25 CHF", Format(7.25, "chf"))
+}
+
+// ── on-chain sources ─────────────────────────────────────────────────────────
+
+// mockCaller answers eth_calls by "to|calldata-prefix".
+type mockCaller map[string]string
+
+func (m mockCaller) CallContract(to, data string) (string, error) {
+	for k, v := range m {
+		parts := strings.SplitN(k, "|", 2)
+		if strings.EqualFold(parts[0], to) && strings.HasPrefix(data, parts[1]) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("execution reverted")
+}
+
+func words(vals ...*big.Int) string {
+	var sb strings.Builder
+	sb.WriteString("0x")
+	for _, v := range vals {
+		if v.Sign() < 0 {
+			v = new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		sb.WriteString(fmt.Sprintf("%064x", v))
+	}
+	return sb.String()
+}
+
+func TestChainlinkReadsFeed(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	feed := "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+	caller := mockCaller{
+		feed + "|" + selLatestRoundData: words(big.NewInt(1), big.NewInt(312345000000), big.NewInt(0), big.NewInt(now.Unix()-60), big.NewInt(1)),
+		feed + "|" + selDecimals:        words(big.NewInt(8)),
+	}
+	s := NewChainlink(func(string) (Caller, error) { return caller, nil })
+	s.now = func() time.Time { return now }
+
+	prices, quoted, err := s.TokenPrices("ethereum", []string{weth, "0x0000000000000000000000000000000000000001"}, "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "usd", quoted)
+	assert.InDelta(t, 3123.45, prices[TokenKey(weth)], 1e-9)
+	assert.Len(t, prices, 1)
+
+	// Stale and negative answers are ignored.
+	s.now = func() time.Time { return now.Add(48 * time.Hour) }
+	prices, _, _ = s.TokenPrices("ethereum", []string{weth}, "usd")
+	assert.Empty(t, prices)
+
+	caller[feed+"|"+selLatestRoundData] = words(big.NewInt(1), big.NewInt(-5), big.NewInt(0), big.NewInt(now.Unix()), big.NewInt(1))
+	s.now = func() time.Time { return now }
+	prices, _, _ = s.TokenPrices("ethereum", []string{weth}, "usd")
+	assert.Empty(t, prices)
+}
+
+func TestMeanTickRoundsDown(t *testing.T) {
+	assert.Equal(t, int64(10), meanTick(big.NewInt(0), big.NewInt(105), 10))
+	assert.Equal(t, int64(-11), meanTick(big.NewInt(0), big.NewInt(-105), 10))
+	assert.Equal(t, int64(-10), meanTick(big.NewInt(0), big.NewInt(-100), 10))
+}
+
+func TestTickPrice(t *testing.T) {
+	// WETH (18) is token1 against USDC (6) on mainnet; ~3000 USDC/WETH is a
+	// raw token1/token0 price of 1e18/3000e6.
+	tick := int64(math.Round(math.Log(1e12/3000) / math.Log(1.0001)))
+	assert.InDelta(t, 3000, tickPrice(tick, false, 18, 6), 1)
+
+	// The same market from a token0's point of view.
+	tick = int64(math.Round(math.Log(3000e6/1e18) / math.Log(1.0001)))
+	assert.InDelta(t, 3000, tickPrice(tick, true, 18, 6), 1)
+}
+
+func TestUniswapTWAP(t *testing.T) {
+	market := uniswapMarkets["ethereum"]
+	pool500 := "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+	pool3000 := "0x8ad599c3a0ff1de082011efddc58f1908eb6e6d8"
+
+	window := int64(1800)
+	tick := int64(math.Round(math.Log(1e12/2500) / math.Log(1.0001)))
+	older := big.NewInt(1_000_000)
+	newer := new(big.Int).Add(older, big.NewInt(tick*window))
+
+	caller := mockCaller{
+		market.Factory + "|" + selGetPool + encAddress(weth) + encAddress(market.Quote) + encWord(big.NewInt(500)):  words(new(big.Int).SetBytes(mustHex(pool500))),
+		market.Factory + "|" + selGetPool + encAddress(weth) + encAddress(market.Quote) + encWord(big.NewInt(3000)): words(new(big.Int).SetBytes(mustHex(pool3000))),
+		market.Factory + "|" + selGetPool: words(big.NewInt(0)),
+		pool500 + "|" + selLiquidity:      words(big.NewInt(9000)),
+		pool3000 + "|" + selLiquidity:     words(big.NewInt(100)),
+		pool500 + "|" + selObserve:        words(big.NewInt(64), big.NewInt(160), big.NewInt(2), older, newer, big.NewInt(2), big.NewInt(0), big.NewInt(0)),
+		weth + "|" + selDecimals:          words(big.NewInt(18)),
+	}
+	s := NewUniswapTWAP(func(string) (Caller, error) { return caller, nil })
+
+	prices, quoted, err := s.TokenPrices("ethereum", []string{weth, usdc}, "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "usd", quoted)
+	assert.InDelta(t, 2500, prices[TokenKey(weth)], 1)
+	assert.Equal(t, 1.0, prices[TokenKey(usdc)])
+
+	_, _, err = s.TokenPrices("fantom", []string{weth}, "usd")
+	assert.ErrorContains(t, err, "unsupported chain")
+}
+
+func mustHex(addr string) []byte {
+	b, ok := new(big.Int).SetString(strings.TrimPrefix(addr, "0x"), 16)
+	if !ok {
+		panic(addr)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,163 @@
+// Package resolve turns what a user typed for an EVM address — a raw 0x
+// address, a wallet name, a registered contract name, an address-book label
+// or an ENS name — into a checksummed address.
+package resolve
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Kind says where a resolved address came from.
+type Kind string
+
+const (
+	KindAddress  Kind = "address"
+	KindWallet   Kind = "wallet"
+	KindContract Kind = "contract"
+	KindContact  Kind = "contact"
+	KindENS      Kind = "ens"
+)
+
+// Result is a resolved address.
+type Result struct {
+	Input   string // what the user typed
+	Address string // EIP-55 checksummed
+	Kind    Kind
+}
+
+// Named reports whether the input was a name rather than a raw address.
+func (r *Result) Named() bool { return r.Kind != KindAddress }
+
+// String renders "name (kind) → 0x…", or just the address for raw input.
+func (r *Result) String() string {
+	if !r.Named() {
+		return r.Address
+	}
+	return fmt.Sprintf("%s (%s) → %s", r.Input, r.Kind, r.Address)
+}
+
+// Lookup finds name in one source; ok is false when the source doesn't know
+// it.
+type Lookup func(name string) (address string, ok bool)
+
+// Resolver resolves names against its sources. Nil sources are skipped.
+type Resolver struct {
+	Wallets   Lookup
+	Contracts Lookup
+	Contacts  Lookup
+	ENS       func(name string) (string, error)
+}
+
+// prefixes lets users pin a source when a name exists in more than one,
+// e.g. "contract:router".
+var prefixes = map[string]Kind{
+	"wallet:":   KindWallet,
+	"contract:": KindContract,
+	"contact:":  KindContact,
+	"ens:":      KindENS,
+}
+
+// Resolve returns the address input refers to. Raw addresses are checked
+// against their EIP-55 checksum when they are mixed-case. Names are looked up
+// in wallets, contracts and contacts; a name found in more than one with
+// different addresses is an error rather than a guess. Anything containing a
+// dot is then tried as an ENS name.
+func (r *Resolver) Resolve(input string) (*Result, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty address")
+	}
+
+	for p, kind := range prefixes {
+		if strings.HasPrefix(input, p) {
+			return r.resolveKind(strings.TrimPrefix(input, p), kind)
+		}
+	}
+
+	if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+		addr, err := ParseAddress(input)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Input: input, Address: addr, Kind: KindAddress}, nil
+	}
+
+	var matches []*Result
+	for _, kind := range []Kind{KindWallet, KindContract, KindContact} {
+		if res, ok := r.lookup(input, kind); ok {
+			matches = append(matches, res)
+		}
+	}
+	if len(matches) > 0 {
+		for _, m := range matches[1:] {
+			if m.Address != matches[0].Address {
+				return nil, ambiguous(input, matches)
+			}
+		}
+		return matches[0], nil
+	}
+
+	if strings.Contains(input, ".") && r.ENS != nil {
+		return r.resolveKind(input, KindENS)
+	}
+	return nil, fmt.Errorf("%q is not an address, wallet, contract, contact or ENS name", input)
+}
+
+func (r *Resolver) resolveKind(name string, kind Kind) (*Result, error) {
+	if kind == KindENS {
+		if r.ENS == nil {
+			return nil, fmt.Errorf("ENS resolution is not available")
+		}
+		addr, err := r.ENS(name)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", name, err)
+		}
+		return &Result{Input: name, Address: common.HexToAddress(addr).Hex(), Kind: KindENS}, nil
+	}
+	res, ok := r.lookup(name, kind)
+	if !ok {
+		return nil, fmt.Errorf("no %s named %q", kind, name)
+	}
+	return res, nil
+}
+
+func (r *Resolver) lookup(name string, kind Kind) (*Result, bool) {
+	src := map[Kind]Lookup{KindWallet: r.Wallets, KindContract: r.Contracts, KindContact: r.Contacts}[kind]
+	if src == nil {
+		return nil, false
+	}
+	addr, ok := src(name)
+	if !ok || !common.IsHexAddress(addr) {
+		return nil, false
+	}
+	return &Result{Input: name, Address: common.HexToAddress(addr).Hex(), Kind: kind}, true
+}
+
+func ambiguous(name string, matches []*Result) error {
+	parts := make([]string, len(matches))
+	kinds := make([]string, len(matches))
+	for i, m := range matches {
+		parts[i] = fmt.Sprintf("%s %s", m.Kind, m.Address)
+		kinds[i] = string(m.Kind) + ":" + name
+	}
+	return fmt.Errorf("%q is ambiguous (%s) — use %s", name, strings.Join(parts, ", "), strings.Join(kinds, " or "))
+}
+
+// ParseAddress validates a 0x address and returns it checksummed. A
+// mixed-case address must match its EIP-55 checksum, which catches most
+// single-character typos; all-lowercase and all-uppercase input carries no
+// checksum and is accepted as is.
+func ParseAddress(s string) (string, error) {
+	if !common.IsHexAddress(s) || !(strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) {
+		return "", fmt.Errorf("invalid address %q — want 0x followed by 40 hex characters", s)
+	}
+	checksummed := common.HexToAddress(s).Hex()
+	body := s[2:]
+	if body != strings.ToLower(body) && body != strings.ToUpper(body) && body != checksummed[2:] {
+		return "", fmt.Errorf("address %s fails its EIP-55 checksum (expected %s) — check for a typo", s, checksummed)
+	}
+	return checksummed, nil
+}
@@ -0,0 +1,109 @@
+package resolve
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	vitalik = "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+	usdc    = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+)
+
+func table(m map[string]string) Lookup {
+	return func(name string) (string, bool) {
+		a, ok := m[name]
+		return a, ok
+	}
+}
+
+func testResolver() *Resolver {
+	return &Resolver{
+		Wallets:   table(map[string]string{"deployer": vitalik, "same": usdc, "clash": vitalik}),
+		Contracts: table(map[string]string{"usdc": usdc, "same": usdc, "clash": usdc}),
+		Contacts:  table(map[string]string{"binance": "0x28c6c06298d514db089934071355e5743bf21d60"}),
+		ENS: func(name string) (string, error) {
+			if name == "vitalik.eth" {
+				return "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", nil
+			}
+			return "", errors.New("no resolver")
+		},
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	a, err := ParseAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	require.NoError(t, err)
+	assert.Equal(t, vitalik, a)
+
+	a, err = ParseAddress(vitalik)
+	require.NoError(t, err)
+	assert.Equal(t, vitalik, a)
+
+	// One flipped case → checksum failure.
+	_, err = ParseAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96046")
+	assert.ErrorContains(t, err, "checksum")
+
+	_, err = ParseAddress("0xd8dA6BF2")
+	assert.ErrorContains(t, err, "invalid address")
+}
+
+func TestResolve_Sources(t *testing.T) {
+	r := testResolver()
+	cases := []struct {
+		input string
+		addr  string
+		kind  Kind
+	}{
+		{vitalik, vitalik, KindAddress},
+		{"deployer", vitalik, KindWallet},
+		{"usdc", usdc, KindContract},
+		{"binance", "0x28C6c06298d514Db089934071355E5743bf21d60", KindContact},
+		{"vitalik.eth", vitalik, KindENS},
+		{"same", usdc, KindWallet}, // same address in two sources is fine
+		{"contract:clash", usdc, KindContract},
+		{"wallet:clash", vitalik, KindWallet},
+	}
+	for _, c := range cases {
+		res, err := r.Resolve(c.input)
+		require.NoError(t, err, c.input)
+		assert.Equal(t, c.addr, res.Address, c.input)
+		assert.Equal(t, c.kind, res.Kind, c.input)
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	r := testResolver()
+
+	_, err := r.Resolve("clash")
+	assert.ErrorContains(t, err, "ambiguous")
+	assert.ErrorContains(t, err, "wallet:clash or contract:clash")
+
+	_, err = r.Resolve("nobody")
+	assert.ErrorContains(t, err, "not an address, wallet")
+
+	_, err = r.Resolve("nobody.eth")
+	assert.ErrorContains(t, err, "resolving nobody.eth")
+
+	_, err = r.Resolve("contact:deployer")
+	assert.ErrorContains(t, err, `no contact named "deployer"`)
+
+	_, err = r.Resolve("")
+	assert.Error(t, err)
+}
+
+func TestResolve_SkipsNilSources(t *testing.T) {
+	r := &Resolver{}
+	_, err := r.Resolve("vitalik.eth")
+	assert.Error(t, err)
+	_, err = r.Resolve("ens:vitalik.eth")
+	assert.ErrorContains(t, err, "not available")
+}
+
+func TestResultString(t *testing.T) {
+	assert.Equal(t, vitalik, (&Result{Input: vitalik, Address: vitalik, Kind: KindAddress}).String())
+	assert.Equal(t, "vitalik.eth (ens) → "+vitalik, (&Result{Input: "vitalik.eth", Address: vitalik, Kind: KindENS}).String())
+}
@@ -0,0 +1,168 @@
+package safe
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Reader is the subset of chain.EVMClient that ReadInfo needs.
+type Reader interface {
+	GetCode(address string) (string, error)
+	GetStorageAt(address, slot string) (string, error)
+	CallContract(toAddr, calldata string) (string, error)
+}
+
+// Info is a Safe's on-chain configuration.
+type Info struct {
+	Address         string
+	Version         string
+	Owners          []string
+	Threshold       uint64
+	Nonce           uint64
+	Modules         []string
+	Guard           string // "" when none
+	FallbackHandler string // "" when none
+}
+
+// IsOwner reports whether address is one of the Safe's owners.
+func (i *Info) IsOwner(address common.Address) bool {
+	for _, o := range i.Owners {
+		if common.HexToAddress(o) == address {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// sentinel starts the Safe's linked lists of owners and modules.
+	sentinel = common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	guardSlot           = crypto.Keccak256Hash([]byte("guard_manager.guard.address"))
+	fallbackHandlerSlot = crypto.Keccak256Hash([]byte("fallback_manager.handler.address"))
+)
+
+// modulePage is how many modules one getModulesPaginated call returns.
+const modulePage = 50
+
+// ReadInfo reads owners, threshold, nonce, version, modules, guard and
+// fallback handler from the Safe at address.
+func ReadInfo(r Reader, address string) (*Info, error) {
+	code, err := r.GetCode(address)
+	if err != nil {
+		return nil, err
+	}
+	if c := strings.TrimPrefix(code, "0x"); c == "" || c == "0" {
+		return nil, fmt.Errorf("%s has no code — not a Safe", address)
+	}
+
+	info := &Info{Address: common.HexToAddress(address).Hex()}
+	call := func(sig string, args ...[]byte) ([]byte, error) {
+		data := append([]byte{}, crypto.Keccak256([]byte(sig))[:4]...)
+		for _, a := range args {
+			data = append(data, a...)
+		}
+		out, err := r.CallContract(address, "0x"+hex.EncodeToString(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sig, err)
+		}
+		return hex.DecodeString(strings.TrimPrefix(out, "0x"))
+	}
+
+	owners, err := call("getOwners()")
+	if err != nil {
+		return nil, err
+	}
+	if info.Owners, err = decodeAddressArray(owners, 0); err != nil {
+		return nil, fmt.Errorf("%s does not look like a Safe: %w", address, err)
+	}
+	threshold, err := call("getThreshold()")
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := call("nonce()")
+	if err != nil {
+		return nil, err
+	}
+	if len(threshold) < 32 || len(nonce) < 32 {
+		return nil, fmt.Errorf("%s does not look like a Safe", address)
+	}
+	info.Threshold = new(big.Int).SetBytes(threshold[:32]).Uint64()
+	info.Nonce = new(big.Int).SetBytes(nonce[:32]).Uint64()
+
+	if v, err := call("VERSION()"); err == nil {
+		info.Version = decodeString(v)
+	}
+
+	start := sentinel
+	for {
+		page, err := call("getModulesPaginated(address,uint256)", common.LeftPadBytes(start.Bytes(), 32), math256(big.NewInt(modulePage)))
+		if err != nil || len(page) < 64 {
+			break
+		}
+		mods, err := decodeAddressArray(page, 0)
+		if err != nil {
+			break
+		}
+		info.Modules = append(info.Modules, mods...)
+		next := common.BytesToAddress(page[32:64])
+		if next == sentinel || next == (common.Address{}) || len(mods) < modulePage {
+			break
+		}
+		start = next
+	}
+
+	slot := func(h common.Hash) string {
+		v, err := r.GetStorageAt(address, h.Hex())
+		if err != nil {
+			return ""
+		}
+		a := common.HexToAddress(v)
+		if a == (common.Address{}) {
+			return ""
+		}
+		return a.Hex()
+	}
+	info.Guard = slot(guardSlot)
+	info.FallbackHandler = slot(fallbackHandlerSlot)
+	return info, nil
+}
+
+// decodeAddressArray decodes an ABI address[] whose offset word is at head.
+func decodeAddressArray(b []byte, head int) ([]string, error) {
+	if len(b) < head+32 {
+		return nil, fmt.Errorf("short return data")
+	}
+	off := new(big.Int).SetBytes(b[head : head+32])
+	if !off.IsInt64() || off.Int64()+32 > int64(len(b)) {
+		return nil, fmt.Errorf("bad array offset")
+	}
+	o := int(off.Int64())
+	n := new(big.Int).SetBytes(b[o : o+32])
+	if !n.IsInt64() || int64(o)+32+n.Int64()*32 > int64(len(b)) {
+		return nil, fmt.Errorf("bad array length")
+	}
+	out := make([]string, n.Int64())
+	for i := range out {
+		w := b[o+32+i*32 : o+64+i*32]
+		out[i] = common.BytesToAddress(w).Hex()
+	}
+	return out, nil
+}
+
+// decodeString decodes an ABI string return value; "" if malformed.
+func decodeString(b []byte) string {
+	if len(b) < 64 {
+		return ""
+	}
+	n := new(big.Int).SetBytes(b[32:64])
+	if !n.IsInt64() || 64+n.Int64() > int64(len(b)) {
+		return ""
+	}
+	return string(b[64 : 64+n.Int64()])
+}
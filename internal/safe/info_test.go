@@ -0,0 +1,96 @@
+package safe
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSafe answers eth_call by selector and storage by slot.
+type fakeSafe struct {
+	code    string
+	calls   map[string][]byte // selector hex → return data
+	storage map[string]string
+}
+
+func (f *fakeSafe) GetCode(string) (string, error) { return f.code, nil }
+
+func (f *fakeSafe) GetStorageAt(_, slot string) (string, error) {
+	if v, ok := f.storage[strings.ToLower(slot)]; ok {
+		return v, nil
+	}
+	return "0x" + strings.Repeat("0", 64), nil
+}
+
+func (f *fakeSafe) CallContract(_, data string) (string, error) {
+	if out, ok := f.calls[data[:10]]; ok {
+		return "0x" + hex.EncodeToString(out), nil
+	}
+	return "", fmt.Errorf("execution reverted")
+}
+
+func sel(sig string) string { return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(sig))[:4]) }
+
+func addrArray(addrs ...common.Address) []byte {
+	out := math256(big.NewInt(32))
+	out = append(out, math256(big.NewInt(int64(len(addrs))))...)
+	for _, a := range addrs {
+		out = append(out, common.LeftPadBytes(a.Bytes(), 32)...)
+	}
+	return out
+}
+
+func TestReadInfo(t *testing.T) {
+	o1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	o2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	mod := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	guard := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	version := append(math256(big.NewInt(32)), math256(big.NewInt(5))...)
+	version = append(version, common.RightPadBytes([]byte("1.4.1"), 32)...)
+
+	// getModulesPaginated returns (address[] array, address next).
+	modules := append(math256(big.NewInt(64)), common.LeftPadBytes(sentinel.Bytes(), 32)...)
+	modules = append(modules, addrArray(mod)[32:]...)
+
+	f := &fakeSafe{
+		code: "0x6080",
+		calls: map[string][]byte{
+			sel("getOwners()"):    addrArray(o1, o2),
+			sel("getThreshold()"): math256(big.NewInt(2)),
+			sel("nonce()"):        math256(big.NewInt(41)),
+			sel("VERSION()"):      version,
+			sel("getModulesPaginated(address,uint256)"): modules,
+		},
+		storage: map[string]string{
+			strings.ToLower(guardSlot.Hex()): common.BytesToHash(guard.Bytes()).Hex(),
+		},
+	}
+
+	info, err := ReadInfo(f, "0x5afe5afe5afe5afe5afe5afe5afe5afe5afe5afe")
+	require.NoError(t, err)
+	assert.Equal(t, []string{o1.Hex(), o2.Hex()}, info.Owners)
+	assert.Equal(t, uint64(2), info.Threshold)
+	assert.Equal(t, uint64(41), info.Nonce)
+	assert.Equal(t, "1.4.1", info.Version)
+	assert.Equal(t, []string{mod.Hex()}, info.Modules)
+	assert.Equal(t, guard.Hex(), info.Guard)
+	assert.Empty(t, info.FallbackHandler)
+	assert.True(t, info.IsOwner(o2))
+	assert.False(t, info.IsOwner(mod))
+}
+
+func TestReadInfoNotASafe(t *testing.T) {
+	_, err := ReadInfo(&fakeSafe{code: "0x"}, "0x1")
+	assert.ErrorContains(t, err, "no code")
+
+	_, err = ReadInfo(&fakeSafe{code: "0x6080", calls: map[string][]byte{}}, "0x1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package safe
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MultiSendCallOnly is the canonical MultiSendCallOnly v1.3.0 deployment. The
+// Safe delegatecalls it to run a batch; it refuses nested delegatecalls.
+var MultiSendCallOnly = common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+
+var selMultiSend = crypto.Keccak256([]byte("multiSend(bytes)"))[:4]
+
+// Call is one call in a batch.
+type Call struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// MultiSendData packs calls for multiSend(bytes): each call is
+// operation (1) ‖ to (20) ‖ value (32) ‖ data length (32) ‖ data.
+func MultiSendData(calls []Call) []byte {
+	var packed []byte
+	for _, c := range calls {
+		value := c.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		packed = append(packed, byte(OpCall))
+		packed = append(packed, c.To.Bytes()...)
+		packed = append(packed, math256(value)...)
+		packed = append(packed, math256(big.NewInt(int64(len(c.Data))))...)
+		packed = append(packed, c.Data...)
+	}
+	out := append([]byte{}, selMultiSend...)
+	out = append(out, math256(big.NewInt(32))...)
+	return append(out, dynBytes(packed)...)
+}
+
+// BatchTx returns a SafeTx running calls in order through multiSend. A
+// single call is sent directly.
+func BatchTx(multiSend common.Address, calls []Call, nonce uint64) (Tx, error) {
+	switch len(calls) {
+	case 0:
+		return Tx{}, fmt.Errorf("batch is empty")
+	case 1:
+		return NewTx(calls[0].To, calls[0].Value, calls[0].Data, OpCall, nonce), nil
+	}
+	return NewTx(multiSend, nil, MultiSendData(calls), OpDelegateCall, nonce), nil
+}
@@ -0,0 +1,55 @@
+package safe
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSendData(t *testing.T) {
+	a := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	b := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := MultiSendData([]Call{
+		{To: a, Value: big.NewInt(5)},
+		{To: b, Data: []byte{0xde, 0xad}},
+	})
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"multiSend","inputs":[{"name":"transactions","type":"bytes"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	args, err := parsed.Methods["multiSend"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	packed := args[0].([]byte)
+
+	require.Len(t, packed, 2*(1+20+32+32)+2)
+	assert.Equal(t, byte(0), packed[0])
+	assert.Equal(t, a.Bytes(), packed[1:21])
+	assert.Equal(t, byte(5), packed[52])
+	second := packed[85:]
+	assert.Equal(t, b.Bytes(), second[1:21])
+	assert.Equal(t, byte(2), second[84])
+	assert.Equal(t, []byte{0xde, 0xad}, second[85:])
+}
+
+func TestBatchTx(t *testing.T) {
+	a := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	_, err := BatchTx(MultiSendCallOnly, nil, 0)
+	assert.Error(t, err)
+
+	tx, err := BatchTx(MultiSendCallOnly, []Call{{To: a, Value: big.NewInt(1)}}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, a.Hex(), tx.To, "a single call is not wrapped")
+	assert.Equal(t, OpCall, tx.Operation)
+
+	tx, err = BatchTx(MultiSendCallOnly, []Call{{To: a}, {To: a}}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, MultiSendCallOnly.Hex(), tx.To)
+	assert.Equal(t, OpDelegateCall, tx.Operation)
+	assert.Equal(t, "0", tx.Value)
+	assert.Equal(t, uint64(3), tx.Nonce)
+}
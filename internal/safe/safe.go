@@ -0,0 +1,396 @@
+// Package safe builds, hashes and signs Safe (formerly Gnosis Safe) multisig
+// transactions. Everything except reading the Safe's state works offline: a
+// proposal is a JSON file that collects owner signatures until it can be
+// executed.
+package safe
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Version is the current proposal file format version.
+const Version = 1
+
+// KindSafeTx marks a proposal file.
+const KindSafeTx = "safe-tx"
+
+// Operation is how the Safe runs a transaction.
+type Operation uint8
+
+const (
+	OpCall         Operation = 0
+	OpDelegateCall Operation = 1
+)
+
+var (
+	safeTxTypeHash = crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	// Safes before 1.3.0 leave the chain ID out of the domain.
+	legacyDomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(address verifyingContract)"))
+
+	selExecTransaction = crypto.Keccak256([]byte("execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)"))[:4]
+)
+
+// Tx is a SafeTx. Amounts are decimal strings so files stay readable.
+type Tx struct {
+	To             string    `json:"to"`
+	Value          string    `json:"value"` // wei
+	Data           string    `json:"data,omitempty"`
+	Operation      Operation `json:"operation"`
+	SafeTxGas      string    `json:"safeTxGas"`
+	BaseGas        string    `json:"baseGas"`
+	GasPrice       string    `json:"gasPrice"`
+	GasToken       string    `json:"gasToken"`
+	RefundReceiver string    `json:"refundReceiver"`
+	Nonce          uint64    `json:"nonce"`
+}
+
+// NewTx returns a SafeTx without refunds: the executor pays the gas.
+func NewTx(to common.Address, value *big.Int, data []byte, op Operation, nonce uint64) Tx {
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := Tx{
+		To:             to.Hex(),
+		Value:          value.String(),
+		Operation:      op,
+		SafeTxGas:      "0",
+		BaseGas:        "0",
+		GasPrice:       "0",
+		GasToken:       common.Address{}.Hex(),
+		RefundReceiver: common.Address{}.Hex(),
+		Nonce:          nonce,
+	}
+	if len(data) > 0 {
+		tx.Data = "0x" + hex.EncodeToString(data)
+	}
+	return tx
+}
+
+// fields is the parsed form of a Tx.
+type fields struct {
+	to, gasToken, refundReceiver        common.Address
+	value, safeTxGas, baseGas, gasPrice *big.Int
+	data                                []byte
+}
+
+func (t Tx) parse() (*fields, error) {
+	f := &fields{}
+	for name, a := range map[string]struct {
+		s   string
+		dst *common.Address
+	}{"to": {t.To, &f.to}, "gasToken": {t.GasToken, &f.gasToken}, "refundReceiver": {t.RefundReceiver, &f.refundReceiver}} {
+		if !common.IsHexAddress(a.s) {
+			return nil, fmt.Errorf("invalid %s address %q", name, a.s)
+		}
+		*a.dst = common.HexToAddress(a.s)
+	}
+	for name, n := range map[string]struct {
+		s   string
+		dst **big.Int
+	}{"value": {t.Value, &f.value}, "safeTxGas": {t.SafeTxGas, &f.safeTxGas}, "baseGas": {t.BaseGas, &f.baseGas}, "gasPrice": {t.GasPrice, &f.gasPrice}} {
+		v, ok := new(big.Int).SetString(n.s, 10)
+		if !ok || v.Sign() < 0 {
+			return nil, fmt.Errorf("invalid %s %q — expected a decimal integer", name, n.s)
+		}
+		*n.dst = v
+	}
+	if t.Operation > OpDelegateCall {
+		return nil, fmt.Errorf("invalid operation %d — 0 (call) or 1 (delegatecall)", t.Operation)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(t.Data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	f.data = data
+	return f, nil
+}
+
+// DomainSeparator is the EIP-712 domain of a Safe. version is the Safe's
+// VERSION(); "" means 1.3.0 or later.
+func DomainSeparator(chainID int64, safe common.Address, version string) common.Hash {
+	if version != "" && !atLeast(version, 1, 3) {
+		return crypto.Keccak256Hash(legacyDomainTypeHash[:], common.LeftPadBytes(safe.Bytes(), 32))
+	}
+	return crypto.Keccak256Hash(domainTypeHash[:], math256(big.NewInt(chainID)), common.LeftPadBytes(safe.Bytes(), 32))
+}
+
+// Hash returns the EIP-712 SafeTx hash owners sign.
+func (t Tx) Hash(chainID int64, safe common.Address, version string) (common.Hash, error) {
+	f, err := t.parse()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	structHash := crypto.Keccak256Hash(
+		safeTxTypeHash[:],
+		common.LeftPadBytes(f.to.Bytes(), 32),
+		math256(f.value),
+		crypto.Keccak256(f.data),
+		math256(big.NewInt(int64(t.Operation))),
+		math256(f.safeTxGas),
+		math256(f.baseGas),
+		math256(f.gasPrice),
+		common.LeftPadBytes(f.gasToken.Bytes(), 32),
+		common.LeftPadBytes(f.refundReceiver.Bytes(), 32),
+		math256(new(big.Int).SetUint64(t.Nonce)),
+	)
+	domain := DomainSeparator(chainID, safe, version)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domain[:], structHash[:]), nil
+}
+
+// ExecData is the calldata for Safe.execTransaction with packed signatures.
+func (t Tx) ExecData(signatures []byte) ([]byte, error) {
+	f, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+	const head = 10 * 32
+	dataEnc := dynBytes(f.data)
+	out := append([]byte{}, selExecTransaction...)
+	out = append(out, common.LeftPadBytes(f.to.Bytes(), 32)...)
+	out = append(out, math256(f.value)...)
+	out = append(out, math256(big.NewInt(head))...)
+	out = append(out, math256(big.NewInt(int64(t.Operation)))...)
+	out = append(out, math256(f.safeTxGas)...)
+	out = append(out, math256(f.baseGas)...)
+	out = append(out, math256(f.gasPrice)...)
+	out = append(out, common.LeftPadBytes(f.gasToken.Bytes(), 32)...)
+	out = append(out, common.LeftPadBytes(f.refundReceiver.Bytes(), 32)...)
+	out = append(out, math256(big.NewInt(int64(head+len(dataEnc))))...)
+	out = append(out, dataEnc...)
+	return append(out, dynBytes(signatures)...), nil
+}
+
+// ── proposal file ────────────────────────────────────────────────────────────
+
+// Signature is one owner's signature over the SafeTx hash.
+type Signature struct {
+	Signer   string `json:"signer"`
+	Data     string `json:"data"` // 65-byte r ‖ s ‖ v, hex
+	SignedAt string `json:"signedAt,omitempty"`
+}
+
+// Document is a proposed Safe transaction and the signatures collected so far.
+type Document struct {
+	Kind        string      `json:"kind"`
+	Version     int         `json:"version"`
+	Network     string      `json:"network"`
+	ChainID     int64       `json:"chainId"`
+	Safe        string      `json:"safe"`
+	SafeVersion string      `json:"safeVersion,omitempty"`
+	Tx          Tx          `json:"tx"`
+	SafeTxHash  string      `json:"safeTxHash"`
+	Signatures  []Signature `json:"signatures"`
+	Note        string      `json:"note,omitempty"`
+	CreatedAt   string      `json:"createdAt"`
+}
+
+// NewDocument builds a proposal and computes its hash.
+func NewDocument(network string, chainID int64, safe common.Address, safeVersion string, tx Tx, note, createdAt string) (*Document, error) {
+	d := &Document{
+		Kind:        KindSafeTx,
+		Version:     Version,
+		Network:     network,
+		ChainID:     chainID,
+		Safe:        safe.Hex(),
+		SafeVersion: safeVersion,
+		Tx:          tx,
+		Signatures:  []Signature{},
+		Note:        note,
+		CreatedAt:   createdAt,
+	}
+	h, err := tx.Hash(chainID, safe, safeVersion)
+	if err != nil {
+		return nil, err
+	}
+	d.SafeTxHash = h.Hex()
+	return d, nil
+}
+
+// Validate checks the file and that safeTxHash matches its contents, so a
+// signer never signs a hash that describes a different transaction.
+func (d *Document) Validate() error {
+	if d.Kind != KindSafeTx {
+		return fmt.Errorf("not a Safe transaction file (kind %q)", d.Kind)
+	}
+	if d.Version != Version {
+		return fmt.Errorf("unsupported file version %d", d.Version)
+	}
+	if d.ChainID <= 0 {
+		return fmt.Errorf("chainId is required")
+	}
+	if !common.IsHexAddress(d.Safe) {
+		return fmt.Errorf("invalid safe address %q", d.Safe)
+	}
+	h, err := d.Tx.Hash(d.ChainID, common.HexToAddress(d.Safe), d.SafeVersion)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(h.Hex(), d.SafeTxHash) {
+		return fmt.Errorf("safeTxHash %s does not match the transaction (%s) — the file was modified", d.SafeTxHash, h.Hex())
+	}
+	for _, s := range d.Signatures {
+		signer, err := recoverSigner(h, s.Data)
+		if err != nil {
+			return fmt.Errorf("signature by %s: %w", s.Signer, err)
+		}
+		if !strings.EqualFold(signer.Hex(), s.Signer) {
+			return fmt.Errorf("signature claims %s but was made by %s", s.Signer, signer.Hex())
+		}
+	}
+	return nil
+}
+
+// AddSignature verifies sig against the proposal and records it, replacing
+// an earlier signature by the same owner. Both direct EIP-712 signatures
+// (v = 27/28) and eth_sign signatures (v = 31/32) are accepted.
+func (d *Document) AddSignature(sig []byte, signedAt string) (common.Address, error) {
+	sigHex := "0x" + hex.EncodeToString(sig)
+	signer, err := recoverSigner(common.HexToHash(d.SafeTxHash), sigHex)
+	if err != nil {
+		return common.Address{}, err
+	}
+	entry := Signature{Signer: signer.Hex(), Data: sigHex, SignedAt: signedAt}
+	for i, s := range d.Signatures {
+		if strings.EqualFold(s.Signer, entry.Signer) {
+			d.Signatures[i] = entry
+			return signer, nil
+		}
+	}
+	d.Signatures = append(d.Signatures, entry)
+	return signer, nil
+}
+
+// Signed reports whether owner has signed.
+func (d *Document) Signed(owner common.Address) bool {
+	for _, s := range d.Signatures {
+		if common.HexToAddress(s.Signer) == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// PackSignatures concatenates the signatures sorted by signer, as
+// execTransaction requires. approvedBy adds a pre-validated signature for
+// an owner who is also the executor (msg.sender), so they need not sign.
+func (d *Document) PackSignatures(approvedBy *common.Address) ([]byte, error) {
+	type sig struct {
+		signer common.Address
+		data   []byte
+	}
+	var sigs []sig
+	for _, s := range d.Signatures {
+		b, err := hex.DecodeString(strings.TrimPrefix(s.Data, "0x"))
+		if err != nil || len(b) != 65 {
+			return nil, fmt.Errorf("invalid signature by %s", s.Signer)
+		}
+		sigs = append(sigs, sig{common.HexToAddress(s.Signer), b})
+	}
+	if approvedBy != nil && !d.Signed(*approvedBy) {
+		// r = owner, s = 0, v = 1: "msg.sender approves".
+		b := make([]byte, 65)
+		copy(b[12:32], approvedBy.Bytes())
+		b[64] = 1
+		sigs = append(sigs, sig{*approvedBy, b})
+	}
+	sort.Slice(sigs, func(i, j int) bool {
+		return strings.ToLower(sigs[i].signer.Hex()) < strings.ToLower(sigs[j].signer.Hex())
+	})
+	var out []byte
+	for _, s := range sigs {
+		out = append(out, s.data...)
+	}
+	return out, nil
+}
+
+// recoverSigner returns the owner that produced sigHex over hash.
+func recoverSigner(hash common.Hash, sigHex string) (common.Address, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil || len(sig) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes of hex")
+	}
+	digest := hash.Bytes()
+	v := sig[64]
+	switch {
+	case v == 27 || v == 28:
+	case v == 31 || v == 32:
+		digest = crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash.Bytes())
+		v -= 4
+	default:
+		return common.Address{}, fmt.Errorf("unsupported signature type v=%d", v)
+	}
+	raw := append([]byte{}, sig...)
+	raw[64] = v - 27
+	pub, err := crypto.SigToPub(digest, raw)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("recovering signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Load reads and validates a proposal file.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Document
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// Save writes a proposal file.
+func Save(path string, d *Document) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ── helpers ─────────────────────────────────────────────────────────────────
+
+// atLeast reports whether a Safe version like "1.3.0+L2" is ≥ major.minor.
+func atLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(strings.SplitN(version, "+", 2)[0], ".", 3)
+	if len(parts) < 2 {
+		return true
+	}
+	maj, err1 := strconv.Atoi(parts[0])
+	mnr, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	return maj > major || (maj == major && mnr >= minor)
+}
+
+// math256 left-pads n to a 32-byte word.
+func math256(n *big.Int) []byte {
+	return common.LeftPadBytes(n.Bytes(), 32)
+}
+
+// dynBytes ABI-encodes a dynamic bytes value: length word + right-padded data.
+func dynBytes(b []byte) []byte {
+	out := math256(big.NewInt(int64(len(b))))
+	out = append(out, b...)
+	if pad := (32 - len(b)%32) % 32; pad > 0 {
+		out = append(out, make([]byte, pad)...)
+	}
+	return out
+}
@@ -0,0 +1,205 @@
+package safe
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSafe = common.HexToAddress("0x5afe5afE5afE5afE5afE5aFe5aFe5Afe5Afe5AfE")
+	testTo   = common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+)
+
+func testTx() Tx {
+	return NewTx(testTo, big.NewInt(1e18), []byte{0xa9, 0x05, 0x9c, 0xbb}, OpCall, 7)
+}
+
+func testKey(t *testing.T, seed string) (*ecdsa.PrivateKey, common.Address) {
+	t.Helper()
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte(seed)))
+	require.NoError(t, err)
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, digest []byte) []byte {
+	t.Helper()
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	sig[64] += 27
+	return sig
+}
+
+func TestTypeHashes(t *testing.T) {
+	assert.Equal(t, "0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8", safeTxTypeHash.Hex())
+	assert.Equal(t, "0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218", domainTypeHash.Hex())
+	assert.Equal(t, "0x6a761202", hexutil.Encode(selExecTransaction))
+	assert.Equal(t, "0x8d80ff0a", hexutil.Encode(selMultiSend))
+}
+
+func TestHashMatchesEIP712(t *testing.T) {
+	tx := testTx()
+	got, err := tx.Hash(8453, testSafe, "1.4.1")
+	require.NoError(t, err)
+
+	// Encode the EIP-712 struct by hand, word by word, as the Safe contract does.
+	word := func(v int64) []byte { return common.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+	struct_ := crypto.Keccak256(
+		safeTxTypeHash[:],
+		common.LeftPadBytes(testTo.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(1e18).Bytes(), 32),
+		crypto.Keccak256([]byte{0xa9, 0x05, 0x9c, 0xbb}),
+		word(0), word(0), word(0), word(0), word(0), word(0), word(7),
+	)
+	domain := crypto.Keccak256(domainTypeHash[:], word(8453), common.LeftPadBytes(testSafe.Bytes(), 32))
+	want := crypto.Keccak256([]byte{0x19, 0x01}, domain, struct_)
+	assert.Equal(t, hexutil.Encode(want), got.Hex())
+
+	// The chain ID is part of the domain from 1.3.0 on, not before.
+	other, _ := tx.Hash(1, testSafe, "1.3.0+L2")
+	assert.NotEqual(t, got, other)
+	a, _ := tx.Hash(1, testSafe, "1.1.1")
+	b, _ := tx.Hash(8453, testSafe, "1.1.1")
+	assert.Equal(t, a, b)
+}
+
+func TestHashRejectsBadFields(t *testing.T) {
+	for _, mutate := range []func(*Tx){
+		func(tx *Tx) { tx.To = "0x12" },
+		func(tx *Tx) { tx.Value = "-1" },
+		func(tx *Tx) { tx.SafeTxGas = "0x10" },
+		func(tx *Tx) { tx.Operation = 2 },
+		func(tx *Tx) { tx.Data = "0xzz" },
+	} {
+		tx := testTx()
+		mutate(&tx)
+		_, err := tx.Hash(1, testSafe, "")
+		assert.Error(t, err)
+	}
+}
+
+func TestDocumentSignatures(t *testing.T) {
+	d, err := NewDocument("base", 8453, testSafe, "1.3.0", testTx(), "pay", "2026-01-01T00:00:00Z")
+	require.NoError(t, err)
+	hash := common.HexToHash(d.SafeTxHash)
+
+	k1, a1 := testKey(t, "owner-1")
+	k2, a2 := testKey(t, "owner-2")
+
+	got, err := d.AddSignature(sign(t, k1, hash[:]), "")
+	require.NoError(t, err)
+	assert.Equal(t, a1, got)
+
+	// eth_sign style (v + 4) signatures, as produced by hardware wallets.
+	ethSign := sign(t, k2, crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash[:]))
+	ethSign[64] += 4
+	got, err = d.AddSignature(ethSign, "")
+	require.NoError(t, err)
+	assert.Equal(t, a2, got)
+
+	// Re-signing replaces instead of duplicating.
+	_, err = d.AddSignature(sign(t, k1, hash[:]), "later")
+	require.NoError(t, err)
+	require.Len(t, d.Signatures, 2)
+	assert.True(t, d.Signed(a1))
+	require.NoError(t, d.Validate())
+
+	packed, err := d.PackSignatures(nil)
+	require.NoError(t, err)
+	require.Len(t, packed, 130)
+	first := a1
+	if strings.ToLower(a2.Hex()) < strings.ToLower(a1.Hex()) {
+		first = a2
+	}
+	firstSig := d.Signatures[0].Data
+	if !strings.EqualFold(d.Signatures[0].Signer, first.Hex()) {
+		firstSig = d.Signatures[1].Data
+	}
+	assert.Equal(t, firstSig, "0x"+hex.EncodeToString(packed[:65]), "sorted by signer")
+
+	// The executor can approve as msg.sender without signing.
+	_, a3 := testKey(t, "owner-3")
+	packed, err = d.PackSignatures(&a3)
+	require.NoError(t, err)
+	require.Len(t, packed, 195)
+	var found bool
+	for i := 0; i < 3; i++ {
+		s := packed[i*65 : (i+1)*65]
+		if common.BytesToAddress(s[12:32]) == a3 && s[64] == 1 {
+			found = true
+		}
+	}
+	assert.True(t, found)
+	packed, _ = d.PackSignatures(&a1)
+	assert.Len(t, packed, 130, "an owner who signed is not approved twice")
+
+	_, err = d.AddSignature([]byte{1, 2, 3}, "")
+	assert.Error(t, err)
+}
+
+func TestDocumentValidateDetectsTampering(t *testing.T) {
+	d, err := NewDocument("base", 8453, testSafe, "", testTx(), "", "")
+	require.NoError(t, err)
+	k, _ := testKey(t, "owner-1")
+	hash := common.HexToHash(d.SafeTxHash)
+	_, err = d.AddSignature(sign(t, k, hash[:]), "")
+	require.NoError(t, err)
+
+	d.Tx.Value = "2000000000000000000"
+	assert.ErrorContains(t, d.Validate(), "modified")
+
+	d.Tx.Value = "1000000000000000000"
+	d.Signatures[0].Signer = testTo.Hex()
+	assert.Error(t, d.Validate())
+}
+
+func TestSaveLoad(t *testing.T) {
+	d, err := NewDocument("base", 8453, testSafe, "1.4.1", testTx(), "", "")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "safe-tx.json")
+	require.NoError(t, Save(path, d))
+	got, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, d, got)
+}
+
+func TestExecData(t *testing.T) {
+	const execABI = `[{"type":"function","name":"execTransaction","inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}],"outputs":[{"type":"bool"}]}]`
+	parsed, err := abi.JSON(strings.NewReader(execABI))
+	require.NoError(t, err)
+
+	sigs := make([]byte, 130)
+	sigs[0] = 0xaa
+	data, err := testTx().ExecData(sigs)
+	require.NoError(t, err)
+
+	args, err := parsed.Methods["execTransaction"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, testTo, args[0])
+	assert.Equal(t, big.NewInt(1e18), args[1])
+	assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, args[2])
+	assert.Equal(t, uint8(0), args[3])
+	assert.Equal(t, sigs, args[9])
+}
+
+func TestAtLeast(t *testing.T) {
+	assert.True(t, atLeast("1.3.0", 1, 3))
+	assert.True(t, atLeast("1.4.1+L2", 1, 3))
+	assert.False(t, atLeast("1.2.0", 1, 3))
+	assert.False(t, atLeast("0.1.0", 1, 3))
+	assert.True(t, atLeast("weird", 1, 3))
+}
@@ -0,0 +1,103 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PublicKey is a 32-byte Solana account address.
+type PublicKey [32]byte
+
+// ParsePublicKey decodes a base58 address.
+func ParsePublicKey(s string) (PublicKey, error) {
+	var pk PublicKey
+	b, err := Base58Decode(strings.TrimSpace(s))
+	if err != nil {
+		return pk, fmt.Errorf("invalid Solana address %q: %w", s, err)
+	}
+	if len(b) != 32 {
+		return pk, fmt.Errorf("invalid Solana address %q: %d bytes, want 32", s, len(b))
+	}
+	copy(pk[:], b)
+	return pk, nil
+}
+
+// MustPublicKey is ParsePublicKey for compile-time constants.
+func MustPublicKey(s string) PublicKey {
+	pk, err := ParsePublicKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return pk
+}
+
+// IsAddress reports whether s is a base58 32-byte address.
+func IsAddress(s string) bool {
+	_, err := ParsePublicKey(s)
+	return err == nil
+}
+
+// String returns the base58 address.
+func (p PublicKey) String() string { return Base58Encode(p[:]) }
+
+// GenerateKey creates a new ed25519 keypair.
+func GenerateKey() (ed25519.PrivateKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	return priv, err
+}
+
+// ParsePrivateKey accepts the key formats Solana wallets export:
+//   - base58 of the 64-byte secret key (Phantom "Export Private Key")
+//   - base58 of the 32-byte seed
+//   - a JSON byte array of 64 (or 32) numbers (solana-keygen id.json)
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	s = strings.TrimSpace(s)
+	var raw []byte
+	if strings.HasPrefix(s, "[") {
+		var nums []int
+		if err := json.Unmarshal([]byte(s), &nums); err != nil {
+			return nil, fmt.Errorf("parsing keypair JSON: %w", err)
+		}
+		raw = make([]byte, len(nums))
+		for i, n := range nums {
+			if n < 0 || n > 255 {
+				return nil, fmt.Errorf("keypair JSON: byte %d out of range", i)
+			}
+			raw[i] = byte(n)
+		}
+	} else {
+		b, err := Base58Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		raw = b
+	}
+
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		priv := ed25519.NewKeyFromSeed(raw[:32])
+		if string(priv[32:]) != string(raw[32:]) {
+			return nil, fmt.Errorf("secret key and public key do not match")
+		}
+		return priv, nil
+	}
+	return nil, fmt.Errorf("secret key is %d bytes, want 32 or 64", len(raw))
+}
+
+// EncodePrivateKey returns the base58 64-byte secret key, the format Phantom
+// and most wallets import.
+func EncodePrivateKey(priv ed25519.PrivateKey) string {
+	return Base58Encode(priv)
+}
+
+// PublicKeyOf returns the address of priv.
+func PublicKeyOf(priv ed25519.PrivateKey) PublicKey {
+	var pk PublicKey
+	copy(pk[:], priv.Public().(ed25519.PublicKey))
+	return pk
+}
@@ -0,0 +1,95 @@
+package solana
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// Well-known program IDs.
+var (
+	SystemProgramID          = MustPublicKey("11111111111111111111111111111111")
+	TokenProgramID           = MustPublicKey("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	Token2022ProgramID       = MustPublicKey("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	AssociatedTokenProgramID = MustPublicKey("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+)
+
+// Edwards25519 field constants for the on-curve check.
+var (
+	fieldP = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	curveD = func() *big.Int { // -121665 / 121666 mod p
+		num := new(big.Int).Sub(fieldP, big.NewInt(121665))
+		den := new(big.Int).ModInverse(big.NewInt(121666), fieldP)
+		return num.Mul(num, den).Mod(num, fieldP)
+	}()
+	legendreExp = new(big.Int).Rsh(new(big.Int).Sub(fieldP, big.NewInt(1)), 1)
+)
+
+// IsOnCurve reports whether b decompresses to an ed25519 point. Program
+// derived addresses must not, so no private key can ever sign for them.
+func IsOnCurve(b [32]byte) bool {
+	le := b
+	le[31] &= 0x7f
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	y := new(big.Int).SetBytes(le[:])
+	y.Mod(y, fieldP)
+
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, fieldP)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, fieldP)
+	v := new(big.Int).Mul(curveD, y2)
+	v.Add(v, big.NewInt(1)).Mod(v, fieldP)
+
+	// x² = u / v must be a square (or zero).
+	x2 := new(big.Int).ModInverse(v, fieldP)
+	if x2 == nil {
+		return false
+	}
+	x2.Mul(x2, u).Mod(x2, fieldP)
+	if x2.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).Exp(x2, legendreExp, fieldP).Cmp(big.NewInt(1)) == 0
+}
+
+// CreateProgramAddress derives an address from seeds and a program ID. It
+// fails when the hash lands on the curve.
+func CreateProgramAddress(seeds [][]byte, program PublicKey) (PublicKey, error) {
+	var pk PublicKey
+	h := sha256.New()
+	for _, s := range seeds {
+		if len(s) > 32 {
+			return pk, fmt.Errorf("seed longer than 32 bytes")
+		}
+		h.Write(s)
+	}
+	h.Write(program[:])
+	h.Write([]byte("ProgramDerivedAddress"))
+	copy(pk[:], h.Sum(nil))
+	if IsOnCurve(pk) {
+		return pk, fmt.Errorf("derived address is on the ed25519 curve")
+	}
+	return pk, nil
+}
+
+// FindProgramAddress searches bump seeds from 255 down for the first
+// off-curve address, as the runtime's find_program_address does.
+func FindProgramAddress(seeds [][]byte, program PublicKey) (PublicKey, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		withBump := append(append([][]byte{}, seeds...), []byte{byte(bump)})
+		if pk, err := CreateProgramAddress(withBump, program); err == nil {
+			return pk, uint8(bump), nil
+		}
+	}
+	return PublicKey{}, 0, fmt.Errorf("no viable bump seed")
+}
+
+// AssociatedTokenAddress returns owner's canonical token account for mint
+// under tokenProgram (TokenProgramID or Token2022ProgramID).
+func AssociatedTokenAddress(owner, mint, tokenProgram PublicKey) (PublicKey, error) {
+	pk, _, err := FindProgramAddress([][]byte{owner[:], tokenProgram[:], mint[:]}, AssociatedTokenProgramID)
+	return pk, err
+}
@@ -0,0 +1,200 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// base58
+// ---------------------------------------------------------------------------
+
+func TestBase58KnownVectors(t *testing.T) {
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", Base58Encode([]byte("Hello World!")))
+	assert.Equal(t, "11111111111111111111111111111111", Base58Encode(make([]byte, 32)))
+
+	b, err := Base58Decode("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	require.NoError(t, err)
+	assert.Equal(t, "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9", hex.EncodeToString(b))
+}
+
+func TestBase58RoundTripKeepsLeadingZeros(t *testing.T) {
+	in := []byte{0, 0, 1, 2, 3, 255}
+	out, err := Base58Decode(Base58Encode(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestBase58DecodeRejectsBadCharacters(t *testing.T) {
+	_, err := Base58Decode("0OIl")
+	assert.Error(t, err)
+	_, err = Base58Decode("")
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// keys
+// ---------------------------------------------------------------------------
+
+func TestParsePublicKey(t *testing.T) {
+	pk, err := ParsePublicKey("11111111111111111111111111111111")
+	require.NoError(t, err)
+	assert.Equal(t, PublicKey{}, pk)
+	assert.Equal(t, "11111111111111111111111111111111", pk.String())
+
+	assert.False(t, IsAddress("0x1234"))
+	assert.False(t, IsAddress("2NEpo7TZRRrLZSi2U")) // valid base58, wrong length
+	assert.True(t, IsAddress(TokenProgramID.String()))
+}
+
+func TestParsePrivateKeyFormats(t *testing.T) {
+	priv, err := GenerateKey()
+	require.NoError(t, err)
+	want := PublicKeyOf(priv)
+
+	// Phantom: base58 of the 64-byte secret key.
+	got, err := ParsePrivateKey(EncodePrivateKey(priv))
+	require.NoError(t, err)
+	assert.Equal(t, want, PublicKeyOf(got))
+
+	// Seed only.
+	got, err = ParsePrivateKey(Base58Encode(priv.Seed()))
+	require.NoError(t, err)
+	assert.Equal(t, want, PublicKeyOf(got))
+
+	// solana-keygen id.json.
+	nums := make([]int, len(priv))
+	for i, b := range priv {
+		nums[i] = int(b)
+	}
+	js, _ := json.Marshal(nums)
+	got, err = ParsePrivateKey("  " + string(js) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, want, PublicKeyOf(got))
+}
+
+func TestParsePrivateKeyRejectsMismatchedPublicHalf(t *testing.T) {
+	priv, _ := GenerateKey()
+	bad := append([]byte(nil), priv...)
+	bad[40] ^= 0xff
+	_, err := ParsePrivateKey(Base58Encode(bad))
+	assert.ErrorContains(t, err, "do not match")
+
+	_, err = ParsePrivateKey("[1,2,3]")
+	assert.ErrorContains(t, err, "want 32 or 64")
+	_, err = ParsePrivateKey("[1,2,300]")
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// program-derived addresses
+// ---------------------------------------------------------------------------
+
+func TestIsOnCurve(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		priv, _ := GenerateKey()
+		assert.True(t, IsOnCurve(PublicKeyOf(priv)), "real public keys are on the curve")
+	}
+}
+
+func TestAssociatedTokenAddressIsOffCurveAndDeterministic(t *testing.T) {
+	owner := PublicKeyOf(ed25519.NewKeyFromSeed(make([]byte, 32)))
+	mint := MustPublicKey("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	a, err := AssociatedTokenAddress(owner, mint, TokenProgramID)
+	require.NoError(t, err)
+	b, _ := AssociatedTokenAddress(owner, mint, TokenProgramID)
+	c, _ := AssociatedTokenAddress(owner, mint, Token2022ProgramID)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c, "token program is part of the seeds")
+	assert.False(t, IsOnCurve(a))
+}
+
+func TestCreateProgramAddressRejectsLongSeeds(t *testing.T) {
+	_, err := CreateProgramAddress([][]byte{make([]byte, 33)}, SystemProgramID)
+	assert.Error(t, err)
+}
+
+// ---------------------------------------------------------------------------
+// messages
+// ---------------------------------------------------------------------------
+
+const blockhash = "EETubP5AKHgjPAhzPAFcb8BAY1hMH639CWCFTqi3hq1k"
+
+func TestNewMessageSystemTransfer(t *testing.T) {
+	from := MustPublicKey("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+	to := AssociatedTokenProgramID
+	m, err := NewMessage(from, blockhash, SystemTransfer(from, to, 1_000_000))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint8(1), m.NumRequiredSignatures)
+	assert.Equal(t, uint8(0), m.NumReadonlySigned)
+	assert.Equal(t, uint8(1), m.NumReadonlyUnsigned)
+	assert.Equal(t, []PublicKey{from, to, SystemProgramID}, m.AccountKeys)
+	require.Len(t, m.Instructions, 1)
+	assert.Equal(t, uint8(2), m.Instructions[0].ProgramIndex)
+	assert.Equal(t, []uint8{0, 1}, m.Instructions[0].Accounts)
+	assert.Equal(t, "0200000040420f0000000000", hex.EncodeToString(m.Instructions[0].Data))
+
+	raw := m.Serialize()
+	// header(3) + len(1) + 3 keys + blockhash + ix count(1) + ix(1+1+2+1+12)
+	assert.Len(t, raw, 3+1+3*32+32+1+17)
+	assert.Equal(t, []byte{1, 0, 1, 3}, raw[:4])
+}
+
+func TestNewMessageOrdersTokenTransferAccounts(t *testing.T) {
+	owner := PublicKeyOf(ed25519.NewKeyFromSeed(make([]byte, 32)))
+	dest := PublicKeyOf(ed25519.NewKeyFromSeed(append(make([]byte, 31), 1)))
+	mint := MustPublicKey("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	src, _ := AssociatedTokenAddress(owner, mint, TokenProgramID)
+	dst, _ := AssociatedTokenAddress(dest, mint, TokenProgramID)
+
+	m, err := NewMessage(owner, blockhash,
+		CreateAssociatedTokenAccountIdempotent(owner, dst, dest, mint, TokenProgramID),
+		TransferChecked(TokenProgramID, src, mint, dst, owner, 5, 6),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint8(1), m.NumRequiredSignatures)
+	assert.Equal(t, owner, m.AccountKeys[0])
+	// Writable non-signers (dst, src) come before every read-only account.
+	assert.ElementsMatch(t, []PublicKey{dst, src}, m.AccountKeys[1:3])
+	assert.Equal(t, uint8(len(m.AccountKeys)-3), m.NumReadonlyUnsigned)
+	assert.Equal(t, []byte{12, 5, 0, 0, 0, 0, 0, 0, 0, 6}, m.Instructions[1].Data)
+}
+
+func TestNewMessageValidation(t *testing.T) {
+	_, err := NewMessage(PublicKey{}, "not-base58!", SystemTransfer(PublicKey{}, PublicKey{}, 1))
+	assert.Error(t, err)
+	_, err = NewMessage(PublicKey{}, blockhash)
+	assert.Error(t, err)
+}
+
+func TestEncodeTransactionSignsMessage(t *testing.T) {
+	priv, _ := GenerateKey()
+	from := PublicKeyOf(priv)
+	m, err := NewMessage(from, blockhash, SystemTransfer(from, SystemProgramID, 1))
+	require.NoError(t, err)
+	msg := m.Serialize()
+
+	raw, err := EncodeTransaction([][]byte{ed25519.Sign(priv, msg)}, msg)
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), raw[0])
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), raw[65:], raw[1:65]))
+
+	_, err = EncodeTransaction([][]byte{{1, 2}}, msg)
+	assert.Error(t, err)
+}
+
+func TestCompactU16(t *testing.T) {
+	assert.Equal(t, []byte{0x00}, appendCompactU16(nil, 0))
+	assert.Equal(t, []byte{0x7f}, appendCompactU16(nil, 127))
+	assert.Equal(t, []byte{0x80, 0x01}, appendCompactU16(nil, 128))
+	assert.Equal(t, []byte{0xff, 0xff, 0x03}, appendCompactU16(nil, 65535))
+}
@@ -0,0 +1,207 @@
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AccountMeta is one account an instruction reads or writes.
+type AccountMeta struct {
+	PublicKey  PublicKey
+	IsSigner   bool
+	IsWritable bool
+}
+
+// Instruction is a single program call.
+type Instruction struct {
+	ProgramID PublicKey
+	Accounts  []AccountMeta
+	Data      []byte
+}
+
+// Message is a compiled legacy transaction message: the bytes every signer
+// signs.
+type Message struct {
+	NumRequiredSignatures uint8
+	NumReadonlySigned     uint8
+	NumReadonlyUnsigned   uint8
+	AccountKeys           []PublicKey
+	RecentBlockhash       PublicKey
+	Instructions          []CompiledInstruction
+}
+
+// CompiledInstruction references accounts by index into Message.AccountKeys.
+type CompiledInstruction struct {
+	ProgramIndex uint8
+	Accounts     []uint8
+	Data         []byte
+}
+
+// NewMessage compiles instructions into a message paid for by feePayer.
+// Accounts are ordered as the runtime requires: writable signers (fee payer
+// first), read-only signers, writable non-signers, read-only non-signers.
+func NewMessage(feePayer PublicKey, recentBlockhash string, ixs ...Instruction) (*Message, error) {
+	bh, err := Base58Decode(recentBlockhash)
+	if err != nil || len(bh) != 32 {
+		return nil, fmt.Errorf("invalid recent blockhash %q", recentBlockhash)
+	}
+	if len(ixs) == 0 {
+		return nil, fmt.Errorf("message has no instructions")
+	}
+
+	type flags struct{ signer, writable bool }
+	order := []PublicKey{feePayer}
+	seen := map[PublicKey]*flags{feePayer: {signer: true, writable: true}}
+	add := func(pk PublicKey, signer, writable bool) {
+		f, ok := seen[pk]
+		if !ok {
+			f = &flags{}
+			seen[pk] = f
+			order = append(order, pk)
+		}
+		f.signer = f.signer || signer
+		f.writable = f.writable || writable
+	}
+	for _, ix := range ixs {
+		for _, a := range ix.Accounts {
+			add(a.PublicKey, a.IsSigner, a.IsWritable)
+		}
+		add(ix.ProgramID, false, false)
+	}
+
+	m := &Message{}
+	copy(m.RecentBlockhash[:], bh)
+	for _, group := range []flags{{true, true}, {true, false}, {false, true}, {false, false}} {
+		n := uint8(0)
+		for _, pk := range order {
+			if *seen[pk] == group {
+				m.AccountKeys = append(m.AccountKeys, pk)
+				n++
+			}
+		}
+		switch {
+		case group.signer:
+			m.NumRequiredSignatures += n
+			if !group.writable {
+				m.NumReadonlySigned = n
+			}
+		case !group.writable:
+			m.NumReadonlyUnsigned = n
+		}
+	}
+	if len(m.AccountKeys) > 256 {
+		return nil, fmt.Errorf("too many accounts (%d)", len(m.AccountKeys))
+	}
+
+	index := make(map[PublicKey]uint8, len(m.AccountKeys))
+	for i, pk := range m.AccountKeys {
+		index[pk] = uint8(i)
+	}
+	for _, ix := range ixs {
+		ci := CompiledInstruction{ProgramIndex: index[ix.ProgramID], Data: ix.Data}
+		for _, a := range ix.Accounts {
+			ci.Accounts = append(ci.Accounts, index[a.PublicKey])
+		}
+		m.Instructions = append(m.Instructions, ci)
+	}
+	return m, nil
+}
+
+// Serialize returns the wire encoding of the message.
+func (m *Message) Serialize() []byte {
+	out := []byte{m.NumRequiredSignatures, m.NumReadonlySigned, m.NumReadonlyUnsigned}
+	out = appendCompactU16(out, len(m.AccountKeys))
+	for _, pk := range m.AccountKeys {
+		out = append(out, pk[:]...)
+	}
+	out = append(out, m.RecentBlockhash[:]...)
+	out = appendCompactU16(out, len(m.Instructions))
+	for _, ix := range m.Instructions {
+		out = append(out, ix.ProgramIndex)
+		out = appendCompactU16(out, len(ix.Accounts))
+		out = append(out, ix.Accounts...)
+		out = appendCompactU16(out, len(ix.Data))
+		out = append(out, ix.Data...)
+	}
+	return out
+}
+
+// EncodeTransaction joins signatures (in AccountKeys order) and the
+// serialized message into a wire transaction.
+func EncodeTransaction(sigs [][]byte, message []byte) ([]byte, error) {
+	out := appendCompactU16(nil, len(sigs))
+	for i, s := range sigs {
+		if len(s) != 64 {
+			return nil, fmt.Errorf("signature %d is %d bytes, want 64", i, len(s))
+		}
+		out = append(out, s...)
+	}
+	return append(out, message...), nil
+}
+
+// appendCompactU16 writes Solana's "shortvec" length prefix.
+func appendCompactU16(b []byte, n int) []byte {
+	v := uint16(n)
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// --- instructions ---
+
+// SystemTransfer moves lamports between two system accounts.
+func SystemTransfer(from, to PublicKey, lamports uint64) Instruction {
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:], 2) // SystemInstruction::Transfer
+	binary.LittleEndian.PutUint64(data[4:], lamports)
+	return Instruction{
+		ProgramID: SystemProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: from, IsSigner: true, IsWritable: true},
+			{PublicKey: to, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// TransferChecked moves amount base units of mint between token accounts.
+// The program checks decimals, so a wrong mint or scale fails instead of
+// sending the wrong amount.
+func TransferChecked(tokenProgram, source, mint, dest, owner PublicKey, amount uint64, decimals uint8) Instruction {
+	data := make([]byte, 10)
+	data[0] = 12 // TokenInstruction::TransferChecked
+	binary.LittleEndian.PutUint64(data[1:], amount)
+	data[9] = decimals
+	return Instruction{
+		ProgramID: tokenProgram,
+		Accounts: []AccountMeta{
+			{PublicKey: source, IsWritable: true},
+			{PublicKey: mint},
+			{PublicKey: dest, IsWritable: true},
+			{PublicKey: owner, IsSigner: true},
+		},
+		Data: data,
+	}
+}
+
+// CreateAssociatedTokenAccountIdempotent creates owner's associated token
+// account for mint, or does nothing if it already exists.
+func CreateAssociatedTokenAccountIdempotent(payer, ata, owner, mint, tokenProgram PublicKey) Instruction {
+	return Instruction{
+		ProgramID: AssociatedTokenProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: payer, IsSigner: true, IsWritable: true},
+			{PublicKey: ata, IsWritable: true},
+			{PublicKey: owner},
+			{PublicKey: mint},
+			{PublicKey: SystemProgramID},
+			{PublicKey: tokenProgram},
+		},
+		Data: []byte{1},
+	}
+}
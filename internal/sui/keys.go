@@ -0,0 +1,109 @@
+// Package sui implements the SUI key formats and transaction signing w3cli
+// needs offline. Transactions themselves are built by the node's
+// transaction-builder RPCs and only signed here.
+package sui
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// FlagEd25519 is the signature-scheme flag for ed25519 keys.
+const FlagEd25519 = 0x00
+
+// PrivateKeyPrefix is the bech32 prefix of exported SUI private keys.
+const PrivateKeyPrefix = "suiprivkey"
+
+// GenerateKey creates a new ed25519 keypair.
+func GenerateKey() (ed25519.PrivateKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	return priv, err
+}
+
+// Address returns the SUI address of an ed25519 public key:
+// BLAKE2b-256(flag || pubkey), hex encoded.
+func Address(pub ed25519.PublicKey) string {
+	h := blake2b.Sum256(append([]byte{FlagEd25519}, pub...))
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+// AddressOf returns the address of priv.
+func AddressOf(priv ed25519.PrivateKey) string {
+	return Address(priv.Public().(ed25519.PublicKey))
+}
+
+// IsAddress reports whether s is a 0x-prefixed 32-byte hex address.
+func IsAddress(s string) bool {
+	if len(s) != 66 || !strings.HasPrefix(strings.ToLower(s), "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// ParsePrivateKey accepts the formats SUI tooling exports:
+//   - suiprivkey1… bech32 (sui keytool export, wallet extensions)
+//   - base64 of flag || 32-byte secret (entries in sui.keystore)
+//   - 32-byte hex seed, with or without 0x
+//
+// Only ed25519 keys are supported.
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	s = strings.TrimSpace(s)
+	var raw []byte
+	switch {
+	case strings.HasPrefix(strings.ToLower(s), PrivateKeyPrefix+"1"):
+		hrp, data, err := bech32Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if hrp != PrivateKeyPrefix {
+			return nil, fmt.Errorf("unexpected bech32 prefix %q", hrp)
+		}
+		raw = data
+	case len(strings.TrimPrefix(s, "0x")) == 64:
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex key: %w", err)
+		}
+		return ed25519.NewKeyFromSeed(b), nil
+	default:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("unrecognised SUI key format (want suiprivkey1…, base64 or hex)")
+		}
+		raw = b
+	}
+
+	if len(raw) != 1+ed25519.SeedSize {
+		return nil, fmt.Errorf("key is %d bytes, want 33 (flag + secret)", len(raw))
+	}
+	if raw[0] != FlagEd25519 {
+		return nil, fmt.Errorf("unsupported key scheme flag 0x%02x (only ed25519)", raw[0])
+	}
+	return ed25519.NewKeyFromSeed(raw[1:]), nil
+}
+
+// EncodePrivateKey returns priv as a suiprivkey1… bech32 string.
+func EncodePrivateKey(priv ed25519.PrivateKey) string {
+	s, _ := bech32Encode(PrivateKeyPrefix, append([]byte{FlagEd25519}, priv.Seed()...))
+	return s
+}
+
+// SignTransaction signs BCS transaction bytes and returns the serialized
+// signature the node expects: base64(flag || signature || pubkey). The signed
+// digest is BLAKE2b-256 of the TransactionData intent (0,0,0) and the bytes.
+func SignTransaction(priv ed25519.PrivateKey, txBytes []byte) string {
+	digest := blake2b.Sum256(append([]byte{0, 0, 0}, txBytes...))
+	sig := ed25519.Sign(priv, digest[:])
+	out := make([]byte, 0, 1+ed25519.SignatureSize+ed25519.PublicKeySize)
+	out = append(out, FlagEd25519)
+	out = append(out, sig...)
+	out = append(out, priv.Public().(ed25519.PublicKey)...)
+	return base64.StdEncoding.EncodeToString(out)
+}
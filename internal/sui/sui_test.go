@@ -0,0 +1,103 @@
+package sui
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ---------------------------------------------------------------------------
+// bech32
+// ---------------------------------------------------------------------------
+
+func TestBech32BIP173Vectors(t *testing.T) {
+	for _, s := range []string{
+		"A12UEL5L",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+	} {
+		_, _, err := bech32Decode(s)
+		assert.NoError(t, err, s)
+	}
+	_, _, err := bech32Decode("abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx")
+	assert.ErrorContains(t, err, "checksum")
+	_, _, err = bech32Decode("A12uEL5L")
+	assert.ErrorContains(t, err, "mixed case")
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	data := []byte{0, 1, 2, 250, 251, 252, 253, 254, 255}
+	s, err := bech32Encode("test", data)
+	require.NoError(t, err)
+	hrp, got, err := bech32Decode(s)
+	require.NoError(t, err)
+	assert.Equal(t, "test", hrp)
+	assert.Equal(t, data, got)
+}
+
+// ---------------------------------------------------------------------------
+// keys
+// ---------------------------------------------------------------------------
+
+func TestAddressDerivation(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	pub := priv.Public().(ed25519.PublicKey)
+	want := blake2b.Sum256(append([]byte{0x00}, pub...))
+
+	addr := AddressOf(priv)
+	assert.Equal(t, "0x"+hex.EncodeToString(want[:]), addr)
+	assert.True(t, IsAddress(addr))
+	assert.False(t, IsAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), "EVM addresses are 20 bytes")
+}
+
+func TestParsePrivateKeyFormats(t *testing.T) {
+	priv, err := GenerateKey()
+	require.NoError(t, err)
+	want := AddressOf(priv)
+
+	bech := EncodePrivateKey(priv)
+	assert.Contains(t, bech, "suiprivkey1")
+
+	keystore := base64.StdEncoding.EncodeToString(append([]byte{FlagEd25519}, priv.Seed()...))
+	for name, in := range map[string]string{
+		"bech32":   bech,
+		"keystore": keystore,
+		"hex":      hex.EncodeToString(priv.Seed()),
+		"0x hex":   "0x" + hex.EncodeToString(priv.Seed()),
+	} {
+		got, err := ParsePrivateKey(in)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, AddressOf(got), name)
+	}
+}
+
+func TestParsePrivateKeyRejectsOtherSchemes(t *testing.T) {
+	secp := base64.StdEncoding.EncodeToString(append([]byte{0x01}, make([]byte, 32)...))
+	_, err := ParsePrivateKey(secp)
+	assert.ErrorContains(t, err, "only ed25519")
+
+	_, err = ParsePrivateKey("not a key")
+	assert.Error(t, err)
+
+	other, _ := bech32Encode("suipubkey", make([]byte, 33))
+	_, err = ParsePrivateKey(other)
+	assert.Error(t, err)
+}
+
+func TestSignTransaction(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	txBytes := []byte{1, 2, 3, 4}
+
+	raw, err := base64.StdEncoding.DecodeString(SignTransaction(priv, txBytes))
+	require.NoError(t, err)
+	require.Len(t, raw, 97)
+	assert.Equal(t, byte(FlagEd25519), raw[0])
+	assert.Equal(t, []byte(priv.Public().(ed25519.PublicKey)), raw[65:])
+
+	digest := blake2b.Sum256([]byte{0, 0, 0, 1, 2, 3, 4})
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), digest[:], raw[1:65]))
+}
@@ -0,0 +1,59 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// manifestABIDir is where UpdateManifest writes ABIs, relative to the manifest.
+const manifestABIDir = "abis"
+
+// UpdateManifest records name's deployments (network → address) in the
+// manifest at path, creating it if needed. The ABI is written next to it as
+// abis/<name>.json and pinned by hash, other contracts and networks are
+// kept, and the version is bumped so clients pick the change up.
+func UpdateManifest(path, name string, abi []byte, addresses map[string]string) (*Manifest, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no deployments to record")
+	}
+
+	m := &Manifest{}
+	if raw, err := os.ReadFile(path); err == nil {
+		if m, err = parseManifest(raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if m.Contracts == nil {
+		m.Contracts = map[string]map[string]ManifestEntry{}
+	}
+
+	abiRel := manifestABIDir + "/" + name + ".json"
+	abiPath := filepath.Join(filepath.Dir(path), filepath.FromSlash(abiRel))
+	if err := os.MkdirAll(filepath.Dir(abiPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(abiPath, abi, 0o644); err != nil {
+		return nil, err
+	}
+
+	if m.Contracts[name] == nil {
+		m.Contracts[name] = map[string]ManifestEntry{}
+	}
+	for network, address := range addresses {
+		m.Contracts[name][network] = ManifestEntry{Address: address, ABIUrl: abiRel, ABIHash: HashABI(abi)}
+	}
+	m.Version++
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
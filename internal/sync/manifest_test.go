@@ -0,0 +1,62 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateManifestCreatesAndMerges(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, Manifest{Version: 4, Contracts: map[string]map[string]ManifestEntry{
+		"Token": {"base": {Address: "0xT"}},
+		"Vault": {"ethereum": {Address: "0xV0"}},
+	}}, nil)
+
+	m, err := UpdateManifest(path, "Vault", []byte(vaultABI), map[string]string{"base": "0xV1", "optimism": "0xV1"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, m.Version)
+	assert.Equal(t, "0xT", m.Contracts["Token"]["base"].Address, "other contracts are kept")
+	assert.Equal(t, "0xV0", m.Contracts["Vault"]["ethereum"].Address, "other networks are kept")
+	assert.Equal(t, ManifestEntry{Address: "0xV1", ABIUrl: "abis/Vault.json", ABIHash: HashABI([]byte(vaultABI))}, m.Contracts["Vault"]["optimism"])
+
+	abi, err := os.ReadFile(filepath.Join(dir, "abis", "Vault.json"))
+	require.NoError(t, err)
+	assert.Equal(t, vaultABI, string(abi))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	parsed, err := parseManifest(raw)
+	require.NoError(t, err)
+	assert.Equal(t, m, parsed)
+}
+
+func TestUpdateManifestIsSyncable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deployments.json")
+	_, err := UpdateManifest(path, "Vault", []byte(vaultABI), map[string]string{"base": "0xV1"})
+	require.NoError(t, err)
+
+	s, _, reg := testSyncer(t)
+	require.NoError(t, s.SetSource(path))
+	require.NoError(t, s.Run(context.Background()))
+
+	vault, err := reg.Get("Vault", "base")
+	require.NoError(t, err)
+	assert.Equal(t, "0xV1", vault.Address)
+	assert.Len(t, vault.ABI, 1)
+}
+
+func TestUpdateManifestErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := UpdateManifest(filepath.Join(dir, "deployments.json"), "Vault", []byte(vaultABI), nil)
+	assert.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("{"), 0o600))
+	_, err = UpdateManifest(bad, "Vault", []byte(vaultABI), map[string]string{"base": "0xV1"})
+	assert.Error(t, err)
+}
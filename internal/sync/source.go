@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultManifestPath is the file read from a git source without a path.
+const DefaultManifestPath = "deployments.json"
+
+// reader reads a manifest and the files next to it (signature, relative ABI
+// paths) from one source:
+//
+//	https://example.com/deployments.json
+//	file:///srv/deployments.json   or a plain path
+//	git+https://github.com/acme/deploys.git#main:deployments.json
+//
+// Git sources are shallow-cloned into a temp dir; call close when done.
+type reader struct {
+	client *http.Client
+	base   string // manifest URL or local file path
+	local  bool
+	tmpDir string
+}
+
+// openSource prepares a reader for source.
+func openSource(ctx context.Context, client *http.Client, source string) (*reader, error) {
+	switch {
+	case strings.HasPrefix(source, "git+"):
+		return openGit(ctx, client, strings.TrimPrefix(source, "git+"))
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		return &reader{client: client, base: source}, nil
+	case strings.HasPrefix(source, "file://"):
+		u, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file URL %q: %w", source, err)
+		}
+		return &reader{client: client, base: filepath.FromSlash(u.Host + u.Path), local: true}, nil
+	case strings.Contains(source, "://"):
+		return nil, fmt.Errorf("unsupported source %q — use https://, file://, git+<repo>#<ref>:<path> or a local path", source)
+	default:
+		return &reader{client: client, base: source, local: true}, nil
+	}
+}
+
+// openGit shallow-clones repo#[ref:]path and reads from the checkout.
+func openGit(ctx context.Context, client *http.Client, spec string) (*reader, error) {
+	repo, frag, _ := strings.Cut(spec, "#")
+	ref, path := "", frag
+	if r, p, ok := strings.Cut(frag, ":"); ok {
+		ref, path = r, p
+	}
+	if path == "" {
+		path = DefaultManifestPath
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+		return nil, fmt.Errorf("git source path %q must be inside the repository", path)
+	}
+
+	tmp, err := os.MkdirTemp("", "w3cli-sync-")
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", repo, tmp)
+	if out, err := exec.CommandContext(ctx, "git", args...).CombinedOutput(); err != nil {
+		os.RemoveAll(tmp)
+		return nil, fmt.Errorf("git clone %s: %v: %s", repo, err, strings.TrimSpace(string(out)))
+	}
+	return &reader{client: client, base: filepath.Join(tmp, path), local: true, tmpDir: tmp}, nil
+}
+
+// close removes a git checkout.
+func (r *reader) close() {
+	if r.tmpDir != "" {
+		os.RemoveAll(r.tmpDir)
+	}
+}
+
+// manifest reads the manifest itself.
+func (r *reader) manifest(ctx context.Context) ([]byte, error) {
+	return r.read(ctx, r.base)
+}
+
+// signature reads the detached signature stored next to the manifest as
+// <manifest>.sig.
+func (r *reader) signature(ctx context.Context) ([]byte, error) {
+	if r.local {
+		return r.read(ctx, r.base+".sig")
+	}
+	u, err := url.Parse(r.base)
+	if err != nil {
+		return nil, err
+	}
+	u.Path += ".sig"
+	return r.read(ctx, u.String())
+}
+
+// resolve turns a reference from the manifest (an ABI URL or path) into
+// something read can open: absolute URLs stay as they are, relative paths
+// are relative to the manifest.
+func (r *reader) resolve(ref string) (string, error) {
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return ref, nil
+	}
+	if strings.HasPrefix(ref, "file://") {
+		if !r.local {
+			return "", fmt.Errorf("remote manifest cannot reference local file %s", ref)
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", err
+		}
+		return filepath.FromSlash(u.Host + u.Path), nil
+	}
+	if r.local {
+		if filepath.IsAbs(ref) {
+			return ref, nil
+		}
+		return filepath.Join(filepath.Dir(r.base), filepath.FromSlash(ref)), nil
+	}
+	base, err := url.Parse(r.base)
+	if err != nil {
+		return "", err
+	}
+	rel, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(rel).String(), nil
+}
+
+// read fetches a URL or reads a local file.
+func (r *reader) read(ctx context.Context, loc string) ([]byte, error) {
+	if !strings.HasPrefix(loc, "http://") && !strings.HasPrefix(loc, "https://") {
+		return os.ReadFile(loc)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: HTTP %d", loc, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -2,76 +2,266 @@ package sync
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
 	"github.com/Mohsinsiddi/w3cli/internal/config"
+	"github.com/Mohsinsiddi/w3cli/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Manifest is the structure of a deployments.json manifest.
 type Manifest struct {
+	// Version increases with every published manifest. The syncer refuses
+	// to apply a manifest older than the last one it applied.
+	Version   int                                 `json:"version,omitempty"`
 	Contracts map[string]map[string]ManifestEntry `json:"contracts"`
 }
 
 // ManifestEntry is a single contract deployment entry.
 type ManifestEntry struct {
 	Address string `json:"address"`
-	ABIUrl  string `json:"abi_url"`
+	ABIUrl  string `json:"abi_url"`            // URL, or a path relative to the manifest
+	ABIHash string `json:"abi_hash,omitempty"` // keccak256 of the ABI file, see HashABI
+}
+
+// KindSynced marks registry entries written by sync. Only these are updated
+// in place and pruned; contracts added by hand are never touched.
+const KindSynced = "synced"
+
+// ChangeKind classifies a difference between the manifest and the registry.
+type ChangeKind string
+
+const (
+	ChangeAdd      ChangeKind = "add"
+	ChangeUpdate   ChangeKind = "update"
+	ChangeRemove   ChangeKind = "remove"
+	ChangeConflict ChangeKind = "conflict" // a hand-added entry has the same name and network; kept
+)
+
+// Change is one difference between the manifest and the registry.
+type Change struct {
+	Kind       ChangeKind
+	Name       string
+	Network    string
+	Address    string // manifest address ("" for removals)
+	OldAddress string // registry address ("" for additions)
+	ABIChanged bool
+
+	entry *contract.Entry // what Apply writes for adds and updates
+}
+
+// Plan is what applying a manifest would do.
+type Plan struct {
+	Source      string
+	Version     int
+	PrevVersion int
+	Signer      string // verified signer; "" when the manifest is unsigned
+	Changes     []Change
+	Warnings    []string // ABI fetch and hash problems; those entries keep their local ABI
+}
+
+// Options tune how a manifest is applied.
+type Options struct {
+	Prune bool // remove synced entries that are no longer in the manifest
+	Force bool // accept an older manifest version and overwrite hand-added entries
 }
 
 // Syncer handles fetching and updating contracts from a remote manifest.
 type Syncer struct {
-	cfg     *config.Config
-	reg     *contract.Registry
-	fetcher *contract.Fetcher
-	client  *http.Client
+	cfg    *config.Config
+	reg    *contract.Registry
+	client *http.Client
+	opts   Options
 }
 
-// New creates a new Syncer.
+// New creates a new Syncer. Entries that left the manifest are kept unless
+// Options.Prune is set.
 func New(cfg *config.Config, reg *contract.Registry) *Syncer {
 	return &Syncer{
-		cfg:     cfg,
-		reg:     reg,
-		fetcher: contract.NewFetcher(""),
-		client:  &http.Client{Timeout: 15 * time.Second},
+		cfg:    cfg,
+		reg:    reg,
+		client: &http.Client{Timeout: 15 * time.Second},
 	}
 }
 
-// Run fetches the manifest from the configured source and updates the contract registry.
+// SetOptions replaces the apply options.
+func (s *Syncer) SetOptions(o Options) { s.opts = o }
+
+// Run fetches the manifest from the configured source and updates the contract
+// registry. ABI problems are printed as warnings and don't fail the sync.
 func (s *Syncer) Run(ctx context.Context) error {
+	plan, err := s.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	for _, w := range plan.Warnings {
+		fmt.Printf("warning: %s\n", w)
+	}
+	return s.Apply(plan)
+}
+
+// Plan fetches, verifies and diffs the manifest against the registry without
+// changing anything.
+func (s *Syncer) Plan(ctx context.Context) (*Plan, error) {
 	syncCfg, err := s.cfg.LoadSync()
 	if err != nil {
-		return fmt.Errorf("loading sync config: %w", err)
+		return nil, fmt.Errorf("loading sync config: %w", err)
 	}
 
 	if syncCfg.Source == "" {
-		return fmt.Errorf("no sync source configured — run: w3cli sync set-source <url>")
+		return nil, fmt.Errorf("no sync source configured — run: w3cli sync set-source <url>")
+	}
+
+	r, err := openSource(ctx, s.client, syncCfg.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	raw, err := r.manifest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest: %w", err)
+	}
+
+	plan := &Plan{Source: syncCfg.Source, PrevVersion: syncCfg.Version}
+	if syncCfg.Signer != "" {
+		sig, err := r.signature(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("manifest must be signed by %s but the signature could not be read: %w", syncCfg.Signer, err)
+		}
+		if err := VerifyManifest(raw, sig, syncCfg.Signer); err != nil {
+			return nil, err
+		}
+		plan.Signer = common.HexToAddress(syncCfg.Signer).Hex()
 	}
 
-	manifest, err := s.fetchManifest(ctx, syncCfg.Source)
+	manifest, err := parseManifest(raw)
 	if err != nil {
-		return fmt.Errorf("fetching manifest: %w", err)
+		return nil, fmt.Errorf("fetching manifest: %w", err)
+	}
+	plan.Version = manifest.Version
+	if manifest.Version < syncCfg.Version && !s.opts.Force {
+		return nil, fmt.Errorf("manifest version %d is older than the last synced version %d — refusing to roll back (use --force)",
+			manifest.Version, syncCfg.Version)
+	}
+
+	inManifest := map[string]bool{}
+	for _, name := range sortedKeys(manifest.Contracts) {
+		networks := manifest.Contracts[name]
+		for _, network := range sortedKeys(networks) {
+			inManifest[name+"@"+network] = true
+			if c, ok := s.diffEntry(ctx, r, plan, name, network, networks[network]); ok {
+				plan.Changes = append(plan.Changes, c)
+			}
+		}
 	}
 
-	for name, networks := range manifest.Contracts {
-		for network, entry := range networks {
-			abi, err := s.fetcher.FetchFromURL(entry.ABIUrl)
-			if err != nil {
-				fmt.Printf("warning: could not fetch ABI for %s on %s: %v\n", name, network, err)
-				abi = nil
+	if s.opts.Prune {
+		for _, e := range s.reg.All() {
+			if isSynced(e) && !inManifest[e.Name+"@"+e.Network] {
+				plan.Changes = append(plan.Changes, Change{Kind: ChangeRemove, Name: e.Name, Network: e.Network, OldAddress: e.Address})
 			}
+		}
+	}
+	return plan, nil
+}
+
+// diffEntry compares one manifest entry with the registry. ok is false when
+// nothing changed.
+func (s *Syncer) diffEntry(ctx context.Context, r *reader, plan *Plan, name, network string, me ManifestEntry) (Change, bool) {
+	local, _ := s.reg.Get(name, network)
+	c := Change{Name: name, Network: network, Address: me.Address}
+	if local != nil {
+		c.OldAddress = local.Address
+	}
+	if local != nil && !isSynced(local) && !s.opts.Force {
+		c.Kind = ChangeConflict
+		return c, true
+	}
+
+	abi, hash, err := s.loadABI(ctx, r, me, local, plan.Signer != "")
+	if err != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s on %s: %v", name, network, err))
+	}
+	c.entry = &contract.Entry{
+		Name:    name,
+		Network: network,
+		Address: me.Address,
+		ABI:     abi,
+		ABIUrl:  me.ABIUrl,
+		ABIHash: hash,
+		Kind:    KindSynced,
+	}
+
+	switch {
+	case local == nil:
+		c.Kind = ChangeAdd
+		c.ABIChanged = len(abi) > 0
+	case !strings.EqualFold(local.Address, me.Address) || local.ABIHash != hash || local.ABIUrl != me.ABIUrl || local.Kind != KindSynced:
+		c.Kind = ChangeUpdate
+		c.ABIChanged = local.ABIHash != hash
+	default:
+		return c, false
+	}
+	return c, true
+}
+
+// loadABI returns the ABI for a manifest entry and its hash. When the local
+// entry already has the ABI the manifest pins, nothing is fetched. On any
+// error the local ABI (if any) is kept. A signed manifest must pin every ABI:
+// the signature covers abi_hash, not the ABI file itself.
+func (s *Syncer) loadABI(ctx context.Context, r *reader, me ManifestEntry, local *contract.Entry, signed bool) ([]contract.ABIEntry, string, error) {
+	var keepABI []contract.ABIEntry
+	var keepHash string
+	if local != nil {
+		keepABI, keepHash = local.ABI, local.ABIHash
+	}
+	if me.ABIUrl == "" {
+		return keepABI, keepHash, nil
+	}
+	if signed && me.ABIHash == "" {
+		return keepABI, keepHash, fmt.Errorf("signed manifest has no abi_hash for %s — the ABI cannot be verified", me.ABIUrl)
+	}
+	if me.ABIHash != "" && strings.EqualFold(keepHash, me.ABIHash) && len(keepABI) > 0 {
+		return keepABI, keepHash, nil
+	}
 
-			s.reg.Add(&contract.Entry{
-				Name:    name,
-				Network: network,
-				Address: entry.Address,
-				ABI:     abi,
-				ABIUrl:  entry.ABIUrl,
-			})
+	loc, err := r.resolve(me.ABIUrl)
+	if err != nil {
+		return keepABI, keepHash, fmt.Errorf("could not fetch ABI: %w", err)
+	}
+	data, err := r.read(ctx, loc)
+	if err != nil {
+		return keepABI, keepHash, fmt.Errorf("could not fetch ABI: %w", err)
+	}
+	hash := HashABI(data)
+	if me.ABIHash != "" && !strings.EqualFold(hash, me.ABIHash) {
+		return keepABI, keepHash, fmt.Errorf("ABI hash mismatch: manifest pins %s, %s hashes to %s", me.ABIHash, me.ABIUrl, hash)
+	}
+	abi, err := contract.ParseABI(data)
+	if err != nil {
+		return keepABI, keepHash, fmt.Errorf("invalid ABI at %s: %w", me.ABIUrl, err)
+	}
+	return abi, hash, nil
+}
+
+// Apply writes a plan to the registry and records the manifest version.
+// Conflicts are left alone.
+func (s *Syncer) Apply(p *Plan) error {
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case ChangeAdd, ChangeUpdate:
+			s.reg.Add(c.entry)
+		case ChangeRemove:
+			_ = s.reg.Remove(c.Name, c.Network)
 		}
 	}
 
@@ -79,12 +269,18 @@ func (s *Syncer) Run(ctx context.Context) error {
 		return fmt.Errorf("saving contracts: %w", err)
 	}
 
-	// Update last synced timestamp.
+	syncCfg, err := s.cfg.LoadSync()
+	if err != nil {
+		return err
+	}
+	// Update last synced timestamp and version.
 	syncCfg.LastSynced = time.Now().UTC().Format(time.RFC3339)
+	syncCfg.Version = p.Version
 	return s.cfg.SaveSync(syncCfg)
 }
 
-// SetSource sets the remote manifest URL.
+// SetSource sets the manifest source: an https:// or file:// URL, a local
+// path, or git+<repo>#[<ref>:]<path>.
 func (s *Syncer) SetSource(url string) error {
 	syncCfg, err := s.cfg.LoadSync()
 	if err != nil {
@@ -94,6 +290,23 @@ func (s *Syncer) SetSource(url string) error {
 	return s.cfg.SaveSync(syncCfg)
 }
 
+// SetSigner pins the address manifests must be signed by. An empty address
+// accepts unsigned manifests again.
+func (s *Syncer) SetSigner(address string) error {
+	syncCfg, err := s.cfg.LoadSync()
+	if err != nil {
+		return err
+	}
+	if address != "" {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid signer address %q", address)
+		}
+		address = common.HexToAddress(address).Hex()
+	}
+	syncCfg.Signer = address
+	return s.cfg.SaveSync(syncCfg)
+}
+
 // Watch runs Syncer.Run on a ticker until ctx is cancelled.
 func (s *Syncer) Watch(ctx context.Context, interval time.Duration) error {
 	if err := s.Run(ctx); err != nil {
@@ -111,22 +324,49 @@ func (s *Syncer) Watch(ctx context.Context, interval time.Duration) error {
 	}
 }
 
-func (s *Syncer) fetchManifest(ctx context.Context, url string) (*Manifest, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+func parseManifest(raw []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
 	}
+	return &m, nil
+}
 
-	resp, err := s.client.Do(req)
+// HashABI returns the 0x-prefixed keccak256 of an ABI file's bytes, the value
+// manifests pin in abi_hash.
+func HashABI(data []byte) string {
+	return crypto.Keccak256Hash(data).Hex()
+}
+
+// VerifyManifest checks that sig (hex, as written to <manifest>.sig) is an
+// EIP-191 signature of the exact manifest bytes by signer.
+func VerifyManifest(manifest, sig []byte, signer string) error {
+	sigHex := strings.TrimPrefix(strings.TrimSpace(string(sig)), "0x")
+	sigBytes, err := hex.DecodeString(sigHex)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("invalid manifest signature: %w", err)
+	}
+	recovered, err := wallet.VerifyMessage(manifest, sigBytes)
+	if err != nil {
+		return fmt.Errorf("invalid manifest signature: %w", err)
+	}
+	if recovered != common.HexToAddress(signer) {
+		return fmt.Errorf("manifest signed by %s, expected %s — refusing to sync", recovered.Hex(), common.HexToAddress(signer).Hex())
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	body, _ := io.ReadAll(resp.Body)
-	var m Manifest
-	if err := json.Unmarshal(body, &m); err != nil {
-		return nil, fmt.Errorf("parsing manifest: %w", err)
+// isSynced reports whether sync owns an entry. Entries from before kinds
+// were recorded are recognised by their ABI URL, which only sync sets.
+func isSynced(e *contract.Entry) bool {
+	return e.Kind == KindSynced || (e.Kind == "" && e.ABIUrl != "")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	return &m, nil
+	sort.Strings(keys)
+	return keys
 }
@@ -2,15 +2,20 @@ package sync
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/Mohsinsiddi/w3cli/internal/config"
 	"github.com/Mohsinsiddi/w3cli/internal/contract"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -112,47 +117,6 @@ func TestManifestParseMultipleContracts(t *testing.T) {
 	assert.Equal(t, "0xAAA", m.Contracts["TokenA"]["ethereum"].Address)
 }
 
-// ---------------------------------------------------------------------------
-// fetchManifest
-// ---------------------------------------------------------------------------
-
-func TestFetchManifestSuccess(t *testing.T) {
-	m := Manifest{
-		Contracts: map[string]map[string]ManifestEntry{
-			"Vault": {
-				"arbitrum": {Address: "0xVault", ABIUrl: "https://example.com/vault.json"},
-			},
-		},
-	}
-
-	srv := manifestServer(t, m)
-	defer srv.Close()
-
-	s, _, _ := testSyncer(t)
-	got, err := s.fetchManifest(context.Background(), srv.URL)
-	require.NoError(t, err)
-	require.Contains(t, got.Contracts, "Vault")
-	assert.Equal(t, "0xVault", got.Contracts["Vault"]["arbitrum"].Address)
-}
-
-func TestFetchManifestInvalidJSON(t *testing.T) {
-	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(`{not json}`)) //nolint:errcheck
-	}))
-	defer srv.Close()
-
-	s, _, _ := testSyncer(t)
-	_, err := s.fetchManifest(context.Background(), srv.URL)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "parsing manifest")
-}
-
-func TestFetchManifestConnectionError(t *testing.T) {
-	s, _, _ := testSyncer(t)
-	_, err := s.fetchManifest(context.Background(), "http://127.0.0.1:19993")
-	require.Error(t, err)
-}
-
 // ---------------------------------------------------------------------------
 // New / SetSource
 // ---------------------------------------------------------------------------
@@ -162,7 +126,6 @@ func TestSyncerNew(t *testing.T) {
 	assert.NotNil(t, s)
 	assert.NotNil(t, s.cfg)
 	assert.NotNil(t, s.reg)
-	assert.NotNil(t, s.fetcher)
 	assert.NotNil(t, s.client)
 	_ = cfg
 	_ = reg
@@ -326,3 +289,273 @@ func TestWatchCancellation(t *testing.T) {
 	// At least one initial Run should have been triggered.
 	assert.GreaterOrEqual(t, callCount, 1)
 }
+
+// ---------------------------------------------------------------------------
+// Plan / Apply — diff, pruning, conflicts
+// ---------------------------------------------------------------------------
+
+// writeManifest writes a manifest (and optional files next to it) into dir
+// and returns the manifest path.
+func writeManifest(t *testing.T, dir string, m Manifest, files map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "deployments.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	for name, body := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600))
+	}
+	return path
+}
+
+const vaultABI = `[{"name":"deposit","type":"function","inputs":[],"outputs":[],"stateMutability":"payable"}]`
+
+func TestPlanDiffAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	s, _, reg := testSyncer(t)
+	reg.Add(&contract.Entry{Name: "Old", Network: "base", Address: "0xOLD", ABIUrl: "abis/old.json"}) // legacy synced entry
+	reg.Add(&contract.Entry{Name: "Vault", Network: "base", Address: "0xV1", Kind: KindSynced})
+	reg.Add(&contract.Entry{Name: "Mine", Network: "base", Address: "0xMINE", Kind: "custom"})
+	reg.Add(&contract.Entry{Name: "Hand", Network: "base", Address: "0xHAND"})
+
+	path := writeManifest(t, dir, Manifest{Version: 2, Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV2", ABIUrl: "abis/vault.json"}},
+		"Mine":  {"base": {Address: "0xOTHER"}},
+		"New":   {"ethereum": {Address: "0xNEW"}},
+	}}, map[string]string{"abis/vault.json": vaultABI})
+	require.NoError(t, s.SetSource("file://"+filepath.ToSlash(path)))
+	s.SetOptions(Options{Prune: true})
+
+	plan, err := s.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, plan.Version)
+	assert.Empty(t, plan.Warnings)
+
+	kinds := map[string]ChangeKind{}
+	for _, c := range plan.Changes {
+		kinds[c.Name] = c.Kind
+	}
+	assert.Equal(t, map[string]ChangeKind{
+		"Vault": ChangeUpdate,
+		"Mine":  ChangeConflict,
+		"New":   ChangeAdd,
+		"Old":   ChangeRemove,
+	}, kinds, "hand-added Hand is neither pruned nor reported")
+
+	// Planning is a dry run.
+	_, err = reg.Get("Old", "base")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Apply(plan))
+	vault, err := reg.Get("Vault", "base")
+	require.NoError(t, err)
+	assert.Equal(t, "0xV2", vault.Address)
+	require.Len(t, vault.ABI, 1, "relative ABI path resolved next to the manifest")
+	assert.Equal(t, HashABI([]byte(vaultABI)), vault.ABIHash)
+	mine, _ := reg.Get("Mine", "base")
+	assert.Equal(t, "0xMINE", mine.Address, "conflicts keep the local entry")
+	_, err = reg.Get("Old", "base")
+	assert.Error(t, err)
+	_, err = reg.Get("Hand", "base")
+	assert.NoError(t, err)
+
+	// A second plan against the same manifest has nothing but the conflict.
+	plan, err = s.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ChangeConflict, plan.Changes[0].Kind)
+}
+
+func TestPlanRefusesRollbackUnlessForced(t *testing.T) {
+	dir := t.TempDir()
+	s, cfg, _ := testSyncer(t)
+	path := writeManifest(t, dir, Manifest{Version: 3, Contracts: map[string]map[string]ManifestEntry{}}, nil)
+	require.NoError(t, s.SetSource(path))
+	require.NoError(t, s.Run(context.Background()))
+
+	sc, err := cfg.LoadSync()
+	require.NoError(t, err)
+	assert.Equal(t, 3, sc.Version)
+
+	writeManifest(t, dir, Manifest{Version: 2, Contracts: map[string]map[string]ManifestEntry{}}, nil)
+	_, err = s.Plan(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "roll back")
+
+	s.SetOptions(Options{Force: true})
+	_, err = s.Plan(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPlanABIHashMismatchKeepsLocalABI(t *testing.T) {
+	dir := t.TempDir()
+	s, _, reg := testSyncer(t)
+	reg.Add(&contract.Entry{Name: "Vault", Network: "base", Address: "0xV", Kind: KindSynced,
+		ABI: []contract.ABIEntry{{Name: "withdraw", Type: "function"}}, ABIUrl: "vault.json", ABIHash: "0xold"})
+
+	path := writeManifest(t, dir, Manifest{Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV", ABIUrl: "vault.json", ABIHash: "0xdeadbeef"}},
+	}}, map[string]string{"vault.json": vaultABI})
+	require.NoError(t, s.SetSource(path))
+
+	plan, err := s.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0], "hash mismatch")
+	assert.Empty(t, plan.Changes, "tampered ABI is not applied")
+
+	// With the right hash pinned, the new ABI is taken.
+	writeManifest(t, dir, Manifest{Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV", ABIUrl: "vault.json", ABIHash: HashABI([]byte(vaultABI))}},
+	}}, nil)
+	plan, err = s.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.True(t, plan.Changes[0].ABIChanged)
+}
+
+func TestPlanKeepsRemovedEntriesByDefault(t *testing.T) {
+	dir := t.TempDir()
+	s, _, reg := testSyncer(t)
+	reg.Add(&contract.Entry{Name: "Gone", Network: "base", Address: "0xG", Kind: KindSynced})
+	reg.Add(&contract.Entry{Name: "Legacy", Network: "base", Address: "0xL", ABIUrl: "https://example.com/l.json"})
+	path := writeManifest(t, dir, Manifest{Contracts: map[string]map[string]ManifestEntry{}}, nil)
+	require.NoError(t, s.SetSource(path))
+
+	require.NoError(t, s.Run(context.Background()))
+	assert.Len(t, reg.All(), 2, "nothing is removed without Prune")
+}
+
+// ---------------------------------------------------------------------------
+// Signatures
+// ---------------------------------------------------------------------------
+
+func signManifest(t *testing.T, data []byte) (sig string, signer string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	raw, err := crypto.Sign(accounts.TextHash(data), key)
+	require.NoError(t, err)
+	raw[64] += 27
+	return "0x" + hex.EncodeToString(raw), crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+func TestPlanVerifiesPinnedSigner(t *testing.T) {
+	dir := t.TempDir()
+	s, _, _ := testSyncer(t)
+	path := writeManifest(t, dir, Manifest{Version: 1, Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV"}},
+	}}, nil)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sig, signer := signManifest(t, data)
+	require.NoError(t, s.SetSource(path))
+	require.NoError(t, s.SetSigner(signer))
+
+	// No signature file yet.
+	_, err = s.Plan(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature")
+
+	require.NoError(t, os.WriteFile(path+".sig", []byte(sig+"\n"), 0o600))
+	plan, err := s.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signer, plan.Signer)
+
+	// Any edit to the manifest breaks the signature.
+	require.NoError(t, os.WriteFile(path, append(data, ' '), 0o600))
+	_, err = s.Plan(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected "+signer)
+
+	assert.Error(t, s.SetSigner("not-an-address"))
+}
+
+func TestSignedManifestRequiresABIHash(t *testing.T) {
+	dir := t.TempDir()
+	s, _, reg := testSyncer(t)
+	path := writeManifest(t, dir, Manifest{Version: 1, Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV", ABIUrl: "vault.json"}},
+		"Pool":  {"base": {Address: "0xP", ABIUrl: "vault.json", ABIHash: HashABI([]byte(vaultABI))}},
+	}}, map[string]string{"vault.json": vaultABI})
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sig, signer := signManifest(t, data)
+	require.NoError(t, os.WriteFile(path+".sig", []byte(sig), 0o600))
+	require.NoError(t, s.SetSource(path))
+	require.NoError(t, s.SetSigner(signer))
+
+	plan, err := s.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0], "no abi_hash")
+	require.NoError(t, s.Apply(plan))
+
+	vault, err := reg.Get("Vault", "base")
+	require.NoError(t, err)
+	assert.Empty(t, vault.ABI, "an unpinned ABI is not taken from a signed manifest")
+	pool, err := reg.Get("Pool", "base")
+	require.NoError(t, err)
+	assert.Len(t, pool.ABI, 1)
+}
+
+func TestSignatureOverHTTP(t *testing.T) {
+	body := []byte(`{"version":1,"contracts":{}}`)
+	sig, signer := signManifest(t, body)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/deployments.json":
+			w.Write(body) //nolint:errcheck
+		case "/deployments.json.sig":
+			w.Write([]byte(sig)) //nolint:errcheck
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	s, _, _ := testSyncer(t)
+	require.NoError(t, s.SetSource(srv.URL+"/deployments.json"))
+	require.NoError(t, s.SetSigner(signer))
+	plan, err := s.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signer, plan.Signer)
+}
+
+// ---------------------------------------------------------------------------
+// Sources
+// ---------------------------------------------------------------------------
+
+func TestGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	writeManifest(t, filepath.Join(repo), Manifest{Version: 7, Contracts: map[string]map[string]ManifestEntry{
+		"Vault": {"base": {Address: "0xV", ABIUrl: "abis/vault.json"}},
+	}}, map[string]string{"abis/vault.json": vaultABI})
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.email=t@example.com", "-c", "user.name=t"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "deployments")
+
+	s, _, reg := testSyncer(t)
+	require.NoError(t, s.SetSource("git+file://"+filepath.ToSlash(repo)+"#main:deployments.json"))
+	require.NoError(t, s.Run(context.Background()))
+
+	e, err := reg.Get("Vault", "base")
+	require.NoError(t, err)
+	assert.Len(t, e.ABI, 1)
+}
+
+func TestOpenSourceRejectsUnknownScheme(t *testing.T) {
+	_, err := openSource(context.Background(), http.DefaultClient, "ftp://example.com/d.json")
+	assert.Error(t, err)
+	_, err = openSource(context.Background(), http.DefaultClient, "git+file:///tmp/x#../../etc/passwd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,275 @@
+package tokenlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a list name isn't subscribed.
+var ErrNotFound = errors.New("token list not found")
+
+// Subscription is a list the user added: where it comes from and what the
+// cached copy holds.
+type Subscription struct {
+	Name      string `json:"name"`
+	Source    string `json:"source"` // URL or local file path
+	Title     string `json:"title"`  // the list's own name
+	Version   string `json:"version"`
+	Tokens    int    `json:"tokens"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Store manages subscriptions in <dir>/tokenlists.json and the cached lists
+// in <dir>/tokenlists/<name>.json.
+type Store struct {
+	dir    string
+	client *http.Client
+	now    func() time.Time
+
+	subs   []*Subscription
+	lists  map[string]*List
+	loaded bool
+}
+
+// NewStore creates a Store rooted at the config directory.
+func NewStore(dir string) *Store {
+	return &Store{
+		dir:    dir,
+		client: &http.Client{Timeout: 20 * time.Second},
+		now:    time.Now,
+		lists:  make(map[string]*List),
+	}
+}
+
+func (s *Store) indexPath() string { return filepath.Join(s.dir, "tokenlists.json") }
+
+func (s *Store) cachePath(name string) string {
+	return filepath.Join(s.dir, "tokenlists", name+".json")
+}
+
+// Load reads the subscriptions. A missing file means none.
+func (s *Store) Load() error {
+	s.loaded = true
+	data, err := os.ReadFile(s.indexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.subs)
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	sort.Slice(s.subs, func(i, j int) bool { return s.subs[i].Name < s.subs[j].Name })
+	data, err := json.MarshalIndent(s.subs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.indexPath(), data, 0o600)
+}
+
+// Subscriptions returns the subscribed lists, sorted by name.
+func (s *Store) Subscriptions() []*Subscription {
+	out := append([]*Subscription(nil), s.subs...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *Store) find(name string) int {
+	for i, sub := range s.subs {
+		if sub.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add fetches the list at source (a URL or file path), caches it and
+// subscribes to it as name. An empty name is derived from the list's own
+// name. Re-adding a name replaces it.
+func (s *Store) Add(name, source string) (*Subscription, error) {
+	l, raw, err := s.fetch(source)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = Slug(l.Name)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("cannot derive a name from %q — pass one", l.Name)
+	}
+	sub := &Subscription{Name: name, Source: source}
+	if err := s.store(sub, l, raw); err != nil {
+		return nil, err
+	}
+	if i := s.find(name); i >= 0 {
+		s.subs[i] = sub
+	} else {
+		s.subs = append(s.subs, sub)
+	}
+	return sub, s.save()
+}
+
+// Remove unsubscribes name and deletes its cached copy.
+func (s *Store) Remove(name string) error {
+	i := s.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	s.subs = append(s.subs[:i], s.subs[i+1:]...)
+	delete(s.lists, name)
+	if err := os.Remove(s.cachePath(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return s.save()
+}
+
+// Update re-fetches name from its source. On failure the cached copy is
+// kept, so a flaky URL never loses tokens.
+func (s *Store) Update(name string) (*Subscription, error) {
+	i := s.find(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	sub := *s.subs[i]
+	l, raw, err := s.fetch(sub.Source)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store(&sub, l, raw); err != nil {
+		return nil, err
+	}
+	s.subs[i] = &sub
+	return &sub, s.save()
+}
+
+// store writes the cached copy and fills in sub's metadata.
+func (s *Store) store(sub *Subscription, l *List, raw []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.cachePath(sub.Name)), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.cachePath(sub.Name), raw, 0o600); err != nil {
+		return err
+	}
+	sub.Title = l.Name
+	sub.Version = l.Version.String()
+	sub.Tokens = len(l.Tokens)
+	sub.UpdatedAt = s.now().UTC().Format(time.RFC3339)
+	s.lists[sub.Name] = l
+	return nil
+}
+
+// fetch reads and parses a list from a URL or file.
+func (s *Store) fetch(source string) (*List, []byte, error) {
+	var raw []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		raw, err = s.get(source)
+	} else {
+		raw, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", source, err)
+	}
+	l, err := Parse(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return l, raw, nil
+}
+
+func (s *Store) get(u string) ([]byte, error) {
+	resp, err := s.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// list returns the cached copy of a subscription, reading it on first use.
+// Unreadable copies are skipped.
+func (s *Store) list(name string) *List {
+	if l, ok := s.lists[name]; ok {
+		return l
+	}
+	data, err := os.ReadFile(s.cachePath(name))
+	if err != nil {
+		return nil
+	}
+	l, err := Parse(data)
+	if err != nil {
+		return nil
+	}
+	s.lists[name] = l
+	return l
+}
+
+// Tokens returns every listed token on chainID, deduplicated by address
+// across lists (the first list, by name, wins).
+func (s *Store) Tokens(chainID int64) []Token {
+	if !s.loaded {
+		_ = s.Load()
+	}
+	var out []Token
+	seen := map[string]bool{}
+	for _, sub := range s.Subscriptions() {
+		l := s.list(sub.Name)
+		if l == nil {
+			continue
+		}
+		for _, t := range l.OnChain(chainID) {
+			key := strings.ToLower(t.Address)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// BySymbol returns the listed tokens on chainID whose symbol matches
+// (case-insensitive). More than one result means the symbol is ambiguous.
+func (s *Store) BySymbol(chainID int64, symbol string) []Token {
+	var out []Token
+	for _, t := range s.Tokens(chainID) {
+		if strings.EqualFold(t.Symbol, symbol) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Slug turns a list title ("Uniswap Labs Default") into a subscription name
+// ("uniswap-labs-default").
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
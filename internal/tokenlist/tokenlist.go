@@ -0,0 +1,79 @@
+// Package tokenlist reads Uniswap-format token lists (https://tokenlists.org)
+// and keeps the ones the user subscribed to cached on disk, so commands can
+// look tokens up by chain ID and symbol without touching the network.
+package tokenlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Token is one entry of a token list.
+type Token struct {
+	ChainID  int64    `json:"chainId"`
+	Address  string   `json:"address"`
+	Name     string   `json:"name"`
+	Symbol   string   `json:"symbol"`
+	Decimals int      `json:"decimals"`
+	LogoURI  string   `json:"logoURI,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Version is a list's semantic version.
+type Version struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// String renders "major.minor.patch".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// List is a parsed token list.
+type List struct {
+	Name      string  `json:"name"`
+	Timestamp string  `json:"timestamp"`
+	Version   Version `json:"version"`
+	Tokens    []Token `json:"tokens"`
+}
+
+var addressRe = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Parse decodes a token list. Tokens that aren't valid EVM entries (bad
+// address, missing symbol, out-of-range decimals) are dropped rather than
+// failing the whole list — large community lists routinely carry a few.
+func Parse(data []byte) (*List, error) {
+	var l List
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parsing token list: %w", err)
+	}
+	if l.Name == "" || l.Tokens == nil {
+		return nil, fmt.Errorf("not a token list: missing name or tokens")
+	}
+	valid := l.Tokens[:0]
+	for _, t := range l.Tokens {
+		t.Symbol = strings.TrimSpace(t.Symbol)
+		if t.ChainID <= 0 || !addressRe.MatchString(t.Address) || t.Symbol == "" ||
+			t.Decimals < 0 || t.Decimals > 255 {
+			continue
+		}
+		valid = append(valid, t)
+	}
+	l.Tokens = valid
+	return &l, nil
+}
+
+// OnChain returns the list's tokens on chainID.
+func (l *List) OnChain(chainID int64) []Token {
+	var out []Token
+	for _, t := range l.Tokens {
+		if t.ChainID == chainID {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package tokenlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleList = `{
+  "name": "Sample List",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": {"major": 1, "minor": 2, "patch": 3},
+  "tokens": [
+    {"chainId": 1, "address": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "name": "USD Coin", "symbol": "USDC", "decimals": 6},
+    {"chainId": 8453, "address": "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", "name": "USD Coin", "symbol": "USDC", "decimals": 6},
+    {"chainId": 1, "address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "name": "Dai", "symbol": "DAI", "decimals": 18},
+    {"chainId": 1, "address": "not-an-address", "name": "Broken", "symbol": "BRK", "decimals": 18},
+    {"chainId": 1, "address": "0x1111111111111111111111111111111111111111", "name": "No symbol", "symbol": " ", "decimals": 18}
+  ]
+}`
+
+func TestParseDropsInvalidTokens(t *testing.T) {
+	l, err := Parse([]byte(sampleList))
+	require.NoError(t, err)
+	assert.Equal(t, "Sample List", l.Name)
+	assert.Equal(t, "1.2.3", l.Version.String())
+	assert.Len(t, l.Tokens, 3)
+	assert.Len(t, l.OnChain(1), 2)
+	assert.Len(t, l.OnChain(8453), 1)
+
+	_, err = Parse([]byte(`{"tokens": []}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`[1, 2]`))
+	assert.Error(t, err)
+}
+
+func TestStoreAddFromFileAndURL(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "list.json")
+	require.NoError(t, os.WriteFile(file, []byte(sampleList), 0o600))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "Remote", "version": {"major": 2}, "tokens": [
+			{"chainId": 1, "address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "name": "USDC dup", "symbol": "USDC", "decimals": 6},
+			{"chainId": 1, "address": "0x2222222222222222222222222222222222222222", "name": "Fake USDC", "symbol": "usdc", "decimals": 6}
+		]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	s := NewStore(dir)
+	require.NoError(t, s.Load())
+	sub, err := s.Add("", file)
+	require.NoError(t, err)
+	assert.Equal(t, "sample-list", sub.Name)
+	assert.Equal(t, 3, sub.Tokens)
+
+	_, err = s.Add("remote", srv.URL)
+	require.NoError(t, err)
+
+	// A fresh store reads everything back from disk.
+	s2 := NewStore(dir)
+	require.NoError(t, s2.Load())
+	require.Len(t, s2.Subscriptions(), 2)
+	assert.Len(t, s2.Tokens(1), 3, "duplicate address across lists is listed once")
+	assert.Len(t, s2.BySymbol(1, "usdc"), 2, "same symbol, different address is ambiguous")
+	assert.Len(t, s2.BySymbol(8453, "USDC"), 1)
+	assert.Empty(t, s2.BySymbol(1, "WETH"))
+}
+
+func TestStoreUpdateAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "list.json")
+	require.NoError(t, os.WriteFile(file, []byte(sampleList), 0o600))
+
+	s := NewStore(dir)
+	_, err := s.Add("mine", file)
+	require.NoError(t, err)
+
+	// A failed update keeps the cached copy.
+	require.NoError(t, os.Remove(file))
+	_, err = s.Update("mine")
+	assert.Error(t, err)
+	assert.Len(t, NewStore(dir).Tokens(1), 2)
+
+	_, err = s.Update("other")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	require.NoError(t, s.Remove("mine"))
+	assert.Empty(t, s.Subscriptions())
+	assert.Empty(t, NewStore(dir).Tokens(1))
+	assert.True(t, errors.Is(s.Remove("mine"), ErrNotFound))
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "uniswap-labs-default", Slug("Uniswap Labs Default"))
+	assert.Equal(t, "coingecko", Slug("  CoinGecko!! "))
+	assert.Equal(t, "", Slug("***"))
+}
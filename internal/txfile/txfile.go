@@ -0,0 +1,322 @@
+// Package txfile defines the JSON files that carry a transaction between the
+// online machine that builds it, the offline machine that signs it, and back
+// for broadcast.
+package txfile
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Version is the current file format version.
+const Version = 1
+
+// Document kinds.
+const (
+	KindUnsigned = "unsigned"
+	KindSigned   = "signed"
+)
+
+// Unsigned is an unsigned EIP-1559 transaction with every field resolved, so
+// signing needs no network access. Amounts are decimal wei strings.
+type Unsigned struct {
+	Kind                 string `json:"kind"`
+	Version              int    `json:"version"`
+	Network              string `json:"network"`
+	ChainID              int64  `json:"chainId"`
+	From                 string `json:"from"`
+	To                   string `json:"to,omitempty"` // empty for contract creation
+	Value                string `json:"value"`
+	Data                 string `json:"data,omitempty"`
+	Nonce                uint64 `json:"nonce"`
+	Gas                  uint64 `json:"gas"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	Note                 string `json:"note,omitempty"`
+	CreatedAt            string `json:"createdAt"`
+}
+
+// Signed is a signed transaction ready to broadcast.
+type Signed struct {
+	Kind     string    `json:"kind"`
+	Version  int       `json:"version"`
+	Network  string    `json:"network"`
+	ChainID  int64     `json:"chainId"`
+	From     string    `json:"from"`
+	Hash     string    `json:"hash"`
+	Raw      string    `json:"raw"`
+	Unsigned *Unsigned `json:"unsigned,omitempty"`
+	SignedAt string    `json:"signedAt"`
+}
+
+// Validate checks that every field is present and well-formed.
+func (u *Unsigned) Validate() error {
+	if u.Kind != KindUnsigned {
+		return fmt.Errorf("not an unsigned transaction file (kind %q)", u.Kind)
+	}
+	if u.Version != Version {
+		return fmt.Errorf("unsupported file version %d", u.Version)
+	}
+	if u.ChainID <= 0 {
+		return fmt.Errorf("chainId is required")
+	}
+	if !common.IsHexAddress(u.From) {
+		return fmt.Errorf("invalid from address %q", u.From)
+	}
+	if u.To != "" && !common.IsHexAddress(u.To) {
+		return fmt.Errorf("invalid to address %q", u.To)
+	}
+	if u.To == "" && u.Data == "" {
+		return fmt.Errorf("contract creation needs data")
+	}
+	if u.Gas == 0 {
+		return fmt.Errorf("gas is required")
+	}
+	for name, v := range map[string]string{"value": u.Value, "maxFeePerGas": u.MaxFeePerGas, "maxPriorityFeePerGas": u.MaxPriorityFeePerGas} {
+		if _, err := parseWei(v); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if _, err := decodeHex(u.Data); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	return nil
+}
+
+// Tx builds the go-ethereum transaction described by u.
+func (u *Unsigned) Tx() (*types.Transaction, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	value, _ := parseWei(u.Value)
+	feeCap, _ := parseWei(u.MaxFeePerGas)
+	tipCap, _ := parseWei(u.MaxPriorityFeePerGas)
+	data, _ := decodeHex(u.Data)
+
+	inner := &types.DynamicFeeTx{
+		ChainID:   big.NewInt(u.ChainID),
+		Nonce:     u.Nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       u.Gas,
+		Value:     value,
+		Data:      data,
+	}
+	if u.To != "" {
+		to := common.HexToAddress(u.To)
+		inner.To = &to
+	}
+	return types.NewTx(inner), nil
+}
+
+// Signer signs transactions (wallet.Signer satisfies it).
+type Signer interface {
+	SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error)
+}
+
+// Sign signs u offline and checks the signature recovers to u.From, so a
+// file built for one wallet can't be signed by another by mistake.
+func Sign(u *Unsigned, signer Signer, signedAt string) (*Signed, error) {
+	tx, err := u.Tx()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signer.SignTx(tx, big.NewInt(u.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	rawHex := "0x" + hex.EncodeToString(raw)
+	d, err := Decode(rawHex)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(d.From, u.From) {
+		return nil, fmt.Errorf("signed by %s but the transaction is from %s", d.From, u.From)
+	}
+	return &Signed{
+		Kind:     KindSigned,
+		Version:  Version,
+		Network:  u.Network,
+		ChainID:  u.ChainID,
+		From:     d.From,
+		Hash:     d.Hash,
+		Raw:      rawHex,
+		Unsigned: u,
+		SignedAt: signedAt,
+	}, nil
+}
+
+// Load reads a transaction file and returns either an *Unsigned or a *Signed.
+func Load(path string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var head struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	switch head.Kind {
+	case KindUnsigned:
+		var u Unsigned
+		if err := json.Unmarshal(data, &u); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return &u, nil
+	case KindSigned:
+		var s Signed
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return &s, nil
+	}
+	return nil, fmt.Errorf("%s is not a w3cli transaction file (kind %q)", path, head.Kind)
+}
+
+// LoadUnsigned reads an unsigned transaction file.
+func LoadUnsigned(path string) (*Unsigned, error) {
+	doc, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := doc.(*Unsigned)
+	if !ok {
+		return nil, fmt.Errorf("%s is already signed", path)
+	}
+	return u, u.Validate()
+}
+
+// LoadSigned reads a signed transaction file.
+func LoadSigned(path string) (*Signed, error) {
+	doc, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := doc.(*Signed)
+	if !ok {
+		return nil, fmt.Errorf("%s is not signed — run `w3cli tx sign` first", path)
+	}
+	return s, nil
+}
+
+// Save writes doc as indented JSON.
+func Save(path string, doc any) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// SignedPath returns the default output path for signing path.
+func SignedPath(path string) string {
+	base := strings.TrimSuffix(path, ".json")
+	base = strings.TrimSuffix(base, ".unsigned")
+	return base + ".signed.json"
+}
+
+// Decoded is a signed raw transaction broken into fields.
+type Decoded struct {
+	Type     string
+	Hash     string
+	ChainID  *big.Int
+	Nonce    uint64
+	From     string
+	To       string // empty for contract creation
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int // legacy / access-list txs
+	FeeCap   *big.Int // dynamic-fee txs
+	TipCap   *big.Int
+	Data     []byte
+	Tx       *types.Transaction
+}
+
+// Decode parses a raw signed transaction (hex) and recovers its sender.
+func Decode(rawHex string) (*Decoded, error) {
+	b, err := decodeHex(strings.TrimSpace(rawHex))
+	if err != nil {
+		return nil, fmt.Errorf("raw transaction: %w", err)
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("raw transaction is empty")
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("decoding transaction: %w", err)
+	}
+
+	d := &Decoded{
+		Type:    typeName(tx.Type()),
+		Hash:    tx.Hash().Hex(),
+		ChainID: tx.ChainId(),
+		Nonce:   tx.Nonce(),
+		Value:   tx.Value(),
+		Gas:     tx.Gas(),
+		Data:    tx.Data(),
+		Tx:      tx,
+	}
+	if tx.To() != nil {
+		d.To = tx.To().Hex()
+	}
+	if tx.Type() == types.DynamicFeeTxType || tx.Type() == types.BlobTxType || tx.Type() == types.SetCodeTxType {
+		d.FeeCap, d.TipCap = tx.GasFeeCap(), tx.GasTipCap()
+	} else {
+		d.GasPrice = tx.GasPrice()
+	}
+
+	var signer types.Signer = types.HomesteadSigner{}
+	if tx.Protected() {
+		signer = types.LatestSignerForChainID(tx.ChainId())
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("recovering sender: %w", err)
+	}
+	d.From = from.Hex()
+	return d, nil
+}
+
+func typeName(t uint8) string {
+	switch t {
+	case types.LegacyTxType:
+		return "legacy"
+	case types.AccessListTxType:
+		return "access-list (EIP-2930)"
+	case types.DynamicFeeTxType:
+		return "dynamic-fee (EIP-1559)"
+	case types.BlobTxType:
+		return "blob (EIP-4844)"
+	case types.SetCodeTxType:
+		return "set-code (EIP-7702)"
+	}
+	return fmt.Sprintf("unknown (0x%02x)", t)
+}
+
+func parseWei(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing amount")
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid wei amount %q", s)
+	}
+	return v, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if s == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(s)
+}
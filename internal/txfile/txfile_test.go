@@ -0,0 +1,178 @@
+package txfile
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keySigner struct{ key *ecdsa.PrivateKey }
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	signed, err := types.SignTx(tx, types.NewLondonSigner(chainID), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func newSigner(t *testing.T) (*keySigner, string) {
+	t.Helper()
+	k, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &keySigner{key: k}, crypto.PubkeyToAddress(k.PublicKey).Hex()
+}
+
+func sampleUnsigned(from string) *Unsigned {
+	return &Unsigned{
+		Kind:                 KindUnsigned,
+		Version:              Version,
+		Network:              "base",
+		ChainID:              8453,
+		From:                 from,
+		To:                   "0x1111111111111111111111111111111111111111",
+		Value:                "1000000000000000",
+		Data:                 "0xa9059cbb",
+		Nonce:                7,
+		Gas:                  60000,
+		MaxFeePerGas:         "2000000000",
+		MaxPriorityFeePerGas: "1000000000",
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Unsigned
+// ---------------------------------------------------------------------------
+
+func TestUnsignedTx(t *testing.T) {
+	u := sampleUnsigned("0x2222222222222222222222222222222222222222")
+	tx, err := u.Tx()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, uint64(7), tx.Nonce())
+	assert.Equal(t, "1000000000000000", tx.Value().String())
+	assert.Equal(t, "2000000000", tx.GasFeeCap().String())
+	assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, tx.Data())
+	assert.Equal(t, common.HexToAddress(u.To), *tx.To())
+}
+
+func TestUnsignedValidate(t *testing.T) {
+	cases := map[string]func(u *Unsigned){
+		"kind":     func(u *Unsigned) { u.Kind = KindSigned },
+		"version":  func(u *Unsigned) { u.Version = 99 },
+		"chain":    func(u *Unsigned) { u.ChainID = 0 },
+		"from":     func(u *Unsigned) { u.From = "0x12" },
+		"to":       func(u *Unsigned) { u.To = "nope" },
+		"create":   func(u *Unsigned) { u.To, u.Data = "", "" },
+		"gas":      func(u *Unsigned) { u.Gas = 0 },
+		"value":    func(u *Unsigned) { u.Value = "1.5" },
+		"fee":      func(u *Unsigned) { u.MaxFeePerGas = "" },
+		"bad data": func(u *Unsigned) { u.Data = "0xzz" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := sampleUnsigned("0x2222222222222222222222222222222222222222")
+			mutate(u)
+			assert.Error(t, u.Validate())
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Sign / Decode
+// ---------------------------------------------------------------------------
+
+func TestSignAndDecode(t *testing.T) {
+	signer, from := newSigner(t)
+	u := sampleUnsigned(from)
+
+	s, err := Sign(u, signer, "2026-01-01T00:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, KindSigned, s.Kind)
+	assert.Equal(t, from, s.From)
+
+	d, err := Decode(s.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, s.Hash, d.Hash)
+	assert.Equal(t, from, d.From)
+	assert.Equal(t, int64(8453), d.ChainID.Int64())
+	assert.Equal(t, uint64(7), d.Nonce)
+	assert.Equal(t, "1000000000", d.TipCap.String())
+	assert.Nil(t, d.GasPrice)
+	assert.Contains(t, d.Type, "1559")
+}
+
+func TestSignRejectsWrongWallet(t *testing.T) {
+	signer, _ := newSigner(t)
+	_, other := newSigner(t)
+	_, err := Sign(sampleUnsigned(other), signer, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signed by")
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	k, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := types.SignTx(types.NewTransaction(3, to, big.NewInt(5), 21000, big.NewInt(9), nil), types.NewEIP155Signer(big.NewInt(1)), k)
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	d, err := Decode(hex.EncodeToString(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", d.Type)
+	assert.Equal(t, "9", d.GasPrice.String())
+	assert.Equal(t, crypto.PubkeyToAddress(k.PublicKey).Hex(), d.From)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, bad := range []string{"", "0x", "0xzz", "0x01020304"} {
+		_, err := Decode(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Files
+// ---------------------------------------------------------------------------
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	signer, from := newSigner(t)
+	u := sampleUnsigned(from)
+
+	upath := filepath.Join(dir, "pay.json")
+	require.NoError(t, Save(upath, u))
+	got, err := LoadUnsigned(upath)
+	require.NoError(t, err)
+	assert.Equal(t, u, got)
+
+	_, err = LoadSigned(upath)
+	assert.Error(t, err, "unsigned file is not broadcastable")
+
+	s, err := Sign(got, signer, "now")
+	require.NoError(t, err)
+	spath := SignedPath(upath)
+	assert.Equal(t, filepath.Join(dir, "pay.signed.json"), spath)
+	require.NoError(t, Save(spath, s))
+
+	loaded, err := LoadSigned(spath)
+	require.NoError(t, err)
+	assert.Equal(t, s.Raw, loaded.Raw)
+	_, err = LoadUnsigned(spath)
+	assert.Error(t, err)
+}
+
+func TestSignedPath(t *testing.T) {
+	assert.Equal(t, "tx.signed.json", SignedPath("tx.unsigned.json"))
+	assert.Equal(t, "tx.signed.json", SignedPath("tx"))
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MDStatus is the state of one chain in a multi-chain deploy.
+type MDStatus int
+
+const (
+	MDStatusPending MDStatus = iota
+	MDStatusDeploying
+	MDStatusDone
+	MDStatusSkipped // already deployed (CREATE2 address has code)
+	MDStatusError
+)
+
+// MultiDeployRow holds the progress of one chain's deployment.
+type MultiDeployRow struct {
+	ChainName   string
+	DisplayName string
+	Status      MDStatus
+	Stage       string // e.g. "estimating gas", "confirming"
+	Address     string
+	TxHash      string
+	GasUsed     uint64
+	Elapsed     time.Duration
+	ErrMsg      string
+}
+
+// MultiDeployStageMsg reports that a chain moved to a new stage.
+type MultiDeployStageMsg struct {
+	ChainName string
+	Stage     string
+}
+
+// MultiDeployResult is sent by each deploy goroutine when it finishes.
+type MultiDeployResult struct {
+	ChainName string
+	Address   string
+	TxHash    string
+	GasUsed   uint64
+	Skipped   bool
+	Elapsed   time.Duration
+	Err       error
+}
+
+// MultiDeployResultMsg wraps MultiDeployResult as a Bubble Tea message.
+type MultiDeployResultMsg MultiDeployResult
+
+type multiDeployTickMsg struct{}
+
+// MultiDeployModel is the Bubble Tea model for deploying one contract to
+// several chains at once. It quits by itself once every chain has finished.
+type MultiDeployModel struct {
+	Name     string // contract name
+	Mode     string
+	Rows     []MultiDeployRow
+	RowIndex map[string]int
+	Total    int
+	Done     int
+	Frame    int
+	Quitting bool
+}
+
+func (m MultiDeployModel) Init() tea.Cmd { return mdTick() }
+
+func mdTick() tea.Cmd {
+	return tea.Tick(80*time.Millisecond, func(time.Time) tea.Msg {
+		return multiDeployTickMsg{}
+	})
+}
+
+func (m MultiDeployModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+	case multiDeployTickMsg:
+		m.Frame = (m.Frame + 1) % len(abSpinFrames)
+		return m, mdTick()
+
+	case MultiDeployStageMsg:
+		if idx, ok := m.RowIndex[msg.ChainName]; ok && m.Rows[idx].Status <= MDStatusDeploying {
+			m.Rows[idx].Status = MDStatusDeploying
+			m.Rows[idx].Stage = msg.Stage
+		}
+
+	case MultiDeployResultMsg:
+		idx, ok := m.RowIndex[msg.ChainName]
+		if !ok || m.Rows[idx].Status > MDStatusDeploying {
+			return m, nil
+		}
+		row := &m.Rows[idx]
+		row.Address, row.TxHash, row.GasUsed, row.Elapsed = msg.Address, msg.TxHash, msg.GasUsed, msg.Elapsed
+		switch {
+		case msg.Err != nil:
+			row.Status = MDStatusError
+			row.ErrMsg = trimErr(msg.Err.Error())
+		case msg.Skipped:
+			row.Status = MDStatusSkipped
+		default:
+			row.Status = MDStatusDone
+		}
+		m.Done++
+		if m.Done >= m.Total {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m MultiDeployModel) View() string {
+	if m.Quitting {
+		return ""
+	}
+
+	var sb strings.Builder
+	spin := abSpinFrames[m.Frame]
+
+	sb.WriteString(StyleTitle.Render(fmt.Sprintf("🚀 Deploying %s  ·  mode: %s", m.Name, m.Mode)) + "\n")
+	sb.WriteString(StyleInfo.Render(fmt.Sprintf("%s %d/%d chains finished…", spin, m.Done, m.Total)) + "\n\n")
+
+	const (
+		wChain = 16
+		wState = 22
+		wAddr  = 13 // 0x1234…abcd
+		wTx    = 13
+		wGas   = 10
+	)
+	sep := StyleMeta.Render(strings.Repeat("─", wChain+wState+wAddr+wTx+wGas+14))
+
+	sb.WriteString(
+		padR(StyleDim.Render("CHAIN"), wChain) + "  " +
+			padR(StyleDim.Render("STATUS"), wState) + "  " +
+			padR(StyleDim.Render("ADDRESS"), wAddr) + "  " +
+			padR(StyleDim.Render("TX"), wTx) + "  " +
+			padR(StyleDim.Render("GAS USED"), wGas) + "  " +
+			StyleDim.Render("TIME") + "\n",
+	)
+	sb.WriteString(sep + "\n")
+
+	for _, row := range m.Rows {
+		stateStr, addrStr, txStr, gasStr, timeStr := renderDeployRow(row, spin)
+		sb.WriteString(
+			padR(ChainName(row.DisplayName), wChain) + "  " +
+				padR(stateStr, wState) + "  " +
+				padR(addrStr, wAddr) + "  " +
+				padR(txStr, wTx) + "  " +
+				padR(gasStr, wGas) + "  " +
+				timeStr + "\n",
+		)
+	}
+
+	sb.WriteString(sep + "\n\n")
+	sb.WriteString(StyleMeta.Render("Transactions already sent keep confirming if you quit.   ") +
+		StyleInfo.Render("[ q ]") + StyleMeta.Render(" quit"))
+	return sb.String()
+}
+
+func renderDeployRow(row MultiDeployRow, spin string) (stateStr, addrStr, txStr, gasStr, timeStr string) {
+	dash := StyleMeta.Render("—")
+	switch row.Status {
+	case MDStatusPending:
+		return StyleMeta.Render("⏳ waiting"), dash, dash, dash, dash
+
+	case MDStatusDeploying:
+		return StyleInfo.Render(spin + " " + row.Stage), dash, dash, dash, dash
+
+	case MDStatusDone:
+		return StyleSuccess.Render("✓ deployed"),
+			StyleValue.Render(TruncateAddr(row.Address)),
+			StyleMeta.Render(TruncateAddr(row.TxHash)),
+			StyleValue.Render(fmt.Sprintf("%d", row.GasUsed)),
+			StyleMeta.Render(row.Elapsed.Truncate(time.Second).String())
+
+	case MDStatusSkipped:
+		return StyleInfo.Render("ℹ already deployed"),
+			StyleValue.Render(TruncateAddr(row.Address)),
+			dash, dash, dash
+
+	case MDStatusError:
+		short := row.ErrMsg
+		if len(short) > 20 {
+			short = short[:20] + "…"
+		}
+		txStr = dash
+		if row.TxHash != "" {
+			txStr = StyleMeta.Render(TruncateAddr(row.TxHash))
+		}
+		return StyleError.Render("✗ " + short), dash, txStr, dash, dash
+	}
+	return "", "", "", "", ""
+}
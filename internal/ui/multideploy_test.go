@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMultiDeploy() MultiDeployModel {
+	return MultiDeployModel{
+		Name: "Vault",
+		Mode: "testnet",
+		Rows: []MultiDeployRow{
+			{ChainName: "base", DisplayName: "Base"},
+			{ChainName: "optimism", DisplayName: "Optimism"},
+			{ChainName: "arbitrum", DisplayName: "Arbitrum"},
+		},
+		RowIndex: map[string]int{"base": 0, "optimism": 1, "arbitrum": 2},
+		Total:    3,
+	}
+}
+
+func TestMultiDeployStageAndResults(t *testing.T) {
+	var m tea.Model = newTestMultiDeploy()
+
+	m, _ = m.Update(MultiDeployStageMsg{ChainName: "base", Stage: "confirming"})
+	md := m.(MultiDeployModel)
+	assert.Equal(t, MDStatusDeploying, md.Rows[0].Status)
+	assert.Contains(t, md.View(), "confirming")
+
+	m, cmd := m.Update(MultiDeployResultMsg{ChainName: "base", Address: "0x1111111111111111111111111111111111111111", GasUsed: 21000})
+	assert.Nil(t, cmd)
+	m, _ = m.Update(MultiDeployResultMsg{ChainName: "optimism", Address: "0x2222222222222222222222222222222222222222", Skipped: true})
+	m, _ = m.Update(MultiDeployStageMsg{ChainName: "optimism", Stage: "late"})
+
+	md = m.(MultiDeployModel)
+	assert.Equal(t, MDStatusDone, md.Rows[0].Status)
+	assert.Equal(t, MDStatusSkipped, md.Rows[1].Status, "stages after a result are ignored")
+	assert.Equal(t, 2, md.Done)
+	assert.Contains(t, md.View(), "already deployed")
+
+	m, cmd = m.Update(MultiDeployResultMsg{ChainName: "arbitrum", Err: errors.New("insufficient funds")})
+	require.NotNil(t, cmd, "finishing every chain quits")
+	md = m.(MultiDeployModel)
+	assert.Equal(t, MDStatusError, md.Rows[2].Status)
+	assert.Equal(t, "insufficient funds", md.Rows[2].ErrMsg)
+	assert.True(t, md.Quitting)
+	assert.Empty(t, md.View())
+}
+
+func TestMultiDeployIgnoresUnknownAndDuplicateResults(t *testing.T) {
+	var m tea.Model = newTestMultiDeploy()
+	m, _ = m.Update(MultiDeployResultMsg{ChainName: "nope"})
+	m, _ = m.Update(MultiDeployResultMsg{ChainName: "base"})
+	m, _ = m.Update(MultiDeployResultMsg{ChainName: "base"})
+	assert.Equal(t, 1, m.(MultiDeployModel).Done)
+}
@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ---------------------------------------------------------------------------
@@ -263,3 +265,163 @@ func TestStudioEntryIsPayableField(t *testing.T) {
 	assert.False(t, nonPayable.IsPayable, "withdraw should not be payable")
 	assert.False(t, readOnly.IsPayable, "balance should not be payable")
 }
+
+func TestStudioUpdateActions(t *testing.T) {
+	model := StudioModel{
+		Entries: []StudioEntry{
+			{Name: "balanceOf", Selector: "0x70a08231", Sig: "balanceOf(address)"},
+			{Name: "transfer", Selector: "0xa9059cbb", Sig: "transfer(address,uint256)", IsWrite: true},
+		},
+	}
+	model.buildNav()
+
+	next, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m := next.(StudioModel)
+	require.NotNil(t, m.Selected)
+	assert.Equal(t, "balanceOf", m.Selected.Name)
+	assert.Equal(t, StudioCall, m.Action)
+
+	next, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	next, _ = next.(StudioModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = next.(StudioModel)
+	require.NotNil(t, m.Selected)
+	assert.Equal(t, "transfer", m.Selected.Name)
+	assert.Equal(t, StudioCalldata, m.Action)
+	assert.Contains(t, model.View(), "copy calldata")
+}
+
+func studioKeys(m StudioModel, keys ...tea.KeyMsg) StudioModel {
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(StudioModel)
+	}
+	return m
+}
+
+func runes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func studioTestModel() StudioModel {
+	m := StudioModel{
+		Entries: []StudioEntry{
+			{Name: "balanceOf", Selector: "0x70a08231", Sig: "balanceOf(address)", Inputs: []StudioParam{{Name: "owner", Type: "address"}}},
+			{Name: "totalSupply", Selector: "0x18160ddd", Sig: "totalSupply()"},
+			{Name: "transfer", Selector: "0xa9059cbb", Sig: "transfer(address,uint256)", IsWrite: true},
+			{Name: "Transfer", Sig: "Transfer(address,address,uint256)", IsEvent: true},
+		},
+	}
+	m.buildNav()
+	return m
+}
+
+func TestStudioMatches(t *testing.T) {
+	e := StudioEntry{Name: "balanceOf", Selector: "0x70a08231"}
+	assert.True(t, studioMatches("", e))
+	assert.True(t, studioMatches("blof", e))
+	assert.True(t, studioMatches("BAL", e))
+	assert.True(t, studioMatches("0x70a0", e))
+	assert.False(t, studioMatches("fob", e))
+	assert.False(t, studioMatches("supply", e))
+}
+
+func TestStudioFavouritesFirst(t *testing.T) {
+	m := studioKeys(studioTestModel(), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}, runes("f"))
+	assert.True(t, m.Entries[2].Favourite)
+	assert.Equal(t, []int{2, 0, 1}, m.navItems)
+	assert.Equal(t, 0, m.cursor, "cursor follows the pinned entry")
+	assert.Contains(t, m.View(), "Favourites")
+
+	m = studioKeys(m, runes("f"))
+	assert.Equal(t, []int{0, 1, 2}, m.navItems)
+	assert.Equal(t, 2, m.cursor)
+}
+
+func TestStudioSearch(t *testing.T) {
+	m := studioKeys(studioTestModel(), runes("/"), runes("t"), runes("s"), runes("p"))
+	assert.Equal(t, []int{1}, m.navItems)
+	assert.Contains(t, m.View(), "1 match(es)")
+
+	// q is part of the query while searching, Enter selects the match.
+	m = studioKeys(m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "t", m.query)
+	assert.False(t, studioKeys(m, runes("q")).Quitting)
+	selected := studioKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, selected.Selected)
+	assert.Equal(t, "totalSupply", selected.Selected.Name)
+
+	// Esc clears the search instead of quitting.
+	m = studioKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.Quitting)
+	assert.Empty(t, m.query)
+	assert.Len(t, m.navItems, 3)
+}
+
+func TestStudioHistoryRerun(t *testing.T) {
+	m := studioTestModel()
+	m = studioKeys(m, runes("h"))
+	assert.False(t, m.showHistory, "nothing to show without history")
+
+	m.History = []StudioHistoryItem{
+		{Sig: "totalSupply()", Outcome: "100", At: "10:00"},
+		{Sig: "transfer(address,uint256)", Args: []string{"0xabc", "1"}, Outcome: "0xhash", At: "09:00"},
+	}
+	m = studioKeys(m, runes("h"))
+	require.True(t, m.showHistory)
+	assert.Contains(t, m.View(), "transfer(0xabc, 1)")
+	m = studioKeys(m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, StudioRerun, m.Action)
+	assert.Equal(t, 1, m.HistoryIndex)
+	assert.Nil(t, m.Selected)
+}
+
+func TestStudioWatchToggle(t *testing.T) {
+	m := studioKeys(studioTestModel(), tea.KeyMsg{Type: tea.KeyDown}, runes("w"))
+	require.Len(t, m.Watches, 1)
+	assert.Equal(t, "totalSupply()", m.Watches[0].Label)
+	assert.Contains(t, m.View(), "watching")
+
+	m = studioKeys(m, runes("w"))
+	assert.Empty(t, m.Watches)
+
+	// Functions with arguments go back to collect them.
+	withArgs := studioKeys(studioTestModel(), runes("w"))
+	assert.Equal(t, StudioAddWatch, withArgs.Action)
+	require.NotNil(t, withArgs.Selected)
+	assert.Equal(t, "balanceOf", withArgs.Selected.Name)
+
+	write := studioKeys(studioTestModel(), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}, runes("w"))
+	assert.Empty(t, write.Watches)
+	assert.Nil(t, write.Selected)
+	assert.Contains(t, write.View(), "Only read functions")
+}
+
+func TestStudioApplyLive(t *testing.T) {
+	m := studioTestModel()
+	m.Poll = func([]StudioWatch) StudioLive { return StudioLive{} }
+	m.Watches = []StudioWatch{{Selector: "0x18160ddd", Label: "totalSupply()"}}
+	key := m.Watches[0].Key()
+
+	m.applyLive(StudioLive{Block: 10, Values: map[string]string{key: "100"}, Events: []StudioEvent{{Block: 10, Name: "Transfer", Args: "value=1"}}})
+	assert.Equal(t, uint64(10), m.Block)
+	assert.Equal(t, "100", m.Watches[0].Value)
+	assert.False(t, m.Watches[0].Changed, "first value is not a change")
+
+	m.applyLive(StudioLive{Block: 11, Values: map[string]string{key: "150"}})
+	assert.True(t, m.Watches[0].Changed)
+
+	var events []StudioEvent
+	for i := 0; i < 30; i++ {
+		events = append(events, StudioEvent{Block: 12, Name: "Transfer"})
+	}
+	m.applyLive(StudioLive{Block: 12, Values: map[string]string{key: "150"}, Events: events})
+	assert.False(t, m.Watches[0].Changed)
+	assert.Len(t, m.Events, maxStudioEvents)
+
+	m.applyLive(StudioLive{Err: assert.AnError})
+	assert.Equal(t, uint64(12), m.Block, "errors keep the last state")
+	assert.NotEmpty(t, m.LiveErr)
+
+	view := m.View()
+	assert.Contains(t, view, "block 12")
+	assert.Contains(t, view, "Recent events")
+	assert.Contains(t, view, "totalSupply()")
+}
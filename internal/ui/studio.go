@@ -3,8 +3,10 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ── Types ────────────────────────────────────────────────────────────────────
@@ -25,22 +27,47 @@ type StudioParam struct {
 // StudioEntry is one navigable item in the contract studio.
 type StudioEntry struct {
 	Name        string
-	Selector    string       // "0xa9059cbb" — empty for events
-	Sig         string       // canonical sig, e.g. "transfer(address,uint256)"
+	Selector    string // "0xa9059cbb" — empty for events
+	Sig         string // canonical sig, e.g. "transfer(address,uint256)"
 	IsWrite     bool
 	IsPayable   bool
 	IsEvent     bool
+	Favourite   bool // pinned at the top of the list
 	Inputs      []StudioParam
 	OutputTypes []string // display only (read functions)
 	Description string   // human description, shown in the info panel
 }
 
+// StudioAction is what the user chose to do with the selected entry.
+type StudioAction int
+
+const (
+	StudioCall     StudioAction = iota // call it, or send it as a transaction
+	StudioCalldata                     // only build its calldata, to copy elsewhere
+	StudioRerun                        // repeat History[HistoryIndex]; Selected is nil
+	StudioAddWatch                     // collect arguments and watch the selected view function
+)
+
+// StudioHistoryItem is one past call shown in the history view.
+type StudioHistoryItem struct {
+	Sig     string
+	Args    []string
+	Outcome string // results, tx hash or error
+	Failed  bool
+	At      string
+}
+
+// maxStudioEvents is the number of events kept in the live panel.
+const maxStudioEvents = 20
+
 // ── Bubble Tea model ─────────────────────────────────────────────────────────
 
 // StudioModel is the Bubble Tea model for the interactive function navigator.
-// It shows read functions, write functions and events in labelled sections,
-// lets the user navigate with ↑↓ / j k, and exits with the selected entry
-// when Enter is pressed.
+// It shows favourite, read and write functions and events in labelled
+// sections, lets the user navigate with ↑↓ / j k, and exits with the
+// selected entry when Enter (call) or c (build calldata) is pressed. When
+// Poll is set, a side panel shows the contract's recent events and the
+// watched view functions, refreshed every PollEvery.
 type StudioModel struct {
 	// Static contract metadata
 	ContractName string
@@ -52,41 +79,135 @@ type StudioModel struct {
 	EventCount   int
 
 	Entries []StudioEntry
+	History []StudioHistoryItem // newest first
+
+	// live panel; kept between runs so values and events survive a call
+	Poll      StudioPoller
+	PollEvery time.Duration
+	Watches   []StudioWatch
+	Events    []StudioEvent // oldest first
+	Block     uint64
+	LiveErr   string
 
 	// internal navigation
-	navItems []int // navItems[i] = index into Entries; events are excluded
-	cursor   int   // position inside navItems
+	navItems    []int // navItems[i] = index into Entries; events are excluded
+	cursor      int   // position inside navItems
+	query       string
+	searching   bool
+	showHistory bool
+	histCursor  int
+	notice      string
+	width       int
 
 	// output
-	Selected *StudioEntry
-	Quitting bool
+	Selected     *StudioEntry
+	Action       StudioAction
+	HistoryIndex int // for StudioRerun
+	Quitting     bool
 }
 
-func (m *StudioModel) buildNav() {
-	m.navItems = nil
-	// order: reads first, then writes; events are displayed but not navigable
-	var reads, writes []int
+// sections returns the navigable entries matching the search, split into
+// favourites, reads and writes.
+func (m *StudioModel) sections() (favs, reads, writes []int) {
 	for i, e := range m.Entries {
-		if e.IsEvent {
+		if e.IsEvent || !studioMatches(m.query, e) {
 			continue
 		}
-		if e.IsWrite {
+		switch {
+		case e.Favourite:
+			favs = append(favs, i)
+		case e.IsWrite:
 			writes = append(writes, i)
-		} else {
+		default:
 			reads = append(reads, i)
 		}
 	}
+	return favs, reads, writes
+}
+
+func (m *StudioModel) buildNav() {
+	// order: favourites, reads, then writes; events are displayed but not navigable
+	favs, reads, writes := m.sections()
+	m.navItems = nil
+	m.navItems = append(m.navItems, favs...)
 	m.navItems = append(m.navItems, reads...)
 	m.navItems = append(m.navItems, writes...)
+	if m.cursor >= len(m.navItems) {
+		m.cursor = max(len(m.navItems)-1, 0)
+	}
 }
 
-func (m StudioModel) Init() tea.Cmd { return nil }
+// current returns the index into Entries of the entry under the cursor.
+func (m *StudioModel) current() (int, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.navItems) {
+		return 0, false
+	}
+	return m.navItems[m.cursor], true
+}
+
+// moveTo puts the cursor on an entry, if it is still listed.
+func (m *StudioModel) moveTo(idx int) {
+	for pos, i := range m.navItems {
+		if i == idx {
+			m.cursor = pos
+			return
+		}
+	}
+}
+
+func (m StudioModel) Init() tea.Cmd {
+	if m.Poll == nil {
+		return nil
+	}
+	return m.pollCmd()
+}
 
 func (m StudioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case studioLiveMsg:
+		m.applyLive(StudioLive(msg))
+		return m, tea.Tick(m.pollInterval(), func(time.Time) tea.Msg { return studioPollMsg{} })
+	case studioPollMsg:
+		return m, m.pollCmd()
 	case tea.KeyMsg:
+		m.notice = ""
+		if m.showHistory {
+			return m.updateHistory(msg)
+		}
+		if m.searching {
+			switch msg.Type {
+			case tea.KeyRunes:
+				m.query += string(msg.Runes)
+				m.cursor = 0
+				m.buildNav()
+				return m, nil
+			case tea.KeyBackspace:
+				if r := []rune(m.query); len(r) > 0 {
+					m.query = string(r[:len(r)-1])
+					m.buildNav()
+				}
+				return m, nil
+			case tea.KeyEsc:
+				m.searching, m.query = false, ""
+				m.buildNav()
+				return m, nil
+			case tea.KeyEnter:
+				m.searching = false
+			}
+		}
 		switch msg.String() {
-		case "q", "ctrl+c", "esc":
+		case "esc":
+			if m.query != "" {
+				m.query = ""
+				m.buildNav()
+				return m, nil
+			}
+			m.Quitting = true
+			return m, tea.Quit
+		case "q", "ctrl+c":
 			m.Quitting = true
 			return m, tea.Quit
 		case "up", "k":
@@ -97,10 +218,32 @@ func (m StudioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < len(m.navItems)-1 {
 				m.cursor++
 			}
-		case "enter", " ":
-			if len(m.navItems) > 0 {
-				e := m.Entries[m.navItems[m.cursor]]
+		case "/":
+			m.searching = true
+		case "f":
+			if idx, ok := m.current(); ok {
+				m.Entries[idx].Favourite = !m.Entries[idx].Favourite
+				m.buildNav()
+				m.moveTo(idx)
+			}
+		case "h":
+			if len(m.History) == 0 {
+				m.notice = "No calls yet for this contract."
+			} else {
+				m.showHistory, m.histCursor = true, 0
+			}
+		case "w":
+			if idx, ok := m.current(); ok {
+				return m.toggleWatch(idx)
+			}
+		case "enter", " ", "c":
+			if idx, ok := m.current(); ok {
+				e := m.Entries[idx]
 				m.Selected = &e
+				m.Action = StudioCall
+				if msg.String() == "c" {
+					m.Action = StudioCalldata
+				}
 				return m, tea.Quit
 			}
 		}
@@ -108,6 +251,61 @@ func (m StudioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateHistory handles keys while the history view is open.
+func (m StudioModel) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		m.Quitting = true
+		return m, tea.Quit
+	case "esc", "h":
+		m.showHistory = false
+	case "up", "k":
+		if m.histCursor > 0 {
+			m.histCursor--
+		}
+	case "down", "j":
+		if m.histCursor < len(m.History)-1 {
+			m.histCursor++
+		}
+	case "enter", " ":
+		m.Action = StudioRerun
+		m.HistoryIndex = m.histCursor
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// toggleWatch stops watching a view function, or starts: directly when it
+// takes no arguments, otherwise by returning to collect them.
+func (m StudioModel) toggleWatch(idx int) (tea.Model, tea.Cmd) {
+	e := m.Entries[idx]
+	if e.IsWrite {
+		m.notice = "Only read functions can be watched."
+		return m, nil
+	}
+	kept := m.Watches[:0:0]
+	for _, w := range m.Watches {
+		if w.Selector != e.Selector {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) != len(m.Watches) {
+		m.Watches = kept
+		m.notice = "Stopped watching " + e.Name + "."
+		return m, nil
+	}
+	if len(e.Inputs) > 0 {
+		m.Selected = &e
+		m.Action = StudioAddWatch
+		return m, tea.Quit
+	}
+	m.Watches = append(m.Watches, StudioWatch{Selector: e.Selector, Label: e.Name + "()"})
+	if m.Poll == nil {
+		m.notice = "Watching " + e.Name + "() — live updates are off."
+	}
+	return m, nil
+}
+
 func (m StudioModel) View() string {
 	if m.Quitting {
 		return ""
@@ -137,57 +335,62 @@ func (m StudioModel) View() string {
 		StyleMeta.Render(fmt.Sprintf("%d events", m.EventCount))))
 	sb.WriteString("\n")
 
+	ruler := StyleMeta.Render(strings.Repeat("─", sepWidth))
+	header := func(label string, n int) string {
+		hdr := fmt.Sprintf("  ── %s (%d) ", label, n)
+		fill := sepWidth - len(hdr) - 2
+		if fill < 0 {
+			fill = 0
+		}
+		return StyleHeader.Render(hdr) + StyleMeta.Render(strings.Repeat("─", fill)) + "\n"
+	}
+
+	if m.showHistory {
+		sb.WriteString(header("History", len(m.History)))
+		sb.WriteString(m.historyView())
+		sb.WriteString("\n" + ruler + "\n\n")
+		sb.WriteString(
+			StyleMeta.Render("  [ ↑↓ / jk ]") + " navigate   " +
+				StyleInfo.Render("[ Enter ]") + " re-run   " +
+				StyleMeta.Render("[ h / Esc ]") + " back   " +
+				StyleMeta.Render("[ q ]") + " quit\n")
+		return m.withLivePanel(sb.String())
+	}
+
+	// ── Search ────────────────────────────────────────────────────────────
+	if m.searching || m.query != "" {
+		cursor := ""
+		if m.searching {
+			cursor = "▏"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s%s  %s\n\n",
+			StyleInfo.Render("/"),
+			StyleValue.Render(m.query), cursor,
+			StyleMeta.Render(fmt.Sprintf("%d match(es)", len(m.navItems)))))
+	}
+
 	// Build reverse-lookup: entry index → nav cursor position
 	navPos := make(map[int]int, len(m.navItems))
 	for pos, entIdx := range m.navItems {
 		navPos[entIdx] = pos
 	}
 
-	// Split entries into three buckets
-	var readIdxs, writeIdxs []int
+	favs, reads, writes := m.sections()
 	var eventEntries []StudioEntry
-	for i, e := range m.Entries {
+	for _, e := range m.Entries {
 		if e.IsEvent {
 			eventEntries = append(eventEntries, e)
-		} else if e.IsWrite {
-			writeIdxs = append(writeIdxs, i)
-		} else {
-			readIdxs = append(readIdxs, i)
 		}
 	}
 
-	ruler := StyleMeta.Render(strings.Repeat("─", sepWidth))
-
-	// ── Read section ──────────────────────────────────────────────────────
-	if len(readIdxs) > 0 {
-		hdr := fmt.Sprintf("  ── Read (%d) ", len(readIdxs))
-		fill := sepWidth - len(hdr) - 2
-		if fill < 0 {
-			fill = 0
+	section := func(label string, idxs []int) {
+		if len(idxs) == 0 {
+			return
 		}
-		sb.WriteString(StyleHeader.Render(hdr) + StyleMeta.Render(strings.Repeat("─", fill)) + "\n")
-
-		for _, idx := range readIdxs {
-			e := m.Entries[idx]
-			pos := navPos[idx]
-			selected := pos == m.cursor
-
-			outStr := ""
-			if len(e.OutputTypes) > 0 {
-				outStr = StyleMeta.Render("  →  " + strings.Join(e.OutputTypes, ", "))
-			}
-
-			prefix := "    "
-			if selected {
-				prefix = "  ▸ "
-			}
-			line := fmt.Sprintf("%s%s  %s(%s)%s",
-				prefix,
-				StyleMeta.Render(e.Selector),
-				StyleValue.Render(e.Name),
-				StyleMeta.Render(studioParamSig(e.Inputs)),
-				outStr,
-			)
+		sb.WriteString(header(label, len(idxs)))
+		for _, idx := range idxs {
+			selected := navPos[idx] == m.cursor
+			line := m.entryLine(m.Entries[idx], selected)
 			if selected {
 				sb.WriteString(StyleSelected.Render(line) + "\n")
 			} else {
@@ -197,52 +400,17 @@ func (m StudioModel) View() string {
 		sb.WriteString("\n")
 	}
 
-	// ── Write section ─────────────────────────────────────────────────────
-	if len(writeIdxs) > 0 {
-		hdr := fmt.Sprintf("  ── Write (%d) ", len(writeIdxs))
-		fill := sepWidth - len(hdr) - 2
-		if fill < 0 {
-			fill = 0
-		}
-		sb.WriteString(StyleHeader.Render(hdr) + StyleMeta.Render(strings.Repeat("─", fill)) + "\n")
-
-		for _, idx := range writeIdxs {
-			e := m.Entries[idx]
-			pos := navPos[idx]
-			selected := pos == m.cursor
-
-			prefix := "    "
-			if selected {
-				prefix = "  ▸ "
-			}
-			payableBadge := ""
-			if e.IsPayable {
-				payableBadge = StyleInfo.Render("  Ξ payable")
-			}
-			line := fmt.Sprintf("%s%s  %s(%s)%s",
-				prefix,
-				StyleMeta.Render(e.Selector),
-				StyleWarning.Render(e.Name),
-				StyleMeta.Render(studioParamSig(e.Inputs)),
-				payableBadge,
-			)
-			if selected {
-				sb.WriteString(StyleSelected.Render(line) + "\n")
-			} else {
-				sb.WriteString(line + "\n")
-			}
-		}
-		sb.WriteString("\n")
+	// ── Favourites, Read and Write sections ───────────────────────────────
+	section("★ Favourites", favs)
+	section("Read", reads)
+	section("Write", writes)
+	if len(m.navItems) == 0 && m.query != "" {
+		sb.WriteString(StyleMeta.Render("  No functions match.") + "\n\n")
 	}
 
 	// ── Events section ────────────────────────────────────────────────────
-	if len(eventEntries) > 0 {
-		hdr := fmt.Sprintf("  ── Events (%d) ", len(eventEntries))
-		fill := sepWidth - len(hdr) - 2
-		if fill < 0 {
-			fill = 0
-		}
-		sb.WriteString(StyleHeader.Render(hdr) + StyleMeta.Render(strings.Repeat("─", fill)) + "\n")
+	if len(eventEntries) > 0 && m.query == "" {
+		sb.WriteString(header("Events", len(eventEntries)))
 		for _, e := range eventEntries {
 			sb.WriteString(fmt.Sprintf("    %s(%s)\n",
 				StyleInfo.Render(e.Name),
@@ -253,39 +421,112 @@ func (m StudioModel) View() string {
 
 	// ── Description panel ─────────────────────────────────────────────────
 	sb.WriteString(ruler + "\n")
-	if len(m.navItems) > 0 {
-		cur := m.Entries[m.navItems[m.cursor]]
+	if idx, ok := m.current(); ok {
+		cur := m.Entries[idx]
 		desc := cur.Description
 		if desc == "" {
 			desc = cur.Sig
 		}
 		sb.WriteString(StyleMeta.Render("  "+desc) + "\n")
 	}
+	if m.notice != "" {
+		sb.WriteString(StyleWarning.Render("  "+m.notice) + "\n")
+	}
 	sb.WriteString(ruler + "\n\n")
 
 	// ── Controls ──────────────────────────────────────────────────────────
 	sb.WriteString(
 		StyleMeta.Render("  [ ↑↓ / jk ]") + " navigate   " +
 			StyleInfo.Render("[ Enter ]") + " select & call   " +
+			StyleInfo.Render("[ c ]") + " copy calldata   " +
 			StyleMeta.Render("[ q ]") + " quit\n")
+	sb.WriteString(
+		StyleMeta.Render("  [ / ]") + " search   " +
+			StyleMeta.Render("[ f ]") + " favourite   " +
+			StyleMeta.Render("[ w ]") + " watch   " +
+			StyleMeta.Render("[ h ]") + " history\n")
+
+	return m.withLivePanel(sb.String())
+}
+
+// entryLine renders one function of the list.
+func (m StudioModel) entryLine(e StudioEntry, selected bool) string {
+	prefix := "    "
+	if selected {
+		prefix = "  ▸ "
+	}
+	star := ""
+	if e.Favourite {
+		star = StyleWarning.Render("★ ")
+	}
+	name := StyleValue.Render(e.Name)
+	suffix := ""
+	if e.IsWrite {
+		name = StyleWarning.Render(e.Name)
+		if e.IsPayable {
+			suffix = StyleInfo.Render("  Ξ payable")
+		}
+	} else {
+		if len(e.OutputTypes) > 0 {
+			suffix = StyleMeta.Render("  →  " + strings.Join(e.OutputTypes, ", "))
+		}
+		if m.isWatched(e.Selector) {
+			suffix += StyleInfo.Render("  ◉ watching")
+		}
+	}
+	return fmt.Sprintf("%s%s%s  %s(%s)%s",
+		prefix,
+		star,
+		StyleMeta.Render(e.Selector),
+		name,
+		StyleMeta.Render(studioParamSig(e.Inputs)),
+		suffix,
+	)
+}
 
+func (m StudioModel) historyView() string {
+	var sb strings.Builder
+	for i, h := range m.History {
+		prefix := "    "
+		if i == m.histCursor {
+			prefix = "  ▸ "
+		}
+		outcome := StyleMeta.Render("  →  " + studioTrunc(h.Outcome, 40))
+		if h.Failed {
+			outcome = StyleError.Render("  ✗  " + studioTrunc(h.Outcome, 40))
+		}
+		line := fmt.Sprintf("%s%s  %s%s",
+			prefix,
+			StyleMeta.Render(h.At),
+			StyleValue.Render(studioTrunc(studioCallLabel(h.Sig, h.Args), 48)),
+			outcome)
+		if i == m.histCursor {
+			sb.WriteString(StyleSelected.Render(line) + "\n")
+		} else {
+			sb.WriteString(line + "\n")
+		}
+	}
 	return sb.String()
 }
 
 // RunStudio launches the interactive function navigator with altscreen and
-// returns the selected entry, or nil if the user quit.
-func RunStudio(m StudioModel) (*StudioEntry, error) {
+// returns the final model: Selected and Action say what to do next, and
+// Selected is nil if the user quit (or chose StudioRerun). Pass the model
+// back in to keep favourites, watches and events between runs.
+func RunStudio(m StudioModel) (StudioModel, error) {
+	m.Selected, m.Action, m.Quitting = nil, StudioCall, false
+	m.showHistory, m.searching = false, false
 	m.buildNav()
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	final, err := p.Run()
 	if err != nil {
-		return nil, fmt.Errorf("studio: %w", err)
+		return m, fmt.Errorf("studio: %w", err)
 	}
 	fm := final.(StudioModel)
-	if fm.Quitting || fm.Selected == nil {
-		return nil, nil
+	if fm.Quitting {
+		fm.Selected = nil
 	}
-	return fm.Selected, nil
+	return fm, nil
 }
 
 // studioParamSig formats params as "type name, type name".
@@ -300,3 +541,53 @@ func studioParamSig(params []StudioParam) string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+// studioMatches reports whether an entry matches a search: the query's
+// characters appear in order in the function name (case-insensitive), or
+// it is a prefix of the selector.
+func studioMatches(query string, e StudioEntry) bool {
+	if query == "" {
+		return true
+	}
+	q := strings.ToLower(query)
+	if strings.HasPrefix(e.Selector, q) {
+		return true
+	}
+	name := strings.ToLower(e.Name)
+	for _, r := range q {
+		i := strings.IndexRune(name, r)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(string(r)):]
+	}
+	return true
+}
+
+// studioCallLabel formats a call as "name(arg, arg)".
+func studioCallLabel(sig string, args []string) string {
+	name, _, _ := strings.Cut(sig, "(")
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// studioTrunc shortens s to n runes, marking the cut with "…".
+func studioTrunc(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// withLivePanel puts the live panel beside the main view, or below it on
+// narrow terminals.
+func (m StudioModel) withLivePanel(main string) string {
+	if m.Poll == nil && len(m.Watches) == 0 {
+		return main
+	}
+	panel := m.liveView()
+	if m.width > 0 && m.width < lipgloss.Width(main)+studioPanelWidth+2 {
+		return main + "\n" + panel
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, main, "  ", panel)
+}
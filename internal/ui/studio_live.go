@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// studioPanelWidth is the width of the live panel beside the function list.
+const studioPanelWidth = 48
+
+// StudioWatch is a view function the studio re-calls on every new block.
+type StudioWatch struct {
+	Selector string
+	Label    string   // e.g. "balanceOf(0xAb…)"
+	Args     []string // encoded as entered
+	Value    string   // latest result, "" until the first poll
+	Changed  bool     // the value changed at the last new block
+}
+
+// Key identifies the watch across polls.
+func (w StudioWatch) Key() string {
+	return w.Selector + "(" + strings.Join(w.Args, ",") + ")"
+}
+
+// StudioEvent is one decoded contract event in the live panel.
+type StudioEvent struct {
+	Block  uint64
+	Name   string
+	Args   string // "from=0x12…, value=100"
+	TxHash string
+}
+
+// StudioLive is the result of one poll.
+type StudioLive struct {
+	Block  uint64
+	Events []StudioEvent     // new since the last poll, oldest first
+	Values map[string]string // StudioWatch.Key → latest value
+	Err    error
+}
+
+// StudioPoller fetches the chain head, new events and watched values. It is
+// called from a goroutine, one call at a time.
+type StudioPoller func(watches []StudioWatch) StudioLive
+
+type studioLiveMsg StudioLive
+type studioPollMsg struct{}
+
+func (m StudioModel) pollCmd() tea.Cmd {
+	if m.Poll == nil {
+		return nil
+	}
+	poll := m.Poll
+	watches := append([]StudioWatch(nil), m.Watches...)
+	return func() tea.Msg {
+		return studioLiveMsg(poll(watches))
+	}
+}
+
+func (m StudioModel) pollInterval() time.Duration {
+	if m.PollEvery > 0 {
+		return m.PollEvery
+	}
+	return 3 * time.Second
+}
+
+// applyLive merges a poll into the model.
+func (m *StudioModel) applyLive(live StudioLive) {
+	if live.Err != nil {
+		m.LiveErr = live.Err.Error()
+		return
+	}
+	m.LiveErr = ""
+	if live.Block > m.Block {
+		m.Block = live.Block
+	}
+	m.Events = append(m.Events, live.Events...)
+	if len(m.Events) > maxStudioEvents {
+		m.Events = m.Events[len(m.Events)-maxStudioEvents:]
+	}
+	for i := range m.Watches {
+		w := &m.Watches[i]
+		if v, ok := live.Values[w.Key()]; ok {
+			w.Changed = w.Value != "" && v != w.Value
+			w.Value = v
+		}
+	}
+}
+
+func (m StudioModel) isWatched(selector string) bool {
+	for _, w := range m.Watches {
+		if w.Selector == selector {
+			return true
+		}
+	}
+	return false
+}
+
+// liveView renders the side panel: chain head, watched values, recent events.
+func (m StudioModel) liveView() string {
+	const inner = studioPanelWidth - 6
+	var sb strings.Builder
+
+	head := "Live · connecting…"
+	if m.Poll == nil {
+		head = "Live · off"
+	} else if m.Block > 0 {
+		head = fmt.Sprintf("Live · block %d", m.Block)
+	}
+	sb.WriteString(StyleHeader.Render("── "+head+" ") + "\n")
+	if m.LiveErr != "" {
+		sb.WriteString(StyleError.Render("  "+studioTrunc(trimErr(m.LiveErr), inner)) + "\n")
+	}
+
+	sb.WriteString("\n" + StyleInfo.Render("  Watching") + "\n")
+	if len(m.Watches) == 0 {
+		sb.WriteString(StyleMeta.Render("    press w on a read function") + "\n")
+	}
+	for _, w := range m.Watches {
+		sb.WriteString("    " + StyleValue.Render(studioTrunc(w.Label, inner)) + "\n")
+		val := w.Value
+		if val == "" {
+			val = "…"
+		}
+		val = studioTrunc(val, inner-2)
+		if w.Changed {
+			sb.WriteString("      " + StyleSuccess.Render(val+" ▲") + "\n")
+		} else {
+			sb.WriteString("      " + StyleMeta.Render(val) + "\n")
+		}
+	}
+
+	sb.WriteString("\n" + StyleInfo.Render("  Recent events") + "\n")
+	if len(m.Events) == 0 {
+		sb.WriteString(StyleMeta.Render("    none yet") + "\n")
+	}
+	shown := 0
+	for i := len(m.Events) - 1; i >= 0 && shown < 8; i-- {
+		e := m.Events[i]
+		sb.WriteString(fmt.Sprintf("    %s %s\n",
+			StyleMeta.Render(fmt.Sprintf("#%d", e.Block)),
+			StyleInfo.Render(studioTrunc(e.Name, inner-12))))
+		if e.Args != "" {
+			sb.WriteString("      " + StyleMeta.Render(studioTrunc(e.Args, inner-2)) + "\n")
+		}
+		shown++
+	}
+	return sb.String()
+}
@@ -64,7 +64,7 @@ func (m txListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.flash = "No hash available"
 					break
 				}
-				if err := copyToClipboard(hash); err == nil {
+				if err := CopyToClipboard(hash); err == nil {
 					m.flash = "Copied: " + hash[:10] + "…"
 				} else {
 					m.flash = "Copy failed: " + err.Error()
@@ -150,8 +150,8 @@ func OpenURL(url string) {
 	_ = cmd.Start()
 }
 
-// copyToClipboard writes text to the system clipboard.
-func copyToClipboard(text string) error {
+// CopyToClipboard writes text to the system clipboard.
+func CopyToClipboard(text string) error {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
 	case "darwin":
@@ -88,7 +88,7 @@ func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.flash = "No hash available"
 					break
 				}
-				if err := copyToClipboard(hash); err == nil {
+				if err := CopyToClipboard(hash); err == nil {
 					m.flash = "Copied: " + hash[:10] + "…"
 				} else {
 					m.flash = "Copy failed"
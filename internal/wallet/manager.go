@@ -8,6 +8,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -17,6 +19,14 @@ const (
 	TypeSigning   = "signing"
 )
 
+// Chain types a wallet can belong to. Wallets saved before chain types
+// existed have an empty ChainType and are EVM.
+const (
+	ChainEVM    = "evm"
+	ChainSolana = "solana"
+	ChainSUI    = "sui"
+)
+
 // Errors.
 var (
 	ErrWalletNotFound = errors.New("wallet not found")
@@ -35,6 +45,14 @@ type Wallet struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// Chain returns the wallet's chain type, treating legacy wallets as EVM.
+func (w *Wallet) Chain() string {
+	if w.ChainType == "" {
+		return ChainEVM
+	}
+	return w.ChainType
+}
+
 // UnmarshalJSON handles both snake_case (current) and PascalCase (legacy) field
 // names so existing wallets.json files continue to work after the tag convention
 // was changed from Go-default PascalCase to explicit snake_case.
@@ -148,6 +166,13 @@ func (m *Manager) Add(name string, w *Wallet) error {
 // keychain, and returns both the wallet metadata and the raw hex private key.
 // The caller is responsible for displaying the key to the user exactly once.
 func (m *Manager) Generate(name string) (*Wallet, string, error) {
+	return m.GenerateFor(name, ChainEVM)
+}
+
+// GenerateFor is Generate for any supported chain type. The returned key is
+// in the chain's usual export format (hex for EVM, base58 for Solana,
+// suiprivkey bech32 for SUI).
+func (m *Manager) GenerateFor(name, chainType string) (*Wallet, string, error) {
 	if err := m.load(); err != nil {
 		return nil, "", err
 	}
@@ -155,15 +180,12 @@ func (m *Manager) Generate(name string) (*Wallet, string, error) {
 		return nil, "", ErrWalletExists
 	}
 
-	privKey, err := crypto.GenerateKey()
+	key, addr, err := newKey(chainType)
 	if err != nil {
-		return nil, "", fmt.Errorf("generating key: %w", err)
+		return nil, "", err
 	}
 
-	hexKey := "0x" + hex.EncodeToString(crypto.FromECDSA(privKey))
-	addr := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
-
-	ref, err := m.keystore.Store(name, hexKey)
+	ref, err := m.keystore.Store(name, key)
 	if err != nil {
 		return nil, "", fmt.Errorf("storing key: %w", err)
 	}
@@ -173,14 +195,14 @@ func (m *Manager) Generate(name string) (*Wallet, string, error) {
 		Address:   addr,
 		Type:      TypeSigning,
 		KeyRef:    ref,
-		ChainType: "evm",
+		ChainType: chainType,
 		CreatedAt: time.Now().UTC().Format(time.RFC3339),
 	}
 	m.wallets[name] = w
 	if err := m.persist(); err != nil {
 		return nil, "", err
 	}
-	return w, hexKey, nil
+	return w, key, nil
 }
 
 // ExportKey retrieves the raw hex private key for a signing wallet from the keystore.
@@ -201,6 +223,14 @@ func (m *Manager) ExportKey(name string) (string, error) {
 // AddWithKey derives an EVM address from a hex private key and stores the wallet.
 // The private key is stored in the keystore (encrypted).
 func (m *Manager) AddWithKey(name, hexKey string) error {
+	return m.AddWithKeyFor(name, ChainEVM, hexKey)
+}
+
+// AddWithKeyFor imports a private key for chainType. Solana keys may be
+// base58 (Phantom) or a solana-keygen JSON byte array and are stored as
+// base58; SUI keys may be suiprivkey bech32, sui.keystore base64 or a hex
+// seed and are stored as suiprivkey.
+func (m *Manager) AddWithKeyFor(name, chainType, key string) error {
 	if err := m.load(); err != nil {
 		return err
 	}
@@ -208,15 +238,13 @@ func (m *Manager) AddWithKey(name, hexKey string) error {
 		return ErrWalletExists
 	}
 
-	privKey, err := crypto.HexToECDSA(stripHexPrefix(hexKey))
+	stored, addr, err := parseKey(chainType, key)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		return err
 	}
 
-	addr := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
-
 	// Store the key in the keystore.
-	ref, err := m.keystore.Store(name, hexKey)
+	ref, err := m.keystore.Store(name, stored)
 	if err != nil {
 		return fmt.Errorf("storing key: %w", err)
 	}
@@ -226,13 +254,66 @@ func (m *Manager) AddWithKey(name, hexKey string) error {
 		Address:   addr,
 		Type:      TypeSigning,
 		KeyRef:    ref,
-		ChainType: "evm",
+		ChainType: chainType,
 		CreatedAt: time.Now().UTC().Format(time.RFC3339),
 	}
 	m.wallets[name] = w
 	return m.persist()
 }
 
+// newKey generates a key for chainType and returns it in storage form with
+// its address.
+func newKey(chainType string) (key, addr string, err error) {
+	switch chainType {
+	case ChainEVM:
+		privKey, err := crypto.GenerateKey()
+		if err != nil {
+			return "", "", fmt.Errorf("generating key: %w", err)
+		}
+		return "0x" + hex.EncodeToString(crypto.FromECDSA(privKey)),
+			crypto.PubkeyToAddress(privKey.PublicKey).Hex(), nil
+	case ChainSolana:
+		priv, err := solana.GenerateKey()
+		if err != nil {
+			return "", "", fmt.Errorf("generating key: %w", err)
+		}
+		return solana.EncodePrivateKey(priv), solana.PublicKeyOf(priv).String(), nil
+	case ChainSUI:
+		priv, err := sui.GenerateKey()
+		if err != nil {
+			return "", "", fmt.Errorf("generating key: %w", err)
+		}
+		return sui.EncodePrivateKey(priv), sui.AddressOf(priv), nil
+	}
+	return "", "", fmt.Errorf("unsupported chain type %q", chainType)
+}
+
+// parseKey validates an imported key and returns it in storage form with
+// its address.
+func parseKey(chainType, key string) (stored, addr string, err error) {
+	switch chainType {
+	case ChainEVM:
+		privKey, err := crypto.HexToECDSA(stripHexPrefix(key))
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return key, crypto.PubkeyToAddress(privKey.PublicKey).Hex(), nil
+	case ChainSolana:
+		priv, err := solana.ParsePrivateKey(key)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return solana.EncodePrivateKey(priv), solana.PublicKeyOf(priv).String(), nil
+	case ChainSUI:
+		priv, err := sui.ParsePrivateKey(key)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return sui.EncodePrivateKey(priv), sui.AddressOf(priv), nil
+	}
+	return "", "", fmt.Errorf("unsupported chain type %q", chainType)
+}
+
 // Get returns a wallet by name.
 func (m *Manager) Get(name string) (*Wallet, error) {
 	if err := m.load(); err != nil {
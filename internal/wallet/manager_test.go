@@ -1,9 +1,14 @@
 package wallet_test
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/Mohsinsiddi/w3cli/internal/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -202,3 +207,104 @@ func TestExportKeyWatchOnlyErrors(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "watch-only")
 }
+
+// ---------------------------------------------------------------------------
+// Solana wallets
+// ---------------------------------------------------------------------------
+
+func TestGenerateSolanaWallet(t *testing.T) {
+	mgr := wallet.NewManager(wallet.WithInMemoryStore())
+
+	w, key, err := mgr.GenerateFor("sol", wallet.ChainSolana)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainSolana, w.ChainType)
+	assert.True(t, solana.IsAddress(w.Address))
+
+	// The displayed key is the base58 secret Phantom imports.
+	priv, err := solana.ParsePrivateKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, w.Address, solana.PublicKeyOf(priv).String())
+}
+
+func TestAddSolanaKeyFormats(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	want := solana.PublicKeyOf(priv).String()
+
+	nums := make([]string, len(priv))
+	for i, b := range priv {
+		nums[i] = fmt.Sprint(b)
+	}
+	keygenJSON := "[" + strings.Join(nums, ",") + "]"
+
+	for name, key := range map[string]string{
+		"phantom": solana.EncodePrivateKey(priv),
+		"keygen":  keygenJSON,
+	} {
+		mgr := wallet.NewManager(wallet.WithInMemoryStore())
+		require.NoError(t, mgr.AddWithKeyFor(name, wallet.ChainSolana, key), name)
+
+		w, _ := mgr.Get(name)
+		assert.Equal(t, want, w.Address, name)
+		assert.Equal(t, wallet.ChainSolana, w.ChainType, name)
+
+		// Stored (and exported) as base58 regardless of input format.
+		got, err := mgr.ExportKey(name)
+		require.NoError(t, err)
+		assert.Equal(t, solana.EncodePrivateKey(priv), got, name)
+	}
+}
+
+func TestAddSolanaKeyInvalid(t *testing.T) {
+	mgr := wallet.NewManager(wallet.WithInMemoryStore())
+	err := mgr.AddWithKeyFor("bad", wallet.ChainSolana, "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	assert.ErrorIs(t, err, wallet.ErrInvalidKey)
+
+	err = mgr.AddWithKeyFor("bad", "cosmos", "whatever")
+	assert.ErrorContains(t, err, "unsupported chain type")
+}
+
+func TestWalletChainDefaultsToEVM(t *testing.T) {
+	assert.Equal(t, wallet.ChainEVM, (&wallet.Wallet{}).Chain())
+	assert.Equal(t, wallet.ChainSolana, (&wallet.Wallet{ChainType: "solana"}).Chain())
+}
+
+// ---------------------------------------------------------------------------
+// SUI wallets
+// ---------------------------------------------------------------------------
+
+func TestGenerateSUIWallet(t *testing.T) {
+	mgr := wallet.NewManager(wallet.WithInMemoryStore())
+
+	w, key, err := mgr.GenerateFor("s", wallet.ChainSUI)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.ChainSUI, w.ChainType)
+	assert.True(t, sui.IsAddress(w.Address))
+	assert.True(t, strings.HasPrefix(key, "suiprivkey1"))
+
+	priv, err := sui.ParsePrivateKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, w.Address, sui.AddressOf(priv))
+}
+
+func TestAddSUIKeyFormats(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	want := sui.AddressOf(priv)
+	keystoreEntry := base64.StdEncoding.EncodeToString(append([]byte{sui.FlagEd25519}, priv.Seed()...))
+
+	for name, key := range map[string]string{
+		"bech32":   sui.EncodePrivateKey(priv),
+		"keystore": keystoreEntry,
+	} {
+		mgr := wallet.NewManager(wallet.WithInMemoryStore())
+		require.NoError(t, mgr.AddWithKeyFor(name, wallet.ChainSUI, key), name)
+		w, _ := mgr.Get(name)
+		assert.Equal(t, want, w.Address, name)
+
+		got, err := mgr.ExportKey(name)
+		require.NoError(t, err)
+		assert.Equal(t, sui.EncodePrivateKey(priv), got, "stored as suiprivkey")
+	}
+
+	mgr := wallet.NewManager(wallet.WithInMemoryStore())
+	assert.ErrorIs(t, mgr.AddWithKeyFor("bad", wallet.ChainSUI, "nope"), wallet.ErrInvalidKey)
+}
@@ -64,3 +64,32 @@ func eip191Hash(message []byte) []byte {
 	data := append([]byte(prefix), message...)
 	return crypto.Keccak256(data)
 }
+
+// SignHash signs a 32-byte digest directly, without the EIP-191 prefix —
+// what EIP-712 structured data and Safe transaction hashes need.
+// Returns a 65-byte signature (R || S || V) with V = 27/28.
+func SignHash(w *Wallet, ks KeystoreBackend, hash []byte) ([]byte, error) {
+	if w.Type != TypeSigning {
+		return nil, fmt.Errorf("wallet %q is watch-only and cannot sign", w.Name)
+	}
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash must be 32 bytes, got %d", len(hash))
+	}
+
+	hexKey, err := ks.Retrieve(w.KeyRef)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving key: %w", err)
+	}
+
+	privKey, err := crypto.HexToECDSA(stripHexPrefix(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	sig, err := crypto.Sign(hash, privKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing hash: %w", err)
+	}
+	sig[64] += 27
+	return sig, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -162,3 +163,31 @@ func TestEIP191HashDifferentMessages(t *testing.T) {
 	h2 := eip191Hash([]byte("message B"))
 	assert.NotEqual(t, hex.EncodeToString(h1), hex.EncodeToString(h2))
 }
+
+// ---------------------------------------------------------------------------
+// SignHash
+// ---------------------------------------------------------------------------
+
+func TestSignHashRecoversWithoutPrefix(t *testing.T) {
+	iks := NewInMemoryKeystore()
+	ref, err := iks.Store("hash", testPrivKeyHex)
+	require.NoError(t, err)
+	w := &Wallet{Name: "hash", Address: testSignerAddr, Type: TypeSigning, KeyRef: ref}
+
+	hash := crypto.Keccak256([]byte("safe tx"))
+	sig, err := SignHash(w, iks, hash)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	raw := append([]byte{}, sig...)
+	raw[64] -= 27
+	pub, err := crypto.SigToPub(hash, raw)
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddr, crypto.PubkeyToAddress(*pub).Hex())
+
+	_, err = SignHash(w, iks, hash[:31])
+	assert.Error(t, err)
+	_, err = SignHash(&Wallet{Name: "watch", Type: TypeWatchOnly}, iks, hash)
+	assert.Error(t, err)
+}
@@ -1,9 +1,13 @@
 package wallet
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -24,6 +28,9 @@ func (s *Signer) SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error)
 	if s.wallet.Type != TypeSigning {
 		return nil, fmt.Errorf("wallet %q is watch-only and cannot sign", s.wallet.Name)
 	}
+	if s.wallet.Chain() != ChainEVM {
+		return nil, fmt.Errorf("wallet %q is a %s wallet and cannot sign EVM transactions", s.wallet.Name, s.wallet.Chain())
+	}
 
 	hexKey, err := s.ks.Retrieve(s.wallet.KeyRef)
 	if err != nil {
@@ -49,6 +56,54 @@ func (s *Signer) SignTx(tx *types.Transaction, chainID *big.Int) ([]byte, error)
 	return raw, nil
 }
 
+// SignSolana signs a serialized Solana transaction message with the wallet's
+// ed25519 key and returns the 64-byte signature.
+func (s *Signer) SignSolana(message []byte) ([]byte, error) {
+	if s.wallet.Type != TypeSigning {
+		return nil, fmt.Errorf("wallet %q is watch-only and cannot sign", s.wallet.Name)
+	}
+	if s.wallet.Chain() != ChainSolana {
+		return nil, fmt.Errorf("wallet %q is not a Solana wallet", s.wallet.Name)
+	}
+
+	key, err := s.ks.Retrieve(s.wallet.KeyRef)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving key: %w", err)
+	}
+	priv, err := solana.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	if solana.PublicKeyOf(priv).String() != s.wallet.Address {
+		return nil, fmt.Errorf("stored key does not match wallet address %s", s.wallet.Address)
+	}
+	return ed25519.Sign(priv, message), nil
+}
+
+// SignSUI signs BCS transaction bytes with the wallet's ed25519 key and
+// returns the serialized signature sui_executeTransactionBlock expects.
+func (s *Signer) SignSUI(txBytes []byte) (string, error) {
+	if s.wallet.Type != TypeSigning {
+		return "", fmt.Errorf("wallet %q is watch-only and cannot sign", s.wallet.Name)
+	}
+	if s.wallet.Chain() != ChainSUI {
+		return "", fmt.Errorf("wallet %q is not a SUI wallet", s.wallet.Name)
+	}
+
+	key, err := s.ks.Retrieve(s.wallet.KeyRef)
+	if err != nil {
+		return "", fmt.Errorf("retrieving key: %w", err)
+	}
+	priv, err := sui.ParsePrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("parsing private key: %w", err)
+	}
+	if !strings.EqualFold(sui.AddressOf(priv), s.wallet.Address) {
+		return "", fmt.Errorf("stored key does not match wallet address %s", s.wallet.Address)
+	}
+	return sui.SignTransaction(priv, txBytes), nil
+}
+
 // Address returns the wallet's address.
 func (s *Signer) Address() string {
 	return s.wallet.Address
@@ -1,10 +1,13 @@
 package wallet
 
 import (
+	"crypto/ed25519"
 	"math/big"
 	"testing"
 
 	"github.com/99designs/keyring"
+	"github.com/Mohsinsiddi/w3cli/internal/solana"
+	"github.com/Mohsinsiddi/w3cli/internal/sui"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -186,3 +189,59 @@ func TestInMemoryKeystoreMultipleKeys(t *testing.T) {
 		assert.Equal(t, vals[name], got)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Signer.SignSolana
+// ---------------------------------------------------------------------------
+
+func TestSignSolana(t *testing.T) {
+	ks := testKeystore(t)
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	ref, err := ks.Store("sol", solana.EncodePrivateKey(priv))
+	require.NoError(t, err)
+
+	w := &Wallet{Name: "sol", Address: solana.PublicKeyOf(priv).String(), Type: TypeSigning, KeyRef: ref, ChainType: ChainSolana}
+	msg := []byte("solana message")
+	sig, err := NewSigner(w, ks).SignSolana(msg)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), msg, sig))
+
+	// A Solana wallet can't sign EVM transactions and vice versa.
+	tx := types.NewTransaction(0, [20]byte{}, big.NewInt(0), 21000, big.NewInt(1e9), nil)
+	_, err = NewSigner(w, ks).SignTx(tx, big.NewInt(1))
+	assert.ErrorContains(t, err, "solana wallet")
+
+	evm := &Wallet{Name: "evm", Address: testSignerAddr, Type: TypeSigning, KeyRef: ref}
+	_, err = NewSigner(evm, ks).SignSolana(msg)
+	assert.ErrorContains(t, err, "not a Solana wallet")
+}
+
+func TestSignSolanaRejectsMismatchedKey(t *testing.T) {
+	ks := testKeystore(t)
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	ref, _ := ks.Store("sol", solana.EncodePrivateKey(priv))
+
+	w := &Wallet{Name: "sol", Address: solana.SystemProgramID.String(), Type: TypeSigning, KeyRef: ref, ChainType: ChainSolana}
+	_, err := NewSigner(w, ks).SignSolana([]byte("x"))
+	assert.ErrorContains(t, err, "does not match")
+}
+
+// ---------------------------------------------------------------------------
+// Signer.SignSUI
+// ---------------------------------------------------------------------------
+
+func TestSignSUI(t *testing.T) {
+	ks := testKeystore(t)
+	priv := ed25519.NewKeyFromSeed(make([]byte, 32))
+	ref, err := ks.Store("s", sui.EncodePrivateKey(priv))
+	require.NoError(t, err)
+
+	w := &Wallet{Name: "s", Address: sui.AddressOf(priv), Type: TypeSigning, KeyRef: ref, ChainType: ChainSUI}
+	sig, err := NewSigner(w, ks).SignSUI([]byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, sui.SignTransaction(priv, []byte{1, 2, 3}), sig)
+
+	w.ChainType = ChainSolana
+	_, err = NewSigner(w, ks).SignSUI([]byte{1})
+	assert.ErrorContains(t, err, "not a SUI wallet")
+}